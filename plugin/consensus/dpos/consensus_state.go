@@ -12,6 +12,8 @@ import (
 	"fmt"
 	"reflect"
 	"runtime/debug"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -102,6 +104,11 @@ type ConsensusState struct {
 	vrfInfosMap      map[int64][]*dty.VrfInfo
 
 	cachedTopNCands []*dty.TopNCandidators
+
+	//每个cycle中各节点的出块统计
+	cycleSlots     map[int64]map[string]*dty.DposSlotStat
+	lastSlotPeriod int64
+	lastSlotsCycle int64
 }
 
 // NewConsensusState returns a new ConsensusState.
@@ -117,6 +124,7 @@ func NewConsensusState(client *Client, valMgr ValidatorMgr) *ConsensusState {
 		cycleBoundaryMap: make(map[int64]*dty.DposCBInfo),
 		vrfInfoMap:       make(map[int64]*dty.VrfInfo),
 		vrfInfosMap:      make(map[int64][]*dty.VrfInfo),
+		cycleSlots:       make(map[int64]map[string]*dty.DposSlotStat),
 	}
 
 	cs.updateToValMgr(valMgr)
@@ -747,12 +755,7 @@ func (cs *ConsensusState) VerifyCBInfo(info *dty.DposCBInfo) bool {
 
 	buf := new(bytes.Buffer)
 
-	canonical := dty.CanonicalOnceCBInfo{
-		Cycle:      info.Cycle,
-		StopHeight: info.StopHeight,
-		StopHash:   info.StopHash,
-		Pubkey:     info.Pubkey,
-	}
+	canonical := dty.CanonicalCBInfo(info)
 
 	byteCB, err := json.Marshal(&canonical)
 	if err != nil {
@@ -777,12 +780,7 @@ func (cs *ConsensusState) VerifyCBInfo(info *dty.DposCBInfo) bool {
 // SendCBTx method
 func (cs *ConsensusState) SendCBTx(info *dty.DposCBInfo) bool {
 	//info.Pubkey = strings.ToUpper(hex.EncodeToString(cs.privValidator.GetPubKey().Bytes()))
-	canonical := dty.CanonicalOnceCBInfo{
-		Cycle:      info.Cycle,
-		StopHeight: info.StopHeight,
-		StopHash:   info.StopHash,
-		Pubkey:     info.Pubkey,
-	}
+	canonical := dty.CanonicalCBInfo(info)
 
 	byteCB, err := json.Marshal(&canonical)
 	if err != nil {
@@ -1025,6 +1023,18 @@ func (cs *ConsensusState) GetVrfInfosByCircle(cycle int64) (infos []*dty.VrfInfo
 
 // ShuffleValidators method
 func (cs *ConsensusState) ShuffleValidators(cycle int64) {
+	if shuffleType != dposShuffleTypeFixOrderByAddr && cycle == cs.validatorMgr.ShuffleCycle {
+		//如果已经洗过牌，则直接返回，不重复洗牌
+		dposlog.Info("Shuffle for this cycle is done already.", "cycle", cycle)
+		return
+	}
+
+	cs.shuffleValidators(cycle)
+	//被禁止出块的节点不参与本cycle的出块，其出块时段由其他节点轮流补上
+	cs.excludeJailedValidators(cycle)
+}
+
+func (cs *ConsensusState) shuffleValidators(cycle int64) {
 	if shuffleType == dposShuffleTypeFixOrderByAddr {
 		dposlog.Info("ShuffleType FixOrderByAddr,so do nothing", "cycle", cycle)
 
@@ -1114,6 +1124,137 @@ func (cs *ConsensusState) ShuffleValidators(cycle int64) {
 	dposlog.Info("Vrf validators is part,use part vrf to shuffle.", "cycle", cycle, "vrf validators size", cs.validatorMgr.VrfValidators.Size(), "non vrf validators size", cs.validatorMgr.NoVrfValidators.Size())
 }
 
+func (cs *ConsensusState) excludeJailedValidators(cycle int64) {
+	jailed, err := cs.client.QueryJailedCandidators(cycle, nil)
+	if err != nil {
+		dposlog.Error("QueryJailedCandidators failed", "cycle", cycle, "err", err)
+		return
+	}
+
+	if cs.validatorMgr.ExcludeJailed(jailed) {
+		dposlog.Info("Exclude jailed validators from shuffle", "cycle", cycle, "jailed", len(jailed))
+	}
+}
+
+// RecordSlots method
+func (cs *ConsensusState) RecordSlots(vote *dpostype.VoteItem, online bool) {
+	if vote == nil || vote.PeriodStart <= cs.lastSlotPeriod {
+		return
+	}
+
+	_, val := cs.validatorMgr.Validators.GetByAddress(vote.VotedNodeAddress)
+	if val == nil {
+		dposlog.Info("RecordSlots voted node is not a validator", "addr", hex.EncodeToString(vote.VotedNodeAddress))
+		return
+	}
+	cs.lastSlotPeriod = vote.PeriodStart
+
+	produced := cs.countBlocks(vote.PeriodStart, vote.PeriodStop)
+	missed := int64(0)
+	//不出空块时，没有交易的时段不出块是正常的，只有收不到通知时才认为节点没有出块
+	if !online || createEmptyBlocks {
+		missed = dposContinueBlockNum - produced
+	}
+	if missed < 0 {
+		missed = 0
+	}
+
+	slots, ok := cs.cycleSlots[vote.Cycle]
+	if !ok {
+		slots = make(map[string]*dty.DposSlotStat)
+		cs.cycleSlots[vote.Cycle] = slots
+	}
+
+	pubkey := strings.ToUpper(hex.EncodeToString(val.PubKey))
+	stat, ok := slots[pubkey]
+	if !ok {
+		stat = &dty.DposSlotStat{Pubkey: pubkey}
+		slots[pubkey] = stat
+	}
+	stat.Produced += produced
+	stat.Missed += missed
+	dposlog.Info("RecordSlots", "cycle", vote.Cycle, "pubkey", pubkey, "produced", produced, "missed", missed, "online", online)
+}
+
+// countBlocks 统计本地区块中出块时间在[start, stop]之间的区块数
+func (cs *ConsensusState) countBlocks(start, stop int64) int64 {
+	block := cs.client.GetCurrentBlock()
+	count := int64(0)
+	for i := int64(0); block != nil && block.BlockTime >= start && i <= dposContinueBlockNum; i++ {
+		if block.BlockTime <= stop {
+			count++
+		}
+		if block.Height == 0 {
+			break
+		}
+
+		height := block.Height - 1
+		var err error
+		block, err = cs.client.RequestBlock(height)
+		if err != nil {
+			dposlog.Error("countBlocks RequestBlock failed", "height", height, "err", err)
+			break
+		}
+	}
+
+	return count
+}
+
+// SendSlotsTx 分叉之后执行器要求超过2/3的受托节点报告后才禁止节点出块，
+// 不在cycle结尾出块的受托节点也在cycle结束时上报本cycle的出块统计
+func (cs *ConsensusState) SendSlotsTx(vote *dpostype.VoteItem) {
+	if vote == nil || vote.PeriodStop != vote.CycleStop || vote.Cycle <= cs.lastSlotsCycle || missedSlotsThreshold <= 0 {
+		return
+	}
+
+	cfg := cs.client.GetAPI().GetConfig()
+	block := cs.client.GetCurrentBlock()
+	if !cfg.IsDappFork(block.Height, dty.DPosX, dty.ForkDposJail) {
+		return
+	}
+	cs.lastSlotsCycle = vote.Cycle
+
+	slots := cs.GetSlotsByCycle(vote.Cycle)
+	missed := false
+	for _, stat := range slots {
+		if stat.Missed > missedSlotsThreshold {
+			missed = true
+			break
+		}
+	}
+	if !missed {
+		return
+	}
+
+	info := &dty.DposCBInfo{
+		Cycle:      vote.Cycle,
+		StopHeight: block.Height,
+		StopHash:   hex.EncodeToString(block.Hash(cfg)),
+		Pubkey:     strings.ToUpper(hex.EncodeToString(cs.privValidator.GetPubKey().Bytes())),
+		Slots:      slots,
+	}
+	dposlog.Info("Send slots of cycle", "cycle", info.Cycle, "stopHeight", info.StopHeight, "slots", len(slots))
+	cs.SendCBTx(info)
+}
+
+// GetSlotsByCycle method
+func (cs *ConsensusState) GetSlotsByCycle(cycle int64) (stats []*dty.DposSlotStat) {
+	for k := range cs.cycleSlots {
+		if k < cycle {
+			delete(cs.cycleSlots, k)
+		}
+	}
+
+	for _, stat := range cs.cycleSlots[cycle] {
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Pubkey < stats[j].Pubkey
+	})
+
+	return stats
+}
+
 func isValidVrfInfo(info *dty.VrfInfo) bool {
 	if info != nil && len(info.M) > 0 && len(info.R) > 0 && len(info.P) > 0 {
 		return true
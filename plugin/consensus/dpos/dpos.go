@@ -22,6 +22,8 @@ import (
 	cty "github.com/33cn/chain33/system/dapp/coins/types"
 	"github.com/33cn/chain33/types"
 	ttypes "github.com/33cn/plugin/plugin/consensus/dpos/types"
+	"github.com/33cn/plugin/plugin/consensus/peerfilter"
+	"github.com/33cn/plugin/plugin/consensus/remotesigner"
 
 	dty "github.com/33cn/plugin/plugin/dapp/dposvote/types"
 	"github.com/golang/protobuf/proto"
@@ -55,6 +57,7 @@ var (
 	blockNumToUpdateDelegate int64 = 20000
 	registTopNHeightLimit    int64 = 100
 	updateTopNHeightLimit    int64 = 200
+	missedSlotsThreshold     int64 //与dposvote执行器的配置一致，超过该值时各受托节点上报出块统计
 )
 
 func init() {
@@ -69,6 +72,7 @@ type Client struct {
 	genesisDoc    *ttypes.GenesisDoc // initial validator set
 	privValidator ttypes.PrivValidator
 	privKey       crypto.PrivKey // local node's p2p key
+	peerFilter    *peerfilter.PeerFilter
 	pubKey        string
 	csState       *ConsensusState
 	crypto        crypto.Crypto
@@ -98,6 +102,16 @@ type subConfig struct {
 	BlockNumToUpdateDelegate  int64    `json:"blockNumToUpdateDelegate"`
 	RegistTopNHeightLimit     int64    `json:"registTopNHeightLimit"`
 	UpdateTopNHeightLimit     int64    `json:"updateTopNHeightLimit"`
+	MissedSlotsThreshold      int64    `json:"missedSlotsThreshold"`
+	SignerAddr                string   `json:"signerAddr"`
+	SignerPubKey              string   `json:"signerPubKey"`
+	SignerNodeKey             string   `json:"signerNodeKey"`
+	NodeKey                   string   `json:"nodeKey"`
+	AuthPeers                 bool     `json:"authPeers"`
+	AllowPeers                []string `json:"allowPeers"`
+	AllowIPs                  []string `json:"allowIPs"`
+	DenyIPs                   []string `json:"denyIPs"`
+	MaxPeersPerIP             int      `json:"maxPeersPerIP"`
 }
 
 func (client *Client) applyConfig(sub []byte) {
@@ -175,6 +189,10 @@ func (client *Client) applyConfig(sub []byte) {
 	if subcfg.UpdateTopNHeightLimit > 0 {
 		updateTopNHeightLimit = subcfg.UpdateTopNHeightLimit
 	}
+
+	if subcfg.MissedSlotsThreshold > 0 {
+		missedSlotsThreshold = subcfg.MissedSlotsThreshold
+	}
 }
 
 // New ...
@@ -206,16 +224,22 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 	}
 	ttypes.SecureConnCrypto = cr2
 
-	priv, err := cr2.GenKey()
+	priv, err := loadNodeKey(sub)
 	if err != nil {
 		dposlog.Error("NewDPosClient", "GenKey err", err)
 		return nil
 	}
 
-	privValidator := ttypes.LoadOrGenPrivValidatorFS("./priv_validator.json")
-	if privValidator == nil {
-		dposlog.Error("NewDPosClient create priv_validator file failed")
-		//return nil
+	peerFilter, err := loadPeerFilter(sub)
+	if err != nil {
+		dposlog.Error("NewDPosClient create peer filter failed", "err", err)
+		return nil
+	}
+
+	privValidator, err := loadPrivValidator(sub)
+	if err != nil {
+		dposlog.Error("NewDPosClient create priv_validator failed", "err", err)
+		return nil
 	}
 
 	ttypes.InitMessageMap()
@@ -227,6 +251,7 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 		genesisDoc:    genDoc,
 		privValidator: privValidator,
 		privKey:       priv,
+		peerFilter:    peerFilter,
 		pubKey:        pubkey,
 		crypto:        cr,
 		stopC:         make(chan struct{}, 1),
@@ -239,6 +264,56 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 	return client
 }
 
+// loadPrivValidator 配置了signerAddr时使用远程签名服务，否则从priv_validator.json加载私钥
+func loadPrivValidator(sub []byte) (ttypes.PrivValidator, error) {
+	var subcfg subConfig
+	if sub != nil {
+		types.MustDecode(sub, &subcfg)
+	}
+	if subcfg.SignerAddr == "" {
+		return ttypes.LoadOrGenPrivValidatorFS("./priv_validator.json"), nil
+	}
+	pub, err := hex.DecodeString(subcfg.SignerPubKey)
+	if err != nil {
+		return nil, err
+	}
+	signerPubKey, err := ttypes.SecureConnCrypto.PubKeyFromBytes(pub)
+	if err != nil {
+		return nil, err
+	}
+	nodeKeyFile := subcfg.SignerNodeKey
+	if nodeKeyFile == "" {
+		nodeKeyFile = "./signer_node_key.json"
+	}
+	nodeKey, err := remotesigner.LoadOrGenNodeKey(ttypes.SecureConnCrypto, nodeKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	dposlog.Info("Use remote signer", "addr", subcfg.SignerAddr, "nodePubKey", nodeKey.PubKey().KeyString())
+	return NewRemoteSigner(subcfg.SignerAddr, signerPubKey, nodeKey)
+}
+
+// loadNodeKey 配置了nodeKey时从文件加载节点的p2p私钥，节点公钥配置在其他节点的allowPeers中时需要保持不变
+func loadNodeKey(sub []byte) (crypto.PrivKey, error) {
+	var subcfg subConfig
+	if sub != nil {
+		types.MustDecode(sub, &subcfg)
+	}
+	if subcfg.NodeKey == "" {
+		return ttypes.SecureConnCrypto.GenKey()
+	}
+	return remotesigner.LoadOrGenNodeKey(ttypes.SecureConnCrypto, subcfg.NodeKey)
+}
+
+// loadPeerFilter 创建共识节点连接的过滤器
+func loadPeerFilter(sub []byte) (*peerfilter.PeerFilter, error) {
+	var subcfg subConfig
+	if sub != nil {
+		types.MustDecode(sub, &subcfg)
+	}
+	return peerfilter.New("dpos", subcfg.AuthPeers, subcfg.AllowPeers, subcfg.AllowIPs, subcfg.DenyIPs, subcfg.MaxPeersPerIP)
+}
+
 // PrivValidator returns the Node's PrivValidator.
 func (client *Client) PrivValidator() ttypes.PrivValidator {
 	return client.privValidator
@@ -371,6 +446,15 @@ OuterLoop:
 	protocol, listeningAddress := "tcp", "0.0.0.0:"+dposPort
 	node := NewNode(validatorNodes, protocol, listeningAddress, client.privKey, valMgr.ChainID, dposVersion, csState)
 
+	node.SetPeerFilter(client.peerFilter)
+	//验证者对节点公钥签名，握手时证明节点属于该验证者
+	sig, err := client.privValidator.SignMsg(peerfilter.NodeKeySignBytes("dpos", valMgr.ChainID, client.privKey.PubKey().Bytes()))
+	if err != nil {
+		dposlog.Error("StartConsensus sign node key failed", "err", err)
+	} else {
+		node.SetNodeKeySig(client.privValidator.GetPubKey().Bytes(), sig.Bytes())
+	}
+
 	client.node = node
 
 	// 对于受托节点，才需要初始化区块，启动共识相关程序等,后续支持投票要做成动态切换的。
@@ -564,6 +648,56 @@ func (client *Client) QueryCandidators() ([]*dty.Candidator, error) {
 	return cands, nil
 }
 
+// QueryJailedCandidators query the pubkeys of the candidators which are jailed at the cycle
+func (client *Client) QueryJailedCandidators(cycle int64, pubkeys [][]byte) ([][]byte, error) {
+	req := &dty.DposJailQuery{
+		Cycle: cycle,
+	}
+	for _, pubkey := range pubkeys {
+		req.Pubkeys = append(req.Pubkeys, strings.ToUpper(hex.EncodeToString(pubkey)))
+	}
+	param, err := proto.Marshal(req)
+	if err != nil {
+		dposlog.Error("Marshal DposJailQuery failed", "err", err)
+		return nil, err
+	}
+	msg := client.GetQueueClient().NewMessage("execs", types.EventBlockChainQuery,
+		&types.ChainExecutor{
+			Driver:    dty.DPosX,
+			FuncName:  dty.FuncNameQueryJailedCandidators,
+			StateHash: zeroHash[:],
+			Param:     param,
+		})
+
+	err = client.GetQueueClient().Send(msg, true)
+	if err != nil {
+		dposlog.Error("send DposJailQuery to dpos exec failed", "err", err)
+		return nil, err
+	}
+
+	msg, err = client.GetQueueClient().Wait(msg)
+	if err != nil {
+		dposlog.Error("send DposJailQuery wait failed", "err", err)
+		return nil, err
+	}
+
+	if err, ok := msg.GetData().(error); ok {
+		return nil, err
+	}
+	res := msg.GetData().(types.Message).(*dty.CandidatorReply)
+
+	var jailed [][]byte
+	for _, val := range res.GetCandidators() {
+		bPubkey, err := hex.DecodeString(val.Pubkey)
+		if err != nil {
+			return nil, err
+		}
+
+		jailed = append(jailed, bPubkey)
+	}
+	return jailed, nil
+}
+
 func (client *Client) isValidatorSetSame(v1, v2 *ttypes.ValidatorSet) bool {
 	if v1 == nil || v2 == nil || len(v1.Validators) != len(v2.Validators) {
 		return false
@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"sync"
 	"testing"
@@ -17,6 +18,7 @@ import (
 	"github.com/33cn/chain33/rpc"
 	"github.com/33cn/chain33/types"
 	ttypes "github.com/33cn/plugin/plugin/consensus/dpos/types"
+	"github.com/33cn/plugin/plugin/consensus/peerfilter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -599,3 +601,25 @@ func initEnvDpos1(configName string) (queue.Queue, *blockchain.BlockChain, queue
 
 	return q, chain, s, mem, exec, cs, network
 }
+
+func TestPeerFilter(t *testing.T) {
+	cr, err := crypto.New(types.GetSignName("", types.SECP256K1))
+	assert.Nil(t, err)
+	ttypes.ConsensusCrypto = cr
+	filter, err := peerfilter.New("dpos", true, nil, nil, nil, 0)
+	assert.Nil(t, err)
+
+	valKey, err := cr.GenKey()
+	assert.Nil(t, err)
+	nodeKey, err := secureConnCrypto.GenKey()
+	assert.Nil(t, err)
+	sig := valKey.Sign(peerfilter.NodeKeySignBytes("dpos", "chain", nodeKey.PubKey().Bytes()))
+	validators := []*peerfilter.Validator{{PubKey: valKey.PubKey().Bytes(), VotingPower: 1}}
+	valPubKey := hex.EncodeToString(valKey.PubKey().Bytes())
+	assert.Nil(t, filter.Authorize(cr, "chain", nodeKey.PubKey(), valPubKey, hex.EncodeToString(sig.Bytes()), validators))
+	assert.NotNil(t, filter.Authorize(cr, "chain", securePriv.PubKey(), valPubKey, hex.EncodeToString(sig.Bytes()), validators))
+
+	testSet := NewPeerSet()
+	testSet.Add(&peerConn{id: "1", ip: net.IP{127, 0, 0, 1}})
+	assert.Equal(t, 1, testSet.CountIP(net.IP{127, 0, 0, 1}))
+}
@@ -68,6 +68,7 @@ type peerConn struct {
 	persistent bool
 	ip         net.IP
 	id         ID
+	nodeInfo   *NodeInfo
 
 	sendQueue     chan MsgInfo
 	sendQueueSize int32
@@ -156,6 +157,20 @@ func (ps *PeerSet) hasIP(peerIP net.IP) bool {
 	return false
 }
 
+// CountIP returns the number of peers with the IP address
+func (ps *PeerSet) CountIP(peerIP net.IP) int {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	count := 0
+	for _, item := range ps.lookup {
+		if ip, err := item.peer.RemoteIP(); err == nil && ip.Equal(peerIP) {
+			count++
+		}
+	}
+	return count
+}
+
 // Size of list
 func (ps *PeerSet) Size() int {
 	ps.mtx.Lock()
@@ -0,0 +1,295 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dpos
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/dpos/types"
+	"github.com/33cn/plugin/plugin/consensus/remotesigner"
+)
+
+// remote signer request types
+const (
+	SignerReqVote   = "vote"
+	SignerReqNotify = "notify"
+	SignerReqMsg    = "msg"
+	SignerReqTx     = "tx"
+	SignerReqVrf    = "vrf"
+)
+
+// Errors define
+var (
+	ErrSignerConflict   = errors.New("Error remote signer refuse to sign conflicting data")
+	ErrSignerRegression = errors.New("Error remote signer refuse to sign older period")
+)
+
+// signerHandshake makes the SecretConnection with the remote signer or the consensus node
+func signerHandshake(conn net.Conn, key crypto.PrivKey) (remotesigner.SecretConn, error) {
+	sc, err := MakeSecretConnection(conn, key)
+	if err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// RemoteSigner implements PrivValidator by signing through a remote signer
+type RemoteSigner struct {
+	client *remotesigner.Client
+
+	address []byte
+	pubKey  crypto.PubKey
+}
+
+// NewRemoteSigner connects to the signer at addr and fetches the validator pubkey
+func NewRemoteSigner(addr string, signerPubKey crypto.PubKey, nodeKey crypto.PrivKey) (*RemoteSigner, error) {
+	rs := &RemoteSigner{client: remotesigner.NewClient(addr, signerPubKey, nodeKey, signerHandshake)}
+	data, err := rs.call(&remotesigner.Request{Type: remotesigner.ReqPubKey})
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := ttypes.ConsensusCrypto.PubKeyFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	rs.pubKey = pubKey
+	rs.address = address.PubKeyToAddress(pubKey.Bytes()).Hash160[:]
+	return rs, nil
+}
+
+func (rs *RemoteSigner) call(req *remotesigner.Request) ([]byte, error) {
+	return rs.client.Call(req)
+}
+
+// GetAddress returns the address of the validator
+func (rs *RemoteSigner) GetAddress() []byte {
+	return rs.address
+}
+
+// GetPubKey returns the public key of the validator
+func (rs *RemoteSigner) GetPubKey() crypto.PubKey {
+	return rs.pubKey
+}
+
+// SignVote signs the vote by the remote signer
+func (rs *RemoteSigner) SignVote(chainID string, vote *ttypes.Vote) error {
+	data, err := rs.call(&remotesigner.Request{Type: SignerReqVote, ChainID: chainID, Data: types.Encode(vote.DPosVote)})
+	if err != nil {
+		return fmt.Errorf("Error signing vote: %v", err)
+	}
+	vote.Signature = data
+	return nil
+}
+
+// SignNotify signs the notify by the remote signer
+func (rs *RemoteSigner) SignNotify(chainID string, notify *ttypes.Notify) error {
+	data, err := rs.call(&remotesigner.Request{Type: SignerReqNotify, ChainID: chainID, Data: types.Encode(notify.DPosNotify)})
+	if err != nil {
+		return fmt.Errorf("Error signing notify: %v", err)
+	}
+	notify.Signature = data
+	return nil
+}
+
+// SignMsg signs the msg by the remote signer
+func (rs *RemoteSigner) SignMsg(msg []byte) (crypto.Signature, error) {
+	data, err := rs.call(&remotesigner.Request{Type: SignerReqMsg, Data: msg})
+	if err != nil {
+		return nil, err
+	}
+	return ttypes.ConsensusCrypto.SignatureFromBytes(data)
+}
+
+// SignTx signs the tx by the remote signer
+func (rs *RemoteSigner) SignTx(tx *types.Transaction) {
+	data, err := rs.call(&remotesigner.Request{Type: SignerReqTx, Data: types.Encode(tx)})
+	if err != nil {
+		dposlog.Error("RemoteSigner SignTx failed", "err", err)
+		return
+	}
+	signed := &types.Transaction{}
+	if err := types.Decode(data, signed); err != nil {
+		dposlog.Error("RemoteSigner SignTx decode failed", "err", err)
+		return
+	}
+	tx.Signature = signed.Signature
+}
+
+// VrfEvaluate evaluates the vrf by the remote signer
+func (rs *RemoteSigner) VrfEvaluate(input []byte) (hash [32]byte, proof []byte) {
+	data, err := rs.call(&remotesigner.Request{Type: SignerReqVrf, Data: input})
+	if err != nil || len(data) < len(hash) {
+		dposlog.Error("RemoteSigner VrfEvaluate failed", "err", err)
+		return hash, nil
+	}
+	copy(hash[:], data)
+	return hash, data[len(hash):]
+}
+
+// VrfProof check the vrf, no key is needed so it's done locally
+func (rs *RemoteSigner) VrfProof(pubkey []byte, input []byte, hash [32]byte, proof []byte) bool {
+	return ttypes.VrfVerify(pubkey, input, hash, proof)
+}
+
+// Close closes the connection to the signer
+func (rs *RemoteSigner) Close() {
+	rs.client.Close()
+}
+
+// signerState is the last signed vote and notify, persisted by the signer to prevent double signing
+type signerState struct {
+	VotePeriod   int64  `json:"vote_period"`
+	VoteID       []byte `json:"vote_id"`
+	VotedNode    []byte `json:"voted_node"`
+	NotifyPeriod int64  `json:"notify_period"`
+	HeightStop   int64  `json:"height_stop"`
+	HashStop     []byte `json:"hash_stop"`
+}
+
+// SignerServer serves the sign requests of the authorized consensus nodes.
+// In one period it signs the votes for the same node and the notifies of the same stop block only.
+type SignerServer struct {
+	*remotesigner.Server
+	privValidator ttypes.PrivValidator
+
+	mtx       sync.Mutex
+	state     signerState
+	stateFile string
+}
+
+// NewSignerServer returns a SignerServer only accepting the nodes with the authorized pubkeys,
+// the last signed vote and notify are loaded from and saved to stateFile.
+func NewSignerServer(privValidator ttypes.PrivValidator, nodeKey crypto.PrivKey, authorized []crypto.PubKey, stateFile string) (*SignerServer, error) {
+	ss := &SignerServer{
+		privValidator: privValidator,
+		stateFile:     stateFile,
+	}
+	ss.Server = remotesigner.NewServer(nodeKey, authorized, signerHandshake, ss.handleRequest)
+	data, err := ioutil.ReadFile(stateFile)
+	if err == nil {
+		if err := json.Unmarshal(data, &ss.state); err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	return ss, nil
+}
+
+func (ss *SignerServer) handleRequest(req *remotesigner.Request) ([]byte, error) {
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+	return ss.sign(req)
+}
+
+func (ss *SignerServer) sign(req *remotesigner.Request) ([]byte, error) {
+	switch req.Type {
+	case remotesigner.ReqPubKey:
+		return ss.privValidator.GetPubKey().Bytes(), nil
+	case SignerReqVote:
+		vote := &ttypes.Vote{DPosVote: &ttypes.DPosVote{}}
+		if err := types.Decode(req.Data, vote.DPosVote); err != nil {
+			return nil, err
+		}
+		if vote.VoteItem == nil {
+			return nil, types.ErrInvalidParam
+		}
+		if err := ss.checkVote(vote.VoteItem); err != nil {
+			return nil, err
+		}
+		if err := ss.privValidator.SignVote(req.ChainID, vote); err != nil {
+			return nil, err
+		}
+		ss.state.VotePeriod = vote.VoteItem.PeriodStart
+		ss.state.VoteID = vote.VoteItem.VoteID
+		ss.state.VotedNode = vote.VoteItem.VotedNodeAddress
+		return vote.Signature, ss.saveState()
+	case SignerReqNotify:
+		notify := &ttypes.Notify{DPosNotify: &ttypes.DPosNotify{}}
+		if err := types.Decode(req.Data, notify.DPosNotify); err != nil {
+			return nil, err
+		}
+		if notify.Vote == nil {
+			return nil, types.ErrInvalidParam
+		}
+		if err := ss.checkNotify(notify.DPosNotify); err != nil {
+			return nil, err
+		}
+		if err := ss.privValidator.SignNotify(req.ChainID, notify); err != nil {
+			return nil, err
+		}
+		ss.state.NotifyPeriod = notify.Vote.PeriodStart
+		ss.state.HeightStop = notify.HeightStop
+		ss.state.HashStop = notify.HashStop
+		return notify.Signature, ss.saveState()
+	case SignerReqMsg:
+		//vote和notify的签名数据必须走各自的检查
+		var canonical map[string]interface{}
+		if json.Unmarshal(req.Data, &canonical) == nil {
+			if _, ok := canonical["chain_id"]; ok {
+				return nil, ErrSignerConflict
+			}
+		}
+		sig, err := ss.privValidator.SignMsg(req.Data)
+		if err != nil {
+			return nil, err
+		}
+		return sig.Bytes(), nil
+	case SignerReqTx:
+		tx := &types.Transaction{}
+		if err := types.Decode(req.Data, tx); err != nil {
+			return nil, err
+		}
+		ss.privValidator.SignTx(tx)
+		return types.Encode(tx), nil
+	case SignerReqVrf:
+		hash, proof := ss.privValidator.VrfEvaluate(req.Data)
+		return append(hash[:], proof...), nil
+	default:
+		return nil, remotesigner.ErrUnknownRequest
+	}
+}
+
+// checkVote refuses the vote of an older period, and the vote for another node in the same period
+func (ss *SignerServer) checkVote(item *ttypes.VoteItem) error {
+	if item.PeriodStart < ss.state.VotePeriod {
+		return ErrSignerRegression
+	}
+	if item.PeriodStart == ss.state.VotePeriod &&
+		(!bytes.Equal(item.VoteID, ss.state.VoteID) || !bytes.Equal(item.VotedNodeAddress, ss.state.VotedNode)) {
+		return ErrSignerConflict
+	}
+	return nil
+}
+
+// checkNotify refuses the notify of an older period, and the notify of another stop block in the same period
+func (ss *SignerServer) checkNotify(notify *ttypes.DPosNotify) error {
+	if notify.Vote.PeriodStart < ss.state.NotifyPeriod {
+		return ErrSignerRegression
+	}
+	if notify.Vote.PeriodStart == ss.state.NotifyPeriod &&
+		(notify.HeightStop != ss.state.HeightStop || !bytes.Equal(notify.HashStop, ss.state.HashStop)) {
+		return ErrSignerConflict
+	}
+	return nil
+}
+
+func (ss *SignerServer) saveState() error {
+	data, err := json.Marshal(&ss.state)
+	if err != nil {
+		return err
+	}
+	return ttypes.WriteFileAtomic(ss.stateFile, data, 0600)
+}
@@ -0,0 +1,102 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dpos
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/dpos/types"
+	"github.com/33cn/plugin/plugin/consensus/remotesigner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	cr, err := crypto.New(types.GetSignName("", types.SECP256K1))
+	assert.Nil(t, err)
+	ttypes.ConsensusCrypto = cr
+	cr2, err := crypto.New(types.GetSignName("", types.ED25519))
+	assert.Nil(t, err)
+	ttypes.SecureConnCrypto = cr2
+	dir, err := ioutil.TempDir("", "dpos-signer")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, "priv_validator.enc.json")
+	stateFile := filepath.Join(dir, "signer_state.json")
+	password := []byte("signer password")
+
+	pv := ttypes.LoadOrGenPrivValidatorFSWithPassword(keyFile, password)
+	signerKey, err := remotesigner.LoadOrGenNodeKey(cr2, filepath.Join(dir, "signer_node_key.json"))
+	assert.Nil(t, err)
+	nodeKey, err := remotesigner.LoadOrGenNodeKey(cr2, filepath.Join(dir, "node_key.json"))
+	assert.Nil(t, err)
+
+	server, err := NewSignerServer(pv, signerKey, []crypto.PubKey{nodeKey.PubKey()}, stateFile)
+	assert.Nil(t, err)
+	assert.Nil(t, server.Start("127.0.0.1:0"))
+	addr := server.Addr().String()
+
+	otherKey, _ := cr2.GenKey()
+	_, err = NewRemoteSigner(addr, signerKey.PubKey(), otherKey)
+	assert.NotNil(t, err)
+
+	rs, err := NewRemoteSigner(addr, signerKey.PubKey(), nodeKey)
+	assert.Nil(t, err)
+	assert.Equal(t, pv.GetAddress(), rs.GetAddress())
+
+	newVote := func(votedNode string) *ttypes.Vote {
+		return &ttypes.Vote{DPosVote: &ttypes.DPosVote{
+			VoteItem: &ttypes.VoteItem{
+				VotedNodeAddress: []byte(votedNode),
+				PeriodStart:      100,
+				VoteID:           []byte(votedNode),
+			},
+			VoteTimestamp:    time.Now().Unix(),
+			VoterNodeAddress: rs.GetAddress(),
+		}}
+	}
+	vote := newVote("node-a")
+	assert.Nil(t, rs.SignVote("chain", vote))
+	assert.Nil(t, vote.Verify("chain", rs.GetPubKey()))
+	assert.Nil(t, rs.SignVote("chain", newVote("node-a")))
+	assert.NotNil(t, rs.SignVote("chain", newVote("node-b")))
+
+	notify := &ttypes.Notify{DPosNotify: &ttypes.DPosNotify{Vote: vote.VoteItem, HeightStop: 10,
+		HashStop: []byte("hash"), NotifyNodeAddress: rs.GetAddress()}}
+	assert.Nil(t, rs.SignNotify("chain", notify))
+	assert.Nil(t, notify.Verify("chain", rs.GetPubKey()))
+
+	// the vote and notify sign bytes can not be signed as a msg
+	_, err = rs.SignMsg(ttypes.SignBytes("chain", newVote("node-b")))
+	assert.NotNil(t, err)
+	sig, err := rs.SignMsg([]byte("cbinfo"))
+	assert.Nil(t, err)
+	assert.True(t, rs.GetPubKey().VerifyBytes([]byte("cbinfo"), sig))
+
+	hash, proof := rs.VrfEvaluate([]byte("input"))
+	assert.True(t, rs.VrfProof(rs.GetPubKey().Bytes(), []byte("input"), hash, proof))
+
+	tx := &types.Transaction{Execer: []byte("dpos"), Payload: []byte("payload")}
+	rs.SignTx(tx)
+	assert.True(t, tx.CheckSign())
+
+	// the last signed vote is kept after the signer is restarted
+	rs.Close()
+	server.Stop()
+	server, err = NewSignerServer(ttypes.LoadPrivValidatorFSWithPassword(keyFile, password), signerKey,
+		[]crypto.PubKey{nodeKey.PubKey()}, stateFile)
+	assert.Nil(t, err)
+	assert.Nil(t, server.Start("127.0.0.1:0"))
+	defer server.Stop()
+	rs, err = NewRemoteSigner(server.Addr().String(), signerKey.PubKey(), nodeKey)
+	assert.Nil(t, err)
+	defer rs.Close()
+	assert.NotNil(t, rs.SignVote("chain", newVote("node-b")))
+}
@@ -4,9 +4,9 @@
 
 // Package dpos Uses nacl's secret_box to encrypt a net.Conn.
 // It is (meant to be) an implementation of the STS protocol.
-// Note we do not (yet) assume that a remote peer's pubkey
-// is known ahead of time, and thus we are technically
-// still vulnerable to MITM. (TODO!)
+// The remote pubkey authenticated by the handshake is checked by PeerFilter,
+// which binds it to the current validators, so a man in the middle can not
+// connect as a validator without the validator signed node key.
 // See docs/sts-final.pdf for more info
 package dpos
 
@@ -149,8 +149,8 @@ func (sc *SecretConnection) Write(data []byte) (n int, err error) {
 // CONTRACT: data smaller than dataMaxSize is read atomically.
 func (sc *SecretConnection) Read(data []byte) (n int, err error) {
 	if 0 < len(sc.recvBuffer) {
-		count := copy(data, sc.recvBuffer)
-		sc.recvBuffer = sc.recvBuffer[count:]
+		n = copy(data, sc.recvBuffer)
+		sc.recvBuffer = sc.recvBuffer[n:]
 		return
 	}
 
@@ -153,6 +153,10 @@ func generateVote(cs *ConsensusState) *dpostype.Vote {
 	cs.validatorMgr.FillVoteItem(voteItem)
 
 	index := cs.validatorMgr.GetIndexByPubKey(cs.privValidator.GetPubKey().Bytes())
+	if index == -1 {
+		//被禁止出块的节点不在本cycle的出块顺序中，但仍然参与投票
+		index, _ = cs.validatorMgr.Validators.GetByAddress(cs.privValidator.GetAddress())
+	}
 
 	if index == -1 {
 		panic("This node's address is not exist in Validators.")
@@ -591,6 +595,9 @@ func (voted *VotedState) timeOut(cs *ConsensusState) {
 			//当前时间超过了节点切换时间，需要进行重新投票
 			dposlog.Info("VotedState timeOut over periodStop.", "periodStop", cs.currentVote.PeriodStop, "cycleStop", cs.currentVote.CycleStop)
 
+			//记录本节点在本出块时段的出块情况
+			cs.RecordSlots(cs.currentVote, true)
+
 			isCycleSwith := false
 			//如果到了cycle结尾，需要构造一个交易，把最终的CycleBoundary信息发布出去
 			if cs.currentVote.PeriodStop == cs.currentVote.CycleStop {
@@ -602,6 +609,7 @@ func (voted *VotedState) timeOut(cs *ConsensusState) {
 					StopHeight: block.Height,
 					StopHash:   hex.EncodeToString(block.Hash(cfg)),
 					Pubkey:     strings.ToUpper(hex.EncodeToString(cs.privValidator.GetPubKey().Bytes())),
+					Slots:      cs.GetSlotsByCycle(cs.currentVote.Cycle),
 				}
 
 				info2 := &dpostype.DPosCBInfo{
@@ -800,6 +808,10 @@ type WaitNofifyState struct {
 func (wait *WaitNofifyState) timeOut(cs *ConsensusState) {
 	//cs.clearVotes()
 
+	//没有收到出块节点的通知，认为出块节点不在线
+	cs.RecordSlots(cs.lastVote, false)
+	cs.SendSlotsTx(cs.lastVote)
+
 	//检查是否需要更新TopN，如果有更新，则更新TOPN节点后进入新的状态循环。
 	now := time.Now().Unix()
 	if now >= cs.lastVote.PeriodStop && cs.lastVote.PeriodStop == cs.lastVote.CycleStop {
@@ -877,6 +889,8 @@ func (wait *WaitNofifyState) recvNotify(cs *ConsensusState, notify *dpostype.DPo
 	cs.ClearCachedNotify()
 	cs.SaveNotify()
 	cs.SetNotify(notify)
+	cs.RecordSlots(notify.Vote, true)
+	cs.SendSlotsTx(notify.Vote)
 
 	//检查是否需要更新TopN，如果有更新，则更新TOPN节点后进入新的状态循环。
 	now := time.Now().Unix()
@@ -0,0 +1,117 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// signer is the reference remote signer of the dpos validator.
+// The validator key is kept in a file encrypted with the password, which is read from
+// the SIGNER_PASSWORD environment or the terminal. Only the consensus nodes whose
+// node pubkeys are in the allow list can connect.
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/consensus/dpos"
+	ttypes "github.com/33cn/plugin/plugin/consensus/dpos/types"
+	"github.com/33cn/plugin/plugin/consensus/remotesigner"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var (
+	keyFile     = flag.String("key", "priv_validator.enc.json", "encrypted validator key file, created if not exist")
+	importFile  = flag.String("import", "", "plain priv_validator.json to be encrypted into the key file")
+	stateFile   = flag.String("state", "signer_state.json", "last signed vote and notify")
+	nodeKeyFile = flag.String("nodekey", "signer_node_key.json", "signer identity key file, created if not exist")
+	laddr       = flag.String("laddr", "127.0.0.1:46670", "listen address")
+	allow       = flag.String("allow", "", "comma separated node pubkeys of the authorized consensus nodes")
+)
+
+func main() {
+	flag.Parse()
+	cr, err := crypto.New(types.GetSignName("", types.SECP256K1))
+	if err != nil {
+		exit(err)
+	}
+	ttypes.ConsensusCrypto = cr
+	cr2, err := crypto.New(types.GetSignName("", types.ED25519))
+	if err != nil {
+		exit(err)
+	}
+	ttypes.SecureConnCrypto = cr2
+
+	var authorized []crypto.PubKey
+	for _, key := range strings.Split(*allow, ",") {
+		if key = strings.TrimSpace(key); key == "" {
+			continue
+		}
+		pub, err := hex.DecodeString(key)
+		if err != nil {
+			exit(err)
+		}
+		pubKey, err := cr2.PubKeyFromBytes(pub)
+		if err != nil {
+			exit(err)
+		}
+		authorized = append(authorized, pubKey)
+	}
+	if len(authorized) == 0 {
+		exit(fmt.Errorf("no authorized node pubkey, use -allow"))
+	}
+
+	password, err := readPassword()
+	if err != nil {
+		exit(err)
+	}
+	var privValidator *ttypes.PrivValidatorImp
+	if *importFile != "" {
+		if _, err := os.Stat(*keyFile); err == nil {
+			exit(fmt.Errorf("key file %v already exists", *keyFile))
+		}
+		privValidator = ttypes.ImportPrivValidatorFS(*importFile, *keyFile, password)
+	} else {
+		privValidator = ttypes.LoadOrGenPrivValidatorFSWithPassword(*keyFile, password)
+	}
+	nodeKey, err := remotesigner.LoadOrGenNodeKey(cr2, *nodeKeyFile)
+	if err != nil {
+		exit(err)
+	}
+
+	server, err := dpos.NewSignerServer(privValidator, nodeKey, authorized, *stateFile)
+	if err != nil {
+		exit(err)
+	}
+	if err := server.Start(*laddr); err != nil {
+		exit(err)
+	}
+	fmt.Println("validator pubkey:", privValidator.GetPubKey().KeyString())
+	fmt.Println("signer pubkey:", nodeKey.PubKey().KeyString())
+	fmt.Println("listen on:", server.Addr())
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+	<-c
+	server.Stop()
+}
+
+func readPassword() ([]byte, error) {
+	if password := os.Getenv("SIGNER_PASSWORD"); password != "" {
+		return []byte(password), nil
+	}
+	fmt.Print("password: ")
+	password, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	return password, err
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
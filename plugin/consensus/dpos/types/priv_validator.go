@@ -19,6 +19,7 @@ import (
 	"github.com/33cn/chain33/common/crypto"
 	vrf "github.com/33cn/chain33/common/vrf/secp256k1"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/consensus/privfile"
 	secp256k1 "github.com/btcsuite/btcd/btcec"
 )
 
@@ -70,6 +71,10 @@ type PrivValidatorImp struct {
 	// Overloaded for testing.
 	filePath string
 	mtx      sync.Mutex
+
+	// the file is encrypted with the key derived from password if it's set
+	fileKey  []byte
+	fileSalt []byte
 }
 
 // Signer is an interface that defines how to sign messages.
@@ -176,8 +181,61 @@ func LoadPrivValidatorFSWithSigner(filePath string, signerFunc func(PrivValidato
 	if err != nil {
 		Exit(err.Error())
 	}
+	return privValidatorFromJSON(filePath, privValJSONBytes, signerFunc)
+}
+
+// LoadPrivValidatorFSWithPassword loads a PrivValidatorImp from the file encrypted with password.
+func LoadPrivValidatorFSWithPassword(filePath string, password []byte) *PrivValidatorImp {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		Exit(err.Error())
+	}
+	privValJSONBytes, key, salt, err := privfile.Decrypt(data, password)
+	if err != nil {
+		Exit(Fmt("Error decrypting PrivValidator from %v: %v\n", filePath, err))
+	}
+	privVal := privValidatorFromJSON(filePath, privValJSONBytes, func(privVal PrivValidator) Signer {
+		return NewDefaultSigner(privVal.(*PrivValidatorImp).PrivKey)
+	})
+	privVal.fileKey = key
+	privVal.fileSalt = salt
+	return privVal
+}
+
+// LoadOrGenPrivValidatorFSWithPassword loads the encrypted PrivValidatorImp from the given filePath
+// or else generates a new one and saves it encrypted with password.
+func LoadOrGenPrivValidatorFSWithPassword(filePath string, password []byte) *PrivValidatorImp {
+	if _, err := os.Stat(filePath); err == nil {
+		return LoadPrivValidatorFSWithPassword(filePath, password)
+	}
+	privVal := GenPrivValidatorImp(filePath)
+	key, salt, err := privfile.DeriveKey(password, nil)
+	if err != nil {
+		Exit(Fmt("Error deriving key for PrivValidator: %v\n", err))
+	}
+	privVal.fileKey = key
+	privVal.fileSalt = salt
+	privVal.Save()
+	return privVal
+}
+
+// ImportPrivValidatorFS encrypts the plain priv validator file with password and saves it to filePath
+func ImportPrivValidatorFS(plainFilePath string, filePath string, password []byte) *PrivValidatorImp {
+	privVal := LoadPrivValidatorFS(plainFilePath)
+	key, salt, err := privfile.DeriveKey(password, nil)
+	if err != nil {
+		Exit(Fmt("Error deriving key for PrivValidator: %v\n", err))
+	}
+	privVal.filePath = filePath
+	privVal.fileKey = key
+	privVal.fileSalt = salt
+	privVal.Save()
+	return privVal
+}
+
+func privValidatorFromJSON(filePath string, privValJSONBytes []byte, signerFunc func(PrivValidator) Signer) *PrivValidatorImp {
 	privVal := &PrivValidatorFS{}
-	err = json.Unmarshal(privValJSONBytes, &privVal)
+	err := json.Unmarshal(privValJSONBytes, &privVal)
 	if err != nil {
 		Exit(Fmt("Error reading PrivValidator from %v: %v\n", filePath, err))
 	}
@@ -267,6 +325,12 @@ func (pv *PrivValidatorImp) save() {
 		// `@; BOOM!!!
 		PanicCrisis(err)
 	}
+	if pv.fileKey != nil {
+		jsonBytes, err = privfile.Encrypt(jsonBytes, pv.fileKey, pv.fileSalt)
+		if err != nil {
+			PanicCrisis(err)
+		}
+	}
 	err = WriteFileAtomic(pv.filePath, jsonBytes, 0600)
 	if err != nil {
 		// `@; BOOM!!!
@@ -344,7 +408,11 @@ func (pv *PrivValidatorImp) VrfEvaluate(input []byte) (hash [32]byte, proof []by
 func (pv *PrivValidatorImp) VrfProof(pubkey []byte, input []byte, hash [32]byte, proof []byte) bool {
 	pv.mtx.Lock()
 	defer pv.mtx.Unlock()
+	return VrfVerify(pubkey, input, hash, proof)
+}
 
+// VrfVerify check the vrf hash & proof with the pubkey.
+func VrfVerify(pubkey []byte, input []byte, hash [32]byte, proof []byte) bool {
 	pubKey, err := secp256k1.ParsePubKey(pubkey, secp256k1.S256())
 	if err != nil {
 		return false
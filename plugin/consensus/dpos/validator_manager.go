@@ -6,6 +6,7 @@ package dpos
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 
@@ -164,6 +165,70 @@ func (s *ValidatorMgr) GetValidatorByIndex(index int) (addr []byte, val *ttypes.
 	return nil, nil
 }
 
+// ExcludeJailed method
+func (s *ValidatorMgr) ExcludeJailed(jailed [][]byte) bool {
+	if len(jailed) == 0 || s.Validators == nil {
+		return false
+	}
+
+	var order []*ttypes.Validator
+	var active []*ttypes.Validator
+	hasJailed := false
+	for i := 0; i < s.Validators.Size(); i++ {
+		_, val := s.GetValidatorByIndex(i)
+		if val == nil {
+			return false
+		}
+		order = append(order, val)
+		if isPubKeyExist(val.PubKey, jailed) {
+			hasJailed = true
+		} else {
+			active = append(active, val)
+		}
+	}
+
+	//全部节点都被禁止出块时不做处理，避免出块停止
+	if !hasJailed || len(active) == 0 {
+		return false
+	}
+
+	//被禁止出块的节点的出块时段按顺序由未被禁止的节点轮流补上，
+	//用出块顺序作为地址，NewValidatorSet按地址排序后保持原有顺序
+	slots := make([]*ttypes.Validator, len(order))
+	k := 0
+	for i, val := range order {
+		if isPubKeyExist(val.PubKey, jailed) {
+			val = active[k%len(active)]
+			k++
+		}
+		slots[i] = &ttypes.Validator{
+			PubKey:  val.PubKey,
+			Address: slotAddress(i),
+		}
+	}
+
+	s.ShuffleType = ShuffleTypeVrf
+	s.VrfValidators = ttypes.NewValidatorSet(slots)
+	s.NoVrfValidators = nil
+	return true
+}
+
+func slotAddress(index int) []byte {
+	addr := make([]byte, 20)
+	binary.BigEndian.PutUint64(addr[12:], uint64(index))
+	return addr
+}
+
+func isPubKeyExist(pubkey []byte, set [][]byte) bool {
+	for i := 0; i < len(set); i++ {
+		if bytes.Equal(pubkey, set[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // GetIndexByPubKey method
 func (s *ValidatorMgr) GetIndexByPubKey(pubkey []byte) (index int) {
 	if nil == pubkey {
@@ -351,3 +351,37 @@ func TestUpdateFromVoteItem(t *testing.T) {
 	assert.True(t, len(newMgr.VrfValidators.Validators) == 2)
 	assert.True(t, len(newMgr.NoVrfValidators.Validators) == 1)
 }
+
+func TestExcludeJailed(t *testing.T) {
+	vMgr, err := MakeGenesisValidatorMgr(genDoc)
+	require.Nil(t, err)
+	vMgr.ShuffleType = ShuffleTypeNoVrf
+
+	assert.False(t, vMgr.ExcludeJailed(nil))
+	assert.False(t, vMgr.ExcludeJailed([][]byte{[]byte("afdafafdfa")}))
+	assert.True(t, vMgr.ShuffleType == ShuffleTypeNoVrf)
+
+	//全部被禁止时不做处理
+	var all [][]byte
+	for _, val := range vMgr.Validators.Validators {
+		all = append(all, val.PubKey)
+	}
+	assert.False(t, vMgr.ExcludeJailed(all))
+
+	jailed := vMgr.Validators.Validators[1].PubKey
+	assert.True(t, vMgr.ExcludeJailed([][]byte{jailed}))
+	assert.True(t, vMgr.ShuffleType == ShuffleTypeVrf)
+	assert.True(t, vMgr.VrfValidators.Size() == 3)
+	assert.True(t, vMgr.NoVrfValidators == nil)
+
+	//出块顺序不变，被禁止节点的出块时段由第一个未被禁止的节点补上
+	_, val := vMgr.GetValidatorByIndex(0)
+	assert.True(t, bytes.Equal(val.PubKey, vMgr.Validators.Validators[0].PubKey))
+	_, val = vMgr.GetValidatorByIndex(1)
+	assert.True(t, bytes.Equal(val.PubKey, vMgr.Validators.Validators[0].PubKey))
+	_, val = vMgr.GetValidatorByIndex(2)
+	assert.True(t, bytes.Equal(val.PubKey, vMgr.Validators.Validators[2].PubKey))
+
+	assert.True(t, vMgr.GetIndexByPubKey(jailed) == -1)
+	assert.True(t, vMgr.GetIndexByPubKey(vMgr.Validators.Validators[2].PubKey) == 2)
+}
@@ -20,10 +20,10 @@ import (
 	"github.com/33cn/chain33/common/crypto"
 	"github.com/33cn/chain33/common/merkle"
 	"github.com/33cn/chain33/queue"
-	"github.com/33cn/chain33/rpc/grpcclient"
 	drivers "github.com/33cn/chain33/system/consensus"
 	cty "github.com/33cn/chain33/system/dapp/coins/types"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/dapp/paracross/mainclient"
 	paracross "github.com/33cn/plugin/plugin/dapp/paracross/types"
 	pt "github.com/33cn/plugin/plugin/dapp/paracross/types"
 )
@@ -270,7 +270,7 @@ func (client *client) InitBlock() {
 
 	client.execAPI = api.New(client.BaseClient.GetAPI(), client.grpcClient)
 	cfg := client.GetAPI().GetConfig()
-	grpcCli, err := grpcclient.NewMainChainClient(cfg, "")
+	grpcCli, err := mainclient.NewMainChainClient(cfg)
 	if err != nil {
 		panic(err)
 	}
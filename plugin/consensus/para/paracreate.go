@@ -539,6 +539,12 @@ out:
 				plog.Debug("para CreateBlock count not match", "count", count, "items", len(paraTxs.Items))
 				count = int64(len(paraTxs.Items))
 			}
+			err = client.checkMainBlockHash(paraTxs)
+			if err != nil {
+				time.Sleep(time.Second * time.Duration(client.subCfg.WriteBlockSeconds))
+				continue
+			}
+
 			//如果当前正在追赶，暂不处理
 			if client.commitMsgClient.authAccount != "" && client.isCaughtUp() && len(paraTxs.Items) > 0 {
 				client.commitMsgClient.commitTxCheckNotify(paraTxs.Items[0])
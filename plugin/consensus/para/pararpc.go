@@ -13,6 +13,30 @@ import (
 	"github.com/33cn/chain33/types"
 )
 
+//配置了多个主链节点时，主链客户端支持区块哈希交叉校验
+type mainBlockHashChecker interface {
+	CheckBlockHash(height int64, hash []byte) error
+}
+
+// 接受主链区块前，与其他主链节点交叉校验最后一个区块的哈希，前面的区块已经通过parentHash校验
+func (client *client) checkMainBlockHash(mainBlocks *types.ParaTxDetails) error {
+	checker, ok := client.grpcClient.(mainBlockHashChecker)
+	if !ok || len(mainBlocks.Items) == 0 {
+		return nil
+	}
+	last := mainBlocks.Items[len(mainBlocks.Items)-1]
+	height, hash := last.Header.Height, last.Header.Hash
+	//回滚的区块，校验回滚后的主链区块
+	if last.Type == types.DelBlock {
+		height, hash = last.Header.Height-1, last.Header.ParentHash
+	}
+	err := checker.CheckBlockHash(height, hash)
+	if err != nil {
+		plog.Error("checkMainBlockHash", "height", height, "hash", common.ToHex(hash), "err", err)
+	}
+	return err
+}
+
 func (client *client) GetBlockByHeight(height int64) (*types.Block, error) {
 	//from blockchain db
 	blockDetails, err := client.GetAPI().GetBlocks(&types.ReqBlocks{Start: height, End: height})
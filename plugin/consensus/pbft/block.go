@@ -22,19 +22,32 @@ func init() {
 // Client Pbft implementation
 type Client struct {
 	*drivers.BaseClient
+	replica     *Replica
 	replyChan   chan *types.ClientReply
 	requestChan chan *types.Request
-	isPrimary   bool
+	blockChan   chan struct{}
 }
 
 // NewBlockstore create Pbft Client
-func NewBlockstore(cfg *types.Consensus, replyChan chan *types.ClientReply, requestChan chan *types.Request, isPrimary bool) *Client {
+func NewBlockstore(cfg *types.Consensus, replica *Replica) *Client {
 	c := drivers.NewBaseClient(cfg)
-	client := &Client{BaseClient: c, replyChan: replyChan, requestChan: requestChan, isPrimary: isPrimary}
+	client := &Client{
+		BaseClient:  c,
+		replica:     replica,
+		replyChan:   replica.replyChan,
+		requestChan: replica.requestChan,
+		blockChan:   make(chan struct{}, 1),
+	}
 	c.SetChild(client)
 	return client
 }
 
+// Close method
+func (client *Client) Close() {
+	client.replica.Stop()
+	client.BaseClient.Close()
+}
+
 // ProcEvent method
 func (client *Client) ProcEvent(msg *queue.Message) bool {
 	return false
@@ -60,18 +73,18 @@ func (client *Client) SetQueueClient(c queue.Client) {
 		client.InitBlock()
 	})
 	go client.EventLoop()
-	//go client.readReply()
+	go client.readReply()
 	go client.CreateBlock()
 }
 
 // CreateBlock method
 func (client *Client) CreateBlock() {
 	issleep := true
-	if !client.isPrimary {
-		return
-	}
 	cfg := client.GetQueueClient().GetConfig()
 	for {
+		if client.IsClosed() {
+			return
+		}
 		if issleep {
 			time.Sleep(10 * time.Second)
 		}
@@ -82,6 +95,12 @@ func (client *Client) CreateBlock() {
 			issleep = true
 			continue
 		}
+		if !client.replica.IsPrimary() {
+			//备份节点有交易等待打包, 主节点超时没有出块时发起视图切换
+			client.replica.Suspect()
+			issleep = true
+			continue
+		}
 		issleep = false
 		plog.Info("==================start create new block!=====================")
 		//check dup
@@ -102,8 +121,7 @@ func (client *Client) CreateBlock() {
 			newblock.BlockTime = lastBlock.BlockTime + 1
 		}
 		client.Propose(&newblock)
-		//time.Sleep(time.Second)
-		client.readReply()
+		client.waitBlock(newblock.Height)
 		plog.Info("===============readreply and writeblock done===============")
 	}
 }
@@ -127,22 +145,42 @@ func (client *Client) CreateGenesisTx() (ret []*types.Transaction) {
 	return
 }
 
-func (client *Client) readReply() {
-
-	data := <-client.replyChan
-	if data == nil {
-		plog.Error("block is nil")
-		return
-	}
-	plog.Info("===============Get block from reply channel===========")
-	//client.SetCurrentBlock(data.Result.Value)
-	lastBlock := client.GetCurrentBlock()
-	err := client.WriteBlock(lastBlock.StateHash, data.Result.Value)
-
-	if err != nil {
-		plog.Error("********************err:", err)
-		return
+// waitBlock 等待区块写入, 超时之后重新打包
+func (client *Client) waitBlock(height int64) {
+	timeout := time.NewTimer(client.replica.timeout)
+	defer timeout.Stop()
+	for client.GetCurrentBlock().Height < height {
+		select {
+		case <-client.blockChan:
+		case <-timeout.C:
+			return
+		}
 	}
-	client.SetCurrentBlock(data.Result.Value)
+}
 
+func (client *Client) readReply() {
+	for data := range client.replyChan {
+		if data == nil || data.Result == nil || data.Result.Value == nil {
+			plog.Error("block is nil")
+			continue
+		}
+		plog.Info("===============Get block from reply channel===========")
+		block := data.Result.Value
+		lastBlock := client.GetCurrentBlock()
+		//视图切换之后可能会重复提交相同高度的区块, 只写入下一个高度的区块
+		if block.Height != lastBlock.Height+1 {
+			plog.Info("skip block", "height", block.Height, "current", lastBlock.Height)
+			continue
+		}
+		err := client.WriteBlock(lastBlock.StateHash, block)
+		if err != nil {
+			plog.Error("********************err:", err)
+			continue
+		}
+		client.SetCurrentBlock(block)
+		select {
+		case client.blockChan <- struct{}{}:
+		default:
+		}
+	}
 }
@@ -6,7 +6,10 @@ package pbft
 
 import (
 	"strings"
+	"time"
 
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/crypto"
 	log "github.com/33cn/chain33/common/log/log15"
 	"github.com/33cn/chain33/queue"
 	pb "github.com/33cn/chain33/types"
@@ -25,6 +28,10 @@ type subConfig struct {
 	NodeID           int64  `json:"nodeID"`
 	PeersURL         string `json:"peersURL"`
 	ClientAddr       string `json:"clientAddr"`
+	// PrivKey 本节点的签名私钥, PeersPubKey 所有节点的公钥, 顺序和 PeersURL 一致
+	PrivKey           string `json:"privKey"`
+	PeersPubKey       string `json:"peersPubKey"`
+	ViewChangeTimeout int64  `json:"viewChangeTimeout"`
 }
 
 // NewPbft create pbft cluster
@@ -47,8 +54,47 @@ func NewPbft(cfg *pb.Consensus, sub []byte) queue.Module {
 	}
 	clientAddr = subcfg.ClientAddr
 
-	var c *Client
-	replyChan, requestChan, isPrimary := NewReplica(uint32(subcfg.NodeID), subcfg.PeersURL, subcfg.ClientAddr)
-	c = NewBlockstore(cfg, replyChan, requestChan, isPrimary)
-	return c
+	privKey, pubKeys, err := loadKeys(subcfg.PrivKey, subcfg.PeersPubKey)
+	if err != nil {
+		plog.Error("load pbft keys failed", "err", err)
+		return nil
+	}
+	timeout := DefaultViewChangeTimeout
+	if subcfg.ViewChangeTimeout > 0 {
+		timeout = time.Duration(subcfg.ViewChangeTimeout) * time.Second
+	}
+	replica, err := NewReplica(uint32(subcfg.NodeID), subcfg.PeersURL, subcfg.ClientAddr, privKey, pubKeys, timeout)
+	if err != nil {
+		plog.Error("create pbft replica failed", "err", err)
+		return nil
+	}
+	return NewBlockstore(cfg, replica)
+}
+
+func loadKeys(privKey, peersPubKey string) (crypto.PrivKey, []crypto.PubKey, error) {
+	cr, err := crypto.New(pb.GetSignName("", pb.SECP256K1))
+	if err != nil {
+		return nil, nil, err
+	}
+	bkey, err := common.FromHex(privKey)
+	if err != nil || len(bkey) == 0 {
+		return nil, nil, errPrivKey
+	}
+	priv, err := cr.PrivKeyFromBytes(bkey)
+	if err != nil {
+		return nil, nil, err
+	}
+	var pubKeys []crypto.PubKey
+	for _, key := range strings.Split(peersPubKey, ",") {
+		bkey, err := common.FromHex(strings.TrimSpace(key))
+		if err != nil || len(bkey) == 0 {
+			return nil, nil, errPeersPubKey
+		}
+		pub, err := cr.PubKeyFromBytes(bkey)
+		if err != nil {
+			return nil, nil, err
+		}
+		pubKeys = append(pubKeys, pub)
+	}
+	return priv, pubKeys, nil
 }
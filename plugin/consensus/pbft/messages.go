@@ -10,11 +10,17 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"time"
 
 	"github.com/33cn/chain33/types"
 	"github.com/golang/protobuf/proto"
 )
 
+const (
+	dialTimeout  = 3 * time.Second
+	writeTimeout = 10 * time.Second
+)
+
 // EQ Digest
 func EQ(d1 []byte, d2 []byte) bool {
 	if len(d1) != len(d2) {
@@ -146,8 +152,12 @@ func RepDigest(reply fmt.Stringer) []byte {
 
 // WriteMessage write proto message
 func WriteMessage(addr string, msg proto.Message) error {
-	conn, err := net.Dial("tcp", addr)
+	conn, err := net.DialTimeout("tcp", addr, dialTimeout)
+	if err != nil {
+		return err
+	}
 	defer conn.Close()
+	err = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
 	if err != nil {
 		return err
 	}
@@ -7,9 +7,14 @@ package pbft
 import (
 	"errors"
 	"net"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/33cn/chain33/common/crypto"
 	pb "github.com/33cn/chain33/types"
+	pt "github.com/33cn/plugin/plugin/consensus/pbft/types"
 	"github.com/golang/protobuf/proto"
 )
 
@@ -17,86 +22,166 @@ import (
 const (
 	CheckPointPeriod uint32 = 128
 	ConstantFactor   uint32 = 2
+	// DefaultViewChangeTimeout 主节点在该时间内没有处理完请求时发起视图切换
+	DefaultViewChangeTimeout = 10 * time.Second
+	readTimeout              = 10 * time.Second
+	chanSize                 = 1024
+)
+
+var (
+	errNodeID      = errors.New("ErrPbftNodeID")
+	errPeersPubKey = errors.New("ErrPbftPeersPubKey")
+	errPrivKey     = errors.New("ErrPbftPrivKey")
 )
 
 // Replica struct
 type Replica struct {
 	ID          uint32
 	replicas    map[uint32]string
+	pubKeys     map[uint32]crypto.PubKey
+	privKey     crypto.PrivKey
+	cr          crypto.Crypto
 	activeView  bool
 	view        uint32
 	sequence    uint32
 	requestChan chan *pb.Request
 	replyChan   chan *pb.ClientReply
-	errChan     chan error
-	doneChan    chan string
+	replyNotify chan struct{}
 	requests    map[string][]*pb.Request
+	clients     map[string]*pb.Request
 	replies     map[string][]*pb.ClientReply
-	lastReply   *pb.ClientReply
-	pendingVC   []*pb.Request
+	pending     []*pb.ClientReply
+	lastDigest  []byte
+	pendingNV   *pb.Request
 	executed    []uint32
 	checkpoints []*pb.Checkpoint
+
+	mu        sync.Mutex
+	listener  net.Listener
+	stopped   bool
+	suspected bool
+	timeout   time.Duration
+	timer     *time.Timer
+	timerSeq  uint64
 }
 
-// NewReplica create Replica instance
-func NewReplica(id uint32, PeersURL string, addr string) (chan *pb.ClientReply, chan *pb.Request, bool) {
-	replyChan := make(chan *pb.ClientReply)
-	requestChan := make(chan *pb.Request)
+// NewReplica create Replica instance, 节点编号从1开始, 和 peersURL, pubKeys 的顺序一一对应
+func NewReplica(id uint32, peersURL string, addr string, privKey crypto.PrivKey, pubKeys []crypto.PubKey, timeout time.Duration) (*Replica, error) {
+	peers := strings.Split(peersURL, ",")
+	if len(peers) != len(pubKeys) {
+		return nil, errPeersPubKey
+	}
+	if id == 0 || int(id) > len(peers) {
+		return nil, errNodeID
+	}
+	if privKey == nil || !privKey.PubKey().Equals(pubKeys[id-1]) {
+		return nil, errPrivKey
+	}
+	cr, err := crypto.New(pb.GetSignName("", pb.SECP256K1))
+	if err != nil {
+		return nil, err
+	}
+	if timeout <= 0 {
+		timeout = DefaultViewChangeTimeout
+	}
 	pn := &Replica{
 		ID:          id,
 		replicas:    make(map[uint32]string),
+		pubKeys:     make(map[uint32]crypto.PubKey),
+		privKey:     privKey,
+		cr:          cr,
 		activeView:  true,
 		view:        1,
 		sequence:    0,
-		requestChan: requestChan,
-		replyChan:   replyChan,
-		errChan:     make(chan error),
+		requestChan: make(chan *pb.Request, chanSize),
+		replyChan:   make(chan *pb.ClientReply, chanSize),
+		replyNotify: make(chan struct{}, 1),
 		requests:    make(map[string][]*pb.Request),
+		clients:     make(map[string]*pb.Request),
 		replies:     make(map[string][]*pb.ClientReply),
-		doneChan:    make(chan string),
-		lastReply:   nil,
-		pendingVC:   make([]*pb.Request, 10),
-		executed:    make([]uint32, 10),
+		executed:    []uint32{0},
+		timeout:     timeout,
 	}
-	peers := strings.Split(PeersURL, ",")
 	for num, peer := range peers {
-		pn.replicas[uint32(num)] = peer
+		pn.replicas[uint32(num+1)] = strings.TrimSpace(peer)
+		pn.pubKeys[uint32(num+1)] = pubKeys[num]
 	}
 	pn.checkpoints = []*pb.Checkpoint{ToCheckpoint(0, []byte(""))}
-	pn.Startnode(addr)
-	return replyChan, requestChan, pn.isPrimary(pn.ID)
-
+	if err := pn.Startnode(addr); err != nil {
+		return nil, err
+	}
+	return pn, nil
 }
 
 // Startnode method
-func (rep *Replica) Startnode(addr string) {
-	rep.acceptConnections(addr)
+func (rep *Replica) Startnode(addr string) error {
+	return rep.acceptConnections(addr)
+}
+
+// Stop 停止节点, 不再收发消息
+func (rep *Replica) Stop() {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	if rep.stopped {
+		return
+	}
+	rep.stopped = true
+	rep.stopTimer()
+	if rep.listener != nil {
+		rep.listener.Close()
+	}
+}
+
+// IsPrimary 当前节点是否是正常工作的主节点
+func (rep *Replica) IsPrimary() bool {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	return !rep.stopped && rep.activeView && rep.isPrimary(rep.ID)
+}
+
+// View 返回当前视图
+func (rep *Replica) View() uint32 {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	return rep.view
+}
+
+// Suspect 备份节点有请求等待处理而主节点长时间没有出块, 超时之后发起视图切换
+func (rep *Replica) Suspect() {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	if rep.stopped || (rep.activeView && rep.isPrimary(rep.ID)) {
+		return
+	}
+	rep.suspected = true
+	rep.startTimer()
+}
+
+func (rep *Replica) isStopped() bool {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	return rep.stopped
 }
 
 // Basic operations
 
 func (rep *Replica) primary() uint32 {
-	return rep.view % uint32(len(rep.replicas)+1)
+	return rep.newPrimary(rep.view)
 }
 
 func (rep *Replica) newPrimary(view uint32) uint32 {
-	return view % uint32(len(rep.replicas)+1)
+	if view == 0 {
+		return 0
+	}
+	return (view-1)%uint32(len(rep.replicas)) + 1
 }
 
 func (rep *Replica) isPrimary(ID uint32) bool {
 	return ID == rep.primary()
 }
 
-func (rep *Replica) oneThird(count int) bool {
-	return count >= (len(rep.replicas)+1)/3
-}
-
 func (rep *Replica) overOneThird(count int) bool {
-	return count > (len(rep.replicas)+1)/3
-}
-
-func (rep *Replica) twoThirds(count int) bool {
-	return count >= 2*(len(rep.replicas)-1)/3
+	return count > (len(rep.replicas)-1)/3
 }
 
 func (rep *Replica) overTwoThirds(count int) bool {
@@ -119,19 +204,15 @@ func (rep *Replica) lastExecuted() uint32 {
 	return rep.executed[len(rep.executed)-1]
 }
 
-func (rep *Replica) lastStable() *pb.Checkpoint {
-	return rep.checkpoints[len(rep.checkpoints)-1]
+func (rep *Replica) setExecuted(sequence uint32) {
+	if len(rep.executed) >= int(CheckPointPeriod) {
+		rep.executed = rep.executed[len(rep.executed)-1:]
+	}
+	rep.executed = append(rep.executed, sequence)
 }
 
-func (rep *Replica) theLastReply() *pb.ClientReply {
-	lastReply := rep.lastReply
-	for _, replies := range rep.replies {
-		reply := replies[len(replies)-1]
-		if reply.Timestamp > lastReply.Timestamp {
-			lastReply = reply
-		}
-	}
-	return lastReply
+func (rep *Replica) lastStable() *pb.Checkpoint {
+	return rep.checkpoints[len(rep.checkpoints)-1]
 }
 
 func (rep *Replica) lastReplyToClient(client string) *pb.ClientReply {
@@ -141,8 +222,9 @@ func (rep *Replica) lastReplyToClient(client string) *pb.ClientReply {
 	return nil
 }
 
+// stateDigest 最后执行的请求摘要, 所有正常节点按相同顺序执行请求, 摘要相同
 func (rep *Replica) stateDigest() []byte {
-	return RepDigest(rep.theLastReply())
+	return rep.lastDigest
 }
 
 func (rep *Replica) isCheckpoint(sequence uint32) bool {
@@ -151,86 +233,140 @@ func (rep *Replica) isCheckpoint(sequence uint32) bool {
 
 func (rep *Replica) addCheckpoint(checkpoint *pb.Checkpoint) {
 	rep.checkpoints = append(rep.checkpoints, checkpoint)
+	if len(rep.checkpoints) > int(ConstantFactor) {
+		rep.checkpoints = rep.checkpoints[len(rep.checkpoints)-int(ConstantFactor):]
+	}
 }
 
-func (rep *Replica) acceptConnections(addr string) {
+func (rep *Replica) acceptConnections(addr string) error {
 	ln, err := net.Listen("tcp", addr)
 	if err != nil {
 		plog.Error("tcp connect error", "err", err)
+		return err
 	}
+	rep.listener = ln
 	go rep.sendRoutine()
+	go rep.replyRoutine()
 	go func() {
 		for {
 			conn, err := ln.Accept()
 			if err != nil {
-				plog.Error("Accept error")
+				if rep.isStopped() {
+					return
+				}
+				plog.Error("Accept error", "err", err)
+				continue
 			}
-			req := &pb.Request{}
-			err = ReadMessage(conn, req)
+			env := &pt.SignedRequest{}
+			conn.SetReadDeadline(time.Now().Add(readTimeout))
+			err = ReadMessage(conn, env)
+			conn.Close()
 			if err != nil {
 				plog.Error("readmessage error", "err", err)
+				continue
+			}
+			if err := rep.verify(env); err != nil {
+				plog.Error("verify message error", "replica", env.Replica, "err", err)
+				continue
 			}
-			rep.handleRequest(req)
+			rep.handleRequest(env.Request, env.Replica)
 		}
 	}()
+	return nil
+}
+
+// Signature
+
+func (rep *Replica) sign(REQ *pb.Request) *pt.SignedRequest {
+	sig := rep.privKey.Sign(pb.Encode(REQ))
+	return &pt.SignedRequest{Request: REQ, Replica: rep.ID, Signature: sig.Bytes()}
+}
+
+func (rep *Replica) verify(env *pt.SignedRequest) error {
+	if env.Request == nil {
+		return pb.ErrInvalidParam
+	}
+	pub, ok := rep.pubKeys[env.Replica]
+	if !ok {
+		return errNodeID
+	}
+	sig, err := rep.cr.SignatureFromBytes(env.Signature)
+	if err != nil {
+		return err
+	}
+	if !pub.VerifyBytes(pb.Encode(env.Request), sig) {
+		return pb.ErrSign
+	}
+	// 消息中声明的节点必须是签名的节点
+	if replica, ok := requestReplica(env.Request); ok && replica != env.Replica {
+		return pb.ErrSign
+	}
+	return nil
+}
+
+func requestReplica(REQ *pb.Request) (uint32, bool) {
+	switch REQ.Value.(type) {
+	case *pb.Request_Preprepare:
+		return REQ.GetPreprepare().Replica, true
+	case *pb.Request_Prepare:
+		return REQ.GetPrepare().Replica, true
+	case *pb.Request_Commit:
+		return REQ.GetCommit().Replica, true
+	case *pb.Request_Checkpoint:
+		return REQ.GetCheckpoint().Replica, true
+	case *pb.Request_Viewchange:
+		return REQ.GetViewchange().Replica, true
+	case *pb.Request_Ack:
+		return REQ.GetAck().Replica, true
+	case *pb.Request_Newview:
+		return REQ.GetNewview().Replica, true
+	}
+	return 0, false
 }
 
 // Sends
 
-func (rep *Replica) multicast(REQ proto.Message) error {
-	for _, replica := range rep.replicas {
+func (rep *Replica) send(REQ *pb.Request) {
+	rep.requestChan <- REQ
+}
+
+func (rep *Replica) multicast(REQ proto.Message) {
+	for id, replica := range rep.replicas {
 		err := WriteMessage(replica, REQ)
 		if err != nil {
-			return err
+			plog.Debug("write message error", "replica", id, "err", err)
 		}
 	}
-	return nil
 }
 
 func (rep *Replica) sendRoutine() {
 	for REQ := range rep.requestChan {
-		switch REQ.Value.(type) {
-		case *pb.Request_Ack:
-			view := REQ.GetAck().View
-			primaryID := rep.newPrimary(view)
-			primary := rep.replicas[primaryID]
-			if primary == "" {
-				plog.Error("primary not exeist")
-				continue
-			}
-			err := WriteMessage(primary, REQ)
-			if err != nil {
-				go func() {
-					rep.errChan <- err
-				}()
-			}
-		default:
-			err := rep.multicast(REQ)
-
-			if err != nil {
-				go func() {
-					rep.errChan <- err
-				}()
-			}
+		if rep.isStopped() {
+			continue
 		}
+		rep.multicast(rep.sign(REQ))
 	}
 }
 
-func (rep *Replica) allRequests() []*pb.Request {
-	var requests []*pb.Request
-	for _, reqs := range rep.requests {
-		requests = append(requests, reqs...)
+// replyRoutine 在锁外按执行顺序把结果发给客户端, 避免replyChan阻塞时持有锁
+func (rep *Replica) replyRoutine() {
+	for range rep.replyNotify {
+		rep.mu.Lock()
+		pending := rep.pending
+		rep.pending = nil
+		rep.mu.Unlock()
+		for _, reply := range pending {
+			rep.replyChan <- reply
+		}
 	}
-	return requests
 }
 
 // Log
-// !hasRequest before append?
 
 func (rep *Replica) logRequest(REQ *pb.Request) {
 	switch REQ.Value.(type) {
 	case *pb.Request_Client:
-		rep.requests["client"] = append(rep.requests["client"], REQ)
+		rep.clients[string(ReqDigest(REQ))] = REQ
 	case *pb.Request_Preprepare:
 		rep.requests["pre-prepare"] = append(rep.requests["pre-prepare"], REQ)
 	case *pb.Request_Prepare:
@@ -241,29 +377,17 @@ func (rep *Replica) logRequest(REQ *pb.Request) {
 		rep.requests["checkpoint"] = append(rep.requests["checkpoint"], REQ)
 	case *pb.Request_Viewchange:
 		rep.requests["view-change"] = append(rep.requests["view-change"], REQ)
-	case *pb.Request_Ack:
-		rep.requests["ack"] = append(rep.requests["ack"], REQ)
 	case *pb.Request_Newview:
 		rep.requests["new-view"] = append(rep.requests["new-view"], REQ)
 	default:
-		plog.Info("Replica %d tried logging unrecognized request type\n", rep.ID)
-	}
-}
-
-func (rep *Replica) logPendingVC(REQ *pb.Request) error {
-	switch REQ.Value.(type) {
-	case *pb.Request_Viewchange:
-		rep.pendingVC = append(rep.pendingVC, REQ)
-		return nil
-	default:
-		return errors.New("Request is wrong type")
+		plog.Info("tried logging unrecognized request type", "replica", rep.ID)
 	}
 }
 
 func (rep *Replica) logReply(client string, reply *pb.ClientReply) {
 	lastReplyToClient := rep.lastReplyToClient(client)
 	if lastReplyToClient == nil || lastReplyToClient.Timestamp < reply.Timestamp {
-		rep.replies[client] = append(rep.replies[client], reply)
+		rep.replies[client] = []*pb.ClientReply{reply}
 	}
 }
 
@@ -277,12 +401,12 @@ func (rep *Replica) hasRequest(REQ *pb.Request) bool {
 		return rep.hasRequestPrepare(REQ)
 	case *pb.Request_Commit:
 		return rep.hasRequestCommit(REQ)
-	//case *pb.Request_Viewchange:
-	//	return rep.hasRequestViewChange(REQ)
-	//case *pb.Request_Ack:
-	//	return rep.hasRequestAck(REQ)
-	//case *pb.Request_Newview:
-	//	return rep.hasRequestNewView(REQ)
+	case *pb.Request_Checkpoint:
+		return rep.hasRequestCheckpoint(REQ)
+	case *pb.Request_Viewchange:
+		return rep.hasRequestViewChange(REQ)
+	case *pb.Request_Newview:
+		return rep.hasRequestNewView(REQ)
 	default:
 		return false
 	}
@@ -335,169 +459,137 @@ func (rep *Replica) hasRequestCommit(REQ *pb.Request) bool {
 	return false
 }
 
-//func (rep *Replica) hasRequestViewChange(REQ *pb.Request) bool {
-//	view := REQ.GetViewchange().View
-//	replica := REQ.GetViewchange().Replica
-//	for _, req := range rep.requests["view-change"] {
-//		v := req.GetViewchange().View
-//		r := req.GetViewchange().Replica
-//		if v == view && r == replica {
-//			return true
-//		}
-//	}
-//	return false
-//}
-
-//func (rep *Replica) hasRequestAck(REQ *pb.Request) bool {
-//	view := REQ.GetAck().View
-//	replica := REQ.GetAck().Replica
-//	viewchanger := REQ.GetAck().Viewchanger
-//	for _, req := range rep.requests["ack"] {
-//		v := req.GetAck().View
-//		r := req.GetAck().Replica
-//		vc := req.GetAck().Viewchanger
-//		if v == view && r == replica && vc == viewchanger {
-//			return true
-//		}
-//	}
-//	return false
-//}
-
-//func (rep *Replica) hasRequestNewView(REQ *pb.Request) bool {
-//	view := REQ.GetNewview().View
-//	for _, req := range rep.requests["new-view"] {
-//		v := req.GetNewview().View
-//		if v == view {
-//			return true
-//		}
-//	}
-//	return false
-//}
+func (rep *Replica) hasRequestCheckpoint(REQ *pb.Request) bool {
+	sequence := REQ.GetCheckpoint().Sequence
+	replica := REQ.GetCheckpoint().Replica
+	for _, req := range rep.requests["checkpoint"] {
+		s := req.GetCheckpoint().Sequence
+		r := req.GetCheckpoint().Replica
+		if s == sequence && r == replica {
+			return true
+		}
+	}
+	return false
+}
+
+func (rep *Replica) hasRequestViewChange(REQ *pb.Request) bool {
+	view := REQ.GetViewchange().View
+	replica := REQ.GetViewchange().Replica
+	for _, req := range rep.requests["view-change"] {
+		v := req.GetViewchange().View
+		r := req.GetViewchange().Replica
+		if v == view && r == replica {
+			return true
+		}
+	}
+	return false
+}
+
+func (rep *Replica) hasRequestNewView(REQ *pb.Request) bool {
+	view := REQ.GetNewview().View
+	for _, req := range rep.requests["new-view"] {
+		v := req.GetNewview().View
+		if v == view {
+			return true
+		}
+	}
+	return false
+}
 
 // Clear requests
 
-//func (rep *Replica) clearRequestsBySeq(sequence uint32) {
-//	rep.clearRequestClients()
-//	rep.clearRequestPrepreparesBySeq(sequence)
-//	rep.clearRequestPreparesBySeq(sequence)
-//	rep.clearRequestCommitsBySeq(sequence)
-//	//rep.clearRequestCheckpointsBySeq(sequence)
-//}
-
-//func (rep *Replica) clearRequestClients() {
-//	clientReqs := rep.requests["client"]
-//	lastTimestamp := rep.theLastReply().Timestamp
-//	for idx, req := range rep.requests["client"] {
-//		timestamp := req.GetClient().Timestamp
-//		if lastTimestamp >= timestamp && idx < (len(clientReqs)-1) {
-//			clientReqs = append(clientReqs[:idx], clientReqs[idx+1:]...)
-//		} else {
-//			clientReqs = clientReqs[:idx-1]
-//		}
-//	}
-//	rep.requests["client"] = clientReqs
-//}
-//
-//func (rep *Replica) clearRequestPrepreparesBySeq(sequence uint32) {
-//	prePrepares := rep.requests["pre-prepare"]
-//	for idx, req := range rep.requests["pre-prepare"] {
-//		s := req.GetPreprepare().Sequence
-//		if s <= sequence && idx < (len(prePrepares)-1) {
-//			prePrepares = append(prePrepares[:idx], prePrepares[idx+1:]...)
-//		} else {
-//			prePrepares = prePrepares[:idx-1]
-//		}
-//	}
-//	rep.requests["pre-prepare"] = prePrepares
-//}
-//
-//func (rep *Replica) clearRequestPreparesBySeq(sequence uint32) {
-//	prepares := rep.requests["prepare"]
-//	for idx, req := range rep.requests["prepare"] {
-//		s := req.GetPrepare().Sequence
-//		if s <= sequence && idx < (len(prepares)-1) {
-//			prepares = append(prepares[:idx], prepares[idx+1:]...)
-//		} else {
-//			prepares = prepares[:idx-1]
-//		}
-//	}
-//	rep.requests["prepare"] = prepares
-//}
-//
-//func (rep *Replica) clearRequestCommitsBySeq(sequence uint32) {
-//	commits := rep.requests["commit"]
-//	for idx, req := range rep.requests["commit"] {
-//		s := req.GetCommit().Sequence
-//		if s <= sequence && idx < (len(commits)-1) {
-//			commits = append(commits[:idx], commits[idx+1:]...)
-//		} else {
-//			commits = commits[:idx-1]
-//		}
-//	}
-//	rep.requests["commit"] = commits
-//}
-//
-//func (rep *Replica) clearRequestCheckpointsBySeq(sequence uint32) {
-//	checkpoints := rep.requests["checkpoint"]
-//	for idx, req := range rep.requests["checkpoint"] {
-//		s := req.GetCheckpoint().Sequence
-//		if s <= sequence && idx < (len(checkpoints)-1) {
-//			checkpoints = append(checkpoints[:idx], checkpoints[idx+1:]...)
-//		} else {
-//			checkpoints = checkpoints[:idx-1]
-//		}
-//	}
-//	rep.requests["checkpoint"] = checkpoints
-//}
-//
-//func (rep *Replica) clearRequestsByView(view uint32) {
-//	rep.clearRequestPrepreparesByView(view)
-//	rep.clearRequestPreparesByView(view)
-//	rep.clearRequestCommitsByView(view)
-//	//add others
-//}
-//
-//func (rep *Replica) clearRequestPrepreparesByView(view uint32) {
-//	prePrepares := rep.requests["pre-prepare"]
-//	for idx, req := range rep.requests["pre-prepare"] {
-//		v := req.GetPreprepare().View
-//		if v < view {
-//			prePrepares = append(prePrepares[:idx], prePrepares[idx+1:]...)
-//		}
-//	}
-//	rep.requests["pre-prepare"] = prePrepares
-//}
-//
-//func (rep *Replica) clearRequestPreparesByView(view uint32) {
-//	prepares := rep.requests["prepare"]
-//	for idx, req := range rep.requests["prepare"] {
-//		v := req.GetPrepare().View
-//		if v < view {
-//			prepares = append(prepares[:idx], prepares[idx+1:]...)
-//		}
-//	}
-//	rep.requests["prepare"] = prepares
-//}
-//
-//func (rep *Replica) clearRequestCommitsByView(view uint32) {
-//	commits := rep.requests["commit"]
-//	for idx, req := range rep.requests["commit"] {
-//		v := req.GetCommit().View
-//		if v < view {
-//			commits = append(commits[:idx], commits[idx+1:]...)
-//		}
-//	}
-//	rep.requests["commit"] = commits
-//}
+func (rep *Replica) clearRequests(kind string, remove func(req *pb.Request) bool) {
+	var reqs []*pb.Request
+	for _, req := range rep.requests[kind] {
+		if !remove(req) {
+			reqs = append(reqs, req)
+		}
+	}
+	rep.requests[kind] = reqs
+}
+
+func (rep *Replica) clearRequestsBySeq(sequence uint32) {
+	rep.clearRequests("pre-prepare", func(req *pb.Request) bool {
+		return req.GetPreprepare().Sequence <= sequence
+	})
+	rep.clearRequests("prepare", func(req *pb.Request) bool {
+		return req.GetPrepare().Sequence <= sequence
+	})
+	rep.clearRequests("commit", func(req *pb.Request) bool {
+		return req.GetCommit().Sequence <= sequence
+	})
+	rep.clearRequests("checkpoint", func(req *pb.Request) bool {
+		return req.GetCheckpoint().Sequence < sequence
+	})
+}
+
+func (rep *Replica) clearRequestsByView(view uint32) {
+	rep.clearRequests("pre-prepare", func(req *pb.Request) bool {
+		return req.GetPreprepare().View < view
+	})
+	rep.clearRequests("prepare", func(req *pb.Request) bool {
+		return req.GetPrepare().View < view
+	})
+	rep.clearRequests("commit", func(req *pb.Request) bool {
+		return req.GetCommit().View < view
+	})
+	rep.clearRequests("view-change", func(req *pb.Request) bool {
+		return req.GetViewchange().View < view
+	})
+	rep.clearRequests("new-view", func(req *pb.Request) bool {
+		return req.GetNewview().View < view
+	})
+}
+
+// Timer
+
+func (rep *Replica) startTimer() {
+	if rep.timer != nil {
+		return
+	}
+	timeout := rep.timeout
+	if !rep.activeView {
+		// 新视图迟迟没有建立时, 等待更长的时间再切换到下一个视图
+		timeout *= 2
+	}
+	rep.timerSeq++
+	seq := rep.timerSeq
+	rep.timer = time.AfterFunc(timeout, func() {
+		rep.handleTimeout(seq)
+	})
+}
+
+func (rep *Replica) stopTimer() {
+	if rep.timer != nil {
+		rep.timer.Stop()
+		rep.timer = nil
+	}
+}
+
+func (rep *Replica) handleTimeout(seq uint64) {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	if rep.stopped || seq != rep.timerSeq {
+		return
+	}
+	rep.timer = nil
+	plog.Info("view change timeout", "replica", rep.ID, "view", rep.view)
+	rep.requestViewChange(rep.view + 1)
+}
 
 // Handle requests
 
-func (rep *Replica) handleRequest(REQ *pb.Request) {
+func (rep *Replica) handleRequest(REQ *pb.Request, sender uint32) {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	if rep.stopped {
+		return
+	}
 
 	switch REQ.Value.(type) {
 	case *pb.Request_Client:
 
-		rep.handleRequestClient(REQ)
+		rep.handleRequestClient(REQ, sender)
 
 	case *pb.Request_Preprepare:
 
@@ -511,1096 +603,574 @@ func (rep *Replica) handleRequest(REQ *pb.Request) {
 
 		rep.handleRequestCommit(REQ)
 
-	//case *pb.Request_Checkpoint:
-	//
-	//	rep.handleRequestCheckpoint(REQ)
-	//
-	//case *pb.Request_Viewchange:
-	//
-	//	rep.handleRequestViewChange(REQ)
-	//
-	//case *pb.Request_Ack:
-	//
-	//	rep.handleRequestAck(REQ)
+	case *pb.Request_Checkpoint:
+
+		rep.handleRequestCheckpoint(REQ)
+
+	case *pb.Request_Viewchange:
+
+		rep.handleRequestViewChange(REQ)
+
+	case *pb.Request_Newview:
+
+		rep.handleRequestNewView(REQ)
 
 	default:
-		plog.Info("Replica %d received unrecognized request type\n", rep.ID)
+		plog.Info("received unrecognized request type", "replica", rep.ID)
 	}
 }
 
-func (rep *Replica) handleRequestClient(REQ *pb.Request) {
-	rep.sequence++
+func (rep *Replica) handleRequestClient(REQ *pb.Request, sender uint32) {
+	// 客户端请求由主节点广播
+	if !rep.activeView || sender != rep.primary() {
+		return
+	}
 	client := REQ.GetClient().Client
 	timestamp := REQ.GetClient().Timestamp
 	lastReplyToClient := rep.lastReplyToClient(client)
-	if lastReplyToClient != nil {
-		if lastReplyToClient.Timestamp == timestamp {
-			reply := ToReply(rep.view, timestamp, client, rep.ID, lastReplyToClient.Result)
-			rep.logReply(client, reply)
-			go func() {
-				rep.replyChan <- reply
-			}()
-			return
-		}
+	if lastReplyToClient != nil && lastReplyToClient.Timestamp == timestamp {
+		return
 	}
-	rep.logRequest(REQ)
-	if !rep.isPrimary(rep.ID) {
+	digest := ReqDigest(REQ)
+	if _, ok := rep.clients[string(digest)]; ok {
 		return
 	}
-	req := ToRequestPreprepare(rep.view, rep.sequence, ReqDigest(REQ), rep.ID)
-	rep.logRequest(req)
-	plog.Info("Client-request done")
-	go func() {
-		rep.requestChan <- req
-	}()
+	rep.logRequest(REQ)
+	rep.startTimer()
+	if rep.isPrimary(rep.ID) {
+		sequence := rep.sequence + 1
+		if !rep.sequenceInRange(sequence) {
+			plog.Error("sequence out of range", "sequence", sequence, "low", rep.lowWaterMark())
+			return
+		}
+		rep.sequence = sequence
+		req := ToRequestPreprepare(rep.view, sequence, digest, rep.ID)
+		rep.logRequest(req)
+		rep.send(req)
+		prepare := ToRequestPrepare(rep.view, sequence, digest, rep.ID)
+		rep.logRequest(prepare)
+		rep.send(prepare)
+		plog.Info("Client-request done", "sequence", sequence)
+		rep.tryCommit(rep.view, sequence)
+	}
+	rep.tryExecute()
 }
 
 func (rep *Replica) handleRequestPreprepare(REQ *pb.Request) {
-	replica := REQ.GetPreprepare().Replica
-	if !rep.isPrimary(replica) {
-		return
-	}
-	view := REQ.GetPreprepare().View
-	if rep.view != view {
+	preprepare := REQ.GetPreprepare()
+	if !rep.activeView || preprepare.View != rep.view || !rep.isPrimary(preprepare.Replica) {
 		return
 	}
-	sequence := REQ.GetPreprepare().Sequence
+	view := preprepare.View
+	sequence := preprepare.Sequence
 	if !rep.sequenceInRange(sequence) {
 		return
 	}
-	digest := REQ.GetPreprepare().Digest
-	accept := true
-	for _, req := range rep.requests["pre-prepare"] {
-		v := req.GetPreprepare().View
-		s := req.GetPreprepare().Sequence
-		d := req.GetPreprepare().Digest
-		if v == view && s == sequence && !EQ(d, digest) {
-			accept = false
-			break
+	if digest, ok := rep.preprepareDigest(view, sequence); ok {
+		if !EQ(digest, preprepare.Digest) {
+			plog.Error("conflicting pre-prepare", "view", view, "sequence", sequence)
 		}
-	}
-	if !accept {
 		return
 	}
 	rep.logRequest(REQ)
-	plog.Info("pre-prepare done")
-	req := ToRequestPrepare(view, sequence, digest, rep.ID)
-	if rep.hasRequest(req) {
-		return
-	}
+	plog.Info("pre-prepare done", "sequence", sequence)
+	req := ToRequestPrepare(view, sequence, preprepare.Digest, rep.ID)
 	rep.logRequest(req)
-	go func() {
-		rep.requestChan <- req
-	}()
+	rep.send(req)
+	rep.tryCommit(view, sequence)
 }
 
 func (rep *Replica) handleRequestPrepare(REQ *pb.Request) {
-
-	replica := REQ.GetPrepare().Replica
-
-	//if rep.isPrimary(replica) {
-	//return
-	//}
-
+	// 新视图中的prepare可能先于new-view消息到达, 先记录下来
 	view := REQ.GetPrepare().View
-
-	if rep.view != view {
+	sequence := REQ.GetPrepare().Sequence
+	if view < rep.view || !rep.sequenceInRange(sequence) || rep.hasRequest(REQ) {
 		return
 	}
+	rep.logRequest(REQ)
+	rep.tryCommit(view, sequence)
+}
 
-	sequence := REQ.GetPrepare().Sequence
-
-	if !rep.sequenceInRange(sequence) {
+func (rep *Replica) handleRequestCommit(REQ *pb.Request) {
+	view := REQ.GetCommit().View
+	sequence := REQ.GetCommit().Sequence
+	if view < rep.view || !rep.sequenceInRange(sequence) || rep.hasRequest(REQ) {
 		return
 	}
+	rep.logRequest(REQ)
+	rep.tryExecute()
+}
 
-	digest := REQ.GetPrepare().Digest
-
+func (rep *Replica) handleRequestCheckpoint(REQ *pb.Request) {
+	sequence := REQ.GetCheckpoint().Sequence
+	if sequence <= rep.lowWaterMark() || rep.hasRequest(REQ) {
+		return
+	}
 	rep.logRequest(REQ)
 
-	//prePrepared := make(chan bool, 1)
-	twoThirds := make(chan bool, 1)
+	digest := REQ.GetCheckpoint().Digest
+	replicas := make(map[uint32]bool)
+	for _, req := range rep.requests["checkpoint"] {
+		s := req.GetCheckpoint().Sequence
+		d := req.GetCheckpoint().Digest
+		if s == sequence && EQ(d, digest) {
+			replicas[req.GetCheckpoint().Replica] = true
+		}
+	}
+	if !rep.overTwoThirds(len(replicas)) {
+		return
+	}
+	rep.addCheckpoint(ToCheckpoint(sequence, digest))
+	// 落后的节点跳过已经稳定的请求, 区块通过同步获取
+	if sequence > rep.lastExecuted() {
+		rep.setExecuted(sequence)
+		rep.lastDigest = digest
+	}
+	if rep.sequence < sequence {
+		rep.sequence = sequence
+	}
+	rep.clearRequestsBySeq(sequence)
+	plog.Info("checkpoint and clear request done", "sequence", sequence)
+	rep.tryExecute()
+}
 
-	//go func() {
-	//prePrepared <- rep.hasRequest(REQ)
-	//}()
+func (rep *Replica) handleRequestViewChange(REQ *pb.Request) {
+	reqViewChange := REQ.GetViewchange()
+	view := reqViewChange.View
+	if view < rep.view || (view == rep.view && rep.activeView) {
+		return
+	}
+	for _, prep := range reqViewChange.GetPreps() {
+		if prep.View >= view {
+			return
+		}
+	}
+	for _, prePrep := range reqViewChange.GetPrepreps() {
+		if prePrep.View >= view {
+			return
+		}
+	}
+	if rep.hasRequest(REQ) {
+		return
+	}
+	rep.logRequest(REQ)
 
-	go func() {
-		count := 0
-		for _, req := range rep.requests["prepare"] {
-			v := req.GetPrepare().View
-			s := req.GetPrepare().Sequence
-			d := req.GetPrepare().Digest
-			r := req.GetPrepare().Replica
-			if v != view || s != sequence || !EQ(d, digest) {
+	if view > rep.view {
+		// 超过1/3的节点要求切换到更高的视图时, 说明主节点确实出错, 跟随切换
+		replicas := make(map[uint32]bool)
+		minView := view
+		for _, req := range rep.requests["view-change"] {
+			v := req.GetViewchange().View
+			if v <= rep.view {
 				continue
 			}
-			if r == replica {
-				plog.Debug("multiple prepare requests", "Replica ", replica, " sent multiple prepare requests")
-				//continue
+			replicas[req.GetViewchange().Replica] = true
+			if v < minView {
+				minView = v
 			}
-			count++
-			if rep.overTwoThirds(count) {
-				twoThirds <- true
-				return
-			}
-			twoThirds <- false
 		}
-	}()
+		if rep.overOneThird(len(replicas)) {
+			rep.requestViewChange(minView)
+		}
+	}
+	rep.checkNewView(view)
+	if rep.pendingNV != nil && rep.pendingNV.GetNewview().View >= rep.view {
+		rep.processNewView(rep.pendingNV)
+	}
+}
 
-	if !<-twoThirds {
+func (rep *Replica) handleRequestNewView(REQ *pb.Request) {
+	view := REQ.GetNewview().View
+	if view < rep.view || (view == rep.view && rep.activeView) {
 		return
 	}
-
-	req := ToRequestCommit(view, sequence, rep.ID)
-	if rep.hasRequest(req) {
+	if REQ.GetNewview().Replica != rep.newPrimary(view) {
 		return
 	}
-
-	rep.logRequest(req)
-	plog.Info("prepare done")
-	go func() {
-		rep.requestChan <- req
-	}()
+	if rep.hasRequest(REQ) {
+		return
+	}
+	rep.logRequest(REQ)
+	// 缺少view-change消息时先保存, 收到之后再处理
+	if !rep.processNewView(REQ) {
+		rep.pendingNV = REQ
+	}
 }
 
-func (rep *Replica) handleRequestCommit(REQ *pb.Request) {
+// Execute
 
-	view := REQ.GetCommit().View
+func (rep *Replica) preprepareDigest(view, sequence uint32) ([]byte, bool) {
+	for _, req := range rep.requests["pre-prepare"] {
+		v := req.GetPreprepare().View
+		s := req.GetPreprepare().Sequence
+		if v == view && s == sequence {
+			return req.GetPreprepare().Digest, true
+		}
+	}
+	return nil, false
+}
 
-	if rep.view != view {
-		return
+func (rep *Replica) prepared(view, sequence uint32) ([]byte, bool) {
+	digest, ok := rep.preprepareDigest(view, sequence)
+	if !ok {
+		return nil, false
+	}
+	replicas := make(map[uint32]bool)
+	for _, req := range rep.requests["prepare"] {
+		v := req.GetPrepare().View
+		s := req.GetPrepare().Sequence
+		d := req.GetPrepare().Digest
+		if v == view && s == sequence && EQ(d, digest) {
+			replicas[req.GetPrepare().Replica] = true
+		}
 	}
+	return digest, rep.overTwoThirds(len(replicas))
+}
 
-	sequence := REQ.GetCommit().Sequence
+func (rep *Replica) committed(view, sequence uint32) ([]byte, bool) {
+	digest, ok := rep.prepared(view, sequence)
+	if !ok {
+		return nil, false
+	}
+	replicas := make(map[uint32]bool)
+	for _, req := range rep.requests["commit"] {
+		v := req.GetCommit().View
+		s := req.GetCommit().Sequence
+		if v == view && s == sequence {
+			replicas[req.GetCommit().Replica] = true
+		}
+	}
+	return digest, rep.overTwoThirds(len(replicas))
+}
 
-	if !rep.sequenceInRange(sequence) {
+func (rep *Replica) tryCommit(view, sequence uint32) {
+	if view != rep.view {
 		return
 	}
+	if _, ok := rep.prepared(view, sequence); !ok {
+		return
+	}
+	req := ToRequestCommit(view, sequence, rep.ID)
+	if rep.hasRequest(req) {
+		return
+	}
+	rep.logRequest(req)
+	plog.Info("prepare done", "sequence", sequence)
+	rep.send(req)
+	rep.tryExecute()
+}
 
-	replica := REQ.GetCommit().Replica
-
-	rep.logRequest(REQ)
-	twoThirds := make(chan bool, 1)
-	go func() {
-		count := 0
-		for _, req := range rep.requests["commit"] {
-			v := req.GetCommit().View
-			s := req.GetCommit().Sequence
-			rr := req.GetCommit().Replica
-			if v != view || s != sequence {
-				continue
-			}
-			if rr == replica {
-				plog.Debug("multiple commit requests", "Replica ", replica, " sent multiple commit requests")
-				//continue
-			}
-			count++
-			if rep.overTwoThirds(count) {
-				twoThirds <- true
-				return
-			}
-		}
-		twoThirds <- false
-	}()
-	if !<-twoThirds {
+// tryExecute 按序号顺序执行已经提交的请求
+func (rep *Replica) tryExecute() {
+	if !rep.activeView {
 		return
 	}
-	//log.Println(count)
-	//log.Println("overtwothirds done")
-	var digest []byte
-	digests := make(map[string]struct{})
-	for _, req := range rep.requests["pre-prepare"] {
-		v := req.GetPreprepare().View
-		s := req.GetPreprepare().Sequence
-		if v == view && s <= sequence {
-			digests[string(req.GetPreprepare().Digest)] = struct{}{}
-			//log.Println(string(req.Digest()))
-			if s == sequence {
-				//log.Println("break done ")
-				digest = req.GetPreprepare().Digest
+	progress := false
+	for {
+		sequence := rep.lastExecuted() + 1
+		digest, ok := rep.committed(rep.view, sequence)
+		if !ok {
+			break
+		}
+		var REQ *pb.Request
+		if len(digest) > 0 {
+			// 空请求直接跳过, 否则等待主节点广播的客户端请求
+			if REQ = rep.clients[string(digest)]; REQ == nil {
 				break
 			}
 		}
+		rep.execute(sequence, digest, REQ)
+		progress = true
+	}
+	if progress {
+		rep.suspected = false
+		rep.stopTimer()
+		if len(rep.clients) > 0 {
+			rep.startTimer()
+		}
 	}
-	for _, req := range rep.requests["client"] {
-		d := ReqDigest(req)
+}
+
+func (rep *Replica) execute(sequence uint32, digest []byte, REQ *pb.Request) {
+	rep.setExecuted(sequence)
+	if REQ != nil {
+		delete(rep.clients, string(digest))
+		op := REQ.GetClient().Op
+		timestamp := REQ.GetClient().Timestamp
+		client := REQ.GetClient().Client
+		result := &pb.Result{Value: op.Value}
+		reply := ToReply(rep.view, timestamp, client, rep.ID, result)
+		rep.logReply(client, reply)
+		rep.lastDigest = digest
+		plog.Info("commit done", "sequence", sequence)
+		rep.pending = append(rep.pending, proto.Clone(reply).(*pb.ClientReply))
+		select {
+		case rep.replyNotify <- struct{}{}:
+		default:
+		}
+	}
+	if !rep.isCheckpoint(sequence) {
+		return
+	}
+	req := ToRequestCheckpoint(sequence, rep.stateDigest(), rep.ID)
+	rep.send(req)
+}
 
-		if _, exists := digests[string(d)]; !exists {
+// View change
 
+// prepBySequence 返回本节点在该序号上最高视图的 prepared 记录和 pre-prepare 记录
+func (rep *Replica) prepBySequence(sequence uint32) (prep *pb.Entry, prePrep *pb.Entry) {
+	for _, req := range rep.requests["pre-prepare"] {
+		v := req.GetPreprepare().View
+		s := req.GetPreprepare().Sequence
+		if s != sequence {
 			continue
 		}
-		if !EQ(d, digest) {
+		digest := req.GetPreprepare().Digest
+		if prePrep == nil || v > prePrep.View {
+			prePrep = ToEntry(sequence, digest, v)
+		}
+		if _, ok := rep.prepared(v, sequence); ok && (prep == nil || v > prep.View) {
+			prep = ToEntry(sequence, digest, v)
+		}
+	}
+	return prep, prePrep
+}
 
-			continue
+func (rep *Replica) requestViewChange(view uint32) {
+	if view <= rep.view {
+		return
+	}
+	rep.view = view
+	rep.activeView = false
+
+	var preps, prePreps []*pb.Entry
+	for s := rep.lowWaterMark() + 1; s <= rep.highWaterMark(); s++ {
+		prep, prePrep := rep.prepBySequence(s)
+		if prep != nil {
+			preps = append(preps, prep)
+		}
+		if prePrep != nil {
+			prePreps = append(prePreps, prePrep)
 		}
+	}
+	checkpoints := []*pb.Checkpoint{rep.lastStable()}
+	req := ToRequestViewChange(view, rep.lowWaterMark(), checkpoints, preps, prePreps, rep.ID)
+	rep.logRequest(req)
+	rep.send(req)
+	plog.Info("request view change", "replica", rep.ID, "view", view)
+
+	rep.clearRequestsByView(view)
+	// 新视图在超时之前没有建立时, 继续切换到下一个视图
+	rep.stopTimer()
+	rep.startTimer()
+	rep.checkNewView(view)
+}
 
-		op := req.GetClient().Op
-		timestamp := req.GetClient().Timestamp
-		client := req.GetClient().Client
-		result := &pb.Result{Value: op.Value}
+// viewChanges 每个节点在该视图上的view-change消息, 按节点编号排序
+func (rep *Replica) viewChanges(view uint32) []*pb.Request {
+	var requests []*pb.Request
+	for _, req := range rep.requests["view-change"] {
+		if req.GetViewchange().View == view {
+			requests = append(requests, req)
+		}
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].GetViewchange().Replica < requests[j].GetViewchange().Replica
+	})
+	return requests
+}
 
-		rep.executed = append(rep.executed, sequence)
-		reply := ToReply(view, timestamp, client, rep.ID, result)
+func (rep *Replica) checkNewView(view uint32) {
+	if view != rep.view || rep.activeView || rep.newPrimary(view) != rep.ID {
+		return
+	}
+	requests := rep.viewChanges(view)
+	if !rep.overTwoThirds(len(requests)) {
+		return
+	}
+	rep.requestNewView(view, requests)
+}
 
-		rep.logReply(client, reply)
-		plog.Info("commit done")
-		rep.lastReply = reply
+func (rep *Replica) requestNewView(view uint32, requests []*pb.Request) {
+	req := rep.createNewView(view, requests)
+	if rep.hasRequest(req) {
+		return
+	}
+	rep.logRequest(req)
+	rep.send(req)
+	plog.Info("request new view", "replica", rep.ID, "view", view)
+	rep.processNewView(req)
+}
 
-		go func() {
-			rep.replyChan <- reply
-		}()
+func (rep *Replica) createNewView(view uint32, requests []*pb.Request) *pb.Request {
+	var viewChanges []*pb.ViewChange
+	for _, req := range requests {
+		viewChanges = append(viewChanges, ToViewChange(req.GetViewchange().Replica, ReqDigest(req)))
+	}
+	return ToRequestNewView(view, viewChanges, rep.summaries(requests), rep.ID)
+}
 
-		if !rep.isCheckpoint(sequence) {
-			return
+// summaries 根据view-change消息计算新视图需要重新处理的请求, 第一项是稳定检查点
+func (rep *Replica) summaries(requests []*pb.Request) []*pb.Summary {
+	var start, end uint32
+	var digest []byte
+	for _, req := range requests {
+		reqViewChange := req.GetViewchange()
+		if reqViewChange.Sequence > start {
+			start = reqViewChange.Sequence
+			digest = nil
+		}
+		if reqViewChange.Sequence == start && digest == nil {
+			for _, checkpoint := range reqViewChange.GetCheckpoints() {
+				if checkpoint.Sequence == start {
+					digest = checkpoint.Digest
+				}
+			}
+		}
+		for _, prep := range reqViewChange.GetPreps() {
+			if prep.Sequence > end {
+				end = prep.Sequence
+			}
 		}
-		stateDigest := rep.stateDigest()
-		req := ToRequestCheckpoint(sequence, stateDigest, rep.ID)
-		rep.logRequest(req)
-
-		go func() {
-			rep.requestChan <- req
-		}()
-		return
 	}
-
+	summaries := []*pb.Summary{ToSummary(start, digest)}
+	for s := start + 1; s <= end; s++ {
+		summaries = append(summaries, ToSummary(s, rep.selectDigest(requests, s)))
+	}
+	return summaries
 }
 
-//func (rep *Replica) handleRequestCheckpoint(REQ *pb.Request) {
-//
-//	sequence := REQ.GetCheckpoint().Sequence
-//
-//	if !rep.sequenceInRange(sequence) {
-//		return
-//	}
-//
-//	digest := REQ.GetCheckpoint().Digest
-//	replica := REQ.GetCheckpoint().Replica
-//
-//	count := 0
-//	for _, req := range rep.requests["checkpoint"] {
-//		s := req.GetCheckpoint().Sequence
-//		d := req.GetCheckpoint().Digest
-//		r := req.GetCheckpoint().Replica
-//		if s != sequence || !EQ(d, digest) {
-//			continue
-//		}
-//		if r == replica {
-//			plog.Info("Replica %d sent multiple checkpoint requests\n", replica)
-//			//continue
-//		}
-//		count++
-//		if !rep.overTwoThirds(count) {
-//			continue
-//		}
-//		// rep.clearEntries(sequence)
-//		rep.clearRequestsBySeq(sequence)
-//		checkpoint := ToCheckpoint(sequence, digest)
-//		rep.addCheckpoint(checkpoint)
-//		plog.Info("checkpoint and clear request done")
-//		return
-//	}
-//
-//}
-
-//func (rep *Replica) handleRequestViewChange(REQ *pb.Request) {
-//
-//	view := REQ.GetViewchange().View
-//
-//	if view < rep.view {
-//		return
-//	}
-//
-//	reqViewChange := REQ.GetViewchange()
-//
-//	for _, prep := range reqViewChange.GetPreps() {
-//		v := prep.View
-//		s := prep.Sequence
-//		if v >= view || !rep.sequenceInRange(s) {
-//			return
-//		}
-//	}
-//
-//	for _, prePrep := range reqViewChange.GetPrepreps() {
-//		v := prePrep.View
-//		s := prePrep.Sequence
-//		if v >= view || !rep.sequenceInRange(s) {
-//			return
-//		}
-//	}
-//
-//	for _, checkpoint := range reqViewChange.GetCheckpoints() {
-//		s := checkpoint.Sequence
-//		if !rep.sequenceInRange(s) {
-//			return
-//		}
-//	}
-//
-//	if rep.hasRequest(REQ) {
-//		return
-//	}
-//
-//	rep.logRequest(REQ)
-//
-//	viewchanger := reqViewChange.Replica
-//
-//	req := ToRequestAck(
-//		view,
-//		rep.ID,
-//		viewchanger,
-//		ReqDigest(REQ))
-//
-//	go func() {
-//		rep.requestChan <- req
-//	}()
-//}
-
-//func (rep *Replica) handleRequestAck(REQ *pb.Request) {
-//
-//	view := REQ.GetAck().View
-//	primaryID := rep.newPrimary(view)
-//
-//	if rep.ID != primaryID {
-//		return
-//	}
-//
-//	if rep.hasRequest(REQ) {
-//		return
-//	}
-//
-//	rep.logRequest(REQ)
-//
-//	replica := REQ.GetAck().Replica
-//	viewchanger := REQ.GetAck().Viewchanger
-//	digest := REQ.GetAck().Digest
-//
-//	reqViewChange := make(chan *pb.Request, 1)
-//	twoThirds := make(chan bool, 1)
-//
-//	go func() {
-//		for _, req := range rep.requests["view-change"] {
-//			v := req.GetViewchange().View
-//			vc := req.GetViewchange().Replica
-//			if v == view && vc == viewchanger {
-//				reqViewChange <- req
-//			}
-//		}
-//		reqViewChange <- nil
-//	}()
-//
-//	go func() {
-//		count := 0
-//		for _, req := range rep.requests["ack"] {
-//			v := req.GetAck().View
-//			r := req.GetAck().Replica
-//			vc := req.GetAck().Viewchanger
-//			d := req.GetAck().Digest
-//			if v != view || vc != viewchanger || !EQ(d, digest) {
-//				continue
-//			}
-//			if r == replica {
-//				plog.Info("Replica %d sent multiple ack requests\n", replica)
-//				continue
-//			}
-//			count++
-//			if rep.twoThirds(count) {
-//				twoThirds <- true
-//				return
-//			}
-//		}
-//		twoThirds <- false
-//	}()
-//
-//	req := <-reqViewChange
-//
-//	if req == nil || !<-twoThirds {
-//		return
-//	}
-//
-//	rep.logPendingVC(req)
-//
-//	// When to send new view?
-//	rep.requestNewView(view)
-//}
-
-//func (rep *Replica) handleRequestNewView(REQ *pb.Request) {
-//
-//	view := REQ.GetNewview().View
-//
-//	if view == 0 || view < rep.view {
-//		return
-//	}
-//
-//	replica := REQ.GetNewview().Replica
-//	primary := rep.newPrimary(view)
-//
-//	if replica != primary {
-//		return
-//	}
-//
-//	if rep.hasRequest(REQ) {
-//		return
-//	}
-//
-//	rep.logRequest(REQ)
-//
-//	rep.processNewView(REQ)
-//}
-
-//func (rep *Replica) correctViewChanges(viewChanges []*pb.ViewChange) (requests []*pb.Request) {
-//
-//	// Returns requests if correct, else returns nil
-//
-//	valid := false
-//	for _, vc := range viewChanges {
-//		for _, req := range rep.requests["view-change"] {
-//			d := ReqDigest(req)
-//			if !EQ(d, vc.Digest) {
-//				continue
-//			}
-//			requests = append(requests, req)
-//			v := req.GetViewchange().View
-//			// VIEW or rep.view??
-//			if v == rep.view {
-//				valid = true
-//				break
-//			}
-//		}
-//		if !valid {
-//			return nil
-//		}
-//	}
-//
-//	if rep.isPrimary(rep.ID) {
-//		reps := make(map[uint32]int)
-//		valid = false
-//		for _, req := range rep.requests["ack"] {
-//			reqAck := req.GetAck()
-//			reps[reqAck.Replica]++
-//			if rep.twoThirds(reps[reqAck.Replica]) { //-2
-//				valid = true
-//				break
-//			}
-//		}
-//		if !valid {
-//			return nil
-//		}
-//	}
-//
-//	return
-//}
-
-//func (rep *Replica) correctSummaries(requests []*pb.Request, summaries []*pb.Summary) (correct bool) {
-//
-//	// Verify SUMMARIES
-//
-//	var start uint32
-//	var digest []byte
-//	digests := make(map[uint32][]byte)
-//
-//	for _, summary := range summaries {
-//		s := summary.Sequence
-//		d := summary.Digest
-//		if _d, ok := digests[s]; ok && !EQ(_d, d) {
-//			return
-//		} else if !ok {
-//			digests[s] = d
-//		}
-//		if s < start || start == uint32(0) {
-//			start = s
-//			digest = d
-//		}
-//	}
-//
-//	var A1 []*pb.Request
-//	var A2 []*pb.Request
-//
-//	valid := false
-//	for _, req := range requests {
-//		reqViewChange := req.GetViewchange()
-//		s := reqViewChange.Sequence
-//		if s <= start {
-//			A1 = append(A1, req)
-//		}
-//		checkpoints := reqViewChange.GetCheckpoints()
-//		for _, checkpoint := range checkpoints {
-//			if checkpoint.Sequence == start && EQ(checkpoint.Digest, digest) {
-//				A2 = append(A2, req)
-//				break
-//			}
-//		}
-//		if rep.twoThirds(len(A1)) && rep.oneThird(len(A2)) {
-//			valid = true
-//			break
-//		}
-//	}
-//
-//	if !valid {
-//		return
-//	}
-//
-//	end := start + CheckPointPeriod*ConstantFactor
-//
-//	for seq := start; seq <= end; seq++ {
-//
-//		valid = false
-//
-//		for _, summary := range summaries {
-//
-//			if summary.Sequence != seq {
-//				continue
-//			}
-//
-//			if summary.Digest != nil {
-//
-//				var view uint32
-//
-//				for _, req := range requests {
-//					reqViewChange := req.GetViewchange()
-//					preps := reqViewChange.GetPreps()
-//					for _, prep := range preps {
-//						s := prep.Sequence
-//						d := prep.Digest
-//						if s != summary.Sequence || !EQ(d, summary.Digest) {
-//							continue
-//						}
-//						v := prep.View
-//						if v > view {
-//							view = v
-//						}
-//					}
-//				}
-//
-//				verifiedA1 := make(chan bool, 1)
-//
-//				// Verify A1
-//				go func() {
-//
-//					var A1 []*pb.Request
-//
-//				FOR_LOOP:
-//					for _, req := range requests {
-//						reqViewChange := req.GetViewchange()
-//						s := reqViewChange.Sequence
-//						if s >= summary.Sequence {
-//							continue
-//						}
-//						preps := reqViewChange.GetPreps()
-//						for _, prep := range preps {
-//							s = prep.Sequence
-//							if s != summary.Sequence {
-//								continue
-//							}
-//							d := prep.Digest
-//							v := prep.View
-//							if v > view || (v == view && !EQ(d, summary.Digest)) {
-//								continue FOR_LOOP
-//							}
-//						}
-//						A1 = append(A1, req)
-//						if rep.twoThirds(len(A1)) {
-//							verifiedA1 <- true
-//							return
-//						}
-//					}
-//					verifiedA1 <- false
-//				}()
-//
-//				verifiedA2 := make(chan bool, 1)
-//
-//				// Verify A2
-//				go func() {
-//
-//					var A2 []*pb.Request
-//
-//					for _, req := range requests {
-//						reqViewChange := req.GetViewchange()
-//						prePreps := reqViewChange.GetPrepreps()
-//						for _, prePrep := range prePreps {
-//							s := prePrep.Sequence
-//							d := prePrep.Digest
-//							v := prePrep.View
-//							if s == summary.Sequence && EQ(d, summary.Digest) && v >= view {
-//								A2 = append(A2, req)
-//								break
-//							}
-//						}
-//						if rep.oneThird(len(A2)) {
-//							verifiedA2 <- true
-//							return
-//						}
-//					}
-//					verifiedA2 <- false
-//				}()
-//
-//				if !<-verifiedA1 || !<-verifiedA2 {
-//					continue
-//				}
-//
-//				valid = true
-//				break
-//
-//			} else {
-//
-//				var A1 []*pb.Request
-//
-//			FOR_LOOP:
-//
-//				for _, req := range requests {
-//
-//					reqViewChange := req.GetViewchange()
-//
-//					s := reqViewChange.Sequence
-//
-//					if s >= summary.Sequence {
-//						continue
-//					}
-//
-//					preps := reqViewChange.GetPreps()
-//					for _, prep := range preps {
-//						if prep.Sequence == summary.Sequence {
-//							continue FOR_LOOP
-//						}
-//					}
-//
-//					A1 = append(A1, req)
-//					if rep.twoThirds(len(A1)) {
-//						valid = true
-//						break
-//					}
-//				}
-//				if valid {
-//					break
-//				}
-//			}
-//		}
-//		if !valid {
-//			return
-//		}
-//	}
-//
-//	return true
-//}
-
-//func (rep *Replica) processNewView(REQ *pb.Request) (success bool) {
-//
-//	if rep.activeView {
-//		return
-//	}
-//
-//	reqNewView := REQ.GetNewview()
-//
-//	viewChanges := reqNewView.GetViewchanges()
-//	requests := rep.correctViewChanges(viewChanges)
-//
-//	if requests == nil {
-//		return
-//	}
-//
-//	summaries := reqNewView.GetSummaries()
-//	correct := rep.correctSummaries(requests, summaries)
-//
-//	if !correct {
-//		return
-//	}
-//
-//	var h uint32
-//	for _, checkpoint := range rep.checkpoints {
-//		if checkpoint.Sequence < h || h == uint32(0) {
-//			h = checkpoint.Sequence
-//		}
-//	}
-//
-//	var s uint32
-//	for _, summary := range summaries {
-//		if summary.Sequence < s || s == uint32(0) {
-//			s = summary.Sequence
-//		}
-//		if summary.Sequence > h {
-//			valid := false
-//			for _, req := range rep.requests["view-change"] { //in
-//				if EQ(ReqDigest(req), summary.Digest) {
-//					valid = true
-//					break
-//				}
-//			}
-//			if !valid {
-//				return
-//			}
-//		}
-//	}
-//
-//	if h < s {
-//		return
-//	}
-//
-//	// Process new view
-//	rep.activeView = true
-//
-//	for _, summary := range summaries {
-//
-//		if rep.ID != reqNewView.Replica {
-//			req := ToRequestPrepare(
-//				reqNewView.View,
-//				summary.Sequence,
-//				summary.Digest,
-//				rep.ID) // the backup sends/logs prepare
-//
-//			go func() {
-//				if !rep.hasRequest(req) {
-//					rep.requestChan <- req
-//				}
-//			}()
-//			if summary.Sequence <= h {
-//				continue
-//			}
-//
-//			if !rep.hasRequest(req) {
-//				rep.logRequest(req)
-//			}
-//		} else {
-//			if summary.Sequence <= h {
-//				break
-//			}
-//		}
-//
-//		req := ToRequestPreprepare(
-//			reqNewView.View,
-//			summary.Sequence,
-//			summary.Digest,
-//			reqNewView.Replica) // new primary pre-prepares
-//
-//		if !rep.hasRequest(req) {
-//			rep.logRequest(req)
-//		}
-//	}
-//
-//	var maxSequence uint32
-//	for _, req := range rep.requests["pre-prepare"] {
-//		reqPrePrepare := req.GetPreprepare()
-//		if reqPrePrepare.Sequence > maxSequence {
-//			maxSequence = reqPrePrepare.Sequence
-//		}
-//	}
-//	rep.sequence = maxSequence
-//	return true
-//}
-
-//func (rep *Replica) prePrepBySequence(sequence uint32) []*pb.Entry {
-//	var view uint32
-//	var requests []*pb.Request
-//	for _, req := range rep.requests["pre-prepare"] {
-//		v := req.GetPreprepare().View
-//		s := req.GetPreprepare().Sequence
-//		if v >= view && s == sequence {
-//			view = v
-//			requests = append(requests, req)
-//		}
-//	}
-//	if requests == nil {
-//		return nil
-//	}
-//	var prePreps []*pb.Entry
-//	for _, req := range requests {
-//		v := req.GetPreprepare().View
-//		if v == view {
-//			s := req.GetPreprepare().Sequence
-//			d := req.GetPreprepare().Digest
-//			prePrep := ToEntry(s, d, v)
-//			prePreps = append(prePreps, prePrep)
-//		}
-//	}
-//FOR_LOOP:
-//	for _, prePrep := range prePreps {
-//		for _, req := range rep.allRequests() { //TODO: optimize
-//			if EQ(ReqDigest(req), prePrep.Digest) {
-//				continue FOR_LOOP
-//			}
-//		}
-//		return nil
-//	}
-//	return prePreps
-//}
-//
-//func (rep *Replica) prepBySequence(sequence uint32) ([]*pb.Entry, []*pb.Entry) {
-//
-//	prePreps := rep.prePrepBySequence(sequence)
-//
-//	if prePreps == nil {
-//		return nil, nil
-//	}
-//
-//	var preps []*pb.Entry
-//
-//FOR_LOOP:
-//	for _, prePrep := range prePreps {
-//
-//		view := prePrep.View
-//		digest := prePrep.Digest
-//
-//		replicas := make(map[uint32]int)
-//		for _, req := range rep.requests["prepare"] {
-//			reqPrepare := req.GetPrepare()
-//			v := reqPrepare.View
-//			s := reqPrepare.Sequence
-//			d := reqPrepare.Digest
-//			if v == view && s == sequence && EQ(d, digest) {
-//				r := reqPrepare.Replica
-//				replicas[r]++
-//				if rep.twoThirds(replicas[r]) {
-//					prep := ToEntry(s, d, v)
-//					preps = append(preps, prep)
-//					continue FOR_LOOP
-//				}
-//			}
-//		}
-//		return prePreps, nil
-//	}
-//
-//	return prePreps, preps
-//}
-
-/*
-func (rep *Replica) prepBySequence(sequence uint32) *pb.Entry {
-	var REQ *pb.Request
-	var view uint32
-	// Looking for a commit that
-	// replica sent with sequence#
-	for _, req := range rep.requests["commit"] {
-		v := req.GetCommit().View
-		s := req.GetCommit().Sequence
-		r := req.GetCommit().Replica
-		if v >= view && s == sequence && r == rep.ID {
-			REQ = req
-			view = v
+// selectDigest 选择之前视图中最高视图上 prepared 的请求,
+// 并且至少有 f+1 个节点在不低于该视图时收到过相同的 pre-prepare, 否则使用空请求
+func (rep *Replica) selectDigest(requests []*pb.Request, sequence uint32) []byte {
+	var best *pb.Entry
+	for _, req := range requests {
+		for _, prep := range req.GetViewchange().GetPreps() {
+			if prep.Sequence == sequence && (best == nil || prep.View > best.View) {
+				best = prep
+			}
 		}
 	}
-	if REQ == nil {
+	if best == nil {
 		return nil
 	}
-	entry := pb.ToEntry(sequence, nil, view)
-	return entry
+	count := 0
+	for _, req := range requests {
+		for _, prePrep := range req.GetViewchange().GetPrepreps() {
+			if prePrep.Sequence == sequence && prePrep.View >= best.View && EQ(prePrep.Digest, best.Digest) {
+				count++
+				break
+			}
+		}
+	}
+	if !rep.overOneThird(count) {
+		return nil
+	}
+	return best.Digest
 }
 
-func (rep *Replica) prePrepBySequence(sequence uint32) *pb.Entry {
-	var REQ *pb.Request
-	var view uint32
-	// Looking for prepare/pre-prepare that
-	// replica sent with matching sequence
-	for _, req := range rep.requests["pre-prepare"] {
-		v := req.GetPreprepare().View
-		s := req.GetPreprepare().Sequence
-		r := req.GetPreprepare().Replica
-		if v >= view && s == sequence && r == rep.ID {
-			REQ = req
-			view = v
+// correctViewChanges 找到new-view引用的view-change消息, 缺少消息时返回nil
+func (rep *Replica) correctViewChanges(view uint32, viewChanges []*pb.ViewChange) (requests []*pb.Request) {
+	if !rep.overTwoThirds(len(viewChanges)) {
+		return nil
+	}
+	replicas := make(map[uint32]bool)
+	for _, vc := range viewChanges {
+		if replicas[vc.Viewchanger] {
+			return nil
+		}
+		replicas[vc.Viewchanger] = true
+		var found *pb.Request
+		for _, req := range rep.requests["view-change"] {
+			v := req.GetViewchange().View
+			r := req.GetViewchange().Replica
+			if v == view && r == vc.Viewchanger && EQ(ReqDigest(req), vc.Digest) {
+				found = req
+				break
+			}
+		}
+		if found == nil {
+			return nil
 		}
+		requests = append(requests, found)
 	}
-	for _, req := range rep.requests["prepare"] {
-		v := req.GetPreprepare().View
-		s := req.GetPreprepare().Sequence
-		r := req.GetPreprepare().Replica
-		if v >= view && s == sequence && r == rep.ID {
-			REQ = req
-			view = v
+	return requests
+}
+
+// correctSummaries 根据相同的view-change消息重新计算, 结果必须和主节点一致
+func (rep *Replica) correctSummaries(requests []*pb.Request, summaries []*pb.Summary) bool {
+	expected := rep.summaries(requests)
+	if len(expected) != len(summaries) {
+		return false
+	}
+	for i, summary := range summaries {
+		if summary.Sequence != expected[i].Sequence || !EQ(summary.Digest, expected[i].Digest) {
+			return false
 		}
 	}
-	if REQ == nil {
-		return nil
+	return true
+}
+
+func (rep *Replica) processNewView(REQ *pb.Request) bool {
+	reqNewView := REQ.GetNewview()
+	view := reqNewView.View
+	if view < rep.view || (view == rep.view && rep.activeView) {
+		return true
 	}
-	var digest []byte
-	switch REQ.Value.(type) {
-	case *pb.Request_Preprepare:
-		digest = REQ.GetPreprepare().Digest
-	case *pb.Request_Prepare:
-		digest = REQ.GetPrepare().Digest
-	}
-	entry := pb.ToEntry(sequence, digest, view)
-	return entry
-}
-*/
-
-//func (rep *Replica) requestViewChange(view uint32) {
-//
-//	if view != rep.view+1 {
-//		return
-//	}
-//	rep.view = view
-//	rep.activeView = false
-//
-//	var prePreps []*pb.Entry
-//	var preps []*pb.Entry
-//
-//	start := rep.lowWaterMark() + 1
-//	end := rep.highWaterMark()
-//
-//	for s := start; s <= end; s++ {
-//		_prePreps, _preps := rep.prepBySequence(s)
-//		if _prePreps != nil {
-//			prePreps = append(prePreps, _prePreps...)
-//		}
-//		if _preps != nil {
-//			preps = append(preps, _preps...)
-//		}
-//	}
-//
-//	sequence := rep.lowWaterMark()
-//
-//	req := ToRequestViewChange(
-//		view,
-//		sequence,
-//		rep.checkpoints, //
-//		preps,
-//		prePreps,
-//		rep.ID)
-//
-//	rep.logRequest(req)
-//
-//	go func() {
-//		rep.requestChan <- req
-//	}()
-//
-//	rep.clearRequestsByView(view)
-//}
-//
-//func (rep *Replica) createNewView(view uint32) (request *pb.Request) {
-//
-//	// Returns RequestNewView if successful, else returns nil
-//	// create viewChanges
-//	viewChanges := make([]*pb.ViewChange, len(rep.pendingVC))
-//
-//	for idx := range viewChanges {
-//		req := rep.pendingVC[idx]
-//		viewchanger := req.GetViewchange().Replica
-//		vc := ToViewChange(viewchanger, ReqDigest(req))
-//		viewChanges[idx] = vc
-//	}
-//
-//	var summaries []*pb.Summary
-//	var summary *pb.Summary
-//
-//	start := rep.lowWaterMark() + 1
-//	end := rep.highWaterMark()
-//
-//	// select starting checkpoint
-//FOR_LOOP_1:
-//	for seq := start; seq <= end; seq++ {
-//
-//		overLWM := 0
-//		var digest []byte
-//		digests := make(map[string]int)
-//
-//		for _, req := range rep.pendingVC {
-//			reqViewChange := req.GetViewchange()
-//			if reqViewChange.Sequence <= seq {
-//				overLWM++
-//			}
-//			for _, checkpoint := range reqViewChange.GetCheckpoints() {
-//				if checkpoint.Sequence == seq {
-//					d := checkpoint.Digest
-//					digests[string(d)]++
-//					if rep.oneThird(digests[string(d)]) {
-//						digest = d
-//						break
-//					}
-//				}
-//			}
-//			if rep.twoThirds(overLWM) && rep.oneThird(digests[string(digest)]) {
-//				summary = ToSummary(seq, digest)
-//				continue FOR_LOOP_1
-//			}
-//		}
-//	}
-//
-//	if summary == nil {
-//		return
-//	}
-//
-//	summaries = append(summaries, summary)
-//
-//	start = summary.Sequence
-//	end = start + CheckPointPeriod*ConstantFactor
-//
-//	// select summaries
-//	// TODO: optimize
-//FOR_LOOP_2:
-//	for seq := start; seq <= end; seq++ {
-//
-//		for _, REQ := range rep.pendingVC {
-//
-//			sequence := REQ.GetViewchange().Sequence
-//
-//			if sequence != seq {
-//				continue
-//			}
-//
-//			var A1 []*pb.Request
-//			var A2 []*pb.Request
-//
-//			view := REQ.GetViewchange().View
-//			digest := ReqDigest(REQ)
-//
-//		FOR_LOOP_3:
-//			for _, req := range rep.pendingVC {
-//
-//				reqViewChange := req.GetViewchange()
-//
-//				if reqViewChange.Sequence < sequence {
-//					preps := reqViewChange.GetPreps()
-//					for _, prep := range preps {
-//						if prep.Sequence != sequence {
-//							continue
-//						}
-//						if prep.View > view || (prep.View == view && !EQ(prep.Digest, digest)) {
-//							continue FOR_LOOP_3
-//						}
-//					}
-//					A1 = append(A1, req)
-//				}
-//				prePreps := reqViewChange.GetPrepreps()
-//				for _, prePrep := range prePreps {
-//					if prePrep.Sequence != sequence {
-//						continue
-//					}
-//					if prePrep.View >= view && EQ(prePrep.Digest, digest) {
-//						A2 = append(A2, req)
-//						continue FOR_LOOP_3
-//					}
-//				}
-//			}
-//
-//			if rep.twoThirds(len(A1)) && rep.oneThird(len(A2)) {
-//				summary = ToSummary(sequence, digest)
-//				summaries = append(summaries, summary)
-//				continue FOR_LOOP_2
-//			}
-//		}
-//	}
-//
-//	request = ToRequestNewView(view, viewChanges, summaries, rep.ID)
-//	return
-//}
-//
-//func (rep *Replica) requestNewView(view uint32) {
-//
-//	req := rep.createNewView(view)
-//
-//	if req == nil || rep.hasRequest(req) {
-//		return
-//	}
-//
-//	// Process new view
-//
-//	success := rep.processNewView(req)
-//
-//	if !success {
-//		return
-//	}
-//
-//	rep.logRequest(req)
-//
-//	go func() {
-//		rep.requestChan <- req
-//	}()
-//
-//}
+	requests := rep.correctViewChanges(view, reqNewView.GetViewchanges())
+	if requests == nil {
+		return false
+	}
+	summaries := reqNewView.GetSummaries()
+	if !rep.correctSummaries(requests, summaries) {
+		plog.Error("incorrect new view summaries", "view", view, "primary", reqNewView.Replica)
+		return false
+	}
+
+	rep.view = view
+	rep.activeView = true
+	rep.pendingNV = nil
+	rep.clearRequestsByView(view)
+
+	start := summaries[0]
+	if start.Sequence > rep.lowWaterMark() {
+		rep.addCheckpoint(ToCheckpoint(start.Sequence, start.Digest))
+		rep.clearRequestsBySeq(start.Sequence)
+	}
+	if start.Sequence > rep.lastExecuted() {
+		rep.setExecuted(start.Sequence)
+		rep.lastDigest = start.Digest
+	}
+	rep.sequence = rep.lastExecuted()
+
+	digests := make(map[string]bool)
+	for _, summary := range summaries[1:] {
+		rep.logRequest(ToRequestPreprepare(view, summary.Sequence, summary.Digest, reqNewView.Replica))
+		req := ToRequestPrepare(view, summary.Sequence, summary.Digest, rep.ID)
+		rep.logRequest(req)
+		rep.send(req)
+		digests[string(summary.Digest)] = true
+		if summary.Sequence > rep.sequence {
+			rep.sequence = summary.Sequence
+		}
+	}
+	// 没有进入新视图的客户端请求直接丢弃, 由新的主节点重新打包
+	for digest := range rep.clients {
+		if !digests[digest] {
+			delete(rep.clients, digest)
+		}
+	}
+	plog.Info("new view done", "replica", rep.ID, "view", view, "primary", reqNewView.Replica)
+
+	rep.suspected = false
+	rep.stopTimer()
+	if len(rep.clients) > 0 {
+		rep.startTimer()
+	}
+	for _, summary := range summaries[1:] {
+		rep.tryCommit(view, summary.Sequence)
+	}
+	rep.tryExecute()
+	return true
+}
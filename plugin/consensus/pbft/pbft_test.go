@@ -10,6 +10,7 @@ import (
 	"math/rand"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,7 +26,10 @@ import (
 	"github.com/33cn/chain33/store"
 	cty "github.com/33cn/chain33/system/dapp/coins/types"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/33cn/chain33/util/testnode"
 	"github.com/33cn/chain33/wallet"
+	"github.com/stretchr/testify/assert"
 
 	_ "github.com/33cn/chain33/system"
 	_ "github.com/33cn/plugin/plugin/dapp/init"
@@ -153,3 +157,142 @@ func clearTestData() {
 	}
 	fmt.Println("test data clear successfully!")
 }
+
+func newTestReplicas(t *testing.T, n, port int) []*Replica {
+	privKeys, pubKeys := newTestKeys(t, n)
+	addrs := testAddrs(n, port)
+	peersURL := strings.Join(addrs, ",")
+	var replicas []*Replica
+	for i := 0; i < n; i++ {
+		replica, err := NewReplica(uint32(i+1), peersURL, addrs[i], privKeys[i], pubKeys, time.Second)
+		assert.Nil(t, err)
+		replicas = append(replicas, replica)
+	}
+	return replicas
+}
+
+func newTestKeys(t *testing.T, n int) ([]crypto.PrivKey, []crypto.PubKey) {
+	cr, err := crypto.New(types.GetSignName("", types.SECP256K1))
+	assert.Nil(t, err)
+	var privKeys []crypto.PrivKey
+	var pubKeys []crypto.PubKey
+	for i := 0; i < n; i++ {
+		priv, err := cr.GenKey()
+		assert.Nil(t, err)
+		privKeys = append(privKeys, priv)
+		pubKeys = append(pubKeys, priv.PubKey())
+	}
+	return privKeys, pubKeys
+}
+
+func testAddrs(n, port int) []string {
+	var addrs []string
+	for i := 0; i < n; i++ {
+		addrs = append(addrs, fmt.Sprintf("127.0.0.1:%d", port+i))
+	}
+	return addrs
+}
+
+// newTestNodeConfig 集群中一个节点的配置, 每个节点使用独立的数据目录, 不启动p2p
+// 视图切换超时需要大于出块循环的等待时间(10秒), 否则备份节点会在主节点打包之前切换视图
+func newTestNodeConfig(id int, peersURL, addr string, privKey crypto.PrivKey, pubKeys []crypto.PubKey) *types.Chain33Config {
+	var keys []string
+	for _, pub := range pubKeys {
+		keys = append(keys, common.ToHex(pub.Bytes()))
+	}
+	replacer := strings.NewReplacer(
+		"nodeID=1", fmt.Sprintf("nodeID=%d", id),
+		`peersURL="127.0.0.1:8890"`, fmt.Sprintf("peersURL=%q", peersURL),
+		`clientAddr="127.0.0.1:8890"`, fmt.Sprintf("clientAddr=%q", addr),
+		`privKey="0x03303be5b156382f2e5da900613eeaa37a6405fd4fd43db8645c8bc7fdd4e701"`, fmt.Sprintf("privKey=%q", common.ToHex(privKey.Bytes())),
+		`peersPubKey="0x03d52ea06b0f4f8818ed994b48a6b2b3afdc3efb77ce089c6d87a47b47a1f743a2"`, fmt.Sprintf("peersPubKey=%q", strings.Join(keys, ",")),
+		"viewChangeTimeout=10", "viewChangeTimeout=12",
+	)
+	cfg := types.NewChain33Config(replacer.Replace(types.ReadFile("chain33.test.toml")))
+	cfg.GetModuleConfig().P2P.Enable = false
+	return cfg
+}
+
+// TestPbftViewChange 使用真实的区块链节点运行共识, 4个节点都启动, 视图1的主节点出块之后宕机,
+// 备份节点的交易池中有交易等待打包, 超时之后切换视图, 由新的主节点继续出块
+func TestPbftViewChange(t *testing.T) {
+	n := 4
+	privKeys, pubKeys := newTestKeys(t, n)
+	addrs := testAddrs(n, 18890)
+	peersURL := strings.Join(addrs, ",")
+	var nodes []*testnode.Chain33Mock
+	for i := 0; i < n; i++ {
+		node := testnode.NewWithConfig(newTestNodeConfig(i+1, peersURL, addrs[i], privKeys[i], pubKeys), nil)
+		nodes = append(nodes, node)
+	}
+	// 1号节点是视图1的主节点, 在测试中途关闭
+	primary, backups := nodes[0], nodes[1:]
+	defer func() {
+		for _, node := range backups {
+			node.Close()
+		}
+	}()
+
+	cfg := primary.GetAPI().GetConfig()
+	deadline := time.Now().Add(3 * time.Minute)
+	produce := func(nodes []*testnode.Chain33Mock, height int64) {
+		to, _ := util.Genaddress()
+		tx := util.CreateCoinsTx(cfg, primary.GetGenesisKey(), to, types.Coin)
+		for _, node := range nodes {
+			reply, err := node.GetAPI().SendTx(tx)
+			assert.Nil(t, err)
+			assert.True(t, reply.IsOk)
+		}
+		for _, node := range nodes {
+			for node.GetLastBlock().Height < height {
+				if time.Now().After(deadline) {
+					t.Fatalf("block height %d not reached", height)
+				}
+				time.Sleep(100 * time.Millisecond)
+			}
+		}
+	}
+
+	// 视图1中由1号节点出块
+	for height := int64(1); height <= 2; height++ {
+		produce(nodes, height)
+	}
+	primary.Close()
+
+	// 主节点宕机之后, 剩余节点切换视图继续出块
+	for height := int64(3); height <= 5; height++ {
+		produce(backups, height)
+	}
+	// 所有存活节点写入的区块一致
+	for height := int64(1); height <= 5; height++ {
+		hash := backups[0].GetBlock(height).Hash(cfg)
+		for _, node := range backups[1:] {
+			assert.Equal(t, hash, node.GetBlock(height).Hash(cfg))
+		}
+		assert.Equal(t, 1, len(backups[0].GetBlock(height).Txs))
+	}
+}
+
+func TestPbftSignature(t *testing.T) {
+	replicas := newTestReplicas(t, 4, 18900)
+	defer func() {
+		for _, replica := range replicas {
+			replica.Stop()
+		}
+	}()
+	rep := replicas[0]
+	req := ToRequestPrepare(1, 1, []byte("digest"), 2)
+	env := replicas[1].sign(req)
+	assert.Nil(t, rep.verify(env))
+
+	// 伪造其他节点的消息
+	env = replicas[2].sign(req)
+	assert.Equal(t, types.ErrSign, rep.verify(env))
+	env.Replica = 2
+	assert.Equal(t, types.ErrSign, rep.verify(env))
+	env = replicas[1].sign(req)
+	env.Request = ToRequestPrepare(1, 2, []byte("digest"), 2)
+	assert.Equal(t, types.ErrSign, rep.verify(env))
+	env.Replica = 5
+	assert.Equal(t, errNodeID, rep.verify(env))
+}
@@ -0,0 +1,99 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: pbft_msg.proto
+
+package types
+
+import (
+	fmt "fmt"
+
+	proto "github.com/golang/protobuf/proto"
+
+	math "math"
+
+	types "github.com/33cn/chain33/types"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// SignedRequest 带签名的pbft消息, replica 是发送消息的节点
+type SignedRequest struct {
+	Request              *types.Request `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Replica              uint32         `protobuf:"varint,2,opt,name=replica,proto3" json:"replica,omitempty"`
+	Signature            []byte         `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SignedRequest) Reset()         { *m = SignedRequest{} }
+func (m *SignedRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedRequest) ProtoMessage()    {}
+func (*SignedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pbft_msg_c6660d72e9f58898, []int{0}
+}
+func (m *SignedRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedRequest.Unmarshal(m, b)
+}
+func (m *SignedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedRequest.Marshal(b, m, deterministic)
+}
+func (dst *SignedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedRequest.Merge(dst, src)
+}
+func (m *SignedRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedRequest.Size(m)
+}
+func (m *SignedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedRequest proto.InternalMessageInfo
+
+func (m *SignedRequest) GetRequest() *types.Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedRequest) GetReplica() uint32 {
+	if m != nil {
+		return m.Replica
+	}
+	return 0
+}
+
+func (m *SignedRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*SignedRequest)(nil), "types.SignedRequest")
+}
+
+func init() { proto.RegisterFile("pbft_msg.proto", fileDescriptor_pbft_msg_c6660d72e9f58898) }
+
+var fileDescriptor_pbft_msg_c6660d72e9f58898 = []byte{
+	// 135 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2b, 0x48, 0x4a, 0x2b,
+	0x89, 0xcf, 0x2d, 0x4e, 0xd7, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2d, 0xa9, 0x2c, 0x48,
+	0x2d, 0x96, 0xe2, 0x02, 0x09, 0x43, 0x84, 0x94, 0x0a, 0xb9, 0x78, 0x83, 0x33, 0xd3, 0xf3, 0x52,
+	0x53, 0x82, 0x52, 0x0b, 0x4b, 0x53, 0x8b, 0x4b, 0x84, 0x34, 0xb8, 0xd8, 0x8b, 0x20, 0x4c, 0x09,
+	0x46, 0x05, 0x46, 0x0d, 0x6e, 0x23, 0x3e, 0x3d, 0xb0, 0x2e, 0x3d, 0xa8, 0x82, 0x20, 0x98, 0xb4,
+	0x90, 0x04, 0x48, 0x65, 0x41, 0x4e, 0x66, 0x72, 0xa2, 0x04, 0x93, 0x02, 0xa3, 0x06, 0x6f, 0x10,
+	0x8c, 0x2b, 0x24, 0xc3, 0xc5, 0x59, 0x9c, 0x99, 0x9e, 0x97, 0x58, 0x52, 0x5a, 0x94, 0x2a, 0xc1,
+	0xac, 0xc0, 0xa8, 0xc1, 0x13, 0x84, 0x10, 0x48, 0x62, 0x03, 0xdb, 0x6c, 0x0c, 0x18, 0x00, 0x54,
+	0xc8, 0x2a, 0xdf, 0x9e, 0x00, 0x00, 0x00,
+}
@@ -0,0 +1,181 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package peerfilter 共识节点连接的过滤和认证，供tendermint和dpos共用
+package peerfilter
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/33cn/chain33/common/crypto"
+)
+
+// DefaultMaxPeersPerIP is the max connections from the same ip by default
+const DefaultMaxPeersPerIP = 1
+
+// Errors define
+var (
+	ErrPeerNotAuthorized = errors.New("Error peer is not authorized")
+	ErrPeerIPRejected    = errors.New("Error peer ip is rejected")
+	ErrPeerIPLimit       = errors.New("Error too many connections from the peer ip")
+)
+
+// Validator is the validator pubkey and voting power the peer is authorized against
+type Validator struct {
+	PubKey      []byte
+	VotingPower int64
+}
+
+// PeerFilter filters the peer connections by ip and authenticates the peer by its node pubkey
+type PeerFilter struct {
+	// consensus is the name of the consensus, which prefixes the node key sign bytes
+	consensus string
+	// authPeers only accept the peers whose node keys are signed by current validators or in allowPeers
+	authPeers     bool
+	allowPeers    map[string]bool
+	allowIPs      []*net.IPNet
+	denyIPs       []*net.IPNet
+	maxPeersPerIP int
+}
+
+// New creates the peer filter, ips can be ip or CIDR, allowPeers are hex node pubkeys
+func New(consensus string, authPeers bool, allowPeers []string, allowIPs []string, denyIPs []string, maxPeersPerIP int) (*PeerFilter, error) {
+	filter := &PeerFilter{
+		consensus:     consensus,
+		authPeers:     authPeers,
+		allowPeers:    make(map[string]bool),
+		maxPeersPerIP: maxPeersPerIP,
+	}
+	if filter.maxPeersPerIP <= 0 {
+		filter.maxPeersPerIP = DefaultMaxPeersPerIP
+	}
+	for _, key := range allowPeers {
+		pub, err := hex.DecodeString(strings.TrimSpace(key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid allow peer %v: %v", key, err)
+		}
+		filter.allowPeers[string(pub)] = true
+	}
+	var err error
+	if filter.allowIPs, err = parseIPNets(allowIPs); err != nil {
+		return nil, err
+	}
+	if filter.denyIPs, err = parseIPNets(denyIPs); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+func parseIPNets(ips []string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, item := range ips {
+		item = strings.TrimSpace(item)
+		if !strings.Contains(item, "/") {
+			ip := net.ParseIP(item)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid ip %v", item)
+			}
+			bits := 32
+			if ip.To4() == nil {
+				bits = 128
+			}
+			item = fmt.Sprintf("%v/%v", item, bits)
+		}
+		_, ipNet, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// NodeKeySignBytes returns the bytes signed by the validator for the node pubkey,
+// the prefix makes it never be the sign bytes of a consensus message
+func NodeKeySignBytes(consensus string, chainID string, nodeKey []byte) []byte {
+	return []byte(fmt.Sprintf("%s-node-key:%s:%X", consensus, chainID, nodeKey))
+}
+
+// AuthPeers returns whether the peers are authenticated by the node pubkey
+func (f *PeerFilter) AuthPeers() bool {
+	return f.authPeers
+}
+
+// MaxPeersPerIP returns the max connections from the same ip
+func (f *PeerFilter) MaxPeersPerIP() int {
+	return f.maxPeersPerIP
+}
+
+// FilterIP rejects the ip in deny list, or not in allow list if the allow list is not empty
+func (f *PeerFilter) FilterIP(ip net.IP) error {
+	if containsIP(f.denyIPs, ip) {
+		return ErrPeerIPRejected
+	}
+	if len(f.allowIPs) > 0 && !containsIP(f.allowIPs, ip) {
+		return ErrPeerIPRejected
+	}
+	return nil
+}
+
+// Authorize checks the authenticated node pubkey of the peer against the validators,
+// the peer must prove that its node key is signed by the validator key it claims,
+// valPubKey and nodeKeySig are the hex validator pubkey and signature in the peer node info
+func (f *PeerFilter) Authorize(cr crypto.Crypto, chainID string, nodeKey crypto.PubKey, valPubKey string, nodeKeySig string, validators []*Validator) error {
+	if !f.authPeers {
+		return nil
+	}
+	if nodeKey == nil {
+		return ErrPeerNotAuthorized
+	}
+	if f.allowPeers[string(nodeKey.Bytes())] {
+		return nil
+	}
+	if valPubKey == "" || nodeKeySig == "" {
+		return fmt.Errorf("%v: node key is not signed by validator", ErrPeerNotAuthorized)
+	}
+	pubKeyBytes, err := hex.DecodeString(valPubKey)
+	if err != nil {
+		return err
+	}
+	isValidator := false
+	for _, val := range validators {
+		if bytes.Equal(val.PubKey, pubKeyBytes) && val.VotingPower > 0 {
+			isValidator = true
+			break
+		}
+	}
+	if !isValidator {
+		return fmt.Errorf("%v: %v is not a validator", ErrPeerNotAuthorized, valPubKey)
+	}
+	pubKey, err := cr.PubKeyFromBytes(pubKeyBytes)
+	if err != nil {
+		return err
+	}
+	sigBytes, err := hex.DecodeString(nodeKeySig)
+	if err != nil {
+		return err
+	}
+	sig, err := cr.SignatureFromBytes(sigBytes)
+	if err != nil {
+		return err
+	}
+	if !pubKey.VerifyBytes(NodeKeySignBytes(f.consensus, chainID, nodeKey.Bytes()), sig) {
+		return fmt.Errorf("%v: wrong node key signature", ErrPeerNotAuthorized)
+	}
+	return nil
+}
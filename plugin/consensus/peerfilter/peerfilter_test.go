@@ -0,0 +1,69 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package peerfilter
+
+import (
+	"encoding/hex"
+	"net"
+	"testing"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterIP(t *testing.T) {
+	_, err := New("test", true, nil, []string{"1.1.1"}, nil, 0)
+	assert.NotNil(t, err)
+	filter, err := New("test", true, nil, []string{"127.0.0.0/8", "10.0.0.1"}, []string{"127.0.0.2"}, 0)
+	assert.Nil(t, err)
+	assert.True(t, filter.AuthPeers())
+	assert.Equal(t, DefaultMaxPeersPerIP, filter.MaxPeersPerIP())
+	assert.Nil(t, filter.FilterIP(net.ParseIP("127.0.0.1")))
+	assert.Nil(t, filter.FilterIP(net.ParseIP("10.0.0.1")))
+	assert.Equal(t, ErrPeerIPRejected, filter.FilterIP(net.ParseIP("127.0.0.2")))
+	assert.Equal(t, ErrPeerIPRejected, filter.FilterIP(net.ParseIP("10.0.0.2")))
+}
+
+func TestAuthorize(t *testing.T) {
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	assert.Nil(t, err)
+	filter, err := New("test", true, nil, nil, nil, 0)
+	assert.Nil(t, err)
+
+	valKey, err := cr.GenKey()
+	assert.Nil(t, err)
+	nodeKey, err := cr.GenKey()
+	assert.Nil(t, err)
+	valPubKey := hex.EncodeToString(valKey.PubKey().Bytes())
+	sig := hex.EncodeToString(valKey.Sign(NodeKeySignBytes("test", "chain", nodeKey.PubKey().Bytes())).Bytes())
+	validators := []*Validator{{PubKey: valKey.PubKey().Bytes(), VotingPower: 10}}
+	assert.Nil(t, filter.Authorize(cr, "chain", nodeKey.PubKey(), valPubKey, sig, validators))
+
+	// the signature is bound to the consensus, the chain and the node key
+	other, err := New("other", true, nil, nil, nil, 0)
+	assert.Nil(t, err)
+	assert.NotNil(t, other.Authorize(cr, "chain", nodeKey.PubKey(), valPubKey, sig, validators))
+	assert.NotNil(t, filter.Authorize(cr, "chain2", nodeKey.PubKey(), valPubKey, sig, validators))
+	otherKey, err := cr.GenKey()
+	assert.Nil(t, err)
+	assert.NotNil(t, filter.Authorize(cr, "chain", otherKey.PubKey(), valPubKey, sig, validators))
+	assert.NotNil(t, filter.Authorize(cr, "chain", nil, valPubKey, sig, validators))
+	assert.NotNil(t, filter.Authorize(cr, "chain", nodeKey.PubKey(), "", "", validators))
+	assert.NotNil(t, filter.Authorize(cr, "chain", nodeKey.PubKey(), valPubKey, sig, nil))
+
+	// the validator removed with zero voting power is not authorized
+	removed := []*Validator{{PubKey: valKey.PubKey().Bytes(), VotingPower: 0}}
+	assert.NotNil(t, filter.Authorize(cr, "chain", nodeKey.PubKey(), valPubKey, sig, removed))
+
+	// the static allowed peers and the filter without authentication
+	filter, err = New("test", true, []string{hex.EncodeToString(otherKey.PubKey().Bytes())}, nil, nil, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, filter.MaxPeersPerIP())
+	assert.Nil(t, filter.Authorize(cr, "chain", otherKey.PubKey(), "", "", nil))
+	filter, err = New("test", false, nil, nil, nil, 0)
+	assert.Nil(t, err)
+	assert.Nil(t, filter.Authorize(cr, "chain", otherKey.PubKey(), "", "", nil))
+}
@@ -0,0 +1,125 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package privfile 共识节点私钥文件的加密和解密，供tendermint和dpos共用
+package privfile
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	fileCipher    = "aes-256-gcm"
+	fileKeyLen    = 32
+	fileSaltLen   = 32
+	fileScryptN   = 1 << 15
+	fileScryptR   = 8
+	fileScryptP   = 1
+	fileCipherKDF = "scrypt"
+)
+
+// ErrPassword is returned when the encrypted priv validator file can not be decrypted
+var ErrPassword = errors.New("Error wrong password or corrupted priv validator file")
+
+// EncryptedFS is the priv validator file encrypted with a key derived from the password
+type EncryptedFS struct {
+	Cipher string `json:"cipher"`
+	KDF    string `json:"kdf"`
+	Salt   string `json:"salt"`
+	Nonce  string `json:"nonce"`
+	Data   string `json:"data"`
+}
+
+// DeriveKey derives the file key from password, a random salt is generated if salt is nil
+func DeriveKey(password []byte, salt []byte) (key []byte, newSalt []byte, err error) {
+	if len(password) == 0 {
+		return nil, nil, errors.New("Error empty password")
+	}
+	if salt == nil {
+		salt = make([]byte, fileSaltLen)
+		if _, err := crand.Read(salt); err != nil {
+			return nil, nil, err
+		}
+	}
+	key, err = scrypt.Key(password, salt, fileScryptN, fileScryptR, fileScryptP, fileKeyLen)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, salt, nil
+}
+
+// Encrypt encrypts the plain priv validator file with the key derived by DeriveKey
+func Encrypt(plain []byte, key []byte, salt []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := crand.Read(nonce); err != nil {
+		return nil, err
+	}
+	encrypted := &EncryptedFS{
+		Cipher: fileCipher,
+		KDF:    fileCipherKDF,
+		Salt:   hex.EncodeToString(salt),
+		Nonce:  hex.EncodeToString(nonce),
+		Data:   hex.EncodeToString(gcm.Seal(nil, nonce, plain, salt)),
+	}
+	return json.Marshal(encrypted)
+}
+
+// Decrypt returns the plain file and the key to encrypt it again
+func Decrypt(data []byte, password []byte) (plain []byte, key []byte, salt []byte, err error) {
+	var encrypted EncryptedFS
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return nil, nil, nil, err
+	}
+	if encrypted.Cipher != fileCipher || encrypted.KDF != fileCipherKDF {
+		return nil, nil, nil, fmt.Errorf("Error unsupported cipher %v/%v", encrypted.Cipher, encrypted.KDF)
+	}
+	salt, err = hex.DecodeString(encrypted.Salt)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	nonce, err := hex.DecodeString(encrypted.Nonce)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	sealed, err := hex.DecodeString(encrypted.Data)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	key, _, err = DeriveKey(password, salt)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, nil, nil, ErrPassword
+	}
+	plain, err = gcm.Open(nil, nonce, sealed, salt)
+	if err != nil {
+		return nil, nil, nil, ErrPassword
+	}
+	return plain, key, salt, nil
+}
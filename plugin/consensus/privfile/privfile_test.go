@@ -0,0 +1,30 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package privfile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	password := []byte("password")
+	key, salt, err := DeriveKey(password, nil)
+	assert.Nil(t, err)
+	data, err := Encrypt([]byte("priv validator"), key, salt)
+	assert.Nil(t, err)
+
+	plain, decryptKey, decryptSalt, err := Decrypt(data, password)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("priv validator"), plain)
+	assert.Equal(t, key, decryptKey)
+	assert.Equal(t, salt, decryptSalt)
+
+	_, _, _, err = Decrypt(data, []byte("wrong"))
+	assert.Equal(t, ErrPassword, err)
+	_, _, err = DeriveKey(nil, nil)
+	assert.NotNil(t, err)
+}
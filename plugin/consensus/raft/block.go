@@ -16,7 +16,6 @@ import (
 	cty "github.com/33cn/chain33/system/dapp/coins/types"
 	"github.com/33cn/chain33/types"
 	"github.com/coreos/etcd/snap"
-	"github.com/golang/protobuf/proto"
 )
 
 var (
@@ -39,6 +38,10 @@ type Client struct {
 	ctx         context.Context
 	cancel      context.CancelFunc
 	once        sync.Once
+	//从快照恢复时需要同步到的区块
+	snapMu      sync.Mutex
+	snapTarget  *types.Header
+	snapSyncing bool
 }
 
 // NewBlockstore create Raft Client
@@ -78,20 +81,6 @@ func (client *Client) CheckBlock(parent *types.Block, current *types.BlockDetail
 	return nil
 }
 
-func (client *Client) getSnapshot() ([]byte, error) {
-	//这里可能导致死锁
-	return proto.Marshal(client.GetCurrentBlock())
-}
-
-func (client *Client) recoverFromSnapshot(snapshot []byte) error {
-	var block types.Block
-	if err := proto.Unmarshal(snapshot, &block); err != nil {
-		return err
-	}
-	client.SetCurrentBlock(&block)
-	return nil
-}
-
 // SetQueueClient method
 func (client *Client) SetQueueClient(c queue.Client) {
 	rlog.Info("Enter SetQueue method of raft consensus")
@@ -6,12 +6,14 @@ package raft
 
 import (
 	"context"
+	"net/http"
 	"strings"
 	"sync/atomic"
 
 	log "github.com/33cn/chain33/common/log/log15"
 	"github.com/33cn/chain33/queue"
 	"github.com/33cn/chain33/types"
+	"github.com/coreos/etcd/pkg/transport"
 	"github.com/coreos/etcd/raft/raftpb"
 )
 
@@ -34,6 +36,10 @@ type subConfig struct {
 	NodeID            int64  `json:"nodeID"`
 	PeersURL          string `json:"peersURL"`
 	RaftAPIPort       int64  `json:"raftAPIPort"`
+	RaftAPIHost       string `json:"raftAPIHost"`
+	RaftAPICertFile   string `json:"raftAPICertFile"`
+	RaftAPIKeyFile    string `json:"raftAPIKeyFile"`
+	RaftAPICAFile     string `json:"raftAPICAFile"`
 	IsNewJoinNode     bool   `json:"isNewJoinNode"`
 	ReadOnlyPeersURL  string `json:"readOnlyPeersURL"`
 	AddPeersURL       string `json:"addPeersURL"`
@@ -78,7 +84,17 @@ func NewRaftCluster(cfg *types.Consensus, sub []byte) queue.Module {
 		heartbeatTick = int(subcfg.HeartbeatTick)
 	}
 	var b *Client
-	getSnapshot := func() ([]byte, error) { return b.getSnapshot() }
+	//raft节点先于共识模块创建，回调需要等待共识模块创建完成
+	clientReady := make(chan struct{})
+	getClient := func() *Client {
+		<-clientReady
+		return b
+	}
+	getSnapshot := func(committedHeight int64) ([]byte, error) { return getClient().getSnapshot(committedHeight) }
+	recoverSnapshot := func(snapshot []byte, leaderURL func() (string, error)) error {
+		return getClient().recoverFromSnapshot(snapshot, leaderURL)
+	}
+	blockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { getClient().serveBlocks(w, r) })
 	// raft集群的建立,1. 初始化两条channel： propose channel用于客户端和raft底层交互, commit channel用于获取commit消息
 	// 2. raft集群中的节点之间建立http连接
 	peers := strings.Split(subcfg.PeersURL, ",")
@@ -98,10 +114,16 @@ func NewRaftCluster(cfg *types.Consensus, sub []byte) queue.Module {
 	// propose channel
 	proposeC := make(chan *types.Block)
 	confChangeC = make(chan raftpb.ConfChange)
-	commitC, errorC, snapshotterReady, validatorC := NewRaftNode(ctx, int(subcfg.NodeID), subcfg.IsNewJoinNode, peers, readOnlyPeers, addPeers, getSnapshot, proposeC, confChangeC)
-	//启动raft删除节点操作监听
-	go serveHTTPRaftAPI(ctx, int(subcfg.RaftAPIPort), confChangeC, errorC)
+	commitC, errorC, snapshotterReady, validatorC, cluster := NewRaftNode(ctx, int(subcfg.NodeID), subcfg.IsNewJoinNode, peers, readOnlyPeers, addPeers, getSnapshot, recoverSnapshot, blockHandler, proposeC, confChangeC)
+	//启动raft集群管理接口，配置证书后启用双向TLS认证
+	tlsInfo := transport.TLSInfo{
+		CertFile:      subcfg.RaftAPICertFile,
+		KeyFile:       subcfg.RaftAPIKeyFile,
+		TrustedCAFile: subcfg.RaftAPICAFile,
+	}
+	go serveHTTPRaftAPI(ctx, subcfg.RaftAPIHost, int(subcfg.RaftAPIPort), tlsInfo, cluster, errorC)
 	// 监听commit channel,取block
 	b = NewBlockstore(ctx, cfg, <-snapshotterReady, proposeC, commitC, errorC, validatorC, stop)
+	close(clientReady)
 	return b
 }
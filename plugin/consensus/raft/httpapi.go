@@ -6,76 +6,299 @@ package raft
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
-	"fmt"
-
+	"github.com/coreos/etcd/pkg/transport"
+	"github.com/coreos/etcd/raft"
 	"github.com/coreos/etcd/raft/raftpb"
 )
 
+// 等待成员变更提交或leader转移的超时时间
+var raftAPITimeout = 15 * time.Second
+
+// ClusterStatus raft集群状态
+type ClusterStatus struct {
+	ID             uint64                     `json:"id"`
+	Leader         uint64                     `json:"leader"`
+	Term           uint64                     `json:"term"`
+	Vote           uint64                     `json:"vote"`
+	Commit         uint64                     `json:"commit"`
+	Applied        uint64                     `json:"applied"`
+	RaftState      string                     `json:"raftState"`
+	LeadTransferee uint64                     `json:"leadTransferee"`
+	Progress       map[uint64]*MemberProgress `json:"progress,omitempty"`
+}
+
+// MemberProgress leader上记录的成员日志同步进度
+type MemberProgress struct {
+	Match        uint64 `json:"match"`
+	Next         uint64 `json:"next"`
+	State        string `json:"state"`
+	IsLearner    bool   `json:"isLearner"`
+	RecentActive bool   `json:"recentActive"`
+	Paused       bool   `json:"paused"`
+}
+
+// NewClusterStatus convert raft status to cluster status
+func NewClusterStatus(status raft.Status) *ClusterStatus {
+	cs := &ClusterStatus{
+		ID:             status.ID,
+		Leader:         status.Lead,
+		Term:           status.Term,
+		Vote:           status.Vote,
+		Commit:         status.Commit,
+		Applied:        status.Applied,
+		RaftState:      status.RaftState.String(),
+		LeadTransferee: status.LeadTransferee,
+	}
+	if len(status.Progress) > 0 {
+		cs.Progress = make(map[uint64]*MemberProgress)
+		for id, pr := range status.Progress {
+			cs.Progress[id] = &MemberProgress{
+				Match:        pr.Match,
+				Next:         pr.Next,
+				State:        pr.State.String(),
+				IsLearner:    pr.IsLearner,
+				RecentActive: pr.RecentActive,
+				Paused:       pr.Paused,
+			}
+		}
+	}
+	return cs
+}
+
 // Handler for a http based httpRaftAPI backed by raft
+// GET    /status                 集群状态
+// POST   /members/{id}           添加节点，body为节点的url，learner=true时添加为只读节点
+// POST   /members/{id}/promote   将只读节点提升为共识节点
+// DELETE /members/{id}           删除节点
+// POST   /leader/{id}            转移leader
+// 兼容旧接口 POST /{id} 和 DELETE /{id}
 type httpRaftAPI struct {
-	confChangeC chan<- raftpb.ConfChange
+	cluster Cluster
 }
 
 func (h *httpRaftAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	key := r.RequestURI
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
 	switch {
-	case r.Method == "POST":
+	case len(parts) == 1 && parts[0] == "status":
+		h.handleStatus(w, r)
+	case len(parts) >= 2 && parts[0] == "members":
+		h.handleMembers(w, r, parts[1:])
+	case len(parts) == 2 && parts[0] == "leader":
+		h.handleLeader(w, r, parts[1])
+	case len(parts) == 1:
+		h.handleLegacy(w, r, parts[0])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *httpRaftAPI) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		methodNotAllowed(w, "GET")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(NewClusterStatus(h.cluster.Status())); err != nil {
+		rlog.Error(fmt.Sprintf("Failed to encode raft status (%v)", err.Error()))
+	}
+}
+
+func (h *httpRaftAPI) handleMembers(w http.ResponseWriter, r *http.Request, parts []string) {
+	nodeID, err := parseNodeID(parts[0])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	switch {
+	case len(parts) == 2 && parts[1] == "promote":
+		if r.Method != "POST" {
+			methodNotAllowed(w, "POST")
+			return
+		}
+		//只有leader知道成员是否为只读节点，提升不存在的节点会添加一个无法连接的共识节点
+		status := h.cluster.Status()
+		if status.RaftState != raft.StateLeader {
+			http.Error(w, fmt.Sprintf("not leader, leader is %d", status.Lead), http.StatusConflict)
+			return
+		}
+		if pr, ok := status.Progress[nodeID]; !ok || !pr.IsLearner {
+			http.Error(w, fmt.Sprintf("node %d is not a learner", nodeID), http.StatusBadRequest)
+			return
+		}
+		h.confChange(w, r, raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: nodeID}, http.StatusOK)
+	case len(parts) == 1 && r.Method == "POST":
 		url, err := ioutil.ReadAll(r.Body)
-		if err != nil {
-			rlog.Error(fmt.Sprintf("Failed to convert ID for conf change (%v)", err.Error()))
-			http.Error(w, "Failed on POST", http.StatusBadRequest)
+		if err != nil || len(url) == 0 {
+			http.Error(w, "node url is required", http.StatusBadRequest)
 			return
 		}
+		cc := raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: nodeID, Context: url}
+		if learner, _ := strconv.ParseBool(r.URL.Query().Get("learner")); learner {
+			cc.Type = raftpb.ConfChangeAddLearnerNode
+		}
+		h.confChange(w, r, cc, http.StatusCreated)
+	case len(parts) == 1 && r.Method == "DELETE":
+		h.confChange(w, r, raftpb.ConfChange{Type: raftpb.ConfChangeRemoveNode, NodeID: nodeID}, http.StatusOK)
+	case len(parts) == 1:
+		methodNotAllowed(w, "POST", "DELETE")
+	default:
+		http.NotFound(w, r)
+	}
+}
 
-		nodeID, err := strconv.ParseUint(key[1:], 0, 64)
+func (h *httpRaftAPI) handleLeader(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != "POST" {
+		methodNotAllowed(w, "POST")
+		return
+	}
+	nodeID, err := parseNodeID(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	status := h.cluster.Status()
+	if pr, ok := status.Progress[nodeID]; status.RaftState == raft.StateLeader && (!ok || pr.IsLearner) {
+		http.Error(w, fmt.Sprintf("node %d is not a voter", nodeID), http.StatusBadRequest)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), raftAPITimeout)
+	defer cancel()
+	if err := h.cluster.TransferLeadership(ctx, nodeID); err != nil {
+		rlog.Error(fmt.Sprintf("Failed to transfer leadership to %d (%v)", nodeID, err.Error()))
+		http.Error(w, err.Error(), errorStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *httpRaftAPI) handleLegacy(w http.ResponseWriter, r *http.Request, id string) {
+	switch r.Method {
+	case "POST":
+		url, err := ioutil.ReadAll(r.Body)
 		if err != nil {
-			rlog.Error(fmt.Sprintf("Failed to convert ID for conf change (%v)", err.Error()))
+			rlog.Error(fmt.Sprintf("Failed to read url for conf change (%v)", err.Error()))
 			http.Error(w, "Failed on POST", http.StatusBadRequest)
 			return
 		}
-
-		cc := raftpb.ConfChange{
-			Type:    raftpb.ConfChangeAddNode,
-			NodeID:  nodeID,
-			Context: url,
+		nodeID, err := parseNodeID(id)
+		if err != nil {
+			http.Error(w, "Failed on POST", http.StatusBadRequest)
+			return
 		}
-		h.confChangeC <- cc
-		// As above, optimistic that raft will apply the conf change
-		w.WriteHeader(http.StatusCreated)
-	case r.Method == "DELETE":
-		nodeID, err := strconv.ParseUint(key[1:], 0, 64)
+		h.confChange(w, r, raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: nodeID, Context: url}, http.StatusCreated)
+	case "DELETE":
+		nodeID, err := parseNodeID(id)
 		if err != nil {
-			rlog.Error(fmt.Sprintf("Failed to convert ID for conf change (%v)", err.Error()))
 			http.Error(w, "Failed on DELETE", http.StatusBadRequest)
 			return
 		}
-		cc := raftpb.ConfChange{
-			Type:   raftpb.ConfChangeRemoveNode,
-			NodeID: nodeID,
-		}
-		h.confChangeC <- cc
-		// As above, optimistic that raft will apply the conf change
-		w.WriteHeader(http.StatusAccepted)
+		h.confChange(w, r, raftpb.ConfChange{Type: raftpb.ConfChangeRemoveNode, NodeID: nodeID}, http.StatusAccepted)
 	default:
-		w.Header().Add("Allow", "POST")
-		w.Header().Add("Allow", "DELETE")
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		methodNotAllowed(w, "POST", "DELETE")
+	}
+}
+
+// confChange 提交成员变更，变更被集群应用后才返回成功
+func (h *httpRaftAPI) confChange(w http.ResponseWriter, r *http.Request, cc raftpb.ConfChange, code int) {
+	ctx, cancel := context.WithTimeout(r.Context(), raftAPITimeout)
+	defer cancel()
+	if err := h.cluster.ProposeConfChange(ctx, cc); err != nil {
+		rlog.Error(fmt.Sprintf("Failed to apply conf change %v for node %d (%v)", cc.Type, cc.NodeID, err.Error()))
+		http.Error(w, err.Error(), errorStatus(err))
+		return
+	}
+	rlog.Info(fmt.Sprintf("conf change %v for node %d applied", cc.Type, cc.NodeID))
+	w.WriteHeader(code)
+}
+
+func parseNodeID(id string) (uint64, error) {
+	nodeID, err := strconv.ParseUint(id, 0, 64)
+	if err != nil {
+		rlog.Error(fmt.Sprintf("Failed to convert ID for conf change (%v)", err.Error()))
+		return 0, err
+	}
+	if nodeID == raft.None {
+		return 0, fmt.Errorf("invalid node id %v", id)
+	}
+	return nodeID, nil
+}
+
+func errorStatus(err error) int {
+	switch err {
+	case context.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case ErrRaftNotReady, ErrRaftStopped:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+func methodNotAllowed(w http.ResponseWriter, methods ...string) {
+	for _, method := range methods {
+		w.Header().Add("Allow", method)
 	}
+	http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 }
 
-func serveHTTPRaftAPI(ctx context.Context, port int, confChangeC chan<- raftpb.ConfChange, errorC <-chan error) {
+func isLocalHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// newRaftAPIServer 配置了证书时启用双向TLS，客户端必须持有ca签发的证书，
+// 未配置证书时只允许监听本地地址
+func newRaftAPIServer(host string, port int, tlsInfo transport.TLSInfo, cluster Cluster) (*http.Server, error) {
+	if host == "" {
+		host = "localhost"
+	}
 	srv := &http.Server{
-		Addr: "localhost:" + strconv.Itoa(port),
-		Handler: &httpRaftAPI{
-			confChangeC: confChangeC,
-		},
+		Addr:    net.JoinHostPort(host, strconv.Itoa(port)),
+		Handler: &httpRaftAPI{cluster: cluster},
+	}
+	if tlsInfo.Empty() && tlsInfo.TrustedCAFile == "" {
+		if !isLocalHost(host) {
+			return nil, fmt.Errorf("raft api on %v requires tls, please set raftAPICertFile, raftAPIKeyFile and raftAPICAFile", host)
+		}
+		return srv, nil
+	}
+	if tlsInfo.TrustedCAFile == "" {
+		return nil, fmt.Errorf("raft api requires raftAPICAFile to verify the client certificate")
+	}
+	tlsInfo.ClientCertAuth = true
+	tlsConfig, err := tlsInfo.ServerConfig()
+	if err != nil {
+		return nil, err
+	}
+	srv.TLSConfig = tlsConfig
+	return srv, nil
+}
+
+func serveHTTPRaftAPI(ctx context.Context, host string, port int, tlsInfo transport.TLSInfo, cluster Cluster, errorC <-chan error) {
+	srv, err := newRaftAPIServer(host, port, tlsInfo, cluster)
+	if err != nil {
+		rlog.Error(fmt.Sprintf("raft api is disabled: (%v)", err.Error()))
+		return
 	}
 	go func() {
-		if err := srv.ListenAndServe(); err != nil {
+		var err error
+		if srv.TLSConfig != nil {
+			err = srv.ListenAndServeTLS("", "")
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil {
 			rlog.Error(fmt.Sprintf("ListenAndServe have a err: (%v)", err.Error()))
 		}
 	}()
@@ -0,0 +1,121 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package raft
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/coreos/etcd/pkg/transport"
+	"github.com/coreos/etcd/raft"
+	"github.com/coreos/etcd/raft/raftpb"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockCluster struct {
+	status raft.Status
+	ccs    []raftpb.ConfChange
+	err    error
+}
+
+func (m *mockCluster) Status() raft.Status { return m.status }
+
+func (m *mockCluster) ProposeConfChange(ctx context.Context, cc raftpb.ConfChange) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.ccs = append(m.ccs, cc)
+	switch cc.Type {
+	case raftpb.ConfChangeAddNode, raftpb.ConfChangeAddLearnerNode:
+		m.status.Progress[cc.NodeID] = raft.Progress{IsLearner: cc.Type == raftpb.ConfChangeAddLearnerNode}
+	case raftpb.ConfChangeRemoveNode:
+		delete(m.status.Progress, cc.NodeID)
+	}
+	return nil
+}
+
+func (m *mockCluster) TransferLeadership(ctx context.Context, transferee uint64) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.status.Lead = transferee
+	m.status.RaftState = raft.StateFollower
+	return nil
+}
+
+func doRequest(h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return w
+}
+
+func TestHTTPRaftAPI(t *testing.T) {
+	cluster := &mockCluster{}
+	cluster.status.ID = 1
+	cluster.status.Lead = 1
+	cluster.status.Term = 2
+	cluster.status.RaftState = raft.StateLeader
+	cluster.status.Progress = map[uint64]raft.Progress{1: {Match: 10, Next: 11}, 2: {Match: 10, Next: 11}}
+	h := &httpRaftAPI{cluster: cluster}
+
+	w := doRequest(h, "GET", "/status", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var cs ClusterStatus
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &cs))
+	assert.Equal(t, uint64(1), cs.Leader)
+	assert.Equal(t, "StateLeader", cs.RaftState)
+	assert.Len(t, cs.Progress, 2)
+	assert.Equal(t, uint64(10), cs.Progress[2].Match)
+
+	// 先添加为只读节点，再提升为共识节点
+	assert.Equal(t, http.StatusBadRequest, doRequest(h, "POST", "/members/3", "").Code)
+	assert.Equal(t, http.StatusBadRequest, doRequest(h, "POST", "/members/3/promote", "").Code)
+	assert.Equal(t, http.StatusCreated, doRequest(h, "POST", "/members/3?learner=true", "http://127.0.0.1:9023").Code)
+	assert.Equal(t, http.StatusOK, doRequest(h, "POST", "/members/3/promote", "").Code)
+	assert.Equal(t, http.StatusOK, doRequest(h, "DELETE", "/members/2", "").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, doRequest(h, "PUT", "/members/2", "").Code)
+	assert.Equal(t, http.StatusBadRequest, doRequest(h, "DELETE", "/members/0", "").Code)
+	// 兼容旧接口
+	assert.Equal(t, http.StatusCreated, doRequest(h, "POST", "/4", "http://127.0.0.1:9024").Code)
+	assert.Equal(t, http.StatusAccepted, doRequest(h, "DELETE", "/4", "").Code)
+	assert.Len(t, cluster.ccs, 5)
+	assert.Equal(t, raftpb.ConfChangeAddLearnerNode, cluster.ccs[0].Type)
+	assert.Equal(t, []byte("http://127.0.0.1:9023"), cluster.ccs[0].Context)
+	assert.Equal(t, raftpb.ConfChangeAddNode, cluster.ccs[1].Type)
+	assert.Equal(t, uint64(3), cluster.ccs[1].NodeID)
+	assert.Equal(t, raftpb.ConfChangeRemoveNode, cluster.ccs[2].Type)
+
+	// 只读节点不能成为leader
+	cluster.status.Progress[5] = raft.Progress{IsLearner: true}
+	assert.Equal(t, http.StatusBadRequest, doRequest(h, "POST", "/leader/5", "").Code)
+	assert.Equal(t, http.StatusOK, doRequest(h, "POST", "/leader/3", "").Code)
+	assert.Equal(t, uint64(3), cluster.status.Lead)
+	// 非leader节点无法提升只读节点
+	assert.Equal(t, http.StatusConflict, doRequest(h, "POST", "/members/5/promote", "").Code)
+
+	cluster.err = context.DeadlineExceeded
+	assert.Equal(t, http.StatusGatewayTimeout, doRequest(h, "DELETE", "/members/3", "").Code)
+	cluster.err = ErrRaftNotReady
+	assert.Equal(t, http.StatusServiceUnavailable, doRequest(h, "POST", "/leader/1", "").Code)
+}
+
+func TestNewRaftAPIServer(t *testing.T) {
+	srv, err := newRaftAPIServer("", 9121, transport.TLSInfo{}, &mockCluster{})
+	assert.Nil(t, err)
+	assert.Equal(t, "localhost:9121", srv.Addr)
+	assert.Nil(t, srv.TLSConfig)
+
+	// 监听非本地地址必须启用双向TLS
+	_, err = newRaftAPIServer("0.0.0.0", 9121, transport.TLSInfo{}, &mockCluster{})
+	assert.NotNil(t, err)
+	_, err = newRaftAPIServer("0.0.0.0", 9121, transport.TLSInfo{CertFile: "server.crt", KeyFile: "server.key"}, &mockCluster{})
+	assert.NotNil(t, err)
+	_, err = newRaftAPIServer("0.0.0.0", 9121, transport.TLSInfo{TrustedCAFile: "ca.crt"}, &mockCluster{})
+	assert.NotNil(t, err)
+}
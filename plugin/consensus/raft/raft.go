@@ -6,6 +6,7 @@ package raft
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -17,7 +18,9 @@ import (
 	"github.com/33cn/chain33/types"
 	"github.com/coreos/etcd/etcdserver/stats"
 	"github.com/coreos/etcd/pkg/fileutil"
+	"github.com/coreos/etcd/pkg/idutil"
 	typec "github.com/coreos/etcd/pkg/types"
+	"github.com/coreos/etcd/pkg/wait"
 	"github.com/coreos/etcd/raft"
 	"github.com/coreos/etcd/raft/raftpb"
 	"github.com/coreos/etcd/rafthttp"
@@ -31,6 +34,22 @@ var (
 	isReady bool
 )
 
+// Errors define
+var (
+	ErrRaftNotReady = errors.New("ErrRaftNotReady")
+	ErrRaftStopped  = errors.New("ErrRaftStopped")
+)
+
+// Cluster raft集群的成员管理接口
+type Cluster interface {
+	// Status 返回当前节点的raft状态，只有leader节点包含各成员的同步进度
+	Status() raft.Status
+	// ProposeConfChange 提交成员变更，并等待变更被集群提交并应用
+	ProposeConfChange(ctx context.Context, cc raftpb.ConfChange) error
+	// TransferLeadership 转移leader，并等待新的leader产生
+	TransferLeadership(ctx context.Context, transferee uint64) error
+}
+
 type raftNode struct {
 	proposeC         <-chan *types.Block
 	confChangeC      <-chan raftpb.ConfChange
@@ -43,7 +62,10 @@ type raftNode struct {
 	join             bool
 	waldir           string
 	snapdir          string
-	getSnapshot      func() ([]byte, error)
+	getSnapshot      func(committedHeight int64) ([]byte, error)
+	recoverSnapshot  func(snapshot []byte, leaderURL func() (string, error)) error
+	blockHandler     http.Handler
+	committedHeight  int64
 	lastIndex        uint64
 	confState        raftpb.ConfState
 	snapshotIndex    uint64
@@ -63,11 +85,18 @@ type raftNode struct {
 	validatorC chan bool
 	//用于判断该节点是否重启过
 	restartC chan struct{}
+	//成员变更的ID生成器和提交等待
+	idGen *idutil.Generator
+	w     wait.Wait
+	//集群中各节点的url，用于从leader同步区块
+	peerMu   sync.RWMutex
+	peerURLs map[uint64]string
 }
 
 // NewRaftNode create raft node
-func NewRaftNode(ctx context.Context, id int, join bool, peers []string, readOnlyPeers []string, addPeers []string, getSnapshot func() ([]byte, error), proposeC <-chan *types.Block,
-	confChangeC <-chan raftpb.ConfChange) (<-chan *types.Block, <-chan error, <-chan *snap.Snapshotter, <-chan bool) {
+func NewRaftNode(ctx context.Context, id int, join bool, peers []string, readOnlyPeers []string, addPeers []string, getSnapshot func(int64) ([]byte, error),
+	recoverSnapshot func([]byte, func() (string, error)) error, blockHandler http.Handler, proposeC <-chan *types.Block,
+	confChangeC <-chan raftpb.ConfChange) (<-chan *types.Block, <-chan error, <-chan *snap.Snapshotter, <-chan bool, Cluster) {
 
 	rlog.Info("Enter consensus raft")
 	// commit channel
@@ -86,15 +115,21 @@ func NewRaftNode(ctx context.Context, id int, join bool, peers []string, readOnl
 		waldir:           fmt.Sprintf("chain33_raft-%d%swal", id, string(os.PathSeparator)),
 		snapdir:          fmt.Sprintf("chain33_raft-%d%ssnap", id, string(os.PathSeparator)),
 		getSnapshot:      getSnapshot,
+		recoverSnapshot:  recoverSnapshot,
+		blockHandler:     blockHandler,
+		committedHeight:  -1,
 		snapCount:        defaultSnapCount,
 		validatorC:       make(chan bool),
 		snapshotterReady: make(chan *snap.Snapshotter, 1),
 		restartC:         make(chan struct{}, 1),
 		ctx:              ctx,
+		idGen:            idutil.NewGenerator(uint16(id), time.Now()),
+		w:                wait.New(),
+		peerURLs:         make(map[uint64]string),
 	}
 	go rc.startRaft()
 
-	return commitC, errorC, rc.snapshotterReady, rc.validatorC
+	return commitC, errorC, rc.snapshotterReady, rc.validatorC, rc
 }
 
 //  启动raft节点
@@ -131,16 +166,21 @@ func (rc *raftNode) startRaft() {
 	if len(rc.readOnlyPeers) > 0 && rc.id > len(rc.bootstrapPeers) {
 		rc.join = true
 	}
+	var node raft.Node
 	if oldwal {
 		rc.restartC <- struct{}{}
-		rc.node = raft.RestartNode(c)
+		node = raft.RestartNode(c)
 	} else {
 		startPeers := rpeers
 		if rc.join {
 			startPeers = nil
 		}
-		rc.node = raft.StartNode(c, startPeers)
+		node = raft.StartNode(c, startPeers)
 	}
+	//管理接口可能在节点启动前被调用
+	rc.stopMu.Lock()
+	rc.node = node
+	rc.stopMu.Unlock()
 
 	rc.transport = &rafthttp.Transport{
 		ID:          typec.ID(rc.id),
@@ -161,22 +201,28 @@ func (rc *raftNode) startRaft() {
 			rc.transport.AddPeer(typec.ID(i+1), []string{rc.bootstrapPeers[i]})
 		}
 	}
+	for i, peer := range rc.allPeers() {
+		if peer != "" {
+			rc.setPeerURL(uint64(i+1), peer)
+		}
+	}
 
 	// 启动网络监听
 	go rc.serveRaft()
 	go rc.serveChannels()
 
+	//重启前可能没有同步到本地快照的高度
+	if snapshot, err := rc.raftStorage.Snapshot(); err == nil && !raft.IsEmptySnap(snapshot) {
+		rc.recoverSnapshot(snapshot.Data, rc.leaderURL)
+	}
+
 	//定时轮询watch leader 状态是否改变，更新validator
 	go rc.updateValidator()
 }
 
 // 网络监听
 func (rc *raftNode) serveRaft() {
-	var peers []string
-	//TODO: 配置太繁琐，有风险
-	peers = append(peers, rc.bootstrapPeers...)
-	peers = append(peers, rc.readOnlyPeers...)
-	peers = append(peers, rc.addPeers...)
+	peers := rc.allPeers()
 	nodeURL, err := url.Parse(peers[rc.id-1])
 	if err != nil {
 		rlog.Error(fmt.Sprintf("raft: Failed parsing URL (%v)", err.Error()))
@@ -188,7 +234,11 @@ func (rc *raftNode) serveRaft() {
 		rlog.Error(fmt.Sprintf("raft: Failed to listen rafthttp (%v)", err.Error()))
 		panic(err)
 	}
-	raftSrv := &http.Server{Handler: rc.transport.Handler()}
+	//同一端口上同时提供raft消息和快照恢复时的区块下载
+	mux := http.NewServeMux()
+	mux.Handle("/", rc.transport.Handler())
+	mux.Handle(raftBlocksPath, rc.blockHandler)
+	raftSrv := &http.Server{Handler: mux}
 	err = raftSrv.Serve(ln)
 	if err != nil {
 		rlog.Error(fmt.Sprintf("raft: Failed to serve rafthttp (%v)", err.Error()))
@@ -216,7 +266,6 @@ func (rc *raftNode) serveChannels() {
 	defer ticker.Stop()
 
 	go func() {
-		var confChangeCount uint64
 		// 通过propose和proposeConfchange方法往RaftNode发通知
 		for rc.proposeC != nil && rc.confChangeC != nil {
 			select {
@@ -238,8 +287,7 @@ func (rc *raftNode) serveChannels() {
 				if !ok {
 					rc.confChangeC = nil
 				} else {
-					confChangeCount++
-					cc.ID = confChangeCount
+					cc.ID = rc.idGen.Next()
 					err = rc.node.ProposeConfChange(context.TODO(), cc)
 					if err != nil {
 						rlog.Error(fmt.Sprintf("rc.node.ProposeConfChange:%v", err.Error()))
@@ -325,10 +373,105 @@ func (rc *raftNode) updateValidator() {
 
 	}
 }
-func (rc *raftNode) Status() raft.Status {
+
+func (rc *raftNode) allPeers() []string {
+	var peers []string
+	//TODO: 配置太繁琐，有风险
+	peers = append(peers, rc.bootstrapPeers...)
+	peers = append(peers, rc.readOnlyPeers...)
+	peers = append(peers, rc.addPeers...)
+	return peers
+}
+
+func (rc *raftNode) setPeerURL(id uint64, url string) {
+	rc.peerMu.Lock()
+	defer rc.peerMu.Unlock()
+	if url == "" {
+		delete(rc.peerURLs, id)
+		return
+	}
+	rc.peerURLs[id] = url
+}
+
+// leaderURL 返回leader节点的url
+func (rc *raftNode) leaderURL() (string, error) {
+	lead := rc.Status().Lead
+	if lead == raft.None || lead == uint64(rc.id) {
+		return "", ErrRaftNoLeader
+	}
+	rc.peerMu.RLock()
+	defer rc.peerMu.RUnlock()
+	url, ok := rc.peerURLs[lead]
+	if !ok {
+		return "", fmt.Errorf("url of leader %d not found", lead)
+	}
+	return url, nil
+}
+
+func (rc *raftNode) getNode() raft.Node {
 	rc.stopMu.RLock()
 	defer rc.stopMu.RUnlock()
-	return rc.node.Status()
+	return rc.node
+}
+
+// Status 返回当前节点的raft状态
+func (rc *raftNode) Status() raft.Status {
+	node := rc.getNode()
+	if node == nil {
+		return raft.Status{ID: uint64(rc.id)}
+	}
+	return node.Status()
+}
+
+// ProposeConfChange 提交成员变更，直到变更被应用或者ctx超时才返回
+func (rc *raftNode) ProposeConfChange(ctx context.Context, cc raftpb.ConfChange) error {
+	node := rc.getNode()
+	if node == nil {
+		return ErrRaftNotReady
+	}
+	cc.ID = rc.idGen.Next()
+	ch := rc.w.Register(cc.ID)
+	if err := node.ProposeConfChange(ctx, cc); err != nil {
+		rc.w.Trigger(cc.ID, nil)
+		return err
+	}
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		//已有未应用的成员变更时，raft会丢弃该变更，这里只能超时返回
+		rc.w.Trigger(cc.ID, nil)
+		return ctx.Err()
+	case <-rc.ctx.Done():
+		return ErrRaftStopped
+	}
+}
+
+// TransferLeadership 转移leader给指定节点，直到新的leader产生或者ctx超时才返回
+func (rc *raftNode) TransferLeadership(ctx context.Context, transferee uint64) error {
+	node := rc.getNode()
+	if node == nil {
+		return ErrRaftNotReady
+	}
+	status := node.Status()
+	if status.Lead == transferee {
+		return nil
+	}
+	node.TransferLeadership(ctx, status.Lead, transferee)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if node.Status().Lead == transferee {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-rc.ctx.Done():
+			return ErrRaftStopped
+		}
+	}
 }
 
 func (rc *raftNode) replayWAL() *wal.WAL {
@@ -342,6 +485,9 @@ func (rc *raftNode) replayWAL() *wal.WAL {
 	rc.raftStorage = raft.NewMemoryStorage()
 	if snapshot != nil {
 		rc.raftStorage.ApplySnapshot(*snapshot)
+		if header, err := decodeSnapshot(snapshot.Data); err == nil {
+			rc.committedHeight = header.Height
+		}
 	}
 	rc.raftStorage.SetHardState(st)
 
@@ -387,11 +533,13 @@ func (rc *raftNode) maybeTriggerSnapshot() {
 	snapshotIndex := rc.snapshotIndex
 	confState := rc.confState
 	rlog.Info(fmt.Sprintf("start snapshot [applied index: %d | last snapshot index: %d]", appliedIndex, snapshotIndex))
-	ents, err := rc.raftStorage.Entries(appliedIndex, appliedIndex+1, 2)
+	//快照记录已提交的区块高度和状态哈希，本地还没有执行到该高度或者获取失败时不压缩日志，下次再试
+	data, err := rc.getSnapshot(rc.committedHeight)
 	if err != nil {
 		rlog.Error(fmt.Sprintf("Err happened when get snapshot:%v", err.Error()))
+		return
 	}
-	snapShot, err := rc.raftStorage.CreateSnapshot(appliedIndex, &confState, ents[0].Data)
+	snapShot, err := rc.raftStorage.CreateSnapshot(appliedIndex, &confState, data)
 	if err != nil {
 		panic(err)
 	}
@@ -403,7 +551,14 @@ func (rc *raftNode) maybeTriggerSnapshot() {
 	if appliedIndex > snapshotCatchUpEntriesN {
 		compactIndex = appliedIndex - snapshotCatchUpEntriesN
 	}
-	if err := rc.raftStorage.Compact(compactIndex); err != nil {
+	//leader保留活跃节点还未同步的日志，只有长时间离线的节点才需要通过快照恢复
+	status := rc.node.Status()
+	for id, pr := range status.Progress {
+		if id != uint64(rc.id) && pr.RecentActive && pr.Match > 0 && pr.Match < compactIndex {
+			compactIndex = pr.Match
+		}
+	}
+	if err := rc.raftStorage.Compact(compactIndex); err != nil && err != raft.ErrCompacted {
 		panic(err)
 	}
 
@@ -422,7 +577,13 @@ func (rc *raftNode) publishSnapshot(snapshotToSave raftpb.Snapshot) {
 	if snapshotToSave.Metadata.Index <= rc.appliedIndex {
 		rlog.Error(fmt.Sprintf("snapshot index [%d] should > progress.appliedIndex [%d] + 1", snapshotToSave.Metadata.Index, rc.appliedIndex))
 	}
-	rc.commitC <- nil // trigger kvstore to load snapshot
+	//从leader同步到快照高度的区块
+	if err := rc.recoverSnapshot(snapshotToSave.Data, rc.leaderURL); err != nil {
+		rlog.Error(fmt.Sprintf("recover from snapshot at index %d: %v", snapshotToSave.Metadata.Index, err.Error()))
+	}
+	if header, err := decodeSnapshot(snapshotToSave.Data); err == nil {
+		rc.committedHeight = header.Height
+	}
 
 	rc.confState = snapshotToSave.Metadata.ConfState
 	rc.snapshotIndex = snapshotToSave.Metadata.Index
@@ -490,6 +651,8 @@ func (rc *raftNode) publishEntries(ents []raftpb.Entry) bool {
 			block := &types.Block{}
 			if err := proto.Unmarshal(ents[i].Data, block); err != nil {
 				rlog.Error(fmt.Sprintf("failed to unmarshal: %v", err.Error()))
+			} else {
+				rc.committedHeight = block.Height
 			}
 			select {
 			case rc.commitC <- block:
@@ -501,10 +664,13 @@ func (rc *raftNode) publishEntries(ents []raftpb.Entry) bool {
 			var cc raftpb.ConfChange
 			cc.Unmarshal(ents[i].Data)
 			rc.confState = *rc.node.ApplyConfChange(cc)
+			//通知等待该变更的管理接口
+			rc.w.Trigger(cc.ID, nil)
 			switch cc.Type {
 			case raftpb.ConfChangeAddNode:
 				if len(cc.Context) > 0 {
 					rc.transport.AddPeer(typec.ID(cc.NodeID), []string{string(cc.Context)})
+					rc.setPeerURL(cc.NodeID, string(cc.Context))
 				}
 			case raftpb.ConfChangeRemoveNode:
 				if cc.NodeID == uint64(rc.id) {
@@ -512,9 +678,11 @@ func (rc *raftNode) publishEntries(ents []raftpb.Entry) bool {
 					return false
 				}
 				rc.transport.RemovePeer(typec.ID(cc.NodeID))
+				rc.setPeerURL(cc.NodeID, "")
 			case raftpb.ConfChangeAddLearnerNode:
 				if len(cc.Context) > 0 {
 					rc.transport.AddPeer(typec.ID(cc.NodeID), []string{string(cc.Context)})
+					rc.setPeerURL(cc.NodeID, string(cc.Context))
 				}
 				isReady = true
 			}
@@ -551,9 +719,15 @@ func (rc *raftNode) entriesToApply(ents []raftpb.Entry) (nents []raftpb.Entry) {
 func (rc *raftNode) Process(ctx context.Context, m raftpb.Message) error {
 	return rc.node.Step(ctx, m)
 }
-func (rc *raftNode) IsIDRemoved(id uint64) bool                           { return false }
-func (rc *raftNode) ReportUnreachable(id uint64)                          {}
-func (rc *raftNode) ReportSnapshot(id uint64, status raft.SnapshotStatus) {}
+func (rc *raftNode) IsIDRemoved(id uint64) bool { return false }
+
+// ReportUnreachable 通知raft节点不可达，leader会转为探测状态重新同步日志
+func (rc *raftNode) ReportUnreachable(id uint64) { rc.node.ReportUnreachable(id) }
+
+// ReportSnapshot 通知raft快照发送结果，发送失败时leader会重新发送快照
+func (rc *raftNode) ReportSnapshot(id uint64, status raft.SnapshotStatus) {
+	rc.node.ReportSnapshot(id, status)
+}
 func (rc *raftNode) addReadOnlyPeers() {
 	isReady = true
 	//信息校验，防止是空数组
@@ -0,0 +1,263 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package raft
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+)
+
+const (
+	// leader通过raft节点的监听端口提供区块下载
+	raftBlocksPath = "/chain33/blocks"
+	// 每次最多下载的区块数
+	maxSyncBlocks int64 = 100
+)
+
+var (
+	snapshotRetryInterval = 3 * time.Second
+	blocksClient          = &http.Client{Timeout: 30 * time.Second}
+)
+
+// Errors define
+var (
+	ErrRaftNoLeader         = errors.New("ErrRaftNoLeader")
+	ErrSnapshotNotReady     = errors.New("ErrSnapshotNotReady")
+	ErrSnapshotHashMismatch = errors.New("ErrSnapshotHashMismatch")
+)
+
+// decodeSnapshot raft快照中记录的是区块头，包含高度、区块哈希和状态哈希
+// 早期版本的快照数据是区块，解码后没有区块哈希
+func decodeSnapshot(data []byte) (*types.Header, error) {
+	header := &types.Header{}
+	if len(data) == 0 {
+		return header, nil
+	}
+	if err := types.Decode(data, header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// getSnapshot 快照的高度为raft日志中已经提交的区块高度，本地还没有执行到该高度时跳过这次快照，
+// 否则快照记录的状态落后于压缩掉的日志，通过消息队列从blockchain读取，不需要持有共识模块的锁
+func (client *Client) getSnapshot(committedHeight int64) ([]byte, error) {
+	api := client.GetAPI()
+	if api == nil || committedHeight < 0 {
+		return nil, ErrSnapshotNotReady
+	}
+	last, err := api.GetLastHeader()
+	if err != nil {
+		return nil, err
+	}
+	if last.Height < committedHeight {
+		rlog.Info("skip raft snapshot", "height", last.Height, "committedHeight", committedHeight)
+		return nil, ErrSnapshotNotReady
+	}
+	headers, err := api.GetHeaders(&types.ReqBlocks{Start: committedHeight, End: committedHeight})
+	if err != nil {
+		return nil, err
+	}
+	if len(headers.Items) != 1 {
+		return nil, types.ErrBlockNotFound
+	}
+	header := headers.Items[0]
+	rlog.Info("create raft snapshot", "height", header.Height, "hash", common.ToHex(header.Hash), "stateHash", common.ToHex(header.StateHash))
+	return types.Encode(header), nil
+}
+
+// recoverFromSnapshot 落后的节点收到leader的快照后，从leader下载并执行到快照高度的区块，
+// 后台同步，不阻塞raft的消息处理
+func (client *Client) recoverFromSnapshot(snapshot []byte, leaderURL func() (string, error)) error {
+	header, err := decodeSnapshot(snapshot)
+	if err != nil {
+		rlog.Error("recoverFromSnapshot decode", "err", err)
+		return err
+	}
+	client.snapMu.Lock()
+	defer client.snapMu.Unlock()
+	if client.snapTarget == nil || header.Height > client.snapTarget.Height {
+		client.snapTarget = header
+	}
+	if client.snapSyncing {
+		return nil
+	}
+	client.snapSyncing = true
+	go client.syncToSnapshot(leaderURL)
+	return nil
+}
+
+func (client *Client) syncToSnapshot(leaderURL func() (string, error)) {
+	for {
+		client.snapMu.Lock()
+		target := client.snapTarget
+		client.snapMu.Unlock()
+
+		done, err := client.syncBlocks(target, leaderURL)
+		if done {
+			client.snapMu.Lock()
+			//同步期间收到了更新的快照，继续同步
+			if client.snapTarget == target {
+				client.snapSyncing = false
+				client.snapMu.Unlock()
+				return
+			}
+			client.snapMu.Unlock()
+			continue
+		}
+		if err == nil {
+			continue
+		}
+		rlog.Error("syncToSnapshot", "target", target.Height, "err", err)
+		select {
+		case <-client.ctx.Done():
+			return
+		case <-time.After(snapshotRetryInterval):
+		}
+	}
+}
+
+// syncBlocks 同步一批区块，到达快照高度后校验区块哈希和状态哈希
+func (client *Client) syncBlocks(target *types.Header, leaderURL func() (string, error)) (bool, error) {
+	api := client.GetAPI()
+	if api == nil {
+		return false, ErrSnapshotNotReady
+	}
+	last, err := api.GetLastHeader()
+	if err != nil {
+		return false, err
+	}
+	if last.Height >= target.Height {
+		return true, client.checkSnapshot(target)
+	}
+	url, err := leaderURL()
+	if err != nil {
+		return false, err
+	}
+	end := last.Height + maxSyncBlocks
+	if end > target.Height {
+		end = target.Height
+	}
+	details, err := fetchBlocks(url, last.Height+1, end)
+	if err != nil {
+		return false, err
+	}
+	if len(details.GetItems()) == 0 {
+		return false, types.ErrBlockNotFound
+	}
+	for _, detail := range details.GetItems() {
+		if err := client.syncBlock(detail.GetBlock()); err != nil {
+			return false, err
+		}
+	}
+	rlog.Info("sync blocks from leader", "start", last.Height+1, "end", end, "target", target.Height)
+	return false, nil
+}
+
+func (client *Client) checkSnapshot(target *types.Header) error {
+	//早期版本的快照没有区块哈希，无法校验
+	if len(target.Hash) == 0 {
+		return nil
+	}
+	headers, err := client.GetAPI().GetHeaders(&types.ReqBlocks{Start: target.Height, End: target.Height})
+	if err != nil {
+		return err
+	}
+	if len(headers.Items) != 1 || !bytes.Equal(headers.Items[0].Hash, target.Hash) || !bytes.Equal(headers.Items[0].StateHash, target.StateHash) {
+		rlog.Error("local chain is different from the raft snapshot", "height", target.Height, "hash", common.ToHex(target.Hash), "stateHash", common.ToHex(target.StateHash))
+		return ErrSnapshotHashMismatch
+	}
+	rlog.Info("recover from raft snapshot", "height", target.Height, "hash", common.ToHex(target.Hash))
+	return nil
+}
+
+// syncBlock 与p2p同步的区块一样交给blockchain执行，执行后的状态哈希必须与区块一致
+func (client *Client) syncBlock(block *types.Block) error {
+	qclient := client.GetQueueClient()
+	msg := qclient.NewMessage("blockchain", types.EventSyncBlock, &types.BlockPid{Pid: "raft", Block: block})
+	if err := qclient.Send(msg, true); err != nil {
+		return err
+	}
+	resp, err := qclient.Wait(msg)
+	if err != nil {
+		return err
+	}
+	if reply := resp.GetData().(*types.Reply); !reply.GetIsOk() {
+		return errors.New(string(reply.GetMsg()))
+	}
+	return nil
+}
+
+func fetchBlocks(url string, start, end int64) (*types.BlockDetails, error) {
+	resp, err := blocksClient.Get(fmt.Sprintf("%s%s?start=%d&end=%d", strings.TrimRight(url, "/"), raftBlocksPath, start, end))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch blocks %v: %v", resp.Status, strings.TrimSpace(string(data)))
+	}
+	details := &types.BlockDetails{}
+	if err := types.Decode(data, details); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// serveBlocks 向落后的节点提供区块下载
+func (client *Client) serveBlocks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		methodNotAllowed(w, "GET")
+		return
+	}
+	start, err1 := strconv.ParseInt(r.URL.Query().Get("start"), 10, 64)
+	end, err2 := strconv.ParseInt(r.URL.Query().Get("end"), 10, 64)
+	if err1 != nil || err2 != nil || start < 0 || end < start {
+		http.Error(w, "invalid block range", http.StatusBadRequest)
+		return
+	}
+	if end-start >= maxSyncBlocks {
+		end = start + maxSyncBlocks - 1
+	}
+	api := client.GetAPI()
+	if api == nil {
+		http.Error(w, ErrSnapshotNotReady.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	last, err := api.GetLastHeader()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if end > last.Height {
+		end = last.Height
+	}
+	if start > end {
+		http.Error(w, types.ErrBlockNotFound.Error(), http.StatusNotFound)
+		return
+	}
+	details, err := api.GetBlocks(&types.ReqBlocks{Start: start, End: end})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if _, err := w.Write(types.Encode(details)); err != nil {
+		rlog.Error("serveBlocks", "err", err)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package raft
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apimocks "github.com/33cn/chain33/client/mocks"
+	drivers "github.com/33cn/chain33/system/consensus"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newSnapshotClient(api *apimocks.QueueProtocolAPI) *Client {
+	client := &Client{BaseClient: drivers.NewBaseClient(&types.Consensus{})}
+	client.SetAPI(api)
+	return client
+}
+
+func TestGetSnapshot(t *testing.T) {
+	api := new(apimocks.QueueProtocolAPI)
+	api.On("GetLastHeader").Return(&types.Header{Height: 10}, nil)
+	api.On("GetHeaders", &types.ReqBlocks{Start: 5, End: 5}).Return(&types.Headers{Items: []*types.Header{{Height: 5, Hash: []byte("hash5"), StateHash: []byte("state5")}}}, nil)
+	api.On("GetHeaders", &types.ReqBlocks{Start: 10, End: 10}).Return(&types.Headers{Items: []*types.Header{{Height: 10, Hash: []byte("hash10"), StateHash: []byte("state10")}}}, nil)
+	client := newSnapshotClient(api)
+
+	// 快照高度不超过raft已提交的区块高度
+	data, err := client.getSnapshot(5)
+	assert.Nil(t, err)
+	header, err := decodeSnapshot(data)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), header.Height)
+	assert.Equal(t, []byte("state5"), header.StateHash)
+
+	data, err = client.getSnapshot(10)
+	assert.Nil(t, err)
+	header, err = decodeSnapshot(data)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), header.Height)
+	assert.Equal(t, []byte("hash10"), header.Hash)
+
+	// 本地还没有执行到已提交的高度时跳过快照
+	_, err = client.getSnapshot(20)
+	assert.Equal(t, ErrSnapshotNotReady, err)
+
+	_, err = client.getSnapshot(-1)
+	assert.Equal(t, ErrSnapshotNotReady, err)
+
+	// 早期版本的快照数据是区块
+	header, err = decodeSnapshot(types.Encode(&types.Block{Height: 3}))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), header.Height)
+	assert.Nil(t, header.Hash)
+}
+
+func TestServeBlocks(t *testing.T) {
+	api := new(apimocks.QueueProtocolAPI)
+	api.On("GetLastHeader").Return(&types.Header{Height: 150}, nil)
+	api.On("GetBlocks", mock.Anything).Return(func(req *types.ReqBlocks) *types.BlockDetails {
+		details := &types.BlockDetails{}
+		for i := req.Start; i <= req.End; i++ {
+			details.Items = append(details.Items, &types.BlockDetail{Block: &types.Block{Height: i}})
+		}
+		return details
+	}, nil)
+	client := newSnapshotClient(api)
+	mux := http.NewServeMux()
+	mux.HandleFunc(raftBlocksPath, client.serveBlocks)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	details, err := fetchBlocks(srv.URL, 1, 10)
+	assert.Nil(t, err)
+	assert.Len(t, details.Items, 10)
+	assert.Equal(t, int64(10), details.Items[9].Block.Height)
+
+	// 每次最多返回maxSyncBlocks个区块，且不超过最新高度
+	details, err = fetchBlocks(srv.URL, 1, 1000)
+	assert.Nil(t, err)
+	assert.Len(t, details.Items, int(maxSyncBlocks))
+	details, err = fetchBlocks(srv.URL, 120, 200)
+	assert.Nil(t, err)
+	assert.Len(t, details.Items, 31)
+
+	_, err = fetchBlocks(srv.URL, 200, 210)
+	assert.NotNil(t, err)
+	_, err = fetchBlocks(srv.URL, 10, 1)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,166 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// raftctl is the client of the raft cluster management api.
+// Each membership change returns after it is committed and applied by the cluster,
+// so the nodes can be rotated one by one: add the new node as a learner, wait until
+// it catches up in the status, promote it, then remove the old node.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/33cn/plugin/plugin/consensus/raft"
+	"github.com/coreos/etcd/pkg/transport"
+)
+
+var (
+	addr    = flag.String("addr", "http://localhost:9121", "raft api address, use https with the client certificate")
+	cert    = flag.String("cert", "", "client certificate file")
+	key     = flag.String("key", "", "client private key file")
+	cacert  = flag.String("cacert", "", "ca certificate file to verify the raft api server")
+	timeout = flag.Duration("timeout", 30*time.Second, "request timeout")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage: raftctl [flags] command [args]
+
+Commands:
+  status                   show leader, term, applied index and member progress
+  add <id> <url>           add a voting node
+  add-learner <id> <url>   add a learner node which only replicates the log
+  promote <id>             promote a learner to a voting node, must be sent to the leader
+  remove <id>              remove a node
+  transfer <id>            transfer the leadership to the node
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	args := flag.Args()
+	if len(args) == 0 {
+		usage()
+		os.Exit(2)
+	}
+	client, err := newClient()
+	if err != nil {
+		exit(err)
+	}
+	switch {
+	case args[0] == "status" && len(args) == 1:
+		err = status(client)
+	case args[0] == "add" && len(args) == 3:
+		err = request(client, "POST", "/members/"+args[1], args[2])
+	case args[0] == "add-learner" && len(args) == 3:
+		err = request(client, "POST", "/members/"+args[1]+"?learner=true", args[2])
+	case args[0] == "promote" && len(args) == 2:
+		err = request(client, "POST", "/members/"+args[1]+"/promote", "")
+	case args[0] == "remove" && len(args) == 2:
+		err = request(client, "DELETE", "/members/"+args[1], "")
+	case args[0] == "transfer" && len(args) == 2:
+		err = request(client, "POST", "/leader/"+args[1], "")
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		exit(err)
+	}
+}
+
+func newClient() (*http.Client, error) {
+	client := &http.Client{Timeout: *timeout}
+	if !strings.HasPrefix(*addr, "https://") {
+		return client, nil
+	}
+	tlsInfo := transport.TLSInfo{CertFile: *cert, KeyFile: *key, TrustedCAFile: *cacert}
+	tlsConfig, err := tlsInfo.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	return client, nil
+}
+
+func do(client *http.Client, method, path, body string) ([]byte, error) {
+	req, err := http.NewRequest(method, strings.TrimRight(*addr, "/")+path, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("%v: %v", resp.Status, strings.TrimSpace(string(data)))
+	}
+	return data, nil
+}
+
+func request(client *http.Client, method, path, body string) error {
+	if _, err := do(client, method, path, body); err != nil {
+		return err
+	}
+	fmt.Println("ok")
+	return nil
+}
+
+func status(client *http.Client) error {
+	data, err := do(client, "GET", "/status", "")
+	if err != nil {
+		return err
+	}
+	var cs raft.ClusterStatus
+	if err := json.Unmarshal(data, &cs); err != nil {
+		return err
+	}
+	fmt.Printf("id: %d\nstate: %s\nleader: %d\nterm: %d\ncommit: %d\napplied: %d\n",
+		cs.ID, cs.RaftState, cs.Leader, cs.Term, cs.Commit, cs.Applied)
+	if cs.LeadTransferee != 0 {
+		fmt.Printf("leadTransferee: %d\n", cs.LeadTransferee)
+	}
+	if len(cs.Progress) == 0 {
+		if cs.ID != cs.Leader {
+			fmt.Println("member progress is only available on the leader")
+		}
+		return nil
+	}
+	ids := make([]uint64, 0, len(cs.Progress))
+	for id := range cs.Progress {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	fmt.Printf("\n%-6s %-8s %-10s %-10s %-10s %-6s\n", "ID", "ROLE", "MATCH", "NEXT", "STATE", "ACTIVE")
+	for _, id := range ids {
+		pr := cs.Progress[id]
+		role := "voter"
+		if pr.IsLearner {
+			role = "learner"
+		}
+		fmt.Printf("%-6d %-8s %-10d %-10d %-10s %-6v\n", id, role, pr.Match, pr.Next, pr.State, pr.RecentActive)
+	}
+	return nil
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,372 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package remotesigner 共识节点和远程签名服务之间的连接和消息收发，供tendermint和dpos共用
+package remotesigner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/common/log/log15"
+)
+
+// ReqPubKey is the request type for the validator pubkey, the other types are defined by the consensus
+const ReqPubKey = "pubkey"
+
+const (
+	maxMsgSize = 1024 * 1024
+	timeout    = 3 * time.Second
+)
+
+var rslog = log15.New("module", "remotesigner")
+
+// Errors define
+var (
+	ErrUnauthorized   = errors.New("Error remote signer peer is not authorized")
+	ErrMsgSize        = errors.New("Error remote signer message size")
+	ErrUnknownRequest = errors.New("Error remote signer unknown request")
+)
+
+// Request is sent to the remote signer, Data is the encoded message to be signed
+type Request struct {
+	Type    string `json:"type"`
+	ChainID string `json:"chainID,omitempty"`
+	Data    []byte `json:"data,omitempty"`
+}
+
+// Response is the reply of the remote signer
+type Response struct {
+	Data  []byte `json:"data,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// SecretConn is the encrypted connection authenticated by the remote pubkey
+type SecretConn interface {
+	net.Conn
+	RemotePubKey() crypto.PubKey
+}
+
+// Handshake makes the SecretConn on conn with the local key
+type Handshake func(conn net.Conn, key crypto.PrivKey) (SecretConn, error)
+
+// Handler signs the request, it returns the signed data
+type Handler func(req *Request) ([]byte, error)
+
+// WriteMsg writes the length prefixed json message
+func WriteMsg(w io.Writer, msg interface{}) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if len(data) > maxMsgSize {
+		return ErrMsgSize
+	}
+	buf := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(buf[:4], uint32(len(data)))
+	copy(buf[4:], data)
+	_, err = w.Write(buf)
+	return err
+}
+
+// ReadMsg reads the length prefixed json message
+func ReadMsg(r io.Reader, msg interface{}) error {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size == 0 || size > maxMsgSize {
+		return ErrMsgSize
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, msg)
+}
+
+// KeyText is the key type and hex key data
+type KeyText struct {
+	Kind string `json:"type"`
+	Data string `json:"data"`
+}
+
+// NodeKey is the identity key used to authenticate the remote signer connection
+type NodeKey struct {
+	PrivKey KeyText `json:"priv_key"`
+}
+
+// LoadOrGenNodeKey loads the ed25519 identity key from filePath, or generates a new one by cr and saves it
+func LoadOrGenNodeKey(cr crypto.Crypto, filePath string) (crypto.PrivKey, error) {
+	if data, err := ioutil.ReadFile(filePath); err == nil {
+		var nodeKey NodeKey
+		if err := json.Unmarshal(data, &nodeKey); err != nil {
+			return nil, err
+		}
+		keyBytes, err := hex.DecodeString(nodeKey.PrivKey.Data)
+		if err != nil {
+			return nil, err
+		}
+		return cr.PrivKeyFromBytes(keyBytes)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	priv, err := cr.GenKey()
+	if err != nil {
+		return nil, err
+	}
+	nodeKey := NodeKey{PrivKey: KeyText{Kind: "ed25519", Data: fmt.Sprintf("%X", priv.Bytes())}}
+	data, err := json.Marshal(nodeKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(filePath, data, 0600); err != nil {
+		return nil, err
+	}
+	return priv, nil
+}
+
+func writeFileAtomic(filePath string, data []byte, mode os.FileMode) error {
+	f, err := ioutil.TempFile(filepath.Dir(filePath), "")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), mode)
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filePath)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// Client sends the sign requests to the remote signer.
+// The signer is authenticated by its pubkey and the signer authenticates us by our node key.
+type Client struct {
+	mtx          sync.Mutex
+	addr         string
+	signerPubKey crypto.PubKey
+	nodeKey      crypto.PrivKey
+	handshake    Handshake
+	conn         net.Conn
+}
+
+// NewClient returns the client of the signer at addr, the connection is made on the first call
+func NewClient(addr string, signerPubKey crypto.PubKey, nodeKey crypto.PrivKey, handshake Handshake) *Client {
+	return &Client{
+		addr:         addr,
+		signerPubKey: signerPubKey,
+		nodeKey:      nodeKey,
+		handshake:    handshake,
+	}
+}
+
+func (c *Client) connect() error {
+	conn, err := net.DialTimeout("tcp", c.addr, timeout)
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(timeout))
+	sc, err := c.handshake(conn, c.nodeKey)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	if !bytes.Equal(sc.RemotePubKey().Bytes(), c.signerPubKey.Bytes()) {
+		sc.Close()
+		return ErrUnauthorized
+	}
+	c.conn = sc
+	return nil
+}
+
+// Call sends the request and waits for the response, the connection is made again once if it's broken.
+// The signer must return the same signature for the same request, so it's safe to send it again.
+func (c *Client) Call(req *Request) ([]byte, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	var err error
+	for i := 0; i < 2; i++ {
+		if c.conn == nil {
+			if err = c.connect(); err != nil {
+				rslog.Error("Client connect failed", "addr", c.addr, "err", err)
+				continue
+			}
+		}
+		var resp Response
+		c.conn.SetDeadline(time.Now().Add(timeout))
+		err = WriteMsg(c.conn, req)
+		if err == nil {
+			err = ReadMsg(c.conn, &resp)
+		}
+		if err != nil {
+			rslog.Error("Client call failed", "type", req.Type, "err", err)
+			c.conn.Close()
+			c.conn = nil
+			continue
+		}
+		if resp.Error != "" {
+			return nil, errors.New(resp.Error)
+		}
+		return resp.Data, nil
+	}
+	return nil, err
+}
+
+// Close closes the connection to the signer
+func (c *Client) Close() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+	}
+}
+
+// Server accepts the authorized consensus nodes and serves their requests by the handler
+type Server struct {
+	nodeKey    crypto.PrivKey
+	authorized [][]byte
+	handshake  Handshake
+	handler    Handler
+	listener   net.Listener
+	quit       chan struct{}
+	wg         sync.WaitGroup
+}
+
+// NewServer returns a Server only accepting the nodes with the authorized pubkeys
+func NewServer(nodeKey crypto.PrivKey, authorized []crypto.PubKey, handshake Handshake, handler Handler) *Server {
+	s := &Server{
+		nodeKey:   nodeKey,
+		handshake: handshake,
+		handler:   handler,
+		quit:      make(chan struct{}),
+	}
+	for _, pubKey := range authorized {
+		s.authorized = append(s.authorized, pubKey.Bytes())
+	}
+	return s
+}
+
+// Start listens on the address and serves the connections
+func (s *Server) Start(laddr string) error {
+	listener, err := net.Listen("tcp", laddr)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+	s.wg.Add(1)
+	go s.acceptRoutine()
+	return nil
+}
+
+// Addr returns the listening address
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Stop closes the listener and waits the connections to be closed
+func (s *Server) Stop() {
+	close(s.quit)
+	s.listener.Close()
+	s.wg.Wait()
+}
+
+func (s *Server) acceptRoutine() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			select {
+			case <-s.quit:
+				return
+			default:
+			}
+			rslog.Error("Server accept failed", "err", err)
+			continue
+		}
+		s.wg.Add(1)
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Server) isAuthorized(pubKey crypto.PubKey) bool {
+	for _, key := range s.authorized {
+		if bytes.Equal(key, pubKey.Bytes()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer s.wg.Done()
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+	sc, err := s.handshake(conn, s.nodeKey)
+	if err != nil {
+		rslog.Error("Server handshake failed", "remote", conn.RemoteAddr(), "err", err)
+		return
+	}
+	if !s.isAuthorized(sc.RemotePubKey()) {
+		rslog.Error("Server reject unauthorized node", "remote", conn.RemoteAddr(),
+			"pubkey", sc.RemotePubKey().KeyString())
+		return
+	}
+	conn.SetDeadline(time.Time{})
+	closed := make(chan struct{})
+	defer close(closed)
+	go func() {
+		select {
+		case <-s.quit:
+			conn.Close()
+		case <-closed:
+		}
+	}()
+
+	for {
+		var req Request
+		if err := ReadMsg(sc, &req); err != nil {
+			if err != io.EOF {
+				rslog.Error("Server read request failed", "remote", conn.RemoteAddr(), "err", err)
+			}
+			return
+		}
+		resp := &Response{}
+		data, err := s.handler(&req)
+		if err != nil {
+			rslog.Error("Server sign failed", "type", req.Type, "err", err)
+			resp.Error = err.Error()
+		} else {
+			resp.Data = data
+		}
+		if err := WriteMsg(sc, resp); err != nil {
+			rslog.Error("Server write response failed", "remote", conn.RemoteAddr(), "err", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package remotesigner
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type plainConn struct {
+	net.Conn
+	remote crypto.PubKey
+}
+
+func (c *plainConn) RemotePubKey() crypto.PubKey {
+	return c.remote
+}
+
+// plainHandshake only exchanges the pubkeys, the engines use the SecretConnection
+func plainHandshake(cr crypto.Crypto) Handshake {
+	return func(conn net.Conn, key crypto.PrivKey) (SecretConn, error) {
+		if err := WriteMsg(conn, key.PubKey().Bytes()); err != nil {
+			return nil, err
+		}
+		var remote []byte
+		if err := ReadMsg(conn, &remote); err != nil {
+			return nil, err
+		}
+		pubKey, err := cr.PubKeyFromBytes(remote)
+		if err != nil {
+			return nil, err
+		}
+		return &plainConn{Conn: conn, remote: pubKey}, nil
+	}
+}
+
+func TestMsg(t *testing.T) {
+	var buf bytes.Buffer
+	req := &Request{Type: ReqPubKey, ChainID: "chain", Data: []byte("data")}
+	assert.Nil(t, WriteMsg(&buf, req))
+	var got Request
+	assert.Nil(t, ReadMsg(&buf, &got))
+	assert.Equal(t, req, &got)
+
+	assert.Equal(t, ErrMsgSize, WriteMsg(&buf, make([]byte, maxMsgSize)))
+	assert.Equal(t, ErrMsgSize, ReadMsg(bytes.NewReader([]byte{0, 0, 0, 0}), &got))
+}
+
+func TestLoadOrGenNodeKey(t *testing.T) {
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	assert.Nil(t, err)
+	dir, err := ioutil.TempDir("", "remotesigner")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	keyFile := filepath.Join(dir, "node_key.json")
+	nodeKey, err := LoadOrGenNodeKey(cr, keyFile)
+	assert.Nil(t, err)
+	again, err := LoadOrGenNodeKey(cr, keyFile)
+	assert.Nil(t, err)
+	assert.Equal(t, nodeKey.Bytes(), again.Bytes())
+	info, err := os.Stat(keyFile)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestClientServer(t *testing.T) {
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	assert.Nil(t, err)
+	signerKey, _ := cr.GenKey()
+	nodeKey, _ := cr.GenKey()
+	otherKey, _ := cr.GenKey()
+
+	handler := func(req *Request) ([]byte, error) {
+		if req.Type != ReqPubKey {
+			return nil, ErrUnknownRequest
+		}
+		return append([]byte(req.ChainID), req.Data...), nil
+	}
+	server := NewServer(signerKey, []crypto.PubKey{nodeKey.PubKey()}, plainHandshake(cr), handler)
+	assert.Nil(t, server.Start("127.0.0.1:0"))
+	addr := server.Addr().String()
+
+	// the unauthorized node and the wrong signer are rejected
+	client := NewClient(addr, signerKey.PubKey(), otherKey, plainHandshake(cr))
+	_, err = client.Call(&Request{Type: ReqPubKey})
+	assert.NotNil(t, err)
+	client = NewClient(addr, otherKey.PubKey(), nodeKey, plainHandshake(cr))
+	_, err = client.Call(&Request{Type: ReqPubKey})
+	assert.Equal(t, ErrUnauthorized, err)
+
+	client = NewClient(addr, signerKey.PubKey(), nodeKey, plainHandshake(cr))
+	defer client.Close()
+	data, err := client.Call(&Request{Type: ReqPubKey, ChainID: "chain", Data: []byte("-data")})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("chain-data"), data)
+	_, err = client.Call(&Request{Type: "vote"})
+	assert.Equal(t, errors.New(ErrUnknownRequest.Error()), err)
+
+	// the client connects again after the signer is restarted
+	server.Stop()
+	server = NewServer(signerKey, []crypto.PubKey{nodeKey.PubKey()}, plainHandshake(cr), handler)
+	assert.Nil(t, server.Start(addr))
+	defer server.Stop()
+	data, err = client.Call(&Request{Type: ReqPubKey, ChainID: "chain"})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("chain"), data)
+}
@@ -64,6 +64,7 @@ type ConsensusState struct {
 	// services for creating and executing blocks
 	// TODO: encapsulate all of this in one "BlockManager"
 	blockExec *BlockExecutor
+	evpool    *EvidencePool
 
 	// internal state
 	mtx sync.Mutex
@@ -75,6 +76,7 @@ type ConsensusState struct {
 	peerMsgQueue     chan MsgInfo
 	internalMsgQueue chan MsgInfo
 	timeoutTicker    TimeoutTicker
+	wal              WAL
 
 	// for tests where we want to limit the number of transitions the state makes
 	nSteps int
@@ -99,9 +101,11 @@ func NewConsensusState(client *Client, state State, blockExec *BlockExecutor) *C
 	cs := &ConsensusState{
 		client:           client,
 		blockExec:        blockExec,
+		evpool:           NewEvidencePool(),
 		peerMsgQueue:     make(chan MsgInfo, msgQueueSize),
 		internalMsgQueue: make(chan MsgInfo, msgQueueSize),
 		timeoutTicker:    NewTimeoutTicker(),
+		wal:              nilWAL{},
 
 		quit:         make(chan struct{}),
 		txsAvailable: make(chan int64, 1),
@@ -169,6 +173,13 @@ func (cs *ConsensusState) SetPrivValidator(priv ttypes.PrivValidator) {
 	cs.privValidator = priv
 }
 
+// SetWAL sets the write ahead log of consensus messages
+func (cs *ConsensusState) SetWAL(wal WAL) {
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+	cs.wal = wal
+}
+
 // SetTimeoutTicker sets the local timer. It may be useful to overwrite for testing.
 func (cs *ConsensusState) SetTimeoutTicker(timeoutTicker TimeoutTicker) {
 	cs.mtx.Lock()
@@ -191,6 +202,9 @@ func (cs *ConsensusState) Start() {
 	if atomic.CompareAndSwapUint32(&cs.status, 0, 1) {
 		cs.timeoutTicker.Start()
 
+		// replay the messages of current height before handling new ones
+		cs.catchupReplay(cs.Height)
+
 		go cs.checkTxsAvailable()
 		// now start the receiveRoutine
 		go cs.receiveRoutine(0)
@@ -373,13 +387,23 @@ func (cs *ConsensusState) receiveRoutine(maxSteps int) {
 		case height := <-cs.txsAvailable:
 			cs.handleTxsAvailable(height)
 		case mi = <-cs.peerMsgQueue:
+			if err := cs.wal.Write(mi); err != nil {
+				tendermintlog.Error("Error writing to wal", "err", err)
+			}
 			// handles proposals, block parts, votes
 			// may generate internal events (votes, complete proposals, 2/3 majorities)
 			cs.handleMsg(mi)
 		case mi = <-cs.internalMsgQueue:
+			// our own signed messages must be persisted before they are sent out
+			if err := cs.wal.WriteSync(mi); err != nil {
+				panic(fmt.Sprintf("Failed to write %v msg to consensus wal: %v", mi.TypeID, err))
+			}
 			// handles proposals, block parts, votes
 			cs.handleMsg(mi)
 		case ti := <-cs.timeoutTicker.Chan(): // tockChan:
+			if err := cs.wal.Write(ti); err != nil {
+				tendermintlog.Error("Error writing to wal", "err", err)
+			}
 			// if the timeout is relevant to the rs
 			// go to the next step
 			cs.handleTimeout(ti, rs)
@@ -392,6 +416,28 @@ func (cs *ConsensusState) receiveRoutine(maxSteps int) {
 	}
 }
 
+// catchupReplay replays the messages of the height from the wal, so a restarted validator
+// rebuilds its round state and votes before taking part in consensus again.
+func (cs *ConsensusState) catchupReplay(height int64) {
+	msgs, found, err := cs.wal.ReadFromEndHeight(height - 1)
+	if err != nil {
+		tendermintlog.Error("catchupReplay read wal fail", "err", err)
+		return
+	}
+	if !found && height > 1 {
+		tendermintlog.Info("catchupReplay no end height found in wal", "height", height-1)
+	}
+	tendermintlog.Info("catchupReplay", "height", height, "msgs", len(msgs))
+	for _, msg := range msgs {
+		switch m := msg.(type) {
+		case MsgInfo:
+			cs.handleMsg(m)
+		case timeoutInfo:
+			cs.handleTimeout(m, *cs.GetRoundState())
+		}
+	}
+}
+
 // state transitions on complete-proposal, 2/3-any, 2/3-one
 func (cs *ConsensusState) handleMsg(mi MsgInfo) {
 	cs.mtx.Lock()
@@ -429,6 +475,8 @@ func (cs *ConsensusState) handleMsg(mi MsgInfo) {
 		// TODO: If rs.Height == vote.Height && rs.Round < vote.Round,
 		// the peer is sending us CatchupCommit precommits.
 		// We could make note of this and help filter in broadcastHasVoteMessage().
+	case *tmtypes.DuplicateVoteEvidence:
+		err = cs.addEvidence(&ttypes.DuplicateVoteEvidence{DuplicateVoteEvidence: msg}, peerIP)
 	default:
 		tendermintlog.Error("Unknown msg type", msg.String(), "peerid", peerID, "peerip", peerIP)
 	}
@@ -731,7 +779,11 @@ func (cs *ConsensusState) createProposalBlock() (block *ttypes.TendermintBlock)
 
 	// Mempool validated transactions
 	beg := time.Now()
-	pblock := cs.client.BuildBlock()
+	var evidenceTxs []*types.Transaction
+	for _, ev := range cs.evpool.PendingEvidence(maxEvidencePerBlock) {
+		evidenceTxs = append(evidenceTxs, CreateEvidenceTx(cs.client.pubKey, ev))
+	}
+	pblock := cs.client.BuildBlock(evidenceTxs)
 	tendermintlog.Info(fmt.Sprintf("createProposalBlock BuildBlock. Current: %v/%v/%v", cs.Height, cs.Round, cs.Step),
 		"txs-len", len(pblock.Txs), "cost", types.Since(beg))
 
@@ -1113,6 +1165,13 @@ func (cs *ConsensusState) finalizeCommit(height int64) {
 			"tx-len", len(commitBlock.Txs), "cost", types.Since(cs.begCons), "proposer-addr", fmt.Sprintf("%X", ttypes.Fingerprint(block.TendermintBlock.Header.ProposerAddr)))
 	}
 
+	// remove the committed evidence from the pool
+	evs, err := evidenceFromTxs(commitBlock.Txs)
+	if err != nil {
+		tendermintlog.Error("finalizeCommit get evidence fail", "err", err)
+	}
+	cs.evpool.Update(height, stateCopy.ConsensusParams.EvidenceParams.MaxAge, evs)
+
 	//check whether need update validator nodes
 	valNodes, err := cs.client.QueryValidatorsByHeight(block.Header.Height)
 	if err == nil && valNodes != nil {
@@ -1142,6 +1201,11 @@ func (cs *ConsensusState) finalizeCommit(height int64) {
 	}
 	tendermintlog.Info(fmt.Sprintf("Save consensus state. Current: %v/%v/%v", cs.Height, cs.CommitRound, cs.Step), "cost", types.Since(cs.begCons))
 
+	// the messages of this height are not needed to replay any more
+	if err := cs.wal.WriteEndHeight(height); err != nil {
+		tendermintlog.Error("finalizeCommit WriteEndHeight fail", "err", err)
+	}
+
 	// NewHeightStep!
 	cs.updateToState(stateCopy)
 
@@ -1286,11 +1350,12 @@ func (cs *ConsensusState) tryAddVote(voteRaw *tmtypes.Vote, peerID string, peerI
 		// If it's otherwise invalid, punish peer.
 		if err == ErrVoteHeightMismatch {
 			return err
-		} else if err == ttypes.ErrVoteConflict {
+		} else if conflictErr, ok := err.(*ttypes.ErrVoteConflictingVotes); ok {
 			if bytes.Equal(vote.ValidatorAddress, cs.privValidator.GetAddress()) {
 				tendermintlog.Error("Found conflicting vote from ourselves. Did you unsafe_reset a validator?", "height", vote.Height, "round", vote.Round, "type", vote.Type)
 				return err
 			}
+			cs.addEvidence(conflictErr.DuplicateVoteEvidence, peerIP)
 		} else {
 			// Probably an invalid signature / Bad peer.
 			// Seems this can also err sometimes with "Unexpected step" - perhaps not from a bad peer ?
@@ -1301,6 +1366,19 @@ func (cs *ConsensusState) tryAddVote(voteRaw *tmtypes.Vote, peerID string, peerI
 	return nil
 }
 
+// Add the evidence to the pool and gossip it if it's new
+func (cs *ConsensusState) addEvidence(ev *ttypes.DuplicateVoteEvidence, peerIP string) error {
+	added, err := cs.evpool.AddEvidence(cs.state, ev)
+	if err != nil {
+		tendermintlog.Error("Error attempting to add evidence", "evidence", ev.String(), "peerip", peerIP, "err", err)
+		return err
+	}
+	if added {
+		cs.broadcastChannel <- MsgInfo{TypeID: ttypes.EvidenceID, Msg: ev.DuplicateVoteEvidence, PeerID: cs.ourID, PeerIP: ""}
+	}
+	return nil
+}
+
 //-----------------------------------------------------------------------------
 
 func (cs *ConsensusState) addVote(vote *ttypes.Vote, peerID string, peerIP string) (added bool, err error) {
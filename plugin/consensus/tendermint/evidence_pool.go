@@ -0,0 +1,160 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tendermint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
+)
+
+const (
+	maxEvidencePerBlock = 10
+)
+
+// Errors define
+var (
+	ErrEvidenceChainID   = errors.New("Error evidence chainID mismatch")
+	ErrEvidenceExpired   = errors.New("Error evidence expired")
+	ErrEvidenceValidator = errors.New("Error evidence validator not found")
+)
+
+// EvidencePool maintains the double sign evidence which is waiting to be packed into a block
+type EvidencePool struct {
+	mtx       sync.Mutex
+	pending   map[string]*ttypes.DuplicateVoteEvidence
+	committed map[string]int64
+}
+
+// NewEvidencePool returns a new EvidencePool
+func NewEvidencePool() *EvidencePool {
+	return &EvidencePool{
+		pending:   make(map[string]*ttypes.DuplicateVoteEvidence),
+		committed: make(map[string]int64),
+	}
+}
+
+// AddEvidence verifies the evidence against the state and adds it to the pool.
+// It returns true if the evidence is new.
+func (evpool *EvidencePool) AddEvidence(state State, ev *ttypes.DuplicateVoteEvidence) (bool, error) {
+	if err := verifyEvidence(state, state.LastBlockHeight+1, ev); err != nil {
+		return false, err
+	}
+	// the validator must be known at the height of the double sign
+	_, val := state.Validators.GetByAddress(ev.Address())
+	if val == nil || !bytes.Equal(val.PubKey, ev.PubKey) {
+		_, val = state.LastValidators.GetByAddress(ev.Address())
+		if val == nil || !bytes.Equal(val.PubKey, ev.PubKey) {
+			return false, ErrEvidenceValidator
+		}
+	}
+
+	key := string(ev.Hash())
+	evpool.mtx.Lock()
+	defer evpool.mtx.Unlock()
+	if _, ok := evpool.committed[key]; ok {
+		return false, nil
+	}
+	if _, ok := evpool.pending[key]; ok {
+		return false, nil
+	}
+	evpool.pending[key] = ev
+	tendermintlog.Info("Add evidence to pool", "evidence", ev.String())
+	return true, nil
+}
+
+// PendingEvidence returns at most max pending evidence ordered by height
+func (evpool *EvidencePool) PendingEvidence(max int) []*ttypes.DuplicateVoteEvidence {
+	evpool.mtx.Lock()
+	defer evpool.mtx.Unlock()
+	evs := make([]*ttypes.DuplicateVoteEvidence, 0, len(evpool.pending))
+	for _, ev := range evpool.pending {
+		evs = append(evs, ev)
+	}
+	sort.Slice(evs, func(i, j int) bool {
+		if evs[i].Height() != evs[j].Height() {
+			return evs[i].Height() < evs[j].Height()
+		}
+		return bytes.Compare(evs[i].Hash(), evs[j].Hash()) < 0
+	})
+	if len(evs) > max {
+		evs = evs[:max]
+	}
+	return evs
+}
+
+// Size returns the number of pending evidence
+func (evpool *EvidencePool) Size() int {
+	evpool.mtx.Lock()
+	defer evpool.mtx.Unlock()
+	return len(evpool.pending)
+}
+
+// Update removes the evidence committed in the block, the pending evidence of the slashed validators
+// and the evidence older than maxAge.
+func (evpool *EvidencePool) Update(height int64, maxAge int64, committed []*ttypes.DuplicateVoteEvidence) {
+	evpool.mtx.Lock()
+	defer evpool.mtx.Unlock()
+	for _, ev := range committed {
+		evpool.committed[string(ev.Hash())] = ev.Height()
+		for key, pending := range evpool.pending {
+			if bytes.Equal(pending.Address(), ev.Address()) {
+				delete(evpool.pending, key)
+			}
+		}
+	}
+	for key, ev := range evpool.pending {
+		if height-ev.Height() > maxAge {
+			delete(evpool.pending, key)
+		}
+	}
+	for key, evHeight := range evpool.committed {
+		if height-evHeight > maxAge {
+			delete(evpool.committed, key)
+		}
+	}
+}
+
+// verifyEvidence checks the evidence is valid for the chain at the given height
+func verifyEvidence(state State, height int64, ev *ttypes.DuplicateVoteEvidence) error {
+	if err := ev.Verify(); err != nil {
+		return err
+	}
+	if ev.ChainID != state.ChainID {
+		return ErrEvidenceChainID
+	}
+	if ev.Height() > height || height-ev.Height() > state.ConsensusParams.EvidenceParams.MaxAge {
+		return ErrEvidenceExpired
+	}
+	return nil
+}
+
+// evidenceFromTxs returns the evidence packed in the valnode transactions
+func evidenceFromTxs(txs []*types.Transaction) ([]*ttypes.DuplicateVoteEvidence, error) {
+	var evs []*ttypes.DuplicateVoteEvidence
+	for _, tx := range txs {
+		if string(tx.Execer) != tmtypes.ValNodeX {
+			continue
+		}
+		var action tmtypes.ValNodeAction
+		if err := types.Decode(tx.GetPayload(), &action); err != nil {
+			continue
+		}
+		if action.GetTy() != tmtypes.ValNodeActionEvidence {
+			continue
+		}
+		if action.GetEvidence() == nil {
+			return nil, fmt.Errorf("Empty evidence in tx %X", tx.Hash())
+		}
+		evs = append(evs, &ttypes.DuplicateVoteEvidence{DuplicateVoteEvidence: action.GetEvidence()})
+	}
+	return evs, nil
+}
@@ -0,0 +1,125 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tendermint
+
+import (
+	"testing"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	ty "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	vty "github.com/33cn/plugin/plugin/dapp/valnode/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const evidenceChainID = "test-chain-evidence"
+
+func signTestVote(t *testing.T, priv crypto.PrivKey, height int64, blockHash []byte) *ty.Vote {
+	vote := &ty.Vote{Vote: &vty.Vote{
+		ValidatorAddress: ty.GenAddressByPubKey(priv.PubKey()),
+		ValidatorIndex:   0,
+		Height:           height,
+		Round:            0,
+		Type:             uint32(ty.VoteTypePrevote),
+		BlockID:          &vty.BlockID{Hash: blockHash},
+	}}
+	vote.Signature = priv.Sign(ty.SignBytes(evidenceChainID, vote)).Bytes()
+	return vote
+}
+
+func newTestEvidenceState(t *testing.T) (State, crypto.PrivKey) {
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	assert.Nil(t, err)
+	ty.ConsensusCrypto = cr
+	priv, err := cr.GenKey()
+	assert.Nil(t, err)
+	state := State{
+		ChainID:         evidenceChainID,
+		LastBlockHeight: 10,
+		Validators:      ty.NewValidatorSet([]*ty.Validator{ty.NewValidator(priv.PubKey(), 10)}),
+		LastValidators:  ty.NewValidatorSet(nil),
+		ConsensusParams: *ty.DefaultConsensusParams(),
+	}
+	state.ConsensusParams.EvidenceParams.MaxAge = 5
+	return state, priv
+}
+
+func TestDuplicateVoteEvidence(t *testing.T) {
+	state, priv := newTestEvidenceState(t)
+
+	// conflicting votes in the vote set give the evidence
+	voteSet := ty.NewVoteSet(evidenceChainID, 11, 0, ty.VoteTypePrevote, state.Validators)
+	added, err := voteSet.AddVote(signTestVote(t, priv, 11, []byte("block-b")))
+	assert.True(t, added)
+	assert.Nil(t, err)
+	_, err = voteSet.AddVote(signTestVote(t, priv, 11, []byte("block-a")))
+	conflictErr, ok := err.(*ty.ErrVoteConflictingVotes)
+	assert.True(t, ok)
+	ev := conflictErr.DuplicateVoteEvidence
+	assert.Nil(t, ev.Verify())
+	assert.Equal(t, int64(11), ev.Height())
+	assert.Equal(t, []byte("block-a"), ev.VoteA.BlockID.Hash)
+
+	// the order of the votes does not change the evidence
+	same := ty.NewDuplicateVoteEvidence(priv.PubKey().Bytes(), evidenceChainID,
+		signTestVote(t, priv, 11, []byte("block-a")), signTestVote(t, priv, 11, []byte("block-b")))
+	assert.Equal(t, ev.Hash(), same.Hash())
+
+	bad := ty.NewDuplicateVoteEvidence(priv.PubKey().Bytes(), evidenceChainID,
+		signTestVote(t, priv, 11, []byte("block-a")), signTestVote(t, priv, 11, []byte("block-a")))
+	assert.Equal(t, ty.ErrEvidenceNotConflict, bad.Verify())
+	bad = ty.NewDuplicateVoteEvidence(priv.PubKey().Bytes(), evidenceChainID,
+		signTestVote(t, priv, 11, []byte("block-a")), signTestVote(t, priv, 12, []byte("block-b")))
+	assert.Equal(t, ty.ErrEvidenceInvalidVotes, bad.Verify())
+	bad = ty.NewDuplicateVoteEvidence(priv.PubKey().Bytes(), "other-chain",
+		signTestVote(t, priv, 11, []byte("block-a")), signTestVote(t, priv, 11, []byte("block-b")))
+	assert.Equal(t, ty.ErrEvidenceInvalidSig, bad.Verify())
+	other, _ := ty.ConsensusCrypto.GenKey()
+	bad = ty.NewDuplicateVoteEvidence(other.PubKey().Bytes(), evidenceChainID,
+		signTestVote(t, priv, 11, []byte("block-a")), signTestVote(t, priv, 11, []byte("block-b")))
+	assert.Equal(t, ty.ErrVoteInvalidValidatorAddress, bad.Verify())
+}
+
+func TestEvidencePool(t *testing.T) {
+	state, priv := newTestEvidenceState(t)
+	pool := NewEvidencePool()
+	ev := ty.NewDuplicateVoteEvidence(priv.PubKey().Bytes(), evidenceChainID,
+		signTestVote(t, priv, 9, []byte("block-a")), signTestVote(t, priv, 9, []byte("block-b")))
+
+	added, err := pool.AddEvidence(state, ev)
+	assert.True(t, added)
+	assert.Nil(t, err)
+	added, err = pool.AddEvidence(state, ev)
+	assert.False(t, added)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(pool.PendingEvidence(maxEvidencePerBlock)))
+
+	// evidence of unknown validator or too old is rejected
+	other, _ := ty.ConsensusCrypto.GenKey()
+	_, err = pool.AddEvidence(state, ty.NewDuplicateVoteEvidence(other.PubKey().Bytes(), evidenceChainID,
+		signTestVote(t, other, 9, []byte("block-a")), signTestVote(t, other, 9, []byte("block-b"))))
+	assert.Equal(t, ErrEvidenceValidator, err)
+	_, err = pool.AddEvidence(state, ty.NewDuplicateVoteEvidence(priv.PubKey().Bytes(), evidenceChainID,
+		signTestVote(t, priv, 2, []byte("block-a")), signTestVote(t, priv, 2, []byte("block-b"))))
+	assert.Equal(t, ErrEvidenceExpired, err)
+
+	// evidence packed in the block is removed with the other evidence of the same validator
+	later := ty.NewDuplicateVoteEvidence(priv.PubKey().Bytes(), evidenceChainID,
+		signTestVote(t, priv, 10, []byte("block-a")), signTestVote(t, priv, 10, []byte("block-b")))
+	added, _ = pool.AddEvidence(state, later)
+	assert.True(t, added)
+	assert.Equal(t, 2, pool.Size())
+	assert.Equal(t, ev.Hash(), pool.PendingEvidence(1)[0].Hash())
+
+	tx := CreateEvidenceTx("2FA6E7E4FFA6FD6D2B6E1B4F62B3B9D6C8C8E56D0C6B2E0D5E8B1C1F4D6A7B8C", ev)
+	evs, err := evidenceFromTxs([]*types.Transaction{{Execer: []byte("coins")}, tx})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(evs))
+	assert.Equal(t, ev.Hash(), evs[0].Hash())
+	pool.Update(11, state.ConsensusParams.EvidenceParams.MaxAge, evs)
+	assert.Equal(t, 0, pool.Size())
+	added, _ = pool.AddEvidence(state, ev)
+	assert.False(t, added)
+}
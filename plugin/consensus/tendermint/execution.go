@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/33cn/chain33/types"
 	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
 	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
 )
@@ -122,7 +123,10 @@ func updateValidators(currentSet *ttypes.ValidatorSet, updates []*tmtypes.ValNod
 		}
 
 		_, val := currentSet.GetByAddress(address)
-		if val == nil {
+		if val == nil && v.Power == 0 {
+			// the validator is already removed, e.g. slashed for double sign
+			tendermintlog.Info("Ignore removing unknown validator", "address", fmt.Sprintf("%X", address))
+		} else if val == nil {
 			// add val
 			added := currentSet.Add(ttypes.NewValidator(pubkey, power))
 			if !added {
@@ -219,6 +223,13 @@ func validateBlock(stateDB *CSStateDB, s State, b *ttypes.TendermintBlock) error
 	if !bytes.Equal(b.Header.ValidatorsHash, s.Validators.Hash()) {
 		return fmt.Errorf("Wrong Block.Header.ValidatorsHash.  Expected %X, got %v", s.Validators.Hash(), b.Header.ValidatorsHash)
 	}
+	//分叉之后valnode执行器使用基础交易中的验证者集合验证提案，需要与共识的验证者集合一致
+	cfg := stateDB.client.GetAPI().GetConfig()
+	if cfg.IsDappFork(b.Header.Height, tmtypes.ValNodeX, tmtypes.ForkValNodeGovern) {
+		if err := validateBaseTxValidators(s, b); err != nil {
+			return err
+		}
+	}
 
 	// Validate block LastCommit.
 	if b.Header.Height == 1 {
@@ -240,3 +251,30 @@ func validateBlock(stateDB *CSStateDB, s State, b *ttypes.TendermintBlock) error
 
 	return nil
 }
+
+// validateBaseTxValidators check the validators in the base tx, which are used by valnode executor to verify validator proposals
+func validateBaseTxValidators(s State, b *ttypes.TendermintBlock) error {
+	if b.Data == nil || len(b.Data.Txs) == 0 {
+		return nil
+	}
+	var action tmtypes.ValNodeAction
+	if err := types.Decode(b.Data.Txs[0].GetPayload(), &action); err != nil {
+		return err
+	}
+	if action.GetTy() != tmtypes.ValNodeActionBlockInfo || action.GetBlockInfo() == nil {
+		return ttypes.ErrBaseTxType
+	}
+	validators := action.GetBlockInfo().GetState().GetValidators().GetValidators()
+	valSet := &ttypes.ValidatorSet{Validators: make([]*ttypes.Validator, 0, len(validators))}
+	for _, val := range validators {
+		valSet.Validators = append(valSet.Validators, &ttypes.Validator{
+			Address:     val.GetAddress(),
+			PubKey:      val.GetPubKey(),
+			VotingPower: val.GetVotingPower(),
+		})
+	}
+	if !bytes.Equal(valSet.Hash(), s.Validators.Hash()) {
+		return fmt.Errorf("Wrong base tx validators.  Expected %X, got %X", s.Validators.Hash(), valSet.Hash())
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/plugin/plugin/consensus/peerfilter"
 	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
 )
 
@@ -40,6 +41,9 @@ const (
 	reconnectBackOffAttempts    = 10
 	reconnectBackOffBaseSeconds = 3
 
+	// check the authorization of the connected peers when the validators changed
+	filterPeersInterval = 10 * time.Second
+
 	minReadBufferSize  = 1024
 	minWriteBufferSize = 65536
 )
@@ -105,6 +109,10 @@ type NodeInfo struct {
 	Network string `json:"network"`
 	Version string `json:"version"`
 	IP      string `json:"ip,omitempty"`
+
+	// ValidatorPubKey signs the node pubkey to prove the node belongs to the validator
+	ValidatorPubKey string `json:"validator_pub_key,omitempty"`
+	NodeKeySig      string `json:"node_key_sig,omitempty"`
 }
 
 // Node struct
@@ -123,6 +131,12 @@ type Node struct {
 	dialing      *IP2IPPort
 	reconnecting *IP2IPPort
 
+	filter          *peerfilter.PeerFilter
+	validatorPubKey string
+	nodeKeySig      string
+	pendingMtx      sync.Mutex
+	pending         map[string]int // inbound connections in handshake by ip
+
 	seeds    []string
 	protocol string
 	lAddr    string
@@ -137,6 +151,7 @@ type Node struct {
 // NewNode method
 func NewNode(seeds []string, protocol string, lAddr string, privKey crypto.PrivKey, network string, version string, state *ConsensusState) *Node {
 	address := GenAddressByPubKey(privKey.PubKey())
+	filter, _ := peerfilter.New("tendermint", false, nil, nil, nil, peerfilter.DefaultMaxPeersPerIP)
 
 	node := &Node{
 		peerSet:     NewPeerSet(),
@@ -154,6 +169,8 @@ func NewNode(seeds []string, protocol string, lAddr string, privKey crypto.PrivK
 		broadcastChannel: make(chan MsgInfo, maxSendQueueSize),
 		state:            state,
 		localIPs:         make(map[string]net.IP),
+		filter:           filter,
+		pending:          make(map[string]int),
 	}
 
 	state.SetOurID(node.ID)
@@ -168,6 +185,17 @@ func NewNode(seeds []string, protocol string, lAddr string, privKey crypto.PrivK
 	return node
 }
 
+// SetPeerFilter set the filter of peer connections, must be called before Start
+func (node *Node) SetPeerFilter(filter *peerfilter.PeerFilter) {
+	node.filter = filter
+}
+
+// SetNodeKeySig set the validator pubkey and its signature of our node pubkey, which are sent in handshake
+func (node *Node) SetNodeKeySig(validatorPubKey []byte, sig []byte) {
+	node.validatorPubKey = hex.EncodeToString(validatorPubKey)
+	node.nodeKeySig = hex.EncodeToString(sig)
+}
+
 // Start node
 func (node *Node) Start() {
 	if atomic.CompareAndSwapUint32(&node.started, 0, 1) {
@@ -213,6 +241,9 @@ func (node *Node) Start() {
 
 		go node.StartConsensusRoutine()
 		go node.BroadcastRoutine()
+		if node.filter.AuthPeers() {
+			go node.filterPeersRoutine()
+		}
 	}
 }
 
@@ -319,6 +350,17 @@ func (node *Node) connectComming(inConn net.Conn) {
 		return
 	}
 
+	// Filter by ip before the handshake
+	addr := inConn.RemoteAddr()
+	if err := node.acquireInbound(addr); err != nil {
+		tendermintlog.Info("Reject inbound connection", "address", addr.String(), "reason", err)
+		if er := inConn.Close(); er != nil {
+			tendermintlog.Error("connectComming close conn failed", "er", er)
+		}
+		return
+	}
+	defer node.releaseInbound(addr)
+
 	// New inbound connection!
 	err := node.addInboundPeer(inConn)
 	if err != nil {
@@ -374,9 +416,11 @@ func (node *Node) addPeer(pc *peerConn) error {
 	remoteIP, rErr := pc.RemoteIP()
 
 	nodeinfo := NodeInfo{
-		ID:      node.ID,
-		Network: node.Network,
-		Version: node.Version,
+		ID:              node.ID,
+		Network:         node.Network,
+		Version:         node.Version,
+		ValidatorPubKey: node.validatorPubKey,
+		NodeKeySig:      node.nodeKeySig,
 	}
 	// Exchange NodeInfo on the conn
 	peerNodeInfo, err := pc.HandshakeTimeout(nodeinfo, handshakeTimeout*time.Second)
@@ -407,8 +451,8 @@ func (node *Node) addPeer(pc *peerConn) error {
 		return fmt.Errorf("Duplicate peer ID %v", peerID)
 	}
 
-	// Check for duplicate connection or peer info IP.
-	if rErr == nil && node.peerSet.HasIP(remoteIP) {
+	// Check for the connections from the same IP.
+	if rErr == nil && node.peerSet.CountIP(remoteIP) >= node.filter.MaxPeersPerIP() {
 		return fmt.Errorf("Duplicate peer IP %v", remoteIP)
 	} else if rErr != nil {
 		return fmt.Errorf("get remote ip failed:%v", rErr)
@@ -419,6 +463,13 @@ func (node *Node) addPeer(pc *peerConn) error {
 		return err
 	}
 
+	// Check the node key is authorized
+	pc.nodeInfo = &peerNodeInfo
+	if err := node.authorizePeer(pc); err != nil {
+		tendermintlog.Info("Reject unauthorized peer", "peer", peerID, "address", addr, "reason", err)
+		return err
+	}
+
 	tendermintlog.Info("Successful handshake with peer", "peerNodeInfo", peerNodeInfo)
 
 	// All good. Start peer
@@ -471,11 +522,79 @@ func (node *Node) startInitPeer(peer *peerConn) error {
 	return nil
 }
 
-// FilterConnByAddr TODO:can make fileter by addr
+// FilterConnByAddr filter the connection by the allow and deny ip list
 func (node *Node) FilterConnByAddr(addr net.Addr) error {
+	ip, _ := splitHostPort(addr.String())
+	if err := node.filter.FilterIP(net.ParseIP(ip)); err != nil {
+		return fmt.Errorf("%v: %v", err, ip)
+	}
 	return nil
 }
 
+// acquireInbound limit the inbound connections from the same ip, including the connections in handshake
+func (node *Node) acquireInbound(addr net.Addr) error {
+	if err := node.FilterConnByAddr(addr); err != nil {
+		return err
+	}
+	ip, _ := splitHostPort(addr.String())
+	node.pendingMtx.Lock()
+	defer node.pendingMtx.Unlock()
+	if node.peerSet.CountIP(net.ParseIP(ip))+node.pending[ip] >= node.filter.MaxPeersPerIP() {
+		return peerfilter.ErrPeerIPLimit
+	}
+	node.pending[ip]++
+	return nil
+}
+
+func (node *Node) releaseInbound(addr net.Addr) {
+	ip, _ := splitHostPort(addr.String())
+	node.pendingMtx.Lock()
+	defer node.pendingMtx.Unlock()
+	node.pending[ip]--
+	if node.pending[ip] <= 0 {
+		delete(node.pending, ip)
+	}
+}
+
+// authorizePeer checks the authenticated node pubkey of the secret connection against current validators
+func (node *Node) authorizePeer(pc *peerConn) error {
+	var nodeKey crypto.PubKey
+	if sc, ok := pc.conn.(*SecretConnection); ok {
+		nodeKey = sc.RemotePubKey()
+	}
+	var info NodeInfo
+	if pc.nodeInfo != nil {
+		info = *pc.nodeInfo
+	}
+	_, validators := node.state.GetValidators()
+	vals := make([]*peerfilter.Validator, 0, len(validators))
+	for _, val := range validators {
+		vals = append(vals, &peerfilter.Validator{PubKey: val.PubKey, VotingPower: val.VotingPower})
+	}
+	return node.filter.Authorize(ttypes.ConsensusCrypto, node.Network, nodeKey, info.ValidatorPubKey, info.NodeKeySig, vals)
+}
+
+// filterPeersRoutine stop the peers which are not validators any more
+func (node *Node) filterPeersRoutine() {
+	ticker := time.NewTicker(filterPeersInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !node.IsRunning() {
+			return
+		}
+		for _, peer := range node.peerSet.List() {
+			pc, ok := peer.(*peerConn)
+			if !ok {
+				continue
+			}
+			if err := node.authorizePeer(pc); err != nil {
+				tendermintlog.Info("Stop unauthorized peer", "peer", pc.ID(), "reason", err)
+				node.stopAndRemovePeer(pc, err)
+			}
+		}
+	}
+}
+
 // CompatibleWith one node by nodeInfo
 func (node *Node) CompatibleWith(other NodeInfo) error {
 	iMajor, iMinor, _, iErr := splitVersion(node.Version)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/33cn/chain33/common/crypto"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/consensus/peerfilter"
 	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
 	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
 	"github.com/stretchr/testify/assert"
@@ -253,3 +254,27 @@ func testUpdateStateRoutine(t *testing.T, pc *peerConn) {
 
 	fmt.Println("testUpdateStateRoutine ok")
 }
+
+func TestPeerFilter(t *testing.T) {
+	ttypes.ConsensusCrypto = secureConnCrypto
+	filter, err := peerfilter.New("tendermint", true, nil, nil, nil, 0)
+	assert.Nil(t, err)
+
+	// the node key signed by the priv validator is authorized by the shared peer filter
+	pv := ttypes.GenPrivValidatorImp("")
+	nodeKey, err := secureConnCrypto.GenKey()
+	assert.Nil(t, err)
+	sig, err := pv.SignNodeKey("chain", nodeKey.PubKey().Bytes())
+	assert.Nil(t, err)
+	validators := []*peerfilter.Validator{{PubKey: pv.GetPubKey().Bytes(), VotingPower: 10}}
+	valPubKey := hex.EncodeToString(pv.GetPubKey().Bytes())
+	assert.Nil(t, filter.Authorize(ttypes.ConsensusCrypto, "chain", nodeKey.PubKey(), valPubKey, hex.EncodeToString(sig), validators))
+	validators[0].VotingPower = 0
+	assert.NotNil(t, filter.Authorize(ttypes.ConsensusCrypto, "chain", nodeKey.PubKey(), valPubKey, hex.EncodeToString(sig), validators))
+
+	testSet := NewPeerSet()
+	testSet.Add(&peerConn{id: "1", ip: net.IP{127, 0, 0, 1}})
+	testSet.Add(&peerConn{id: "2", ip: net.IP{127, 0, 0, 1}})
+	assert.Equal(t, 2, testSet.CountIP(net.IP{127, 0, 0, 1}))
+	assert.Equal(t, 0, testSet.CountIP(net.IP{127, 0, 0, 2}))
+}
@@ -77,6 +77,7 @@ type peerConn struct {
 	persistent bool
 	ip         net.IP
 	id         ID
+	nodeInfo   *NodeInfo
 
 	sendQueue     chan MsgInfo
 	sendQueueSize int32
@@ -171,6 +172,20 @@ func (ps *PeerSet) hasIP(peerIP net.IP) bool {
 	return false
 }
 
+// CountIP returns the number of peers with the IP address
+func (ps *PeerSet) CountIP(peerIP net.IP) int {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	count := 0
+	for _, item := range ps.lookup {
+		if ip, err := item.peer.RemoteIP(); err == nil && ip.Equal(peerIP) {
+			count++
+		}
+	}
+	return count
+}
+
 // Size of list
 func (ps *PeerSet) Size() int {
 	ps.mtx.Lock()
@@ -563,7 +578,7 @@ FOR_LOOP:
 					continue
 				}
 				if pc.transferChannel != nil && (pkt.TypeID == ttypes.ProposalID || pkt.TypeID == ttypes.VoteID ||
-					pkt.TypeID == ttypes.ProposalBlockID) {
+					pkt.TypeID == ttypes.ProposalBlockID || pkt.TypeID == ttypes.EvidenceID) {
 					pc.transferChannel <- MsgInfo{pkt.TypeID, realMsg.(proto.Message), pc.ID(), pc.ip.String()}
 					if pkt.TypeID == ttypes.ProposalID {
 						proposal := realMsg.(*tmtypes.Proposal)
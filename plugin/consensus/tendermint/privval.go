@@ -0,0 +1,231 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tendermint
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/consensus/remotesigner"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
+)
+
+// remote signer request types
+const (
+	SignerReqVote      = "vote"
+	SignerReqProposal  = "proposal"
+	SignerReqHeartbeat = "heartbeat"
+	SignerReqNodeKey   = "nodekey"
+)
+
+// signerHandshake makes the SecretConnection with the remote signer or the consensus node
+func signerHandshake(conn net.Conn, key crypto.PrivKey) (remotesigner.SecretConn, error) {
+	sc, err := MakeSecretConnection(conn, key)
+	if err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// RemoteSigner implements PrivValidator by signing through a remote signer.
+// The signer keeps its own signed height/round/step, so it never double signs whatever we request.
+type RemoteSigner struct {
+	mtx    sync.Mutex
+	client *remotesigner.Client
+
+	address    []byte
+	pubKey     crypto.PubKey
+	lastHeight int64
+	lastRound  int
+	lastStep   int8
+}
+
+// NewRemoteSigner connects to the signer at addr and fetches the validator pubkey
+func NewRemoteSigner(addr string, signerPubKey crypto.PubKey, nodeKey crypto.PrivKey) (*RemoteSigner, error) {
+	rs := &RemoteSigner{client: remotesigner.NewClient(addr, signerPubKey, nodeKey, signerHandshake)}
+	data, err := rs.call(&remotesigner.Request{Type: remotesigner.ReqPubKey})
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := ttypes.ConsensusCrypto.PubKeyFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	rs.pubKey = pubKey
+	rs.address = ttypes.GenAddressByPubKey(pubKey)
+	return rs, nil
+}
+
+func (rs *RemoteSigner) call(req *remotesigner.Request) ([]byte, error) {
+	return rs.client.Call(req)
+}
+
+// GetAddress returns the address of the validator
+func (rs *RemoteSigner) GetAddress() []byte {
+	return rs.address
+}
+
+// GetPubKey returns the public key of the validator
+func (rs *RemoteSigner) GetPubKey() crypto.PubKey {
+	return rs.pubKey
+}
+
+// SignVote signs the vote by the remote signer
+func (rs *RemoteSigner) SignVote(chainID string, vote *ttypes.Vote) error {
+	data, err := rs.call(&remotesigner.Request{Type: SignerReqVote, ChainID: chainID, Data: types.Encode(vote.Vote)})
+	if err != nil {
+		return fmt.Errorf("Error signing vote: %v", err)
+	}
+	signed := &tmtypes.Vote{}
+	if err := types.Decode(data, signed); err != nil {
+		return err
+	}
+	vote.Timestamp = signed.Timestamp
+	vote.Signature = signed.Signature
+	rs.setLastSigned(vote.Height, int(vote.Round), ttypes.VoteStep(vote))
+	return nil
+}
+
+// SignProposal signs the proposal by the remote signer
+func (rs *RemoteSigner) SignProposal(chainID string, proposal *ttypes.Proposal) error {
+	data, err := rs.call(&remotesigner.Request{Type: SignerReqProposal, ChainID: chainID, Data: types.Encode(&proposal.Proposal)})
+	if err != nil {
+		return fmt.Errorf("Error signing proposal: %v", err)
+	}
+	signed := &tmtypes.Proposal{}
+	if err := types.Decode(data, signed); err != nil {
+		return err
+	}
+	proposal.Timestamp = signed.Timestamp
+	proposal.Signature = signed.Signature
+	rs.setLastSigned(proposal.Height, int(proposal.Round), ttypes.ProposalStep())
+	return nil
+}
+
+// SignHeartbeat signs the heartbeat by the remote signer
+func (rs *RemoteSigner) SignHeartbeat(chainID string, heartbeat *ttypes.Heartbeat) error {
+	data, err := rs.call(&remotesigner.Request{Type: SignerReqHeartbeat, ChainID: chainID, Data: types.Encode(heartbeat.Heartbeat)})
+	if err != nil {
+		return fmt.Errorf("Error signing heartbeat: %v", err)
+	}
+	signed := &tmtypes.Heartbeat{}
+	if err := types.Decode(data, signed); err != nil {
+		return err
+	}
+	heartbeat.Signature = signed.Signature
+	return nil
+}
+
+// SignNodeKey signs the p2p node pubkey by the remote signer
+func (rs *RemoteSigner) SignNodeKey(chainID string, nodeKey []byte) ([]byte, error) {
+	sig, err := rs.call(&remotesigner.Request{Type: SignerReqNodeKey, ChainID: chainID, Data: nodeKey})
+	if err != nil {
+		return nil, fmt.Errorf("Error signing node key: %v", err)
+	}
+	return sig, nil
+}
+
+func (rs *RemoteSigner) setLastSigned(height int64, round int, step int8) {
+	rs.mtx.Lock()
+	defer rs.mtx.Unlock()
+	rs.lastHeight = height
+	rs.lastRound = round
+	rs.lastStep = step
+}
+
+// GetLastHeight returns the height last signed by us
+func (rs *RemoteSigner) GetLastHeight() int64 {
+	rs.mtx.Lock()
+	defer rs.mtx.Unlock()
+	return rs.lastHeight
+}
+
+// GetLastRound returns the round last signed by us
+func (rs *RemoteSigner) GetLastRound() int {
+	rs.mtx.Lock()
+	defer rs.mtx.Unlock()
+	return rs.lastRound
+}
+
+// GetLastStep returns the step last signed by us
+func (rs *RemoteSigner) GetLastStep() int8 {
+	rs.mtx.Lock()
+	defer rs.mtx.Unlock()
+	return rs.lastStep
+}
+
+// ResetLastHeight only resets the local record, the signer keeps its own signed height round step
+func (rs *RemoteSigner) ResetLastHeight(height int64) {
+	rs.mtx.Lock()
+	defer rs.mtx.Unlock()
+	if rs.lastHeight >= height {
+		return
+	}
+	rs.lastHeight = height
+	rs.lastRound = 0
+	rs.lastStep = 0
+}
+
+// Close closes the connection to the signer
+func (rs *RemoteSigner) Close() {
+	rs.client.Close()
+}
+
+// SignerServer serves the sign requests of the authorized consensus nodes.
+// The double sign protection is done by the PrivValidator.
+type SignerServer struct {
+	*remotesigner.Server
+	privValidator ttypes.PrivValidator
+}
+
+// NewSignerServer returns a SignerServer only accepting the nodes with the authorized pubkeys
+func NewSignerServer(privValidator ttypes.PrivValidator, nodeKey crypto.PrivKey, authorized []crypto.PubKey) *SignerServer {
+	ss := &SignerServer{privValidator: privValidator}
+	ss.Server = remotesigner.NewServer(nodeKey, authorized, signerHandshake, ss.sign)
+	return ss
+}
+
+// sign signs the request by the PrivValidator
+func (ss *SignerServer) sign(req *remotesigner.Request) ([]byte, error) {
+	switch req.Type {
+	case remotesigner.ReqPubKey:
+		return ss.privValidator.GetPubKey().Bytes(), nil
+	case SignerReqVote:
+		vote := &tmtypes.Vote{}
+		if err := types.Decode(req.Data, vote); err != nil {
+			return nil, err
+		}
+		if err := ss.privValidator.SignVote(req.ChainID, &ttypes.Vote{Vote: vote}); err != nil {
+			return nil, err
+		}
+		return types.Encode(vote), nil
+	case SignerReqProposal:
+		proposal := &ttypes.Proposal{}
+		if err := types.Decode(req.Data, &proposal.Proposal); err != nil {
+			return nil, err
+		}
+		if err := ss.privValidator.SignProposal(req.ChainID, proposal); err != nil {
+			return nil, err
+		}
+		return types.Encode(&proposal.Proposal), nil
+	case SignerReqHeartbeat:
+		heartbeat := &tmtypes.Heartbeat{}
+		if err := types.Decode(req.Data, heartbeat); err != nil {
+			return nil, err
+		}
+		if err := ss.privValidator.SignHeartbeat(req.ChainID, &ttypes.Heartbeat{Heartbeat: heartbeat}); err != nil {
+			return nil, err
+		}
+		return types.Encode(heartbeat), nil
+	case SignerReqNodeKey:
+		return ss.privValidator.SignNodeKey(req.ChainID, req.Data)
+	default:
+		return nil, remotesigner.ErrUnknownRequest
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tendermint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/consensus/remotesigner"
+	ty "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	vty "github.com/33cn/plugin/plugin/dapp/valnode/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	assert.Nil(t, err)
+	ty.ConsensusCrypto = cr
+	dir, err := ioutil.TempDir("", "tendermint-signer")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, "priv_validator.enc.json")
+	password := []byte("signer password")
+
+	pv := ty.LoadOrGenPrivValidatorFSWithPassword(keyFile, password)
+	signerKey, err := remotesigner.LoadOrGenNodeKey(cr, filepath.Join(dir, "signer_node_key.json"))
+	assert.Nil(t, err)
+	nodeKey, err := remotesigner.LoadOrGenNodeKey(cr, filepath.Join(dir, "node_key.json"))
+	assert.Nil(t, err)
+
+	server := NewSignerServer(pv, signerKey, []crypto.PubKey{nodeKey.PubKey()})
+	assert.Nil(t, server.Start("127.0.0.1:0"))
+	defer server.Stop()
+	addr := server.Addr().String()
+
+	// the unauthorized node and the wrong signer are rejected
+	otherKey, _ := cr.GenKey()
+	_, err = NewRemoteSigner(addr, signerKey.PubKey(), otherKey)
+	assert.NotNil(t, err)
+	_, err = NewRemoteSigner(addr, otherKey.PubKey(), nodeKey)
+	assert.Equal(t, remotesigner.ErrUnauthorized, err)
+
+	rs, err := NewRemoteSigner(addr, signerKey.PubKey(), nodeKey)
+	assert.Nil(t, err)
+	defer rs.Close()
+	assert.Equal(t, pv.GetAddress(), rs.GetAddress())
+
+	newVote := func(hash string) *ty.Vote {
+		return &ty.Vote{Vote: &vty.Vote{
+			ValidatorAddress: rs.GetAddress(),
+			Height:           3,
+			Timestamp:        time.Now().UnixNano(),
+			Type:             uint32(ty.VoteTypePrevote),
+			BlockID:          &vty.BlockID{Hash: []byte(hash)},
+		}}
+	}
+	vote := newVote("block-a")
+	assert.Nil(t, rs.SignVote("chain", vote))
+	assert.Nil(t, vote.Verify("chain", rs.GetPubKey()))
+	assert.Equal(t, int64(3), rs.GetLastHeight())
+
+	proposal := &ty.Proposal{Proposal: vty.Proposal{Height: 4, Timestamp: time.Now().UnixNano(),
+		POLBlockID: &vty.BlockID{}, Blockhash: []byte("block-c")}}
+	assert.Nil(t, rs.SignProposal("chain", proposal))
+	assert.NotNil(t, proposal.Signature)
+
+	sig, err := rs.SignNodeKey("chain", nodeKey.PubKey().Bytes())
+	assert.Nil(t, err)
+	signature, err := cr.SignatureFromBytes(sig)
+	assert.Nil(t, err)
+	assert.True(t, rs.GetPubKey().VerifyBytes(ty.NodeKeySignBytes("chain", nodeKey.PubKey().Bytes()), signature))
+
+	// the signer refuses to double sign even the node is restarted
+	rs.ResetLastHeight(0)
+	assert.NotNil(t, rs.SignVote("chain", newVote("block-b")))
+
+	// the encrypted key file keeps the signed height round step
+	data, err := ioutil.ReadFile(keyFile)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), pv.PubKey.KeyString())
+	reloaded := ty.LoadPrivValidatorFSWithPassword(keyFile, password)
+	assert.Equal(t, pv.GetAddress(), reloaded.GetAddress())
+	assert.Equal(t, int64(4), reloaded.GetLastHeight())
+}
@@ -457,3 +457,15 @@ func CreateBlockInfoTx(pubkey string, state *tmtypes.State, block *tmtypes.Tende
 
 	return tx
 }
+
+// CreateEvidenceTx make double sign evidence to a transaction and execer is valnode
+func CreateEvidenceTx(pubkey string, ev *ttypes.DuplicateVoteEvidence) *types.Transaction {
+	nput := &tmtypes.ValNodeAction_Evidence{Evidence: ev.DuplicateVoteEvidence}
+	action := &tmtypes.ValNodeAction{Value: nput, Ty: tmtypes.ValNodeActionEvidence}
+	tx := &types.Transaction{Execer: []byte("valnode"), Payload: types.Encode(action), Fee: fee}
+	tx.To = address.ExecAddress("valnode")
+	tx.Nonce = random.Int63()
+	tx.Sign(types.SECP256K1, getprivkey(pubkey))
+
+	return tx
+}
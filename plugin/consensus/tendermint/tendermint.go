@@ -19,6 +19,8 @@ import (
 	cty "github.com/33cn/chain33/system/dapp/coins/types"
 	"github.com/33cn/chain33/types"
 	"github.com/33cn/chain33/util"
+	"github.com/33cn/plugin/plugin/consensus/peerfilter"
+	"github.com/33cn/plugin/plugin/consensus/remotesigner"
 	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
 	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
 	"github.com/golang/protobuf/proto"
@@ -62,6 +64,7 @@ type Client struct {
 	genesisDoc    *ttypes.GenesisDoc // initial validator set
 	privValidator ttypes.PrivValidator
 	privKey       crypto.PrivKey // local node's p2p key
+	peerFilter    *peerfilter.PeerFilter
 	pubKey        string
 	csState       *ConsensusState
 	csStore       *ConsensusStore // save consensus state
@@ -87,6 +90,16 @@ type subConfig struct {
 	CreateEmptyBlocksInterval int32    `json:"createEmptyBlocksInterval"`
 	ValidatorNodes            []string `json:"validatorNodes"`
 	FastSync                  bool     `json:"fastSync"`
+	PrivValidator             string   `json:"privValidator"`
+	SignerAddr                string   `json:"signerAddr"`
+	SignerPubKey              string   `json:"signerPubKey"`
+	SignerNodeKey             string   `json:"signerNodeKey"`
+	NodeKey                   string   `json:"nodeKey"`
+	AuthPeers                 bool     `json:"authPeers"`
+	AllowPeers                []string `json:"allowPeers"`
+	AllowIPs                  []string `json:"allowIPs"`
+	DenyIPs                   []string `json:"denyIPs"`
+	MaxPeersPerIP             int      `json:"maxPeersPerIP"`
 }
 
 func (client *Client) applyConfig(sub []byte) {
@@ -141,6 +154,11 @@ func DefaultDBProvider(name string) dbm.DB {
 	return dbm.NewDB(name, "leveldb", fmt.Sprintf("datadir%stendermint", string(os.PathSeparator)), 0)
 }
 
+// DefaultWALPath returns the path of the consensus write ahead log
+func DefaultWALPath() string {
+	return fmt.Sprintf("datadir%stendermint%scs.wal", string(os.PathSeparator), string(os.PathSeparator))
+}
+
 // New ...
 func New(cfg *types.Consensus, sub []byte) queue.Module {
 	tendermintlog.Info("Start to create tendermint client")
@@ -161,15 +179,21 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 
 	ttypes.ConsensusCrypto = cr
 
-	priv, err := cr.GenKey()
+	priv, err := loadNodeKey(sub)
 	if err != nil {
 		tendermintlog.Error("NewTendermintClient", "GenKey err", err)
 		return nil
 	}
 
-	privValidator := ttypes.LoadOrGenPrivValidatorFS("priv_validator.json")
-	if privValidator == nil {
-		tendermintlog.Error("NewTendermintClient create priv_validator file failed")
+	peerFilter, err := loadPeerFilter(sub)
+	if err != nil {
+		tendermintlog.Error("NewTendermintClient create peer filter failed", "err", err)
+		return nil
+	}
+
+	privValidator, err := loadPrivValidator(sub)
+	if err != nil {
+		tendermintlog.Error("NewTendermintClient create priv_validator failed", "err", err)
 		return nil
 	}
 
@@ -182,6 +206,7 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 		genesisDoc:    genDoc,
 		privValidator: privValidator,
 		privKey:       priv,
+		peerFilter:    peerFilter,
 		pubKey:        pubkey,
 		csStore:       NewConsensusStore(),
 		crypto:        cr,
@@ -194,6 +219,58 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 	return client
 }
 
+// loadPrivValidator uses the remote signer if signerAddr is configured,
+// otherwise the keys are loaded from privValidator, priv_validator.json by default
+func loadPrivValidator(sub []byte) (ttypes.PrivValidator, error) {
+	var subcfg subConfig
+	if sub != nil {
+		types.MustDecode(sub, &subcfg)
+	}
+	if subcfg.SignerAddr == "" {
+		privValidatorFile := subcfg.PrivValidator
+		if privValidatorFile == "" {
+			privValidatorFile = "priv_validator.json"
+		}
+		return ttypes.LoadOrGenPrivValidatorFS(privValidatorFile), nil
+	}
+	signerPubKey, err := ttypes.PubKeyFromString(subcfg.SignerPubKey)
+	if err != nil {
+		return nil, err
+	}
+	nodeKeyFile := subcfg.SignerNodeKey
+	if nodeKeyFile == "" {
+		nodeKeyFile = "signer_node_key.json"
+	}
+	nodeKey, err := remotesigner.LoadOrGenNodeKey(ttypes.ConsensusCrypto, nodeKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	tendermintlog.Info("Use remote signer", "addr", subcfg.SignerAddr, "nodePubKey", nodeKey.PubKey().KeyString())
+	return NewRemoteSigner(subcfg.SignerAddr, signerPubKey, nodeKey)
+}
+
+// loadNodeKey loads the p2p key from nodeKey file if configured,
+// the key should be kept if the node pubkey is in allowPeers of other nodes
+func loadNodeKey(sub []byte) (crypto.PrivKey, error) {
+	var subcfg subConfig
+	if sub != nil {
+		types.MustDecode(sub, &subcfg)
+	}
+	if subcfg.NodeKey == "" {
+		return ttypes.ConsensusCrypto.GenKey()
+	}
+	return remotesigner.LoadOrGenNodeKey(ttypes.ConsensusCrypto, subcfg.NodeKey)
+}
+
+// loadPeerFilter creates the filter of the consensus peers
+func loadPeerFilter(sub []byte) (*peerfilter.PeerFilter, error) {
+	var subcfg subConfig
+	if sub != nil {
+		types.MustDecode(sub, &subcfg)
+	}
+	return peerfilter.New("tendermint", subcfg.AuthPeers, subcfg.AllowPeers, subcfg.AllowIPs, subcfg.DenyIPs, subcfg.MaxPeersPerIP)
+}
+
 // PrivValidator returns the Node's PrivValidator.
 func (client *Client) PrivValidator() ttypes.PrivValidator {
 	return client.privValidator
@@ -306,6 +383,11 @@ OuterLoop:
 
 	// Make ConsensusReactor
 	csState := NewConsensusState(client, state, blockExec)
+	wal, err := OpenWAL(DefaultWALPath())
+	if err != nil {
+		panic(fmt.Sprintf("StartConsensus OpenWAL fail: %v", err))
+	}
+	csState.SetWAL(wal)
 	// reset height, round, state begin at newheigt,0,0
 	client.privValidator.ResetLastHeight(state.LastBlockHeight)
 	csState.SetPrivValidator(client.privValidator)
@@ -316,6 +398,14 @@ OuterLoop:
 	protocol, listeningAddress := "tcp", "0.0.0.0:46656"
 	node := NewNode(validatorNodes, protocol, listeningAddress, client.privKey, state.ChainID, tendermintVersion, csState)
 
+	node.SetPeerFilter(client.peerFilter)
+	sig, err := client.privValidator.SignNodeKey(state.ChainID, client.privKey.PubKey().Bytes())
+	if err != nil {
+		tendermintlog.Error("StartConsensus sign node key failed", "err", err)
+	} else {
+		node.SetNodeKeySig(client.privValidator.GetPubKey().Bytes(), sig)
+	}
+
 	client.node = node
 	node.Start()
 
@@ -468,14 +558,14 @@ func (client *Client) CheckTxDup(txs []*types.Transaction, height int64) (transa
 	return types.CacheToTxs(cacheTxs)
 }
 
-// BuildBlock build a new block
-func (client *Client) BuildBlock() *types.Block {
+// BuildBlock build a new block, evidence transactions follow the base transaction
+func (client *Client) BuildBlock(evidenceTxs []*types.Transaction) *types.Block {
 	lastBlock := client.GetCurrentBlock()
 	cfg := client.GetAPI().GetConfig()
-	txs := client.RequestTx(int(cfg.GetP(lastBlock.Height+1).MaxTxNumber)-1, nil)
+	txs := client.RequestTx(int(cfg.GetP(lastBlock.Height+1).MaxTxNumber)-1-len(evidenceTxs), nil)
 	// placeholder
 	tx0 := &types.Transaction{}
-	txs = append([]*types.Transaction{tx0}, txs...)
+	txs = append(append([]*types.Transaction{tx0}, evidenceTxs...), txs...)
 
 	var newblock types.Block
 	newblock.ParentHash = lastBlock.Hash(cfg)
@@ -11,13 +11,17 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/33cn/chain33/blockchain"
 	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/common/crypto"
 	"github.com/33cn/chain33/common/limits"
 	"github.com/33cn/chain33/common/log"
 	"github.com/33cn/chain33/executor"
@@ -53,13 +57,21 @@ func init() {
 	log.SetLogLevel("info")
 }
 func TestTendermintPerf(t *testing.T) {
-	TendermintPerf(t)
+	// the signed height round step is saved to priv_validator.json, so sign with a copy of the fixture
+	privValidator, err := ioutil.ReadFile("priv_validator.json")
+	assert.Nil(t, err)
+	dir, err := ioutil.TempDir("", "tendermint")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	privValidatorFile := filepath.Join(dir, "priv_validator.json")
+	assert.Nil(t, ioutil.WriteFile(privValidatorFile, privValidator, 0600))
+	TendermintPerf(t, privValidatorFile)
 	fmt.Println("=======start clear test data!=======")
 	clearTestData()
 }
 
-func TendermintPerf(t *testing.T) {
-	q, chain, s, mem, exec, cs := initEnvTendermint()
+func TendermintPerf(t *testing.T, privValidatorFile string) {
+	q, chain, s, mem, exec, cs := initEnvTendermint(privValidatorFile)
 	defer chain.Close()
 	defer mem.Close()
 	defer exec.Close()
@@ -76,17 +88,21 @@ func TendermintPerf(t *testing.T) {
 		time.Sleep(time.Second)
 	}
 	CheckState(t, cs.(*Client))
-	AddNode()
+	AddNode(t)
 	for i := 0; i < loopCount*3; i++ {
 		NormPut()
 		time.Sleep(time.Second)
 	}
 	time.Sleep(2 * time.Second)
+	_, vals := cs.(*Client).csState.GetValidators()
+	assert.Len(t, vals, 2)
 }
 
-func initEnvTendermint() (queue.Queue, *blockchain.BlockChain, queue.Module, queue.Module, *executor.Executor, queue.Module) {
+func initEnvTendermint(privValidatorFile string) (queue.Queue, *blockchain.BlockChain, queue.Module, queue.Module, *executor.Executor, queue.Module) {
 	flag.Parse()
-	chain33Cfg := types.NewChain33Config(types.ReadFile("chain33.test.toml"))
+	cfgstring := strings.Replace(types.ReadFile("chain33.test.toml"), "[consensus.sub.tendermint]",
+		fmt.Sprintf("[consensus.sub.tendermint]\nprivValidator=%q", privValidatorFile), 1)
+	chain33Cfg := types.NewChain33Config(cfgstring)
 	var q = queue.New("channel")
 	q.SetConfig(chain33Cfg)
 	cfg := chain33Cfg.GetModuleConfig()
@@ -185,29 +201,32 @@ func NormPut() {
 	}
 }
 
-func AddNode() {
+//AddNode 唯一的验证者提案增加一个验证者，提案者的批准超过2/3权重，提案直接通过
+func AddNode(t *testing.T) {
 	pubkey := "788657125A5A547B499F8B74239092EBB6466E8A205348D9EA645D510235A671"
 	pubkeybyte, err := hex.DecodeString(pubkey)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		return
-	}
-	nput := &vty.ValNodeAction_Node{Node: &vty.ValNode{PubKey: pubkeybyte, Power: int64(2)}}
-	action := &vty.ValNodeAction{Value: nput, Ty: vty.ValNodeActionUpdate}
+	assert.Nil(t, err)
+	propose := &vty.ValNodeAction_Propose{Propose: &vty.ValNodePropose{Nodes: []*vty.ValNode{{PubKey: pubkeybyte, Power: int64(2)}}}}
+	action := &vty.ValNodeAction{Value: propose, Ty: vty.ValNodeActionPropose}
 	tx := &types.Transaction{Execer: []byte("valnode"), Payload: types.Encode(action), Fee: fee}
 	tx.To = address.ExecAddress("valnode")
 	tx.Nonce = r.Int63()
-	tx.Sign(types.SECP256K1, getprivkey("CC38546E9E659D15E6B4893F0AB32A06D103931A8230B0BDE71459D2B27D6944"))
+	tx.Sign(types.ED25519, getValidatorKey(t))
 
 	reply, err := c.SendTransaction(context.Background(), tx)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		return
-	}
-	if !reply.IsOk {
-		fmt.Fprintln(os.Stderr, errors.New(string(reply.GetMsg())))
-		return
-	}
+	assert.Nil(t, err)
+	assert.True(t, reply.GetIsOk(), string(reply.GetMsg()))
+}
+
+//getValidatorKey 测试验证者priv_validator.json中的私钥
+func getValidatorKey(t *testing.T) crypto.PrivKey {
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	assert.Nil(t, err)
+	key, err := hex.DecodeString("23278EA4CFE8B00360EBB376F2BBFAC345136EE5BC4549532C394C0AF2B80DFE8D80E15927EF2854C78D981015BD2AD469867957081357D0FADD88871752A7E1")
+	assert.Nil(t, err)
+	priv, err := cr.PrivKeyFromBytes(key)
+	assert.Nil(t, err)
+	return priv
 }
 
 func CheckState(t *testing.T, client *Client) {
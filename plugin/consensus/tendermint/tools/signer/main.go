@@ -0,0 +1,103 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// signer is the reference remote signer of the tendermint validator.
+// The validator key is kept in a file encrypted with the password, which is read from
+// the SIGNER_PASSWORD environment or the terminal. Only the consensus nodes whose
+// node pubkeys are in the allow list can connect.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/consensus/remotesigner"
+	"github.com/33cn/plugin/plugin/consensus/tendermint"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var (
+	keyFile     = flag.String("key", "priv_validator.enc.json", "encrypted validator key file, created if not exist")
+	importFile  = flag.String("import", "", "plain priv_validator.json to be encrypted into the key file")
+	nodeKeyFile = flag.String("nodekey", "signer_node_key.json", "signer identity key file, created if not exist")
+	laddr       = flag.String("laddr", "127.0.0.1:46670", "listen address")
+	allow       = flag.String("allow", "", "comma separated node pubkeys of the authorized consensus nodes")
+)
+
+func main() {
+	flag.Parse()
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	if err != nil {
+		exit(err)
+	}
+	ttypes.ConsensusCrypto = cr
+
+	var authorized []crypto.PubKey
+	for _, key := range strings.Split(*allow, ",") {
+		if key = strings.TrimSpace(key); key == "" {
+			continue
+		}
+		pubKey, err := ttypes.PubKeyFromString(key)
+		if err != nil {
+			exit(err)
+		}
+		authorized = append(authorized, pubKey)
+	}
+	if len(authorized) == 0 {
+		exit(fmt.Errorf("no authorized node pubkey, use -allow"))
+	}
+
+	password, err := readPassword()
+	if err != nil {
+		exit(err)
+	}
+	var privValidator *ttypes.PrivValidatorImp
+	if *importFile != "" {
+		if _, err := os.Stat(*keyFile); err == nil {
+			exit(fmt.Errorf("key file %v already exists", *keyFile))
+		}
+		privValidator = ttypes.ImportPrivValidatorFS(*importFile, *keyFile, password)
+	} else {
+		privValidator = ttypes.LoadOrGenPrivValidatorFSWithPassword(*keyFile, password)
+	}
+	nodeKey, err := remotesigner.LoadOrGenNodeKey(cr, *nodeKeyFile)
+	if err != nil {
+		exit(err)
+	}
+
+	server := tendermint.NewSignerServer(privValidator, nodeKey, authorized)
+	if err := server.Start(*laddr); err != nil {
+		exit(err)
+	}
+	fmt.Println("validator pubkey:", privValidator.GetPubKey().KeyString())
+	fmt.Println("signer pubkey:", nodeKey.PubKey().KeyString())
+	fmt.Println("listen on:", server.Addr())
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+	<-c
+	server.Stop()
+}
+
+func readPassword() ([]byte, error) {
+	if password := os.Getenv("SIGNER_PASSWORD"); password != "" {
+		return []byte(password), nil
+	}
+	fmt.Print("password: ")
+	password, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	return password, err
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
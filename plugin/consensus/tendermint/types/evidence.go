@@ -0,0 +1,120 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
+)
+
+// error defines
+var (
+	ErrEvidenceInvalid      = errors.New("Invalid evidence")
+	ErrEvidenceNotConflict  = errors.New("Evidence votes are not conflicting")
+	ErrEvidenceInvalidVotes = errors.New("Evidence votes are not from the same validator and step")
+	ErrEvidenceInvalidSig   = errors.New("Evidence vote signature is invalid")
+)
+
+// ErrVoteConflictingVotes is returned by VoteSet when a validator signed two different votes
+// for the same height/round/type. It carries the evidence of the double sign.
+type ErrVoteConflictingVotes struct {
+	*DuplicateVoteEvidence
+}
+
+func (err *ErrVoteConflictingVotes) Error() string {
+	return fmt.Sprintf("Conflicting votes from validator %X", err.Address())
+}
+
+// Cause make errors.Cause return ErrVoteConflict
+func (err *ErrVoteConflictingVotes) Cause() error {
+	return ErrVoteConflict
+}
+
+// DuplicateVoteEvidence contains evidence a validator signed two conflicting votes.
+type DuplicateVoteEvidence struct {
+	*tmtypes.DuplicateVoteEvidence
+}
+
+// NewDuplicateVoteEvidence creates evidence from two conflicting votes.
+// The votes are ordered by block hash so the same double sign always gives the same evidence.
+func NewDuplicateVoteEvidence(pubKey []byte, chainID string, voteA, voteB *Vote) *DuplicateVoteEvidence {
+	a, b := voteA.Vote, voteB.Vote
+	if bytes.Compare(a.BlockID.GetHash(), b.BlockID.GetHash()) > 0 {
+		a, b = b, a
+	}
+	return &DuplicateVoteEvidence{
+		DuplicateVoteEvidence: &tmtypes.DuplicateVoteEvidence{
+			PubKey:  pubKey,
+			VoteA:   a,
+			VoteB:   b,
+			ChainID: chainID,
+		},
+	}
+}
+
+// Height returns the height the double sign happened at
+func (ev *DuplicateVoteEvidence) Height() int64 {
+	return ev.VoteA.GetHeight()
+}
+
+// Address returns the address of the double signing validator
+func (ev *DuplicateVoteEvidence) Address() []byte {
+	return ev.VoteA.GetValidatorAddress()
+}
+
+// Hash returns the hash of the evidence
+func (ev *DuplicateVoteEvidence) Hash() []byte {
+	return crypto.Ripemd160(types.Encode(ev.DuplicateVoteEvidence))
+}
+
+// String returns a string representation of the evidence
+func (ev *DuplicateVoteEvidence) String() string {
+	return fmt.Sprintf("DuplicateVoteEvidence{%X %v/%02d/%v %X %X}", Fingerprint(ev.Address()), ev.Height(),
+		ev.VoteA.GetRound(), ev.VoteA.GetType(), Fingerprint(ev.VoteA.GetBlockID().GetHash()), Fingerprint(ev.VoteB.GetBlockID().GetHash()))
+}
+
+// Verify returns an error if the two votes aren't conflicting or aren't signed by the validator.
+// It does not rely on ConsensusCrypto so that the executor can verify evidence as well.
+func (ev *DuplicateVoteEvidence) Verify() error {
+	if ev.DuplicateVoteEvidence == nil || ev.VoteA == nil || ev.VoteB == nil ||
+		ev.VoteA.BlockID == nil || ev.VoteB.BlockID == nil {
+		return ErrEvidenceInvalid
+	}
+	a, b := ev.VoteA, ev.VoteB
+	if !IsVoteTypeValid(byte(a.Type)) || a.Height != b.Height || a.Round != b.Round || a.Type != b.Type ||
+		a.ValidatorIndex != b.ValidatorIndex || !bytes.Equal(a.ValidatorAddress, b.ValidatorAddress) {
+		return ErrEvidenceInvalidVotes
+	}
+	if bytes.Equal(a.BlockID.Hash, b.BlockID.Hash) {
+		return ErrEvidenceNotConflict
+	}
+
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	if err != nil {
+		return err
+	}
+	pubKey, err := cr.PubKeyFromBytes(ev.PubKey)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(GenAddressByPubKey(pubKey), a.ValidatorAddress) {
+		return ErrVoteInvalidValidatorAddress
+	}
+	for _, vote := range []*tmtypes.Vote{a, b} {
+		sig, err := cr.SignatureFromBytes(vote.Signature)
+		if err != nil {
+			return ErrEvidenceInvalidSig
+		}
+		if !pubKey.VerifyBytes(SignBytes(ev.ChainID, &Vote{Vote: vote}), sig) {
+			return ErrEvidenceInvalidSig
+		}
+	}
+	return nil
+}
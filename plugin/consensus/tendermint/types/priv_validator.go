@@ -17,6 +17,8 @@ import (
 	"encoding/hex"
 
 	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/plugin/plugin/consensus/peerfilter"
+	"github.com/33cn/plugin/plugin/consensus/privfile"
 )
 
 // TODO: type ?
@@ -45,6 +47,16 @@ func voteToStep(vote *Vote) int8 {
 	}
 }
 
+// VoteStep returns the sign step of the vote
+func VoteStep(vote *Vote) int8 {
+	return voteToStep(vote)
+}
+
+// ProposalStep returns the sign step of the proposal
+func ProposalStep() int8 {
+	return stepPropose
+}
+
 // PrivValidator defines the functionality of a local Tendermint validator
 // that signs votes, proposals, and heartbeats, and never double signs.
 type PrivValidator interface {
@@ -54,12 +66,13 @@ type PrivValidator interface {
 	SignVote(chainID string, vote *Vote) error
 	SignProposal(chainID string, proposal *Proposal) error
 	SignHeartbeat(chainID string, heartbeat *Heartbeat) error
+	SignNodeKey(chainID string, nodeKey []byte) ([]byte, error)
 
 	GetLastHeight() int64
 	GetLastRound() int
 	GetLastStep() int8
 
-	//reset height,round,step used by start to catch up
+	//reset height,round,step used by start to catch up, never go back to the signed height
 	ResetLastHeight(height int64)
 }
 
@@ -97,6 +110,10 @@ type PrivValidatorImp struct {
 	// Overloaded for testing.
 	filePath string
 	mtx      sync.Mutex
+
+	// the file is encrypted with the key derived from password if it's set
+	fileKey  []byte
+	fileSalt []byte
 }
 
 // Signer is an interface that defines how to sign messages.
@@ -217,8 +234,62 @@ func LoadPrivValidatorFSWithSigner(filePath string, signerFunc func(PrivValidato
 	if err != nil {
 		Exit(err.Error())
 	}
+	return privValidatorFromJSON(filePath, privValJSONBytes, signerFunc)
+}
+
+// LoadPrivValidatorFSWithPassword loads a PrivValidatorImp from the file encrypted with password.
+// The signed height round step is saved encrypted as well.
+func LoadPrivValidatorFSWithPassword(filePath string, password []byte) *PrivValidatorImp {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		Exit(err.Error())
+	}
+	privValJSONBytes, key, salt, err := privfile.Decrypt(data, password)
+	if err != nil {
+		Exit(Fmt("Error decrypting PrivValidator from %v: %v\n", filePath, err))
+	}
+	privVal := privValidatorFromJSON(filePath, privValJSONBytes, func(privVal PrivValidator) Signer {
+		return NewDefaultSigner(privVal.(*PrivValidatorImp).PrivKey)
+	})
+	privVal.fileKey = key
+	privVal.fileSalt = salt
+	return privVal
+}
+
+// LoadOrGenPrivValidatorFSWithPassword loads the encrypted PrivValidatorImp from the given filePath
+// or else generates a new one and saves it encrypted with password.
+func LoadOrGenPrivValidatorFSWithPassword(filePath string, password []byte) *PrivValidatorImp {
+	if _, err := os.Stat(filePath); err == nil {
+		return LoadPrivValidatorFSWithPassword(filePath, password)
+	}
+	privVal := GenPrivValidatorImp(filePath)
+	key, salt, err := privfile.DeriveKey(password, nil)
+	if err != nil {
+		Exit(Fmt("Error deriving key for PrivValidator: %v\n", err))
+	}
+	privVal.fileKey = key
+	privVal.fileSalt = salt
+	privVal.Save()
+	return privVal
+}
+
+// ImportPrivValidatorFS encrypts the plain priv validator file with password and saves it to filePath
+func ImportPrivValidatorFS(plainFilePath string, filePath string, password []byte) *PrivValidatorImp {
+	privVal := LoadPrivValidatorFS(plainFilePath)
+	key, salt, err := privfile.DeriveKey(password, nil)
+	if err != nil {
+		Exit(Fmt("Error deriving key for PrivValidator: %v\n", err))
+	}
+	privVal.filePath = filePath
+	privVal.fileKey = key
+	privVal.fileSalt = salt
+	privVal.Save()
+	return privVal
+}
+
+func privValidatorFromJSON(filePath string, privValJSONBytes []byte, signerFunc func(PrivValidator) Signer) *PrivValidatorImp {
 	privVal := &PrivValidatorFS{}
-	err = json.Unmarshal(privValJSONBytes, &privVal)
+	err := json.Unmarshal(privValJSONBytes, &privVal)
 	if err != nil {
 		Exit(Fmt("Error reading PrivValidator from %v: %v\n", filePath, err))
 	}
@@ -311,6 +382,12 @@ func (pv *PrivValidatorImp) save() {
 		// `@; BOOM!!!
 		PanicCrisis(err)
 	}
+	if pv.fileKey != nil {
+		jsonBytes, err = privfile.Encrypt(jsonBytes, pv.fileKey, pv.fileSalt)
+		if err != nil {
+			PanicCrisis(err)
+		}
+	}
 	err = WriteFileAtomic(pv.filePath, jsonBytes, 0600)
 	if err != nil {
 		// `@; BOOM!!!
@@ -334,11 +411,14 @@ func (pv *PrivValidatorImp) Reset() {
 func (pv *PrivValidatorImp) SignVote(chainID string, vote *Vote) error {
 	pv.mtx.Lock()
 	defer pv.mtx.Unlock()
-	signature, err := pv.signBytesHRS(vote.Height, int(vote.Round), voteToStep(vote),
+	signature, timestamp, err := pv.signBytesHRS(vote.Height, int(vote.Round), voteToStep(vote),
 		SignBytes(chainID, vote), checkVotesOnlyDifferByTimestamp)
 	if err != nil {
 		return errors.New(Fmt("Error signing vote: %v", err))
 	}
+	if timestamp != 0 {
+		vote.Timestamp = timestamp
+	}
 	vote.Signature = signature.Bytes()
 	return nil
 }
@@ -348,11 +428,14 @@ func (pv *PrivValidatorImp) SignVote(chainID string, vote *Vote) error {
 func (pv *PrivValidatorImp) SignProposal(chainID string, proposal *Proposal) error {
 	pv.mtx.Lock()
 	defer pv.mtx.Unlock()
-	signature, err := pv.signBytesHRS(proposal.Height, int(proposal.Round), stepPropose,
+	signature, timestamp, err := pv.signBytesHRS(proposal.Height, int(proposal.Round), stepPropose,
 		SignBytes(chainID, proposal), checkProposalsOnlyDifferByTimestamp)
 	if err != nil {
 		return fmt.Errorf("Error signing proposal: %v", err)
 	}
+	if timestamp != 0 {
+		proposal.Timestamp = timestamp
+	}
 	proposal.Signature = signature.Bytes()
 	return nil
 }
@@ -387,13 +470,14 @@ func (pv *PrivValidatorImp) checkHRS(height int64, round int, step int8) (bool,
 
 // signBytesHRS signs the given signBytes if the height/round/step (HRS) are
 // greater than the latest state. If the HRS are equal and the only thing changed is the timestamp,
-// it returns the privValidator.LastSignature. Else it returns an error.
+// it returns the privValidator.LastSignature with the timestamp signed last time. Else it returns an error.
+// The signed HRS is persisted before the signature is returned.
 func (pv *PrivValidatorImp) signBytesHRS(height int64, round int, step int8,
-	signBytes []byte, checkFn checkOnlyDifferByTimestamp) (crypto.Signature, error) {
+	signBytes []byte, checkFn checkOnlyDifferByTimestamp) (crypto.Signature, int64, error) {
 
 	sameHRS, err := pv.checkHRS(height, round, step)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	// We might crash before writing to the wal,
@@ -401,19 +485,21 @@ func (pv *PrivValidatorImp) signBytesHRS(height int64, round int, step int8,
 	if sameHRS {
 		// if they're the same or only differ by timestamp,
 		// return the LastSignature. Otherwise, error
-		if bytes.Equal(signBytes, pv.LastSignBytes) ||
-			checkFn(pv.LastSignBytes, signBytes) {
-			return pv.LastSignature, nil
+		if bytes.Equal(signBytes, pv.LastSignBytes) {
+			return pv.LastSignature, 0, nil
+		}
+		if timestamp, ok := checkFn(pv.LastSignBytes, signBytes); ok {
+			return pv.LastSignature, timestamp, nil
 		}
-		return nil, fmt.Errorf("Conflicting data")
+		return nil, 0, fmt.Errorf("Conflicting data")
 	}
 
 	sig, err := pv.Sign(signBytes)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	//pv.saveSigned(height, round, step, signBytes, sig)
-	return sig, nil
+	pv.saveSigned(height, round, step, signBytes, sig)
+	return sig, 0, nil
 }
 
 // Persist height/round/step and signature
@@ -438,6 +524,24 @@ func (pv *PrivValidatorImp) SignHeartbeat(chainID string, heartbeat *Heartbeat)
 	return err
 }
 
+// SignNodeKey signs the p2p node pubkey, which binds the node key to the validator in peer handshake.
+// Implements PrivValidator.
+func (pv *PrivValidatorImp) SignNodeKey(chainID string, nodeKey []byte) ([]byte, error) {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	sig, err := pv.Sign(NodeKeySignBytes(chainID, nodeKey))
+	if err != nil {
+		return nil, err
+	}
+	return sig.Bytes(), nil
+}
+
+// NodeKeySignBytes returns the bytes signed by the validator for the node pubkey,
+// the prefix makes it never be the sign bytes of a vote, proposal or heartbeat
+func NodeKeySignBytes(chainID string, nodeKey []byte) []byte {
+	return peerfilter.NodeKeySignBytes("tendermint", chainID, nodeKey)
+}
+
 // String returns a string representation of the PrivValidatorImp.
 func (pv *PrivValidatorImp) String() string {
 	return Fmt("PrivValidator{%X LH:%v, LR:%v, LS:%v}", pv.GetAddress(), pv.LastHeight, pv.LastRound, pv.LastStep)
@@ -458,8 +562,14 @@ func (pv *PrivValidatorImp) GetLastStep() int8 {
 	return pv.LastStep
 }
 
-// ResetLastHeight ...
+// ResetLastHeight reset the height round step to catch up.
+// The signed height round step is kept if it's not lower, to prevent double signing after restart.
 func (pv *PrivValidatorImp) ResetLastHeight(height int64) {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	if pv.LastHeight >= height {
+		return
+	}
 	pv.LastHeight = height
 	pv.LastRound = 0
 	pv.LastStep = 0
@@ -484,10 +594,10 @@ func (pvs PrivValidatorsByAddress) Swap(i, j int) {
 
 //-------------------------------------
 
-type checkOnlyDifferByTimestamp func([]byte, []byte) bool
+type checkOnlyDifferByTimestamp func([]byte, []byte) (int64, bool)
 
-// returns true if the only difference in the votes is their timestamp
-func checkVotesOnlyDifferByTimestamp(lastSignBytes, newSignBytes []byte) bool {
+// returns the last timestamp and true if the only difference in the votes is their timestamp
+func checkVotesOnlyDifferByTimestamp(lastSignBytes, newSignBytes []byte) (int64, bool) {
 	var lastVote, newVote CanonicalJSONOnceVote
 	if err := json.Unmarshal(lastSignBytes, &lastVote); err != nil {
 		panic(Fmt("LastSignBytes cannot be unmarshalled into vote: %v", err))
@@ -496,6 +606,11 @@ func checkVotesOnlyDifferByTimestamp(lastSignBytes, newSignBytes []byte) bool {
 		panic(Fmt("signBytes cannot be unmarshalled into vote: %v", err))
 	}
 
+	lastTime, err := time.Parse(timeFormat, lastVote.Vote.Timestamp)
+	if err != nil {
+		panic(Fmt("LastSignBytes timestamp cannot be parsed: %v", err))
+	}
+
 	// set the times to the same value and check equality
 	now := CanonicalTime(time.Now())
 	lastVote.Vote.Timestamp = now
@@ -509,11 +624,11 @@ func checkVotesOnlyDifferByTimestamp(lastSignBytes, newSignBytes []byte) bool {
 		panic(Fmt("Marshal newVoteBytes failed: %v", err))
 	}
 
-	return bytes.Equal(newVoteBytes, lastVoteBytes)
+	return lastTime.UnixNano(), bytes.Equal(newVoteBytes, lastVoteBytes)
 }
 
-// returns true if the only difference in the proposals is their timestamp
-func checkProposalsOnlyDifferByTimestamp(lastSignBytes, newSignBytes []byte) bool {
+// returns the last timestamp and true if the only difference in the proposals is their timestamp
+func checkProposalsOnlyDifferByTimestamp(lastSignBytes, newSignBytes []byte) (int64, bool) {
 	var lastProposal, newProposal CanonicalJSONOnceProposal
 	if err := json.Unmarshal(lastSignBytes, &lastProposal); err != nil {
 		panic(Fmt("LastSignBytes cannot be unmarshalled into proposal: %v", err))
@@ -522,6 +637,11 @@ func checkProposalsOnlyDifferByTimestamp(lastSignBytes, newSignBytes []byte) boo
 		panic(Fmt("signBytes cannot be unmarshalled into proposal: %v", err))
 	}
 
+	lastTime, err := time.Parse(timeFormat, lastProposal.Proposal.Timestamp)
+	if err != nil {
+		panic(Fmt("LastSignBytes timestamp cannot be parsed: %v", err))
+	}
+
 	// set the times to the same value and check equality
 	now := CanonicalTime(time.Now())
 	lastProposal.Proposal.Timestamp = now
@@ -535,5 +655,5 @@ func checkProposalsOnlyDifferByTimestamp(lastSignBytes, newSignBytes []byte) boo
 		panic(Fmt("Marshal newProposalBytes failed: %v", err))
 	}
 
-	return bytes.Equal(newProposalBytes, lastProposalBytes)
+	return lastTime.UnixNano(), bytes.Equal(newProposalBytes, lastProposalBytes)
 }
@@ -42,6 +42,7 @@ const (
 	ProposalHeartbeatID = byte(0x08)
 	ProposalBlockID     = byte(0x09)
 	ValidBlockID        = byte(0x0a)
+	EvidenceID          = byte(0x0b)
 
 	PacketTypePing = byte(0xff)
 	PacketTypePong = byte(0xfe)
@@ -60,6 +61,7 @@ func InitMessageMap() {
 		ProposalHeartbeatID: reflect.TypeOf(tmtypes.Heartbeat{}),
 		ProposalBlockID:     reflect.TypeOf(tmtypes.TendermintBlock{}),
 		ValidBlockID:        reflect.TypeOf(tmtypes.ValidBlockMsg{}),
+		EvidenceID:          reflect.TypeOf(tmtypes.DuplicateVoteEvidence{}),
 	}
 }
 
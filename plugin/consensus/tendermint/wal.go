@@ -0,0 +1,256 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tendermint
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// wal record types
+const (
+	walTypeMsgInfo   = byte(0x01)
+	walTypeTimeout   = byte(0x02)
+	walTypeEndHeight = byte(0x03)
+
+	walHeaderSize    = 8
+	maxWALRecordSize = 10 * 1024 * 1024
+)
+
+// Errors define
+var (
+	ErrWALCorrupted   = errors.New("Error wal record corrupted")
+	ErrWALUnknownType = errors.New("Error wal unknown record type")
+)
+
+// WALMessage is MsgInfo, timeoutInfo or EndHeightMessage
+type WALMessage interface{}
+
+// EndHeightMessage marks the end of the given height inside WAL.
+type EndHeightMessage struct {
+	Height int64 `json:"height"`
+}
+
+// WAL is the write ahead log of the consensus messages.
+// Messages are written before they are handled, and replayed on startup
+// so a restarted validator continues the height/round it was in.
+type WAL interface {
+	Write(WALMessage) error
+	WriteSync(WALMessage) error
+	WriteEndHeight(height int64) error
+	ReadFromEndHeight(height int64) ([]WALMessage, bool, error)
+	Close()
+}
+
+// BaseWAL write the records to a file, each record is crc32(4)+len(4)+data
+type BaseWAL struct {
+	mtx  sync.Mutex
+	path string
+	file *os.File
+}
+
+// OpenWAL opens the wal file at path, the file is created if it does not exist
+func OpenWAL(path string) (*BaseWAL, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &BaseWAL{path: path, file: file}, nil
+}
+
+// Write writes the message to the file without fsync
+func (wal *BaseWAL) Write(msg WALMessage) error {
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+	return wal.write(msg)
+}
+
+// WriteSync writes the message and fsync the file, it is used for our own messages
+// which must be in the wal before they are sent out.
+func (wal *BaseWAL) WriteSync(msg WALMessage) error {
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+	if err := wal.write(msg); err != nil {
+		return err
+	}
+	return wal.file.Sync()
+}
+
+func (wal *BaseWAL) write(msg WALMessage) error {
+	record, err := encodeWALRecord(msg)
+	if err != nil {
+		return err
+	}
+	_, err = wal.file.Write(record)
+	return err
+}
+
+// WriteEndHeight marks the height is committed. The records of the committed heights
+// are not needed any more, so the file is replaced by one only holding the mark.
+func (wal *BaseWAL) WriteEndHeight(height int64) error {
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+	record, err := encodeWALRecord(EndHeightMessage{Height: height})
+	if err != nil {
+		return err
+	}
+	if err := ttypes.WriteFileAtomic(wal.path, record, 0600); err != nil {
+		return err
+	}
+	wal.file.Close()
+	wal.file, err = os.OpenFile(wal.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	return err
+}
+
+// ReadFromEndHeight returns the messages after the end mark of height.
+// found is false if there is no end mark of the height. A torn record at the tail,
+// which is left by a crash during writing, ends the reading without error.
+func (wal *BaseWAL) ReadFromEndHeight(height int64) (msgs []WALMessage, found bool, err error) {
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+	file, err := os.Open(wal.path)
+	if err != nil {
+		return nil, false, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		msg, err := readWALRecord(reader)
+		if err == io.EOF || err == io.ErrUnexpectedEOF || err == ErrWALCorrupted {
+			if err != io.EOF {
+				tendermintlog.Error("ReadFromEndHeight stop at torn record", "err", err)
+			}
+			return msgs, found, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if end, ok := msg.(EndHeightMessage); ok {
+			if end.Height == height {
+				msgs, found = nil, true
+				continue
+			}
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+// Close closes the wal file
+func (wal *BaseWAL) Close() {
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+	wal.file.Close()
+}
+
+func encodeWALRecord(msg WALMessage) ([]byte, error) {
+	data, err := encodeWALMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	record := make([]byte, walHeaderSize+len(data))
+	binary.BigEndian.PutUint32(record[0:4], crc32.ChecksumIEEE(data))
+	binary.BigEndian.PutUint32(record[4:8], uint32(len(data)))
+	copy(record[walHeaderSize:], data)
+	return record, nil
+}
+
+func readWALRecord(reader io.Reader) (WALMessage, error) {
+	var header [walHeaderSize]byte
+	if _, err := io.ReadFull(reader, header[:]); err != nil {
+		return nil, err
+	}
+	crc := binary.BigEndian.Uint32(header[0:4])
+	length := binary.BigEndian.Uint32(header[4:8])
+	if length == 0 || length > maxWALRecordSize {
+		return nil, ErrWALCorrupted
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return nil, err
+	}
+	if crc32.ChecksumIEEE(data) != crc {
+		return nil, ErrWALCorrupted
+	}
+	return decodeWALMessage(data)
+}
+
+func encodeWALMessage(msg WALMessage) ([]byte, error) {
+	switch m := msg.(type) {
+	case MsgInfo:
+		bytes, err := proto.Marshal(m.Msg)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{walTypeMsgInfo, m.TypeID}, bytes...), nil
+	case timeoutInfo:
+		bytes, err := json.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{walTypeTimeout}, bytes...), nil
+	case EndHeightMessage:
+		bytes := make([]byte, 9)
+		bytes[0] = walTypeEndHeight
+		binary.BigEndian.PutUint64(bytes[1:], uint64(m.Height))
+		return bytes, nil
+	default:
+		return nil, fmt.Errorf("Unknown wal message %v", reflect.TypeOf(msg))
+	}
+}
+
+func decodeWALMessage(data []byte) (WALMessage, error) {
+	switch data[0] {
+	case walTypeMsgInfo:
+		if len(data) < 2 {
+			return nil, ErrWALCorrupted
+		}
+		v, ok := ttypes.MsgMap[data[1]]
+		if !ok {
+			return nil, ErrWALUnknownType
+		}
+		msg := reflect.New(v).Interface().(proto.Message)
+		if err := proto.Unmarshal(data[2:], msg); err != nil {
+			return nil, err
+		}
+		return MsgInfo{TypeID: data[1], Msg: msg}, nil
+	case walTypeTimeout:
+		var ti timeoutInfo
+		if err := json.Unmarshal(data[1:], &ti); err != nil {
+			return nil, err
+		}
+		return ti, nil
+	case walTypeEndHeight:
+		if len(data) != 9 {
+			return nil, ErrWALCorrupted
+		}
+		return EndHeightMessage{Height: int64(binary.BigEndian.Uint64(data[1:]))}, nil
+	default:
+		return nil, ErrWALUnknownType
+	}
+}
+
+type nilWAL struct{}
+
+func (nilWAL) Write(m WALMessage) error                                   { return nil }
+func (nilWAL) WriteSync(m WALMessage) error                               { return nil }
+func (nilWAL) WriteEndHeight(height int64) error                          { return nil }
+func (nilWAL) ReadFromEndHeight(height int64) ([]WALMessage, bool, error) { return nil, false, nil }
+func (nilWAL) Close()                                                     {}
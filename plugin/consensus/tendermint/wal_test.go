@@ -0,0 +1,102 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tendermint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	ty "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	vty "github.com/33cn/plugin/plugin/dapp/valnode/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWAL(t *testing.T) {
+	ty.InitMessageMap()
+	dir, err := ioutil.TempDir("", "tendermint-wal")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cs.wal")
+
+	wal, err := OpenWAL(path)
+	assert.Nil(t, err)
+	vote := &vty.Vote{Height: 2, Round: 1, Type: uint32(ty.VoteTypePrevote), BlockID: &vty.BlockID{Hash: []byte("block")}}
+	assert.Nil(t, wal.Write(MsgInfo{TypeID: ty.VoteID, Msg: vote, PeerID: "peer"}))
+	assert.Nil(t, wal.WriteEndHeight(1))
+	assert.Nil(t, wal.WriteSync(MsgInfo{TypeID: ty.VoteID, Msg: vote}))
+	ti := timeoutInfo{Duration: time.Second, Height: 2, Round: 1, Step: ty.RoundStepPrevoteWait}
+	assert.Nil(t, wal.Write(ti))
+	wal.Close()
+
+	// the records before the end height are dropped
+	wal, err = OpenWAL(path)
+	assert.Nil(t, err)
+	msgs, found, err := wal.ReadFromEndHeight(1)
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 2, len(msgs))
+	assert.Equal(t, ty.VoteID, msgs[0].(MsgInfo).TypeID)
+	assert.Equal(t, vote.BlockID.Hash, msgs[0].(MsgInfo).Msg.(*vty.Vote).BlockID.Hash)
+	assert.Equal(t, ti, msgs[1])
+
+	_, found, err = wal.ReadFromEndHeight(5)
+	assert.Nil(t, err)
+	assert.False(t, found)
+	wal.Close()
+
+	// a torn record at the tail is ignored
+	data, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(path, data[:len(data)-3], 0600))
+	wal, err = OpenWAL(path)
+	assert.Nil(t, err)
+	msgs, found, err = wal.ReadFromEndHeight(1)
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 1, len(msgs))
+	wal.Close()
+}
+
+func TestPrivValidatorLastSigned(t *testing.T) {
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	assert.Nil(t, err)
+	ty.ConsensusCrypto = cr
+	dir, err := ioutil.TempDir("", "tendermint-privval")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "priv_validator.json")
+
+	pv := ty.LoadOrGenPrivValidatorFS(path)
+	newVote := func(hash string) *ty.Vote {
+		return &ty.Vote{Vote: &vty.Vote{
+			ValidatorAddress: pv.GetAddress(),
+			Height:           5,
+			Round:            0,
+			Timestamp:        time.Now().UnixNano(),
+			Type:             uint32(ty.VoteTypePrevote),
+			BlockID:          &vty.BlockID{Hash: []byte(hash)},
+		}}
+	}
+	vote := newVote("block-a")
+	assert.Nil(t, pv.SignVote("chain", vote))
+
+	// restart, the signed height round step is loaded from file
+	pv = ty.LoadPrivValidatorFS(path)
+	pv.ResetLastHeight(4)
+	assert.Equal(t, int64(5), pv.GetLastHeight())
+	assert.NotNil(t, pv.SignVote("chain", newVote("block-b")))
+
+	// sign the same vote again gives the same signature and timestamp
+	time.Sleep(10 * time.Millisecond)
+	again := newVote("block-a")
+	assert.Nil(t, pv.SignVote("chain", again))
+	assert.Equal(t, vote.Signature, again.Signature)
+	assert.Nil(t, again.Verify("chain", pv.GetPubKey()))
+}
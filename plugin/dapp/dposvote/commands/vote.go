@@ -58,6 +58,8 @@ func DPosCmd() *cobra.Command {
 		DPosCBRecordCmd(),
 		DPosCBQueryCmd(),
 		DPosTopNQueryCmd(),
+		DPosUnjailCmd(),
+		DPosJailQueryCmd(),
 	)
 
 	return cmd
@@ -987,3 +989,76 @@ func topNQuery(cmd *cobra.Command, args []string) {
 	ctx := jsonrpc.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &res)
 	ctx.Run()
 }
+
+//DPosUnjailCmd 构造被禁止出块的节点恢复出块资格的命令行
+func DPosUnjailCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unjail",
+		Short: "unjail a jailed candidator",
+		Run:   unjail,
+	}
+	addUnjailFlags(cmd)
+	return cmd
+}
+
+func addUnjailFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("pubkey", "k", "", "pubkey")
+	cmd.MarkFlagRequired("pubkey")
+}
+
+func unjail(cmd *cobra.Command, args []string) {
+	title, _ := cmd.Flags().GetString("title")
+	cfg := types.GetCliSysParam(title)
+
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	pubkey, _ := cmd.Flags().GetString("pubkey")
+
+	payload := fmt.Sprintf("{\"pubkey\":\"%s\"}", pubkey)
+	params := &rpctypes.CreateTxIn{
+		Execer:     cfg.ExecName(dty.DPosX),
+		ActionName: dty.CreateUnjailTx,
+		Payload:    []byte(payload),
+	}
+
+	var res string
+	ctx := jsonrpc.NewRPCCtx(rpcLaddr, "Chain33.CreateTransaction", params, &res)
+	ctx.RunWithoutMarshal()
+}
+
+//DPosJailQueryCmd 构造查询被禁止出块节点的命令行
+func DPosJailQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jailQuery",
+		Short: "query jailed candidators",
+		Run:   jailQuery,
+	}
+	addJailQueryFlags(cmd)
+	return cmd
+}
+
+func addJailQueryFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64P("cycle", "c", 0, "cycle, 0 means the candidators in jailed status")
+	cmd.Flags().StringP("pubkeys", "k", "", "pubkeys")
+}
+
+func jailQuery(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	cycle, _ := cmd.Flags().GetInt64("cycle")
+	pubkeys, _ := cmd.Flags().GetString("pubkeys")
+
+	var params rpctypes.Query4Jrpc
+	params.Execer = dty.DPosX
+
+	req := &dty.DposJailQuery{
+		Cycle: cycle,
+	}
+	if pubkeys != "" {
+		req.Pubkeys = append(req.Pubkeys, strings.Split(pubkeys, ";")...)
+	}
+
+	params.FuncName = dty.FuncNameQueryJailedCandidators
+	params.Payload = types.MustPBToJSON(req)
+	var res dty.CandidatorReply
+	ctx := jsonrpc.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &res)
+	ctx.Run()
+}
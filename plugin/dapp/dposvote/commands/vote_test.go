@@ -460,4 +460,13 @@ func testCmd(cmd *cobra.Command) {
 
 	rootCmd.SetArgs([]string{"dpos", "vrfVerify", "--pubkey", strPubkey, "--m", "input", "--hash", "3975b20c89894a3961dbab6cefb07ce7736761b4105931f268e47a99511eb635", "--proof", "6fe5e2d8a5de203da8f487459c5af24b1e56bf69848f4ca2f786eac5d4ad60bab35d83fc15b903b3007f570e8766942031ffed84d42e9bb3314d408fec557fd5043e72a99cf64ae29c89282367c473e0925e8bd841063d508264af5c6320faecb61692f8fbde47cd3b82d0e9804e30d89d13f2fafd1769fe32d9bb9750d943ddb4"})
 	rootCmd.Execute()
+
+	rootCmd.SetArgs([]string{"dpos", "unjail", "--pubkey", strPubkey})
+	rootCmd.Execute()
+
+	rootCmd.SetArgs([]string{"dpos", "jailQuery", "--cycle", "1000"})
+	rootCmd.Execute()
+
+	rootCmd.SetArgs([]string{"dpos", "jailQuery", "--pubkeys", strPubkey})
+	rootCmd.Execute()
 }
@@ -24,8 +24,13 @@ var (
 	blockNumToUpdateDelegate int64 = 20000
 	registTopNHeightLimit    int64 = 100
 	updateTopNHeightLimit    int64 = 200
+	missedSlotsThreshold     int64     //一个cycle内错过的出块数超过该值的受托节点将被禁止出块，为0时不启用
 )
 
+//jailDelayCycles CB交易要在下一个cycle才能上链，禁止出块和恢复出块都延后一个cycle生效，
+//保证各节点在洗牌时看到一致的状态
+const jailDelayCycles int64 = 2
+
 // CycleInfo indicates the start and stop of a cycle
 type CycleInfo struct {
 	cycle      int64
@@ -65,6 +70,7 @@ func Init(name string, cfg *types.Chain33Config, sub []byte) {
 	blockNumToUpdateDelegate = types.Conf(cfg, "config.consensus.sub.dpos").GInt("blockNumToUpdateDelegate")
 	registTopNHeightLimit = types.Conf(cfg, "config.consensus.sub.dpos").GInt("registTopNHeightLimit")
 	updateTopNHeightLimit = types.Conf(cfg, "config.consensus.sub.dpos").GInt("updateTopNHeightLimit")
+	missedSlotsThreshold = types.Conf(cfg, "config.consensus.sub.dpos").GInt("missedSlotsThreshold")
 	dposCycle = dposDelegateNum * dposBlockInterval * dposContinueBlockNum
 	dposPeriod = dposBlockInterval * dposContinueBlockNum
 	InitExecType()
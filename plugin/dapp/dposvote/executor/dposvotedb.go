@@ -43,6 +43,7 @@ type Action struct {
 	localDB      dbm.KVDB
 	index        int
 	mainHeight   int64
+	cfg          *types.Chain33Config
 }
 
 //NewAction 生成Action对象
@@ -61,6 +62,7 @@ func NewAction(dpos *DPos, tx *types.Transaction, index int) *Action {
 		localDB:      dpos.GetLocalDB(),
 		index:        index,
 		mainHeight:   dpos.GetMainHeight(),
+		cfg:          dpos.GetAPI().GetConfig(),
 	}
 }
 
@@ -81,6 +83,13 @@ func Key(id string) (key []byte) {
 	return key
 }
 
+//SlotReportsKey State数据库中存储一个cycle内受托节点错过出块报告的Key值
+func SlotReportsKey(cycle int64, pubkey string) (key []byte) {
+	key = append(key, []byte("mavl-"+dty.DPosX+"-"+"slots"+"-")...)
+	key = append(key, []byte(fmt.Sprintf("%018d-%s", cycle, pubkey))...)
+	return key
+}
+
 //TopNKey State数据库中存储记录的Key值格式转换
 func TopNKey(id string) (key []byte) {
 	key = append(key, []byte("mavl-"+dty.DPosX+"-"+"topn"+"-")...)
@@ -305,11 +314,85 @@ func queryCands(kvdb db.KVDB, req *dty.CandidatorQuery) (types.Message, error) {
 
 		candInfo := rows[0].Data.(*dty.CandidatorInfo)
 		cand := &dty.JSONCandidator{
-			Pubkey:  strings.ToUpper(hex.EncodeToString(candInfo.Pubkey)),
-			Address: candInfo.Address,
-			IP:      candInfo.IP,
-			Votes:   candInfo.Votes,
-			Status:  candInfo.Status,
+			Pubkey:      strings.ToUpper(hex.EncodeToString(candInfo.Pubkey)),
+			Address:     candInfo.Address,
+			IP:          candInfo.IP,
+			Votes:       candInfo.Votes,
+			Status:      candInfo.Status,
+			JailedCycle: candInfo.JailedCycle,
+			MissedSlots: candInfo.MissedSlots,
+		}
+		cands = append(cands, cand)
+	}
+	return &dty.CandidatorReply{Candidators: cands}, nil
+}
+
+//isJailedAtCycle 判断候选节点在某一个cycle是否被禁止出块，禁止出块和恢复出块都延后jailDelayCycles个cycle生效
+func isJailedAtCycle(candInfo *dty.CandidatorInfo, cycle int64) bool {
+	if candInfo.JailedCycle == 0 || cycle < candInfo.JailedCycle+jailDelayCycles {
+		return false
+	}
+
+	if candInfo.Status == dty.CandidatorStatusJailed {
+		return true
+	}
+
+	return candInfo.UnjailCycle > 0 && cycle < candInfo.UnjailCycle+jailDelayCycles
+}
+
+//queryJailedCands 查询被禁止出块的候选节点，cycle不为0时查询在该cycle不参与出块的节点，供共识模块洗牌时使用
+func queryJailedCands(kvdb db.KVDB, req *dty.DposJailQuery) (types.Message, error) {
+	var candInfos []*dty.CandidatorInfo
+	candTable := dty.NewDposCandidatorTable(kvdb)
+	query := candTable.GetQuery(kvdb)
+
+	if len(req.Pubkeys) > 0 {
+		for i := 0; i < len(req.Pubkeys); i++ {
+			bPubkey, err := hex.DecodeString(req.Pubkeys[i])
+			if err != nil {
+				return nil, types.ErrInvalidParam
+			}
+			rows, err := query.ListIndex("pubkey", bPubkey, nil, 1, 0)
+			if err != nil {
+				continue
+			}
+			candInfos = append(candInfos, rows[0].Data.(*dty.CandidatorInfo))
+		}
+	} else {
+		statuses := []int64{dty.CandidatorStatusJailed}
+		if req.Cycle != 0 {
+			//解禁后的节点在延迟的cycle内仍然不参与出块
+			statuses = append(statuses, dty.CandidatorStatusRegist, dty.CandidatorStatusVoted, dty.CandidatorStatusReRegist)
+		}
+		for _, status := range statuses {
+			rows, err := query.ListIndex("status", []byte(fmt.Sprintf("%2d", status)), nil, 0, 0)
+			if err != nil {
+				continue
+			}
+			for index := 0; index < len(rows); index++ {
+				candInfos = append(candInfos, rows[index].Data.(*dty.CandidatorInfo))
+			}
+		}
+	}
+
+	var cands []*dty.JSONCandidator
+	for _, candInfo := range candInfos {
+		if req.Cycle == 0 && candInfo.Status != dty.CandidatorStatusJailed {
+			continue
+		}
+
+		if req.Cycle != 0 && !isJailedAtCycle(candInfo, req.Cycle) {
+			continue
+		}
+
+		cand := &dty.JSONCandidator{
+			Pubkey:      strings.ToUpper(hex.EncodeToString(candInfo.Pubkey)),
+			Address:     candInfo.Address,
+			IP:          candInfo.IP,
+			Votes:       candInfo.Votes,
+			Status:      candInfo.Status,
+			JailedCycle: candInfo.JailedCycle,
+			MissedSlots: candInfo.MissedSlots,
 		}
 		cands = append(cands, cand)
 	}
@@ -472,6 +555,8 @@ func (action *Action) getReceiptLog(candInfo *dty.CandidatorInfo, statusChange b
 		log.Ty = dty.TyLogCandicatorCancelRegist
 	} else if candInfo.Status == dty.CandidatorStatusReRegist {
 		log.Ty = dty.TyLogCandicatorReRegist
+	} else if candInfo.Status == dty.CandidatorStatusJailed {
+		log.Ty = dty.TyLogCandicatorJailed
 	}
 
 	r.Index = action.getIndex()
@@ -574,6 +659,7 @@ func queryCBInfoByCycle(kvdb db.KVDB, req *dty.DposCBQuery) (types.Message, erro
 		StopHash:   hex.EncodeToString(cbInfo.StopHash),
 		Pubkey:     strings.ToUpper(hex.EncodeToString(cbInfo.Pubkey)),
 		Signature:  hex.EncodeToString(cbInfo.StopHash),
+		Slots:      cbInfo.Slots,
 	}
 	logger.Info("queryCBInfoByCycle ok", "cycle", req.Cycle, "info", info.String())
 
@@ -598,6 +684,7 @@ func queryCBInfoByHeight(kvdb db.KVDB, req *dty.DposCBQuery) (types.Message, err
 		StopHash:   hex.EncodeToString(cbInfo.StopHash),
 		Pubkey:     strings.ToUpper(hex.EncodeToString(cbInfo.Pubkey)),
 		Signature:  hex.EncodeToString(cbInfo.StopHash),
+		Slots:      cbInfo.Slots,
 	}
 	logger.Info("queryCBInfoByHeight ok", "height", req.StopHeight, "info", info.String())
 
@@ -629,6 +716,7 @@ func queryCBInfoByHash(kvdb db.KVDB, req *dty.DposCBQuery) (types.Message, error
 		StopHash:   hex.EncodeToString(cbInfo.StopHash),
 		Pubkey:     strings.ToUpper(hex.EncodeToString(cbInfo.Pubkey)),
 		Signature:  hex.EncodeToString(cbInfo.StopHash),
+		Slots:      cbInfo.Slots,
 	}
 	logger.Info("queryCBInfoByHash ok", "hash", req.StopHash, "info", info.String())
 
@@ -808,7 +896,7 @@ func (action *Action) CancelRegist(req *dty.DposCandidatorCancelRegist) (*types.
 	logger.Info("Cancel Regist", "addr", action.fromaddr, "execaddr", action.execaddr, "candicator",
 		candInfo.String())
 
-	if candInfo.Status == dty.CandidatorStatusVoted {
+	if candInfo.Status == dty.CandidatorStatusVoted || isJailedWithVotes(candInfo) {
 		for _, voter := range candInfo.Voters {
 			receipt, err := action.coinsAccount.ExecActive(voter.FromAddr, action.execaddr, voter.Votes)
 			if err != nil {
@@ -846,6 +934,11 @@ func (action *Action) CancelRegist(req *dty.DposCandidatorCancelRegist) (*types.
 	return &types.Receipt{Ty: types.ExecOk, KV: kv, Logs: logs}, nil
 }
 
+//isJailedWithVotes 判断候选节点是否在被禁止出块前已经有投票
+func isJailedWithVotes(candInfo *dty.CandidatorInfo) bool {
+	return candInfo.Status == dty.CandidatorStatusJailed && candInfo.PreStatus == dty.CandidatorStatusVoted
+}
+
 //Vote 为某一个候选节点投票
 func (action *Action) Vote(vote *dty.DposVote) (*types.Receipt, error) {
 	var logs []*types.ReceiptLog
@@ -871,6 +964,12 @@ func (action *Action) Vote(vote *dty.DposVote) (*types.Receipt, error) {
 		return nil, types.ErrInvalidParam
 	}
 
+	if candInfo.Status == dty.CandidatorStatusJailed {
+		logger.Error("Vote failed", "addr", action.fromaddr, "execaddr", action.execaddr, "candicator is jailed.",
+			candInfo.String())
+		return nil, dty.ErrCandidatorJailed
+	}
+
 	logger.Info("vote", "addr", action.fromaddr, "execaddr", action.execaddr, "candicator", candInfo.String())
 
 	statusChange := false
@@ -931,7 +1030,8 @@ func (action *Action) CancelVote(vote *dty.DposCancelVote) (*types.Receipt, erro
 		return nil, dty.ErrCandidatorNotExist
 	}
 
-	if candInfo.Status != dty.CandidatorStatusVoted {
+	//被禁止出块的节点仍然允许撤销投票
+	if candInfo.Status != dty.CandidatorStatusVoted && !isJailedWithVotes(candInfo) {
 		logger.Error("CancelVote failed", "addr", action.fromaddr, "execaddr", action.execaddr, "candicator is already canceled.",
 			candInfo.String())
 		return nil, types.ErrInvalidParam
@@ -1166,18 +1266,35 @@ func (action *Action) RecordCB(cbInfo *dty.DposCBInfo) (*types.Receipt, error) {
 
 	logger.Info("RecordCB", "addr", action.fromaddr, "execaddr", action.execaddr, "info", fmt.Sprintf("cycle:%d,stopHeight:%d,stopHash:%s,pubkey:%s", cbInfo.Cycle, cbInfo.StopHeight, cbInfo.StopHash, cbInfo.Pubkey))
 
+	//出块统计会导致受托节点被禁止出块，必须是合法受托节点签名的CB信息，分叉之前不处理出块统计
+	isJailFork := action.isJailFork()
+	if isJailFork && len(cbInfo.Slots) > 0 {
+		if err := cbInfo.Verify(); err != nil {
+			logger.Error("RecordCB failed for signature verify failed", "addr", action.fromaddr, "execaddr", action.execaddr, "err", err)
+			return nil, types.ErrInvalidParam
+		}
+
+		if !action.isTopNDelegate(pubkey) {
+			logger.Error("RecordCB failed for the signer is not legal topN", "addr", action.fromaddr, "execaddr", action.execaddr, "pubkey", cbInfo.Pubkey)
+			return nil, dty.ErrNotLegalTopN
+		}
+	}
+
 	cb := &dty.DposCycleBoundaryInfo{
 		Cycle:      cbInfo.Cycle,
 		StopHeight: cbInfo.StopHeight,
 		StopHash:   hash,
 		Pubkey:     pubkey,
 		Signature:  sig,
+		Slots:      cbInfo.Slots,
 	}
 
 	cbTable := dty.NewDposCBTable(action.localDB)
 	query := cbTable.GetQuery(action.localDB)
 	rows, err := query.ListIndex("cycle", []byte(fmt.Sprintf("%018d", cbInfo.Cycle)), nil, 1, 0)
-	if err == nil && rows[0] != nil {
+	cbExist := err == nil && rows[0] != nil
+	//分叉之后每个受托节点都可以提交本cycle的出块统计，CB信息只记录第一次
+	if cbExist && (!isJailFork || len(cbInfo.Slots) == 0) {
 		logger.Error("RecordCB failed", "addr", action.fromaddr, "execaddr", action.execaddr, "CB info is already recorded.", cbInfo.String())
 		return nil, dty.ErrCBRecordExist
 	}
@@ -1189,24 +1306,194 @@ func (action *Action) RecordCB(cbInfo *dty.DposCBInfo) (*types.Receipt, error) {
 		return nil, dty.ErrCycleNotAllowed
 	}
 
-	middleTime := cycleInfo.cycleStart + (cycleInfo.cycleStop-cycleInfo.cycleStart)/2
-	log := &types.ReceiptLog{}
-	r := &dty.ReceiptCB{}
-	r.Index = action.getIndex()
-	r.Pubkey = pubkey
-	r.Status = dty.CBStatusRecord
-	r.Cycle = cycleInfo.cycle
-	r.Height = action.mainHeight
-	r.Time = action.blocktime
-	r.CycleStart = cycleInfo.cycleStart
-	r.CycleStop = cycleInfo.cycleStop
-	r.CycleMiddle = middleTime
-	r.CbInfo = cb
+	if !cbExist {
+		middleTime := cycleInfo.cycleStart + (cycleInfo.cycleStop-cycleInfo.cycleStart)/2
+		log := &types.ReceiptLog{}
+		r := &dty.ReceiptCB{}
+		r.Index = action.getIndex()
+		r.Pubkey = pubkey
+		r.Status = dty.CBStatusRecord
+		r.Cycle = cycleInfo.cycle
+		r.Height = action.mainHeight
+		r.Time = action.blocktime
+		r.CycleStart = cycleInfo.cycleStart
+		r.CycleStop = cycleInfo.cycleStop
+		r.CycleMiddle = middleTime
+		r.CbInfo = cb
 
-	log.Ty = dty.TyLogCBInfoRecord
-	log.Log = types.Encode(r)
+		log.Ty = dty.TyLogCBInfoRecord
+		log.Log = types.Encode(r)
 
-	logs = append(logs, log)
+		logs = append(logs, log)
+	}
+
+	if !isJailFork {
+		return &types.Receipt{Ty: types.ExecOk, KV: kv, Logs: logs}, nil
+	}
+
+	for _, slot := range cbInfo.Slots {
+		if missedSlotsThreshold <= 0 || slot.Missed <= missedSlotsThreshold {
+			continue
+		}
+
+		receiptLog, kvs := action.reportSlot(slot, cbInfo.Cycle, cbInfo.Pubkey)
+		kv = append(kv, kvs...)
+		if receiptLog != nil {
+			logs = append(logs, receiptLog)
+		}
+	}
+
+	return &types.Receipt{Ty: types.ExecOk, KV: kv, Logs: logs}, nil
+}
+
+func (action *Action) isJailFork() bool {
+	return action.cfg.IsDappFork(action.height, dty.DPosX, dty.ForkDposJail)
+}
+
+//reportSlot 记录受托节点错过出块过多的报告，单个节点上报的统计不可信，
+//同一个cycle内超过2/3的受托节点报告后才禁止其出块
+func (action *Action) reportSlot(slot *dty.DposSlotStat, cycle int64, reporter string) (*types.ReceiptLog, []*types.KeyValue) {
+	pubkey := strings.ToUpper(slot.Pubkey)
+	reporter = strings.ToUpper(reporter)
+	key := SlotReportsKey(cycle, pubkey)
+	reports := &dty.DposSlotReports{Cycle: cycle, Pubkey: pubkey}
+	if data, err := action.db.Get(key); err == nil {
+		if err := types.Decode(data, reports); err != nil {
+			logger.Error("reportSlot decode failed", "pubkey", pubkey, "cycle", cycle, "err", err)
+			return nil, nil
+		}
+	}
+
+	for _, r := range reports.Reporters {
+		if r == reporter {
+			return nil, nil
+		}
+	}
+	reports.Reporters = append(reports.Reporters, reporter)
+	value := types.Encode(reports)
+	if err := action.db.Set(key, value); err != nil {
+		logger.Error("reportSlot set failed", "pubkey", pubkey, "cycle", cycle, "err", err)
+	}
+	kvs := []*types.KeyValue{{Key: key, Value: value}}
+
+	quorum := dposDelegateNum*2/3 + 1
+	logger.Info("report slot", "pubkey", pubkey, "cycle", cycle, "reporter", reporter, "missed", slot.Missed, "reports", len(reports.Reporters), "quorum", quorum)
+	if int64(len(reports.Reporters)) != quorum {
+		return nil, kvs
+	}
+
+	receiptLog, jailKvs := action.jail(slot, cycle)
+	return receiptLog, append(kvs, jailKvs...)
+}
+
+//isTopNDelegate 判断公钥是否属于最近一次达成一致的TopN受托节点，如果从没有注册过TopN，认为是创世阶段的可信环境
+func (action *Action) isTopNDelegate(pubkey []byte) bool {
+	version, _ := calcTopNVersion(action.mainHeight)
+	for ; version >= 0; version-- {
+		topN, err := action.readTopNCandicators(version)
+		if err != nil || topN.Status != dty.TopNCandidatorsVoteMajorOK {
+			continue
+		}
+
+		for i := 0; i < len(topN.FinalCands); i++ {
+			if bytes.Equal(pubkey, topN.FinalCands[i].Pubkey) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+//jail 禁止一个cycle内错过出块过多的受托节点出块，直到节点自己恢复出块资格
+func (action *Action) jail(slot *dty.DposSlotStat, cycle int64) (*types.ReceiptLog, []*types.KeyValue) {
+	bPubkey, err := hex.DecodeString(slot.Pubkey)
+	if err != nil {
+		logger.Error("jail failed for pubkey is not correct", "pubkey", slot.Pubkey)
+		return nil, nil
+	}
+
+	candInfo, err := action.readCandicatorInfo(bPubkey)
+	if err != nil || candInfo == nil {
+		logger.Error("jail failed for candicator is not exist", "pubkey", slot.Pubkey)
+		return nil, nil
+	}
+
+	if candInfo.Status != dty.CandidatorStatusRegist && candInfo.Status != dty.CandidatorStatusVoted && candInfo.Status != dty.CandidatorStatusReRegist {
+		logger.Info("jail ignored for candicator status", "pubkey", slot.Pubkey, "status", candInfo.Status)
+		return nil, nil
+	}
+
+	logger.Info("jail candicator", "pubkey", slot.Pubkey, "cycle", cycle, "produced", slot.Produced, "missed", slot.Missed, "threshold", missedSlotsThreshold)
+
+	candInfo.PreStatus = candInfo.Status
+	candInfo.Status = dty.CandidatorStatusJailed
+	candInfo.JailedCycle = cycle
+	candInfo.MissedSlots = slot.Missed
+	candInfo.UnjailCycle = 0
+	candInfo.PreIndex = candInfo.Index
+	candInfo.Index = action.getIndex()
+
+	receiptLog := action.getReceiptLog(candInfo, true, dty.VoteTypeNone, nil)
+	return receiptLog, action.saveCandicator(candInfo)
+}
+
+//Unjail 被禁止出块的受托节点恢复出块资格
+func (action *Action) Unjail(req *dty.DposCandidatorUnjail) (*types.Receipt, error) {
+	var logs []*types.ReceiptLog
+	var kv []*types.KeyValue
+
+	if !action.isJailFork() {
+		return nil, types.ErrActionNotSupport
+	}
+
+	bPubkey, err := hex.DecodeString(req.Pubkey)
+	if err != nil {
+		logger.Info("Unjail", "addr", action.fromaddr, "execaddr", action.execaddr, "pubkey is not correct",
+			req.Pubkey)
+		return nil, types.ErrInvalidParam
+	}
+
+	candInfo, err := action.readCandicatorInfo(bPubkey)
+	if err != nil || candInfo == nil {
+		logger.Error("Unjail failed", "addr", action.fromaddr, "execaddr", action.execaddr, "candicator is not exist",
+			req.Pubkey)
+		return nil, dty.ErrCandidatorNotExist
+	}
+
+	if action.fromaddr != candInfo.GetAddress() {
+		logger.Error("Unjail failed", "addr", action.fromaddr, "execaddr", action.execaddr, "from addr is not candicator address.",
+			candInfo.String())
+		return nil, dty.ErrNoPrivilege
+	}
+
+	if candInfo.Status != dty.CandidatorStatusJailed {
+		logger.Error("Unjail failed", "addr", action.fromaddr, "execaddr", action.execaddr, "candicator is not jailed.",
+			candInfo.String())
+		return nil, dty.ErrCandidatorNotJailed
+	}
+
+	//至少要在一个cycle内不参与出块，才能恢复出块资格
+	cycleInfo := calcCycleByTime(action.blocktime)
+	if cycleInfo.cycle < candInfo.JailedCycle+jailDelayCycles {
+		logger.Error("Unjail failed", "addr", action.fromaddr, "execaddr", action.execaddr, "jailed cycle", candInfo.JailedCycle, "current cycle", cycleInfo.cycle)
+		return nil, dty.ErrUnjailTooEarly
+	}
+
+	logger.Info("Unjail", "addr", action.fromaddr, "execaddr", action.execaddr, "candicator", candInfo.String())
+
+	candInfo.Status = candInfo.PreStatus
+	candInfo.PreStatus = dty.CandidatorStatusJailed
+	candInfo.UnjailCycle = cycleInfo.cycle
+	candInfo.PreIndex = candInfo.Index
+	candInfo.Index = action.getIndex()
+
+	receiptLog := action.getReceiptLog(candInfo, true, dty.VoteTypeNone, nil)
+	receiptLog.Ty = dty.TyLogCandicatorUnjailed
+
+	logs = append(logs, receiptLog)
+	kv = append(kv, action.saveCandicator(candInfo)...)
 
 	return &types.Receipt{Ty: types.ExecOk, KV: kv, Logs: logs}, nil
 }
@@ -62,3 +62,9 @@ func (d *DPos) Exec_RegistTopN(payload *dty.TopNCandidatorRegist, tx *types.Tran
 	action := NewAction(d, tx, index)
 	return action.RegistTopN(payload)
 }
+
+//Exec_Unjail DPos执行器恢复被禁止出块的受托节点
+func (d *DPos) Exec_Unjail(payload *dty.DposCandidatorUnjail, tx *types.Transaction, index int) (*types.Receipt, error) {
+	action := NewAction(d, tx, index)
+	return action.Unjail(payload)
+}
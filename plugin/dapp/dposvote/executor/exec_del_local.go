@@ -131,6 +131,27 @@ func (d *DPos) rollbackCandVote(log *dty.ReceiptCandicator) (kvs []*types.KeyVal
 	return kvs, nil
 }
 
+func (d *DPos) rollbackCandJail(log *dty.ReceiptCandicator) (kvs []*types.KeyValue, err error) {
+	candTable := dty.NewDposCandidatorTable(d.GetLocalDB())
+
+	//禁止出块和恢复出块都只改变了状态，恢复到前一状态即可
+	candInfo := log.CandInfo
+	log.CandInfo = nil
+	d.rollbackCand(candInfo, log)
+	if log.Status == dty.CandidatorStatusJailed {
+		candInfo.JailedCycle = 0
+		candInfo.MissedSlots = 0
+	} else {
+		candInfo.UnjailCycle = 0
+	}
+
+	err = candTable.Replace(candInfo)
+	if err != nil {
+		return nil, err
+	}
+	return candTable.Save()
+}
+
 func (d *DPos) rollbackVrf(log *dty.ReceiptVrf) (kvs []*types.KeyValue, err error) {
 	if log.Status == dty.VrfStatusMRegist {
 		vrfMTable := dty.NewDposVrfMTable(d.GetLocalDB())
@@ -191,6 +212,17 @@ func (d *DPos) execDelLocal(receipt *types.ReceiptData) (*types.LocalDBSet, erro
 			}
 			dbSet.KV = append(dbSet.KV, kv...)
 
+		case dty.TyLogCandicatorJailed, dty.TyLogCandicatorUnjailed:
+			receiptLog := &dty.ReceiptCandicator{}
+			if err := types.Decode(log.Log, receiptLog); err != nil {
+				return nil, err
+			}
+			kv, err := d.rollbackCandJail(receiptLog)
+			if err != nil {
+				return nil, err
+			}
+			dbSet.KV = append(dbSet.KV, kv...)
+
 		case dty.TyLogVrfMRegist, dty.TyLogVrfRPRegist:
 			receiptLog := &dty.ReceiptVrf{}
 			if err := types.Decode(log.Log, receiptLog); err != nil {
@@ -265,3 +297,8 @@ func (d *DPos) ExecDelLocal_RecordCB(payload *dty.DposCBInfo, tx *types.Transact
 func (d *DPos) ExecDelLocal_RegistTopN(payload *dty.TopNCandidatorRegist, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
 	return d.execDelLocal(receiptData)
 }
+
+//ExecDelLocal_Unjail method
+func (d *DPos) ExecDelLocal_Unjail(payload *dty.DposCandidatorUnjail, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return d.execDelLocal(receiptData)
+}
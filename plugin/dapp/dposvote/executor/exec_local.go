@@ -98,6 +98,17 @@ func (d *DPos) updateCandVote(log *dty.ReceiptCandicator) (kvs []*types.KeyValue
 	return kvs, nil
 }
 
+func (d *DPos) updateCandJail(log *dty.ReceiptCandicator) (kvs []*types.KeyValue, err error) {
+	canTable := dty.NewDposCandidatorTable(d.GetLocalDB())
+
+	err = canTable.Replace(log.CandInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return canTable.Save()
+}
+
 func (d *DPos) updateVrf(log *dty.ReceiptVrf) (kvs []*types.KeyValue, err error) {
 	if log.Status == dty.VrfStatusMRegist {
 		vrfMTable := dty.NewDposVrfMTable(d.GetLocalDB())
@@ -188,6 +199,17 @@ func (d *DPos) execLocal(receipt *types.ReceiptData) (*types.LocalDBSet, error)
 				return nil, err
 			}
 			dbSet.KV = append(dbSet.KV, kvs...)
+		} else if item.Ty == dty.TyLogCandicatorJailed || item.Ty == dty.TyLogCandicatorUnjailed {
+			var candLog dty.ReceiptCandicator
+			err := types.Decode(item.Log, &candLog)
+			if err != nil {
+				return nil, err
+			}
+			kvs, err := d.updateCandJail(&candLog)
+			if err != nil {
+				return nil, err
+			}
+			dbSet.KV = append(dbSet.KV, kvs...)
 		} else if item.Ty >= dty.TyLogVrfMRegist && item.Ty <= dty.TyLogVrfRPRegist {
 			var vrfLog dty.ReceiptVrf
 			err := types.Decode(item.Log, &vrfLog)
@@ -262,3 +284,8 @@ func (d *DPos) ExecLocal_RecordCB(payload *dty.DposCBInfo, tx *types.Transaction
 func (d *DPos) ExecLocal_RegistTopN(payload *dty.TopNCandidatorRegist, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
 	return d.execLocal(receiptData)
 }
+
+//ExecLocal_Unjail method
+func (d *DPos) ExecLocal_Unjail(payload *dty.DposCandidatorUnjail, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return d.execLocal(receiptData)
+}
@@ -63,3 +63,8 @@ func (d *DPos) Query_QueryCBInfoByHash(in *dty.DposCBQuery) (types.Message, erro
 func (d *DPos) Query_QueryTopNByVersion(in *dty.TopNCandidatorsQuery) (types.Message, error) {
 	return queryTopNByVersion(d.GetStateDB(), in)
 }
+
+//Query_QueryJailedCandidators method
+func (d *DPos) Query_QueryJailedCandidators(in *dty.DposJailQuery) (types.Message, error) {
+	return queryJailedCands(d.GetLocalDB(), in)
+}
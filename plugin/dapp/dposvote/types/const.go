@@ -30,6 +30,18 @@ const (
 	TopNCandidatorStatusRegist = iota + 1
 )
 
+//上面的常量共用一个iota，新增的常量单独定义，避免改变已经上链的取值
+const (
+	//DposVoteActionUnjail 被禁止出块的受托节点恢复出块资格
+	DposVoteActionUnjail = 10
+
+	//CandidatorStatusJailed 候选节点因错过出块过多被禁止出块
+	CandidatorStatusJailed = 20
+)
+
+//ForkDposJail 分叉之后受托节点的错过出块报告达到法定数目才禁止其出块，并支持恢复出块资格
+const ForkDposJail = "ForkDposJail"
+
 //log ty
 const (
 	TyLogCandicatorRegist       = 1001
@@ -41,6 +53,8 @@ const (
 	TyLogVrfRPRegist            = 1007
 	TyLogCBInfoRecord           = 1008
 	TyLogTopNCandidatorRegist   = 1009
+	TyLogCandicatorJailed       = 1010
+	TyLogCandicatorUnjailed     = 1011
 )
 
 const (
@@ -125,6 +139,9 @@ const (
 	//CreateRecordCBTx 创建记录CB信息的交易
 	CreateRecordCBTx = "RecordCB"
 
+	//CreateUnjailTx 创建受托节点恢复出块资格的交易
+	CreateUnjailTx = "Unjail"
+
 	//QueryVrfByTime 根据time查询Vrf信息
 	QueryVrfByTime = 1
 
@@ -163,4 +180,7 @@ const (
 
 	//FuncNameQueryTopNByVersion func name
 	FuncNameQueryTopNByVersion = "QueryTopNByVersion"
+
+	//FuncNameQueryJailedCandidators func name
+	FuncNameQueryJailedCandidators = "QueryJailedCandidators"
 )
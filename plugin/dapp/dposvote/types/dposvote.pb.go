@@ -37,6 +37,9 @@ type CandidatorInfo struct {
 	Index                int64        `protobuf:"varint,11,opt,name=index,proto3" json:"index,omitempty"`
 	PreIndex             int64        `protobuf:"varint,12,opt,name=preIndex,proto3" json:"preIndex,omitempty"`
 	Voters               []*DposVoter `protobuf:"bytes,13,rep,name=voters,proto3" json:"voters,omitempty"`
+	JailedCycle          int64        `protobuf:"varint,14,opt,name=jailedCycle,proto3" json:"jailedCycle,omitempty"`
+	MissedSlots          int64        `protobuf:"varint,15,opt,name=missedSlots,proto3" json:"missedSlots,omitempty"`
+	UnjailCycle          int64        `protobuf:"varint,16,opt,name=unjailCycle,proto3" json:"unjailCycle,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -46,7 +49,7 @@ func (m *CandidatorInfo) Reset()         { *m = CandidatorInfo{} }
 func (m *CandidatorInfo) String() string { return proto.CompactTextString(m) }
 func (*CandidatorInfo) ProtoMessage()    {}
 func (*CandidatorInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{0}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{0}
 }
 func (m *CandidatorInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CandidatorInfo.Unmarshal(m, b)
@@ -157,6 +160,27 @@ func (m *CandidatorInfo) GetVoters() []*DposVoter {
 	return nil
 }
 
+func (m *CandidatorInfo) GetJailedCycle() int64 {
+	if m != nil {
+		return m.JailedCycle
+	}
+	return 0
+}
+
+func (m *CandidatorInfo) GetMissedSlots() int64 {
+	if m != nil {
+		return m.MissedSlots
+	}
+	return 0
+}
+
+func (m *CandidatorInfo) GetUnjailCycle() int64 {
+	if m != nil {
+		return m.UnjailCycle
+	}
+	return 0
+}
+
 // DposVoter 投票者信息
 type DposVoter struct {
 	FromAddr             string   `protobuf:"bytes,1,opt,name=fromAddr,proto3" json:"fromAddr,omitempty"`
@@ -173,7 +197,7 @@ func (m *DposVoter) Reset()         { *m = DposVoter{} }
 func (m *DposVoter) String() string { return proto.CompactTextString(m) }
 func (*DposVoter) ProtoMessage()    {}
 func (*DposVoter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{1}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{1}
 }
 func (m *DposVoter) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposVoter.Unmarshal(m, b)
@@ -244,7 +268,7 @@ func (m *Candidator) Reset()         { *m = Candidator{} }
 func (m *Candidator) String() string { return proto.CompactTextString(m) }
 func (*Candidator) ProtoMessage()    {}
 func (*Candidator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{2}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{2}
 }
 func (m *Candidator) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Candidator.Unmarshal(m, b)
@@ -313,7 +337,7 @@ func (m *DposCandidatorRegist) Reset()         { *m = DposCandidatorRegist{} }
 func (m *DposCandidatorRegist) String() string { return proto.CompactTextString(m) }
 func (*DposCandidatorRegist) ProtoMessage()    {}
 func (*DposCandidatorRegist) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{3}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{3}
 }
 func (m *DposCandidatorRegist) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposCandidatorRegist.Unmarshal(m, b)
@@ -367,7 +391,7 @@ func (m *DposCandidatorCancelRegist) Reset()         { *m = DposCandidatorCancel
 func (m *DposCandidatorCancelRegist) String() string { return proto.CompactTextString(m) }
 func (*DposCandidatorCancelRegist) ProtoMessage()    {}
 func (*DposCandidatorCancelRegist) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{4}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{4}
 }
 func (m *DposCandidatorCancelRegist) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposCandidatorCancelRegist.Unmarshal(m, b)
@@ -415,7 +439,7 @@ func (m *DposVote) Reset()         { *m = DposVote{} }
 func (m *DposVote) String() string { return proto.CompactTextString(m) }
 func (*DposVote) ProtoMessage()    {}
 func (*DposVote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{5}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{5}
 }
 func (m *DposVote) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposVote.Unmarshal(m, b)
@@ -469,7 +493,7 @@ func (m *DposCancelVote) Reset()         { *m = DposCancelVote{} }
 func (m *DposCancelVote) String() string { return proto.CompactTextString(m) }
 func (*DposCancelVote) ProtoMessage()    {}
 func (*DposCancelVote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{6}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{6}
 }
 func (m *DposCancelVote) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposCancelVote.Unmarshal(m, b)
@@ -520,6 +544,7 @@ type DposVoteAction struct {
 	//	*DposVoteAction_CbQuery
 	//	*DposVoteAction_RegistTopN
 	//	*DposVoteAction_TopNQuery
+	//	*DposVoteAction_Unjail
 	Value                isDposVoteAction_Value `protobuf_oneof:"value"`
 	Ty                   int32                  `protobuf:"varint,15,opt,name=ty,proto3" json:"ty,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
@@ -531,7 +556,7 @@ func (m *DposVoteAction) Reset()         { *m = DposVoteAction{} }
 func (m *DposVoteAction) String() string { return proto.CompactTextString(m) }
 func (*DposVoteAction) ProtoMessage()    {}
 func (*DposVoteAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{7}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{7}
 }
 func (m *DposVoteAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposVoteAction.Unmarshal(m, b)
@@ -611,6 +636,10 @@ type DposVoteAction_TopNQuery struct {
 	TopNQuery *TopNCandidatorsQuery `protobuf:"bytes,14,opt,name=topNQuery,proto3,oneof"`
 }
 
+type DposVoteAction_Unjail struct {
+	Unjail *DposCandidatorUnjail `protobuf:"bytes,16,opt,name=unjail,proto3,oneof"`
+}
+
 func (*DposVoteAction_Regist) isDposVoteAction_Value() {}
 
 func (*DposVoteAction_CancelRegist) isDposVoteAction_Value() {}
@@ -639,6 +668,8 @@ func (*DposVoteAction_RegistTopN) isDposVoteAction_Value() {}
 
 func (*DposVoteAction_TopNQuery) isDposVoteAction_Value() {}
 
+func (*DposVoteAction_Unjail) isDposVoteAction_Value() {}
+
 func (m *DposVoteAction) GetValue() isDposVoteAction_Value {
 	if m != nil {
 		return m.Value
@@ -744,6 +775,13 @@ func (m *DposVoteAction) GetTopNQuery() *TopNCandidatorsQuery {
 	return nil
 }
 
+func (m *DposVoteAction) GetUnjail() *DposCandidatorUnjail {
+	if x, ok := m.GetValue().(*DposVoteAction_Unjail); ok {
+		return x.Unjail
+	}
+	return nil
+}
+
 func (m *DposVoteAction) GetTy() int32 {
 	if m != nil {
 		return m.Ty
@@ -768,6 +806,7 @@ func (*DposVoteAction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer
 		(*DposVoteAction_CbQuery)(nil),
 		(*DposVoteAction_RegistTopN)(nil),
 		(*DposVoteAction_TopNQuery)(nil),
+		(*DposVoteAction_Unjail)(nil),
 	}
 }
 
@@ -845,6 +884,11 @@ func _DposVoteAction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.TopNQuery); err != nil {
 			return err
 		}
+	case *DposVoteAction_Unjail:
+		b.EncodeVarint(16<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Unjail); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("DposVoteAction.Value has unexpected type %T", x)
@@ -967,6 +1011,14 @@ func _DposVoteAction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto
 		err := b.DecodeMessage(msg)
 		m.Value = &DposVoteAction_TopNQuery{msg}
 		return true, err
+	case 16: // value.unjail
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(DposCandidatorUnjail)
+		err := b.DecodeMessage(msg)
+		m.Value = &DposVoteAction_Unjail{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1046,6 +1098,11 @@ func _DposVoteAction_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *DposVoteAction_Unjail:
+		s := proto.Size(x.Unjail)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1053,6 +1110,45 @@ func _DposVoteAction_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// DposCandidatorUnjail 被禁止出块的受托节点恢复出块资格
+type DposCandidatorUnjail struct {
+	Pubkey               string   `protobuf:"bytes,1,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DposCandidatorUnjail) Reset()         { *m = DposCandidatorUnjail{} }
+func (m *DposCandidatorUnjail) String() string { return proto.CompactTextString(m) }
+func (*DposCandidatorUnjail) ProtoMessage()    {}
+func (*DposCandidatorUnjail) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{8}
+}
+func (m *DposCandidatorUnjail) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DposCandidatorUnjail.Unmarshal(m, b)
+}
+func (m *DposCandidatorUnjail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DposCandidatorUnjail.Marshal(b, m, deterministic)
+}
+func (dst *DposCandidatorUnjail) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DposCandidatorUnjail.Merge(dst, src)
+}
+func (m *DposCandidatorUnjail) XXX_Size() int {
+	return xxx_messageInfo_DposCandidatorUnjail.Size(m)
+}
+func (m *DposCandidatorUnjail) XXX_DiscardUnknown() {
+	xxx_messageInfo_DposCandidatorUnjail.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DposCandidatorUnjail proto.InternalMessageInfo
+
+func (m *DposCandidatorUnjail) GetPubkey() string {
+	if m != nil {
+		return m.Pubkey
+	}
+	return ""
+}
+
 // CandidatorQuery 候选节点查询
 type CandidatorQuery struct {
 	Pubkeys              []string `protobuf:"bytes,1,rep,name=pubkeys,proto3" json:"pubkeys,omitempty"`
@@ -1067,7 +1163,7 @@ func (m *CandidatorQuery) Reset()         { *m = CandidatorQuery{} }
 func (m *CandidatorQuery) String() string { return proto.CompactTextString(m) }
 func (*CandidatorQuery) ProtoMessage()    {}
 func (*CandidatorQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{8}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{9}
 }
 func (m *CandidatorQuery) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CandidatorQuery.Unmarshal(m, b)
@@ -1115,6 +1211,8 @@ type JSONCandidator struct {
 	IP                   string   `protobuf:"bytes,3,opt,name=IP,proto3" json:"IP,omitempty"`
 	Votes                int64    `protobuf:"varint,4,opt,name=votes,proto3" json:"votes,omitempty"`
 	Status               int64    `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`
+	JailedCycle          int64    `protobuf:"varint,6,opt,name=jailedCycle,proto3" json:"jailedCycle,omitempty"`
+	MissedSlots          int64    `protobuf:"varint,7,opt,name=missedSlots,proto3" json:"missedSlots,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1124,7 +1222,7 @@ func (m *JSONCandidator) Reset()         { *m = JSONCandidator{} }
 func (m *JSONCandidator) String() string { return proto.CompactTextString(m) }
 func (*JSONCandidator) ProtoMessage()    {}
 func (*JSONCandidator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{9}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{10}
 }
 func (m *JSONCandidator) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JSONCandidator.Unmarshal(m, b)
@@ -1179,6 +1277,20 @@ func (m *JSONCandidator) GetStatus() int64 {
 	return 0
 }
 
+func (m *JSONCandidator) GetJailedCycle() int64 {
+	if m != nil {
+		return m.JailedCycle
+	}
+	return 0
+}
+
+func (m *JSONCandidator) GetMissedSlots() int64 {
+	if m != nil {
+		return m.MissedSlots
+	}
+	return 0
+}
+
 // CandidatorReply 候选节点查询响应
 type CandidatorReply struct {
 	Candidators          []*JSONCandidator `protobuf:"bytes,1,rep,name=candidators,proto3" json:"candidators,omitempty"`
@@ -1191,7 +1303,7 @@ func (m *CandidatorReply) Reset()         { *m = CandidatorReply{} }
 func (m *CandidatorReply) String() string { return proto.CompactTextString(m) }
 func (*CandidatorReply) ProtoMessage()    {}
 func (*CandidatorReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{10}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{11}
 }
 func (m *CandidatorReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CandidatorReply.Unmarshal(m, b)
@@ -1231,7 +1343,7 @@ func (m *DposVoteQuery) Reset()         { *m = DposVoteQuery{} }
 func (m *DposVoteQuery) String() string { return proto.CompactTextString(m) }
 func (*DposVoteQuery) ProtoMessage()    {}
 func (*DposVoteQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{11}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{12}
 }
 func (m *DposVoteQuery) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposVoteQuery.Unmarshal(m, b)
@@ -1281,7 +1393,7 @@ func (m *JSONDposVoter) Reset()         { *m = JSONDposVoter{} }
 func (m *JSONDposVoter) String() string { return proto.CompactTextString(m) }
 func (*JSONDposVoter) ProtoMessage()    {}
 func (*JSONDposVoter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{12}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{13}
 }
 func (m *JSONDposVoter) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JSONDposVoter.Unmarshal(m, b)
@@ -1348,7 +1460,7 @@ func (m *DposVoteReply) Reset()         { *m = DposVoteReply{} }
 func (m *DposVoteReply) String() string { return proto.CompactTextString(m) }
 func (*DposVoteReply) ProtoMessage()    {}
 func (*DposVoteReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{13}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{14}
 }
 func (m *DposVoteReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposVoteReply.Unmarshal(m, b)
@@ -1397,7 +1509,7 @@ func (m *ReceiptCandicator) Reset()         { *m = ReceiptCandicator{} }
 func (m *ReceiptCandicator) String() string { return proto.CompactTextString(m) }
 func (*ReceiptCandicator) ProtoMessage()    {}
 func (*ReceiptCandicator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{14}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{15}
 }
 func (m *ReceiptCandicator) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiptCandicator.Unmarshal(m, b)
@@ -1514,7 +1626,7 @@ func (m *DposVrfM) Reset()         { *m = DposVrfM{} }
 func (m *DposVrfM) String() string { return proto.CompactTextString(m) }
 func (*DposVrfM) ProtoMessage()    {}
 func (*DposVrfM) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{15}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{16}
 }
 func (m *DposVrfM) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposVrfM.Unmarshal(m, b)
@@ -1619,7 +1731,7 @@ func (m *DposVrfRP) Reset()         { *m = DposVrfRP{} }
 func (m *DposVrfRP) String() string { return proto.CompactTextString(m) }
 func (*DposVrfRP) ProtoMessage()    {}
 func (*DposVrfRP) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{16}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{17}
 }
 func (m *DposVrfRP) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposVrfRP.Unmarshal(m, b)
@@ -1730,7 +1842,7 @@ func (m *DposVrfMRegist) Reset()         { *m = DposVrfMRegist{} }
 func (m *DposVrfMRegist) String() string { return proto.CompactTextString(m) }
 func (*DposVrfMRegist) ProtoMessage()    {}
 func (*DposVrfMRegist) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{17}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{18}
 }
 func (m *DposVrfMRegist) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposVrfMRegist.Unmarshal(m, b)
@@ -1786,7 +1898,7 @@ func (m *DposVrfRPRegist) Reset()         { *m = DposVrfRPRegist{} }
 func (m *DposVrfRPRegist) String() string { return proto.CompactTextString(m) }
 func (*DposVrfRPRegist) ProtoMessage()    {}
 func (*DposVrfRPRegist) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{18}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{19}
 }
 func (m *DposVrfRPRegist) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposVrfRPRegist.Unmarshal(m, b)
@@ -1857,7 +1969,7 @@ func (m *ReceiptVrf) Reset()         { *m = ReceiptVrf{} }
 func (m *ReceiptVrf) String() string { return proto.CompactTextString(m) }
 func (*ReceiptVrf) ProtoMessage()    {}
 func (*ReceiptVrf) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{19}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{20}
 }
 func (m *ReceiptVrf) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiptVrf.Unmarshal(m, b)
@@ -1980,7 +2092,7 @@ func (m *VrfInfo) Reset()         { *m = VrfInfo{} }
 func (m *VrfInfo) String() string { return proto.CompactTextString(m) }
 func (*VrfInfo) ProtoMessage()    {}
 func (*VrfInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{20}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{21}
 }
 func (m *VrfInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VrfInfo.Unmarshal(m, b)
@@ -2071,7 +2183,7 @@ func (m *DposVrfQuery) Reset()         { *m = DposVrfQuery{} }
 func (m *DposVrfQuery) String() string { return proto.CompactTextString(m) }
 func (*DposVrfQuery) ProtoMessage()    {}
 func (*DposVrfQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{21}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{22}
 }
 func (m *DposVrfQuery) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposVrfQuery.Unmarshal(m, b)
@@ -2138,7 +2250,7 @@ func (m *JSONVrfInfo) Reset()         { *m = JSONVrfInfo{} }
 func (m *JSONVrfInfo) String() string { return proto.CompactTextString(m) }
 func (*JSONVrfInfo) ProtoMessage()    {}
 func (*JSONVrfInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{22}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{23}
 }
 func (m *JSONVrfInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JSONVrfInfo.Unmarshal(m, b)
@@ -2226,7 +2338,7 @@ func (m *DposVrfReply) Reset()         { *m = DposVrfReply{} }
 func (m *DposVrfReply) String() string { return proto.CompactTextString(m) }
 func (*DposVrfReply) ProtoMessage()    {}
 func (*DposVrfReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{23}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{24}
 }
 func (m *DposVrfReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposVrfReply.Unmarshal(m, b)
@@ -2253,23 +2365,79 @@ func (m *DposVrfReply) GetVrf() []*JSONVrfInfo {
 	return nil
 }
 
-// DposCycleBoundaryInfo cycle边界信息
-type DposCycleBoundaryInfo struct {
-	Cycle                int64    `protobuf:"varint,1,opt,name=cycle,proto3" json:"cycle,omitempty"`
-	StopHeight           int64    `protobuf:"varint,2,opt,name=stopHeight,proto3" json:"stopHeight,omitempty"`
-	StopHash             []byte   `protobuf:"bytes,3,opt,name=stopHash,proto3" json:"stopHash,omitempty"`
-	Pubkey               []byte   `protobuf:"bytes,4,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
-	Signature            []byte   `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+// DposSlotStat 受托节点在一个cycle内的出块统计
+type DposSlotStat struct {
+	Pubkey               string   `protobuf:"bytes,1,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	Produced             int64    `protobuf:"varint,2,opt,name=produced,proto3" json:"produced,omitempty"`
+	Missed               int64    `protobuf:"varint,3,opt,name=missed,proto3" json:"missed,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *DposSlotStat) Reset()         { *m = DposSlotStat{} }
+func (m *DposSlotStat) String() string { return proto.CompactTextString(m) }
+func (*DposSlotStat) ProtoMessage()    {}
+func (*DposSlotStat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{25}
+}
+func (m *DposSlotStat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DposSlotStat.Unmarshal(m, b)
+}
+func (m *DposSlotStat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DposSlotStat.Marshal(b, m, deterministic)
+}
+func (dst *DposSlotStat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DposSlotStat.Merge(dst, src)
+}
+func (m *DposSlotStat) XXX_Size() int {
+	return xxx_messageInfo_DposSlotStat.Size(m)
+}
+func (m *DposSlotStat) XXX_DiscardUnknown() {
+	xxx_messageInfo_DposSlotStat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DposSlotStat proto.InternalMessageInfo
+
+func (m *DposSlotStat) GetPubkey() string {
+	if m != nil {
+		return m.Pubkey
+	}
+	return ""
+}
+
+func (m *DposSlotStat) GetProduced() int64 {
+	if m != nil {
+		return m.Produced
+	}
+	return 0
+}
+
+func (m *DposSlotStat) GetMissed() int64 {
+	if m != nil {
+		return m.Missed
+	}
+	return 0
+}
+
+// DposCycleBoundaryInfo cycle边界信息
+type DposCycleBoundaryInfo struct {
+	Cycle                int64           `protobuf:"varint,1,opt,name=cycle,proto3" json:"cycle,omitempty"`
+	StopHeight           int64           `protobuf:"varint,2,opt,name=stopHeight,proto3" json:"stopHeight,omitempty"`
+	StopHash             []byte          `protobuf:"bytes,3,opt,name=stopHash,proto3" json:"stopHash,omitempty"`
+	Pubkey               []byte          `protobuf:"bytes,4,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	Signature            []byte          `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Slots                []*DposSlotStat `protobuf:"bytes,6,rep,name=slots,proto3" json:"slots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
 func (m *DposCycleBoundaryInfo) Reset()         { *m = DposCycleBoundaryInfo{} }
 func (m *DposCycleBoundaryInfo) String() string { return proto.CompactTextString(m) }
 func (*DposCycleBoundaryInfo) ProtoMessage()    {}
 func (*DposCycleBoundaryInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{24}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{26}
 }
 func (m *DposCycleBoundaryInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposCycleBoundaryInfo.Unmarshal(m, b)
@@ -2324,23 +2492,31 @@ func (m *DposCycleBoundaryInfo) GetSignature() []byte {
 	return nil
 }
 
+func (m *DposCycleBoundaryInfo) GetSlots() []*DposSlotStat {
+	if m != nil {
+		return m.Slots
+	}
+	return nil
+}
+
 // DposCBInfo cycle边界记录请求消息
 type DposCBInfo struct {
-	Cycle                int64    `protobuf:"varint,1,opt,name=cycle,proto3" json:"cycle,omitempty"`
-	StopHeight           int64    `protobuf:"varint,2,opt,name=stopHeight,proto3" json:"stopHeight,omitempty"`
-	StopHash             string   `protobuf:"bytes,3,opt,name=stopHash,proto3" json:"stopHash,omitempty"`
-	Pubkey               string   `protobuf:"bytes,4,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
-	Signature            string   `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Cycle                int64           `protobuf:"varint,1,opt,name=cycle,proto3" json:"cycle,omitempty"`
+	StopHeight           int64           `protobuf:"varint,2,opt,name=stopHeight,proto3" json:"stopHeight,omitempty"`
+	StopHash             string          `protobuf:"bytes,3,opt,name=stopHash,proto3" json:"stopHash,omitempty"`
+	Pubkey               string          `protobuf:"bytes,4,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	Signature            string          `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Slots                []*DposSlotStat `protobuf:"bytes,6,rep,name=slots,proto3" json:"slots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *DposCBInfo) Reset()         { *m = DposCBInfo{} }
 func (m *DposCBInfo) String() string { return proto.CompactTextString(m) }
 func (*DposCBInfo) ProtoMessage()    {}
 func (*DposCBInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{25}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{27}
 }
 func (m *DposCBInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposCBInfo.Unmarshal(m, b)
@@ -2395,6 +2571,115 @@ func (m *DposCBInfo) GetSignature() string {
 	return ""
 }
 
+func (m *DposCBInfo) GetSlots() []*DposSlotStat {
+	if m != nil {
+		return m.Slots
+	}
+	return nil
+}
+
+// DposSlotReports 一个cycle内报告受托节点错过出块过多的受托节点，达到法定数目后禁止其出块
+type DposSlotReports struct {
+	Cycle                int64    `protobuf:"varint,1,opt,name=cycle,proto3" json:"cycle,omitempty"`
+	Pubkey               string   `protobuf:"bytes,2,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	Reporters            []string `protobuf:"bytes,3,rep,name=reporters,proto3" json:"reporters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DposSlotReports) Reset()         { *m = DposSlotReports{} }
+func (m *DposSlotReports) String() string { return proto.CompactTextString(m) }
+func (*DposSlotReports) ProtoMessage()    {}
+func (*DposSlotReports) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{28}
+}
+func (m *DposSlotReports) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DposSlotReports.Unmarshal(m, b)
+}
+func (m *DposSlotReports) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DposSlotReports.Marshal(b, m, deterministic)
+}
+func (dst *DposSlotReports) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DposSlotReports.Merge(dst, src)
+}
+func (m *DposSlotReports) XXX_Size() int {
+	return xxx_messageInfo_DposSlotReports.Size(m)
+}
+func (m *DposSlotReports) XXX_DiscardUnknown() {
+	xxx_messageInfo_DposSlotReports.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DposSlotReports proto.InternalMessageInfo
+
+func (m *DposSlotReports) GetCycle() int64 {
+	if m != nil {
+		return m.Cycle
+	}
+	return 0
+}
+
+func (m *DposSlotReports) GetPubkey() string {
+	if m != nil {
+		return m.Pubkey
+	}
+	return ""
+}
+
+func (m *DposSlotReports) GetReporters() []string {
+	if m != nil {
+		return m.Reporters
+	}
+	return nil
+}
+
+// DposJailQuery 被禁止出块的候选节点查询
+type DposJailQuery struct {
+	Cycle                int64    `protobuf:"varint,1,opt,name=cycle,proto3" json:"cycle,omitempty"`
+	Pubkeys              []string `protobuf:"bytes,2,rep,name=pubkeys,proto3" json:"pubkeys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DposJailQuery) Reset()         { *m = DposJailQuery{} }
+func (m *DposJailQuery) String() string { return proto.CompactTextString(m) }
+func (*DposJailQuery) ProtoMessage()    {}
+func (*DposJailQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{29}
+}
+func (m *DposJailQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DposJailQuery.Unmarshal(m, b)
+}
+func (m *DposJailQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DposJailQuery.Marshal(b, m, deterministic)
+}
+func (dst *DposJailQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DposJailQuery.Merge(dst, src)
+}
+func (m *DposJailQuery) XXX_Size() int {
+	return xxx_messageInfo_DposJailQuery.Size(m)
+}
+func (m *DposJailQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_DposJailQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DposJailQuery proto.InternalMessageInfo
+
+func (m *DposJailQuery) GetCycle() int64 {
+	if m != nil {
+		return m.Cycle
+	}
+	return 0
+}
+
+func (m *DposJailQuery) GetPubkeys() []string {
+	if m != nil {
+		return m.Pubkeys
+	}
+	return nil
+}
+
 // DposCBQuery cycle边界记录查询请求
 type DposCBQuery struct {
 	Cycle                int64    `protobuf:"varint,1,opt,name=cycle,proto3" json:"cycle,omitempty"`
@@ -2410,7 +2695,7 @@ func (m *DposCBQuery) Reset()         { *m = DposCBQuery{} }
 func (m *DposCBQuery) String() string { return proto.CompactTextString(m) }
 func (*DposCBQuery) ProtoMessage()    {}
 func (*DposCBQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{26}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{30}
 }
 func (m *DposCBQuery) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposCBQuery.Unmarshal(m, b)
@@ -2470,7 +2755,7 @@ func (m *DposCBReply) Reset()         { *m = DposCBReply{} }
 func (m *DposCBReply) String() string { return proto.CompactTextString(m) }
 func (*DposCBReply) ProtoMessage()    {}
 func (*DposCBReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{27}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{31}
 }
 func (m *DposCBReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DposCBReply.Unmarshal(m, b)
@@ -2518,7 +2803,7 @@ func (m *ReceiptCB) Reset()         { *m = ReceiptCB{} }
 func (m *ReceiptCB) String() string { return proto.CompactTextString(m) }
 func (*ReceiptCB) ProtoMessage()    {}
 func (*ReceiptCB) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{28}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{32}
 }
 func (m *ReceiptCB) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiptCB.Unmarshal(m, b)
@@ -2624,7 +2909,7 @@ func (m *TopNCandidator) Reset()         { *m = TopNCandidator{} }
 func (m *TopNCandidator) String() string { return proto.CompactTextString(m) }
 func (*TopNCandidator) ProtoMessage()    {}
 func (*TopNCandidator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{29}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{33}
 }
 func (m *TopNCandidator) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TopNCandidator.Unmarshal(m, b)
@@ -2694,7 +2979,7 @@ func (m *TopNCandidators) Reset()         { *m = TopNCandidators{} }
 func (m *TopNCandidators) String() string { return proto.CompactTextString(m) }
 func (*TopNCandidators) ProtoMessage()    {}
 func (*TopNCandidators) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{30}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{34}
 }
 func (m *TopNCandidators) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TopNCandidators.Unmarshal(m, b)
@@ -2754,7 +3039,7 @@ func (m *TopNCandidatorRegist) Reset()         { *m = TopNCandidatorRegist{} }
 func (m *TopNCandidatorRegist) String() string { return proto.CompactTextString(m) }
 func (*TopNCandidatorRegist) ProtoMessage()    {}
 func (*TopNCandidatorRegist) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{31}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{35}
 }
 func (m *TopNCandidatorRegist) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TopNCandidatorRegist.Unmarshal(m, b)
@@ -2793,7 +3078,7 @@ func (m *TopNCandidatorsQuery) Reset()         { *m = TopNCandidatorsQuery{} }
 func (m *TopNCandidatorsQuery) String() string { return proto.CompactTextString(m) }
 func (*TopNCandidatorsQuery) ProtoMessage()    {}
 func (*TopNCandidatorsQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{32}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{36}
 }
 func (m *TopNCandidatorsQuery) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TopNCandidatorsQuery.Unmarshal(m, b)
@@ -2832,7 +3117,7 @@ func (m *TopNCandidatorsReply) Reset()         { *m = TopNCandidatorsReply{} }
 func (m *TopNCandidatorsReply) String() string { return proto.CompactTextString(m) }
 func (*TopNCandidatorsReply) ProtoMessage()    {}
 func (*TopNCandidatorsReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{33}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{37}
 }
 func (m *TopNCandidatorsReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TopNCandidatorsReply.Unmarshal(m, b)
@@ -2877,7 +3162,7 @@ func (m *ReceiptTopN) Reset()         { *m = ReceiptTopN{} }
 func (m *ReceiptTopN) String() string { return proto.CompactTextString(m) }
 func (*ReceiptTopN) ProtoMessage()    {}
 func (*ReceiptTopN) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dposvote_1126daa6e916fdd0, []int{34}
+	return fileDescriptor_dposvote_54ff11ae6949ec0d, []int{38}
 }
 func (m *ReceiptTopN) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiptTopN.Unmarshal(m, b)
@@ -2955,6 +3240,7 @@ func init() {
 	proto.RegisterType((*DposVote)(nil), "types.DposVote")
 	proto.RegisterType((*DposCancelVote)(nil), "types.DposCancelVote")
 	proto.RegisterType((*DposVoteAction)(nil), "types.DposVoteAction")
+	proto.RegisterType((*DposCandidatorUnjail)(nil), "types.DposCandidatorUnjail")
 	proto.RegisterType((*CandidatorQuery)(nil), "types.CandidatorQuery")
 	proto.RegisterType((*JSONCandidator)(nil), "types.JSONCandidator")
 	proto.RegisterType((*CandidatorReply)(nil), "types.CandidatorReply")
@@ -2971,8 +3257,11 @@ func init() {
 	proto.RegisterType((*DposVrfQuery)(nil), "types.DposVrfQuery")
 	proto.RegisterType((*JSONVrfInfo)(nil), "types.JSONVrfInfo")
 	proto.RegisterType((*DposVrfReply)(nil), "types.DposVrfReply")
+	proto.RegisterType((*DposSlotStat)(nil), "types.DposSlotStat")
 	proto.RegisterType((*DposCycleBoundaryInfo)(nil), "types.DposCycleBoundaryInfo")
 	proto.RegisterType((*DposCBInfo)(nil), "types.DposCBInfo")
+	proto.RegisterType((*DposSlotReports)(nil), "types.DposSlotReports")
+	proto.RegisterType((*DposJailQuery)(nil), "types.DposJailQuery")
 	proto.RegisterType((*DposCBQuery)(nil), "types.DposCBQuery")
 	proto.RegisterType((*DposCBReply)(nil), "types.DposCBReply")
 	proto.RegisterType((*ReceiptCB)(nil), "types.ReceiptCB")
@@ -2984,103 +3273,113 @@ func init() {
 	proto.RegisterType((*ReceiptTopN)(nil), "types.ReceiptTopN")
 }
 
-func init() { proto.RegisterFile("dposvote.proto", fileDescriptor_dposvote_1126daa6e916fdd0) }
-
-var fileDescriptor_dposvote_1126daa6e916fdd0 = []byte{
-	// 1511 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xdd, 0x6e, 0xdc, 0xc4,
-	0x17, 0xcf, 0xd8, 0xfb, 0xe5, 0xe3, 0xcd, 0xe6, 0xdf, 0xf9, 0x6f, 0x2b, 0xab, 0x54, 0x68, 0xb1,
-	0x8a, 0x48, 0xb9, 0x08, 0x74, 0x69, 0xc5, 0x47, 0x55, 0xa4, 0x6e, 0x90, 0xd8, 0x54, 0x34, 0x0d,
-	0xd3, 0x28, 0x42, 0xe2, 0xca, 0xb1, 0xbd, 0xc9, 0x8a, 0xcd, 0xda, 0xb2, 0x9d, 0xa8, 0x2b, 0x21,
-	0x81, 0x84, 0x78, 0x03, 0xae, 0xe1, 0x82, 0x8b, 0x8a, 0x0b, 0x9e, 0x02, 0x9e, 0x82, 0x27, 0xe0,
-	0x21, 0xb8, 0x40, 0x73, 0x66, 0x3c, 0x1e, 0x3b, 0x71, 0xd2, 0x2d, 0x4d, 0x7b, 0xb7, 0x67, 0xe6,
-	0xcc, 0xf1, 0x39, 0xbf, 0xdf, 0x99, 0x73, 0xce, 0x2c, 0xf4, 0x82, 0x38, 0x4a, 0x4f, 0xa2, 0x2c,
-	0xdc, 0x88, 0x93, 0x28, 0x8b, 0x68, 0x33, 0x5b, 0xc4, 0x61, 0xea, 0xfe, 0x63, 0x40, 0x6f, 0xd3,
-	0x9b, 0x07, 0xd3, 0xc0, 0xcb, 0xa2, 0x64, 0x6b, 0x3e, 0x89, 0xe8, 0x35, 0x68, 0xc5, 0xc7, 0xfb,
-	0xdf, 0x84, 0x0b, 0x87, 0x0c, 0xc8, 0x7a, 0x97, 0x49, 0x89, 0x3a, 0xd0, 0xf6, 0x82, 0x20, 0x09,
-	0xd3, 0xd4, 0x31, 0x06, 0x64, 0xdd, 0x62, 0xb9, 0x48, 0x7b, 0x60, 0x6c, 0xed, 0x38, 0x26, 0x2e,
-	0x1a, 0x5b, 0x3b, 0xb4, 0x0f, 0x4d, 0xfe, 0xa5, 0xd4, 0x69, 0x0c, 0xc8, 0xba, 0xc9, 0x84, 0xc0,
-	0xed, 0xa6, 0x99, 0x97, 0x1d, 0xa7, 0x4e, 0x13, 0x97, 0xa5, 0x44, 0x6f, 0x80, 0x15, 0x27, 0xe1,
-	0x13, 0xb1, 0xd5, 0xc2, 0xad, 0x62, 0x81, 0xef, 0xa6, 0x99, 0x97, 0x64, 0xbb, 0xd3, 0xa3, 0xd0,
-	0x69, 0x8b, 0x5d, 0xb5, 0x40, 0x07, 0x60, 0xa3, 0x30, 0x0e, 0xa7, 0x07, 0x87, 0x99, 0xd3, 0xc1,
-	0x7d, 0x7d, 0x49, 0x69, 0xec, 0x3e, 0x1d, 0x7b, 0xe9, 0xa1, 0x63, 0xa1, 0x93, 0xfa, 0x12, 0x7d,
-	0x13, 0x00, 0xc5, 0xad, 0x79, 0x10, 0x3e, 0x75, 0x00, 0x4d, 0x68, 0x2b, 0x3c, 0x9a, 0x29, 0x6e,
-	0xd9, 0x22, 0x1a, 0x14, 0xe8, 0x75, 0xe8, 0xc4, 0x49, 0x28, 0xce, 0x74, 0x71, 0x43, 0xc9, 0x74,
-	0x1d, 0x5a, 0x3c, 0xe4, 0x24, 0x75, 0x56, 0x07, 0xe6, 0xba, 0x3d, 0xfc, 0xdf, 0x06, 0x82, 0xbd,
-	0xf1, 0x59, 0x1c, 0xa5, 0x7b, 0x7c, 0x83, 0xc9, 0x7d, 0xf7, 0x3b, 0xb0, 0xd4, 0x22, 0x37, 0x39,
-	0x49, 0xa2, 0xa3, 0x07, 0x41, 0x90, 0x20, 0xf4, 0x16, 0x53, 0xb2, 0x46, 0x8a, 0x51, 0x22, 0x45,
-	0x41, 0x6d, 0xea, 0x50, 0x2b, 0x97, 0x1b, 0xba, 0xcb, 0x14, 0x1a, 0x19, 0x47, 0x51, 0xc0, 0x8f,
-	0xbf, 0xdd, 0x6f, 0x01, 0x0a, 0xfa, 0x5f, 0x35, 0xf5, 0xee, 0x57, 0xd0, 0xe7, 0xe1, 0x17, 0x1e,
-	0xb0, 0xf0, 0x60, 0x9a, 0x66, 0x15, 0x3f, 0xac, 0xe5, 0xfd, 0x70, 0xb7, 0xe1, 0x7a, 0xd9, 0xf2,
-	0xa6, 0x37, 0xf7, 0xc3, 0xd9, 0x8b, 0xda, 0x77, 0x77, 0xa1, 0x93, 0x13, 0xb5, 0x04, 0x4f, 0xd6,
-	0xf9, 0x3c, 0xb9, 0x9f, 0x42, 0x4f, 0x7a, 0xe9, 0x87, 0x33, 0xb4, 0x5d, 0xe7, 0x99, 0x62, 0xd4,
-	0xd4, 0x18, 0x75, 0xff, 0x68, 0x09, 0x03, 0xfc, 0xe8, 0x03, 0x3f, 0x9b, 0x46, 0x73, 0x7a, 0x17,
-	0x5a, 0x09, 0x06, 0x89, 0x06, 0xec, 0xe1, 0x1b, 0x5a, 0xee, 0x55, 0x71, 0x1e, 0xaf, 0x30, 0xa9,
-	0x4c, 0x3f, 0x87, 0xae, 0xaf, 0x21, 0x84, 0xde, 0xdb, 0xc3, 0xb7, 0xce, 0x3c, 0xac, 0x43, 0x39,
-	0x5e, 0x61, 0xa5, 0x83, 0xf4, 0x63, 0xe8, 0x24, 0xa1, 0x34, 0x62, 0x3e, 0x8f, 0x07, 0x4a, 0x9d,
-	0xbe, 0x0d, 0x0d, 0x0e, 0x0b, 0xa6, 0x8e, 0x3d, 0x5c, 0xab, 0x5c, 0x9a, 0xf1, 0x0a, 0xc3, 0x6d,
-	0xfa, 0x21, 0x80, 0xaf, 0x00, 0xc3, 0x84, 0xb2, 0x87, 0x57, 0xcb, 0xdf, 0x90, 0x9b, 0xe3, 0x15,
-	0xa6, 0xa9, 0xd2, 0x11, 0xac, 0xf9, 0xea, 0xfb, 0x5f, 0x1e, 0x87, 0xc9, 0x02, 0xcb, 0x8d, 0x3d,
-	0xbc, 0x26, 0x4f, 0x6f, 0x96, 0x77, 0xc7, 0x2b, 0xac, 0x7a, 0x80, 0xde, 0x01, 0x8b, 0x3b, 0x21,
-	0x4e, 0xb7, 0xf1, 0x74, 0xbf, 0xe2, 0x68, 0x7e, 0xb6, 0x50, 0xe4, 0x2e, 0x0b, 0x9c, 0xf7, 0x92,
-	0xc9, 0x23, 0xac, 0x52, 0x65, 0x97, 0xf9, 0xb2, 0x02, 0x44, 0x53, 0xa5, 0x9f, 0x80, 0xad, 0x24,
-	0xb6, 0x83, 0xd5, 0xab, 0x70, 0x57, 0x9e, 0x64, 0x3b, 0xea, 0xa8, 0xae, 0x4c, 0x6f, 0x43, 0xe7,
-	0x24, 0x99, 0x08, 0x4f, 0x01, 0x0f, 0xfe, 0xbf, 0x7c, 0x30, 0x77, 0x54, 0xa9, 0xd1, 0xf7, 0x38,
-	0x79, 0x7e, 0x94, 0x04, 0x9b, 0x23, 0xac, 0x76, 0xf6, 0xf0, 0x8a, 0x0e, 0xec, 0x88, 0xf7, 0x07,
-	0x41, 0x99, 0x50, 0xa2, 0x1b, 0xd0, 0xf6, 0xf7, 0xc5, 0x27, 0xba, 0xa8, 0x4f, 0x4b, 0xfa, 0xf9,
-	0x17, 0x72, 0x25, 0x7a, 0x3f, 0x07, 0x62, 0x37, 0x8a, 0xb7, 0x9d, 0xd5, 0x52, 0x7e, 0xf0, 0xa5,
-	0x33, 0xf2, 0x43, 0x3b, 0x40, 0xef, 0x81, 0x95, 0x45, 0xf1, 0xb6, 0xf8, 0x60, 0xef, 0x9c, 0xd3,
-	0xa9, 0x22, 0x41, 0xe9, 0xf3, 0x12, 0x91, 0x2d, 0x9c, 0xb5, 0x01, 0x59, 0x6f, 0x32, 0x23, 0x5b,
-	0x8c, 0xda, 0xd0, 0x3c, 0xf1, 0x66, 0xc7, 0xa1, 0xfb, 0x18, 0xd6, 0x2a, 0xcc, 0xf3, 0x42, 0x20,
-	0x2e, 0x5e, 0xea, 0x90, 0x81, 0xc9, 0x0b, 0x81, 0x14, 0xb1, 0x88, 0x72, 0xdf, 0x0d, 0xb4, 0x83,
-	0xbf, 0xa5, 0x65, 0x33, 0xb7, 0xec, 0x7e, 0x4f, 0xa0, 0xf7, 0xf0, 0xc9, 0xe3, 0xed, 0xda, 0xca,
-	0x6a, 0x5d, 0x7a, 0x65, 0x7d, 0xa8, 0xc7, 0xc4, 0xc2, 0x78, 0xc6, 0x93, 0xd0, 0x2e, 0xb2, 0x59,
-	0xc4, 0x55, 0x64, 0x61, 0xd9, 0x5d, 0xa6, 0x6b, 0xba, 0xf7, 0x61, 0xb5, 0x94, 0xdb, 0xe7, 0xa3,
-	0xc3, 0xfd, 0x97, 0xb1, 0xe0, 0x6f, 0xf7, 0x07, 0x02, 0xab, 0xdc, 0xfc, 0x8b, 0x34, 0x3a, 0xeb,
-	0xa5, 0x35, 0xba, 0x7b, 0x45, 0x10, 0x02, 0x8e, 0x77, 0x73, 0x83, 0x02, 0x88, 0xbe, 0x06, 0x44,
-	0xd1, 0xa7, 0x65, 0x9d, 0xfe, 0xcb, 0x80, 0x2b, 0x2c, 0xf4, 0xc3, 0x69, 0x9c, 0x21, 0x48, 0x3e,
-	0x72, 0xda, 0x87, 0xa6, 0xe8, 0xff, 0x44, 0x7c, 0x5c, 0x34, 0xff, 0xba, 0x4e, 0xad, 0x31, 0x6d,
-	0x96, 0x99, 0x2e, 0x38, 0x6c, 0xd4, 0x0f, 0x46, 0xcd, 0xea, 0x60, 0xe4, 0x42, 0x57, 0xe8, 0x6d,
-	0x1e, 0x7a, 0xf3, 0x83, 0x10, 0x4b, 0x59, 0x87, 0x95, 0xd6, 0x38, 0xd0, 0x3c, 0x80, 0xdd, 0x45,
-	0x2c, 0x66, 0xa7, 0x26, 0x53, 0x32, 0xbd, 0x29, 0xab, 0xad, 0xa8, 0x46, 0xa7, 0x47, 0x14, 0x51,
-	0x6c, 0x75, 0xaa, 0xac, 0x0a, 0x55, 0xb7, 0xa1, 0xc3, 0xd3, 0x84, 0x17, 0x05, 0x59, 0x60, 0xae,
-	0x9e, 0x2a, 0xa4, 0x7c, 0x93, 0x29, 0x35, 0xc5, 0x8c, 0xad, 0x31, 0xf3, 0x37, 0x91, 0xbd, 0x95,
-	0x17, 0xbc, 0xe5, 0x30, 0xed, 0x43, 0xd3, 0x5f, 0xf8, 0xb3, 0x30, 0x4f, 0x0a, 0x14, 0xb8, 0xf6,
-	0xa1, 0x98, 0x07, 0x25, 0x9e, 0x42, 0xa2, 0x5d, 0x20, 0x47, 0x88, 0x63, 0x97, 0x91, 0x23, 0xe5,
-	0x4a, 0xab, 0x70, 0x85, 0x8f, 0x82, 0x68, 0xe2, 0x09, 0x9f, 0xfe, 0xe4, 0xb4, 0xa9, 0xad, 0xf0,
-	0x61, 0x12, 0xa5, 0x47, 0xd3, 0x20, 0x98, 0x85, 0xf9, 0xb8, 0xa9, 0x2d, 0x71, 0xce, 0xa4, 0x7e,
-	0x14, 0x23, 0x60, 0x26, 0x2b, 0x16, 0xdc, 0x1f, 0x0d, 0x39, 0xef, 0x61, 0x81, 0x7e, 0x75, 0xb1,
-	0x76, 0x81, 0x24, 0x18, 0x68, 0x97, 0x91, 0x84, 0x4b, 0x31, 0x06, 0xd7, 0x65, 0x24, 0x56, 0x38,
-	0x74, 0x6a, 0x71, 0xb0, 0x2e, 0xc2, 0x01, 0x2e, 0xc0, 0xc1, 0xae, 0xe2, 0xf0, 0x85, 0x1c, 0x5b,
-	0x54, 0xdb, 0x3b, 0x6f, 0xee, 0x11, 0x51, 0x1b, 0x7a, 0xd4, 0x18, 0x9d, 0xb8, 0x45, 0xe4, 0xc8,
-	0xfd, 0x1a, 0xd6, 0x2a, 0xad, 0x70, 0x79, 0x73, 0x49, 0x6e, 0x4e, 0xc2, 0xd3, 0x10, 0x52, 0xec,
-	0xfe, 0x6c, 0x00, 0xc8, 0xab, 0xbf, 0x97, 0x4c, 0x96, 0xe4, 0xac, 0xb8, 0xd9, 0x66, 0xe9, 0x66,
-	0x2b, 0x37, 0x1a, 0x67, 0x73, 0xd9, 0x3c, 0xcd, 0x65, 0xab, 0xc4, 0x65, 0xbb, 0xc4, 0x65, 0xa7,
-	0xca, 0xa5, 0x55, 0xcb, 0x25, 0x5c, 0xc4, 0xa5, 0x7d, 0x01, 0x97, 0xdd, 0x2a, 0x97, 0xbf, 0x12,
-	0x68, 0xef, 0x25, 0x13, 0xbc, 0xde, 0x2f, 0x98, 0xd1, 0x97, 0x8f, 0x82, 0x3b, 0x83, 0xae, 0x3e,
-	0xf5, 0x9c, 0xd3, 0xc2, 0x44, 0x33, 0x17, 0xf9, 0x61, 0x64, 0x0b, 0x1e, 0x3d, 0xb7, 0x90, 0x66,
-	0xde, 0x51, 0x2c, 0x69, 0x2c, 0x16, 0xce, 0x8e, 0xc1, 0x7d, 0x46, 0xc0, 0xe6, 0x8d, 0x64, 0x19,
-	0x5c, 0xac, 0xff, 0x8a, 0x8b, 0x55, 0xc2, 0xc5, 0x2a, 0xe1, 0x62, 0xd5, 0xe1, 0x72, 0x47, 0xe1,
-	0x22, 0xba, 0xe2, 0x4d, 0x30, 0x4f, 0x92, 0x89, 0xec, 0x89, 0x54, 0xeb, 0x89, 0x32, 0x14, 0xc6,
-	0xb7, 0xdd, 0x5f, 0x08, 0x5c, 0xc5, 0x09, 0x8f, 0x7b, 0x36, 0x8a, 0x8e, 0xe7, 0x81, 0x97, 0x2c,
-	0xf2, 0x48, 0x85, 0xef, 0x44, 0xf7, 0x1d, 0x9f, 0xd8, 0x51, 0x2c, 0x5f, 0xe9, 0x46, 0xfe, 0xc4,
-	0xce, 0x57, 0x78, 0x97, 0x41, 0x89, 0xbf, 0xd0, 0x4d, 0xa4, 0x51, 0xc9, 0x1a, 0x4a, 0x8d, 0x52,
-	0xf6, 0xdc, 0x00, 0x2b, 0x9d, 0x1e, 0xcc, 0xbd, 0xec, 0x38, 0x09, 0x65, 0xa5, 0x2b, 0x16, 0xdc,
-	0x9f, 0x08, 0x40, 0x31, 0xb3, 0xbe, 0x24, 0xb7, 0xac, 0x5a, 0xb7, 0xac, 0x7a, 0xb7, 0x2c, 0xdd,
-	0xad, 0x08, 0x6c, 0x6d, 0x32, 0xbe, 0x04, 0xb7, 0x44, 0xf6, 0x36, 0xd4, 0x28, 0xfa, 0x51, 0xfe,
-	0x41, 0x41, 0xef, 0x2d, 0x68, 0xf9, 0xfb, 0xd8, 0xb0, 0x49, 0xcd, 0x78, 0xcf, 0xa4, 0x82, 0xfb,
-	0x9b, 0x01, 0x56, 0x3e, 0xf3, 0x8c, 0x5e, 0x4b, 0xdd, 0x7b, 0x0d, 0x1d, 0x9a, 0xde, 0x51, 0x00,
-	0x89, 0x89, 0xe6, 0x86, 0x0e, 0x50, 0x35, 0xdb, 0x15, 0x56, 0xcf, 0x08, 0xf4, 0xca, 0x0f, 0x10,
-	0xfa, 0x0e, 0x34, 0xf9, 0xd4, 0x93, 0x8f, 0x97, 0x57, 0x4e, 0x4d, 0x46, 0x4c, 0xec, 0xf3, 0x28,
-	0x0f, 0x39, 0x93, 0x02, 0x41, 0xfc, 0xad, 0x21, 0x62, 0x96, 0x10, 0xe1, 0x33, 0xdf, 0xf4, 0x60,
-	0x1e, 0x26, 0x3b, 0xfa, 0x8d, 0x28, 0xad, 0x5d, 0x70, 0x2f, 0x7e, 0x27, 0xb0, 0x56, 0x79, 0x2a,
-	0xd1, 0xbb, 0xf8, 0xa0, 0x0e, 0x70, 0xee, 0xab, 0xbe, 0x0b, 0x2a, 0x8f, 0x32, 0x4d, 0x91, 0x97,
-	0xd0, 0x93, 0x30, 0x49, 0xa7, 0xd1, 0x5c, 0xe6, 0x68, 0x2e, 0xd6, 0xd2, 0x7f, 0x1b, 0x60, 0x32,
-	0x9d, 0x7b, 0xb3, 0x4d, 0x04, 0xa6, 0x51, 0x07, 0x8c, 0xa6, 0xe4, 0x3e, 0x80, 0xfe, 0x59, 0xef,
-	0x42, 0x7a, 0x0b, 0x1a, 0xdc, 0x15, 0x99, 0xc6, 0x35, 0xde, 0xa2, 0x8a, 0xfb, 0x7e, 0xd5, 0x44,
-	0xaa, 0x5a, 0x40, 0xee, 0x3f, 0x29, 0xf9, 0xef, 0x8e, 0x4e, 0x9d, 0xc8, 0x9f, 0x0c, 0xe2, 0xed,
-	0x47, 0x4a, 0xcf, 0xf0, 0xaa, 0x2a, 0xea, 0xb8, 0x7f, 0x12, 0xb0, 0xe5, 0xf5, 0xc1, 0xa7, 0xeb,
-	0xcb, 0xb9, 0x40, 0x9a, 0xcf, 0x8d, 0x53, 0x98, 0x3f, 0xf7, 0x25, 0xba, 0x25, 0xe3, 0x80, 0x73,
-	0xc1, 0xe3, 0x2a, 0xfb, 0x2d, 0xfc, 0xb7, 0xf8, 0x83, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xd9,
-	0x01, 0x41, 0xd3, 0x3f, 0x16, 0x00, 0x00,
+func init() { proto.RegisterFile("dposvote.proto", fileDescriptor_dposvote_54ff11ae6949ec0d) }
+
+var fileDescriptor_dposvote_54ff11ae6949ec0d = []byte{
+	// 1676 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0x4d, 0x6f, 0x1c, 0x45,
+	0x13, 0xf6, 0xec, 0xec, 0xd7, 0xd4, 0xac, 0xd7, 0x49, 0xbf, 0x9b, 0x68, 0x94, 0x37, 0x7a, 0xe5,
+	0x77, 0x14, 0x84, 0xcd, 0xc1, 0x10, 0x93, 0x88, 0x8f, 0x28, 0xa0, 0xd8, 0x48, 0xd8, 0x11, 0x71,
+	0x4c, 0xdb, 0x58, 0x08, 0xc4, 0x61, 0xbd, 0x33, 0x6b, 0x0f, 0xac, 0x77, 0x46, 0x33, 0xb3, 0x56,
+	0x56, 0x42, 0xe2, 0x80, 0xf8, 0x1b, 0x5c, 0x38, 0x44, 0x1c, 0xf8, 0x0d, 0x5c, 0x38, 0xf1, 0x13,
+	0x38, 0x72, 0xe2, 0x37, 0x70, 0x42, 0x55, 0xdd, 0x33, 0xd3, 0x3d, 0xbb, 0x63, 0xc7, 0x26, 0x1f,
+	0xb7, 0xad, 0xea, 0xaa, 0xee, 0xaa, 0xa7, 0x3e, 0xba, 0xa6, 0x17, 0xba, 0x5e, 0x14, 0x26, 0xa7,
+	0x61, 0xea, 0xaf, 0x45, 0x71, 0x98, 0x86, 0xac, 0x91, 0x4e, 0x23, 0x3f, 0x71, 0xff, 0x34, 0xa1,
+	0xbb, 0xd9, 0x1f, 0x7b, 0x81, 0xd7, 0x4f, 0xc3, 0x78, 0x7b, 0x3c, 0x0c, 0xd9, 0x75, 0x68, 0x46,
+	0x93, 0xc3, 0x6f, 0xfc, 0xa9, 0x63, 0x2c, 0x1b, 0x2b, 0x1d, 0x2e, 0x29, 0xe6, 0x40, 0xab, 0xef,
+	0x79, 0xb1, 0x9f, 0x24, 0x4e, 0x6d, 0xd9, 0x58, 0xb1, 0x78, 0x46, 0xb2, 0x2e, 0xd4, 0xb6, 0x77,
+	0x1d, 0x93, 0x98, 0xb5, 0xed, 0x5d, 0xd6, 0x83, 0x06, 0x9e, 0x94, 0x38, 0xf5, 0x65, 0x63, 0xc5,
+	0xe4, 0x82, 0xc0, 0x7d, 0x93, 0xb4, 0x9f, 0x4e, 0x12, 0xa7, 0x41, 0x6c, 0x49, 0xb1, 0x9b, 0x60,
+	0x45, 0xb1, 0xbf, 0x27, 0x96, 0x9a, 0xb4, 0x54, 0x30, 0x70, 0x35, 0x49, 0xfb, 0x71, 0xba, 0x1f,
+	0x9c, 0xf8, 0x4e, 0x4b, 0xac, 0xe6, 0x0c, 0xb6, 0x0c, 0x36, 0x11, 0x5b, 0x7e, 0x70, 0x74, 0x9c,
+	0x3a, 0x6d, 0x5a, 0x57, 0x59, 0xb9, 0xc4, 0xfe, 0x93, 0xad, 0x7e, 0x72, 0xec, 0x58, 0x64, 0xa4,
+	0xca, 0x62, 0xff, 0x03, 0x20, 0x72, 0x7b, 0xec, 0xf9, 0x4f, 0x1c, 0xa0, 0x2d, 0x14, 0x0e, 0x7a,
+	0x13, 0xd0, 0x92, 0x2d, 0xbc, 0x21, 0x82, 0xdd, 0x80, 0x76, 0x14, 0xfb, 0x42, 0xa7, 0x43, 0x0b,
+	0x39, 0xcd, 0x56, 0xa0, 0x89, 0x2e, 0xc7, 0x89, 0xb3, 0xb8, 0x6c, 0xae, 0xd8, 0xeb, 0x57, 0xd6,
+	0x08, 0xec, 0xb5, 0x8f, 0xa2, 0x30, 0x39, 0xc0, 0x05, 0x2e, 0xd7, 0xd1, 0xba, 0xaf, 0xfb, 0xc1,
+	0xc8, 0xf7, 0x36, 0xa7, 0x83, 0x91, 0xef, 0x74, 0x85, 0xfd, 0x0a, 0x0b, 0x25, 0x4e, 0x82, 0x24,
+	0xf1, 0xbd, 0xbd, 0x51, 0x98, 0x26, 0xce, 0x92, 0x90, 0x50, 0x58, 0x28, 0x31, 0x19, 0xa3, 0x8a,
+	0xd8, 0xe3, 0x8a, 0x90, 0x50, 0x58, 0xee, 0x77, 0x60, 0xe5, 0x47, 0xa3, 0xe1, 0xc3, 0x38, 0x3c,
+	0x79, 0xe0, 0x79, 0x31, 0x05, 0xd8, 0xe2, 0x39, 0xad, 0x84, 0xbe, 0xa6, 0x85, 0x3e, 0x0f, 0xa8,
+	0xa9, 0x06, 0x34, 0x07, 0xa6, 0xae, 0x02, 0xc3, 0xa0, 0x9e, 0x62, 0xac, 0x44, 0x90, 0xe9, 0xb7,
+	0xfb, 0x2d, 0x40, 0x91, 0x64, 0x2f, 0x3b, 0xc1, 0xdc, 0xcf, 0xa1, 0x87, 0xee, 0x17, 0x16, 0x70,
+	0xff, 0x28, 0x48, 0xd2, 0x92, 0x1d, 0xd6, 0xc5, 0xed, 0x70, 0x77, 0xe0, 0x86, 0xbe, 0xf3, 0x66,
+	0x7f, 0x3c, 0xf0, 0x47, 0x97, 0xdd, 0xdf, 0xdd, 0x87, 0x76, 0x16, 0xa8, 0x0b, 0xc4, 0xc9, 0x3a,
+	0x3b, 0x4e, 0xee, 0x07, 0xd0, 0x95, 0x56, 0x0e, 0xfc, 0x11, 0xed, 0x5d, 0x65, 0x59, 0x1e, 0x51,
+	0x53, 0x89, 0xa8, 0xfb, 0x77, 0x53, 0x6c, 0x80, 0xaa, 0x0f, 0x06, 0x69, 0x10, 0x8e, 0xd9, 0x5d,
+	0x68, 0xc6, 0xe4, 0x24, 0x6d, 0x60, 0xaf, 0xff, 0x57, 0xc9, 0xf0, 0x32, 0xce, 0x5b, 0x0b, 0x5c,
+	0x0a, 0xb3, 0x8f, 0xa1, 0x33, 0x50, 0x10, 0x22, 0xeb, 0xed, 0xf5, 0xff, 0xcf, 0x55, 0x56, 0xa1,
+	0xdc, 0x5a, 0xe0, 0x9a, 0x22, 0x7b, 0x0f, 0xda, 0xb1, 0x2f, 0x37, 0x31, 0x9f, 0xc5, 0x82, 0x5c,
+	0x9c, 0xbd, 0x06, 0x75, 0x84, 0x85, 0x52, 0xc7, 0x5e, 0x5f, 0x2a, 0x95, 0xe6, 0xd6, 0x02, 0xa7,
+	0x65, 0xf6, 0x0e, 0xc0, 0x20, 0x07, 0x8c, 0x12, 0xca, 0x5e, 0xbf, 0xa6, 0x9f, 0x21, 0x17, 0xb7,
+	0x16, 0xb8, 0x22, 0xca, 0x36, 0x60, 0x69, 0x90, 0x9f, 0xff, 0xe9, 0xc4, 0x8f, 0xa7, 0xd4, 0xd4,
+	0xec, 0xf5, 0xeb, 0x52, 0x7b, 0x53, 0x5f, 0xdd, 0x5a, 0xe0, 0x65, 0x05, 0x76, 0x07, 0x2c, 0x34,
+	0x42, 0x68, 0xb7, 0x48, 0xbb, 0x57, 0x32, 0x34, 0xd3, 0x2d, 0x04, 0xd1, 0x64, 0x81, 0xf3, 0x41,
+	0x3c, 0x7c, 0xe4, 0xb4, 0x67, 0x4c, 0x46, 0x76, 0x0e, 0x88, 0x22, 0xca, 0xde, 0x07, 0x3b, 0xa7,
+	0xf8, 0xae, 0x63, 0x69, 0xe6, 0x4a, 0x4d, 0xbe, 0x9b, 0xab, 0xaa, 0xc2, 0xec, 0x36, 0xb4, 0x4f,
+	0xe3, 0xa1, 0xb0, 0x14, 0x48, 0xf1, 0x3f, 0xba, 0x62, 0x66, 0x68, 0x2e, 0xc6, 0xde, 0xc4, 0xe0,
+	0x0d, 0xc2, 0xd8, 0xdb, 0xdc, 0xa0, 0x9e, 0x6a, 0xaf, 0x5f, 0x55, 0x81, 0xdd, 0xc0, 0x5b, 0x48,
+	0x84, 0x4c, 0x08, 0xb1, 0x35, 0x68, 0x0d, 0x0e, 0xc5, 0x11, 0x1d, 0x92, 0x67, 0x9a, 0x7c, 0x76,
+	0x42, 0x26, 0xc4, 0xee, 0x67, 0x40, 0xec, 0x87, 0xd1, 0x8e, 0xb3, 0xa8, 0xe5, 0x07, 0xb2, 0xe6,
+	0xe4, 0x87, 0xa2, 0xc0, 0xee, 0x81, 0x95, 0x86, 0xd1, 0x8e, 0x38, 0xb0, 0x7b, 0x86, 0x76, 0x92,
+	0x07, 0x21, 0x97, 0xc7, 0xca, 0x10, 0xad, 0xd7, 0xb9, 0xa2, 0x69, 0xea, 0x79, 0xf9, 0x19, 0x89,
+	0x60, 0x65, 0x08, 0x61, 0xec, 0x2c, 0xe9, 0x94, 0xba, 0x7b, 0x83, 0xd7, 0xd2, 0xe9, 0x46, 0x0b,
+	0x1a, 0xa7, 0xfd, 0xd1, 0xc4, 0x77, 0xd7, 0xa0, 0x37, 0x4f, 0xb5, 0xaa, 0x84, 0xdd, 0xc7, 0xb0,
+	0x54, 0x4a, 0x30, 0xec, 0x37, 0x62, 0x31, 0x71, 0x8c, 0x65, 0x13, 0xfb, 0x8d, 0x24, 0xa9, 0x57,
+	0x23, 0x44, 0x35, 0x3a, 0x97, 0x7e, 0x4b, 0x4b, 0xcc, 0xcc, 0x12, 0xf7, 0x37, 0x03, 0xba, 0x0f,
+	0xf7, 0x1e, 0xef, 0x54, 0x36, 0x70, 0xeb, 0x85, 0x4f, 0x08, 0xa5, 0x5b, 0xb2, 0x79, 0xee, 0x2d,
+	0xd9, 0x9a, 0xb9, 0x25, 0xdd, 0x87, 0x2a, 0x2e, 0xdc, 0x8f, 0x46, 0x58, 0x2f, 0x76, 0x51, 0x78,
+	0x02, 0x9b, 0xa2, 0x60, 0x74, 0x97, 0xb9, 0x2a, 0xe9, 0xde, 0x87, 0x45, 0xad, 0x0c, 0xcf, 0x46,
+	0x18, 0x31, 0x90, 0x78, 0xd0, 0x6f, 0xf7, 0x7b, 0x03, 0x16, 0x71, 0xfb, 0xcb, 0xdc, 0xc9, 0xd6,
+	0x73, 0xbb, 0x93, 0xef, 0x15, 0x4e, 0x08, 0x38, 0xde, 0xc8, 0x36, 0x14, 0x40, 0xf4, 0x14, 0x20,
+	0x8a, 0xc1, 0x45, 0x5e, 0x29, 0x7f, 0xd4, 0xe0, 0x2a, 0xf7, 0x07, 0x7e, 0x10, 0xa5, 0x04, 0xd2,
+	0x80, 0xf2, 0xa2, 0x07, 0x0d, 0x31, 0x10, 0x19, 0xe2, 0x70, 0x22, 0x2a, 0x87, 0x0a, 0x25, 0x5b,
+	0x4c, 0x3d, 0x5b, 0x8a, 0x3c, 0xa8, 0x57, 0x4f, 0x8a, 0x8d, 0xf2, 0xa4, 0xe8, 0x42, 0x47, 0xc8,
+	0x6d, 0x1e, 0xf7, 0xc7, 0x47, 0x22, 0x4d, 0xda, 0x5c, 0xe3, 0x21, 0xd0, 0xe8, 0xc0, 0xfe, 0x34,
+	0x12, 0xc3, 0x64, 0x83, 0xe7, 0x34, 0xbb, 0x25, 0x2f, 0x06, 0xd1, 0x38, 0x67, 0x67, 0x36, 0x5a,
+	0xd5, 0x42, 0x65, 0x95, 0x42, 0x75, 0x1b, 0xda, 0x98, 0x26, 0xd8, 0xbf, 0x64, 0x2f, 0xbc, 0x36,
+	0xd3, 0xf3, 0x71, 0x91, 0xe7, 0x62, 0x79, 0x64, 0x6c, 0x25, 0x32, 0x7f, 0x19, 0x72, 0x0c, 0xc0,
+	0xde, 0x7c, 0x31, 0x4c, 0x7b, 0xd0, 0x18, 0x50, 0x8d, 0xc8, 0xa4, 0x20, 0x02, 0xa5, 0x8f, 0xc5,
+	0x80, 0x2c, 0xf1, 0x14, 0x14, 0xeb, 0x80, 0x71, 0x42, 0x38, 0x76, 0xb8, 0x71, 0x92, 0x9b, 0xd2,
+	0x2c, 0x4c, 0xc1, 0xd9, 0x98, 0xb6, 0xd8, 0xc3, 0x71, 0x58, 0x96, 0x95, 0xc2, 0xc1, 0xba, 0x23,
+	0xea, 0x51, 0xe0, 0x79, 0x23, 0x3f, 0x9b, 0xbf, 0x15, 0x16, 0xc6, 0x4c, 0xca, 0x87, 0x11, 0x01,
+	0x66, 0xf2, 0x82, 0xe1, 0xfe, 0x50, 0x93, 0xa3, 0x29, 0xdd, 0x25, 0x2f, 0xcf, 0xd7, 0x0e, 0x18,
+	0x31, 0x39, 0xda, 0xe1, 0x46, 0x8c, 0x54, 0x44, 0xce, 0x75, 0xb8, 0x11, 0xe5, 0x38, 0xb4, 0x2b,
+	0x71, 0xb0, 0xce, 0xc3, 0x01, 0xce, 0xc1, 0xc1, 0x2e, 0xe3, 0xf0, 0x09, 0x74, 0xf5, 0x1b, 0xfa,
+	0xac, 0x11, 0x4d, 0x78, 0x5d, 0x53, 0xbd, 0x26, 0xef, 0x44, 0x15, 0x19, 0x27, 0xee, 0x97, 0xb0,
+	0x54, 0xba, 0xb5, 0x2f, 0xbe, 0x5d, 0x9c, 0x6d, 0x27, 0xe1, 0xa9, 0x0b, 0x2a, 0x72, 0x7f, 0xac,
+	0x01, 0xc8, 0xd2, 0x3f, 0x88, 0x87, 0x17, 0x8c, 0x59, 0x51, 0xd9, 0xa6, 0x56, 0xd9, 0xb9, 0x19,
+	0xf5, 0xf9, 0xb1, 0x6c, 0xcc, 0xc6, 0xb2, 0xa9, 0xc5, 0xb2, 0xa5, 0xc5, 0xb2, 0x5d, 0x8e, 0xa5,
+	0x55, 0x19, 0x4b, 0x38, 0x2f, 0x96, 0xf6, 0x39, 0xb1, 0xec, 0x94, 0x63, 0xf9, 0x93, 0x01, 0xad,
+	0x83, 0x78, 0x48, 0xe5, 0x7d, 0xc9, 0x8c, 0x7e, 0xf1, 0x28, 0xb8, 0x23, 0xe8, 0xa8, 0x03, 0xda,
+	0x19, 0x57, 0x98, 0x18, 0x08, 0x44, 0x7e, 0xd4, 0xd2, 0x29, 0x7a, 0x8f, 0x3b, 0x24, 0x69, 0xff,
+	0x24, 0x92, 0x61, 0x2c, 0x18, 0xf3, 0x7d, 0x70, 0x9f, 0x1a, 0x60, 0xe3, 0x45, 0x72, 0x11, 0x5c,
+	0xac, 0x7f, 0x8b, 0x8b, 0xa5, 0xe1, 0x62, 0x69, 0xb8, 0x58, 0x55, 0xb8, 0xdc, 0xc9, 0x71, 0x11,
+	0xb7, 0xe2, 0x2d, 0x30, 0x4f, 0xe3, 0xa1, 0xbc, 0x13, 0x99, 0x72, 0x27, 0x4a, 0x57, 0x38, 0x2e,
+	0xbb, 0x5f, 0x08, 0x2d, 0x1c, 0x35, 0xf0, 0x36, 0xaa, 0x2c, 0x37, 0x7a, 0x35, 0x08, 0xbd, 0xc9,
+	0xc0, 0xf7, 0x24, 0xa2, 0x39, 0x8d, 0x3a, 0x62, 0x60, 0xc9, 0x6a, 0x43, 0x50, 0xee, 0xef, 0x06,
+	0x5c, 0xa3, 0x11, 0x10, 0xbd, 0xde, 0x08, 0x27, 0x63, 0xaf, 0x1f, 0x4f, 0x33, 0x14, 0x05, 0x2e,
+	0x86, 0x8a, 0x0b, 0xbd, 0x67, 0x84, 0x91, 0x7c, 0x12, 0xa9, 0x65, 0xef, 0x19, 0x19, 0x07, 0x6d,
+	0x20, 0x0a, 0x9f, 0x43, 0x4c, 0x4a, 0x91, 0x9c, 0x56, 0xec, 0xae, 0x6b, 0x99, 0x89, 0xaf, 0x30,
+	0xc1, 0xd1, 0xb8, 0x9f, 0x4e, 0x62, 0x5f, 0x76, 0xd1, 0x82, 0xc1, 0x56, 0xa1, 0x91, 0xd0, 0xdc,
+	0xd5, 0x5c, 0x36, 0x4b, 0x1f, 0x00, 0x19, 0x22, 0x5c, 0x48, 0xb8, 0xbf, 0x1a, 0x00, 0xc5, 0x94,
+	0xff, 0x9c, 0x3c, 0xb0, 0x2a, 0x3d, 0xb0, 0xaa, 0x3d, 0xb0, 0x2e, 0xe9, 0xc1, 0x57, 0xb0, 0x94,
+	0xb1, 0xb9, 0x1f, 0x85, 0x71, 0x9a, 0x54, 0x78, 0x51, 0x95, 0xcd, 0x37, 0xc1, 0x8a, 0x49, 0xd1,
+	0x8f, 0xb1, 0x0d, 0x62, 0xad, 0x15, 0x0c, 0xf7, 0x43, 0x31, 0x96, 0x3d, 0xec, 0x07, 0x23, 0x51,
+	0x98, 0xf3, 0x37, 0x57, 0xca, 0xb5, 0xa6, 0x95, 0xab, 0x1b, 0x82, 0xad, 0x7c, 0x16, 0xbd, 0x00,
+	0x84, 0x45, 0x3f, 0xa8, 0xe7, 0x1f, 0x08, 0xef, 0x66, 0x07, 0x8a, 0x82, 0x59, 0x85, 0xe6, 0xe0,
+	0x90, 0x46, 0x20, 0xa3, 0xe2, 0xdb, 0x8e, 0x4b, 0x01, 0xf7, 0xe7, 0x1a, 0x58, 0xd9, 0x14, 0xb9,
+	0xf1, 0x4a, 0x6e, 0x92, 0x57, 0x30, 0xf3, 0xb0, 0x3b, 0x39, 0x40, 0x62, 0x46, 0xbc, 0xa9, 0x02,
+	0x54, 0xae, 0xf1, 0x1c, 0xab, 0xa7, 0x06, 0x74, 0xf5, 0xaf, 0x4f, 0xf6, 0x3a, 0x34, 0x70, 0x8e,
+	0xcc, 0x06, 0xf6, 0xab, 0x33, 0xb3, 0x26, 0x17, 0xeb, 0xe8, 0xe5, 0x31, 0x46, 0x52, 0x20, 0x48,
+	0xbf, 0x15, 0x44, 0x4c, 0x0d, 0x11, 0x9c, 0xa2, 0x83, 0xa3, 0xb1, 0x1f, 0xef, 0xaa, 0x7d, 0x40,
+	0xe3, 0x9d, 0xdd, 0x0d, 0xdc, 0x5f, 0x0c, 0x58, 0xd2, 0x2d, 0x4d, 0xd8, 0x5d, 0x7a, 0x4d, 0xf1,
+	0x68, 0x92, 0x2e, 0x7f, 0x69, 0xe9, 0xb2, 0x5c, 0x11, 0xc4, 0x2c, 0x3f, 0xf5, 0xe3, 0x24, 0x08,
+	0xc7, 0x32, 0x47, 0x33, 0xb2, 0x32, 0xfc, 0xb7, 0x01, 0x86, 0xc1, 0xb8, 0x3f, 0xda, 0x24, 0x60,
+	0xea, 0x55, 0xc0, 0x28, 0x42, 0xee, 0x03, 0xe8, 0xcd, 0x7b, 0x14, 0x60, 0xab, 0x50, 0x47, 0x53,
+	0x64, 0x1a, 0x57, 0x58, 0x4b, 0x22, 0xee, 0x5b, 0xd0, 0x9b, 0xf7, 0x32, 0xa0, 0xda, 0x6f, 0x68,
+	0xf6, 0xbb, 0x1b, 0x33, 0x1a, 0xd9, 0x47, 0x98, 0xf8, 0x22, 0x37, 0xb4, 0x37, 0x98, 0xb2, 0x28,
+	0xc9, 0xe0, 0x97, 0xb9, 0x2d, 0xcb, 0x07, 0x05, 0x9e, 0x53, 0x01, 0x29, 0x36, 0xd7, 0x67, 0x30,
+	0x7f, 0xe6, 0x22, 0x5a, 0x95, 0x7e, 0xc0, 0x99, 0xe0, 0xa1, 0xc8, 0x61, 0x93, 0xfe, 0x90, 0x78,
+	0xfb, 0x9f, 0x01, 0x00, 0xe8, 0x07, 0xe8, 0xff, 0xa2, 0x18, 0x00, 0x00,
 }
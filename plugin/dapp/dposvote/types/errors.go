@@ -26,4 +26,7 @@ var (
 	ErrCycleNotAllowed          = errors.New("ErrCycleNotAllowed")
 	ErrVersionTopNNotExist      = errors.New("ErrVersionTopNNotExist")
 	ErrNotLegalTopN             = errors.New("ErrNotLegalTopN")
+	ErrCandidatorNotJailed      = errors.New("ErrCandidatorNotJailed")
+	ErrCandidatorJailed         = errors.New("ErrCandidatorJailed")
+	ErrUnjailTooEarly           = errors.New("ErrUnjailTooEarly")
 )
@@ -16,6 +16,8 @@ type CanonicalOnceCBInfo struct {
 	StopHeight int64  `json:"stopHeight,omitempty"`
 	StopHash   string `json:"stopHash,omitempty"`
 	Pubkey     string `json:"pubkey,omitempty"`
+	//出块统计为空时与旧版本的签名内容保持一致
+	Slots []*DposSlotStat `json:"slots,omitempty"`
 }
 
 // CanonicalCBInfo ...
@@ -25,6 +27,7 @@ func CanonicalCBInfo(cb *DposCBInfo) CanonicalOnceCBInfo {
 		StopHeight: cb.StopHeight,
 		StopHash:   cb.StopHash,
 		Pubkey:     cb.Pubkey,
+		Slots:      cb.Slots,
 	}
 }
 
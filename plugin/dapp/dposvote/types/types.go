@@ -19,6 +19,8 @@ func init() {
 
 func InitFork(cfg *types.Chain33Config) {
 	cfg.RegisterDappFork(DPosX, "Enable", 0)
+	//主网启用高度待定
+	cfg.RegisterDappFork(DPosX, ForkDposJail, types.MaxHeight)
 }
 
 func InitExecutor(cfg *types.Chain33Config) {
@@ -55,6 +57,7 @@ func (t *DPosType) GetTypeMap() map[string]int32 {
 		"RegistVrfRP":  DposVoteActionRegistVrfRP,
 		"RecordCB":     DposVoteActionRecordCB,
 		"RegistTopN":   DPosVoteActionRegistTopNCandidator,
+		"Unjail":       DposVoteActionUnjail,
 	}
 }
 
@@ -70,5 +73,7 @@ func (t *DPosType) GetLogMap() map[int64]*types.LogInfo {
 		TyLogVrfRPRegist:            {Ty: reflect.TypeOf(ReceiptVrf{}), Name: "TyLogVrfRPRegist"},
 		TyLogCBInfoRecord:           {Ty: reflect.TypeOf(ReceiptCB{}), Name: "TyLogCBInfoRecord"},
 		TyLogTopNCandidatorRegist:   {Ty: reflect.TypeOf(ReceiptTopN{}), Name: "TyLogTopNCandidatorRegist"},
+		TyLogCandicatorJailed:       {Ty: reflect.TypeOf(ReceiptCandicator{}), Name: "TyLogCandicatorJailed"},
+		TyLogCandicatorUnjailed:     {Ty: reflect.TypeOf(ReceiptCandicator{}), Name: "TyLogCandicatorUnjailed"},
 	}
 }
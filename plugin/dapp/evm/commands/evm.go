@@ -44,6 +44,7 @@ func EvmCmd() *cobra.Command {
 		createContractCmd(),
 		callContractCmd(),
 		abiCmd(),
+		eventCmd(),
 		estimateContractCmd(),
 		checkContractAddrCmd(),
 		evmDebugCmd(),
@@ -529,6 +530,88 @@ func callAbi(cmd *cobra.Command, args []string) {
 	}
 }
 
+func eventCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "event",
+		Short: "EVM contract event commands",
+		Args:  cobra.MinimumNArgs(1),
+	}
+
+	cmd.AddCommand(
+		listEventCmd(),
+		txEventCmd(),
+	)
+	return cmd
+}
+
+func listEventCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "list events of evm contract by address or topic",
+		Run:   listEvent,
+	}
+
+	cmd.Flags().StringP("address", "a", "", "evm contract address")
+	cmd.Flags().StringP("topic", "t", "", "event signature hash (topic0)")
+	cmd.Flags().StringP("primary", "p", "", "start from the primary key of last page")
+	cmd.Flags().Int32P("count", "c", 20, "event count")
+	cmd.Flags().Int32P("direction", "d", 0, "query direction, 0: desc, 1: asc")
+
+	return cmd
+}
+
+func listEvent(cmd *cobra.Command, args []string) {
+	addr, _ := cmd.Flags().GetString("address")
+	topic, _ := cmd.Flags().GetString("topic")
+	primary, _ := cmd.Flags().GetString("primary")
+	count, _ := cmd.Flags().GetInt32("count")
+	direction, _ := cmd.Flags().GetInt32("direction")
+
+	var req = evmtypes.EvmQueryEventReq{Address: addr, Topic: topic, PrimaryKey: primary, Count: count, Direction: direction}
+	var resp evmtypes.EvmQueryEventResp
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	query := sendQuery(rpcLaddr, "QueryEvents", &req, &resp)
+
+	if query {
+		printEvents(&resp)
+	}
+}
+
+func txEventCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tx",
+		Short: "list evm contract events of the transaction",
+		Run:   txEvent,
+	}
+
+	cmd.Flags().StringP("hash", "s", "", "transaction hash")
+	cmd.MarkFlagRequired("hash")
+
+	return cmd
+}
+
+func txEvent(cmd *cobra.Command, args []string) {
+	hash, _ := cmd.Flags().GetString("hash")
+
+	var req = evmtypes.EvmQueryTxEventReq{TxHash: hash}
+	var resp evmtypes.EvmQueryEventResp
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	query := sendQuery(rpcLaddr, "QueryTxEvents", &req, &resp)
+
+	if query {
+		printEvents(&resp)
+	}
+}
+
+func printEvents(resp *evmtypes.EvmQueryEventResp) {
+	data, err := json.MarshalIndent(resp, "", "  ")
+	if err != nil {
+		fmt.Println(resp.String())
+	} else {
+		fmt.Println(string(data))
+	}
+}
+
 func estimateContract(cmd *cobra.Command, args []string) {
 	code, _ := cmd.Flags().GetString("input")
 	name, _ := cmd.Flags().GetString("exec")
@@ -0,0 +1,126 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+以太坊格式交易的签名验证：
+签名数据为RLP编码的原始以太坊交易，公钥为从以太坊签名中恢复出的压缩公钥，
+验证时要求chain33交易和以太坊交易转换得到的交易完全一致
+*/
+
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	chain33crypto "github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+	"github.com/btcsuite/btcd/btcec"
+)
+
+func init() {
+	chain33crypto.Register(evmtypes.SignNameEthSecp256k1, &Driver{})
+	chain33crypto.RegisterType(evmtypes.SignNameEthSecp256k1, evmtypes.EthSecp256k1)
+}
+
+// Driver 以太坊签名驱动，只用于验证签名，签名需要在以太坊钱包中完成
+type Driver struct{}
+
+// GenKey 不支持生成私钥
+func (d Driver) GenKey() (chain33crypto.PrivKey, error) {
+	return nil, types.ErrNotSupport
+}
+
+// PrivKeyFromBytes 不支持私钥
+func (d Driver) PrivKeyFromBytes(b []byte) (chain33crypto.PrivKey, error) {
+	return nil, types.ErrNotSupport
+}
+
+// PubKeyFromBytes 从压缩格式的secp256k1公钥创建公钥对象
+func (d Driver) PubKeyFromBytes(b []byte) (chain33crypto.PubKey, error) {
+	if len(b) != btcec.PubKeyBytesLenCompressed {
+		return nil, types.ErrPubKeyLen
+	}
+	if _, err := btcec.ParsePubKey(b, btcec.S256()); err != nil {
+		return nil, err
+	}
+	return PubKeyEth(append([]byte{}, b...)), nil
+}
+
+// SignatureFromBytes 签名数据即为RLP编码的以太坊交易
+func (d Driver) SignatureFromBytes(b []byte) (chain33crypto.Signature, error) {
+	if _, err := evmtypes.DecodeEthTransaction(b); err != nil {
+		return nil, err
+	}
+	return SignatureEth(append([]byte{}, b...)), nil
+}
+
+// PubKeyEth 以太坊交易发送者的公钥
+type PubKeyEth []byte
+
+// Bytes 公钥字节数组
+func (pubKey PubKeyEth) Bytes() []byte {
+	return pubKey
+}
+
+// KeyString 公钥的十六进制字符串
+func (pubKey PubKeyEth) KeyString() string {
+	return hex.EncodeToString(pubKey)
+}
+
+// VerifyBytes 验证签名，msg为不包含签名的chain33交易
+func (pubKey PubKeyEth) VerifyBytes(msg []byte, sig chain33crypto.Signature) bool {
+	ethTx, err := evmtypes.DecodeEthTransaction(sig.Bytes())
+	if err != nil {
+		return false
+	}
+	pub, err := ethTx.RecoverPubKey()
+	if err != nil || !bytes.Equal(pub, pubKey) {
+		return false
+	}
+	var tx types.Transaction
+	if err := types.Decode(msg, &tx); err != nil {
+		return false
+	}
+	expect, err := ethTx.ToChain33Tx(string(tx.Execer))
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(types.Encode(expect), msg)
+}
+
+// Equals 比较公钥
+func (pubKey PubKeyEth) Equals(other chain33crypto.PubKey) bool {
+	if otherPub, ok := other.(PubKeyEth); ok {
+		return bytes.Equal(pubKey, otherPub)
+	}
+	return false
+}
+
+// SignatureEth 以太坊格式的签名，即RLP编码的以太坊交易
+type SignatureEth []byte
+
+// Bytes 签名字节数组
+func (sig SignatureEth) Bytes() []byte {
+	return sig
+}
+
+// IsZero 是否为空签名
+func (sig SignatureEth) IsZero() bool {
+	return len(sig) == 0
+}
+
+// String 签名的十六进制字符串
+func (sig SignatureEth) String() string {
+	return hex.EncodeToString(sig)
+}
+
+// Equals 比较签名
+func (sig SignatureEth) Equals(other chain33crypto.Signature) bool {
+	if otherSig, ok := other.(SignatureEth); ok {
+		return bytes.Equal(sig, otherSig)
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package crypto
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/33cn/chain33/types"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/assert"
+)
+
+func signEthTx(t *testing.T, ethTx *evmtypes.EthTransaction) []byte {
+	key, err := btcec.NewPrivateKey(btcec.S256())
+	assert.Nil(t, err)
+	sig, err := btcec.SignCompact(btcec.S256(), key, ethTx.SigHash(), false)
+	assert.Nil(t, err)
+	ethTx.V = big.NewInt(int64(sig[0]))
+	ethTx.R = new(big.Int).SetBytes(sig[1:33])
+	ethTx.S = new(big.Int).SetBytes(sig[33:])
+	return ethTx.Encode()
+}
+
+func TestEthSignature(t *testing.T) {
+	// 加载执行器类型，签名类型由evm执行器提供
+	types.NewChain33Config(types.GetDefaultCfgstring())
+
+	to := make([]byte, 20)
+	to[19] = 1
+	ethTx := &evmtypes.EthTransaction{
+		GasPrice: big.NewInt(2),
+		Gas:      100000,
+		To:       to,
+		Value:    big.NewInt(100),
+		Data:     []byte{1, 2, 3, 4},
+	}
+	raw := signEthTx(t, ethTx)
+	tx, _, err := evmtypes.NewChain33TxFromEth(raw, "user.p.test.evm")
+	assert.Nil(t, err)
+	assert.Equal(t, evmtypes.SignNameEthSecp256k1, types.GetSignName(string(tx.Execer), int(tx.Signature.Ty)))
+	assert.True(t, tx.CheckSign())
+
+	// 交易内容和以太坊交易不一致时验证失败
+	tx.Fee++
+	assert.False(t, tx.CheckSign())
+	tx.Fee--
+	tx.Execer = []byte("user.p.test.coins")
+	assert.False(t, tx.CheckSign())
+	tx.Execer = []byte("user.p.test.evm")
+
+	// 公钥和签名不匹配
+	other, _, err := evmtypes.NewChain33TxFromEth(signEthTx(t, ethTx), "user.p.test.evm")
+	assert.Nil(t, err)
+	tx.Signature.Pubkey = other.Signature.Pubkey
+	assert.False(t, tx.CheckSign())
+
+	d := Driver{}
+	_, err = d.GenKey()
+	assert.Equal(t, types.ErrNotSupport, err)
+	_, err = d.SignatureFromBytes([]byte{1, 2, 3})
+	assert.NotNil(t, err)
+}
@@ -112,6 +112,78 @@ func Unpack(data []byte, methodName, abiData string) (output string, err error)
 	return string(jsondata), err
 }
 
+// UnpackEvent 将合约事件日志按照ABI的格式序列化为json
+// topics 事件主题，第一个主题为事件签名哈希
+// data 事件中非indexed参数的数据
+// abiData 完整的ABI定义
+func UnpackEvent(topics []common.Hash, data []byte, abiData string) (eventName, output string, err error) {
+	if len(topics) == 0 {
+		return eventName, output, errors.New("anonymous event is not supported")
+	}
+	abi, err := JSON(strings.NewReader(abiData))
+	if err != nil {
+		return eventName, output, err
+	}
+
+	var event *Event
+	for _, e := range abi.Events {
+		if e.ID() == topics[0] {
+			event = &e
+			break
+		}
+	}
+	if event == nil {
+		return eventName, output, fmt.Errorf("event %v not exists", topics[0].Hex())
+	}
+
+	values, err := event.Inputs.UnpackValues(data)
+	if err != nil {
+		return eventName, output, err
+	}
+
+	outputs := []*Param{}
+	topicIndex, valueIndex := 1, 0
+	for _, arg := range event.Inputs {
+		var value interface{}
+		if arg.Indexed {
+			if topicIndex >= len(topics) {
+				return eventName, output, fmt.Errorf("event %v topics not match", event.Name)
+			}
+			value, err = unpackTopic(arg.Type, topics[topicIndex])
+			if err != nil {
+				return eventName, output, err
+			}
+			topicIndex++
+		} else {
+			value = values[valueIndex]
+			valueIndex++
+		}
+		outputs = append(outputs, &Param{Name: arg.Name, Type: arg.Type.String(), Value: value})
+	}
+
+	jsondata, err := json.Marshal(outputs)
+	if err != nil {
+		return eventName, output, err
+	}
+	return event.Name, string(jsondata), err
+}
+
+// 解析indexed参数，动态类型的参数在topic中只保存了哈希值，直接返回哈希
+func unpackTopic(typ Type, topic common.Hash) (interface{}, error) {
+	switch typ.T {
+	case StringTy, BytesTy, SliceTy, ArrayTy:
+		return topic.Hex(), nil
+	}
+	value, err := toGoType(0, typ, topic.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if addr, ok := value.(common.Hash160Address); ok {
+		return addr.ToAddress().String(), nil
+	}
+	return value, nil
+}
+
 // Param 返回值参数结构定义
 type Param struct {
 	// Name 参数名称
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
@@ -81,6 +82,32 @@ func TestABI_Unpack(t *testing.T) {
 	}
 }
 
+func TestABI_UnpackEvent(t *testing.T) {
+	abiData := `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"memo","type":"string"},{"indexed":false,"name":"value","type":"uint256"},{"indexed":false,"name":"ok","type":"bool"}],"name":"Deposit","type":"event"}]`
+	abi, err := JSON(strings.NewReader(abiData))
+	assert.NoError(t, err)
+
+	from := common.StringToAddress("1JRNjdEqp4LJ5fqycUBm9ayCKSeeskgMKR")
+	memo := common.BytesToHash(common.FromHex("0x1234"))
+	topics := []common.Hash{abi.Events["Deposit"].ID(), common.BytesToHash(from.Bytes()), memo}
+	data := common.FromHex("0x00000000000000000000000000000000000000000000000000000000000000210000000000000000000000000000000000000000000000000000000000000001")
+
+	name, output, err := UnpackEvent(topics, data, abiData)
+	assert.NoError(t, err)
+	assert.Equal(t, "Deposit", name)
+	assert.Equal(t, `[{"name":"from","type":"address","value":"1JRNjdEqp4LJ5fqycUBm9ayCKSeeskgMKR"},{"name":"memo","type":"string","value":"`+memo.Hex()+`"},{"name":"value","type":"uint256","value":33},{"name":"ok","type":"bool","value":true}]`, output)
+
+	// 事件签名不匹配
+	_, _, err = UnpackEvent([]common.Hash{memo}, data, abiData)
+	assert.Error(t, err)
+	// 缺少indexed参数
+	_, _, err = UnpackEvent(topics[:2], data, abiData)
+	assert.Error(t, err)
+	// 匿名事件
+	_, _, err = UnpackEvent(nil, data, abiData)
+	assert.Error(t, err)
+}
+
 func TestProcFuncCall(t *testing.T) {
 	for _, test := range []struct {
 		input    string
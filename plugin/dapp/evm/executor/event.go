@@ -0,0 +1,199 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"fmt"
+
+	"github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/common/db/table"
+	log "github.com/33cn/chain33/common/log/log15"
+	drivers "github.com/33cn/chain33/system/dapp"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/abi"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+)
+
+// 合约事件保存在localdb中，主键为区块高度+交易序号+事件序号，
+// 另外按照合约地址、事件主题（topic0）、合约地址+事件主题以及交易哈希建立索引
+const (
+	eventIndexAddr      = "addr"
+	eventIndexTopic     = "topic"
+	eventIndexAddrTopic = "addr_topic"
+	eventIndexTx        = "tx"
+
+	// 单次查询返回的事件数量
+	defaultEventCount = 20
+	maxEventCount     = 100
+)
+
+var optEvmEvent = &table.Option{
+	Prefix:  "LODB-evm",
+	Name:    "event",
+	Primary: "heightindex",
+	Index:   []string{eventIndexAddr, eventIndexTopic, eventIndexAddrTopic, eventIndexTx},
+}
+
+// NewEventTable 新建合约事件表
+func NewEventTable(kvdb db.KV) *table.Table {
+	rowmeta := NewEventRow()
+	table, err := table.NewTable(rowmeta, kvdb, optEvmEvent)
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
+// EventRow 合约事件表中的一行数据
+type EventRow struct {
+	*evmtypes.EVMEventRecord
+}
+
+// NewEventRow 新建一个合约事件行对象
+func NewEventRow() *EventRow {
+	return &EventRow{EVMEventRecord: &evmtypes.EVMEventRecord{}}
+}
+
+// CreateRow 新建数据行
+func (r *EventRow) CreateRow() *table.Row {
+	return &table.Row{Data: &evmtypes.EVMEventRecord{}}
+}
+
+// SetPayload 设置数据
+func (r *EventRow) SetPayload(data types.Message) error {
+	if d, ok := data.(*evmtypes.EVMEventRecord); ok {
+		r.EVMEventRecord = d
+		return nil
+	}
+	return types.ErrTypeAsset
+}
+
+// Get 按照indexName查询索引值
+func (r *EventRow) Get(key string) ([]byte, error) {
+	switch key {
+	case "heightindex":
+		return []byte(fmt.Sprintf("%s:%05d", drivers.HeightIndexStr(r.Height, int64(r.TxIndex)), r.Log.GetIndex())), nil
+	case eventIndexAddr:
+		return []byte(r.Log.GetAddress()), nil
+	case eventIndexTopic:
+		return []byte(eventTopic0(r.Log)), nil
+	case eventIndexAddrTopic:
+		return []byte(r.Log.GetAddress() + ":" + eventTopic0(r.Log)), nil
+	case eventIndexTx:
+		return []byte(r.TxHash), nil
+	}
+	return nil, types.ErrNotFound
+}
+
+// 匿名事件没有topic0
+func eventTopic0(eventLog *evmtypes.EVMContractEventLog) string {
+	if len(eventLog.GetTopics()) == 0 {
+		return ""
+	}
+	return common.BytesToHash(eventLog.GetTopics()[0]).Hex()
+}
+
+// 将交易回执中的合约事件写入localdb
+func (evm *EVMExecutor) saveEvents(tx *types.Transaction, logs []*types.ReceiptLog, index int) ([]*types.KeyValue, error) {
+	eventTable := NewEventTable(evm.GetLocalDB())
+	for _, logItem := range logs {
+		if logItem.Ty != evmtypes.TyLogEVMEventData {
+			continue
+		}
+		var eventLog evmtypes.EVMContractEventLog
+		err := types.Decode(logItem.Log, &eventLog)
+		if err != nil {
+			return nil, err
+		}
+		record := &evmtypes.EVMEventRecord{
+			Log:     &eventLog,
+			TxHash:  common.Bytes2Hex(tx.Hash()),
+			Height:  evm.GetHeight(),
+			TxIndex: int32(index),
+		}
+		err = eventTable.Add(record)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return eventTable.Save()
+}
+
+// 按照索引分页查询合约事件，返回查询结果以及下一页的起始主键
+func listEvents(localdb db.KV, indexName, indexValue, primaryKey string, count, direction int32) ([]*evmtypes.EVMEventRecord, string, error) {
+	eventTable := NewEventTable(localdb)
+	var rows []*table.Row
+	var err error
+	if primaryKey == "" {
+		// 索引值后面加上分隔符，避免匹配到以indexValue为前缀的其它索引值
+		rows, err = eventTable.ListIndex(indexName, []byte(indexValue+"-"), nil, count, direction)
+	} else {
+		rows, err = eventTable.ListIndex(indexName, []byte(indexValue), []byte(primaryKey), count, direction)
+	}
+	if err == types.ErrNotFound {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	var records []*evmtypes.EVMEventRecord
+	for _, row := range rows {
+		record := row.Data.(*evmtypes.EVMEventRecord)
+		value, err := (&EventRow{EVMEventRecord: record}).Get(indexName)
+		if err != nil {
+			return nil, "", err
+		}
+		if string(value) != indexValue {
+			return records, "", nil
+		}
+		records = append(records, record)
+	}
+	if len(rows) < int(count) {
+		return records, "", nil
+	}
+	return records, string(rows[len(rows)-1].Primary), nil
+}
+
+// 将合约事件转换为查询结果，如果合约绑定了ABI，则按ABI解析事件名称和参数
+func (evm *EVMExecutor) decodeEvents(records []*evmtypes.EVMEventRecord) []*evmtypes.EvmEvent {
+	abis := make(map[string]string)
+	var events []*evmtypes.EvmEvent
+	for _, record := range records {
+		eventLog := record.GetLog()
+		event := &evmtypes.EvmEvent{
+			Address:  eventLog.GetAddress(),
+			Data:     common.Bytes2Hex(eventLog.GetData()),
+			TxHash:   record.GetTxHash(),
+			Height:   record.GetHeight(),
+			TxIndex:  record.GetTxIndex(),
+			LogIndex: eventLog.GetIndex(),
+		}
+		var topics []common.Hash
+		for _, topic := range eventLog.GetTopics() {
+			topics = append(topics, common.BytesToHash(topic))
+			event.Topics = append(event.Topics, common.BytesToHash(topic).Hex())
+		}
+
+		abiData, ok := abis[eventLog.GetAddress()]
+		if !ok {
+			abiData = evm.mStateDB.GetAbi(eventLog.GetAddress())
+			abis[eventLog.GetAddress()] = abiData
+		}
+		if len(abiData) > 0 {
+			name, jsonData, err := abi.UnpackEvent(topics, eventLog.GetData(), abiData)
+			if err != nil {
+				// 解析失败不影响查询，只返回原始数据
+				log.Debug("unpack evm event error", "address", eventLog.GetAddress(), "error", err)
+			} else {
+				event.EventName = name
+				event.JsonData = jsonData
+			}
+		}
+		events = append(events, event)
+	}
+	return events
+}
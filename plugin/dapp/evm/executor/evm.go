@@ -30,9 +30,19 @@ var (
 
 var driverName = evmtypes.ExecutorName
 
+type subConfig struct {
+	// ChainID CHAINID指令返回的链ID
+	ChainID int64 `json:"chainID"`
+}
+
+var subCfg subConfig
+
 // Init 初始化本合约对象
 func Init(name string, cfg *types.Chain33Config, sub []byte) {
 	driverName = name
+	if sub != nil {
+		types.MustDecode(sub, &subCfg)
+	}
 	drivers.Register(cfg, driverName, newEVMDriver, cfg.GetDappFork(driverName, evmtypes.EVMEnable))
 	EvmAddress = address.ExecAddress(cfg.ExecName(name))
 	// 初始化硬分叉数据
@@ -143,6 +153,22 @@ func (evm *EVMExecutor) getNewAddr(txHash []byte) common.Address {
 
 // CheckTx 校验交易
 func (evm *EVMExecutor) CheckTx(tx *types.Transaction, index int) error {
+	if tx.GetSignature().GetTy() != evmtypes.EthSecp256k1 {
+		return nil
+	}
+	// 以太坊格式的交易需要在分叉之后才能执行，未配置链ID时不启用，避免交易在其他链上被重放
+	cfg := evm.GetAPI().GetConfig()
+	if subCfg.ChainID <= 0 || !cfg.IsDappFork(evm.GetHeight(), driverName, evmtypes.ForkEVMEthTx) {
+		return types.ErrNotSupport
+	}
+	ethTx, err := evmtypes.DecodeEthTransaction(tx.GetSignature().GetSignature())
+	if err != nil {
+		return err
+	}
+	// 只接受EIP-155签名且链ID和本链一致的交易
+	if ethTx.ChainID() != subCfg.ChainID {
+		return evmtypes.ErrEthTxChainID
+	}
 	return nil
 }
 
@@ -176,6 +202,7 @@ func (evm *EVMExecutor) NewEVMContext(msg *common.Message) runtime.Context {
 		BlockNumber: new(big.Int).SetInt64(evm.GetHeight()),
 		Time:        new(big.Int).SetInt64(evm.GetBlockTime()),
 		Difficulty:  new(big.Int).SetUint64(evm.GetDifficulty()),
+		ChainID:     new(big.Int).SetInt64(subCfg.ChainID),
 		GasLimit:    msg.GasLimit(),
 		GasPrice:    msg.GasPrice(),
 	}
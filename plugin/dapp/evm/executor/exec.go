@@ -137,6 +137,10 @@ func (evm *EVMExecutor) innerExec(msg *common.Message, txHash []byte, index int,
 	}
 	logs = append(logs, &types.ReceiptLog{Ty: evmtypes.TyLogCallContract, Log: types.Encode(contractReceipt)})
 	logs = append(logs, evm.mStateDB.GetReceiptLogs(contractAddr.String())...)
+	if cfg.IsDappFork(evm.GetHeight(), "evm", evmtypes.ForkEVMEventLog) {
+		// 将合约生成的事件日志保存到交易回执中
+		logs = append(logs, evm.mStateDB.GetEventLogs()...)
+	}
 
 	if cfg.IsDappFork(evm.GetHeight(), "evm", evmtypes.ForkEVMKVHash) {
 		// 将执行时生成的合约状态数据变更信息也计算哈希并保存
@@ -15,16 +15,19 @@ func (evm *EVMExecutor) ExecDelLocal(tx *types.Transaction, receipt *types.Recei
 	if err != nil {
 		return nil, err
 	}
+	if tx.GetSignature().GetTy() == evmtypes.EthSecp256k1 {
+		set.KV = append(set.KV, &types.KeyValue{Key: ethTxHashKey(evmtypes.EthTxHash(tx))})
+	}
 	if receipt.GetTy() != types.ExecOk {
 		return set, nil
 	}
 	cfg := evm.GetAPI().GetConfig()
-	if cfg.IsDappFork(evm.GetHeight(), "evm", evmtypes.ForkEVMState) {
+	if cfg.IsDappFork(evm.GetHeight(), "evm", evmtypes.ForkEVMState) || cfg.IsDappFork(evm.GetHeight(), "evm", evmtypes.ForkEVMEventLog) {
 		kvs, err := evm.DelRollbackKV(tx, []byte(evmtypes.ExecutorName))
 		if err != nil {
 			return nil, err
 		}
-		set.KV = kvs
+		set.KV = append(set.KV, kvs...)
 	}
 	return set, err
 }
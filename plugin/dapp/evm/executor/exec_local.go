@@ -17,6 +17,10 @@ func (evm *EVMExecutor) ExecLocal(tx *types.Transaction, receipt *types.ReceiptD
 	if err != nil {
 		return nil, err
 	}
+	if tx.GetSignature().GetTy() == evmtypes.EthSecp256k1 {
+		// 以太坊格式的交易按以太坊交易哈希建立索引，以太坊工具使用以太坊交易哈希查询回执
+		set.KV = append(set.KV, &types.KeyValue{Key: ethTxHashKey(evmtypes.EthTxHash(tx)), Value: tx.Hash()})
+	}
 	if receipt.GetTy() != types.ExecOk {
 		return set, nil
 	}
@@ -43,6 +47,19 @@ func (evm *EVMExecutor) ExecLocal(tx *types.Transaction, receipt *types.ReceiptD
 			}
 		}
 	}
+	if cfg.IsDappFork(evm.GetHeight(), "evm", evmtypes.ForkEVMEventLog) {
+		// 为合约事件建立索引，方便按合约地址和事件主题查询
+		kvs, err := evm.saveEvents(tx, receipt.Logs, index)
+		if err != nil {
+			return set, err
+		}
+		set.KV = append(set.KV, kvs...)
+	}
 	set.KV = evm.AddRollbackKV(tx, []byte(evmtypes.ExecutorName), set.KV)
 	return set, err
 }
+
+// 以太坊交易哈希到chain33交易哈希的索引
+func ethTxHashKey(hash []byte) []byte {
+	return append([]byte("LODB-evm-ethtx:"), hash...)
+}
@@ -154,7 +154,14 @@ func (evm *EVMExecutor) Query_Query(in *evmtypes.EvmQueryReq) (types.Message, er
 		caller = common.ExecAddress(cfg.ExecName(evmtypes.ExecutorName))
 	}
 
-	msg := common.NewMessage(caller, common.StringToAddress(in.Address), 0, 0, evmtypes.MaxGasLimit, 1, nil, "estimateGas", in.Input)
+	// 以0x开头的输入为十六进制的原始调用数据，否则按ABI格式调用
+	var data []byte
+	abiCall := in.Input
+	if strings.HasPrefix(in.Input, "0x") {
+		data = common.FromHex(in.Input)
+		abiCall = ""
+	}
+	msg := common.NewMessage(caller, common.StringToAddress(in.Address), 0, 0, evmtypes.MaxGasLimit, 1, data, "estimateGas", abiCall)
 	txHash := common.BigToHash(big.NewInt(evmtypes.MaxGasLimit)).Bytes()
 
 	receipt, err := evm.innerExec(msg, txHash, 1, evmtypes.MaxGasLimit, true)
@@ -186,3 +193,115 @@ func (evm *EVMExecutor) Query_QueryABI(in *evmtypes.EvmQueryAbiReq) (types.Messa
 
 	return &evmtypes.EvmQueryAbiResp{Address: in.GetAddress(), Abi: abiData}, nil
 }
+
+// Query_GetCode 此方法用来查询合约代码，合约不存在时返回空代码
+func (evm *EVMExecutor) Query_GetCode(in *evmtypes.EvmQueryCodeReq) (types.Message, error) {
+	evm.CheckInit()
+
+	addr := common.StringToAddress(in.GetAddress())
+	if addr == nil {
+		return nil, fmt.Errorf("invalid address: %v", in.GetAddress())
+	}
+
+	ret := &evmtypes.EvmQueryCodeResp{Address: in.GetAddress()}
+	ret.Code = evm.mStateDB.GetCode(addr.String())
+	if len(ret.Code) > 0 {
+		ret.CodeHash = evm.mStateDB.GetCodeHash(addr.String()).Bytes()
+	}
+	return ret, nil
+}
+
+// Query_GetEthTxHash 按以太坊交易哈希查询对应的chain33交易哈希
+func (evm *EVMExecutor) Query_GetEthTxHash(in *types.ReqHash) (types.Message, error) {
+	hash, err := evm.GetLocalDB().Get(ethTxHashKey(in.GetHash()))
+	if err != nil {
+		return nil, err
+	}
+	if len(hash) == 0 {
+		return nil, types.ErrNotFound
+	}
+	return &types.ReplyHash{Hash: hash}, nil
+}
+
+// Query_GetStorageAt 此方法用来查询合约指定存储槽中的数据
+func (evm *EVMExecutor) Query_GetStorageAt(in *evmtypes.EvmQueryStorageReq) (types.Message, error) {
+	evm.CheckInit()
+
+	addr := common.StringToAddress(in.GetAddress())
+	if addr == nil {
+		return nil, fmt.Errorf("invalid address: %v", in.GetAddress())
+	}
+	key, err := common.HexToBytes(in.GetKey())
+	if err != nil || len(key) > common.HashLength {
+		return nil, types.ErrInvalidParam
+	}
+
+	value := evm.mStateDB.GetState(addr.String(), common.BytesToHash(key))
+	return &evmtypes.EvmQueryStorageResp{Address: in.GetAddress(), Key: in.GetKey(), Value: value.Hex()}, nil
+}
+
+// Query_QueryEvents 按照合约地址和事件主题（topic0）分页查询合约事件，并使用合约绑定的ABI解析事件
+func (evm *EVMExecutor) Query_QueryEvents(in *evmtypes.EvmQueryEventReq) (types.Message, error) {
+	evm.CheckInit()
+	if in.GetCount() < 0 || in.GetCount() > maxEventCount || (in.GetDirection() != 0 && in.GetDirection() != 1) {
+		return nil, types.ErrInvalidParam
+	}
+	count := in.GetCount()
+	if count == 0 {
+		count = defaultEventCount
+	}
+
+	var addr, topic string
+	if len(in.GetAddress()) > 0 {
+		nAddr := common.StringToAddress(in.GetAddress())
+		if nAddr == nil {
+			return nil, fmt.Errorf("invalid address: %v", in.GetAddress())
+		}
+		addr = nAddr.String()
+	}
+	if len(in.GetTopic()) > 0 {
+		topic = common.BytesToHash(common.FromHex(in.GetTopic())).Hex()
+	}
+
+	var indexName, indexValue string
+	switch {
+	case len(addr) > 0 && len(topic) > 0:
+		indexName, indexValue = eventIndexAddrTopic, addr+":"+topic
+	case len(addr) > 0:
+		indexName, indexValue = eventIndexAddr, addr
+	case len(topic) > 0:
+		indexName, indexValue = eventIndexTopic, topic
+	default:
+		return nil, types.ErrInvalidParam
+	}
+
+	records, primaryKey, err := listEvents(evm.GetLocalDB(), indexName, indexValue, in.GetPrimaryKey(), count, in.GetDirection())
+	if err != nil {
+		return nil, err
+	}
+	return &evmtypes.EvmQueryEventResp{Events: evm.decodeEvents(records), PrimaryKey: primaryKey}, nil
+}
+
+// Query_QueryTxEvents 查询交易中生成的所有合约事件，并使用合约绑定的ABI解析事件
+func (evm *EVMExecutor) Query_QueryTxEvents(in *evmtypes.EvmQueryTxEventReq) (types.Message, error) {
+	evm.CheckInit()
+	hash, err := common.HexToBytes(in.GetTxHash())
+	if err != nil || len(hash) == 0 {
+		return nil, types.ErrInvalidParam
+	}
+
+	var records []*evmtypes.EVMEventRecord
+	primaryKey := ""
+	for {
+		list, next, err := listEvents(evm.GetLocalDB(), eventIndexTx, common.Bytes2Hex(hash), primaryKey, maxEventCount, 1)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, list...)
+		if next == "" {
+			break
+		}
+		primaryKey = next
+	}
+	return &evmtypes.EvmQueryEventResp{Events: evm.decodeEvents(records)}, nil
+}
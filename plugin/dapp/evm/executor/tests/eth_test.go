@@ -0,0 +1,124 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tests
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/33cn/chain33/client"
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	_ "github.com/33cn/plugin/plugin/dapp/evm/crypto"
+	evm "github.com/33cn/plugin/plugin/dapp/evm/executor"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEthTx(t *testing.T, chainID int64) *types.Transaction {
+	key, _ := btcec.NewPrivateKey(btcec.S256())
+	ethTx := &evmtypes.EthTransaction{GasPrice: big.NewInt(1), Gas: 100000, Value: big.NewInt(0), Data: depositCode()}
+	if chainID > 0 {
+		ethTx.V = big.NewInt(chainID*2 + 35)
+	}
+	sig, err := btcec.SignCompact(btcec.S256(), key, ethTx.SigHash(), false)
+	assert.Nil(t, err)
+	ethTx.V = big.NewInt(int64(sig[0]) - 27)
+	if chainID > 0 {
+		ethTx.V.Add(ethTx.V, big.NewInt(chainID*2+35))
+	} else {
+		ethTx.V.Add(ethTx.V, big.NewInt(27))
+	}
+	ethTx.R, ethTx.S = new(big.Int).SetBytes(sig[1:33]), new(big.Int).SetBytes(sig[33:])
+	tx, _, err := evmtypes.NewChain33TxFromEth(ethTx.Encode(), "evm")
+	assert.Nil(t, err)
+	return tx
+}
+
+func TestEthCompatible(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	api, _ := client.New(q.Client(), nil)
+
+	privKey := getPrivKey()
+	stateDB := buildStateDB(getAddr(privKey).String(), 500000000)
+	dir, ldb, localDB := util.CreateTestDB()
+	defer util.CloseTestDB(dir, ldb)
+
+	inst := evm.NewEVMExecutor()
+	inst.SetAPI(api)
+	inst.SetStateDB(stateDB)
+	inst.SetLocalDB(localDB)
+	inst.SetEnv(10, 1539918074, 1)
+
+	// 以太坊格式的交易只接受链ID和本链一致的EIP-155签名交易
+	tx := newEthTx(t, 33)
+	assert.True(t, tx.CheckSign())
+	assert.Nil(t, inst.CheckTx(tx, 0))
+	assert.Equal(t, evmtypes.ErrEthTxChainID, inst.CheckTx(newEthTx(t, 0), 0))
+	assert.Equal(t, evmtypes.ErrEthTxChainID, inst.CheckTx(newEthTx(t, 5), 0))
+
+	receipt, err := inst.Exec(tx, 0)
+	assert.Nil(t, err)
+	for _, kv := range receipt.KV {
+		stateDB.Set(kv.Key, kv.Value)
+	}
+	var contract evmtypes.ReceiptEVMContract
+	for _, item := range receipt.Logs {
+		if item.Ty == evmtypes.TyLogCallContract {
+			assert.Nil(t, types.Decode(item.Log, &contract))
+		}
+	}
+	assert.Equal(t, tx.From(), contract.Caller)
+	// 以太坊签名恢复出的发送者和执行时的调用者地址一致
+	ethTx, err := evmtypes.DecodeEthTransaction(tx.GetSignature().GetSignature())
+	assert.Nil(t, err)
+	sender, err := ethTx.Sender()
+	assert.Nil(t, err)
+	caller := new(address.Address)
+	caller.SetBytes(sender)
+	assert.Equal(t, contract.Caller, caller.String())
+
+	// 按以太坊交易哈希建立索引，回滚时删除
+	receiptData := &types.ReceiptData{Ty: receipt.Ty, Logs: receipt.Logs}
+	set, err := inst.ExecLocal(tx, receiptData, 0)
+	assert.Nil(t, err)
+	for _, kv := range set.KV {
+		assert.Nil(t, localDB.Set(kv.Key, kv.Value))
+	}
+	resp, err := inst.Query("GetEthTxHash", types.Encode(&types.ReqHash{Hash: evmtypes.EthTxHash(tx)}))
+	assert.Nil(t, err)
+	assert.Equal(t, tx.Hash(), resp.(*types.ReplyHash).Hash)
+	set, err = inst.ExecDelLocal(tx, receiptData, 0)
+	assert.Nil(t, err)
+	for _, kv := range set.KV {
+		assert.Nil(t, localDB.Set(kv.Key, kv.Value))
+	}
+	_, err = inst.Query("GetEthTxHash", types.Encode(&types.ReqHash{Hash: evmtypes.EthTxHash(tx)}))
+	assert.Equal(t, types.ErrNotFound, err)
+
+	resp, err = inst.Query("GetCode", types.Encode(&evmtypes.EvmQueryCodeReq{Address: contract.ContractAddr}))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x2a}, resp.(*evmtypes.EvmQueryCodeResp).Code)
+
+	resp, err = inst.Query("GetStorageAt", types.Encode(&evmtypes.EvmQueryStorageReq{Address: contract.ContractAddr, Key: "0x01"}))
+	assert.Nil(t, err)
+	assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000000", resp.(*evmtypes.EvmQueryStorageResp).Value)
+
+	// 十六进制的原始调用数据直接传给合约，合约代码不是有效指令时返回执行错误
+	resp, err = inst.Query("Query", types.Encode(&evmtypes.EvmQueryReq{Address: contract.ContractAddr, Input: "0x12345678"}))
+	assert.Nil(t, err)
+	assert.Equal(t, "invalid OpCode 0x2a", resp.(*evmtypes.EvmQueryResp).JsonData)
+
+	// 分叉之前不支持以太坊格式的交易
+	chainAPI, _ := client.New(func() queue.Client { q := queue.New("channel"); q.SetConfig(chainTestCfg); return q.Client() }(), nil)
+	inst.SetAPI(chainAPI)
+	assert.Equal(t, types.ErrNotSupport, inst.CheckTx(tx, 0))
+	assert.Nil(t, inst.CheckTx(&types.Transaction{Execer: []byte("evm"), To: address.ExecAddress("evm")}, 0))
+}
@@ -0,0 +1,119 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/33cn/chain33/client"
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	evm "github.com/33cn/plugin/plugin/dapp/evm/executor"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common/crypto"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const depositAbi = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Deposit","type":"event"}]`
+
+// 构造函数中触发Deposit(msg.sender, 42)事件，并返回1个字节的合约代码
+func depositCode() []byte {
+	topic := crypto.Keccak256([]byte("Deposit(address,uint256)"))
+	code := common.FromHex("602a600052" + "33" + "7f")
+	code = append(code, topic...)
+	return append(code, common.FromHex("60206000a2"+"6001601ff3")...)
+}
+
+func TestContractEvents(t *testing.T) {
+	// 本地配置中所有分叉高度均为0
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	api, _ := client.New(q.Client(), nil)
+
+	privKey := getPrivKey()
+	caller := getAddr(privKey).String()
+	stateDB := buildStateDB(caller, 500000000)
+	dir, ldb, localDB := util.CreateTestDB()
+	defer util.CloseTestDB(dir, ldb)
+
+	inst := evm.NewEVMExecutor()
+	inst.SetAPI(api)
+	inst.SetStateDB(stateDB)
+	inst.SetLocalDB(localDB)
+	inst.SetEnv(10, 1539918074, 1)
+
+	deploy := func(index int) (*types.Transaction, *types.ReceiptData) {
+		action := evmtypes.EVMContractAction{Code: depositCode(), Abi: depositAbi}
+		tx := &types.Transaction{Execer: []byte("evm"), Payload: types.Encode(&action), Fee: evmtypes.MaxGasLimit, Nonce: int64(index), To: address.ExecAddress(cfg.ExecName("evm"))}
+		tx.Sign(types.SECP256K1, privKey)
+		receipt, err := inst.Exec(tx, index)
+		assert.Nil(t, err)
+		for _, kv := range receipt.KV {
+			stateDB.Set(kv.Key, kv.Value)
+		}
+		receiptData := &types.ReceiptData{Ty: receipt.Ty, Logs: receipt.Logs}
+		set, err := inst.ExecLocal(tx, receiptData, index)
+		assert.Nil(t, err)
+		for _, kv := range set.KV {
+			localDB.Set(kv.Key, kv.Value)
+		}
+		return tx, receiptData
+	}
+	deploy(0)
+	tx, receipt := deploy(1)
+
+	// 事件日志保存在交易回执中
+	var eventLog evmtypes.EVMContractEventLog
+	for _, item := range receipt.Logs {
+		if item.Ty == evmtypes.TyLogEVMEventData {
+			assert.Nil(t, types.Decode(item.Log, &eventLog))
+		}
+	}
+	assert.Equal(t, 2, len(eventLog.Topics))
+	topic := common.BytesToHash(eventLog.Topics[0]).Hex()
+
+	query := func(req types.Message, funcName string) *evmtypes.EvmQueryEventResp {
+		resp, err := inst.Query(funcName, types.Encode(req))
+		assert.Nil(t, err)
+		return resp.(*evmtypes.EvmQueryEventResp)
+	}
+	resp := query(&evmtypes.EvmQueryEventReq{Topic: topic}, "QueryEvents")
+	assert.Equal(t, 2, len(resp.Events))
+	assert.Equal(t, eventLog.Address, resp.Events[0].Address)
+	assert.Equal(t, "Deposit", resp.Events[0].EventName)
+	assert.Equal(t, `[{"name":"from","type":"address","value":"`+caller+`"},{"name":"value","type":"uint256","value":42}]`, resp.Events[0].JsonData)
+
+	// 按合约地址分页查询
+	resp = query(&evmtypes.EvmQueryEventReq{Address: eventLog.Address, Topic: topic, Count: 1}, "QueryEvents")
+	assert.Equal(t, 1, len(resp.Events))
+	assert.Equal(t, int32(1), resp.Events[0].TxIndex)
+	resp = query(&evmtypes.EvmQueryEventReq{Address: eventLog.Address, Count: 1, PrimaryKey: resp.PrimaryKey}, "QueryEvents")
+	assert.Equal(t, 0, len(resp.Events))
+
+	resp = query(&evmtypes.EvmQueryTxEventReq{TxHash: common.Bytes2Hex(tx.Hash())}, "QueryTxEvents")
+	assert.Equal(t, 1, len(resp.Events))
+	assert.Equal(t, common.Bytes2Hex(tx.Hash()), resp.Events[0].TxHash)
+
+	_, err := inst.Query("QueryEvents", types.Encode(&evmtypes.EvmQueryEventReq{}))
+	assert.Equal(t, types.ErrInvalidParam, err)
+
+	// 回滚后事件索引被删除
+	set, err := inst.ExecDelLocal(tx, receipt, 1)
+	assert.Nil(t, err)
+	for _, kv := range set.KV {
+		if kv.Value == nil {
+			ldb.Delete(kv.Key)
+		} else {
+			localDB.Set(kv.Key, kv.Value)
+		}
+	}
+	resp = query(&evmtypes.EvmQueryEventReq{Topic: topic}, "QueryEvents")
+	assert.Equal(t, 1, len(resp.Events))
+	assert.NotEqual(t, eventLog.Address, resp.Events[0].Address)
+}
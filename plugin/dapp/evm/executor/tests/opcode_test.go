@@ -0,0 +1,96 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tests
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/33cn/chain33/account"
+	"github.com/33cn/chain33/client"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	evm "github.com/33cn/plugin/plugin/dapp/evm/executor"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/common/crypto"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/runtime"
+	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/state"
+	"github.com/stretchr/testify/assert"
+)
+
+// 子合约的初始化代码，返回1个字节的合约代码
+const childInitCode = "6001601ff3"
+
+// 依次执行CHAINID、SELFBALANCE、BALANCE(ADDRESS)、CREATE2、EXTCODEHASH以及重复地址的CREATE2，
+// 并将结果分别保存在存储槽0~5中
+const istanbulCode = "46600055" + "47600155" + "3031600255" +
+	"64" + childInitCode + "600052" +
+	"602a6005601b6000f5" + "80600355" + "3f600455" +
+	"602a6005601b6000f5" + "600555" + "00"
+
+func createWithConfig(cfg *types.Chain33Config, code []byte) (common.Address, *state.MemoryStateDB, error) {
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	api, _ := client.New(q.Client(), nil)
+
+	privKey := getPrivKey()
+	tx := createTx(privKey, code, 1000000, 0)
+	mdb := buildStateDB(getAddr(privKey).String(), 500000000)
+
+	dir, ldb, localDB := util.CreateTestDB()
+	defer util.CloseTestDB(dir, ldb)
+
+	inst := evm.NewEVMExecutor()
+	inst.SetAPI(api)
+	inst.SetLocalDB(localDB)
+	inst.CheckInit()
+	msg, _ := inst.GetMessage(&tx)
+	inst.SetEnv(10, 0, uint64(10))
+	statedb := inst.GetMStateDB()
+	statedb.StateDB = mdb
+	statedb.CoinsAccount = account.NewCoinsAccount(cfg)
+	statedb.CoinsAccount.SetDB(statedb.StateDB)
+
+	context := inst.NewEVMContext(msg)
+	context.ChainID = big.NewInt(33)
+	env := runtime.NewEVM(context, statedb, *inst.GetVMConfig())
+
+	addr := *crypto.RandomContractAddress()
+	_, _, _, err := env.Create(runtime.AccountRef(msg.From()), addr, msg.Data(), msg.GasLimit(), "istanbul", "", "")
+	return addr, statedb, err
+}
+
+func TestCreateAddress2(t *testing.T) {
+	// EIP-1014 测试向量
+	addr := crypto.CreateAddress2(common.BytesToAddress(common.FromHex("0x00000000000000000000000000000000deadbeef")),
+		common.BytesToHash(common.FromHex("0x00000000000000000000000000000000000000000000000000000000cafebabe")),
+		crypto.Keccak256(common.FromHex("0xdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef")))
+	assert.Equal(t, common.FromHex("0x1d8bfdc5d46dc4f61d6b6115972536ebe6a8854c"), addr.Bytes())
+}
+
+func TestIstanbulOpcodes(t *testing.T) {
+	// 本地配置中所有分叉高度均为0，新指令可用
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	addr, statedb, err := createWithConfig(cfg, common.FromHex(istanbulCode))
+	assert.Nil(t, err)
+
+	slot := func(i int64) common.Hash {
+		return statedb.GetState(addr.String(), common.BigToHash(big.NewInt(i)))
+	}
+	assert.Equal(t, common.BigToHash(big.NewInt(33)), slot(0))
+	assert.Equal(t, slot(2), slot(1))
+
+	child := crypto.CreateAddress2(addr, common.BigToHash(big.NewInt(0x2a)), crypto.Keccak256(common.FromHex(childInitCode)))
+	assert.Equal(t, common.BytesToHash(child.Bytes()), slot(3))
+	assert.Equal(t, []byte{0}, statedb.GetCode(child.String()))
+	assert.Equal(t, statedb.GetCodeHash(child.String()), slot(4))
+	// 相同salt和代码重复创建时地址冲突，返回0
+	assert.Equal(t, common.Hash{}, slot(5))
+
+	// 分叉之前新指令无效
+	_, _, err = createWithConfig(chainTestCfg, common.FromHex(istanbulCode))
+	assert.NotNil(t, err)
+}
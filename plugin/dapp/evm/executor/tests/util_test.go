@@ -29,7 +29,7 @@ import (
 var chainTestCfg = types.NewChain33Config(strings.Replace(types.GetDefaultCfgstring(), "Title=\"local\"", "Title=\"chain33\"", 1))
 
 func init() {
-	evm.Init(evmtypes.ExecutorName, chainTestCfg, nil)
+	evm.Init(evmtypes.ExecutorName, chainTestCfg, []byte(`{"chainID":33}`))
 }
 
 func getBin(data string) (ret []byte) {
@@ -60,6 +60,12 @@ func RandomContractAddress() *common.Address {
 	return ret
 }
 
+// CreateAddress2 CREATE2指令使用的合约地址生成方式，地址由创建者地址、salt和合约初始化代码确定
+// keccak256(0xff ++ address ++ salt ++ keccak256(initCode))[12:]
+func CreateAddress2(b common.Address, salt common.Hash, initCodeHash []byte) common.Address {
+	return common.BytesToAddress(Keccak256([]byte{0xff}, b.Bytes(), salt.Bytes(), initCodeHash)[12:])
+}
+
 // Keccak256 计算并返回 Keccak256 哈希
 func Keccak256(data ...[]byte) []byte {
 	d := sha3.NewLegacyKeccak256()
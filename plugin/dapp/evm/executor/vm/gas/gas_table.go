@@ -35,6 +35,8 @@ type Table struct {
 	Suicide uint64
 	// ExpByte 额外数据计价
 	ExpByte uint64
+	// ExtcodeHash 获取代码哈希计价（Istanbul）
+	ExtcodeHash uint64
 }
 
 var (
@@ -47,6 +49,7 @@ var (
 		Calls:       40,
 		Suicide:     0,
 		ExpByte:     10,
+		ExtcodeHash: 700,
 	}
 )
 
@@ -326,6 +329,27 @@ func Create(gt Table, evm *params.EVMParam, contractGas *params.GasParam, stack
 	return gas, nil
 }
 
+// Create2 CREATE2指令计费，除CREATE的费用外，还需要按字长对初始化代码的哈希计算计费
+func Create2(gt Table, evm *params.EVMParam, contractGas *params.GasParam, stack *mm.Stack, mem *mm.Memory, memorySize uint64) (uint64, error) {
+	var overflow bool
+	gas, err := Create(gt, evm, contractGas, stack, mem, memorySize)
+	if err != nil {
+		return 0, err
+	}
+
+	wordGas, overflow := common.BigUint64(stack.Back(2))
+	if overflow {
+		return 0, model.ErrGasUintOverflow
+	}
+	if wordGas, overflow = common.SafeMul(common.ToWordSize(wordGas), params.Sha3WordGas); overflow {
+		return 0, model.ErrGasUintOverflow
+	}
+	if gas, overflow = common.SafeAdd(gas, wordGas); overflow {
+		return 0, model.ErrGasUintOverflow
+	}
+	return gas, nil
+}
+
 // Balance 获取余额计费
 func Balance(gt Table, evm *params.EVMParam, contractGas *params.GasParam, stack *mm.Stack, mem *mm.Memory, memorySize uint64) (uint64, error) {
 	return gt.Balance, nil
@@ -336,6 +360,11 @@ func ExtCodeSize(gt Table, evm *params.EVMParam, contractGas *params.GasParam, s
 	return gt.ExtcodeSize, nil
 }
 
+// ExtCodeHash 获取代码哈希计费
+func ExtCodeHash(gt Table, evm *params.EVMParam, contractGas *params.GasParam, stack *mm.Stack, mem *mm.Memory, memorySize uint64) (uint64, error) {
+	return gt.ExtcodeHash, nil
+}
+
 // SLoad 加载存储计费
 func SLoad(gt Table, evm *params.EVMParam, contractGas *params.GasParam, stack *mm.Stack, mem *mm.Memory, memorySize uint64) (uint64, error) {
 	return gt.SLoad, nil
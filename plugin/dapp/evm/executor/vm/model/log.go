@@ -10,7 +10,7 @@ import (
 )
 
 // ContractLog 合约在日志，对应EVM中的Log指令，可以生成指定的日志信息
-// 目前这些日志只是在合约执行完成时进行打印，没有其它用途
+// 合约执行完成时会打印这些日志，并在ForkEVMEventLog之后保存到交易回执中
 type ContractLog struct {
 	// Address 合约地址
 	Address common.Address
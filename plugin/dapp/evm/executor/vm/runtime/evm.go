@@ -76,6 +76,8 @@ type Context struct {
 	Time *big.Int
 	// Difficulty 指令，当前区块难度
 	Difficulty *big.Int
+	// ChainID CHAINID 指令，当前链ID
+	ChainID *big.Int
 }
 
 // EVM 结构对象及其提供的操作方法，用于进行满足以太坊EVM黄皮书规范定义的智能合约代码的创建和执行
@@ -504,6 +504,18 @@ func opExtCodeSize(pc *uint64, evm *EVM, contract *Contract, memory *mm.Memory,
 	return nil, nil
 }
 
+// 获取合约代码哈希，合约不存在时返回0
+func opExtCodeHash(pc *uint64, evm *EVM, contract *Contract, memory *mm.Memory, stack *mm.Stack) ([]byte, error) {
+	slot := stack.Peek()
+	addr := common.BigToAddress(slot).String()
+	if evm.StateDB.Empty(addr) {
+		slot.SetUint64(0)
+	} else {
+		slot.SetBytes(evm.StateDB.GetCodeHash(addr).Bytes())
+	}
+	return nil, nil
+}
+
 // 获取合约代码大小
 func opCodeSize(pc *uint64, evm *EVM, contract *Contract, memory *mm.Memory, stack *mm.Stack) ([]byte, error) {
 	l := evm.Interpreter.IntPool.Get().SetInt64(int64(len(contract.Code)))
@@ -598,6 +610,23 @@ func opGasLimit(pc *uint64, evm *EVM, contract *Contract, memory *mm.Memory, sta
 	return nil, nil
 }
 
+// 获取链ID
+func opChainID(pc *uint64, evm *EVM, contract *Contract, memory *mm.Memory, stack *mm.Stack) ([]byte, error) {
+	chainID := evm.Interpreter.IntPool.Get()
+	if evm.ChainID != nil {
+		chainID.Set(evm.ChainID)
+	}
+	stack.Push(chainID)
+	return nil, nil
+}
+
+// 获取当前合约的余额，比BALANCE指令更便宜
+func opSelfBalance(pc *uint64, evm *EVM, contract *Contract, memory *mm.Memory, stack *mm.Stack) ([]byte, error) {
+	balance := evm.Interpreter.IntPool.Get().SetUint64(evm.StateDB.GetBalance(contract.Address().String()))
+	stack.Push(balance)
+	return nil, nil
+}
+
 // 弹出栈顶数据
 func opPop(pc *uint64, evm *EVM, contract *Contract, memory *mm.Memory, stack *mm.Stack) ([]byte, error) {
 	evm.Interpreter.IntPool.Put(stack.Pop())
@@ -742,6 +771,54 @@ func opCreate(pc *uint64, evm *EVM, contract *Contract, memory *mm.Memory, stack
 	return nil, nil
 }
 
+// 使用确定的地址创建合约，地址由创建者地址、salt和初始化代码计算得出
+func opCreate2(pc *uint64, evm *EVM, contract *Contract, memory *mm.Memory, stack *mm.Stack) ([]byte, error) {
+	// 从栈和内存中分别获取操作所需的参数
+	var (
+		value        = stack.Pop()
+		offset, size = stack.Pop(), stack.Pop()
+		salt         = stack.Pop()
+		inPut        = memory.Get(offset.Int64(), size.Int64())
+		gas          = contract.Gas
+	)
+
+	contract.UseGas(gas)
+
+	addr := crypto.CreateAddress2(contract.Address(), common.BigToHash(salt), crypto.Keccak256(inPut))
+
+	var (
+		res       []byte
+		returnGas uint64
+		suberr    error
+	)
+	// 目标地址已经存在合约时，创建失败，Gas原样返还
+	if !evm.StateDB.Empty(addr.String()) {
+		suberr = model.ErrContractAddressCollision
+		returnGas = gas
+	} else {
+		res, _, returnGas, suberr = evm.Create(contract, addr, inPut, gas, "innerContract", "", "")
+	}
+
+	// 出错时压栈0，否则压栈创建出来的合约对象的地址
+	if suberr != nil && suberr != model.ErrCodeStoreOutOfGas {
+		log15.Error("evm contract opCreate2 instruction error", suberr)
+		stack.Push(evm.Interpreter.IntPool.GetZero())
+	} else {
+		stack.Push(addr.Big())
+	}
+
+	// 剩余的Gas再返还给合约对象
+	contract.Gas += returnGas
+
+	// 其它参数写入整数池
+	evm.Interpreter.IntPool.Put(value, offset, size, salt)
+
+	if suberr == model.ErrExecutionReverted {
+		return res, nil
+	}
+	return nil, nil
+}
+
 // 合约调用操作
 func opCall(pc *uint64, evm *EVM, contract *Contract, memory *mm.Memory, stack *mm.Stack) ([]byte, error) {
 	// 弹出可用的gas，并放入整数池
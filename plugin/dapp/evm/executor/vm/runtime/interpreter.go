@@ -13,6 +13,7 @@ import (
 	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/mm"
 	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/model"
 	"github.com/33cn/plugin/plugin/dapp/evm/executor/vm/params"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
 )
 
 // Config 解释器的配置模型
@@ -48,7 +49,11 @@ func NewInterpreter(evm *EVM, cfg Config) *Interpreter {
 	// 使用是否包含第一个STOP指令判断jump table是否完成初始化
 	// 需要注意，后继如果新增指令，需要在这里判断硬分叉，指定不同的指令集
 	if !cfg.JumpTable[STOP].Valid {
-		cfg.JumpTable = ConstantinopleInstructionSet
+		if evm.StateDB.GetConfig().IsDappFork(evm.BlockNumber.Int64(), "evm", evmtypes.ForkEVMIstanbul) {
+			cfg.JumpTable = IstanbulInstructionSet
+		} else {
+			cfg.JumpTable = ConstantinopleInstructionSet
+		}
 	}
 
 	return &Interpreter{
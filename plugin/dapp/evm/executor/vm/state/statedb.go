@@ -692,7 +692,7 @@ func (mdb *MemoryStateDB) mergeResult(one, two *types.Receipt) (ret *types.Recei
 }
 
 // AddLog LOG0-4 指令对应的具体操作
-// 生成对应的日志信息，目前这些生成的日志信息会在合约执行后打印到日志文件中
+// 生成对应的日志信息，合约执行成功后会保存到交易回执中（ForkEVMEventLog之后）
 func (mdb *MemoryStateDB) AddLog(log *model.ContractLog) {
 	mdb.addChange(addLogChange{txhash: mdb.txHash})
 	log.TxHash = mdb.txHash
@@ -701,6 +701,18 @@ func (mdb *MemoryStateDB) AddLog(log *model.ContractLog) {
 	mdb.logSize++
 }
 
+// GetEventLogs 获取本交易中合约生成的事件日志，用于保存到交易回执中
+func (mdb *MemoryStateDB) GetEventLogs() (logs []*types.ReceiptLog) {
+	for _, item := range mdb.logs[mdb.txHash] {
+		eventLog := &evmtypes.EVMContractEventLog{Address: item.Address.String(), Data: item.Data, Index: int32(item.Index)}
+		for _, topic := range item.Topics {
+			eventLog.Topics = append(eventLog.Topics, topic.Bytes())
+		}
+		logs = append(logs, &types.ReceiptLog{Ty: evmtypes.TyLogEVMEventData, Log: types.Encode(eventLog)})
+	}
+	return
+}
+
 // AddPreimage 存储sha3指令对应的数据
 func (mdb *MemoryStateDB) AddPreimage(hash common.Hash, data []byte) {
 	// 目前只用于打印日志
@@ -0,0 +1,198 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+
+	log "github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/chain33/types"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+)
+
+var elog = log.New("module", "evm.rpc")
+
+// 以太坊兼容接口的配置，和执行器共用[exec.sub.evm]配置
+type ethConfig struct {
+	// ChainID 链ID，eth_chainId返回此值
+	ChainID int64 `json:"chainID"`
+	// EthRPCBindAddr 以太坊兼容JSON-RPC服务的绑定地址，为空时不启动服务
+	EthRPCBindAddr string `json:"ethRPCBindAddr"`
+}
+
+// JSON-RPC 2.0 错误码
+const (
+	ethErrParse          = -32700
+	ethErrInvalidRequest = -32600
+	ethErrMethodNotFound = -32601
+	ethErrInvalidParams  = -32602
+	ethErrInternal       = -32000
+)
+
+type ethRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type ethError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type ethResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      json.RawMessage  `json:"id"`
+	Result  *json.RawMessage `json:"result,omitempty"`
+	Error   *ethError        `json:"error,omitempty"`
+}
+
+type ethMethod func(params json.RawMessage) (interface{}, error)
+
+// EthServer 以太坊兼容的JSON-RPC服务，方便web3.js、ethers、Truffle等工具直接访问chain33节点
+type EthServer struct {
+	cli     *channelClient
+	chainID int64
+	methods map[string]ethMethod
+}
+
+// NewEthServer 新建以太坊兼容的JSON-RPC服务
+func NewEthServer(cli *channelClient, chainID int64) *EthServer {
+	s := &EthServer{cli: cli, chainID: chainID}
+	s.methods = map[string]ethMethod{
+		"eth_chainId":               s.chainIDMethod,
+		"net_version":               s.netVersion,
+		"eth_blockNumber":           s.blockNumber,
+		"eth_gasPrice":              s.gasPrice,
+		"eth_call":                  s.call,
+		"eth_estimateGas":           s.estimateGas,
+		"eth_getCode":               s.getCode,
+		"eth_getStorageAt":          s.getStorageAt,
+		"eth_getLogs":               s.getLogs,
+		"eth_getTransactionReceipt": s.getTransactionReceipt,
+		"eth_sendRawTransaction":    s.sendRawTransaction,
+	}
+	return s
+}
+
+// 根据[exec.sub.evm]中的配置启动以太坊兼容服务
+func startEthServer(cli *channelClient) {
+	var conf ethConfig
+	sub := cli.GetConfig().GetSubConfig().Exec[evmtypes.ExecutorName]
+	if sub != nil {
+		types.MustDecode(sub, &conf)
+	}
+	if conf.EthRPCBindAddr == "" {
+		return
+	}
+	listener, err := net.Listen("tcp", conf.EthRPCBindAddr)
+	if err != nil {
+		elog.Error("start eth rpc server", "addr", conf.EthRPCBindAddr, "err", err)
+		return
+	}
+	elog.Info("start eth rpc server", "addr", listener.Addr().String())
+	go http.Serve(listener, NewEthServer(cli, conf.ChainID))
+}
+
+// ServeHTTP 处理JSON-RPC请求，支持批量请求
+func (s *EthServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	if r.Method == http.MethodOptions {
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.handleBody(data))
+}
+
+func (s *EthServer) handleBody(data []byte) interface{} {
+	var batch []json.RawMessage
+	if err := json.Unmarshal(data, &batch); err == nil {
+		if len(batch) == 0 {
+			return newEthError(nil, ethErrInvalidRequest, "empty batch")
+		}
+		responses := make([]*ethResponse, 0, len(batch))
+		for _, item := range batch {
+			responses = append(responses, s.handleRequest(item))
+		}
+		return responses
+	}
+	return s.handleRequest(data)
+}
+
+func (s *EthServer) handleRequest(data []byte) *ethResponse {
+	var req ethRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return newEthError(nil, ethErrParse, err.Error())
+	}
+	method, ok := s.methods[req.Method]
+	if !ok {
+		return newEthError(req.ID, ethErrMethodNotFound, fmt.Sprintf("the method %s does not exist/is not available", req.Method))
+	}
+	result, err := method(req.Params)
+	if err != nil {
+		code := ethErrInternal
+		if _, ok := err.(*paramsError); ok {
+			code = ethErrInvalidParams
+		}
+		return newEthError(req.ID, code, err.Error())
+	}
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return newEthError(req.ID, ethErrInternal, err.Error())
+	}
+	msg := json.RawMessage(raw)
+	return &ethResponse{JSONRPC: "2.0", ID: req.ID, Result: &msg}
+}
+
+func newEthError(id json.RawMessage, code int, msg string) *ethResponse {
+	return &ethResponse{JSONRPC: "2.0", ID: id, Error: &ethError{Code: code, Message: msg}}
+}
+
+// 参数错误，返回-32602错误码
+type paramsError struct {
+	msg string
+}
+
+func (e *paramsError) Error() string {
+	return e.msg
+}
+
+func invalidParams(format string, args ...interface{}) error {
+	return &paramsError{msg: fmt.Sprintf(format, args...)}
+}
+
+// 按位置解析参数数组，前required个参数必须提供
+func parseParams(params json.RawMessage, required int, args ...interface{}) error {
+	var list []json.RawMessage
+	if len(params) > 0 && string(params) != "null" {
+		if err := json.Unmarshal(params, &list); err != nil {
+			return invalidParams("invalid params: %v", err)
+		}
+	}
+	if len(list) < required || len(list) > len(args) {
+		return invalidParams("invalid params count: %d", len(list))
+	}
+	for i, item := range list {
+		if err := json.Unmarshal(item, args[i]); err != nil {
+			return invalidParams("invalid argument %d: %v", i, err)
+		}
+	}
+	return nil
+}
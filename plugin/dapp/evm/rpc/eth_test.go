@@ -0,0 +1,207 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/33cn/chain33/client/mocks"
+	"github.com/33cn/chain33/common/address"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testContractAddr = "0x0000000000000000000000000000000000000001"
+
+func newTestEthServer() (*EthServer, *mocks.QueueProtocolAPI) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	api := new(mocks.QueueProtocolAPI)
+	api.On("GetConfig", mock.Anything).Return(cfg, nil)
+	cli := &channelClient{
+		ChannelClient: rpctypes.ChannelClient{
+			QueueProtocolAPI: api,
+		},
+	}
+	return NewEthServer(cli, 33), api
+}
+
+func ethCall(t *testing.T, s *EthServer, method, params string) *ethResponse {
+	body := `{"jsonrpc":"2.0","id":1,"method":"` + method + `","params":` + params + `}`
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	var resp ethResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return &resp
+}
+
+func resultOf(resp *ethResponse) string {
+	if resp.Result == nil {
+		return ""
+	}
+	return string(*resp.Result)
+}
+
+func TestEthServer_Basic(t *testing.T) {
+	s, api := newTestEthServer()
+	assert.Equal(t, `"0x21"`, resultOf(ethCall(t, s, "eth_chainId", "[]")))
+	assert.Equal(t, `"33"`, resultOf(ethCall(t, s, "net_version", "[]")))
+
+	api.On("GetLastHeader").Return(&types.Header{Height: 100}, nil)
+	assert.Equal(t, `"0x64"`, resultOf(ethCall(t, s, "eth_blockNumber", "[]")))
+
+	resp := ethCall(t, s, "eth_unknown", "[]")
+	assert.Equal(t, ethErrMethodNotFound, resp.Error.Code)
+	resp = ethCall(t, s, "eth_getCode", "[]")
+	assert.Equal(t, ethErrInvalidParams, resp.Error.Code)
+	resp = ethCall(t, s, "eth_getCode", `["0x1234"]`)
+	assert.Equal(t, ethErrInvalidParams, resp.Error.Code)
+
+	// 批量请求
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":2,"method":"net_version"}]`)))
+	var batch []*ethResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &batch))
+	assert.Equal(t, 2, len(batch))
+	assert.Equal(t, `"33"`, resultOf(batch[1]))
+}
+
+func TestEthServer_State(t *testing.T) {
+	s, api := newTestEthServer()
+	contract, _ := ethToChain33Addr(testContractAddr)
+
+	api.On("Query", "evm", "Query", &evmtypes.EvmQueryReq{Address: contract, Input: "0x12345678"}).Return(&evmtypes.EvmQueryResp{RawData: "0x0000000a"}, nil)
+	assert.Equal(t, `"0x0000000a"`, resultOf(ethCall(t, s, "eth_call", `[{"to":"`+testContractAddr+`","data":"0x12345678"},"latest"]`)))
+
+	api.On("Query", "evm", "Query", &evmtypes.EvmQueryReq{Address: contract, Input: "0xff"}).Return(&evmtypes.EvmQueryResp{JsonData: "execution reverted"}, nil)
+	resp := ethCall(t, s, "eth_call", `[{"to":"`+testContractAddr+`","data":"0xff"}]`)
+	assert.Equal(t, "execution reverted", resp.Error.Message)
+
+	api.On("Query", "evm", "EstimateGas", &evmtypes.EstimateEVMGasReq{To: address.ExecAddress("evm"), Code: []byte{0x60, 0x00}}).Return(&evmtypes.EstimateEVMGasResp{Gas: 300000}, nil)
+	assert.Equal(t, `"0x493e0"`, resultOf(ethCall(t, s, "eth_estimateGas", `[{"data":"0x6000"}]`)))
+
+	api.On("Query", "evm", "GetCode", &evmtypes.EvmQueryCodeReq{Address: contract}).Return(&evmtypes.EvmQueryCodeResp{Code: []byte{0x60, 0x80}}, nil)
+	assert.Equal(t, `"0x6080"`, resultOf(ethCall(t, s, "eth_getCode", `["`+testContractAddr+`","latest"]`)))
+
+	slot := "0x" + strings.Repeat("0", 63) + "1"
+	api.On("Query", "evm", "GetStorageAt", &evmtypes.EvmQueryStorageReq{Address: contract, Key: slot}).Return(&evmtypes.EvmQueryStorageResp{Value: slot}, nil)
+	assert.Equal(t, `"`+slot+`"`, resultOf(ethCall(t, s, "eth_getStorageAt", `["`+testContractAddr+`","0x1","latest"]`)))
+}
+
+func TestEthServer_Logs(t *testing.T) {
+	s, api := newTestEthServer()
+	contract, _ := ethToChain33Addr(testContractAddr)
+	topic := "0x" + strings.Repeat("ab", 32)
+	other := "0x" + strings.Repeat("cd", 32)
+
+	api.On("GetLastHeader").Return(&types.Header{Height: 30}, nil)
+	api.On("GetBlockHash", mock.Anything).Return(&types.ReplyHash{Hash: []byte{1}}, nil)
+	events := []*evmtypes.EvmEvent{
+		{Address: contract, Topics: []string{topic, other}, Data: "0x", TxHash: "0x02", Height: 25, TxIndex: 1},
+		{Address: contract, Topics: []string{topic}, Data: "0x", TxHash: "0x01", Height: 20},
+		{Address: contract, Topics: []string{topic}, Data: "0x", TxHash: "0x00", Height: 5},
+	}
+	api.On("Query", "evm", "QueryEvents", &evmtypes.EvmQueryEventReq{Address: contract, Topic: topic, Count: ethLogPageSize}).Return(&evmtypes.EvmQueryEventResp{Events: events}, nil)
+
+	resp := ethCall(t, s, "eth_getLogs", `[{"fromBlock":"0xa","address":"`+testContractAddr+`","topics":["`+topic+`"]}]`)
+	var logs []*ethLog
+	assert.Nil(t, json.Unmarshal(*resp.Result, &logs))
+	assert.Equal(t, 2, len(logs))
+	assert.Equal(t, "0x14", logs[0].BlockNumber)
+	assert.Equal(t, "0x01", logs[0].TransactionHash)
+	assert.Equal(t, testContractAddr, logs[0].Address)
+	assert.Equal(t, "0x01", logs[1].BlockHash)
+
+	resp = ethCall(t, s, "eth_getLogs", `[{"fromBlock":"0xa","address":"`+testContractAddr+`","topics":["`+topic+`",["`+other+`"]]}]`)
+	assert.Nil(t, json.Unmarshal(*resp.Result, &logs))
+	assert.Equal(t, 1, len(logs))
+	assert.Equal(t, "0x02", logs[0].TransactionHash)
+
+	resp = ethCall(t, s, "eth_getLogs", `[{"fromBlock":"0x0"}]`)
+	assert.Equal(t, ethErrInvalidParams, resp.Error.Code)
+}
+
+func TestEthServer_Transaction(t *testing.T) {
+	s, api := newTestEthServer()
+	contract, _ := ethToChain33Addr(testContractAddr)
+	hash := []byte{1, 2, 3}
+
+	contractLog := &evmtypes.ReceiptEVMContract{ContractAddr: contract, UsedGas: 5000}
+	eventLog := &evmtypes.EVMContractEventLog{Address: contract, Topics: [][]byte{make([]byte, 32)}, Data: []byte{1}}
+	detail := &types.TransactionDetail{
+		Tx:       &types.Transaction{Execer: []byte("evm"), To: address.ExecAddress("evm")},
+		Height:   8,
+		Index:    2,
+		Fromaddr: contract,
+		Receipt: &types.ReceiptData{Ty: types.ExecOk, Logs: []*types.ReceiptLog{
+			{Ty: evmtypes.TyLogCallContract, Log: types.Encode(contractLog)},
+			{Ty: evmtypes.TyLogEVMEventData, Log: types.Encode(eventLog)},
+		}},
+	}
+	api.On("Query", "evm", "GetEthTxHash", mock.Anything).Return(nil, types.ErrNotFound)
+	api.On("QueryTx", &types.ReqHash{Hash: hash}).Return(detail, nil)
+	api.On("QueryTx", mock.Anything).Return(nil, types.ErrTxNotExist)
+	api.On("GetBlockHash", &types.ReqInt{Height: 8}).Return(&types.ReplyHash{Hash: []byte{8}}, nil)
+
+	resp := ethCall(t, s, "eth_getTransactionReceipt", `["0x010203"]`)
+	var receipt ethReceipt
+	assert.Nil(t, json.Unmarshal(*resp.Result, &receipt))
+	assert.Equal(t, "0x1", receipt.Status)
+	assert.Equal(t, "0x1388", receipt.GasUsed)
+	assert.Nil(t, receipt.To)
+	assert.Equal(t, testContractAddr, *receipt.ContractAddress)
+	assert.Equal(t, 1, len(receipt.Logs))
+	assert.Equal(t, "0x08", receipt.Logs[0].BlockHash)
+	assert.NotEqual(t, "0x"+strings.Repeat("0", 512), receipt.LogsBloom)
+
+	// 交易不存在时返回null
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_getTransactionReceipt","params":["0x04"]}`)))
+	assert.Contains(t, w.Body.String(), `"result":null`)
+
+	// 交易格式错误
+	resp = ethCall(t, s, "eth_sendRawTransaction", `["0x1234"]`)
+	assert.NotNil(t, resp.Error)
+	// EIP-155 示例交易，Gas价格超出范围
+	raw := "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"
+	resp = ethCall(t, s, "eth_sendRawTransaction", `["0x`+raw+`"]`)
+	assert.Equal(t, evmtypes.ErrEthTxValue.Error(), resp.Error.Message)
+
+	key, _ := btcec.NewPrivateKey(btcec.S256())
+	ethTx := &evmtypes.EthTransaction{GasPrice: big.NewInt(1), Gas: 100000, Value: big.NewInt(0), Data: []byte{0x60, 0x00}}
+	sig, _ := btcec.SignCompact(btcec.S256(), key, ethTx.SigHash(), false)
+	ethTx.V, ethTx.R, ethTx.S = big.NewInt(int64(sig[0])), new(big.Int).SetBytes(sig[1:33]), new(big.Int).SetBytes(sig[33:])
+	tx, _, err := evmtypes.NewChain33TxFromEth(ethTx.Encode(), "evm")
+	assert.Nil(t, err)
+	api.On("SendTx", tx).Return(&types.Reply{IsOk: true, Msg: tx.Hash()}, nil)
+	resp = ethCall(t, s, "eth_sendRawTransaction", `["0x`+hex.EncodeToString(ethTx.Encode())+`"]`)
+	ethHash := "0x" + hex.EncodeToString(ethTx.Hash())
+	assert.Equal(t, `"`+ethHash+`"`, resultOf(resp))
+
+	// 使用以太坊交易哈希查询回执，发送者和执行时使用的chain33地址一致
+	s, api = newTestEthServer()
+	api.On("Query", "evm", "GetEthTxHash", &types.ReqHash{Hash: ethTx.Hash()}).Return(&types.ReplyHash{Hash: tx.Hash()}, nil)
+	api.On("QueryTx", &types.ReqHash{Hash: tx.Hash()}).Return(&types.TransactionDetail{Tx: tx, Height: 8, Fromaddr: tx.From(), Receipt: &types.ReceiptData{Ty: types.ExecOk}}, nil)
+	api.On("GetBlockHash", &types.ReqInt{Height: 8}).Return(&types.ReplyHash{Hash: []byte{8}}, nil)
+	resp = ethCall(t, s, "eth_getTransactionReceipt", `["`+ethHash+`"]`)
+	assert.Nil(t, json.Unmarshal(*resp.Result, &receipt))
+	assert.Equal(t, ethHash, receipt.TransactionHash)
+	sender, err := ethTx.Sender()
+	assert.Nil(t, err)
+	assert.Equal(t, "0x"+hex.EncodeToString(sender), receipt.From)
+	assert.Equal(t, chain33ToEthAddr(tx.From()), receipt.From)
+	from, err := ethToChain33Addr(receipt.From)
+	assert.Nil(t, err)
+	assert.Equal(t, tx.From(), from)
+}
@@ -0,0 +1,612 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/types"
+	evmtypes "github.com/33cn/plugin/plugin/dapp/evm/types"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// eth_getLogs 单次查询的事件数量
+	ethLogPageSize = 100
+	// eth_getLogs 最多返回的事件数量
+	ethMaxLogs = 10000
+)
+
+// 合约调用参数，地址使用以太坊的十六进制格式
+type ethCallArgs struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Gas      string `json:"gas"`
+	GasPrice string `json:"gasPrice"`
+	Value    string `json:"value"`
+	Data     string `json:"data"`
+	Input    string `json:"input"`
+}
+
+func (args *ethCallArgs) data() string {
+	if args.Data != "" {
+		return args.Data
+	}
+	return args.Input
+}
+
+type ethFilter struct {
+	FromBlock string            `json:"fromBlock"`
+	ToBlock   string            `json:"toBlock"`
+	Address   json.RawMessage   `json:"address"`
+	Topics    []json.RawMessage `json:"topics"`
+	BlockHash string            `json:"blockHash"`
+}
+
+type ethLog struct {
+	Address          string   `json:"address"`
+	Topics           []string `json:"topics"`
+	Data             string   `json:"data"`
+	BlockNumber      string   `json:"blockNumber"`
+	TransactionHash  string   `json:"transactionHash"`
+	TransactionIndex string   `json:"transactionIndex"`
+	BlockHash        string   `json:"blockHash"`
+	// LogIndex 事件在交易中的序号
+	LogIndex string `json:"logIndex"`
+	Removed  bool   `json:"removed"`
+}
+
+type ethReceipt struct {
+	TransactionHash   string    `json:"transactionHash"`
+	TransactionIndex  string    `json:"transactionIndex"`
+	BlockHash         string    `json:"blockHash"`
+	BlockNumber       string    `json:"blockNumber"`
+	From              string    `json:"from"`
+	To                *string   `json:"to"`
+	ContractAddress   *string   `json:"contractAddress"`
+	GasUsed           string    `json:"gasUsed"`
+	CumulativeGasUsed string    `json:"cumulativeGasUsed"`
+	Status            string    `json:"status"`
+	Logs              []*ethLog `json:"logs"`
+	LogsBloom         string    `json:"logsBloom"`
+}
+
+func (s *EthServer) execName() string {
+	return s.cli.GetConfig().ExecName(evmtypes.ExecutorName)
+}
+
+func (s *EthServer) query(funcName string, req types.Message) (types.Message, error) {
+	return s.cli.Query(s.execName(), funcName, req)
+}
+
+func (s *EthServer) chainIDMethod(params json.RawMessage) (interface{}, error) {
+	return hexUint(uint64(s.chainID)), nil
+}
+
+func (s *EthServer) netVersion(params json.RawMessage) (interface{}, error) {
+	return strconv.FormatInt(s.chainID, 10), nil
+}
+
+func (s *EthServer) blockNumber(params json.RawMessage) (interface{}, error) {
+	header, err := s.cli.GetLastHeader()
+	if err != nil {
+		return nil, err
+	}
+	return hexUint(uint64(header.Height)), nil
+}
+
+// chain33中合约手续费即为消耗的Gas，Gas价格固定为1
+func (s *EthServer) gasPrice(params json.RawMessage) (interface{}, error) {
+	return hexUint(1), nil
+}
+
+// 状态查询都基于最新区块，区块参数会被忽略
+func (s *EthServer) call(params json.RawMessage) (interface{}, error) {
+	var args ethCallArgs
+	var block json.RawMessage
+	if err := parseParams(params, 1, &args, &block); err != nil {
+		return nil, err
+	}
+	to, err := ethToChain33Addr(args.To)
+	if err != nil {
+		return nil, err
+	}
+	req := &evmtypes.EvmQueryReq{Address: to, Input: "0x" + strings.TrimPrefix(args.data(), "0x")}
+	if args.From != "" {
+		if req.Caller, err = ethToChain33Addr(args.From); err != nil {
+			return nil, err
+		}
+	}
+	msg, err := s.query("Query", req)
+	if err != nil {
+		return nil, err
+	}
+	resp := msg.(*evmtypes.EvmQueryResp)
+	// 执行出错时错误信息放在JsonData中
+	if resp.RawData == "" && resp.JsonData != "" {
+		return nil, errors.New(resp.JsonData)
+	}
+	if resp.RawData == "" {
+		return "0x", nil
+	}
+	return resp.RawData, nil
+}
+
+func (s *EthServer) estimateGas(params json.RawMessage) (interface{}, error) {
+	var args ethCallArgs
+	var block json.RawMessage
+	if err := parseParams(params, 1, &args, &block); err != nil {
+		return nil, err
+	}
+	code, err := parseHexBytes(args.data())
+	if err != nil {
+		return nil, err
+	}
+	req := &evmtypes.EstimateEVMGasReq{Code: code}
+	// 没有目标地址时为创建合约
+	if args.To == "" {
+		req.To = address.ExecAddress(s.execName())
+	} else if req.To, err = ethToChain33Addr(args.To); err != nil {
+		return nil, err
+	}
+	if args.From != "" {
+		if req.Caller, err = ethToChain33Addr(args.From); err != nil {
+			return nil, err
+		}
+	}
+	if args.Value != "" {
+		if req.Amount, err = parseHexUint(args.Value); err != nil {
+			return nil, err
+		}
+	}
+	msg, err := s.query("EstimateGas", req)
+	if err != nil {
+		return nil, err
+	}
+	gas := msg.(*evmtypes.EstimateEVMGasResp).Gas
+	// 手续费不能低于链上的最低手续费
+	if minFee := uint64(s.cli.GetConfig().GInt("MinFee")); gas < minFee {
+		gas = minFee
+	}
+	return hexUint(gas), nil
+}
+
+func (s *EthServer) getCode(params json.RawMessage) (interface{}, error) {
+	var addr string
+	var block json.RawMessage
+	if err := parseParams(params, 1, &addr, &block); err != nil {
+		return nil, err
+	}
+	chain33Addr, err := ethToChain33Addr(addr)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := s.query("GetCode", &evmtypes.EvmQueryCodeReq{Address: chain33Addr})
+	if err != nil {
+		return nil, err
+	}
+	return "0x" + hex.EncodeToString(msg.(*evmtypes.EvmQueryCodeResp).Code), nil
+}
+
+func (s *EthServer) getStorageAt(params json.RawMessage) (interface{}, error) {
+	var addr, pos string
+	var block json.RawMessage
+	if err := parseParams(params, 2, &addr, &pos, &block); err != nil {
+		return nil, err
+	}
+	chain33Addr, err := ethToChain33Addr(addr)
+	if err != nil {
+		return nil, err
+	}
+	key, err := parseHexBytes(pos)
+	if err != nil || len(key) > 32 {
+		return nil, invalidParams("invalid storage position: %s", pos)
+	}
+	slot := make([]byte, 32)
+	copy(slot[32-len(key):], key)
+	msg, err := s.query("GetStorageAt", &evmtypes.EvmQueryStorageReq{Address: chain33Addr, Key: "0x" + hex.EncodeToString(slot)})
+	if err != nil {
+		return nil, err
+	}
+	return msg.(*evmtypes.EvmQueryStorageResp).Value, nil
+}
+
+// 基于合约事件索引实现，必须指定合约地址或者topic0
+func (s *EthServer) getLogs(params json.RawMessage) (interface{}, error) {
+	var filter ethFilter
+	if err := parseParams(params, 1, &filter); err != nil {
+		return nil, err
+	}
+	addrs, err := parseStringOrList(filter.Address)
+	if err != nil {
+		return nil, err
+	}
+	for i, addr := range addrs {
+		if addrs[i], err = ethToChain33Addr(addr); err != nil {
+			return nil, err
+		}
+	}
+	var topics [][]string
+	for _, raw := range filter.Topics {
+		list, err := parseStringOrList(raw)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list {
+			list[i] = strings.ToLower(list[i])
+		}
+		topics = append(topics, list)
+	}
+	var topic0s []string
+	if len(topics) > 0 {
+		topic0s = topics[0]
+	}
+	if len(addrs) == 0 && len(topic0s) == 0 {
+		return nil, invalidParams("address or topics[0] must be specified")
+	}
+	if len(addrs) == 0 {
+		addrs = []string{""}
+	}
+	if len(topic0s) == 0 {
+		topic0s = []string{""}
+	}
+
+	from, to, err := s.filterRange(&filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*evmtypes.EvmEvent
+	for _, addr := range addrs {
+		for _, topic := range topic0s {
+			list, err := s.listEvents(addr, topic, from, to)
+			if err != nil {
+				return nil, err
+			}
+			for _, event := range list {
+				if matchTopics(event.Topics, topics) {
+					events = append(events, event)
+				}
+			}
+			if len(events) > ethMaxLogs {
+				return nil, fmt.Errorf("query returned more than %d results", ethMaxLogs)
+			}
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Height != events[j].Height {
+			return events[i].Height < events[j].Height
+		}
+		if events[i].TxIndex != events[j].TxIndex {
+			return events[i].TxIndex < events[j].TxIndex
+		}
+		return events[i].LogIndex < events[j].LogIndex
+	})
+
+	logs := make([]*ethLog, 0, len(events))
+	blockHashes := make(map[int64]string)
+	for _, event := range events {
+		blockHash, ok := blockHashes[event.Height]
+		if !ok {
+			if blockHash, err = s.blockHash(event.Height); err != nil {
+				return nil, err
+			}
+			blockHashes[event.Height] = blockHash
+		}
+		log := &ethLog{
+			Address:          chain33ToEthAddr(event.Address),
+			Topics:           event.Topics,
+			Data:             event.Data,
+			BlockNumber:      hexUint(uint64(event.Height)),
+			TransactionHash:  event.TxHash,
+			TransactionIndex: hexUint(uint64(event.TxIndex)),
+			BlockHash:        blockHash,
+			LogIndex:         hexUint(uint64(event.LogIndex)),
+		}
+		if log.Topics == nil {
+			log.Topics = []string{}
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
+}
+
+// 计算查询的区块高度范围，指定blockHash时只查询该区块
+func (s *EthServer) filterRange(filter *ethFilter) (int64, int64, error) {
+	if filter.BlockHash != "" {
+		hash, err := parseHexBytes(filter.BlockHash)
+		if err != nil {
+			return 0, 0, err
+		}
+		overview, err := s.cli.GetBlockOverview(&types.ReqHash{Hash: hash})
+		if err != nil {
+			return 0, 0, err
+		}
+		height := overview.GetHead().GetHeight()
+		return height, height, nil
+	}
+	header, err := s.cli.GetLastHeader()
+	if err != nil {
+		return 0, 0, err
+	}
+	from, err := parseBlockNumber(filter.FromBlock, header.Height)
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := parseBlockNumber(filter.ToBlock, header.Height)
+	if err != nil {
+		return 0, 0, err
+	}
+	return from, to, nil
+}
+
+// 从新到旧分页查询指定范围内的事件
+func (s *EthServer) listEvents(addr, topic string, from, to int64) ([]*evmtypes.EvmEvent, error) {
+	var events []*evmtypes.EvmEvent
+	primaryKey := ""
+	for {
+		req := &evmtypes.EvmQueryEventReq{Address: addr, Topic: topic, PrimaryKey: primaryKey, Count: ethLogPageSize}
+		msg, err := s.query("QueryEvents", req)
+		if err != nil {
+			return nil, err
+		}
+		resp := msg.(*evmtypes.EvmQueryEventResp)
+		for _, event := range resp.Events {
+			if event.Height < from {
+				return events, nil
+			}
+			if event.Height <= to {
+				events = append(events, event)
+			}
+		}
+		if resp.PrimaryKey == "" || len(events) > ethMaxLogs {
+			return events, nil
+		}
+		primaryKey = resp.PrimaryKey
+	}
+}
+
+func (s *EthServer) getTransactionReceipt(params json.RawMessage) (interface{}, error) {
+	var hashStr string
+	if err := parseParams(params, 1, &hashStr); err != nil {
+		return nil, err
+	}
+	hash, err := parseHexBytes(hashStr)
+	if err != nil {
+		return nil, err
+	}
+	// 以太坊格式的交易使用以太坊交易哈希查询，需要先转换为chain33交易哈希
+	txHash := hash
+	if reply, err := s.query("GetEthTxHash", &types.ReqHash{Hash: hash}); err == nil {
+		txHash = reply.(*types.ReplyHash).GetHash()
+	}
+	detail, err := s.cli.QueryTx(&types.ReqHash{Hash: txHash})
+	if err != nil {
+		// 交易还未打包时返回null
+		if err == types.ErrTxNotExist || err == types.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	blockHash, err := s.blockHash(detail.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := detail.GetTx()
+	receipt := &ethReceipt{
+		TransactionHash:  "0x" + hex.EncodeToString(hash),
+		TransactionIndex: hexUint(uint64(detail.Index)),
+		BlockHash:        blockHash,
+		BlockNumber:      hexUint(uint64(detail.Height)),
+		From:             chain33ToEthAddr(detail.Fromaddr),
+		Status:           hexUint(0),
+		Logs:             []*ethLog{},
+	}
+	if detail.GetReceipt().GetTy() == types.ExecOk {
+		receipt.Status = hexUint(1)
+	}
+	isCreate := tx.To == address.ExecAddress(string(tx.Execer))
+	if !isCreate {
+		to := chain33ToEthAddr(tx.To)
+		receipt.To = &to
+	}
+
+	var usedGas uint64
+	var bloom [256]byte
+	for _, item := range detail.GetReceipt().GetLogs() {
+		switch item.Ty {
+		case evmtypes.TyLogCallContract:
+			var contract evmtypes.ReceiptEVMContract
+			if err := types.Decode(item.Log, &contract); err != nil {
+				return nil, err
+			}
+			usedGas = contract.UsedGas
+			if isCreate {
+				contractAddr := chain33ToEthAddr(contract.ContractAddr)
+				receipt.ContractAddress = &contractAddr
+			}
+		case evmtypes.TyLogEVMEventData:
+			var eventLog evmtypes.EVMContractEventLog
+			if err := types.Decode(item.Log, &eventLog); err != nil {
+				return nil, err
+			}
+			log := &ethLog{
+				Address:          chain33ToEthAddr(eventLog.Address),
+				Topics:           []string{},
+				Data:             "0x" + hex.EncodeToString(eventLog.Data),
+				BlockNumber:      receipt.BlockNumber,
+				TransactionHash:  receipt.TransactionHash,
+				TransactionIndex: receipt.TransactionIndex,
+				BlockHash:        blockHash,
+				LogIndex:         hexUint(uint64(eventLog.Index)),
+			}
+			addBloom(&bloom, ethAddrBytes(eventLog.Address))
+			for _, topic := range eventLog.Topics {
+				log.Topics = append(log.Topics, "0x"+hex.EncodeToString(topic))
+				addBloom(&bloom, topic)
+			}
+			receipt.Logs = append(receipt.Logs, log)
+		}
+	}
+	receipt.GasUsed = hexUint(usedGas)
+	receipt.CumulativeGasUsed = receipt.GasUsed
+	receipt.LogsBloom = "0x" + hex.EncodeToString(bloom[:])
+	return receipt, nil
+}
+
+// 签名数据保存原始的以太坊交易，返回以太坊交易哈希，即原始交易的keccak256哈希
+func (s *EthServer) sendRawTransaction(params json.RawMessage) (interface{}, error) {
+	var rawStr string
+	if err := parseParams(params, 1, &rawStr); err != nil {
+		return nil, err
+	}
+	raw, err := parseHexBytes(rawStr)
+	if err != nil {
+		return nil, err
+	}
+	tx, _, err := evmtypes.NewChain33TxFromEth(raw, s.execName())
+	if err != nil {
+		return nil, err
+	}
+	reply, err := s.cli.SendTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	if !reply.GetIsOk() {
+		return nil, errors.New(string(reply.GetMsg()))
+	}
+	return "0x" + hex.EncodeToString(evmtypes.EthTxHash(tx)), nil
+}
+
+func (s *EthServer) blockHash(height int64) (string, error) {
+	reply, err := s.cli.GetBlockHash(&types.ReqInt{Height: height})
+	if err != nil {
+		return "", err
+	}
+	return "0x" + hex.EncodeToString(reply.GetHash()), nil
+}
+
+// topics中每个位置为候选topic列表，空列表表示匹配任意值
+func matchTopics(eventTopics []string, topics [][]string) bool {
+	if len(topics) > len(eventTopics) {
+		return false
+	}
+	for i, candidates := range topics {
+		if len(candidates) == 0 {
+			continue
+		}
+		matched := false
+		for _, topic := range candidates {
+			if strings.ToLower(eventTopics[i]) == topic {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// 以太坊日志布隆过滤器，每个数据设置3位
+func addBloom(bloom *[256]byte, data []byte) {
+	d := sha3.NewLegacyKeccak256()
+	d.Write(data)
+	h := d.Sum(nil)
+	for i := 0; i < 6; i += 2 {
+		bit := (uint(h[i])<<8 | uint(h[i+1])) & 2047
+		bloom[255-bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// 解析字符串或者字符串数组，null返回空
+func parseStringOrList(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return []string{single}, nil
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, invalidParams("invalid filter argument: %s", string(raw))
+	}
+	return list, nil
+}
+
+func parseBlockNumber(s string, latest int64) (int64, error) {
+	switch s {
+	case "", "latest", "pending":
+		return latest, nil
+	case "earliest":
+		return 0, nil
+	}
+	height, err := parseHexUint(s)
+	if err != nil {
+		return 0, err
+	}
+	return int64(height), nil
+}
+
+func hexUint(i uint64) string {
+	return "0x" + strconv.FormatUint(i, 16)
+}
+
+func parseHexUint(s string) (uint64, error) {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return 0, invalidParams("hex string without 0x prefix: %s", s)
+	}
+	i, ok := new(big.Int).SetString(s[2:], 16)
+	if !ok || !i.IsUint64() {
+		return 0, invalidParams("invalid hex number: %s", s)
+	}
+	return i.Uint64(), nil
+}
+
+func parseHexBytes(s string) ([]byte, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, invalidParams("invalid hex string: %v", err)
+	}
+	return b, nil
+}
+
+// 以太坊的十六进制地址转换为chain33地址，两者使用相同的Hash160
+func ethToChain33Addr(s string) (string, error) {
+	b, err := parseHexBytes(s)
+	if err != nil || len(b) != 20 {
+		return "", invalidParams("invalid address: %s", s)
+	}
+	addr := new(address.Address)
+	addr.SetBytes(b)
+	return addr.String(), nil
+}
+
+func ethAddrBytes(chain33Addr string) []byte {
+	addr, err := address.NewAddrFromString(chain33Addr)
+	if err != nil {
+		return nil
+	}
+	return addr.Hash160[:]
+}
+
+func chain33ToEthAddr(chain33Addr string) string {
+	return "0x" + hex.EncodeToString(ethAddrBytes(chain33Addr))
+}
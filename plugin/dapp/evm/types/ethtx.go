@@ -0,0 +1,202 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/mpt/db2/rlp"
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// SignNameEthSecp256k1 以太坊格式交易的签名类型名称
+	SignNameEthSecp256k1 = "evm.EthSecp256k1"
+	// EthSecp256k1 以太坊格式交易的签名类型，签名数据为RLP编码的以太坊交易
+	EthSecp256k1 = 259
+)
+
+var (
+	// ErrRLPDecode RLP数据格式错误
+	ErrRLPDecode = errors.New("ErrRLPDecode")
+	// ErrEthTxSignature 以太坊交易签名错误
+	ErrEthTxSignature = errors.New("ErrEthTxSignature")
+	// ErrEthTxValue 以太坊交易中的金额或Gas价格超出范围
+	ErrEthTxValue = errors.New("ErrEthTxValue")
+	// ErrEthTxChainID 以太坊交易的链ID和本链不一致
+	ErrEthTxChainID = errors.New("ErrEthTxChainID")
+
+	secp256k1HalfN = new(big.Int).Rsh(btcec.S256().N, 1)
+)
+
+// EthTransaction 以太坊格式的交易（Legacy交易，支持EIP-155）
+type EthTransaction struct {
+	Nonce    uint64
+	GasPrice *big.Int
+	Gas      uint64
+	// To 创建合约时为空
+	To    []byte
+	Value *big.Int
+	Data  []byte
+	V     *big.Int
+	R     *big.Int
+	S     *big.Int
+}
+
+// DecodeEthTransaction 解码RLP格式的以太坊交易
+func DecodeEthTransaction(raw []byte) (*EthTransaction, error) {
+	tx := &EthTransaction{}
+	if err := rlp.DecodeBytes(raw, tx); err != nil {
+		return nil, ErrRLPDecode
+	}
+	if len(tx.To) != 0 && len(tx.To) != 20 {
+		return nil, ErrRLPDecode
+	}
+	return tx, nil
+}
+
+// Encode 按RLP格式编码带签名的交易
+func (tx *EthTransaction) Encode() []byte {
+	data, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// ChainID 返回EIP-155签名中的链ID，未使用EIP-155签名时返回0
+func (tx *EthTransaction) ChainID() int64 {
+	if tx.V == nil || !tx.V.IsInt64() || tx.V.Int64() < 35 {
+		return 0
+	}
+	return (tx.V.Int64() - 35) / 2
+}
+
+// SigHash 计算交易的签名哈希
+func (tx *EthTransaction) SigHash() []byte {
+	fields := []interface{}{tx.Nonce, tx.GasPrice, tx.Gas, tx.To, tx.Value, tx.Data}
+	if chainID := tx.ChainID(); chainID > 0 {
+		fields = append(fields, uint64(chainID), uint(0), uint(0))
+	}
+	data, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		panic(err)
+	}
+	return keccak256(data)
+}
+
+// Hash 以太坊格式的交易哈希
+func (tx *EthTransaction) Hash() []byte {
+	return keccak256(tx.Encode())
+}
+
+// Sender 根据签名恢复出发送者地址的20字节Hash160
+// 和chain33交易的From一致，即压缩公钥的Hash160，执行和以太坊接口都使用这个地址
+func (tx *EthTransaction) Sender() ([]byte, error) {
+	pub, err := tx.RecoverPubKey()
+	if err != nil {
+		return nil, err
+	}
+	return address.PubKeyToAddress(pub).Hash160[:], nil
+}
+
+// RecoverPubKey 根据签名恢复出发送者的压缩格式公钥
+func (tx *EthTransaction) RecoverPubKey() ([]byte, error) {
+	if tx.V == nil || tx.R == nil || tx.S == nil || !tx.V.IsInt64() {
+		return nil, ErrEthTxSignature
+	}
+	var recID int64
+	if chainID := tx.ChainID(); chainID > 0 {
+		recID = tx.V.Int64() - 35 - 2*chainID
+	} else {
+		recID = tx.V.Int64() - 27
+	}
+	if recID != 0 && recID != 1 {
+		return nil, ErrEthTxSignature
+	}
+	// 和以太坊一样，不接受S值大于N/2的签名，避免签名延展性问题
+	if tx.R.Sign() <= 0 || tx.S.Sign() <= 0 || tx.R.Cmp(btcec.S256().N) >= 0 || tx.S.Cmp(secp256k1HalfN) > 0 {
+		return nil, ErrEthTxSignature
+	}
+	sig := make([]byte, 65)
+	sig[0] = byte(27 + recID)
+	copy(sig[33-len(tx.R.Bytes()):33], tx.R.Bytes())
+	copy(sig[65-len(tx.S.Bytes()):], tx.S.Bytes())
+	pub, _, err := btcec.RecoverCompact(btcec.S256(), sig, tx.SigHash())
+	if err != nil {
+		return nil, ErrEthTxSignature
+	}
+	return pub.SerializeCompressed(), nil
+}
+
+// ToChain33Tx 将以太坊交易转换为未签名的chain33交易
+// 交易金额以及Gas价格直接按照chain33的最小金额单位处理，手续费为 Gas*GasPrice
+func (tx *EthTransaction) ToChain33Tx(execer string) (*types.Transaction, error) {
+	if execer != ExecutorName && !strings.HasSuffix(execer, "."+ExecutorName) {
+		return nil, types.ErrExecNameNotMatch
+	}
+	if !tx.Value.IsUint64() || !tx.GasPrice.IsUint64() || tx.GasPrice.Uint64() > math.MaxUint32 {
+		return nil, ErrEthTxValue
+	}
+	fee := new(big.Int).Mul(tx.GasPrice, new(big.Int).SetUint64(tx.Gas))
+	if !fee.IsInt64() || tx.Nonce > math.MaxInt64 {
+		return nil, ErrEthTxValue
+	}
+	action := &EVMContractAction{
+		Amount:   tx.Value.Uint64(),
+		GasLimit: tx.Gas,
+		GasPrice: uint32(tx.GasPrice.Uint64()),
+		Code:     tx.Data,
+	}
+	chain33Tx := &types.Transaction{
+		Execer:  []byte(execer),
+		Payload: types.Encode(action),
+		Fee:     fee.Int64(),
+		Nonce:   int64(tx.Nonce),
+	}
+	if len(tx.To) == 0 {
+		chain33Tx.To = address.ExecAddress(execer)
+	} else {
+		to := new(address.Address)
+		to.SetBytes(tx.To)
+		chain33Tx.To = to.String()
+	}
+	return chain33Tx, nil
+}
+
+// NewChain33TxFromEth 将RLP格式的以太坊交易转换为chain33交易，签名数据即为原始的以太坊交易
+func NewChain33TxFromEth(raw []byte, execer string) (*types.Transaction, *EthTransaction, error) {
+	ethTx, err := DecodeEthTransaction(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	pub, err := ethTx.RecoverPubKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	tx, err := ethTx.ToChain33Tx(execer)
+	if err != nil {
+		return nil, nil, err
+	}
+	tx.Signature = &types.Signature{Ty: EthSecp256k1, Pubkey: pub, Signature: raw}
+	return tx, ethTx, nil
+}
+
+// EthTxHash 以太坊格式交易的以太坊交易哈希，即签名中原始以太坊交易的keccak256哈希
+func EthTxHash(tx *types.Transaction) []byte {
+	return keccak256(tx.GetSignature().GetSignature())
+}
+
+func keccak256(data []byte) []byte {
+	d := sha3.NewLegacyKeccak256()
+	d.Write(data)
+	return d.Sum(nil)
+}
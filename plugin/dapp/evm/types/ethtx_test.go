@@ -0,0 +1,89 @@
+package types
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/types"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/assert"
+)
+
+// EIP-155 中的示例交易
+const (
+	eip155RawTx   = "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"
+	eip155SigHash = "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53"
+	eip155PrivKey = "4646464646464646464646464646464646464646464646464646464646464646"
+)
+
+func TestDecodeEthTransaction(t *testing.T) {
+	raw, _ := hex.DecodeString(eip155RawTx)
+	tx, err := DecodeEthTransaction(raw)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(9), tx.Nonce)
+	assert.Equal(t, uint64(21000), tx.Gas)
+	assert.Equal(t, int64(20000000000), tx.GasPrice.Int64())
+	assert.Equal(t, int64(1), tx.ChainID())
+	assert.Equal(t, eip155SigHash, hex.EncodeToString(tx.SigHash()))
+	assert.Equal(t, raw, tx.Encode())
+
+	priv, _ := hex.DecodeString(eip155PrivKey)
+	_, pub := btcec.PrivKeyFromBytes(btcec.S256(), priv)
+	recovered, err := tx.RecoverPubKey()
+	assert.Nil(t, err)
+	assert.Equal(t, pub.SerializeCompressed(), recovered)
+	sender, err := tx.Sender()
+	assert.Nil(t, err)
+	assert.Equal(t, address.PubKeyToAddress(pub.SerializeCompressed()).Hash160[:], sender)
+
+	// Gas价格超过uint32范围，无法转换为chain33交易
+	_, err = tx.ToChain33Tx(ExecutorName)
+	assert.Equal(t, ErrEthTxValue, err)
+
+	// 修改交易内容后签名不再匹配
+	tx.Nonce = 10
+	recovered, _ = tx.RecoverPubKey()
+	assert.NotEqual(t, pub.SerializeCompressed(), recovered)
+
+	_, err = DecodeEthTransaction(raw[:len(raw)-1])
+	assert.Equal(t, ErrRLPDecode, err)
+	_, err = DecodeEthTransaction(append(raw, 0x80))
+	assert.Equal(t, ErrRLPDecode, err)
+}
+
+func TestNewChain33TxFromEth(t *testing.T) {
+	priv, _ := hex.DecodeString(eip155PrivKey)
+	key, pub := btcec.PrivKeyFromBytes(btcec.S256(), priv)
+	ethTx := &EthTransaction{
+		Nonce:    1,
+		GasPrice: big.NewInt(1),
+		Gas:      200000,
+		Value:    big.NewInt(0),
+		Data:     []byte{0x60, 0x00},
+		V:        big.NewInt(33*2 + 35),
+	}
+	sig, err := btcec.SignCompact(btcec.S256(), key, ethTx.SigHash(), false)
+	assert.Nil(t, err)
+	ethTx.V = big.NewInt(int64(sig[0]-27) + 33*2 + 35)
+	ethTx.R = new(big.Int).SetBytes(sig[1:33])
+	ethTx.S = new(big.Int).SetBytes(sig[33:])
+
+	tx, decoded, err := NewChain33TxFromEth(ethTx.Encode(), ExecutorName)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(33), decoded.ChainID())
+	assert.Equal(t, int64(200000), tx.Fee)
+	assert.Equal(t, int64(1), tx.Nonce)
+	assert.Equal(t, address.ExecAddress(ExecutorName), tx.To)
+	assert.Equal(t, address.PubKeyToAddress(pub.SerializeCompressed()).String(), tx.From())
+	var action EVMContractAction
+	assert.Nil(t, types.Decode(tx.Payload, &action))
+	assert.Equal(t, uint64(200000), action.GasLimit)
+	assert.Equal(t, uint32(1), action.GasPrice)
+	assert.Equal(t, ethTx.Data, action.Code)
+	assert.Equal(t, ethTx.Hash(), EthTxHash(tx))
+
+	_, _, err = NewChain33TxFromEth(ethTx.Encode(), "coins")
+	assert.Equal(t, types.ErrExecNameNotMatch, err)
+}
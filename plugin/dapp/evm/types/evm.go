@@ -42,6 +42,12 @@ func InitFork(cfg *types.Chain33Config) {
 	cfg.RegisterDappFork(ExecutorName, ForkEVMABI, 1250000)
 	// EEVM合约用户金额冻结
 	cfg.RegisterDappFork(ExecutorName, ForkEVMFrozen, 1300000)
+	// EVM合约事件日志保存在交易回执中，主网启用高度待定
+	cfg.RegisterDappFork(ExecutorName, ForkEVMEventLog, types.MaxHeight)
+	// EVM支持康士坦丁堡和伊斯坦布尔版本新增的指令，主网启用高度待定
+	cfg.RegisterDappFork(ExecutorName, ForkEVMIstanbul, types.MaxHeight)
+	// EVM支持以太坊签名格式的交易，主网启用高度待定
+	cfg.RegisterDappFork(ExecutorName, ForkEVMEthTx, types.MaxHeight)
 }
 
 func InitExecutor(cfg *types.Chain33Config) {
@@ -120,6 +126,22 @@ func (evm EvmType) CreateTx(action string, message json.RawMessage) (*types.Tran
 	return nil, types.ErrNotSupport
 }
 
+// GetCryptoDriver 获取签名驱动，以太坊格式的交易使用单独的签名类型
+func (evm *EvmType) GetCryptoDriver(ty int) (string, error) {
+	if ty == EthSecp256k1 {
+		return SignNameEthSecp256k1, nil
+	}
+	return "", types.ErrNotSupport
+}
+
+// GetCryptoType 获取签名类型
+func (evm *EvmType) GetCryptoType(name string) (int, error) {
+	if name == SignNameEthSecp256k1 {
+		return EthSecp256k1, nil
+	}
+	return 0, types.ErrNotSupport
+}
+
 // GetLogMap 获取日志类型映射
 func (evm *EvmType) GetLogMap() map[int64]*types.LogInfo {
 	return logInfo
@@ -36,7 +36,7 @@ func (m *EVMContractObject) Reset()         { *m = EVMContractObject{} }
 func (m *EVMContractObject) String() string { return proto.CompactTextString(m) }
 func (*EVMContractObject) ProtoMessage()    {}
 func (*EVMContractObject) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{0}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{0}
 }
 func (m *EVMContractObject) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EVMContractObject.Unmarshal(m, b)
@@ -96,7 +96,7 @@ func (m *EVMContractData) Reset()         { *m = EVMContractData{} }
 func (m *EVMContractData) String() string { return proto.CompactTextString(m) }
 func (*EVMContractData) ProtoMessage()    {}
 func (*EVMContractData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{1}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{1}
 }
 func (m *EVMContractData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EVMContractData.Unmarshal(m, b)
@@ -180,7 +180,7 @@ func (m *EVMContractState) Reset()         { *m = EVMContractState{} }
 func (m *EVMContractState) String() string { return proto.CompactTextString(m) }
 func (*EVMContractState) ProtoMessage()    {}
 func (*EVMContractState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{2}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{2}
 }
 func (m *EVMContractState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EVMContractState.Unmarshal(m, b)
@@ -253,7 +253,7 @@ func (m *EVMContractAction) Reset()         { *m = EVMContractAction{} }
 func (m *EVMContractAction) String() string { return proto.CompactTextString(m) }
 func (*EVMContractAction) ProtoMessage()    {}
 func (*EVMContractAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{3}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{3}
 }
 func (m *EVMContractAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EVMContractAction.Unmarshal(m, b)
@@ -341,7 +341,7 @@ func (m *ReceiptEVMContract) Reset()         { *m = ReceiptEVMContract{} }
 func (m *ReceiptEVMContract) String() string { return proto.CompactTextString(m) }
 func (*ReceiptEVMContract) ProtoMessage()    {}
 func (*ReceiptEVMContract) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{4}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{4}
 }
 func (m *ReceiptEVMContract) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiptEVMContract.Unmarshal(m, b)
@@ -417,7 +417,7 @@ func (m *EVMStateChangeItem) Reset()         { *m = EVMStateChangeItem{} }
 func (m *EVMStateChangeItem) String() string { return proto.CompactTextString(m) }
 func (*EVMStateChangeItem) ProtoMessage()    {}
 func (*EVMStateChangeItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{5}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{5}
 }
 func (m *EVMStateChangeItem) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EVMStateChangeItem.Unmarshal(m, b)
@@ -458,6 +458,134 @@ func (m *EVMStateChangeItem) GetCurrentValue() []byte {
 	return nil
 }
 
+// 合约事件日志，对应EVM中的LOG0-4指令 ForkEVMEventLog
+type EVMContractEventLog struct {
+	// 产生事件的合约地址
+	Address string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Topics  [][]byte `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
+	Data    []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// 事件序号
+	Index                int32    `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EVMContractEventLog) Reset()         { *m = EVMContractEventLog{} }
+func (m *EVMContractEventLog) String() string { return proto.CompactTextString(m) }
+func (*EVMContractEventLog) ProtoMessage()    {}
+func (*EVMContractEventLog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{6}
+}
+func (m *EVMContractEventLog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EVMContractEventLog.Unmarshal(m, b)
+}
+func (m *EVMContractEventLog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EVMContractEventLog.Marshal(b, m, deterministic)
+}
+func (dst *EVMContractEventLog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EVMContractEventLog.Merge(dst, src)
+}
+func (m *EVMContractEventLog) XXX_Size() int {
+	return xxx_messageInfo_EVMContractEventLog.Size(m)
+}
+func (m *EVMContractEventLog) XXX_DiscardUnknown() {
+	xxx_messageInfo_EVMContractEventLog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EVMContractEventLog proto.InternalMessageInfo
+
+func (m *EVMContractEventLog) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *EVMContractEventLog) GetTopics() [][]byte {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+func (m *EVMContractEventLog) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *EVMContractEventLog) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+// 保存在localdb中的合约事件，用于按合约地址和事件主题检索
+type EVMEventRecord struct {
+	Log                  *EVMContractEventLog `protobuf:"bytes,1,opt,name=log,proto3" json:"log,omitempty"`
+	TxHash               string               `protobuf:"bytes,2,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	Height               int64                `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	TxIndex              int32                `protobuf:"varint,4,opt,name=txIndex,proto3" json:"txIndex,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *EVMEventRecord) Reset()         { *m = EVMEventRecord{} }
+func (m *EVMEventRecord) String() string { return proto.CompactTextString(m) }
+func (*EVMEventRecord) ProtoMessage()    {}
+func (*EVMEventRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{7}
+}
+func (m *EVMEventRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EVMEventRecord.Unmarshal(m, b)
+}
+func (m *EVMEventRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EVMEventRecord.Marshal(b, m, deterministic)
+}
+func (dst *EVMEventRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EVMEventRecord.Merge(dst, src)
+}
+func (m *EVMEventRecord) XXX_Size() int {
+	return xxx_messageInfo_EVMEventRecord.Size(m)
+}
+func (m *EVMEventRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_EVMEventRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EVMEventRecord proto.InternalMessageInfo
+
+func (m *EVMEventRecord) GetLog() *EVMContractEventLog {
+	if m != nil {
+		return m.Log
+	}
+	return nil
+}
+
+func (m *EVMEventRecord) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *EVMEventRecord) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *EVMEventRecord) GetTxIndex() int32 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
 // 存放合约固定数据
 type EVMContractDataCmd struct {
 	Creator              string   `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
@@ -475,7 +603,7 @@ func (m *EVMContractDataCmd) Reset()         { *m = EVMContractDataCmd{} }
 func (m *EVMContractDataCmd) String() string { return proto.CompactTextString(m) }
 func (*EVMContractDataCmd) ProtoMessage()    {}
 func (*EVMContractDataCmd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{6}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{8}
 }
 func (m *EVMContractDataCmd) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EVMContractDataCmd.Unmarshal(m, b)
@@ -552,7 +680,7 @@ func (m *EVMContractStateCmd) Reset()         { *m = EVMContractStateCmd{} }
 func (m *EVMContractStateCmd) String() string { return proto.CompactTextString(m) }
 func (*EVMContractStateCmd) ProtoMessage()    {}
 func (*EVMContractStateCmd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{7}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{9}
 }
 func (m *EVMContractStateCmd) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EVMContractStateCmd.Unmarshal(m, b)
@@ -618,7 +746,7 @@ func (m *ReceiptEVMContractCmd) Reset()         { *m = ReceiptEVMContractCmd{} }
 func (m *ReceiptEVMContractCmd) String() string { return proto.CompactTextString(m) }
 func (*ReceiptEVMContractCmd) ProtoMessage()    {}
 func (*ReceiptEVMContractCmd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{8}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{10}
 }
 func (m *ReceiptEVMContractCmd) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiptEVMContractCmd.Unmarshal(m, b)
@@ -684,7 +812,7 @@ func (m *CheckEVMAddrReq) Reset()         { *m = CheckEVMAddrReq{} }
 func (m *CheckEVMAddrReq) String() string { return proto.CompactTextString(m) }
 func (*CheckEVMAddrReq) ProtoMessage()    {}
 func (*CheckEVMAddrReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{9}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{11}
 }
 func (m *CheckEVMAddrReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckEVMAddrReq.Unmarshal(m, b)
@@ -725,7 +853,7 @@ func (m *CheckEVMAddrResp) Reset()         { *m = CheckEVMAddrResp{} }
 func (m *CheckEVMAddrResp) String() string { return proto.CompactTextString(m) }
 func (*CheckEVMAddrResp) ProtoMessage()    {}
 func (*CheckEVMAddrResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{10}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{12}
 }
 func (m *CheckEVMAddrResp) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckEVMAddrResp.Unmarshal(m, b)
@@ -788,7 +916,7 @@ func (m *EstimateEVMGasReq) Reset()         { *m = EstimateEVMGasReq{} }
 func (m *EstimateEVMGasReq) String() string { return proto.CompactTextString(m) }
 func (*EstimateEVMGasReq) ProtoMessage()    {}
 func (*EstimateEVMGasReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{11}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{13}
 }
 func (m *EstimateEVMGasReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EstimateEVMGasReq.Unmarshal(m, b)
@@ -854,7 +982,7 @@ func (m *EstimateEVMGasResp) Reset()         { *m = EstimateEVMGasResp{} }
 func (m *EstimateEVMGasResp) String() string { return proto.CompactTextString(m) }
 func (*EstimateEVMGasResp) ProtoMessage()    {}
 func (*EstimateEVMGasResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{12}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{14}
 }
 func (m *EstimateEVMGasResp) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EstimateEVMGasResp.Unmarshal(m, b)
@@ -893,7 +1021,7 @@ func (m *EvmDebugReq) Reset()         { *m = EvmDebugReq{} }
 func (m *EvmDebugReq) String() string { return proto.CompactTextString(m) }
 func (*EvmDebugReq) ProtoMessage()    {}
 func (*EvmDebugReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{13}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{15}
 }
 func (m *EvmDebugReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EvmDebugReq.Unmarshal(m, b)
@@ -931,7 +1059,7 @@ func (m *EvmDebugResp) Reset()         { *m = EvmDebugResp{} }
 func (m *EvmDebugResp) String() string { return proto.CompactTextString(m) }
 func (*EvmDebugResp) ProtoMessage()    {}
 func (*EvmDebugResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{14}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{16}
 }
 func (m *EvmDebugResp) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EvmDebugResp.Unmarshal(m, b)
@@ -969,7 +1097,7 @@ func (m *EvmQueryAbiReq) Reset()         { *m = EvmQueryAbiReq{} }
 func (m *EvmQueryAbiReq) String() string { return proto.CompactTextString(m) }
 func (*EvmQueryAbiReq) ProtoMessage()    {}
 func (*EvmQueryAbiReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{15}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{17}
 }
 func (m *EvmQueryAbiReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EvmQueryAbiReq.Unmarshal(m, b)
@@ -1008,7 +1136,7 @@ func (m *EvmQueryAbiResp) Reset()         { *m = EvmQueryAbiResp{} }
 func (m *EvmQueryAbiResp) String() string { return proto.CompactTextString(m) }
 func (*EvmQueryAbiResp) ProtoMessage()    {}
 func (*EvmQueryAbiResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{16}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{18}
 }
 func (m *EvmQueryAbiResp) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EvmQueryAbiResp.Unmarshal(m, b)
@@ -1042,6 +1170,459 @@ func (m *EvmQueryAbiResp) GetAbi() string {
 	return ""
 }
 
+type EvmQueryCodeReq struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvmQueryCodeReq) Reset()         { *m = EvmQueryCodeReq{} }
+func (m *EvmQueryCodeReq) String() string { return proto.CompactTextString(m) }
+func (*EvmQueryCodeReq) ProtoMessage()    {}
+func (*EvmQueryCodeReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{19}
+}
+func (m *EvmQueryCodeReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmQueryCodeReq.Unmarshal(m, b)
+}
+func (m *EvmQueryCodeReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmQueryCodeReq.Marshal(b, m, deterministic)
+}
+func (dst *EvmQueryCodeReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmQueryCodeReq.Merge(dst, src)
+}
+func (m *EvmQueryCodeReq) XXX_Size() int {
+	return xxx_messageInfo_EvmQueryCodeReq.Size(m)
+}
+func (m *EvmQueryCodeReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmQueryCodeReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmQueryCodeReq proto.InternalMessageInfo
+
+func (m *EvmQueryCodeReq) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type EvmQueryCodeResp struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Code                 []byte   `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	CodeHash             []byte   `protobuf:"bytes,3,opt,name=codeHash,proto3" json:"codeHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvmQueryCodeResp) Reset()         { *m = EvmQueryCodeResp{} }
+func (m *EvmQueryCodeResp) String() string { return proto.CompactTextString(m) }
+func (*EvmQueryCodeResp) ProtoMessage()    {}
+func (*EvmQueryCodeResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{20}
+}
+func (m *EvmQueryCodeResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmQueryCodeResp.Unmarshal(m, b)
+}
+func (m *EvmQueryCodeResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmQueryCodeResp.Marshal(b, m, deterministic)
+}
+func (dst *EvmQueryCodeResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmQueryCodeResp.Merge(dst, src)
+}
+func (m *EvmQueryCodeResp) XXX_Size() int {
+	return xxx_messageInfo_EvmQueryCodeResp.Size(m)
+}
+func (m *EvmQueryCodeResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmQueryCodeResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmQueryCodeResp proto.InternalMessageInfo
+
+func (m *EvmQueryCodeResp) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *EvmQueryCodeResp) GetCode() []byte {
+	if m != nil {
+		return m.Code
+	}
+	return nil
+}
+
+func (m *EvmQueryCodeResp) GetCodeHash() []byte {
+	if m != nil {
+		return m.CodeHash
+	}
+	return nil
+}
+
+// 查询合约存储数据，key为十六进制格式的存储槽
+type EvmQueryStorageReq struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvmQueryStorageReq) Reset()         { *m = EvmQueryStorageReq{} }
+func (m *EvmQueryStorageReq) String() string { return proto.CompactTextString(m) }
+func (*EvmQueryStorageReq) ProtoMessage()    {}
+func (*EvmQueryStorageReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{21}
+}
+func (m *EvmQueryStorageReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmQueryStorageReq.Unmarshal(m, b)
+}
+func (m *EvmQueryStorageReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmQueryStorageReq.Marshal(b, m, deterministic)
+}
+func (dst *EvmQueryStorageReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmQueryStorageReq.Merge(dst, src)
+}
+func (m *EvmQueryStorageReq) XXX_Size() int {
+	return xxx_messageInfo_EvmQueryStorageReq.Size(m)
+}
+func (m *EvmQueryStorageReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmQueryStorageReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmQueryStorageReq proto.InternalMessageInfo
+
+func (m *EvmQueryStorageReq) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *EvmQueryStorageReq) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type EvmQueryStorageResp struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string   `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvmQueryStorageResp) Reset()         { *m = EvmQueryStorageResp{} }
+func (m *EvmQueryStorageResp) String() string { return proto.CompactTextString(m) }
+func (*EvmQueryStorageResp) ProtoMessage()    {}
+func (*EvmQueryStorageResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{22}
+}
+func (m *EvmQueryStorageResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmQueryStorageResp.Unmarshal(m, b)
+}
+func (m *EvmQueryStorageResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmQueryStorageResp.Marshal(b, m, deterministic)
+}
+func (dst *EvmQueryStorageResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmQueryStorageResp.Merge(dst, src)
+}
+func (m *EvmQueryStorageResp) XXX_Size() int {
+	return xxx_messageInfo_EvmQueryStorageResp.Size(m)
+}
+func (m *EvmQueryStorageResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmQueryStorageResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmQueryStorageResp proto.InternalMessageInfo
+
+func (m *EvmQueryStorageResp) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *EvmQueryStorageResp) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *EvmQueryStorageResp) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// 查询合约事件，address和topic至少指定一个
+type EvmQueryEventReq struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// 事件签名哈希，即topic0
+	Topic      string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	PrimaryKey string `protobuf:"bytes,3,opt,name=primaryKey,proto3" json:"primaryKey,omitempty"`
+	Count      int32  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	// 0: 从新到旧，1: 从旧到新
+	Direction            int32    `protobuf:"varint,5,opt,name=direction,proto3" json:"direction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvmQueryEventReq) Reset()         { *m = EvmQueryEventReq{} }
+func (m *EvmQueryEventReq) String() string { return proto.CompactTextString(m) }
+func (*EvmQueryEventReq) ProtoMessage()    {}
+func (*EvmQueryEventReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{23}
+}
+func (m *EvmQueryEventReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmQueryEventReq.Unmarshal(m, b)
+}
+func (m *EvmQueryEventReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmQueryEventReq.Marshal(b, m, deterministic)
+}
+func (dst *EvmQueryEventReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmQueryEventReq.Merge(dst, src)
+}
+func (m *EvmQueryEventReq) XXX_Size() int {
+	return xxx_messageInfo_EvmQueryEventReq.Size(m)
+}
+func (m *EvmQueryEventReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmQueryEventReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmQueryEventReq proto.InternalMessageInfo
+
+func (m *EvmQueryEventReq) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *EvmQueryEventReq) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *EvmQueryEventReq) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
+func (m *EvmQueryEventReq) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *EvmQueryEventReq) GetDirection() int32 {
+	if m != nil {
+		return m.Direction
+	}
+	return 0
+}
+
+type EvmQueryTxEventReq struct {
+	TxHash               string   `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvmQueryTxEventReq) Reset()         { *m = EvmQueryTxEventReq{} }
+func (m *EvmQueryTxEventReq) String() string { return proto.CompactTextString(m) }
+func (*EvmQueryTxEventReq) ProtoMessage()    {}
+func (*EvmQueryTxEventReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{24}
+}
+func (m *EvmQueryTxEventReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmQueryTxEventReq.Unmarshal(m, b)
+}
+func (m *EvmQueryTxEventReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmQueryTxEventReq.Marshal(b, m, deterministic)
+}
+func (dst *EvmQueryTxEventReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmQueryTxEventReq.Merge(dst, src)
+}
+func (m *EvmQueryTxEventReq) XXX_Size() int {
+	return xxx_messageInfo_EvmQueryTxEventReq.Size(m)
+}
+func (m *EvmQueryTxEventReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmQueryTxEventReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmQueryTxEventReq proto.InternalMessageInfo
+
+func (m *EvmQueryTxEventReq) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+type EvmEvent struct {
+	Address  string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Topics   []string `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
+	Data     string   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	TxHash   string   `protobuf:"bytes,4,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	Height   int64    `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	TxIndex  int32    `protobuf:"varint,6,opt,name=txIndex,proto3" json:"txIndex,omitempty"`
+	LogIndex int32    `protobuf:"varint,7,opt,name=logIndex,proto3" json:"logIndex,omitempty"`
+	// 根据合约绑定的ABI解析出的事件名称和参数
+	EventName            string   `protobuf:"bytes,8,opt,name=eventName,proto3" json:"eventName,omitempty"`
+	JsonData             string   `protobuf:"bytes,9,opt,name=jsonData,proto3" json:"jsonData,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvmEvent) Reset()         { *m = EvmEvent{} }
+func (m *EvmEvent) String() string { return proto.CompactTextString(m) }
+func (*EvmEvent) ProtoMessage()    {}
+func (*EvmEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{25}
+}
+func (m *EvmEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmEvent.Unmarshal(m, b)
+}
+func (m *EvmEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmEvent.Marshal(b, m, deterministic)
+}
+func (dst *EvmEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmEvent.Merge(dst, src)
+}
+func (m *EvmEvent) XXX_Size() int {
+	return xxx_messageInfo_EvmEvent.Size(m)
+}
+func (m *EvmEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmEvent proto.InternalMessageInfo
+
+func (m *EvmEvent) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *EvmEvent) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+func (m *EvmEvent) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *EvmEvent) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *EvmEvent) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *EvmEvent) GetTxIndex() int32 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *EvmEvent) GetLogIndex() int32 {
+	if m != nil {
+		return m.LogIndex
+	}
+	return 0
+}
+
+func (m *EvmEvent) GetEventName() string {
+	if m != nil {
+		return m.EventName
+	}
+	return ""
+}
+
+func (m *EvmEvent) GetJsonData() string {
+	if m != nil {
+		return m.JsonData
+	}
+	return ""
+}
+
+type EvmQueryEventResp struct {
+	Events               []*EvmEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	PrimaryKey           string      `protobuf:"bytes,2,opt,name=primaryKey,proto3" json:"primaryKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *EvmQueryEventResp) Reset()         { *m = EvmQueryEventResp{} }
+func (m *EvmQueryEventResp) String() string { return proto.CompactTextString(m) }
+func (*EvmQueryEventResp) ProtoMessage()    {}
+func (*EvmQueryEventResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{26}
+}
+func (m *EvmQueryEventResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvmQueryEventResp.Unmarshal(m, b)
+}
+func (m *EvmQueryEventResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvmQueryEventResp.Marshal(b, m, deterministic)
+}
+func (dst *EvmQueryEventResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvmQueryEventResp.Merge(dst, src)
+}
+func (m *EvmQueryEventResp) XXX_Size() int {
+	return xxx_messageInfo_EvmQueryEventResp.Size(m)
+}
+func (m *EvmQueryEventResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvmQueryEventResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvmQueryEventResp proto.InternalMessageInfo
+
+func (m *EvmQueryEventResp) GetEvents() []*EvmEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *EvmQueryEventResp) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
 type EvmQueryReq struct {
 	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	Input                string   `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
@@ -1055,7 +1636,7 @@ func (m *EvmQueryReq) Reset()         { *m = EvmQueryReq{} }
 func (m *EvmQueryReq) String() string { return proto.CompactTextString(m) }
 func (*EvmQueryReq) ProtoMessage()    {}
 func (*EvmQueryReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{17}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{27}
 }
 func (m *EvmQueryReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EvmQueryReq.Unmarshal(m, b)
@@ -1111,7 +1692,7 @@ func (m *EvmQueryResp) Reset()         { *m = EvmQueryResp{} }
 func (m *EvmQueryResp) String() string { return proto.CompactTextString(m) }
 func (*EvmQueryResp) ProtoMessage()    {}
 func (*EvmQueryResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{18}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{28}
 }
 func (m *EvmQueryResp) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EvmQueryResp.Unmarshal(m, b)
@@ -1184,7 +1765,7 @@ func (m *EvmContractCreateReq) Reset()         { *m = EvmContractCreateReq{} }
 func (m *EvmContractCreateReq) String() string { return proto.CompactTextString(m) }
 func (*EvmContractCreateReq) ProtoMessage()    {}
 func (*EvmContractCreateReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{19}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{29}
 }
 func (m *EvmContractCreateReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EvmContractCreateReq.Unmarshal(m, b)
@@ -1279,7 +1860,7 @@ func (m *EvmContractCallReq) Reset()         { *m = EvmContractCallReq{} }
 func (m *EvmContractCallReq) String() string { return proto.CompactTextString(m) }
 func (*EvmContractCallReq) ProtoMessage()    {}
 func (*EvmContractCallReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{20}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{30}
 }
 func (m *EvmContractCallReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EvmContractCallReq.Unmarshal(m, b)
@@ -1378,7 +1959,7 @@ func (m *EvmContractTransferReq) Reset()         { *m = EvmContractTransferReq{}
 func (m *EvmContractTransferReq) String() string { return proto.CompactTextString(m) }
 func (*EvmContractTransferReq) ProtoMessage()    {}
 func (*EvmContractTransferReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_evmcontract_555b7353802de0b0, []int{21}
+	return fileDescriptor_evmcontract_a61f3759b2a4006f, []int{31}
 }
 func (m *EvmContractTransferReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EvmContractTransferReq.Unmarshal(m, b)
@@ -1448,6 +2029,8 @@ func init() {
 	proto.RegisterType((*EVMContractAction)(nil), "types.EVMContractAction")
 	proto.RegisterType((*ReceiptEVMContract)(nil), "types.ReceiptEVMContract")
 	proto.RegisterType((*EVMStateChangeItem)(nil), "types.EVMStateChangeItem")
+	proto.RegisterType((*EVMContractEventLog)(nil), "types.EVMContractEventLog")
+	proto.RegisterType((*EVMEventRecord)(nil), "types.EVMEventRecord")
 	proto.RegisterType((*EVMContractDataCmd)(nil), "types.EVMContractDataCmd")
 	proto.RegisterType((*EVMContractStateCmd)(nil), "types.EVMContractStateCmd")
 	proto.RegisterMapType((map[string]string)(nil), "types.EVMContractStateCmd.StorageEntry")
@@ -1460,6 +2043,14 @@ func init() {
 	proto.RegisterType((*EvmDebugResp)(nil), "types.EvmDebugResp")
 	proto.RegisterType((*EvmQueryAbiReq)(nil), "types.EvmQueryAbiReq")
 	proto.RegisterType((*EvmQueryAbiResp)(nil), "types.EvmQueryAbiResp")
+	proto.RegisterType((*EvmQueryCodeReq)(nil), "types.EvmQueryCodeReq")
+	proto.RegisterType((*EvmQueryCodeResp)(nil), "types.EvmQueryCodeResp")
+	proto.RegisterType((*EvmQueryStorageReq)(nil), "types.EvmQueryStorageReq")
+	proto.RegisterType((*EvmQueryStorageResp)(nil), "types.EvmQueryStorageResp")
+	proto.RegisterType((*EvmQueryEventReq)(nil), "types.EvmQueryEventReq")
+	proto.RegisterType((*EvmQueryTxEventReq)(nil), "types.EvmQueryTxEventReq")
+	proto.RegisterType((*EvmEvent)(nil), "types.EvmEvent")
+	proto.RegisterType((*EvmQueryEventResp)(nil), "types.EvmQueryEventResp")
 	proto.RegisterType((*EvmQueryReq)(nil), "types.EvmQueryReq")
 	proto.RegisterType((*EvmQueryResp)(nil), "types.EvmQueryResp")
 	proto.RegisterType((*EvmContractCreateReq)(nil), "types.EvmContractCreateReq")
@@ -1467,71 +2058,91 @@ func init() {
 	proto.RegisterType((*EvmContractTransferReq)(nil), "types.EvmContractTransferReq")
 }
 
-func init() { proto.RegisterFile("evmcontract.proto", fileDescriptor_evmcontract_555b7353802de0b0) }
-
-var fileDescriptor_evmcontract_555b7353802de0b0 = []byte{
-	// 1004 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x56, 0xcf, 0x8f, 0xdb, 0x44,
-	0x14, 0x96, 0x63, 0x27, 0x1b, 0xbf, 0x0d, 0xed, 0xae, 0x29, 0x4b, 0xb4, 0x42, 0x28, 0xb2, 0x28,
-	0xac, 0x2a, 0xb1, 0x42, 0xe5, 0x82, 0x2a, 0x81, 0xb4, 0x4a, 0xa3, 0x82, 0xc4, 0xf2, 0x63, 0x0a,
-	0xe1, 0x3c, 0xb1, 0x67, 0xb3, 0x6e, 0xe3, 0x1f, 0xcc, 0x4c, 0xd2, 0xe6, 0xca, 0x99, 0x23, 0x47,
-	0x6e, 0x1c, 0xb9, 0x20, 0x71, 0xe3, 0xcc, 0x1f, 0xc1, 0x99, 0x13, 0x7f, 0x06, 0x7a, 0xcf, 0x63,
-	0x7b, 0xec, 0x4d, 0x2a, 0x90, 0x2a, 0xc4, 0x29, 0xf3, 0xcd, 0x3c, 0xcf, 0x7c, 0xdf, 0xbc, 0xef,
-	0xbd, 0x09, 0x1c, 0x8b, 0x4d, 0x1a, 0xe5, 0x99, 0x96, 0x3c, 0xd2, 0xe7, 0x85, 0xcc, 0x75, 0x1e,
-	0xf4, 0xf5, 0xb6, 0x10, 0x2a, 0xfc, 0xce, 0x81, 0xe3, 0xd9, 0xfc, 0x72, 0x6a, 0x16, 0x3f, 0x5f,
-	0x3c, 0x11, 0x91, 0x0e, 0x02, 0xf0, 0x78, 0x1c, 0xcb, 0xb1, 0x33, 0x71, 0xce, 0x7c, 0x46, 0xe3,
-	0xe0, 0x1e, 0x78, 0x31, 0xd7, 0x7c, 0xdc, 0x9b, 0x38, 0x67, 0x87, 0xf7, 0x4f, 0xce, 0xe9, 0xfb,
-	0x73, 0xeb, 0xdb, 0x87, 0x5c, 0x73, 0x46, 0x31, 0xc1, 0xbb, 0xd0, 0x57, 0x9a, 0x6b, 0x31, 0x76,
-	0x29, 0xf8, 0xf5, 0x9b, 0xc1, 0x8f, 0x71, 0x99, 0x95, 0x51, 0xe1, 0xcf, 0x0e, 0xdc, 0xee, 0x6c,
-	0x14, 0x8c, 0xe1, 0x20, 0x92, 0x82, 0xeb, 0xbc, 0x62, 0x51, 0x41, 0x24, 0x97, 0xf1, 0x54, 0x10,
-	0x11, 0x9f, 0xd1, 0x38, 0xb8, 0x03, 0x7d, 0xbe, 0x4a, 0xb8, 0xa2, 0x03, 0x7d, 0x56, 0x82, 0x5a,
-	0x86, 0x67, 0xc9, 0x08, 0xc0, 0x8b, 0xf2, 0x58, 0x8c, 0xfb, 0x13, 0xe7, 0x6c, 0xc4, 0x68, 0x1c,
-	0x9c, 0xc2, 0x10, 0x7f, 0x3f, 0xe6, 0xea, 0x7a, 0x3c, 0xa0, 0xf9, 0x1a, 0x07, 0x47, 0xe0, 0xf2,
-	0x45, 0x32, 0x3e, 0xa0, 0x2d, 0x70, 0x18, 0xfe, 0xe9, 0xc0, 0x51, 0x57, 0x09, 0x12, 0xc8, 0xf2,
-	0x2c, 0x12, 0x44, 0xd6, 0x63, 0x25, 0xc0, 0x8d, 0xd5, 0x3a, 0x89, 0x92, 0x58, 0xc4, 0x44, 0x77,
-	0xc8, 0x6a, 0x1c, 0x4c, 0xe0, 0x50, 0xe9, 0x5c, 0xf2, 0x65, 0x79, 0xae, 0x4b, 0xe7, 0xda, 0x53,
-	0xc1, 0x47, 0x70, 0x60, 0xe0, 0xd8, 0x9b, 0xb8, 0x67, 0x87, 0xf7, 0xdf, 0xda, 0x73, 0x8f, 0xe7,
-	0x8f, 0xcb, 0xb0, 0x59, 0xa6, 0xe5, 0x96, 0x55, 0x1f, 0x9d, 0x3e, 0x80, 0x91, 0xbd, 0x80, 0x52,
-	0x9e, 0x8a, 0xad, 0xb9, 0x4e, 0x1c, 0x22, 0xeb, 0x0d, 0x5f, 0xad, 0xcb, 0xbb, 0x1c, 0xb1, 0x12,
-	0x3c, 0xe8, 0x7d, 0xe0, 0x84, 0xbf, 0xb6, 0x7d, 0x71, 0x11, 0xe9, 0x24, 0xcf, 0x82, 0x13, 0x18,
-	0xf0, 0x34, 0x5f, 0x67, 0xda, 0xc8, 0x34, 0x08, 0x75, 0x2e, 0xb9, 0xfa, 0x34, 0x49, 0x13, 0x4d,
-	0x5b, 0x79, 0xac, 0xc6, 0x66, 0xed, 0x0b, 0x99, 0x44, 0xa5, 0x1d, 0x5e, 0x61, 0x35, 0xae, 0x93,
-	0xe1, 0x59, 0xc9, 0xa8, 0x53, 0xd9, 0xef, 0xa4, 0x32, 0xcb, 0xb5, 0xa0, 0xf4, 0x60, 0xd2, 0x73,
-	0x2d, 0x76, 0xa4, 0xe6, 0x37, 0x07, 0x02, 0x26, 0x22, 0x91, 0x14, 0xda, 0x22, 0x8f, 0xb4, 0x23,
-	0xbe, 0x5a, 0x89, 0xca, 0x4a, 0x06, 0x05, 0x21, 0x8c, 0xaa, 0xaa, 0xf8, 0xac, 0x71, 0x54, 0x6b,
-	0xce, 0x8e, 0xb9, 0x40, 0x2f, 0xb9, 0xed, 0x18, 0x9c, 0x43, 0xaf, 0xae, 0x95, 0x88, 0x1f, 0x71,
-	0x45, 0x4a, 0x3c, 0x56, 0x41, 0xa4, 0x28, 0x85, 0x36, 0x66, 0xc3, 0x21, 0xc6, 0x3e, 0x51, 0x79,
-	0xc6, 0x84, 0x36, 0x5a, 0x2a, 0x18, 0x5e, 0x41, 0x30, 0x9b, 0x5f, 0x52, 0x42, 0xa7, 0xd7, 0x3c,
-	0x5b, 0x8a, 0x4f, 0xb4, 0x48, 0x77, 0x24, 0xed, 0x14, 0x86, 0x85, 0x14, 0x73, 0x2b, 0x6f, 0x35,
-	0x26, 0xb6, 0x6b, 0x29, 0x45, 0xa6, 0xcb, 0xf5, 0xd2, 0x55, 0xad, 0xb9, 0xf0, 0x47, 0x87, 0x0e,
-	0xb2, 0xab, 0x6d, 0x9a, 0xc6, 0xff, 0x49, 0xc1, 0xf9, 0x7b, 0x0a, 0xce, 0x6f, 0x0a, 0x2e, 0xfc,
-	0xcb, 0x81, 0x57, 0xbb, 0x06, 0x47, 0x7e, 0x2f, 0xa5, 0xc2, 0xfc, 0x76, 0x85, 0x5d, 0x74, 0x2b,
-	0xec, 0x9d, 0x3d, 0x15, 0x36, 0x4d, 0xe3, 0x97, 0x53, 0x64, 0xbe, 0x5d, 0x64, 0x3f, 0x39, 0xf0,
-	0xda, 0x4d, 0xbb, 0xa2, 0xd8, 0xff, 0x85, 0x63, 0x7d, 0x72, 0x6c, 0x78, 0x17, 0x6e, 0x4f, 0xaf,
-	0x45, 0xf4, 0x74, 0x36, 0xbf, 0xc4, 0x6f, 0x99, 0xf8, 0x76, 0xd7, 0xfb, 0x10, 0xfe, 0xe0, 0xc0,
-	0x51, 0x3b, 0x4e, 0x15, 0x65, 0xa2, 0xcb, 0x73, 0x29, 0x78, 0xc8, 0x6a, 0x7c, 0x83, 0x67, 0x6f,
-	0x07, 0xcf, 0xae, 0x5e, 0x77, 0x87, 0xde, 0x37, 0xc0, 0x27, 0xf7, 0x51, 0x40, 0xe9, 0xbc, 0x66,
-	0x22, 0xdc, 0xc2, 0xf1, 0x4c, 0xe9, 0x24, 0xe5, 0x5a, 0xcc, 0xe6, 0x97, 0x8f, 0xb8, 0x42, 0xfe,
-	0xb7, 0xa0, 0xa7, 0x73, 0xc3, 0xbe, 0xa7, 0xf3, 0xda, 0xa3, 0x3d, 0xab, 0x0f, 0x35, 0x29, 0x70,
-	0x5b, 0x29, 0x68, 0x7a, 0xa0, 0xd7, 0xea, 0x81, 0xa6, 0x1b, 0xf5, 0x9b, 0x6e, 0xf4, 0x36, 0x04,
-	0xdd, 0xa3, 0x55, 0x81, 0x71, 0x4b, 0xae, 0x8c, 0x8b, 0x71, 0x18, 0xde, 0x85, 0xc3, 0xd9, 0x26,
-	0x7d, 0x28, 0x16, 0xeb, 0x25, 0x92, 0x3b, 0x81, 0x41, 0x5e, 0xa0, 0x0d, 0x29, 0xa6, 0xcf, 0x0c,
-	0x0a, 0xdf, 0x83, 0x51, 0x13, 0xa6, 0x0a, 0xb4, 0x77, 0x8c, 0x00, 0x0d, 0xba, 0x56, 0x46, 0x8d,
-	0x3d, 0x15, 0xde, 0x83, 0x5b, 0xb3, 0x4d, 0xfa, 0xe5, 0x5a, 0xc8, 0xed, 0xc5, 0x22, 0xc1, 0xbd,
-	0xc7, 0x70, 0x80, 0xc9, 0x12, 0xaa, 0x8a, 0xaf, 0x60, 0xf8, 0x21, 0xdc, 0x6e, 0xc5, 0xaa, 0x62,
-	0x7f, 0x70, 0xa5, 0xb5, 0xd7, 0x68, 0xfd, 0x9a, 0x34, 0xd0, 0xe7, 0x2f, 0x3c, 0x07, 0xab, 0x21,
-	0xc9, 0x8a, 0xb5, 0xae, 0xaa, 0x81, 0xc0, 0xbe, 0xcb, 0x0e, 0xbf, 0x77, 0x48, 0xb4, 0xd9, 0xf7,
-	0x85, 0x9c, 0xfe, 0xd5, 0xc6, 0xb8, 0x8f, 0xe4, 0xcf, 0xb0, 0xf7, 0x19, 0xcb, 0x54, 0x10, 0x2d,
-	0x8b, 0x1d, 0x99, 0x96, 0xca, 0x64, 0xd6, 0x38, 0xfc, 0xdd, 0x81, 0x3b, 0xb3, 0x4d, 0x5a, 0x57,
-	0x2a, 0x36, 0x48, 0x61, 0x0a, 0x82, 0x0c, 0xe4, 0x58, 0x4d, 0xee, 0x08, 0xdc, 0x2b, 0x51, 0x7a,
-	0xca, 0x65, 0x38, 0xac, 0x1f, 0x31, 0xd7, 0x7a, 0xc4, 0xea, 0x46, 0xea, 0xd9, 0x8d, 0xb4, 0xa1,
-	0xdd, 0x6f, 0xd1, 0x36, 0x17, 0x3f, 0xa8, 0x2f, 0x1e, 0x23, 0xc5, 0xf3, 0x22, 0x91, 0xc2, 0xbc,
-	0x83, 0x06, 0xd1, 0x2b, 0xc1, 0x25, 0xa7, 0xa2, 0x18, 0x96, 0x32, 0x2a, 0x1c, 0xfe, 0x81, 0x2f,
-	0x80, 0x25, 0x83, 0xaf, 0x56, 0xc6, 0x78, 0x3b, 0x5f, 0x77, 0xbb, 0x3a, 0x3a, 0xe2, 0xdc, 0x9b,
-	0xe2, 0x3c, 0x4b, 0xdc, 0x3f, 0x97, 0x11, 0x80, 0x27, 0x9e, 0x8b, 0xc8, 0x88, 0xa0, 0xb1, 0x25,
-	0x6d, 0xb8, 0x57, 0x9a, 0xdf, 0x91, 0xf6, 0x8b, 0x03, 0x27, 0x96, 0xb4, 0xaf, 0x24, 0xcf, 0xd4,
-	0x95, 0x90, 0x46, 0xde, 0xce, 0x9e, 0xda, 0xc8, 0x46, 0x81, 0x3d, 0x5b, 0x36, 0x51, 0x72, 0x77,
-	0x52, 0xf2, 0x5a, 0x94, 0xde, 0x04, 0x48, 0xd4, 0x37, 0x89, 0xbe, 0x8e, 0x25, 0x7f, 0x46, 0x62,
-	0x87, 0xcc, 0x9a, 0x69, 0x51, 0x1e, 0xb4, 0x29, 0x2f, 0x06, 0xf4, 0x87, 0xfc, 0xfd, 0xbf, 0x03,
-	0x00, 0x00, 0xff, 0xff, 0x77, 0x17, 0xf5, 0x15, 0xa5, 0x0b, 0x00, 0x00,
+func init() { proto.RegisterFile("evmcontract.proto", fileDescriptor_evmcontract_a61f3759b2a4006f) }
+
+var fileDescriptor_evmcontract_a61f3759b2a4006f = []byte{
+	// 1322 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0x3d, 0x6f, 0x1c, 0x45,
+	0x18, 0xd6, 0xde, 0xee, 0x9e, 0x6f, 0x5f, 0x1f, 0xb1, 0x3d, 0x09, 0xe6, 0x64, 0xa1, 0xc8, 0x1a,
+	0x11, 0x62, 0x85, 0x60, 0xa1, 0xd0, 0xa0, 0x48, 0x20, 0x2c, 0xe7, 0x14, 0x22, 0x62, 0x3e, 0x26,
+	0xc1, 0x69, 0xd2, 0x8c, 0x77, 0x27, 0xe7, 0x4d, 0x6e, 0x3f, 0xb2, 0x33, 0x77, 0xf1, 0xb5, 0x54,
+	0x14, 0x94, 0x88, 0x8a, 0x8e, 0x92, 0x06, 0x89, 0x8e, 0x9a, 0x1f, 0x41, 0x4d, 0x45, 0xc9, 0x4f,
+	0x40, 0xef, 0xec, 0xec, 0xee, 0xec, 0xfa, 0xce, 0x04, 0x29, 0x42, 0x54, 0x37, 0xcf, 0xec, 0x3b,
+	0x33, 0xcf, 0x33, 0xef, 0xd7, 0x1c, 0x6c, 0x89, 0x79, 0x12, 0x66, 0xa9, 0x2a, 0x78, 0xa8, 0xf6,
+	0xf3, 0x22, 0x53, 0x19, 0xf1, 0xd5, 0x22, 0x17, 0x92, 0x7e, 0xed, 0xc0, 0xd6, 0xf8, 0xf8, 0xe8,
+	0xd0, 0x7c, 0xfc, 0xfc, 0xe4, 0xa9, 0x08, 0x15, 0x21, 0xe0, 0xf1, 0x28, 0x2a, 0x46, 0xce, 0xae,
+	0xb3, 0x17, 0x30, 0x3d, 0x26, 0x37, 0xc0, 0x8b, 0xb8, 0xe2, 0xa3, 0xde, 0xae, 0xb3, 0xb7, 0x7e,
+	0x6b, 0x7b, 0x5f, 0xaf, 0xdf, 0xb7, 0xd6, 0xde, 0xe1, 0x8a, 0x33, 0x6d, 0x43, 0xde, 0x05, 0x5f,
+	0x2a, 0xae, 0xc4, 0xc8, 0xd5, 0xc6, 0x6f, 0x9c, 0x37, 0x7e, 0x80, 0x9f, 0x59, 0x69, 0x45, 0x7f,
+	0x72, 0x60, 0xa3, 0xb3, 0x11, 0x19, 0xc1, 0x5a, 0x58, 0x08, 0xae, 0xb2, 0x8a, 0x45, 0x05, 0x91,
+	0x5c, 0xca, 0x13, 0xa1, 0x89, 0x04, 0x4c, 0x8f, 0xc9, 0x15, 0xf0, 0xf9, 0x34, 0xe6, 0x52, 0x1f,
+	0x18, 0xb0, 0x12, 0xd4, 0x32, 0x3c, 0x4b, 0x06, 0x01, 0x2f, 0xcc, 0x22, 0x31, 0xf2, 0x77, 0x9d,
+	0xbd, 0x21, 0xd3, 0x63, 0xb2, 0x03, 0x03, 0xfc, 0xfd, 0x84, 0xcb, 0xd3, 0x51, 0x5f, 0xcf, 0xd7,
+	0x98, 0x6c, 0x82, 0xcb, 0x4f, 0xe2, 0xd1, 0x9a, 0xde, 0x02, 0x87, 0xf4, 0x0f, 0x07, 0x36, 0xbb,
+	0x4a, 0x90, 0x40, 0x9a, 0xa5, 0xa1, 0xd0, 0x64, 0x3d, 0x56, 0x02, 0xdc, 0x58, 0xce, 0xe2, 0x30,
+	0x8e, 0x44, 0xa4, 0xe9, 0x0e, 0x58, 0x8d, 0xc9, 0x2e, 0xac, 0x4b, 0x95, 0x15, 0x7c, 0x52, 0x9e,
+	0xeb, 0xea, 0x73, 0xed, 0x29, 0xf2, 0x11, 0xac, 0x19, 0x38, 0xf2, 0x76, 0xdd, 0xbd, 0xf5, 0x5b,
+	0x6f, 0xad, 0xb8, 0xc7, 0xfd, 0x07, 0xa5, 0xd9, 0x38, 0x55, 0xc5, 0x82, 0x55, 0x8b, 0x76, 0x6e,
+	0xc3, 0xd0, 0xfe, 0x80, 0x52, 0x9e, 0x89, 0x85, 0xb9, 0x4e, 0x1c, 0x22, 0xeb, 0x39, 0x9f, 0xce,
+	0xca, 0xbb, 0x1c, 0xb2, 0x12, 0xdc, 0xee, 0x7d, 0xe0, 0xd0, 0x5f, 0xda, 0x71, 0x71, 0x10, 0xaa,
+	0x38, 0x4b, 0xc9, 0x36, 0xf4, 0x79, 0x92, 0xcd, 0x52, 0x65, 0x64, 0x1a, 0x84, 0x3a, 0x27, 0x5c,
+	0xde, 0x8f, 0x93, 0x58, 0xe9, 0xad, 0x3c, 0x56, 0x63, 0xf3, 0xed, 0x8b, 0x22, 0x0e, 0xcb, 0x70,
+	0x78, 0x8d, 0xd5, 0xb8, 0x76, 0x86, 0x67, 0x39, 0xa3, 0x76, 0xa5, 0xdf, 0x71, 0x65, 0x9a, 0x29,
+	0x31, 0xea, 0x1b, 0xa7, 0x67, 0x4a, 0x2c, 0x71, 0xcd, 0xaf, 0x0e, 0x10, 0x26, 0x42, 0x11, 0xe7,
+	0xca, 0x22, 0x8f, 0xb4, 0x43, 0x3e, 0x9d, 0x8a, 0x2a, 0x94, 0x0c, 0x22, 0x14, 0x86, 0x55, 0x56,
+	0x7c, 0xd6, 0x44, 0x54, 0x6b, 0xce, 0xb6, 0x39, 0xc0, 0x58, 0x72, 0xdb, 0x36, 0x38, 0x87, 0xb1,
+	0x3a, 0x93, 0x22, 0xba, 0xcb, 0xa5, 0x56, 0xe2, 0xb1, 0x0a, 0x22, 0xc5, 0x42, 0x28, 0x13, 0x6c,
+	0x38, 0x44, 0xdb, 0xa7, 0x32, 0x4b, 0x99, 0x50, 0x46, 0x4b, 0x05, 0xe9, 0x13, 0x20, 0xe3, 0xe3,
+	0x23, 0xed, 0xd0, 0xc3, 0x53, 0x9e, 0x4e, 0xc4, 0x3d, 0x25, 0x92, 0x25, 0x4e, 0xdb, 0x81, 0x41,
+	0x5e, 0x88, 0x63, 0xcb, 0x6f, 0x35, 0xd6, 0x6c, 0x67, 0x45, 0x21, 0x52, 0x55, 0x7e, 0x2f, 0xa3,
+	0xaa, 0x35, 0x47, 0x9f, 0xc3, 0x65, 0xeb, 0x72, 0xc6, 0x73, 0x91, 0xaa, 0xfb, 0xd9, 0x04, 0x89,
+	0x61, 0x82, 0x08, 0x29, 0xab, 0x84, 0x33, 0x10, 0xaf, 0x4f, 0x65, 0x79, 0x1c, 0xca, 0x51, 0x6f,
+	0xd7, 0xdd, 0x1b, 0x32, 0x83, 0xd0, 0x27, 0xba, 0x22, 0x94, 0x87, 0xe8, 0x31, 0x7a, 0x2f, 0x4e,
+	0x23, 0x71, 0xa6, 0x2f, 0xc2, 0x67, 0x25, 0xa0, 0xdf, 0x38, 0x70, 0x69, 0x7c, 0x7c, 0xa4, 0xcf,
+	0x62, 0x22, 0xcc, 0x8a, 0x88, 0xdc, 0x04, 0x77, 0x9a, 0x4d, 0xf4, 0x51, 0xeb, 0xb7, 0x76, 0xce,
+	0x07, 0x76, 0xc5, 0x8b, 0xa1, 0x99, 0xa6, 0x70, 0xa6, 0xf3, 0xa4, 0xf4, 0x91, 0x41, 0x38, 0x7f,
+	0x2a, 0xe2, 0xc9, 0xa9, 0xd2, 0x24, 0x5c, 0x66, 0x10, 0x8a, 0x51, 0x67, 0xf7, 0x2c, 0x22, 0x15,
+	0xa4, 0x3f, 0x38, 0x40, 0xac, 0x63, 0xb0, 0xd6, 0x1c, 0x26, 0xd1, 0x7f, 0x52, 0x6e, 0x82, 0x15,
+	0xe5, 0x26, 0x68, 0xca, 0x0d, 0xfd, 0xd3, 0x69, 0x79, 0xa7, 0x8c, 0x86, 0x24, 0x7a, 0x35, 0xf5,
+	0x25, 0x68, 0xd7, 0x97, 0x83, 0x6e, 0x7d, 0xb9, 0xbe, 0xa2, 0xbe, 0x1c, 0x26, 0xd1, 0xab, 0x29,
+	0x31, 0x81, 0x5d, 0x62, 0x7e, 0x74, 0xe0, 0xf5, 0xf3, 0xc9, 0x8a, 0x62, 0xff, 0x17, 0xf9, 0x1a,
+	0xe8, 0x7c, 0xa5, 0xd7, 0x60, 0xe3, 0xf0, 0x54, 0x84, 0xcf, 0xc6, 0xc7, 0x47, 0xb8, 0x96, 0x89,
+	0xe7, 0xcb, 0xba, 0x23, 0xfd, 0xce, 0x81, 0xcd, 0xb6, 0x9d, 0xcc, 0x4b, 0x47, 0x97, 0xe7, 0x6a,
+	0xe3, 0x01, 0xab, 0xf1, 0x39, 0x9e, 0xbd, 0x25, 0x3c, 0xbb, 0x7a, 0xdd, 0x25, 0x7a, 0xdf, 0x84,
+	0x40, 0x47, 0x9f, 0x36, 0x28, 0x23, 0xaf, 0x99, 0xa0, 0x0b, 0xd8, 0x1a, 0x4b, 0x15, 0x27, 0x5c,
+	0x89, 0xf1, 0xf1, 0xd1, 0x5d, 0x2e, 0x91, 0xff, 0x25, 0xe8, 0xa9, 0xcc, 0xb0, 0xef, 0xa9, 0xac,
+	0x8e, 0xd1, 0x9e, 0x55, 0x85, 0x1b, 0x17, 0xb8, 0x2d, 0x17, 0x34, 0x1d, 0xc0, 0x6b, 0x75, 0x00,
+	0x53, 0x8b, 0xfd, 0xa6, 0x16, 0xbf, 0x0d, 0xa4, 0x7b, 0xb4, 0xcc, 0xd1, 0x6e, 0xc2, 0xa5, 0x89,
+	0x62, 0x1c, 0xd2, 0x6b, 0xb0, 0x3e, 0x9e, 0x27, 0x77, 0xc4, 0xc9, 0x6c, 0x82, 0xe4, 0xb6, 0xa1,
+	0x9f, 0xe5, 0x18, 0x86, 0xda, 0xc6, 0x67, 0x06, 0xd1, 0xf7, 0x60, 0xd8, 0x98, 0xc9, 0x1c, 0xc3,
+	0x3b, 0x42, 0x80, 0x01, 0x3a, 0xab, 0x4a, 0x96, 0x3d, 0x45, 0x6f, 0xc0, 0xa5, 0xf1, 0x3c, 0xf9,
+	0x72, 0x26, 0x8a, 0xc5, 0xc1, 0x49, 0x8c, 0x7b, 0xaf, 0x2c, 0x71, 0xf4, 0x43, 0xd8, 0x68, 0xd9,
+	0xca, 0x7c, 0xb5, 0x71, 0xa5, 0xb5, 0xd7, 0x68, 0x7d, 0xa7, 0x59, 0x7e, 0x98, 0x45, 0xe2, 0xe2,
+	0xb3, 0x1e, 0xc3, 0x66, 0xdb, 0xf8, 0xc2, 0xc3, 0x96, 0x39, 0xc7, 0x2e, 0x20, 0x6e, 0xfb, 0xbd,
+	0x42, 0x3f, 0x06, 0x52, 0xed, 0x6e, 0x32, 0xf3, 0x42, 0x36, 0x55, 0xc6, 0xf6, 0xea, 0x8c, 0xa5,
+	0x8f, 0xe0, 0xf2, 0xb9, 0x1d, 0x64, 0xfe, 0x6f, 0xb6, 0x68, 0x92, 0xde, 0xb5, 0x92, 0x9e, 0x7e,
+	0xef, 0x34, 0xca, 0x4d, 0x2b, 0xb8, 0x88, 0xd9, 0x15, 0xf0, 0x75, 0xa3, 0xa9, 0x2a, 0x87, 0x06,
+	0xe4, 0x2a, 0x40, 0x5e, 0xc4, 0x09, 0x2f, 0x16, 0x9f, 0x8a, 0x85, 0xd9, 0xdf, 0x9a, 0xc1, 0x55,
+	0x61, 0x1d, 0x9f, 0x3e, 0x2b, 0x01, 0x66, 0x49, 0x14, 0x17, 0x42, 0xbf, 0x62, 0x74, 0x90, 0xfa,
+	0xac, 0x99, 0xa0, 0x37, 0x9b, 0x3b, 0x7b, 0x78, 0x56, 0x33, 0x6b, 0x7a, 0x8e, 0x63, 0xf7, 0x1c,
+	0xfa, 0x97, 0x03, 0x83, 0xf1, 0x3c, 0xd1, 0x76, 0x2f, 0xdd, 0x35, 0x83, 0xa5, 0x5d, 0x33, 0x30,
+	0x5d, 0xb3, 0x39, 0xca, 0x5b, 0xd1, 0xde, 0xfc, 0x55, 0xed, 0xad, 0xdf, 0x6a, 0x6f, 0x18, 0x1a,
+	0xd3, 0x6c, 0x52, 0x7e, 0x5a, 0xd3, 0x9f, 0x6a, 0x8c, 0x97, 0x20, 0x90, 0xb4, 0x2e, 0x15, 0x83,
+	0xb2, 0x54, 0xd4, 0x13, 0xb8, 0x12, 0x5f, 0x22, 0xd8, 0x10, 0x47, 0x41, 0xd9, 0x95, 0x2a, 0x4c,
+	0x1f, 0xc3, 0x56, 0xc7, 0x71, 0x32, 0x27, 0xd7, 0xa1, 0xaf, 0x57, 0xa3, 0x72, 0xec, 0x1e, 0x1b,
+	0x55, 0xf7, 0x30, 0x77, 0xc3, 0xcc, 0xe7, 0x8e, 0xcb, 0x7a, 0x5d, 0x97, 0xd1, 0xaf, 0x60, 0xbd,
+	0xda, 0xfd, 0x1f, 0x23, 0x22, 0x4e, 0xf3, 0x99, 0xaa, 0x22, 0x42, 0x83, 0x55, 0xa5, 0x8a, 0x7e,
+	0xeb, 0xc0, 0xb0, 0xd9, 0x57, 0xe6, 0xaf, 0x6a, 0x63, 0xdc, 0xa7, 0xe0, 0x2f, 0xf4, 0x45, 0x95,
+	0xee, 0xaa, 0x60, 0xeb, 0x0e, 0xfd, 0xce, 0x1d, 0xfe, 0xe6, 0xc0, 0x95, 0xf1, 0x3c, 0xa9, 0xfb,
+	0x5c, 0x21, 0xb8, 0x12, 0xa6, 0x9d, 0xe8, 0x0c, 0x77, 0xac, 0x27, 0xc2, 0x26, 0xb8, 0x4f, 0x44,
+	0x99, 0xf4, 0x2e, 0xc3, 0x61, 0xfd, 0x00, 0x76, 0xad, 0x07, 0x70, 0xfd, 0x0c, 0xf1, 0xec, 0x67,
+	0x48, 0x43, 0xdb, 0x6f, 0xd1, 0x36, 0x65, 0xab, 0x5f, 0x97, 0x2d, 0xb4, 0x14, 0x67, 0x79, 0x5c,
+	0x08, 0xf3, 0x86, 0x36, 0x48, 0xbf, 0x30, 0x79, 0xc1, 0xad, 0x38, 0xa9, 0x31, 0xfd, 0xdd, 0x01,
+	0x62, 0xcb, 0xe0, 0xd3, 0xa9, 0x49, 0x96, 0xa5, 0xff, 0x0c, 0xec, 0xf2, 0xd5, 0x11, 0xe7, 0x9e,
+	0x17, 0xe7, 0x59, 0xe2, 0x5e, 0x5e, 0x06, 0x01, 0x4f, 0x9c, 0x89, 0xd0, 0x88, 0xd0, 0x63, 0x4b,
+	0xda, 0x60, 0xa5, 0xb4, 0xa0, 0x23, 0xed, 0x67, 0x07, 0xb6, 0x2d, 0x69, 0x0f, 0x0b, 0x9e, 0xca,
+	0x27, 0xa2, 0x30, 0xf2, 0x96, 0xbe, 0x48, 0x1a, 0xd9, 0x28, 0xb0, 0x67, 0xcb, 0xd6, 0x94, 0xdc,
+	0xa5, 0x94, 0xbc, 0x16, 0xa5, 0xab, 0x00, 0xb1, 0x7c, 0x14, 0xab, 0xd3, 0xa8, 0xe0, 0x2f, 0xb4,
+	0xd8, 0x01, 0xb3, 0x66, 0x5a, 0x94, 0xfb, 0x6d, 0xca, 0x27, 0x7d, 0xfd, 0x67, 0xfe, 0xfd, 0xbf,
+	0x07, 0x00, 0x51, 0x62, 0x12, 0x3e, 0xe1, 0x0f, 0x00, 0x00,
 }
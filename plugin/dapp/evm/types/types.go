@@ -24,6 +24,8 @@ const (
 	TyLogCallContract = 603
 	// TyLogEVMStateChangeItem  合约状态数据变更项日志
 	TyLogEVMStateChangeItem = 604
+	// TyLogEVMEventData  合约事件日志
+	TyLogEVMEventData = 605
 
 	// MaxGasLimit  最大Gas消耗上限
 	MaxGasLimit = 10000000
@@ -40,6 +42,12 @@ const (
 	ForkEVMABI = "ForkEVMABI"
 	// ForkEVMFrozen EVM合约用户金额冻结
 	ForkEVMFrozen = "ForkEVMFrozen"
+	// ForkEVMEventLog EVM合约事件日志保存在交易回执中
+	ForkEVMEventLog = "ForkEVMEventLog"
+	// ForkEVMIstanbul EVM支持CREATE2、EXTCODEHASH、CHAINID、SELFBALANCE指令
+	ForkEVMIstanbul = "ForkEVMIstanbul"
+	// ForkEVMEthTx EVM支持以太坊签名格式的交易
+	ForkEVMEthTx = "ForkEVMEthTx"
 )
 
 var (
@@ -58,5 +66,6 @@ var (
 		TyLogContractData:       {Ty: reflect.TypeOf(EVMContractData{}), Name: "LogContractData"},
 		TyLogContractState:      {Ty: reflect.TypeOf(EVMContractState{}), Name: "LogContractState"},
 		TyLogEVMStateChangeItem: {Ty: reflect.TypeOf(EVMStateChangeItem{}), Name: "LogEVMStateChangeItem"},
+		TyLogEVMEventData:       {Ty: reflect.TypeOf(EVMContractEventLog{}), Name: "LogEVMEventData"},
 	}
 )
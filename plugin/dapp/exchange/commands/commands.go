@@ -2,6 +2,13 @@
 package commands
 
 import (
+	"fmt"
+	"os"
+
+	jsonrpc "github.com/33cn/chain33/rpc/jsonclient"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+	et "github.com/33cn/plugin/plugin/dapp/exchange/types"
 	"github.com/spf13/cobra"
 )
 
@@ -17,7 +24,116 @@ func Cmd() *cobra.Command {
 		Args:  cobra.MinimumNArgs(1),
 	}
 	cmd.AddCommand(
-	//add sub command
+		KlineCmd(),
+		TickerCmd(),
 	)
 	return cmd
 }
+
+func addAssetFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("left_exec", "", "", "left asset execer, eg: coins")
+	cmd.MarkFlagRequired("left_exec")
+	cmd.Flags().StringP("left_symbol", "", "", "left asset symbol, eg: bty")
+	cmd.MarkFlagRequired("left_symbol")
+	cmd.Flags().StringP("right_exec", "", "", "right asset execer, eg: token")
+	cmd.MarkFlagRequired("right_exec")
+	cmd.Flags().StringP("right_symbol", "", "", "right asset symbol, eg: CCNY")
+	cmd.MarkFlagRequired("right_symbol")
+}
+
+func getAssetFlags(cmd *cobra.Command) (*et.Asset, *et.Asset) {
+	leftExec, _ := cmd.Flags().GetString("left_exec")
+	leftSymbol, _ := cmd.Flags().GetString("left_symbol")
+	rightExec, _ := cmd.Flags().GetString("right_exec")
+	rightSymbol, _ := cmd.Flags().GetString("right_symbol")
+	return &et.Asset{Execer: leftExec, Symbol: leftSymbol}, &et.Asset{Execer: rightExec, Symbol: rightSymbol}
+}
+
+// KlineCmd : show kline of the trading pair
+func KlineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kline",
+		Short: "Show kline(OHLCV) of the trading pair",
+		Run:   showKline,
+	}
+	addKlineFlags(cmd)
+	return cmd
+}
+
+func addKlineFlags(cmd *cobra.Command) {
+	addAssetFlags(cmd)
+	cmd.Flags().StringP("period", "p", et.KlinePeriod1m, "kline period (1m, 5m, 1h or 1d)")
+	cmd.Flags().Int64P("start", "s", 0, "start time of the kline, 0 means no limit")
+	cmd.Flags().Int64P("end", "e", 0, "end time of the kline, 0 means no limit")
+	cmd.Flags().StringP("primary", "k", "", "primary key of the last page")
+	cmd.Flags().Int32P("count", "c", 10, "count of the kline, max 20")
+	cmd.Flags().Int32P("direction", "d", 0, "query direction, 0 desc, 1 asc")
+}
+
+func showKline(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	period, _ := cmd.Flags().GetString("period")
+	start, _ := cmd.Flags().GetInt64("start")
+	end, _ := cmd.Flags().GetInt64("end")
+	primary, _ := cmd.Flags().GetString("primary")
+	count, _ := cmd.Flags().GetInt32("count")
+	direction, _ := cmd.Flags().GetInt32("direction")
+	if et.GetKlineSeconds(period) == 0 {
+		fmt.Fprintln(os.Stderr, et.ErrKlinePeriod)
+		return
+	}
+	left, right := getAssetFlags(cmd)
+	req := &et.QueryKline{
+		LeftAsset:  left,
+		RightAsset: right,
+		Period:     period,
+		StartTime:  start,
+		EndTime:    end,
+		PrimaryKey: primary,
+		Count:      count,
+		Direction:  direction,
+	}
+	params := rpctypes.Query4Jrpc{
+		Execer:   et.ExchangeX,
+		FuncName: et.FuncNameQueryKline,
+		Payload:  types.MustPBToJSON(req),
+	}
+	var res et.KlineList
+	ctx := jsonrpc.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &res)
+	ctx.Run()
+}
+
+// TickerCmd : show 24h ticker of the trading pair
+func TickerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ticker",
+		Short: "Show 24h ticker of the trading pair",
+		Run:   showTicker,
+	}
+	addTickerFlags(cmd)
+	return cmd
+}
+
+func addTickerFlags(cmd *cobra.Command) {
+	addAssetFlags(cmd)
+	cmd.Flags().Int64P("time", "t", 0, "end time of the 24h window, 0 means the last block time")
+}
+
+func showTicker(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	endTime, _ := cmd.Flags().GetInt64("time")
+	left, right := getAssetFlags(cmd)
+	req := &et.QueryTicker{
+		LeftAsset:  left,
+		RightAsset: right,
+		Time:       endTime,
+	}
+	params := rpctypes.Query4Jrpc{
+		Execer:   et.ExchangeX,
+		FuncName: et.FuncNameQueryTicker,
+		Payload:  types.MustPBToJSON(req),
+	}
+	var res et.Ticker
+	ctx := jsonrpc.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &res)
+	ctx.Run()
+}
@@ -77,7 +77,20 @@ func (e *exchange) CheckTx(tx *types.Transaction, index int) error {
 		}
 	}
 	if exchange.Ty == exchangetypes.TyMarketOrderAction {
-		return types.ErrActionNotSupport
+		marketOrder := exchange.GetMarketOrder()
+		left := marketOrder.GetLeftAsset()
+		right := marketOrder.GetRightAsset()
+		amount := marketOrder.GetAmount()
+		op := marketOrder.GetOp()
+		if !CheckExchangeAsset(left, right) {
+			return exchangetypes.ErrAsset
+		}
+		if !CheckAmount(amount) {
+			return exchangetypes.ErrAssetAmount
+		}
+		if !CheckOp(op) {
+			return exchangetypes.ErrAssetOp
+		}
 	}
 	return nil
 }
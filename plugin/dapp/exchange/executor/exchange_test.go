@@ -439,6 +439,125 @@ func TestExchange(t *testing.T) {
 	assert.Equal(t, nil, err)
 }
 
+func TestMarketOrder(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	InitExecType()
+	dir, stateDB, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, stateDB)
+	execAddr := address.ExecAddress(et.ExchangeX)
+	accBty, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	accCCNY, _ := account.NewAccountDB(cfg, "token", "CCNY", stateDB)
+	for _, addr := range Nodes {
+		accBty.SaveExecAccount(execAddr, &types.Account{Balance: 100 * types.Coin, Addr: addr})
+		accCCNY.SaveExecAccount(execAddr, &types.Account{Balance: 100 * types.Coin, Addr: addr})
+	}
+	env := &execEnv{
+		10,
+		1,
+		1539918074,
+	}
+	left := &et.Asset{Symbol: "bty", Execer: "coins"}
+	right := &et.Asset{Execer: "token", Symbol: "CCNY"}
+
+	/*
+	  市价买单测试
+	  用例说明：
+	    1.A先挂价格为1和2,数量都为5的卖单
+	    2.B市价买入8,按价格由低往高成交
+	    3.C市价买入10,只能成交剩余的2,未成交部分退回
+	*/
+	Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 100000000, Amount: 5 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	orderList, err := Exec_QueryOrderList(et.Ordered, Nodes[0], "", stateDB, kvdb)
+	assert.Nil(t, err)
+	orderID1 := orderList.List[0].OrderID
+	Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 200000000, Amount: 5 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	orderList, err = Exec_QueryOrderList(et.Ordered, Nodes[0], "", stateDB, kvdb)
+	assert.Nil(t, err)
+	orderID2 := orderList.List[0].OrderID
+
+	err = Exec_MarketOrder(t, &et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: 8 * types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	orderList, err = Exec_QueryOrderList(et.Completed, Nodes[1], "", stateDB, kvdb)
+	assert.Nil(t, err)
+	order := orderList.List[0]
+	assert.Equal(t, int32(et.TyMarketOrderAction), order.Ty)
+	assert.Equal(t, 8*types.Coin, order.Executed)
+	assert.Equal(t, int64(0), order.Balance)
+	// (5*1+3*2)/8=1.375
+	assert.Equal(t, int64(137500000), order.AVGPrice)
+	assert.Equal(t, int32(2), order.MatchCount)
+	order, err = Exec_QueryOrder(orderID1, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(et.Completed), order.Status)
+	assert.Equal(t, int32(1), order.MatchCount)
+	order, err = Exec_QueryOrder(orderID2, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(et.Ordered), order.Status)
+	assert.Equal(t, 2*types.Coin, order.Balance)
+	assert.Equal(t, int32(1), order.MatchCount)
+	//100-5*1-3*2=89
+	acc := accCCNY.LoadExecAccount(Nodes[1], execAddr)
+	assert.Equal(t, 89*types.Coin, acc.Balance)
+	acc = accBty.LoadExecAccount(Nodes[1], execAddr)
+	assert.Equal(t, 108*types.Coin, acc.Balance)
+	marketDepthList, err := Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpSell}, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, 2*types.Coin, marketDepthList.List[0].GetAmount())
+
+	err = Exec_MarketOrder(t, &et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: 10 * types.Coin, Op: et.OpBuy}, PrivKeyC, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	orderList, err = Exec_QueryOrderList(et.Revoked, Nodes[2], "", stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, 2*types.Coin, orderList.List[0].Executed)
+	assert.Equal(t, 8*types.Coin, orderList.List[0].Balance)
+	//未成交部分不冻结
+	acc = accCCNY.LoadExecAccount(Nodes[2], execAddr)
+	assert.Equal(t, 96*types.Coin, acc.Balance)
+	assert.Equal(t, int64(0), acc.Frozen)
+	_, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpSell}, stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+
+	/*
+	  市价卖单测试
+	  用例说明：
+	    1.D挂价格为1,数量为10的买单
+	    2.A市价卖出4
+	*/
+	Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 100000000, Amount: 10 * types.Coin, Op: et.OpBuy}, PrivKeyD, stateDB, kvdb, env)
+	orderList, err = Exec_QueryOrderList(et.Ordered, Nodes[3], "", stateDB, kvdb)
+	assert.Nil(t, err)
+	orderID3 := orderList.List[0].OrderID
+	err = Exec_MarketOrder(t, &et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: 4 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	order, err = Exec_QueryOrder(orderID3, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(et.Ordered), order.Status)
+	assert.Equal(t, 6*types.Coin, order.Balance)
+	//100+5*1+5*2+4*1=119
+	acc = accCCNY.LoadExecAccount(Nodes[0], execAddr)
+	assert.Equal(t, 119*types.Coin, acc.Balance)
+
+	/*
+	  余额不足测试
+	  用例说明：
+	    1.A挂价格为2,数量为50的卖单
+	    2.B市价买入50,余额89只能成交44.5
+	*/
+	Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 200000000, Amount: 50 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	err = Exec_MarketOrder(t, &et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: 50 * types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	orderList, err = Exec_QueryOrderList(et.Revoked, Nodes[1], "", stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4450000000), orderList.List[0].Executed)
+	assert.Equal(t, int64(550000000), orderList.List[0].Balance)
+	acc = accCCNY.LoadExecAccount(Nodes[1], execAddr)
+	assert.Equal(t, int64(0), acc.Balance)
+	marketDepthList, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpSell}, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(550000000), marketDepthList.List[0].GetAmount())
+}
+
 func Exec_LimitOrder(t *testing.T, limitOrder *et.LimitOrder, privKey string, stateDB db.DB, kvdb db.KVDB, env *execEnv) error {
 	ety := types.LoadExecutorType(et.ExchangeX)
 	tx, err := ety.Create("LimitOrder", limitOrder)
@@ -492,6 +611,59 @@ func Exec_LimitOrder(t *testing.T, limitOrder *et.LimitOrder, privKey string, st
 	return nil
 }
 
+func Exec_MarketOrder(t *testing.T, marketOrder *et.MarketOrder, privKey string, stateDB db.DB, kvdb db.KVDB, env *execEnv) error {
+	ety := types.LoadExecutorType(et.ExchangeX)
+	tx, err := ety.Create("MarketOrder", marketOrder)
+	if err != nil {
+		return err
+	}
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	tx, err = types.FormatTx(cfg, et.ExchangeX, tx)
+	if err != nil {
+		return err
+	}
+	tx, err = signTx(tx, privKey)
+	if err != nil {
+		return err
+	}
+	exec := newExchange()
+	e := exec.(*exchange)
+	err = e.CheckTx(tx, 1)
+	if err != nil {
+		return err
+	}
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	api, _ := client.New(q.Client(), nil)
+	exec.SetAPI(api)
+	exec.SetStateDB(stateDB)
+	exec.SetLocalDB(kvdb)
+	env.blockHeight = env.blockHeight + 1
+	env.blockTime = env.blockTime + 20
+	env.difficulty = env.difficulty + 1
+	exec.SetEnv(env.blockHeight, env.blockTime, env.difficulty)
+	receipt, err := exec.Exec(tx, int(1))
+	if err != nil {
+		return err
+	}
+	for _, kv := range receipt.KV {
+		stateDB.Set(kv.Key, kv.Value)
+	}
+	receiptData := &types.ReceiptData{Ty: receipt.Ty, Logs: receipt.Logs}
+	set, err := exec.ExecLocal(tx, receiptData, int(1))
+	if err != nil {
+		return err
+	}
+	for _, kv := range set.KV {
+		kvdb.Set(kv.Key, kv.Value)
+	}
+	//save to database
+	util.SaveKVList(stateDB, set.KV)
+	assert.Equal(t, types.ExecOk, int(receipt.Ty))
+	return nil
+}
+
 func Exec_RevokeOrder(t *testing.T, orderID int64, privKey string, stateDB db.DB, kvdb db.KVDB, env *execEnv) error {
 	ety := types.LoadExecutorType(et.ExchangeX)
 	tx, err := ety.Create("RevokeOrder", &et.RevokeOrder{OrderID: orderID})
@@ -631,6 +803,385 @@ func signTx(tx *types.Transaction, hexPrivKey string) (*types.Transaction, error
 	return tx, nil
 }
 
+func TestTradeFee(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	InitExecType()
+	dir, stateDB, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, stateDB)
+	execAddr := address.ExecAddress(et.ExchangeX)
+	accBty, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	accCCNY, _ := account.NewAccountDB(cfg, "token", "CCNY", stateDB)
+	for _, addr := range Nodes {
+		accBty.SaveExecAccount(execAddr, &types.Account{Balance: 100 * types.Coin, Addr: addr})
+		accCCNY.SaveExecAccount(execAddr, &types.Account{Balance: 100 * types.Coin, Addr: addr})
+	}
+	env := &execEnv{
+		10,
+		1,
+		1539918074,
+	}
+	left := &et.Asset{Symbol: "bty", Execer: "coins"}
+	right := &et.Asset{Execer: "token", Symbol: "CCNY"}
+	//全局挂单费率万分之十,交易对吃单费率万分之二十
+	feeAddr := "1BQXS6TxaYYG5mADaWij4AxhZZUTpw95a5"
+	setManageConfig(stateDB, et.ManageKeyFeeAddr, feeAddr)
+	setManageConfig(stateDB, et.ManageKeyMakerFee, "10")
+	setManageConfig(stateDB, et.ManageKeyTakerFee+"-bty:CCNY", "20")
+
+	Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 100000000, Amount: 10 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	orderList, err := Exec_QueryOrderList(et.Ordered, Nodes[0], "", stateDB, kvdb)
+	assert.Nil(t, err)
+	orderID1 := orderList.List[0].OrderID
+	Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 100000000, Amount: 10 * types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	orderList, err = Exec_QueryOrderList(et.Completed, Nodes[1], "", stateDB, kvdb)
+	assert.Nil(t, err)
+	//吃单方获得10bty,支付0.02bty
+	assert.Equal(t, int64(2000000), orderList.List[0].TakerFee)
+	order, err := Exec_QueryOrder(orderID1, stateDB, kvdb)
+	assert.Nil(t, err)
+	//挂单方获得10CCNY,支付0.01CCNY
+	assert.Equal(t, int64(1000000), order.MakerFee)
+
+	acc := accBty.LoadExecAccount(Nodes[1], execAddr)
+	assert.Equal(t, 110*types.Coin-2000000, acc.Balance)
+	acc = accCCNY.LoadExecAccount(Nodes[0], execAddr)
+	assert.Equal(t, 110*types.Coin-1000000, acc.Balance)
+	acc = accBty.LoadExecAccount(feeAddr, execAddr)
+	assert.Equal(t, int64(2000000), acc.Balance)
+	acc = accCCNY.LoadExecAccount(feeAddr, execAddr)
+	assert.Equal(t, int64(1000000), acc.Balance)
+}
+
+func TestKline(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	InitExecType()
+	dir, stateDB, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, stateDB)
+	execAddr := address.ExecAddress(et.ExchangeX)
+	accBty, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	accCCNY, _ := account.NewAccountDB(cfg, "token", "CCNY", stateDB)
+	for _, addr := range Nodes {
+		accBty.SaveExecAccount(execAddr, &types.Account{Balance: 100 * types.Coin, Addr: addr})
+		accCCNY.SaveExecAccount(execAddr, &types.Account{Balance: 100 * types.Coin, Addr: addr})
+	}
+	//区块时间每笔交易递增20秒,成交时间分别为1539918114,1539918134,1539918154,1539918174
+	env := &execEnv{
+		1539918074,
+		1,
+		1,
+	}
+	left := &et.Asset{Symbol: "bty", Execer: "coins"}
+	right := &et.Asset{Execer: "token", Symbol: "CCNY"}
+
+	/*
+	  K线测试
+	  用例说明：
+	    1.A挂价格为1,数量为5的卖单
+	    2.B以价格1买入2,按1成交
+	    3.B以价格1.5买入2,按挂单价格1成交
+	    4.C以价格2买入2,按挂单价格1成交1,剩余1挂单
+	    5.A以价格1.8卖出1,按吃单方价格1.8成交,回滚后K线恢复原状
+	*/
+	Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 100000000, Amount: 5 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 100000000, Amount: 2 * types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 150000000, Amount: 2 * types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 200000000, Amount: 2 * types.Coin, Op: et.OpBuy}, PrivKeyC, stateDB, kvdb, env)
+	query := &et.QueryKline{LeftAsset: left, RightAsset: right, Period: et.KlinePeriod1m, Direction: et.ListASC}
+	before, err := Exec_QueryKline(query, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(before.List))
+	err = Exec_LimitOrderWithRollback(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 180000000, Amount: 1 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env, func() {
+		after, err := Exec_QueryKline(query, stateDB, kvdb)
+		assert.Nil(t, err)
+		assert.Equal(t, before, after)
+	})
+	assert.Nil(t, err)
+
+	klineList, err := Exec_QueryKline(query, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(klineList.List))
+	assert.Equal(t, int64(1539918060), klineList.List[0].StartTime)
+	assert.Equal(t, int64(2*types.Coin), klineList.List[0].Volume)
+	assert.Equal(t, int32(1), klineList.List[0].Count)
+	kline := klineList.List[1]
+	assert.Equal(t, int64(1539918120), kline.StartTime)
+	assert.Equal(t, int64(100000000), kline.Open)
+	assert.Equal(t, int64(180000000), kline.High)
+	assert.Equal(t, int64(100000000), kline.Low)
+	assert.Equal(t, int64(180000000), kline.Close)
+	assert.Equal(t, int64(4*types.Coin), kline.Volume)
+	assert.Equal(t, int64(480000000), kline.Turnover)
+	assert.Equal(t, int32(3), kline.Count)
+	//按时间区间降序分页查询
+	query = &et.QueryKline{LeftAsset: left, RightAsset: right, Period: et.KlinePeriod1m, StartTime: 1539918060, EndTime: 1539918120, Count: 1}
+	klineList, err = Exec_QueryKline(query, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(klineList.List))
+	assert.Equal(t, int64(1539918120), klineList.List[0].StartTime)
+	query.PrimaryKey = klineList.PrimaryKey
+	klineList, err = Exec_QueryKline(query, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1539918060), klineList.List[0].StartTime)
+	query.PrimaryKey = klineList.PrimaryKey
+	klineList, err = Exec_QueryKline(query, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(klineList.List))
+	//日K线
+	query = &et.QueryKline{LeftAsset: left, RightAsset: right, Period: et.KlinePeriod1d}
+	klineList, err = Exec_QueryKline(query, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(klineList.List))
+	assert.Equal(t, int64(6*types.Coin), klineList.List[0].Volume)
+	assert.Equal(t, int32(4), klineList.List[0].Count)
+	//其他交易对没有K线
+	query = &et.QueryKline{LeftAsset: left, RightAsset: &et.Asset{Execer: "token", Symbol: "USDT"}, Period: et.KlinePeriod1m}
+	klineList, err = Exec_QueryKline(query, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(klineList.List))
+	_, err = Exec_QueryKline(&et.QueryKline{LeftAsset: left, RightAsset: right, Period: "2m"}, stateDB, kvdb)
+	assert.Equal(t, et.ErrKlinePeriod, err)
+
+	ticker, err := Exec_QueryTicker(&et.QueryTicker{LeftAsset: left, RightAsset: right, Time: env.blockTime}, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(100000000), ticker.Open)
+	assert.Equal(t, int64(180000000), ticker.High)
+	assert.Equal(t, int64(100000000), ticker.Low)
+	assert.Equal(t, int64(180000000), ticker.Close)
+	assert.Equal(t, int64(6*types.Coin), ticker.Volume)
+	assert.Equal(t, int32(4), ticker.Count)
+	//24小时内没有成交,价格为最近一次成交价
+	ticker, err = Exec_QueryTicker(&et.QueryTicker{LeftAsset: left, RightAsset: right, Time: env.blockTime + et.TickerWindow + 60}, stateDB, kvdb)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(180000000), ticker.Open)
+	assert.Equal(t, int64(180000000), ticker.Close)
+	assert.Equal(t, int64(0), ticker.Volume)
+	assert.Equal(t, int32(0), ticker.Count)
+}
+
+//Exec_LimitOrderWithRollback 执行限价单后回滚localdb,检查后再重新执行ExecLocal
+func Exec_LimitOrderWithRollback(t *testing.T, limitOrder *et.LimitOrder, privKey string, stateDB db.DB, kvdb db.KVDB, env *execEnv, check func()) error {
+	ety := types.LoadExecutorType(et.ExchangeX)
+	tx, err := ety.Create("LimitOrder", limitOrder)
+	if err != nil {
+		return err
+	}
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	tx, err = types.FormatTx(cfg, et.ExchangeX, tx)
+	if err != nil {
+		return err
+	}
+	tx, err = signTx(tx, privKey)
+	if err != nil {
+		return err
+	}
+	exec := newExchange()
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	api, _ := client.New(q.Client(), nil)
+	exec.SetAPI(api)
+	exec.SetStateDB(stateDB)
+	exec.SetLocalDB(kvdb)
+	env.blockHeight = env.blockHeight + 1
+	env.blockTime = env.blockTime + 20
+	env.difficulty = env.difficulty + 1
+	exec.SetEnv(env.blockHeight, env.blockTime, env.difficulty)
+	receipt, err := exec.Exec(tx, int(1))
+	if err != nil {
+		return err
+	}
+	for _, kv := range receipt.KV {
+		stateDB.Set(kv.Key, kv.Value)
+	}
+	receiptData := &types.ReceiptData{Ty: receipt.Ty, Logs: receipt.Logs}
+	set, err := exec.ExecLocal(tx, receiptData, int(1))
+	if err != nil {
+		return err
+	}
+	for _, kv := range set.KV {
+		kvdb.Set(kv.Key, kv.Value)
+	}
+	set, err = exec.ExecDelLocal(tx, receiptData, int(1))
+	if err != nil {
+		return err
+	}
+	//回滚时value为nil表示删除,测试用的kvdb和stateDB是同一个数据库
+	for _, kv := range set.KV {
+		if kv.Value == nil {
+			stateDB.Delete(kv.Key)
+			continue
+		}
+		kvdb.Set(kv.Key, kv.Value)
+	}
+	check()
+	set, err = exec.ExecLocal(tx, receiptData, int(1))
+	if err != nil {
+		return err
+	}
+	for _, kv := range set.KV {
+		kvdb.Set(kv.Key, kv.Value)
+	}
+	assert.Equal(t, types.ExecOk, int(receipt.Ty))
+	return nil
+}
+
+func Exec_QueryKline(query *et.QueryKline, stateDB db.KV, kvdb db.KVDB) (*et.KlineList, error) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	exec := newExchange()
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	api, _ := client.New(q.Client(), nil)
+	exec.SetAPI(api)
+	exec.SetStateDB(stateDB)
+	exec.SetLocalDB(kvdb)
+	msg, err := exec.Query(et.FuncNameQueryKline, types.Encode(query))
+	if err != nil {
+		return nil, err
+	}
+	return msg.(*et.KlineList), err
+}
+
+func Exec_QueryTicker(query *et.QueryTicker, stateDB db.KV, kvdb db.KVDB) (*et.Ticker, error) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	exec := newExchange()
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	api, _ := client.New(q.Client(), nil)
+	exec.SetAPI(api)
+	exec.SetStateDB(stateDB)
+	exec.SetLocalDB(kvdb)
+	msg, err := exec.Query(et.FuncNameQueryTicker, types.Encode(query))
+	if err != nil {
+		return nil, err
+	}
+	return msg.(*et.Ticker), err
+}
+
+func setManageConfig(stateDB db.DB, key, value string) {
+	item := &types.ConfigItem{
+		Key:   key,
+		Value: &types.ConfigItem_Arr{Arr: &types.ArrayConfig{Value: []string{value}}},
+	}
+	stateDB.Set([]byte(types.ManageKey(key)), types.Encode(item))
+}
+
+func TestGetMaxMatchCount(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	assert.Equal(t, et.MaxMatchCount, GetMaxMatchCount(cfg, 1))
+	cfg = types.NewChain33Config(types.GetDefaultCfgstring() + "\n[mver.exec.sub.exchange]\nmaxMatchCount=5\n")
+	assert.Equal(t, 5, GetMaxMatchCount(cfg, 1))
+}
+
+func TestMatchLimited(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	InitExecType()
+	dir, stateDB, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, stateDB)
+	execAddr := address.ExecAddress(et.ExchangeX)
+	accBty, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	accCCNY, _ := account.NewAccountDB(cfg, "token", "CCNY", stateDB)
+	for _, addr := range Nodes {
+		accBty.SaveExecAccount(execAddr, &types.Account{Balance: 1000 * types.Coin, Addr: addr})
+		accCCNY.SaveExecAccount(execAddr, &types.Account{Balance: 1000 * types.Coin, Addr: addr})
+	}
+	env := &execEnv{
+		10,
+		1,
+		1539918074,
+	}
+	left := &et.Asset{Symbol: "bty", Execer: "coins"}
+	right := &et.Asset{Execer: "token", Symbol: "CCNY"}
+	sell := func(price int64, n int) {
+		for i := 0; i < n; i++ {
+			_, err := execOrder(t, "LimitOrder", &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: price, Amount: types.Coin, Op: et.OpSell}, et.TyLimitOrderLog, PrivKeyA, stateDB, kvdb, env)
+			assert.Nil(t, err)
+		}
+	}
+
+	/*
+	  用例说明：
+	    1.A挂maxMatchCount笔卖单,B的买单正好全部撮合,没有剩余可以撮合的挂单,不是撮合受限
+	    2.A挂maxMatchCount+1笔卖单,C的买单撮合maxMatchCount笔之后还有可以撮合的挂单,撮合受限
+	    3.A以更高价格再挂maxMatchCount-1笔卖单(避免和C剩余的买单撮合),D的市价买单正好全部撮合,不是撮合受限
+	*/
+	sell(100000000, et.MaxMatchCount)
+	re, err := execOrder(t, "LimitOrder", &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 100000000, Amount: 200 * types.Coin, Op: et.OpBuy}, et.TyLimitOrderLog, PrivKeyB, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(et.MaxMatchCount)*types.Coin, re.Order.Executed)
+	assert.False(t, re.MatchLimited)
+
+	sell(200000000, et.MaxMatchCount+1)
+	re, err = execOrder(t, "LimitOrder", &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 200000000, Amount: 200 * types.Coin, Op: et.OpBuy}, et.TyLimitOrderLog, PrivKeyC, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(et.MaxMatchCount)*types.Coin, re.Order.Executed)
+	assert.True(t, re.MatchLimited)
+
+	sell(300000000, et.MaxMatchCount-1)
+	re, err = execOrder(t, "MarketOrder", &et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: 200 * types.Coin, Op: et.OpBuy}, et.TyMarketOrderLog, PrivKeyD, stateDB, kvdb, env)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(et.MaxMatchCount)*types.Coin, re.Order.Executed)
+	assert.False(t, re.MatchLimited)
+}
+
+// execOrder 执行挂单或市价单交易,返回交易回执中的撮合结果
+func execOrder(t *testing.T, action string, payload types.Message, logTy int32, privKey string, stateDB db.DB, kvdb db.KVDB, env *execEnv) (*et.ReceiptExchange, error) {
+	ety := types.LoadExecutorType(et.ExchangeX)
+	tx, err := ety.Create(action, payload)
+	if err != nil {
+		return nil, err
+	}
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	tx, err = types.FormatTx(cfg, et.ExchangeX, tx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err = signTx(tx, privKey)
+	if err != nil {
+		return nil, err
+	}
+	exec := newExchange()
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	api, _ := client.New(q.Client(), nil)
+	exec.SetAPI(api)
+	exec.SetStateDB(stateDB)
+	exec.SetLocalDB(kvdb)
+	env.blockHeight = env.blockHeight + 1
+	env.blockTime = env.blockTime + 20
+	env.difficulty = env.difficulty + 1
+	exec.SetEnv(env.blockHeight, env.blockTime, env.difficulty)
+	receipt, err := exec.Exec(tx, int(1))
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range receipt.KV {
+		stateDB.Set(kv.Key, kv.Value)
+	}
+	receiptData := &types.ReceiptData{Ty: receipt.Ty, Logs: receipt.Logs}
+	set, err := exec.ExecLocal(tx, receiptData, int(1))
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range set.KV {
+		kvdb.Set(kv.Key, kv.Value)
+	}
+	//save to database
+	util.SaveKVList(stateDB, set.KV)
+	re := &et.ReceiptExchange{}
+	for _, log := range receipt.Logs {
+		if log.Ty == logTy {
+			err = types.Decode(log.Log, re)
+		}
+	}
+	return re, err
+}
+
 func TestCheckPrice(t *testing.T) {
 	t.Log(CheckPrice(1e8))
 	t.Log(CheckPrice(-1))
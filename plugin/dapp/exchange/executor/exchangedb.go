@@ -3,6 +3,7 @@ package executor
 import (
 	"fmt"
 	"math/big"
+	"strconv"
 
 	"github.com/33cn/chain33/account"
 	"github.com/33cn/chain33/client"
@@ -166,6 +167,49 @@ func (a *Action) LimitOrder(payload *et.LimitOrder) (*types.Receipt, error) {
 	return nil, fmt.Errorf("unknow op")
 }
 
+//MarketOrder 市价委托,按对手盘最优价格依次成交,未成交部分不挂单
+func (a *Action) MarketOrder(payload *et.MarketOrder) (*types.Receipt, error) {
+	leftAsset := payload.GetLeftAsset()
+	rightAsset := payload.GetRightAsset()
+	if !CheckExchangeAsset(leftAsset, rightAsset) {
+		return nil, et.ErrAsset
+	}
+	if !CheckAmount(payload.GetAmount()) {
+		return nil, et.ErrAssetAmount
+	}
+	if !CheckOp(payload.GetOp()) {
+		return nil, et.ErrAssetOp
+	}
+	cfg := a.api.GetConfig()
+	leftAssetDB, err := account.NewAccountDB(cfg, leftAsset.GetExecer(), leftAsset.GetSymbol(), a.statedb)
+	if err != nil {
+		return nil, err
+	}
+	rightAssetDB, err := account.NewAccountDB(cfg, rightAsset.GetExecer(), rightAsset.GetSymbol(), a.statedb)
+	if err != nil {
+		return nil, err
+	}
+	//买单成交价格未知,只要求有可用余额,撮合时按余额能支付的量成交
+	if payload.GetOp() == et.OpBuy {
+		rightAccount := rightAssetDB.LoadExecAccount(a.fromaddr, a.execaddr)
+		if rightAccount.Balance <= 0 {
+			elog.Error("MarketOrder.BalanceCheck", "addr", a.fromaddr, "execaddr", a.execaddr, "balance", rightAccount.Balance, "err", et.ErrAssetBalance.Error())
+			return nil, et.ErrAssetBalance
+		}
+		return a.matchMarketOrder(payload, leftAssetDB, rightAssetDB)
+	}
+	if payload.GetOp() == et.OpSell {
+		amount := payload.GetAmount()
+		leftAccount := leftAssetDB.LoadExecAccount(a.fromaddr, a.execaddr)
+		if leftAccount.Balance < amount {
+			elog.Error("MarketOrder.BalanceCheck", "addr", a.fromaddr, "execaddr", a.execaddr, "amount", amount, "err", et.ErrAssetBalance.Error())
+			return nil, et.ErrAssetBalance
+		}
+		return a.matchMarketOrder(payload, leftAssetDB, rightAssetDB)
+	}
+	return nil, fmt.Errorf("unknow op")
+}
+
 func (a *Action) RevokeOrder(payload *et.RevokeOrder) (*types.Receipt, error) {
 	var logs []*types.ReceiptLog
 	var kvs []*types.KeyValue
@@ -271,23 +315,20 @@ func (a *Action) matchLimitOrder(payload *et.LimitOrder, leftAccountDB, rightAcc
 		Order: or,
 		Index: a.GetIndex(),
 	}
+	maxMatchCount := GetMaxMatchCount(a.api.GetConfig(), a.height)
+	fee := a.getFeeConfig(payload.GetLeftAsset(), payload.GetRightAsset())
+	re.FeeAddr = fee.addr
 
-	//单笔交易最多撮合100笔历史订单,最大可撮合得深度，系统得自我防护
+	//单笔交易最多撮合maxMatchCount笔历史订单,最大可撮合得深度，系统得自我防护
 	//迭代已有挂单价格
+MATCH:
 	for {
-		//当撮合深度大于最大深度时跳出
-		if count >= et.MaxMatchCount {
-			break
-		}
 		//获取现有市场挂单价格信息
 		marketDepthList, err := QueryMarketDepth(a.localDB, payload.GetLeftAsset(), payload.GetRightAsset(), a.OpSwap(payload.Op), priceKey, et.Count)
 		if err == types.ErrNotFound {
 			break
 		}
 		for _, marketDepth := range marketDepthList.List {
-			if count >= et.MaxMatchCount {
-				break
-			}
 			// 卖单价大于买单价
 			if payload.Op == et.OpBuy && marketDepth.Price > payload.GetPrice() {
 				continue
@@ -298,26 +339,23 @@ func (a *Action) matchLimitOrder(payload *et.LimitOrder, leftAccountDB, rightAcc
 			}
 			//根据价格进行迭代
 			for {
-				//当撮合深度大于等于最大深度时跳出
-				if count >= et.MaxMatchCount {
-					break
-				}
 				orderList, err := findOrderIDListByPrice(a.localDB, payload.GetLeftAsset(), payload.GetRightAsset(), marketDepth.Price, a.OpSwap(payload.Op), et.ListASC, orderKey)
 				if err == types.ErrNotFound {
 					break
 				}
 
 				for _, matchorder := range orderList.List {
-					//当撮合深度大于最大深度时跳出
-					if count >= et.MaxMatchCount {
-						break
-					}
 					//同地址不能交易
 					if matchorder.Addr == a.fromaddr {
 						continue
 					}
+					//达到最大撮合数量时还有可以撮合的挂单,剩余部分继续挂单,等待后续订单撮合
+					if count >= maxMatchCount {
+						re.MatchLimited = true
+						break MATCH
+					}
 					//撮合,指针传递
-					log, kv, err := a.matchModel(leftAccountDB, rightAccountDB, payload, matchorder, or, re)
+					log, kv, err := a.matchModel(leftAccountDB, rightAccountDB, payload, matchorder, or, re, fee)
 					if err != nil {
 						return nil, err
 					}
@@ -330,7 +368,6 @@ func (a *Action) matchLimitOrder(payload *et.LimitOrder, leftAccountDB, rightAcc
 						receipts := &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}
 						return receipts, nil
 					}
-					//TODO 这里得逻辑是否需要调整?当匹配的单数过多，会导致receipt日志数量激增，理论上存在日志存储攻击，需要加下最大匹配深度，防止这种攻击发生
 					//撮合深度计数
 					count = count + 1
 				}
@@ -376,11 +413,141 @@ func (a *Action) matchLimitOrder(payload *et.LimitOrder, leftAccountDB, rightAcc
 	return receipts, nil
 }
 
+//市价单撮合逻辑
+// 规则：
+//1.买单按卖单价格由低往高撮合，卖单按买单价格由高往低撮合，成交价格为挂单价格
+//2.价格相同按先进先出的原则进行撮合
+//3.市价单不冻结资产，也不挂单，未成交的部分直接退回，订单状态为revoked
+func (a *Action) matchMarketOrder(payload *et.MarketOrder, leftAccountDB, rightAccountDB *account.DB) (*types.Receipt, error) {
+	var logs []*types.ReceiptLog
+	var kvs []*types.KeyValue
+	var orderKey string
+	var priceKey string
+	var count int
+	//买单因余额不足无法成交的量
+	var unfilled int64
+	var rightBalance int64
+
+	or := &et.Order{
+		OrderID:    a.GetIndex(),
+		Value:      &et.Order_MarketOrder{MarketOrder: payload},
+		Ty:         et.TyMarketOrderAction,
+		Executed:   0,
+		AVGPrice:   0,
+		Balance:    payload.GetAmount(),
+		Status:     et.Ordered,
+		Addr:       a.fromaddr,
+		UpdateTime: a.blocktime,
+		Index:      a.GetIndex(),
+	}
+	re := &et.ReceiptExchange{
+		Order: or,
+		Index: a.GetIndex(),
+	}
+	if payload.Op == et.OpBuy {
+		rightBalance = rightAccountDB.LoadExecAccount(a.fromaddr, a.execaddr).Balance
+	}
+	fee := a.getFeeConfig(payload.GetLeftAsset(), payload.GetRightAsset())
+	re.FeeAddr = fee.addr
+
+	//与限价单相同，单笔交易最多撮合maxMatchCount笔历史订单
+	maxMatchCount := GetMaxMatchCount(a.api.GetConfig(), a.height)
+MATCH:
+	for {
+		marketDepthList, err := QueryMarketDepth(a.localDB, payload.GetLeftAsset(), payload.GetRightAsset(), a.OpSwap(payload.Op), priceKey, et.Count)
+		if err == types.ErrNotFound {
+			break
+		}
+		for _, marketDepth := range marketDepthList.List {
+			for {
+				orderList, err := findOrderIDListByPrice(a.localDB, payload.GetLeftAsset(), payload.GetRightAsset(), marketDepth.Price, a.OpSwap(payload.Op), et.ListASC, orderKey)
+				if err == types.ErrNotFound {
+					break
+				}
+				for _, matchorder := range orderList.List {
+					//同地址不能交易
+					if matchorder.Addr == a.fromaddr {
+						continue
+					}
+					//达到最大撮合数量时还有可以撮合的挂单
+					if count >= maxMatchCount {
+						re.MatchLimited = true
+						break MATCH
+					}
+					price := matchorder.GetLimitOrder().GetPrice()
+					//买单只成交可用余额能够支付的部分
+					if payload.Op == et.OpBuy {
+						if a.calcActualCost(et.OpBuy, minAmount(or.Balance, matchorder.Balance), price) > rightBalance {
+							affordable := big.NewInt(0).Div(big.NewInt(0).Mul(big.NewInt(rightBalance), big.NewInt(types.Coin)), big.NewInt(price)).Int64()
+							unfilled = or.Balance - affordable
+							or.Balance = affordable
+							if or.Balance <= 0 {
+								break MATCH
+							}
+						}
+						rightBalance = rightBalance - a.calcActualCost(et.OpBuy, minAmount(or.Balance, matchorder.Balance), price)
+					}
+					//以挂单价格作为成交价格,复用限价单撮合模型
+					limitOrder := &et.LimitOrder{
+						LeftAsset:  payload.GetLeftAsset(),
+						RightAsset: payload.GetRightAsset(),
+						Price:      price,
+						Amount:     or.Balance,
+						Op:         payload.Op,
+					}
+					avgPrice, executed := or.AVGPrice, or.Executed
+					log, kv, err := a.matchModel(leftAccountDB, rightAccountDB, limitOrder, matchorder, or, re, fee)
+					if err != nil {
+						return nil, err
+					}
+					logs = append(logs, log...)
+					kvs = append(kvs, kv...)
+					or.AVGPrice = calcMarketAVGPrice(avgPrice, executed, price, or.Executed-executed)
+					if or.Balance == 0 {
+						break MATCH
+					}
+					count = count + 1
+				}
+				if orderList.PrimaryKey == "" {
+					break
+				}
+				orderKey = orderList.PrimaryKey
+			}
+		}
+		if marketDepthList.PrimaryKey == "" {
+			break
+		}
+		priceKey = marketDepthList.PrimaryKey
+	}
+
+	//未成交的部分直接退回,市价单不挂单
+	or.Balance = or.Balance + unfilled
+	if or.Balance == 0 {
+		or.Status = et.Completed
+	} else {
+		or.Status = et.Revoked
+	}
+	re.Order = or
+	a.updateStateDBCache(or)
+	kvs = append(kvs, a.GetKVSet(or)...)
+	receiptlog := &types.ReceiptLog{Ty: et.TyMarketOrderLog, Log: types.Encode(re)}
+	logs = append(logs, receiptlog)
+	receipts := &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}
+	return receipts, nil
+}
+
 //交易撮合模型
-func (a *Action) matchModel(leftAccountDB, rightAccountDB *account.DB, payload *et.LimitOrder, matchorder *et.Order, or *et.Order, re *et.ReceiptExchange) ([]*types.ReceiptLog, []*types.KeyValue, error) {
+func (a *Action) matchModel(leftAccountDB, rightAccountDB *account.DB, payload *et.LimitOrder, matchorder *et.Order, or *et.Order, re *et.ReceiptExchange, fee *feeConfig) ([]*types.ReceiptLog, []*types.KeyValue, error) {
 	var logs []*types.ReceiptLog
 	var kvs []*types.KeyValue
-	//TODO 这里得逻辑是否需要调整?当匹配的单数过多，会导致receipt日志数量激增，理论上存在日志存储攻击，需要加下最大匹配深度，防止这种攻击发生
+	//单笔交易的撮合数量由调用方按maxMatchCount限制,这里只记录双方的成交次数
+	or.MatchCount = or.MatchCount + 1
+	matchorder.MatchCount = matchorder.MatchCount + 1
+	//成交价格,买单按卖单的挂单价格成交,卖单按自身价格成交
+	price := payload.Price
+	if payload.Op == et.OpBuy {
+		price = matchorder.GetLimitOrder().Price
+	}
 
 	//先判断挂单得额度够不够，只有两种状态,大于等于，或者小于
 	if matchorder.GetBalance() >= or.GetBalance() {
@@ -441,6 +608,13 @@ func (a *Action) matchModel(leftAccountDB, rightAccountDB *account.DB, payload *
 			//计算matchOrder平均成交价格
 			matchorder.AVGPrice = caclAVGPrice(matchorder, matchorder.GetLimitOrder().Price, payload.Amount)
 		}
+		//从双方获得的资产中扣除手续费
+		log, kv, err := a.chargeFee(leftAccountDB, rightAccountDB, payload.Op, matchorder, or, re, or.GetBalance(), price, fee)
+		if err != nil {
+			return nil, nil, err
+		}
+		logs = append(logs, log...)
+		kvs = append(kvs, kv...)
 
 		// match receiptorder,涉及赋值先手顺序，代码顺序不可变
 		matchorder.Status = func(a, b int64) int32 {
@@ -524,6 +698,13 @@ func (a *Action) matchModel(leftAccountDB, rightAccountDB *account.DB, payload *
 		//计算matchOrder平均成交价格
 		matchorder.AVGPrice = caclAVGPrice(matchorder, matchorder.GetLimitOrder().Price, matchorder.GetBalance())
 	}
+	//从双方获得的资产中扣除手续费
+	log, kv, err := a.chargeFee(leftAccountDB, rightAccountDB, payload.Op, matchorder, or, re, matchorder.GetBalance(), price, fee)
+	if err != nil {
+		return nil, nil, err
+	}
+	logs = append(logs, log...)
+	kvs = append(kvs, kv...)
 
 	//涉及赋值先后顺序，不可颠倒
 	or.Balance = or.Balance - matchorder.Balance
@@ -543,6 +724,135 @@ func (a *Action) matchModel(leftAccountDB, rightAccountDB *account.DB, payload *
 	return logs, kvs, nil
 }
 
+//手续费配置,费率单位为万分之一
+type feeConfig struct {
+	addr  string
+	maker int64
+	taker int64
+}
+
+//获取交易对的手续费配置,交易对未单独配置费率时使用全局费率,未配置收取地址时不收取手续费
+func (a *Action) getFeeConfig(left, right *et.Asset) *feeConfig {
+	cfg := a.api.GetConfig()
+	fee := &feeConfig{}
+	addr, err := getManageValue(cfg, a.statedb, et.ManageKeyFeeAddr)
+	if err != nil {
+		return fee
+	}
+	pair := fmt.Sprintf("%s:%s", left.GetSymbol(), right.GetSymbol())
+	fee.addr = addr
+	fee.maker = getFeeRate(cfg, a.statedb, et.ManageKeyMakerFee, pair)
+	fee.taker = getFeeRate(cfg, a.statedb, et.ManageKeyTakerFee, pair)
+	return fee
+}
+
+//收取手续费,买方以获得的左边资产支付,卖方以获得的右边资产支付
+func (a *Action) chargeFee(leftAccountDB, rightAccountDB *account.DB, op int32, matchorder, or *et.Order, re *et.ReceiptExchange, amount, price int64, fee *feeConfig) ([]*types.ReceiptLog, []*types.KeyValue, error) {
+	var logs []*types.ReceiptLog
+	var kvs []*types.KeyValue
+	if fee.addr == "" {
+		return logs, kvs, nil
+	}
+	cost := a.calcActualCost(et.OpBuy, amount, price)
+	takerAccountDB, takerIncome := rightAccountDB, cost
+	makerAccountDB, makerIncome := leftAccountDB, amount
+	if op == et.OpBuy {
+		takerAccountDB, takerIncome = leftAccountDB, amount
+		makerAccountDB, makerIncome = rightAccountDB, cost
+	}
+	takerFee := calcFee(takerIncome, fee.taker)
+	if takerFee > 0 {
+		receipt, err := takerAccountDB.ExecTransfer(a.fromaddr, fee.addr, a.execaddr, takerFee)
+		if err != nil {
+			elog.Error("chargeFee.ExecTransfer", "addr", a.fromaddr, "execaddr", a.execaddr, "amount", takerFee, "err", err.Error())
+			return nil, nil, err
+		}
+		logs = append(logs, receipt.Logs...)
+		kvs = append(kvs, receipt.KV...)
+		or.TakerFee = or.TakerFee + takerFee
+		re.TakerFee = re.TakerFee + takerFee
+	}
+	makerFee := calcFee(makerIncome, fee.maker)
+	if makerFee > 0 {
+		receipt, err := makerAccountDB.ExecTransfer(matchorder.Addr, fee.addr, a.execaddr, makerFee)
+		if err != nil {
+			elog.Error("chargeFee.ExecTransfer", "addr", matchorder.Addr, "execaddr", a.execaddr, "amount", makerFee, "err", err.Error())
+			return nil, nil, err
+		}
+		logs = append(logs, receipt.Logs...)
+		kvs = append(kvs, receipt.KV...)
+		matchorder.MakerFee = matchorder.MakerFee + makerFee
+		re.MakerFee = re.MakerFee + makerFee
+	}
+	return logs, kvs, nil
+}
+
+//计算手续费,rate单位为万分之一
+func calcFee(amount, rate int64) int64 {
+	fee := big.NewInt(0).Mul(big.NewInt(amount), big.NewInt(rate))
+	fee = big.NewInt(0).Div(fee, big.NewInt(et.FeeRateBase))
+	return fee.Int64()
+}
+
+//获取费率,优先使用交易对的配置
+func getFeeRate(cfg *types.Chain33Config, db dbm.KV, key, pair string) int64 {
+	value, err := getManageValue(cfg, db, key+"-"+pair)
+	if err != nil {
+		value, err = getManageValue(cfg, db, key)
+		if err != nil {
+			return 0
+		}
+	}
+	rate, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || rate < 0 || rate > et.FeeRateBase {
+		elog.Error("getFeeRate", "key", key, "pair", pair, "value", value)
+		return 0
+	}
+	return rate
+}
+
+//获取manage配置项的最新值
+func getManageValue(cfg *types.Chain33Config, db dbm.KV, key string) (string, error) {
+	value, err := getManageKey(cfg, key, db)
+	if err != nil {
+		return "", err
+	}
+	var item types.ConfigItem
+	err = types.Decode(value, &item)
+	if err != nil {
+		elog.Error("getManageValue", "decode db key", key, "err", err.Error())
+		return "", err
+	}
+	values := item.GetArr().GetValue()
+	if len(values) == 0 {
+		return "", types.ErrNotFound
+	}
+	//取数组最后一位，作为最新配置项的值
+	return values[len(values)-1], nil
+}
+
+func getManageKey(cfg *types.Chain33Config, key string, db dbm.KV) ([]byte, error) {
+	manageKey := types.ManageKey(key)
+	value, err := db.Get([]byte(manageKey))
+	if err != nil {
+		//平行链只有一种存储方式
+		if cfg.IsPara() {
+			return nil, err
+		}
+		return getConfigKey(key, db)
+	}
+	return value, nil
+}
+
+func getConfigKey(key string, db dbm.KV) ([]byte, error) {
+	configKey := types.ConfigKey(key)
+	value, err := db.Get([]byte(configKey))
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
 //根据订单号查询，分为两步，优先去localdb中查询，如没有则再去状态数据库中查询
 // 1.挂单中得订单信会根据orderID在localdb中存储
 // 2.订单撤销，或者成交后，根据orderID在localdb中存储得数据会被删除，这时只能到状态数据库中查询
@@ -587,7 +897,7 @@ func findOrderIDListByPrice(localdb dbm.KV, left, right *et.Asset, price int64,
 	for _, row := range rows {
 		order := row.Data.(*et.Order)
 		//替换已经成交得量
-		order.Executed = order.GetLimitOrder().Amount - order.Balance
+		order.Executed = getOrderAmount(order) - order.Balance
 		orderList.List = append(orderList.List, order)
 	}
 	//设置主键索引
@@ -666,7 +976,7 @@ HERE:
 			continue
 		}
 		//替换已经成交得量
-		order.Executed = order.GetLimitOrder().Amount - order.Balance
+		order.Executed = getOrderAmount(order) - order.Balance
 		orderList.List = append(orderList.List, order)
 		if len(orderList.List) == int(count) {
 			//设置主键索引
@@ -709,7 +1019,7 @@ func QueryOrderList(localdb dbm.KV, addr string, status, count, direction int32,
 	for _, row := range rows {
 		order := row.Data.(*et.Order)
 		//替换已经成交得量
-		order.Executed = order.GetLimitOrder().Amount - order.Balance
+		order.Executed = getOrderAmount(order) - order.Balance
 		orderList.List = append(orderList.List, order)
 	}
 	//设置主键索引
@@ -729,6 +1039,15 @@ func queryMarketDepth(localdb dbm.KV, left, right *et.Asset, op int32, price int
 	return row.Data.(*et.MarketDepth), nil
 }
 
+//GetMaxMatchCount 获取单笔交易最大撮合订单数,可以在mver.exec.sub.exchange中按fork高度配置maxMatchCount,未配置时使用系统默认值
+func GetMaxMatchCount(cfg *types.Chain33Config, height int64) int {
+	maxMatchCount := types.Conf(cfg, "mver.exec.sub."+et.ExchangeX).MGInt("maxMatchCount", height)
+	if maxMatchCount <= 0 {
+		return et.MaxMatchCount
+	}
+	return int(maxMatchCount)
+}
+
 //math库中的安全大数乘法，防溢出
 func SafeMul(x, y int64) int64 {
 	res := big.NewInt(0).Mul(big.NewInt(x), big.NewInt(y))
@@ -736,6 +1055,42 @@ func SafeMul(x, y int64) int64 {
 	return res.Int64()
 }
 
+//计算市价单成交均价,executed为本次撮合前已成交的量,amount为本次成交的量
+func calcMarketAVGPrice(avgPrice, executed, price, amount int64) int64 {
+	if executed+amount == 0 {
+		return 0
+	}
+	x := big.NewInt(0).Mul(big.NewInt(avgPrice), big.NewInt(executed))
+	y := big.NewInt(0).Mul(big.NewInt(price), big.NewInt(amount))
+	total := big.NewInt(0).Add(x, y)
+	avg := big.NewInt(0).Div(total, big.NewInt(executed+amount))
+	return avg.Int64()
+}
+
+func minAmount(x, y int64) int64 {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+//获取订单交易对和买卖操作,兼容限价单和市价单
+func getOrderAsset(order *et.Order) (left, right *et.Asset, op int32) {
+	if marketOrder := order.GetMarketOrder(); marketOrder != nil {
+		return marketOrder.GetLeftAsset(), marketOrder.GetRightAsset(), marketOrder.GetOp()
+	}
+	limitOrder := order.GetLimitOrder()
+	return limitOrder.GetLeftAsset(), limitOrder.GetRightAsset(), limitOrder.GetOp()
+}
+
+//获取订单委托总量,兼容限价单和市价单
+func getOrderAmount(order *et.Order) int64 {
+	if marketOrder := order.GetMarketOrder(); marketOrder != nil {
+		return marketOrder.GetAmount()
+	}
+	return order.GetLimitOrder().GetAmount()
+}
+
 //计算平均成交价格
 func caclAVGPrice(order *et.Order, price int64, amount int64) int64 {
 	x := big.NewInt(0).Mul(big.NewInt(order.AVGPrice), big.NewInt(order.GetLimitOrder().Amount-order.GetBalance()))
@@ -76,6 +76,7 @@ func (e *exchange) updateIndex(receipt *ety.ReceiptExchange) (kvs []*types.KeyVa
 	historyTable := NewHistoryOrderTable(e.GetLocalDB())
 	marketTable := NewMarketDepthTable(e.GetLocalDB())
 	orderTable := NewMarketOrderTable(e.GetLocalDB())
+	klineTable := NewKlineTable(e.GetLocalDB())
 	switch receipt.Order.Status {
 	case ety.Ordered:
 		err := e.updateOrder(marketTable, orderTable, historyTable, receipt.GetOrder(), receipt.GetIndex())
@@ -100,6 +101,16 @@ func (e *exchange) updateIndex(receipt *ety.ReceiptExchange) (kvs []*types.KeyVa
 		if err != nil {
 			return nil
 		}
+		//市价单部分成交后剩余部分被撤回,需要更新已撮合的订单
+		err = e.updateMatchOrders(marketTable, orderTable, historyTable, receipt.GetOrder(), receipt.GetMatchOrders(), receipt.GetIndex())
+		if err != nil {
+			return nil
+		}
+	}
+	//撮合成交计入K线
+	err := e.updateKline(klineTable, receipt)
+	if err != nil {
+		return nil
 	}
 
 	//刷新KV
@@ -121,11 +132,26 @@ func (e *exchange) updateIndex(receipt *ety.ReceiptExchange) (kvs []*types.KeyVa
 		return nil
 	}
 	kvs = append(kvs, kv...)
+	kv, err = klineTable.Save()
+	if err != nil {
+		elog.Error("updateIndex", "klineTable.Save", err.Error())
+		return nil
+	}
+	kvs = append(kvs, kv...)
 
 	return
 }
 
 func (e *exchange) updateOrder(marketTable, orderTable, historyTable *table.Table, order *ety.Order, index int64) error {
+	//市价单不挂单,成交或撤回后直接记录到历史订单中
+	if order.Ty == ety.TyMarketOrderAction {
+		err := historyTable.Replace(order)
+		if err != nil {
+			elog.Error("updateIndex", "historyTable.Replace", err.Error())
+			return err
+		}
+		return nil
+	}
 	left := order.GetLimitOrder().GetLeftAsset()
 	right := order.GetLimitOrder().GetRightAsset()
 	op := order.GetLimitOrder().GetOp()
@@ -209,9 +235,7 @@ func (e *exchange) updateOrder(marketTable, orderTable, historyTable *table.Tabl
 	return nil
 }
 func (e *exchange) updateMatchOrders(marketTable, orderTable, historyTable *table.Table, order *ety.Order, matchOrders []*ety.Order, index int64) error {
-	left := order.GetLimitOrder().GetLeftAsset()
-	right := order.GetLimitOrder().GetRightAsset()
-	op := order.GetLimitOrder().GetOp()
+	left, right, op := getOrderAsset(order)
 	if len(matchOrders) > 0 {
 		//撮合交易更新
 		cache := make(map[int64]int64)
@@ -0,0 +1,207 @@
+package executor
+
+import (
+	"math"
+	"strings"
+
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/common/db/table"
+	"github.com/33cn/chain33/types"
+	et "github.com/33cn/plugin/plugin/dapp/exchange/types"
+)
+
+/*
+ * K线及24小时行情
+ * 每一笔撮合成交都会按成交时的区块时间累加到各周期的K线中,
+ * K线数据保存在localdb中,随ExecLocal的自动回滚一起回滚
+ */
+
+//klinePageCount 查询K线时单次从localdb中读取的条数
+const klinePageCount = int32(100)
+
+//updateKline 将本次交易的所有撮合记录累加到各周期K线中
+func (e *exchange) updateKline(klineTable *table.Table, receipt *et.ReceiptExchange) error {
+	if len(receipt.GetMatchOrders()) == 0 {
+		return nil
+	}
+	left, right, _ := getOrderAsset(receipt.GetOrder())
+	//同一笔交易可能多次更新同一根K线,先在内存中汇总
+	cache := make(map[string]*et.Kline)
+	var keys []string
+	for _, matchOrder := range receipt.GetMatchOrders() {
+		price := getMatchPrice(receipt.GetOrder(), matchOrder)
+		volume := matchOrder.Executed
+		turnover := SafeMul(volume, price)
+		for _, period := range et.KlinePeriods {
+			seconds := et.GetKlineSeconds(period)
+			startTime := e.GetBlockTime() - e.GetBlockTime()%seconds
+			key := calcKlinePrimaryKey(left, right, period, startTime)
+			kline, ok := cache[key]
+			if !ok {
+				var err error
+				kline, err = queryKline(klineTable, key)
+				if err == types.ErrNotFound {
+					kline = &et.Kline{LeftAsset: left, RightAsset: right, Period: period, StartTime: startTime}
+				} else if err != nil {
+					return err
+				}
+				cache[key] = kline
+				keys = append(keys, key)
+			}
+			mergeKline(kline, price, volume, turnover)
+		}
+	}
+	for _, key := range keys {
+		err := klineTable.Replace(cache[key])
+		if err != nil {
+			elog.Error("updateKline", "klineTable.Replace", err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+//getMatchPrice 获取撮合成交价,吃单方为限价卖单时按卖单价格成交,其余情况按挂单方价格成交
+func getMatchPrice(order, matchOrder *et.Order) int64 {
+	if order.Ty == et.TyLimitOrderAction && order.GetLimitOrder().GetOp() == et.OpSell {
+		return order.GetLimitOrder().GetPrice()
+	}
+	return matchOrder.GetLimitOrder().GetPrice()
+}
+
+func mergeKline(kline *et.Kline, price, volume, turnover int64) {
+	if kline.Count == 0 {
+		kline.Open = price
+		kline.High = price
+		kline.Low = price
+	}
+	if price > kline.High {
+		kline.High = price
+	}
+	if price < kline.Low {
+		kline.Low = price
+	}
+	kline.Close = price
+	kline.Volume = kline.Volume + volume
+	kline.Turnover = kline.Turnover + turnover
+	kline.Count = kline.Count + 1
+}
+
+func queryKline(klineTable *table.Table, primaryKey string) (*et.Kline, error) {
+	row, err := klineTable.GetData([]byte(primaryKey))
+	if err != nil {
+		return nil, err
+	}
+	return row.Data.(*et.Kline), nil
+}
+
+//QueryKline 按时间区间查询K线,primaryKey为空时从区间端点开始查询
+func QueryKline(localdb dbm.KV, left, right *et.Asset, period string, startTime, endTime int64, primaryKey string, count, direction int32) (*et.KlineList, error) {
+	if count == 0 {
+		count = et.Count
+	}
+	if endTime == 0 {
+		endTime = math.MaxInt64
+	}
+	prefix := calcKlinePrefix(left, right, period)
+	if primaryKey != "" && !strings.HasPrefix(primaryKey, prefix) {
+		return nil, types.ErrInvalidParam
+	}
+	klineTable := NewKlineTable(localdb)
+	var klineList et.KlineList
+	for {
+		var rows []*table.Row
+		var err error
+		if primaryKey == "" {
+			rows, err = klineTable.ListIndex("time", []byte(prefix), nil, klinePageCount, direction)
+		} else {
+			//翻页时primaryKey一定是已存在的K线,不同交易对的数据存在同一前缀下,需要按前缀过滤
+			rows, err = klineTable.ListIndex("time", nil, []byte(primaryKey), klinePageCount, direction)
+		}
+		if err == types.ErrNotFound {
+			return &klineList, nil
+		}
+		if err != nil {
+			elog.Error("QueryKline.", "left", left, "right", right, "period", period, "err", err.Error())
+			return nil, err
+		}
+		for _, row := range rows {
+			kline := row.Data.(*et.Kline)
+			if !strings.HasPrefix(string(row.Primary), prefix) {
+				return &klineList, nil
+			}
+			//还未进入查询区间
+			if (direction == et.ListASC && kline.StartTime < startTime) || (direction == et.ListDESC && kline.StartTime > endTime) {
+				continue
+			}
+			//已经超出查询区间
+			if kline.StartTime < startTime || kline.StartTime > endTime {
+				return &klineList, nil
+			}
+			klineList.List = append(klineList.List, kline)
+			if len(klineList.List) == int(count) {
+				klineList.PrimaryKey = string(row.Primary)
+				return &klineList, nil
+			}
+		}
+		if len(rows) < int(klinePageCount) {
+			return &klineList, nil
+		}
+		primaryKey = string(rows[len(rows)-1].Primary)
+	}
+}
+
+//QueryTicker 根据1分钟K线统计截至endTime的24小时行情
+func QueryTicker(localdb dbm.KV, left, right *et.Asset, endTime int64) (*et.Ticker, error) {
+	ticker := &et.Ticker{
+		LeftAsset:  left,
+		RightAsset: right,
+		StartTime:  endTime - et.TickerWindow,
+		EndTime:    endTime,
+	}
+	primaryKey := ""
+	for {
+		//从最新的K线往前统计,避免从头遍历历史K线
+		klineList, err := QueryKline(localdb, left, right, et.KlinePeriod1m, ticker.StartTime, endTime, primaryKey, klinePageCount, et.ListDESC)
+		if err != nil {
+			return nil, err
+		}
+		for _, kline := range klineList.List {
+			if ticker.Count == 0 {
+				ticker.Close = kline.Close
+				ticker.High = kline.High
+				ticker.Low = kline.Low
+			}
+			if kline.High > ticker.High {
+				ticker.High = kline.High
+			}
+			if kline.Low < ticker.Low {
+				ticker.Low = kline.Low
+			}
+			ticker.Open = kline.Open
+			ticker.Volume = ticker.Volume + kline.Volume
+			ticker.Turnover = ticker.Turnover + kline.Turnover
+			ticker.Count = ticker.Count + kline.Count
+		}
+		if klineList.PrimaryKey == "" {
+			break
+		}
+		primaryKey = klineList.PrimaryKey
+	}
+	if ticker.Count > 0 {
+		return ticker, nil
+	}
+	//24小时内没有成交,价格取最近一次成交价
+	klineList, err := QueryKline(localdb, left, right, et.KlinePeriod1m, 0, ticker.StartTime-1, "", 1, et.ListDESC)
+	if err != nil {
+		return nil, err
+	}
+	if len(klineList.List) > 0 {
+		price := klineList.List[0].Close
+		ticker.Open = price
+		ticker.High = price
+		ticker.Low = price
+		ticker.Close = price
+	}
+	return ticker, nil
+}
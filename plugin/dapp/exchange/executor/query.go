@@ -61,3 +61,39 @@ func (s *exchange) Query_QueryOrderList(in *et.QueryOrderList) (types.Message, e
 	}
 	return QueryOrderList(s.GetLocalDB(), in.Address, in.Status, in.Count, in.Direction, in.PrimaryKey)
 }
+
+//查询交易对的K线数据
+func (s *exchange) Query_QueryKline(in *et.QueryKline) (types.Message, error) {
+	if !CheckExchangeAsset(in.LeftAsset, in.RightAsset) {
+		return nil, et.ErrAsset
+	}
+	if et.GetKlineSeconds(in.Period) == 0 {
+		return nil, et.ErrKlinePeriod
+	}
+	if !CheckCount(in.Count) {
+		return nil, et.ErrCount
+	}
+	if !CheckDirection(in.Direction) {
+		return nil, et.ErrDirection
+	}
+	if in.EndTime != 0 && in.StartTime > in.EndTime {
+		return nil, et.ErrTimeRange
+	}
+	return QueryKline(s.GetLocalDB(), in.LeftAsset, in.RightAsset, in.Period, in.StartTime, in.EndTime, in.PrimaryKey, in.Count, in.Direction)
+}
+
+//查询交易对的24小时行情,未指定统计时间时以最新区块时间为准
+func (s *exchange) Query_QueryTicker(in *et.QueryTicker) (types.Message, error) {
+	if !CheckExchangeAsset(in.LeftAsset, in.RightAsset) {
+		return nil, et.ErrAsset
+	}
+	endTime := in.Time
+	if endTime == 0 {
+		header, err := s.GetAPI().GetLastHeader()
+		if err != nil {
+			return nil, err
+		}
+		endTime = header.BlockTime
+	}
+	return QueryTicker(s.GetLocalDB(), in.LeftAsset, in.RightAsset, endTime)
+}
@@ -50,6 +50,14 @@ var opt_exchange_history = &table.Option{
 	Index:   []string{"name", "addr_status"},
 }
 
+//K线数据,按交易对,周期和周期开始时间存储
+var opt_exchange_kline = &table.Option{
+	Prefix:  KeyPrefixLocalDB,
+	Name:    "kline",
+	Primary: "time",
+	Index:   nil,
+}
+
 //NewTable 新建表
 func NewMarketDepthTable(kvdb db.KV) *table.Table {
 	rowmeta := NewMarketDepthRow()
@@ -78,6 +86,15 @@ func NewHistoryOrderTable(kvdb db.KV) *table.Table {
 	return table
 }
 
+func NewKlineTable(kvdb db.KV) *table.Table {
+	rowmeta := NewKlineRow()
+	table, err := table.NewTable(rowmeta, kvdb, opt_exchange_kline)
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
 //OrderRow table meta 结构
 type OrderRow struct {
 	*ety.Order
@@ -141,7 +158,8 @@ func (m *HistoryOrderRow) Get(key string) ([]byte, error) {
 	if key == "index" {
 		return []byte(fmt.Sprintf("%022d", m.Index)), nil
 	} else if key == "name" {
-		return []byte(fmt.Sprintf("%s:%s", m.GetLimitOrder().LeftAsset.GetSymbol(), m.GetLimitOrder().RightAsset.GetSymbol())), nil
+		left, right, _ := getOrderAsset(m.Order)
+		return []byte(fmt.Sprintf("%s:%s", left.GetSymbol(), right.GetSymbol())), nil
 	} else if key == "addr_status" {
 		return []byte(fmt.Sprintf("%s:%d", m.Addr, m.Status)), nil
 	}
@@ -179,3 +197,44 @@ func (m *MarketDepthRow) Get(key string) ([]byte, error) {
 	}
 	return nil, types.ErrNotFound
 }
+
+//KlineRow table meta 结构
+type KlineRow struct {
+	*ety.Kline
+}
+
+//NewKlineRow 新建一个meta 结构
+func NewKlineRow() *KlineRow {
+	return &KlineRow{Kline: &ety.Kline{}}
+}
+
+//CreateRow 新建数据行
+func (m *KlineRow) CreateRow() *table.Row {
+	return &table.Row{Data: &ety.Kline{}}
+}
+
+//SetPayload 设置数据
+func (m *KlineRow) SetPayload(data types.Message) error {
+	if txdata, ok := data.(*ety.Kline); ok {
+		m.Kline = txdata
+		return nil
+	}
+	return types.ErrTypeAsset
+}
+
+//Get 按照indexName 查询 indexValue
+func (m *KlineRow) Get(key string) ([]byte, error) {
+	if key == "time" {
+		return []byte(calcKlinePrimaryKey(m.LeftAsset, m.RightAsset, m.Period, m.StartTime)), nil
+	}
+	return nil, types.ErrNotFound
+}
+
+//K线主键,同一交易对同一周期的K线按开始时间排序
+func calcKlinePrimaryKey(left, right *ety.Asset, period string, startTime int64) string {
+	return fmt.Sprintf("%s%016d", calcKlinePrefix(left, right, period), startTime)
+}
+
+func calcKlinePrefix(left, right *ety.Asset, period string) string {
+	return fmt.Sprintf("%s:%s:%s:", left.GetSymbol(), right.GetSymbol(), period)
+}
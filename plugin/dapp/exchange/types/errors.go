@@ -15,4 +15,6 @@ var (
 	ErrDirection    = fmt.Errorf("%s", "The direction only 0 or 1!")
 	ErrStatus       = fmt.Errorf("%s", "The status only in  0 , 1, 2!")
 	ErrOrderID      = fmt.Errorf("%s", "Wrong OrderID!")
+	ErrKlinePeriod  = fmt.Errorf("%s", "The kline period only in 1m, 5m, 1h, 1d!")
+	ErrTimeRange    = fmt.Errorf("%s", "The startTime can't large than endTime!")
 )
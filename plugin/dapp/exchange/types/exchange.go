@@ -27,6 +27,8 @@ const (
 	FuncNameQueryHistoryOrderList = "QueryHistoryOrderList"
 	FuncNameQueryOrder            = "QueryOrder"
 	FuncNameQueryOrderList        = "QueryOrderList"
+	FuncNameQueryKline            = "QueryKline"
+	FuncNameQueryTicker           = "QueryTicker"
 )
 
 // log类型id值
@@ -64,6 +66,44 @@ const (
 	MaxMatchCount = 100
 )
 
+//手续费相关的manage配置项,按交易对配置费率时在key后追加-{leftSymbol}:{rightSymbol}
+const (
+	//手续费收取地址,未配置时不收取手续费
+	ManageKeyFeeAddr = "exchange-feeAddr"
+	//挂单方费率
+	ManageKeyMakerFee = "exchange-makerFee"
+	//吃单方费率
+	ManageKeyTakerFee = "exchange-takerFee"
+	//费率单位为万分之一
+	FeeRateBase = 10000
+)
+
+//K线周期
+const (
+	KlinePeriod1m = "1m"
+	KlinePeriod5m = "5m"
+	KlinePeriod1h = "1h"
+	KlinePeriod1d = "1d"
+	//24小时行情统计区间,单位秒
+	TickerWindow = int64(24 * 3600)
+)
+
+var (
+	//KlinePeriods K线周期及对应的秒数,按周期从小到大排列
+	KlinePeriods = []string{KlinePeriod1m, KlinePeriod5m, KlinePeriod1h, KlinePeriod1d}
+	klineSeconds = map[string]int64{
+		KlinePeriod1m: 60,
+		KlinePeriod5m: 5 * 60,
+		KlinePeriod1h: 3600,
+		KlinePeriod1d: 24 * 3600,
+	}
+)
+
+//GetKlineSeconds 获取K线周期对应的秒数,不支持的周期返回0
+func GetKlineSeconds(period string) int64 {
+	return klineSeconds[period]
+}
+
 var (
 	//ExchangeX 执行器名称定义
 	ExchangeX = "exchange"
@@ -22,6 +22,11 @@ It has these top-level messages:
 	QueryOrder
 	QueryOrderList
 	OrderList
+	QueryKline
+	Kline
+	KlineList
+	QueryTicker
+	Ticker
 	ReceiptExchange
 */
 package types
@@ -382,6 +387,12 @@ type Order struct {
 	UpdateTime int64 `protobuf:"varint,10,opt,name=updateTime" json:"updateTime,omitempty"`
 	// 索引
 	Index int64 `protobuf:"varint,11,opt,name=index" json:"index,omitempty"`
+	// 成交次数
+	MatchCount int32 `protobuf:"varint,12,opt,name=matchCount" json:"matchCount,omitempty"`
+	// 作为挂单方支付的手续费,以挂单方获得的资产计价
+	MakerFee int64 `protobuf:"varint,13,opt,name=makerFee" json:"makerFee,omitempty"`
+	// 作为吃单方支付的手续费,以吃单方获得的资产计价
+	TakerFee int64 `protobuf:"varint,14,opt,name=takerFee" json:"takerFee,omitempty"`
 }
 
 func (m *Order) Reset()                    { *m = Order{} }
@@ -487,6 +498,27 @@ func (m *Order) GetIndex() int64 {
 	return 0
 }
 
+func (m *Order) GetMatchCount() int32 {
+	if m != nil {
+		return m.MatchCount
+	}
+	return 0
+}
+
+func (m *Order) GetMakerFee() int64 {
+	if m != nil {
+		return m.MakerFee
+	}
+	return 0
+}
+
+func (m *Order) GetTakerFee() int64 {
+	if m != nil {
+		return m.TakerFee
+	}
+	return 0
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Order) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Order_OneofMarshaler, _Order_OneofUnmarshaler, _Order_OneofSizer, []interface{}{
@@ -844,17 +876,383 @@ func (m *OrderList) GetPrimaryKey() string {
 	return ""
 }
 
+// 查询K线数据
+type QueryKline struct {
+	// 资产1
+	LeftAsset *Asset `protobuf:"bytes,1,opt,name=leftAsset" json:"leftAsset,omitempty"`
+	// 资产2
+	RightAsset *Asset `protobuf:"bytes,2,opt,name=rightAsset" json:"rightAsset,omitempty"`
+	// K线周期,支持1m,5m,1h,1d
+	Period string `protobuf:"bytes,3,opt,name=period" json:"period,omitempty"`
+	// 开始时间(包含),为0时不限制
+	StartTime int64 `protobuf:"varint,4,opt,name=startTime" json:"startTime,omitempty"`
+	// 结束时间(包含),为0时不限制
+	EndTime int64 `protobuf:"varint,5,opt,name=endTime" json:"endTime,omitempty"`
+	// 主键索引
+	PrimaryKey string `protobuf:"bytes,6,opt,name=primaryKey" json:"primaryKey,omitempty"`
+	// 单页返回多少条记录，默认返回10条,为了系统安全最多单次只能返回20条
+	Count int32 `protobuf:"varint,7,opt,name=count" json:"count,omitempty"`
+	// 0降序，1升序，默认降序
+	Direction int32 `protobuf:"varint,8,opt,name=direction" json:"direction,omitempty"`
+}
+
+func (m *QueryKline) Reset()                    { *m = QueryKline{} }
+func (m *QueryKline) String() string            { return proto.CompactTextString(m) }
+func (*QueryKline) ProtoMessage()               {}
+func (*QueryKline) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *QueryKline) GetLeftAsset() *Asset {
+	if m != nil {
+		return m.LeftAsset
+	}
+	return nil
+}
+
+func (m *QueryKline) GetRightAsset() *Asset {
+	if m != nil {
+		return m.RightAsset
+	}
+	return nil
+}
+
+func (m *QueryKline) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+func (m *QueryKline) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *QueryKline) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *QueryKline) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
+func (m *QueryKline) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *QueryKline) GetDirection() int32 {
+	if m != nil {
+		return m.Direction
+	}
+	return 0
+}
+
+// K线数据,价格均为成交价
+type Kline struct {
+	// 资产1
+	LeftAsset *Asset `protobuf:"bytes,1,opt,name=leftAsset" json:"leftAsset,omitempty"`
+	// 资产2
+	RightAsset *Asset `protobuf:"bytes,2,opt,name=rightAsset" json:"rightAsset,omitempty"`
+	// K线周期
+	Period string `protobuf:"bytes,3,opt,name=period" json:"period,omitempty"`
+	// 周期开始时间,按区块时间计算
+	StartTime int64 `protobuf:"varint,4,opt,name=startTime" json:"startTime,omitempty"`
+	// 开盘价
+	Open int64 `protobuf:"varint,5,opt,name=open" json:"open,omitempty"`
+	// 最高价
+	High int64 `protobuf:"varint,6,opt,name=high" json:"high,omitempty"`
+	// 最低价
+	Low int64 `protobuf:"varint,7,opt,name=low" json:"low,omitempty"`
+	// 收盘价
+	Close int64 `protobuf:"varint,8,opt,name=close" json:"close,omitempty"`
+	// 成交量,以leftAsset计
+	Volume int64 `protobuf:"varint,9,opt,name=volume" json:"volume,omitempty"`
+	// 成交额,以rightAsset计
+	Turnover int64 `protobuf:"varint,10,opt,name=turnover" json:"turnover,omitempty"`
+	// 成交笔数
+	Count int32 `protobuf:"varint,11,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *Kline) Reset()                    { *m = Kline{} }
+func (m *Kline) String() string            { return proto.CompactTextString(m) }
+func (*Kline) ProtoMessage()               {}
+func (*Kline) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *Kline) GetLeftAsset() *Asset {
+	if m != nil {
+		return m.LeftAsset
+	}
+	return nil
+}
+
+func (m *Kline) GetRightAsset() *Asset {
+	if m != nil {
+		return m.RightAsset
+	}
+	return nil
+}
+
+func (m *Kline) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+func (m *Kline) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *Kline) GetOpen() int64 {
+	if m != nil {
+		return m.Open
+	}
+	return 0
+}
+
+func (m *Kline) GetHigh() int64 {
+	if m != nil {
+		return m.High
+	}
+	return 0
+}
+
+func (m *Kline) GetLow() int64 {
+	if m != nil {
+		return m.Low
+	}
+	return 0
+}
+
+func (m *Kline) GetClose() int64 {
+	if m != nil {
+		return m.Close
+	}
+	return 0
+}
+
+func (m *Kline) GetVolume() int64 {
+	if m != nil {
+		return m.Volume
+	}
+	return 0
+}
+
+func (m *Kline) GetTurnover() int64 {
+	if m != nil {
+		return m.Turnover
+	}
+	return 0
+}
+
+func (m *Kline) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// K线列表
+type KlineList struct {
+	List       []*Kline `protobuf:"bytes,1,rep,name=list" json:"list,omitempty"`
+	PrimaryKey string   `protobuf:"bytes,2,opt,name=primaryKey" json:"primaryKey,omitempty"`
+}
+
+func (m *KlineList) Reset()                    { *m = KlineList{} }
+func (m *KlineList) String() string            { return proto.CompactTextString(m) }
+func (*KlineList) ProtoMessage()               {}
+func (*KlineList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *KlineList) GetList() []*Kline {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+func (m *KlineList) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
+// 查询24小时行情
+type QueryTicker struct {
+	// 资产1
+	LeftAsset *Asset `protobuf:"bytes,1,opt,name=leftAsset" json:"leftAsset,omitempty"`
+	// 资产2
+	RightAsset *Asset `protobuf:"bytes,2,opt,name=rightAsset" json:"rightAsset,omitempty"`
+	// 统计截止时间,为0时取最新区块时间
+	Time int64 `protobuf:"varint,3,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *QueryTicker) Reset()                    { *m = QueryTicker{} }
+func (m *QueryTicker) String() string            { return proto.CompactTextString(m) }
+func (*QueryTicker) ProtoMessage()               {}
+func (*QueryTicker) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *QueryTicker) GetLeftAsset() *Asset {
+	if m != nil {
+		return m.LeftAsset
+	}
+	return nil
+}
+
+func (m *QueryTicker) GetRightAsset() *Asset {
+	if m != nil {
+		return m.RightAsset
+	}
+	return nil
+}
+
+func (m *QueryTicker) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+// 24小时行情
+type Ticker struct {
+	// 资产1
+	LeftAsset *Asset `protobuf:"bytes,1,opt,name=leftAsset" json:"leftAsset,omitempty"`
+	// 资产2
+	RightAsset *Asset `protobuf:"bytes,2,opt,name=rightAsset" json:"rightAsset,omitempty"`
+	// 统计开始时间
+	StartTime int64 `protobuf:"varint,3,opt,name=startTime" json:"startTime,omitempty"`
+	// 统计截止时间
+	EndTime int64 `protobuf:"varint,4,opt,name=endTime" json:"endTime,omitempty"`
+	// 统计区间内的第一笔成交价
+	Open int64 `protobuf:"varint,5,opt,name=open" json:"open,omitempty"`
+	// 最高价
+	High int64 `protobuf:"varint,6,opt,name=high" json:"high,omitempty"`
+	// 最低价
+	Low int64 `protobuf:"varint,7,opt,name=low" json:"low,omitempty"`
+	// 最新成交价
+	Close int64 `protobuf:"varint,8,opt,name=close" json:"close,omitempty"`
+	// 成交量,以leftAsset计
+	Volume int64 `protobuf:"varint,9,opt,name=volume" json:"volume,omitempty"`
+	// 成交额,以rightAsset计
+	Turnover int64 `protobuf:"varint,10,opt,name=turnover" json:"turnover,omitempty"`
+	// 成交笔数
+	Count int32 `protobuf:"varint,11,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *Ticker) Reset()                    { *m = Ticker{} }
+func (m *Ticker) String() string            { return proto.CompactTextString(m) }
+func (*Ticker) ProtoMessage()               {}
+func (*Ticker) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *Ticker) GetLeftAsset() *Asset {
+	if m != nil {
+		return m.LeftAsset
+	}
+	return nil
+}
+
+func (m *Ticker) GetRightAsset() *Asset {
+	if m != nil {
+		return m.RightAsset
+	}
+	return nil
+}
+
+func (m *Ticker) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *Ticker) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *Ticker) GetOpen() int64 {
+	if m != nil {
+		return m.Open
+	}
+	return 0
+}
+
+func (m *Ticker) GetHigh() int64 {
+	if m != nil {
+		return m.High
+	}
+	return 0
+}
+
+func (m *Ticker) GetLow() int64 {
+	if m != nil {
+		return m.Low
+	}
+	return 0
+}
+
+func (m *Ticker) GetClose() int64 {
+	if m != nil {
+		return m.Close
+	}
+	return 0
+}
+
+func (m *Ticker) GetVolume() int64 {
+	if m != nil {
+		return m.Volume
+	}
+	return 0
+}
+
+func (m *Ticker) GetTurnover() int64 {
+	if m != nil {
+		return m.Turnover
+	}
+	return 0
+}
+
+func (m *Ticker) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 // exchange执行票据日志
 type ReceiptExchange struct {
 	Order       *Order   `protobuf:"bytes,1,opt,name=order" json:"order,omitempty"`
 	MatchOrders []*Order `protobuf:"bytes,2,rep,name=matchOrders" json:"matchOrders,omitempty"`
 	Index       int64    `protobuf:"varint,3,opt,name=index" json:"index,omitempty"`
+	// 是否因达到单笔交易最大撮合数量而停止撮合
+	MatchLimited bool `protobuf:"varint,4,opt,name=matchLimited" json:"matchLimited,omitempty"`
+	// 本次交易中挂单方支付的手续费总额
+	MakerFee int64 `protobuf:"varint,5,opt,name=makerFee" json:"makerFee,omitempty"`
+	// 本次交易中吃单方支付的手续费总额
+	TakerFee int64 `protobuf:"varint,6,opt,name=takerFee" json:"takerFee,omitempty"`
+	// 手续费收取地址
+	FeeAddr string `protobuf:"bytes,7,opt,name=feeAddr" json:"feeAddr,omitempty"`
 }
 
 func (m *ReceiptExchange) Reset()                    { *m = ReceiptExchange{} }
 func (m *ReceiptExchange) String() string            { return proto.CompactTextString(m) }
 func (*ReceiptExchange) ProtoMessage()               {}
-func (*ReceiptExchange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*ReceiptExchange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *ReceiptExchange) GetOrder() *Order {
 	if m != nil {
@@ -877,6 +1275,34 @@ func (m *ReceiptExchange) GetIndex() int64 {
 	return 0
 }
 
+func (m *ReceiptExchange) GetMatchLimited() bool {
+	if m != nil {
+		return m.MatchLimited
+	}
+	return false
+}
+
+func (m *ReceiptExchange) GetMakerFee() int64 {
+	if m != nil {
+		return m.MakerFee
+	}
+	return 0
+}
+
+func (m *ReceiptExchange) GetTakerFee() int64 {
+	if m != nil {
+		return m.TakerFee
+	}
+	return 0
+}
+
+func (m *ReceiptExchange) GetFeeAddr() string {
+	if m != nil {
+		return m.FeeAddr
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Exchange)(nil), "types.Exchange")
 	proto.RegisterType((*ExchangeAction)(nil), "types.ExchangeAction")
@@ -892,6 +1318,11 @@ func init() {
 	proto.RegisterType((*QueryOrder)(nil), "types.QueryOrder")
 	proto.RegisterType((*QueryOrderList)(nil), "types.QueryOrderList")
 	proto.RegisterType((*OrderList)(nil), "types.OrderList")
+	proto.RegisterType((*QueryKline)(nil), "types.QueryKline")
+	proto.RegisterType((*Kline)(nil), "types.Kline")
+	proto.RegisterType((*KlineList)(nil), "types.KlineList")
+	proto.RegisterType((*QueryTicker)(nil), "types.QueryTicker")
+	proto.RegisterType((*Ticker)(nil), "types.Ticker")
 	proto.RegisterType((*ReceiptExchange)(nil), "types.ReceiptExchange")
 }
 
@@ -936,47 +1367,63 @@ var _Exchange_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("exchange.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 667 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xdd, 0x6e, 0xd3, 0x4a,
-	0x10, 0xae, 0xed, 0x38, 0xa9, 0x27, 0x47, 0xe9, 0x39, 0xab, 0x73, 0x8e, 0x2c, 0x40, 0xa8, 0xf2,
-	0x45, 0xa9, 0x10, 0xca, 0x45, 0x2b, 0xc1, 0x75, 0x50, 0x51, 0x8b, 0x68, 0x05, 0xac, 0x50, 0x25,
-	0xae, 0x90, 0x6b, 0x0f, 0xcd, 0xaa, 0x49, 0x6c, 0xad, 0x37, 0x55, 0x2d, 0x1e, 0x82, 0x3b, 0x9e,
-	0x00, 0x5e, 0x80, 0x77, 0xe0, 0x96, 0x27, 0xe0, 0x61, 0xd0, 0xce, 0xae, 0xe3, 0x4d, 0x5b, 0xd4,
-	0x0a, 0x94, 0x3b, 0x7f, 0xf3, 0xb3, 0xfe, 0x66, 0xe6, 0x9b, 0x5d, 0x18, 0xe0, 0x45, 0x36, 0x4e,
-	0x67, 0xa7, 0x38, 0x2c, 0x65, 0xa1, 0x0a, 0x16, 0xaa, 0xba, 0xc4, 0x2a, 0x01, 0x58, 0x7f, 0x66,
-	0x1d, 0xc9, 0x77, 0x0f, 0x06, 0x0d, 0x18, 0x65, 0x4a, 0x14, 0x33, 0xb6, 0x0b, 0x30, 0x11, 0x53,
-	0xa1, 0x5e, 0xca, 0x1c, 0x65, 0xec, 0x6d, 0x7a, 0xdb, 0xfd, 0x9d, 0x7f, 0x86, 0x94, 0x3a, 0x3c,
-	0x5c, 0x38, 0x0e, 0xd6, 0xb8, 0x13, 0xc6, 0x1e, 0x43, 0x7f, 0x9a, 0xca, 0x33, 0xb4, 0x59, 0x3e,
-	0x65, 0x31, 0x9b, 0x75, 0xd4, 0x7a, 0x0e, 0xd6, 0xb8, 0x1b, 0xa8, 0xf3, 0x24, 0x9e, 0x17, 0x67,
-	0x68, 0xf2, 0x82, 0xa5, 0x3c, 0xde, 0x7a, 0x74, 0x9e, 0x13, 0xc8, 0x06, 0xe0, 0xab, 0x3a, 0xee,
-	0x6e, 0x7a, 0xdb, 0x21, 0xf7, 0x55, 0xfd, 0xb4, 0x07, 0xe1, 0x79, 0x3a, 0x99, 0x63, 0xf2, 0xd9,
-	0x03, 0x68, 0x59, 0xb2, 0x87, 0x10, 0x4d, 0xf0, 0xbd, 0x1a, 0x55, 0x15, 0x2a, 0x5b, 0xcb, 0x5f,
-	0xf6, 0xf4, 0x54, 0xdb, 0x78, 0xeb, 0x66, 0x8f, 0x00, 0xa4, 0x38, 0x1d, 0xdb, 0x60, 0xff, 0x9a,
-	0x60, 0xc7, 0xcf, 0xfe, 0x85, 0xb0, 0x94, 0x22, 0x43, 0xe2, 0x1c, 0x70, 0x03, 0xd8, 0xff, 0xd0,
-	0x4d, 0xa7, 0xc5, 0x7c, 0xa6, 0xe2, 0x0e, 0x99, 0x2d, 0xd2, 0x7c, 0x8b, 0x32, 0x0e, 0x0d, 0xdf,
-	0xa2, 0x4c, 0x3e, 0x7a, 0xd0, 0x77, 0xda, 0xb2, 0x42, 0x9e, 0x2d, 0xa3, 0xe0, 0x1a, 0x46, 0x9d,
-	0x05, 0xa3, 0x07, 0xd0, 0x77, 0xfa, 0xcd, 0x62, 0xe8, 0x15, 0xfa, 0xe3, 0xf9, 0x1e, 0xd1, 0x09,
-	0x78, 0x03, 0x93, 0x27, 0x10, 0xa6, 0xcd, 0xc9, 0x78, 0x81, 0x99, 0x15, 0x49, 0xc4, 0x2d, 0xd2,
-	0xf6, 0xaa, 0x9e, 0x9e, 0x14, 0x13, 0xe2, 0x16, 0x71, 0x8b, 0x92, 0x1f, 0x3e, 0x84, 0x37, 0x1c,
-	0x7e, 0x49, 0x7c, 0xfe, 0x6f, 0x89, 0x2f, 0xb8, 0xad, 0xf8, 0x8c, 0x88, 0x3a, 0x8d, 0x88, 0xd8,
-	0x1d, 0x58, 0xd7, 0x25, 0xcc, 0x15, 0xe6, 0x34, 0xaa, 0x80, 0x2f, 0x30, 0xbb, 0x0b, 0xd1, 0xe8,
-	0x78, 0xff, 0x9d, 0x19, 0x79, 0xd7, 0x38, 0x47, 0xc7, 0xfb, 0xaf, 0x68, 0xea, 0x31, 0xf4, 0x4e,
-	0xd2, 0x49, 0x3a, 0xcb, 0x30, 0xee, 0x99, 0x7a, 0x2c, 0xa4, 0x5e, 0xa8, 0x54, 0xcd, 0xab, 0x78,
-	0x9d, 0x7e, 0x63, 0x11, 0x63, 0xd0, 0x49, 0xf3, 0x5c, 0xc6, 0x11, 0x75, 0x88, 0xbe, 0xd9, 0x7d,
-	0x80, 0x79, 0x99, 0xa7, 0x0a, 0xdf, 0x88, 0x29, 0xc6, 0x40, 0x07, 0x39, 0x16, 0xad, 0x38, 0x31,
-	0xcb, 0xf1, 0x22, 0xee, 0x1b, 0xc5, 0x11, 0x68, 0x95, 0xff, 0xd5, 0x83, 0xbf, 0x5f, 0xcf, 0x51,
-	0xd6, 0xa6, 0xe2, 0x3d, 0x2c, 0xd5, 0x78, 0x85, 0xba, 0x32, 0xfa, 0x09, 0x1a, 0xfd, 0x68, 0xf6,
-	0xa5, 0x14, 0xd3, 0x54, 0xd6, 0x2f, 0xd0, 0x34, 0x35, 0xe2, 0x8e, 0x45, 0xb3, 0xcf, 0x48, 0x86,
-	0x66, 0x09, 0x0c, 0x48, 0xbe, 0x2c, 0xf6, 0x60, 0xd5, 0x7c, 0xff, 0x6c, 0x5f, 0xdf, 0xc2, 0x86,
-	0x43, 0xf3, 0x50, 0x54, 0x8a, 0x6d, 0x41, 0x67, 0x22, 0x2a, 0xcd, 0x32, 0xb8, 0x22, 0x37, 0x8a,
-	0xe2, 0xe4, 0xbf, 0xd4, 0x18, 0xff, 0x72, 0x63, 0x92, 0x6f, 0x1e, 0xfc, 0x47, 0x73, 0x3b, 0x10,
-	0x95, 0x2a, 0x64, 0x4d, 0xda, 0xa4, 0x3f, 0xac, 0xae, 0x19, 0xcb, 0x9c, 0x82, 0x5f, 0x0f, 0xab,
-	0xe3, 0x0c, 0x8b, 0xdd, 0x83, 0x28, 0x17, 0x12, 0xe9, 0x99, 0xb0, 0xbd, 0x69, 0x0d, 0xc9, 0x16,
-	0x00, 0x95, 0x71, 0xd3, 0xfd, 0xf1, 0xc9, 0x83, 0x41, 0x1b, 0x48, 0x85, 0xb6, 0x5b, 0xe2, 0x2d,
-	0x6d, 0x49, 0x0c, 0x3d, 0xbd, 0x19, 0x58, 0x55, 0xb6, 0x6f, 0x0d, 0x5c, 0x49, 0x01, 0x47, 0x10,
-	0xb5, 0x94, 0x36, 0x97, 0xa6, 0xdb, 0x74, 0x92, 0xfc, 0xb7, 0x9c, 0xeb, 0x07, 0xd8, 0xe0, 0x98,
-	0xa1, 0x28, 0x55, 0xf3, 0xc0, 0xb2, 0x04, 0xc2, 0xc2, 0x79, 0x55, 0x97, 0x4f, 0x35, 0x2e, 0x36,
-	0xd4, 0x97, 0x99, 0xca, 0xc6, 0x64, 0xd4, 0x75, 0x5f, 0xfd, 0xbf, 0x1b, 0xd0, 0xde, 0x0a, 0x81,
-	0x73, 0x2b, 0xec, 0x80, 0xbe, 0xca, 0xcc, 0x5f, 0x4f, 0xba, 0xf4, 0xfa, 0xef, 0xfe, 0x0c, 0x00,
-	0x00, 0xff, 0xff, 0xf2, 0x3c, 0xac, 0xed, 0x0f, 0x08, 0x00, 0x00,
+	// 913 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0xcd, 0x8e, 0xdc, 0x44,
+	0x10, 0x8e, 0xff, 0x66, 0xc6, 0x35, 0xcb, 0x24, 0xb4, 0x00, 0x59, 0x80, 0xd0, 0xca, 0x87, 0x10,
+	0x21, 0xb4, 0x87, 0x44, 0x82, 0xf3, 0x40, 0x20, 0x8b, 0x92, 0x15, 0x60, 0x45, 0x91, 0x38, 0x21,
+	0xaf, 0x5d, 0xd9, 0x69, 0xad, 0xed, 0xb6, 0xda, 0x3d, 0xc3, 0x8e, 0x78, 0x03, 0x2e, 0xdc, 0x78,
+	0x02, 0x78, 0x00, 0x50, 0x5e, 0x81, 0x2b, 0xef, 0xc2, 0x1b, 0xa0, 0xae, 0x6e, 0x8f, 0xed, 0x49,
+	0x76, 0xb3, 0x22, 0xb2, 0xb4, 0xb7, 0xfe, 0xaa, 0xba, 0xc7, 0x55, 0x5f, 0x7d, 0x5d, 0x5d, 0x03,
+	0x0b, 0xbc, 0xc8, 0x56, 0x69, 0x75, 0x86, 0x47, 0xb5, 0x14, 0x4a, 0xb0, 0x40, 0x6d, 0x6b, 0x6c,
+	0x62, 0x80, 0xd9, 0x57, 0xd6, 0x11, 0xff, 0xe3, 0xc0, 0xa2, 0x05, 0xcb, 0x4c, 0x71, 0x51, 0xb1,
+	0x07, 0x00, 0x05, 0x2f, 0xb9, 0xfa, 0x56, 0xe6, 0x28, 0x23, 0xe7, 0xd0, 0xb9, 0x37, 0xbf, 0xff,
+	0xf6, 0x11, 0x1d, 0x3d, 0x7a, 0xb2, 0x73, 0x1c, 0xdf, 0x4a, 0x7a, 0xdb, 0xd8, 0x67, 0x30, 0x2f,
+	0x53, 0x79, 0x8e, 0xf6, 0x94, 0x4b, 0xa7, 0x98, 0x3d, 0x75, 0xd2, 0x79, 0x8e, 0x6f, 0x25, 0xfd,
+	0x8d, 0xfa, 0x9c, 0xc4, 0x8d, 0x38, 0x47, 0x73, 0xce, 0x1b, 0x9c, 0x4b, 0x3a, 0x8f, 0x3e, 0xd7,
+	0xdb, 0xc8, 0x16, 0xe0, 0xaa, 0x6d, 0x34, 0x39, 0x74, 0xee, 0x05, 0x89, 0xab, 0xb6, 0x5f, 0x4c,
+	0x21, 0xd8, 0xa4, 0xc5, 0x1a, 0xe3, 0xdf, 0x1d, 0x80, 0x2e, 0x4a, 0xf6, 0x09, 0x84, 0x05, 0x3e,
+	0x57, 0xcb, 0xa6, 0x41, 0x65, 0x73, 0x39, 0xb0, 0xbf, 0x9e, 0x6a, 0x5b, 0xd2, 0xb9, 0xd9, 0xa7,
+	0x00, 0x92, 0x9f, 0xad, 0xec, 0x66, 0xf7, 0x15, 0x9b, 0x7b, 0x7e, 0xf6, 0x0e, 0x04, 0xb5, 0xe4,
+	0x19, 0x52, 0xcc, 0x5e, 0x62, 0x00, 0x7b, 0x0f, 0x26, 0x69, 0x29, 0xd6, 0x95, 0x8a, 0x7c, 0x32,
+	0x5b, 0xa4, 0xe3, 0x15, 0x75, 0x14, 0x98, 0x78, 0x45, 0x1d, 0xff, 0xea, 0xc0, 0xbc, 0x47, 0xcb,
+	0x88, 0x71, 0x76, 0x11, 0x79, 0xaf, 0x88, 0xc8, 0xdf, 0x45, 0xf4, 0x31, 0xcc, 0x7b, 0x7c, 0xb3,
+	0x08, 0xa6, 0x42, 0x2f, 0xbe, 0x79, 0x48, 0xe1, 0x78, 0x49, 0x0b, 0xe3, 0xcf, 0x21, 0x48, 0xdb,
+	0x5f, 0xc6, 0x0b, 0xcc, 0xac, 0x48, 0xc2, 0xc4, 0x22, 0x6d, 0x6f, 0xb6, 0xe5, 0xa9, 0x28, 0x28,
+	0xb6, 0x30, 0xb1, 0x28, 0x7e, 0xe1, 0x41, 0xf0, 0x9a, 0x1f, 0xdf, 0x13, 0x9f, 0xfb, 0xbf, 0xc4,
+	0xe7, 0x5d, 0x57, 0x7c, 0x46, 0x44, 0x7e, 0x2b, 0x22, 0xf6, 0x3e, 0xcc, 0x74, 0x0a, 0x6b, 0x85,
+	0x39, 0x95, 0xca, 0x4b, 0x76, 0x98, 0x7d, 0x00, 0xe1, 0xf2, 0xd9, 0xa3, 0x1f, 0x4d, 0xc9, 0x27,
+	0xc6, 0xb9, 0x7c, 0xf6, 0xe8, 0x3b, 0xaa, 0x7a, 0x04, 0xd3, 0xd3, 0xb4, 0x48, 0xab, 0x0c, 0xa3,
+	0xa9, 0xc9, 0xc7, 0x42, 0xe2, 0x42, 0xa5, 0x6a, 0xdd, 0x44, 0x33, 0xfa, 0x8c, 0x45, 0x8c, 0x81,
+	0x9f, 0xe6, 0xb9, 0x8c, 0x42, 0x62, 0x88, 0xd6, 0xec, 0x23, 0x80, 0x75, 0x9d, 0xa7, 0x0a, 0x9f,
+	0xf2, 0x12, 0x23, 0xa0, 0x1f, 0xea, 0x59, 0xb4, 0xe2, 0x78, 0x95, 0xe3, 0x45, 0x34, 0x37, 0x8a,
+	0x23, 0xa0, 0x4f, 0x95, 0xa9, 0xca, 0x56, 0x5f, 0x52, 0x8d, 0x0f, 0xe8, 0x2b, 0x3d, 0x8b, 0x4e,
+	0xaa, 0x4c, 0xcf, 0x51, 0x7e, 0x8d, 0x18, 0xbd, 0x65, 0xe2, 0x6e, 0xb1, 0xf6, 0xa9, 0xd6, 0xb7,
+	0x30, 0xbe, 0x16, 0x77, 0x37, 0xea, 0x2f, 0x07, 0xee, 0x7c, 0xbf, 0x46, 0xb9, 0x35, 0x4c, 0x3e,
+	0xc4, 0x5a, 0xad, 0x46, 0xd4, 0xab, 0xd1, 0xa5, 0xd7, 0xea, 0x52, 0xe7, 0x57, 0x4b, 0x5e, 0xa6,
+	0x72, 0xfb, 0x18, 0x4d, 0xb1, 0xc2, 0xa4, 0x67, 0xd1, 0xac, 0x64, 0x94, 0xba, 0xb9, 0x5c, 0x06,
+	0xc4, 0x7f, 0xec, 0xee, 0xd7, 0xd8, 0xf1, 0xbe, 0x59, 0x1f, 0xf8, 0x01, 0x6e, 0xf7, 0xc2, 0x7c,
+	0xc2, 0x1b, 0xc5, 0xee, 0x82, 0x5f, 0xf0, 0x46, 0x47, 0xe9, 0xbd, 0x24, 0x63, 0xda, 0x95, 0x90,
+	0x7f, 0x8f, 0x18, 0x77, 0x9f, 0x98, 0xf8, 0x6f, 0x07, 0xde, 0xa5, 0xba, 0x1d, 0xf3, 0x46, 0x09,
+	0xb9, 0x25, 0xcd, 0xd3, 0x17, 0xc6, 0x23, 0x63, 0x18, 0x93, 0x77, 0x79, 0xb1, 0xfc, 0x5e, 0xb1,
+	0xd8, 0x87, 0x10, 0xe6, 0x5c, 0x22, 0x3d, 0x3f, 0x96, 0x9b, 0xce, 0x10, 0xdf, 0x05, 0xa0, 0x34,
+	0x5e, 0xd7, 0x97, 0x7e, 0x73, 0x60, 0xd1, 0x6d, 0xa4, 0x44, 0xbb, 0xdb, 0xe7, 0x0c, 0x6e, 0x5f,
+	0x04, 0x53, 0x7d, 0xe3, 0xb0, 0x69, 0x2c, 0x6f, 0x2d, 0x1c, 0x25, 0x81, 0x13, 0x08, 0xbb, 0x90,
+	0x0e, 0x07, 0xd5, 0x6d, 0x99, 0x24, 0xff, 0x35, 0xeb, 0xfa, 0x8b, 0x6b, 0x09, 0x79, 0x5c, 0xf0,
+	0x0a, 0xc7, 0x7d, 0x39, 0x6a, 0x94, 0x5c, 0xe4, 0x96, 0x07, 0x8b, 0x74, 0xb6, 0x8d, 0x4a, 0xa5,
+	0xa2, 0x36, 0x65, 0xe4, 0xdd, 0x19, 0x34, 0xb7, 0x58, 0xe5, 0xe4, 0x33, 0x3d, 0xb4, 0x85, 0x7b,
+	0x89, 0x4d, 0x2e, 0xe7, 0x76, 0x7a, 0x29, 0xb7, 0xb3, 0x7d, 0x6e, 0xff, 0x74, 0x21, 0xb8, 0x99,
+	0x3c, 0x30, 0xf0, 0x45, 0x8d, 0x95, 0x25, 0x81, 0xd6, 0xda, 0xb6, 0xe2, 0x67, 0x2b, 0xfb, 0x7e,
+	0xd0, 0x9a, 0xdd, 0x01, 0xaf, 0x10, 0x3f, 0xd9, 0x77, 0x43, 0x2f, 0x89, 0x87, 0x42, 0x34, 0x48,
+	0xd9, 0x7a, 0x89, 0x01, 0x3a, 0x8a, 0x8d, 0x28, 0xd6, 0x25, 0xd2, 0x9b, 0xe1, 0x25, 0x16, 0x51,
+	0x0f, 0x5f, 0xcb, 0x4a, 0x6c, 0x50, 0xda, 0x37, 0x63, 0x87, 0x3b, 0x46, 0xe7, 0xfd, 0xde, 0x78,
+	0x02, 0x21, 0x51, 0x76, 0x85, 0x1e, 0xc9, 0x7f, 0x4d, 0x3d, 0xfe, 0x0c, 0x73, 0x92, 0xe3, 0x53,
+	0x9e, 0x9d, 0x8f, 0x3a, 0xc9, 0x30, 0xf0, 0x95, 0xa6, 0xda, 0x34, 0x5a, 0x5a, 0xc7, 0x2f, 0x5c,
+	0x98, 0x8c, 0xfe, 0xe1, 0x41, 0xa1, 0xbd, 0x2b, 0x04, 0xef, 0x0f, 0x05, 0x7f, 0x73, 0x25, 0xf0,
+	0xaf, 0x03, 0xb7, 0x13, 0xcc, 0x90, 0xd7, 0xaa, 0x9d, 0xfe, 0x59, 0x0c, 0x81, 0xe8, 0x8d, 0xfc,
+	0xc3, 0xd6, 0x64, 0x5c, 0xec, 0x48, 0x4f, 0x5a, 0x2a, 0x5b, 0x91, 0x51, 0x37, 0xcf, 0x97, 0x9b,
+	0x58, 0x7f, 0x43, 0x37, 0xb2, 0x78, 0xfd, 0x91, 0x25, 0x86, 0x03, 0xda, 0x44, 0x03, 0x1d, 0xe6,
+	0x44, 0xdb, 0x2c, 0x19, 0xd8, 0x06, 0x63, 0x4b, 0x70, 0xc5, 0xd8, 0x32, 0x19, 0x8e, 0x2d, 0xba,
+	0x1a, 0xcf, 0x11, 0x97, 0x7a, 0xb6, 0x9a, 0x9a, 0xd6, 0x6e, 0xe1, 0x7d, 0xd0, 0xd3, 0x9d, 0xc9,
+	0xf5, 0x74, 0x42, 0x7f, 0x88, 0x1e, 0xfc, 0x37, 0x00, 0xc0, 0x72, 0xb8, 0x5f, 0x22, 0x0d, 0x00,
+	0x00,
 }
@@ -30,6 +30,8 @@ func JavaScriptCmd() *cobra.Command {
 		JavaScriptCreateCmd(),
 		JavaScriptCallCmd(),
 		JavaScriptQueryCmd(),
+		JavaScriptUpgradeCmd(),
+		JavaScriptVersionsCmd(),
 	)
 	return cmd
 }
@@ -159,3 +161,86 @@ func queryJavaScript(cmd *cobra.Command, args []string) {
 	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, rep)
 	ctx.Run()
 }
+
+// JavaScriptUpgradeCmd :
+func JavaScriptUpgradeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "upgrade java script contract code",
+		Run:   upgradeJavaScriptContract,
+	}
+	upgradeJavaScriptContractFlags(cmd)
+	return cmd
+}
+
+func upgradeJavaScriptContractFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("code", "c", "", "path of js file,it must always be in utf-8.")
+	cmd.MarkFlagRequired("code")
+
+	cmd.Flags().StringP("name", "n", "", "contract name")
+	cmd.MarkFlagRequired("name")
+
+	cmd.Flags().StringP("args", "a", "", "json str of args passed to Migrate")
+}
+
+func upgradeJavaScriptContract(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	patch, _ := cmd.Flags().GetString("code")
+	name, _ := cmd.Flags().GetString("name")
+	input, _ := cmd.Flags().GetString("args")
+
+	codestr, err := ioutil.ReadFile(patch)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	upgrade := &jsproto.Upgrade{
+		Code: string(codestr),
+		Name: name,
+		Args: input,
+	}
+
+	params := &rpctypes.CreateTxIn{
+		Execer:     jsty.JsX,
+		ActionName: "Upgrade",
+		Payload:    types.MustPBToJSON(upgrade),
+	}
+
+	var res string
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.CreateTransaction", params, &res)
+	ctx.RunWithoutMarshal()
+}
+
+//JavaScriptVersionsCmd :
+func JavaScriptVersionsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "versions",
+		Short: "query code versions of java script contract",
+		Run:   queryJavaScriptVersions,
+	}
+	cmd.Flags().StringP("name", "n", "", "java script contract name")
+	cmd.MarkFlagRequired("name")
+	cmd.Flags().Int32P("version", "v", -1, "show code of the version, 0 for the current version")
+	return cmd
+}
+
+func queryJavaScriptVersions(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	name, _ := cmd.Flags().GetString("name")
+	version, _ := cmd.Flags().GetInt32("version")
+	var params rpctypes.Query4Jrpc
+	params.Execer = jsty.JsX
+	if version < 0 {
+		params.FuncName = "QueryVersions"
+		params.Payload = types.MustPBToJSON(&jsproto.QueryVersionsReq{Name: name})
+		var rep jsproto.CodeVersionList
+		ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &rep)
+		ctx.Run()
+		return
+	}
+	params.FuncName = "QueryCode"
+	params.Payload = types.MustPBToJSON(&jsproto.QueryCodeReq{Name: name, Version: version})
+	var rep jsproto.CodeVersion
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &rep)
+	ctx.Run()
+}
@@ -16,6 +16,11 @@ func (c *js) userExecName(name string, local bool) string {
 	return execer
 }
 
+func (c *js) isUpgradeFork() bool {
+	cfg := c.GetAPI().GetConfig()
+	return cfg.IsDappFork(c.GetHeight(), ptypes.JsX, ptypes.ForkJsUpgrade)
+}
+
 // execName 在create 时为 jsvm
 // 在 call 时为 user.jsvm.game
 func (c *js) checkTxExec(txExec string, execName string) bool {
@@ -42,7 +47,14 @@ func (c *js) Exec_Create(payload *jsproto.Create, tx *types.Transaction, index i
 	if err == nil {
 		return nil, ptypes.ErrDupName
 	}
-	kvc.AddNoPrefix(calcCodeKey(payload.Name), []byte(payload.Code))
+	//分叉之前只保存代码, 没有版本记录
+	if c.isUpgradeFork() {
+		ver := c.newCodeVersion(payload.Name, payload.Code, 1, tx)
+		ver.Creator = tx.From()
+		addCodeVersion(kvc, ver)
+	} else {
+		kvc.AddNoPrefix(calcCodeKey(payload.Name), []byte(payload.Code))
+	}
 	jsvalue, err := c.callVM("init", &jsproto.Call{Name: payload.Name}, tx, index, nil)
 	if err != nil {
 		return nil, err
@@ -75,3 +87,57 @@ func (c *js) Exec_Call(payload *jsproto.Call, tx *types.Transaction, index int)
 	r := &types.Receipt{Ty: types.ExecOk, KV: kvc.KVList(), Logs: logs}
 	return r, nil
 }
+
+//Exec_Upgrade 升级合约代码, 新版本的代码可以定义 Migrate 函数迁移旧版本的数据
+func (c *js) Exec_Upgrade(payload *jsproto.Upgrade, tx *types.Transaction, index int) (*types.Receipt, error) {
+	if !c.isUpgradeFork() {
+		return nil, types.ErrActionNotSupport
+	}
+	if !c.checkTxExec(string(tx.Execer), ptypes.JsX) {
+		return nil, types.ErrExecNameNotMatch
+	}
+	current, err := getCodeVersion(c.GetStateDB(), payload.Name)
+	if err != nil {
+		return nil, err
+	}
+	if tx.From() != current.Creator {
+		err = checkPriv(tx.From(), ptypes.JsCreator, c.GetStateDB())
+		if err == ptypes.ErrJsCreator {
+			return nil, ptypes.ErrNotContractOwner
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	execer := c.userExecName(payload.Name, false)
+	c.prefix = types.CalcStatePrefix([]byte(execer))
+	kvc := dapp.NewKVCreator(c.GetStateDB(), c.prefix, nil)
+	//升级功能之前创建的合约, 先把当前的代码保存为第一个版本
+	if current.TxHash == "" {
+		first, err := getCodeHistory(c.GetStateDB(), payload.Name, 1)
+		if err != nil {
+			return nil, err
+		}
+		kvc.AddNoPrefix(calcCodeHistoryKey(payload.Name, 1), types.Encode(first))
+	}
+	codecache.Remove(codeCacheKey(c.GetStateDB(), payload.Name))
+	ver := c.newCodeVersion(payload.Name, payload.Code, current.Version+1, tx)
+	ver.Creator = current.Creator
+	addCodeVersion(kvc, ver)
+	//Migrate 函数的参数为空时传入空对象
+	args := payload.Args
+	if args == "" {
+		args = "{}"
+	}
+	jsvalue, err := c.callVM("migrate", &jsproto.Call{Name: payload.Name, Args: args}, tx, index, nil)
+	if err != nil {
+		return nil, err
+	}
+	kvs, logs, err := parseJsReturn(c.prefix, jsvalue)
+	if err != nil {
+		return nil, err
+	}
+	kvc.AddListNoPrefix(kvs)
+	r := &types.Receipt{Ty: types.ExecOk, KV: kvc.KVList(), Logs: logs}
+	return r, nil
+}
@@ -9,6 +9,10 @@ func (c *js) ExecDelLocal_Create(payload *jsproto.Create, tx *types.Transaction,
 	return &types.LocalDBSet{}, nil
 }
 
+func (c *js) ExecDelLocal_Upgrade(payload *jsproto.Upgrade, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return &types.LocalDBSet{}, nil
+}
+
 func (c *js) ExecDelLocal_Call(payload *jsproto.Call, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
 	execer := c.userExecName(payload.Name, true)
 	r := &types.LocalDBSet{}
@@ -9,6 +9,10 @@ func (c *js) ExecLocal_Create(payload *jsproto.Create, tx *types.Transaction, re
 	return &types.LocalDBSet{}, nil
 }
 
+func (c *js) ExecLocal_Upgrade(payload *jsproto.Upgrade, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return &types.LocalDBSet{}, nil
+}
+
 func (c *js) ExecLocal_Call(payload *jsproto.Call, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
 	execer := c.userExecName(payload.Name, true)
 	c.prefix = types.CalcLocalPrefix([]byte(execer))
@@ -114,14 +114,14 @@ func (u *js) callVM(prefix string, payload *jsproto.Call, tx *types.Transaction,
 		return nil, err
 	}
 	vm.Set("loglist", loglist)
-	if prefix == "init" {
-		vm.Set("f", "init")
+	if prefix == "init" || prefix == "migrate" {
+		vm.Set("f", prefix)
 	} else {
 		vm.Set("f", prefix+"_"+payload.Funcname)
 	}
 	vm.Set("args", payload.Args)
 	callfunc := "callcode(context, f, args, loglist)"
-	jsvalue, err := vm.Run(callfunc)
+	jsvalue, err := u.runVM(vm, callfunc, txStepLimit(tx), tx)
 	//除非你知道怎么做，不要返回这样的操作，这会引起整个区块执行失败，从而引起严重的安全问题。
 	//要保证不能人工的创造这样的条件，也就是调用接口的输入，不能用户可以任意修改的。
 	if u.GetExecutorAPI().IsErr() {
@@ -296,7 +296,8 @@ func (u *js) createVM(name string, tx *types.Transaction, index int) (*otto.Otto
 		return nil, err
 	}
 	var vm *otto.Otto
-	if vmitem, ok := codecache.Get(name); ok {
+	cachekey := codeCacheKey(u.GetStateDB(), name)
+	if vmitem, ok := codecache.Get(cachekey); ok {
 		vm = vmitem.(*otto.Otto).Copy()
 	} else {
 		code, err := u.GetStateDB().Get(calcCodeKey(name))
@@ -304,9 +305,13 @@ func (u *js) createVM(name string, tx *types.Transaction, index int) (*otto.Otto
 			return nil, err
 		}
 		//cache 合约代码部分，不会cache 具体执行
+		//顶层代码的步数限制是固定的，和有没有命中cache无关，保证各个节点的执行结果一致
 		cachevm := basevm.Copy()
-		cachevm.Run(code)
-		codecache.Add(name, cachevm)
+		_, err = u.runVM(cachevm, code, ptypes.JsCodeStepLimit, tx)
+		if err == ptypes.ErrJsStepLimit {
+			return nil, err
+		}
+		codecache.Add(cachekey, cachevm)
 		vm = cachevm.Copy()
 	}
 	vm.Set("context", string(data))
@@ -55,6 +55,15 @@ func initExec(ldb db.DB, kvdb db.KVDB, code string, t assert.TestingT) *js {
 	return e
 }
 
+//setForkHeight 使用非 local 的配置, 设置 js 分叉的高度
+func setForkHeight(e *js, fork string, height int64) {
+	cfg := types.NewChain33Config(strings.Replace(types.GetDefaultCfgstring(), "Title=\"local\"", "Title=\"chain33\"", 1))
+	cfg.SetDappFork(ptypes.JsX, fork, height)
+	mockapi := &mocks.QueueProtocolAPI{}
+	mockapi.On("GetConfig", mock.Anything).Return(cfg, nil)
+	e.SetAPI(mockapi)
+}
+
 func createCodeTx(name, jscode string) (*jsproto.Create, *types.Transaction) {
 	data := &jsproto.Create{
 		Code: jscode,
@@ -227,3 +236,222 @@ func printMemUsage() {
 func bToMb(b uint64) uint64 {
 	return b / 1024 / 1024
 }
+
+var loopcode = `
+function Init(context) {
+    this.kvc = new kvcreator("init")
+    return this.kvc.receipt()
+}
+
+Exec.prototype.loop = function(args) {
+    for (var i = 0; i < args.n; i++) {
+    }
+    this.kvc.add("n", args.n)
+    return this.kvc.receipt()
+}
+
+Exec.prototype.forever = function(args) {
+    try {
+        while (true) {}
+    } catch (e) {
+    }
+    return this.kvc.receipt()
+}
+
+Query.prototype.forever = function(args) {
+    while (true) {}
+}
+`
+
+func TestStepLimit(t *testing.T) {
+	dir, ldb, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, ldb)
+	e := initExec(ldb, kvdb, jscode, t)
+	c, tx := createCodeTx("steplimit", loopcode)
+	receipt, err := e.Exec_Create(c, tx, 0)
+	assert.Nil(t, err)
+	util.SaveKVList(ldb, receipt.KV)
+
+	//死循环在步数用完之后中止, js 的 try catch 不能捕获
+	call, tx := callCodeTx("steplimit", "forever", "{}")
+	tx.Fee = 1000000
+	_, err = e.Exec_Call(call, tx, 0)
+	assert.Equal(t, ptypes.ErrJsStepLimit, err)
+	_, err = e.Query_Query(call)
+	assert.Equal(t, ptypes.ErrJsStepLimit, err)
+
+	//步数由手续费决定
+	call, tx = callCodeTx("steplimit", "loop", `{"n":100000}`)
+	tx.Fee = 0
+	_, err = e.Exec_Call(call, tx, 0)
+	assert.Equal(t, ptypes.ErrJsStepLimit, err)
+	tx.Fee = 1000000
+	receipt, err = e.Exec_Call(call, tx, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "100000", string(receipt.KV[0].Value))
+
+	//加载代码时顶层代码的步数有固定的限制
+	c, tx = createCodeTx("toplevel", "while (true) {}\n"+loopcode)
+	tx.Fee = 1000000
+	_, err = e.Exec_Create(c, tx, 0)
+	assert.Equal(t, ptypes.ErrJsStepLimit, err)
+
+	//分叉之前不限制步数
+	setForkHeight(e, ptypes.ForkJsStepLimit, 10)
+	call, tx = callCodeTx("steplimit", "loop", `{"n":100000}`)
+	tx.Fee = 0
+	receipt, err = e.Exec_Call(call, tx, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "100000", string(receipt.KV[0].Value))
+	//查询在分叉之前也限制步数
+	call, _ = callCodeTx("steplimit", "forever", "{}")
+	_, err = e.Query_Query(call)
+	assert.Equal(t, ptypes.ErrJsStepLimit, err)
+	call, tx = callCodeTx("steplimit", "loop", `{"n":100000}`)
+	tx.Fee = 0
+	e.SetEnv(10, time.Now().Unix(), 1)
+	_, err = e.Exec_Call(call, tx, 0)
+	assert.Equal(t, ptypes.ErrJsStepLimit, err)
+
+	assert.Equal(t, uint64(ptypes.JsMaxStepLimit), txStepLimit(nil))
+	assert.Equal(t, uint64(ptypes.JsMaxStepLimit), txStepLimit(&types.Transaction{Fee: math.MaxInt64}))
+	assert.Equal(t, uint64(ptypes.JsBaseStepLimit), txStepLimit(&types.Transaction{Fee: -1}))
+}
+
+var upgradecode1 = `
+function Init(context) {
+    this.kvc = new kvcreator("init")
+    this.kvc.add("value", 1)
+    return this.kvc.receipt()
+}
+
+Exec.prototype.version = function(args) {
+    this.kvc.add("version", 1)
+    return this.kvc.receipt()
+}
+`
+
+var upgradecode2 = `
+function Init(context) {
+    this.kvc = new kvcreator("init")
+    this.kvc.add("value", 1)
+    return this.kvc.receipt()
+}
+
+function Migrate(context, args) {
+    var kvc = new kvcreator("migrate")
+    var value = kvc.get("value")
+    kvc.add("value", value + args.add)
+    kvc.addlog({"from": value})
+    return kvc.receipt()
+}
+
+Exec.prototype.version = function(args) {
+    this.kvc.add("version", 2)
+    return this.kvc.receipt()
+}
+`
+
+var upgradecode3 = upgradecode1 + `
+function Migrate(context, args) {
+    var kvc = new kvcreator("migrate")
+    kvc.addlog({"args": args})
+    return kvc.receipt()
+}
+`
+
+func upgradeCodeTx(name, jscode, args string) (*jsproto.Upgrade, *types.Transaction) {
+	data := &jsproto.Upgrade{
+		Code: jscode,
+		Name: name,
+		Args: args,
+	}
+	return data, &types.Transaction{Execer: []byte(ptypes.JsX), Payload: types.Encode(data)}
+}
+
+func TestUpgrade(t *testing.T) {
+	dir, ldb, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, ldb)
+	e := initExec(ldb, kvdb, jscode, t)
+	c, tx := createCodeTx("upgrade", upgradecode1)
+	receipt, err := e.Exec_Create(c, tx, 0)
+	assert.Nil(t, err)
+	util.SaveKVList(ldb, receipt.KV)
+
+	call, calltx := callCodeTx("upgrade", "version", "{}")
+	receipt, err = e.Exec_Call(call, calltx, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "1", string(receipt.KV[0].Value))
+
+	//只有创建者和管理员可以升级
+	u, tx := upgradeCodeTx("upgrade", upgradecode2, `{"add":10}`)
+	tx.Signature = &types.Signature{Pubkey: []byte("other")}
+	_, err = e.Exec_Upgrade(u, tx, 0)
+	assert.Equal(t, ptypes.ErrNotContractOwner, err)
+	u, tx = upgradeCodeTx("notexist", upgradecode2, "")
+	_, err = e.Exec_Upgrade(u, tx, 0)
+	assert.Equal(t, ptypes.ErrCodeNotFound, err)
+
+	//升级之后执行 Migrate, 并且不再使用旧代码的缓存
+	u, tx = upgradeCodeTx("upgrade", upgradecode2, `{"add":10}`)
+	receipt, err = e.Exec_Upgrade(u, tx, 0)
+	assert.Nil(t, err)
+	util.SaveKVList(ldb, receipt.KV)
+	assert.Equal(t, "11", string(receipt.KV[len(receipt.KV)-1].Value))
+	assert.Equal(t, 1, len(receipt.Logs))
+	receipt, err = e.Exec_Call(call, calltx, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "2", string(receipt.KV[0].Value))
+
+	reply, err := e.Query_QueryVersions(&jsproto.QueryVersionsReq{Name: "upgrade"})
+	assert.Nil(t, err)
+	versions := reply.(*jsproto.CodeVersionList).Versions
+	assert.Equal(t, 2, len(versions))
+	assert.Equal(t, int32(2), versions[1].Version)
+	assert.Equal(t, tx.From(), versions[1].Creator)
+	assert.Equal(t, "", versions[1].Code)
+	reply, err = e.Query_QueryCode(&jsproto.QueryCodeReq{Name: "upgrade", Version: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, upgradecode1, reply.(*jsproto.CodeVersion).Code)
+	reply, err = e.Query_QueryCode(&jsproto.QueryCodeReq{Name: "upgrade"})
+	assert.Nil(t, err)
+	assert.Equal(t, upgradecode2, reply.(*jsproto.CodeVersion).Code)
+	_, err = e.Query_QueryCode(&jsproto.QueryCodeReq{Name: "upgrade", Version: 3})
+	assert.Equal(t, ptypes.ErrCodeNotFound, err)
+
+	//升级功能之前创建的合约没有版本记录, 升级时把当前代码保存为第一个版本
+	kvdb.Set(calcCodeKey("legacy"), []byte(upgradecode1))
+	kvdb.Set(append(types.CalcStatePrefix([]byte("user.jsvm.legacy")), "value"...), []byte("1"))
+	reply, err = e.Query_QueryVersions(&jsproto.QueryVersionsReq{Name: "legacy"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(reply.(*jsproto.CodeVersionList).Versions))
+	u, tx = upgradeCodeTx("legacy", upgradecode2, `{"add":1}`)
+	receipt, err = e.Exec_Upgrade(u, tx, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "2", string(receipt.KV[len(receipt.KV)-1].Value))
+	util.SaveKVList(ldb, receipt.KV)
+	reply, err = e.Query_QueryCode(&jsproto.QueryCodeReq{Name: "legacy", Version: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, upgradecode1, reply.(*jsproto.CodeVersion).Code)
+	assert.Equal(t, "", reply.(*jsproto.CodeVersion).TxHash)
+	reply, err = e.Query_QueryVersions(&jsproto.QueryVersionsReq{Name: "legacy"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(reply.(*jsproto.CodeVersionList).Versions))
+
+	//Migrate 的参数为空时传入空对象
+	u, tx = upgradeCodeTx("legacy", upgradecode3, "")
+	receipt, err = e.Exec_Upgrade(u, tx, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(receipt.Logs))
+
+	//分叉之前不能升级合约, 创建合约时不保存版本记录
+	setForkHeight(e, ptypes.ForkJsUpgrade, 10)
+	c, tx = createCodeTx("beforefork", upgradecode1)
+	receipt, err = e.Exec_Create(c, tx, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(receipt.KV))
+	assert.Equal(t, calcCodeKey("beforefork"), receipt.KV[0].Key)
+	assert.Equal(t, []byte("1"), receipt.KV[1].Value)
+	_, err = e.Exec_Upgrade(u, tx, 0)
+	assert.Equal(t, types.ErrActionNotSupport, err)
+}
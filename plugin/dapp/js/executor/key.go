@@ -1,6 +1,8 @@
 package executor
 
 import (
+	"fmt"
+
 	"github.com/33cn/chain33/types"
 	ptypes "github.com/33cn/plugin/plugin/dapp/js/types"
 )
@@ -15,3 +17,13 @@ func calcAllPrefix(cfg *types.Chain33Config, name string) ([]byte, []byte) {
 func calcCodeKey(name string) []byte {
 	return append([]byte("mavl-"+ptypes.JsX+"-code-"), []byte(name)...)
 }
+
+//合约当前版本的信息, 不包含代码
+func calcCodeVersionKey(name string) []byte {
+	return append([]byte("mavl-"+ptypes.JsX+"-version-"), []byte(name)...)
+}
+
+//合约每个版本的代码
+func calcCodeHistoryKey(name string, version int32) []byte {
+	return []byte(fmt.Sprintf("mavl-%s-history-%s-%010d", ptypes.JsX, name, version))
+}
@@ -22,3 +22,34 @@ func (c *js) Query_Query(payload *jsproto.Call) (types.Message, error) {
 	}
 	return &jsproto.QueryResult{Data: str}, nil
 }
+
+//Query_QueryVersions 查询合约所有版本的信息, 不包含代码
+func (c *js) Query_QueryVersions(payload *jsproto.QueryVersionsReq) (types.Message, error) {
+	current, err := getCodeVersion(c.GetStateDB(), payload.Name)
+	if err != nil {
+		return nil, err
+	}
+	list := &jsproto.CodeVersionList{}
+	for v := int32(1); v <= current.Version; v++ {
+		ver, err := getCodeHistory(c.GetStateDB(), payload.Name, v)
+		if err != nil {
+			return nil, err
+		}
+		ver.Code = ""
+		list.Versions = append(list.Versions, ver)
+	}
+	return list, nil
+}
+
+//Query_QueryCode 查询合约指定版本的代码, version 为0时查询当前版本
+func (c *js) Query_QueryCode(payload *jsproto.QueryCodeReq) (types.Message, error) {
+	version := payload.Version
+	if version == 0 {
+		current, err := getCodeVersion(c.GetStateDB(), payload.Name)
+		if err != nil {
+			return nil, err
+		}
+		version = current.Version
+	}
+	return getCodeHistory(c.GetStateDB(), payload.Name, version)
+}
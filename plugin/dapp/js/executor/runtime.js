@@ -321,7 +321,7 @@ function kvcreator(dbtype) {
 	this.getstate = getstatedb
 	this.getloal = getlocaldb
 	this.list = listdb
-    if (dbtype == "exec" || dbtype == "init") {
+    if (dbtype == "exec" || dbtype == "init" || dbtype == "migrate") {
         this.getdb = this.getstate
     } else if (dbtype == "local") {
         this.getdb = this.getlocal
@@ -389,7 +389,7 @@ kvcreator.prototype.listvalue = function(prefix, key, count, direction) {
 }
 
 kvcreator.prototype.addlog = function(log, ty, format) {
-    if (this.type != "exec") {
+    if (this.type != "exec" && this.type != "migrate") {
         throw new Error("local or query can't set log")
 	}
 	if (!isstring(log)) {
@@ -483,6 +483,13 @@ function callcode(context, f, args, loglist) {
 	if (f == "init") {
 		return Init(JSON.parse(context))
 	}
+	//升级合约时调用新版本代码中的 Migrate 函数迁移数据, 没有定义时不做任何修改
+	if (f == "migrate") {
+		if (typeof Migrate !== "function") {
+			return {kvs: [], logs: []}
+		}
+		return Migrate(JSON.parse(context), JSON.parse(args))
+	}
     var farr = f.split("_", 2)
     if (farr.length !=  2) {
         throw new Error("chain33.js: invalid function name format")
@@ -0,0 +1,73 @@
+package executor
+
+import (
+	"github.com/33cn/chain33/types"
+	ptypes "github.com/33cn/plugin/plugin/dapp/js/types"
+	"github.com/robertkrimen/otto"
+)
+
+type stepLimitPanic struct{}
+
+//stepMeter 统计 js 虚拟机执行的步数
+//当前依赖的 otto 版本在求值每个语句节点和表达式节点之前检查 Interrupt 通道
+//(cmpl_evaluate_nodeStatement 和 cmpl_evaluate_nodeExpression), 所以一步是一个语法树节点, 不是一行代码;
+//一些新版本的 otto 只在语句之间检查, 升级 otto 会改变计步的结果, 需要新的分叉.
+//通道中始终保留一个计数的回调, 计数结果和节点的运行速度无关, 所有节点都在同一步中止执行
+type stepMeter struct {
+	limit uint64
+	used  uint64
+}
+
+func newStepMeter(limit uint64) *stepMeter {
+	return &stepMeter{limit: limit}
+}
+
+//txStepLimit 根据交易手续费计算调用可以执行的步数, 查询没有交易, 使用最大步数
+func txStepLimit(tx *types.Transaction) uint64 {
+	if tx == nil {
+		return ptypes.JsMaxStepLimit
+	}
+	limit := uint64(ptypes.JsBaseStepLimit)
+	if tx.Fee > 0 {
+		limit += uint64(tx.Fee) / ptypes.JsStepPrice
+	}
+	if limit > ptypes.JsMaxStepLimit {
+		limit = ptypes.JsMaxStepLimit
+	}
+	return limit
+}
+
+//runVM 在步数限制内执行代码, 分叉之前的交易不限制步数;
+//查询(tx 为 nil)不影响共识, 在任何高度都限制步数
+func (u *js) runVM(vm *otto.Otto, src interface{}, limit uint64, tx *types.Transaction) (otto.Value, error) {
+	cfg := u.GetAPI().GetConfig()
+	if tx != nil && !cfg.IsDappFork(u.GetHeight(), ptypes.JsX, ptypes.ForkJsStepLimit) {
+		return vm.Run(src)
+	}
+	return newStepMeter(limit).run(vm, src)
+}
+
+//run 在步数限制内执行代码, 超过限制返回 ErrJsStepLimit
+func (m *stepMeter) run(vm *otto.Otto, src interface{}) (value otto.Value, err error) {
+	vm.Interrupt = make(chan func(), 1)
+	var step func()
+	step = func() {
+		m.used++
+		if m.used > m.limit {
+			panic(stepLimitPanic{})
+		}
+		vm.Interrupt <- step
+	}
+	vm.Interrupt <- step
+	defer func() {
+		vm.Interrupt = nil
+		if caught := recover(); caught != nil {
+			if _, ok := caught.(stepLimitPanic); ok {
+				value, err = otto.UndefinedValue(), ptypes.ErrJsStepLimit
+				return
+			}
+			panic(caught)
+		}
+	}()
+	return vm.Run(src)
+}
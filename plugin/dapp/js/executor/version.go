@@ -0,0 +1,103 @@
+package executor
+
+import (
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/system/dapp"
+	"github.com/33cn/chain33/types"
+	ptypes "github.com/33cn/plugin/plugin/dapp/js/types"
+	"github.com/33cn/plugin/plugin/dapp/js/types/jsproto"
+)
+
+//getCodeVersion 获取合约当前版本的信息
+//升级功能之前创建的合约没有版本记录, 当作创建者未知的第一个版本
+func getCodeVersion(db db.KV, name string) (*jsproto.CodeVersion, error) {
+	data, err := db.Get(calcCodeVersionKey(name))
+	if err == nil && data != nil {
+		var ver jsproto.CodeVersion
+		err = types.Decode(data, &ver)
+		if err != nil {
+			return nil, err
+		}
+		return &ver, nil
+	}
+	if err != nil && err != types.ErrNotFound {
+		return nil, err
+	}
+	code, err := db.Get(calcCodeKey(name))
+	if err == types.ErrNotFound || (err == nil && code == nil) {
+		return nil, ptypes.ErrCodeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &jsproto.CodeVersion{Name: name, Version: 1, CodeHash: common.ToHex(common.Sha256(code))}, nil
+}
+
+//getCodeHistory 获取合约指定版本的代码
+func getCodeHistory(db db.KV, name string, version int32) (*jsproto.CodeVersion, error) {
+	data, err := db.Get(calcCodeHistoryKey(name, version))
+	if err == nil && data != nil {
+		var ver jsproto.CodeVersion
+		err = types.Decode(data, &ver)
+		if err != nil {
+			return nil, err
+		}
+		return &ver, nil
+	}
+	if err != nil && err != types.ErrNotFound {
+		return nil, err
+	}
+	//没有版本记录的合约, 当前代码就是第一个版本
+	if version != 1 {
+		return nil, ptypes.ErrCodeNotFound
+	}
+	ver, err := getCodeVersion(db, name)
+	if err != nil {
+		return nil, err
+	}
+	if ver.Version != 1 {
+		return nil, ptypes.ErrCodeNotFound
+	}
+	code, err := db.Get(calcCodeKey(name))
+	if err != nil {
+		return nil, err
+	}
+	ver.Code = string(code)
+	return ver, nil
+}
+
+func (c *js) newCodeVersion(name, code string, version int32, tx *types.Transaction) *jsproto.CodeVersion {
+	return &jsproto.CodeVersion{
+		Name:     name,
+		Version:  version,
+		Height:   c.GetHeight(),
+		TxHash:   common.ToHex(tx.Hash()),
+		From:     tx.From(),
+		CodeHash: common.ToHex(common.Sha256([]byte(code))),
+		Code:     code,
+	}
+}
+
+//addCodeVersion 保存新版本的代码, 历史记录中保存完整的代码, 当前版本的记录中不保存代码
+func addCodeVersion(kvc *dapp.KVCreator, ver *jsproto.CodeVersion) {
+	kvc.AddNoPrefix(calcCodeKey(ver.Name), []byte(ver.Code))
+	kvc.AddNoPrefix(calcCodeHistoryKey(ver.Name, ver.Version), types.Encode(ver))
+	current := *ver
+	current.Code = ""
+	kvc.AddNoPrefix(calcCodeVersionKey(ver.Name), types.Encode(&current))
+}
+
+//codeCacheKey 代码缓存的key包含代码的hash, 升级之后不会再命中旧的缓存
+//升级功能之前创建的合约没有版本记录, 直接使用合约的名字
+func codeCacheKey(db db.KV, name string) string {
+	data, err := db.Get(calcCodeVersionKey(name))
+	if err != nil || data == nil {
+		return name
+	}
+	var ver jsproto.CodeVersion
+	if types.Decode(data, &ver) != nil {
+		return name
+	}
+	return name + "-" + ver.CodeHash
+}
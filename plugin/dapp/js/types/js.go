@@ -10,8 +10,9 @@ import (
 
 // action for executor
 const (
-	jsActionCreate = 0
-	jsActionCall   = 1
+	jsActionCreate  = 0
+	jsActionCall    = 1
+	jsActionUpgrade = 2
 )
 
 //日志类型
@@ -19,13 +20,34 @@ const (
 	TyLogJs = 10000
 )
 
+//分叉名称
+const (
+	//ForkJsStepLimit 分叉之后按照步数限制执行合约代码
+	ForkJsStepLimit = "ForkJsStepLimit"
+	//ForkJsUpgrade 分叉之后支持升级合约, 并保存合约代码的版本记录
+	ForkJsUpgrade = "ForkJsUpgrade"
+)
+
 // JsCreator 配置项 创建js合约的管理员
 const JsCreator = "js-creator"
 
+//执行步数限制, otto 每求值一个语句或者表达式节点计为一步
+const (
+	//JsStepPrice 每一步消耗的手续费, 交易手续费决定调用可以执行的步数
+	JsStepPrice = 1
+	//JsBaseStepLimit 每次调用不需要手续费的基础步数
+	JsBaseStepLimit = 100000
+	//JsMaxStepLimit 单次调用的最大步数, 查询也使用这个限制
+	JsMaxStepLimit = 10000000
+	//JsCodeStepLimit 加载合约代码时执行顶层代码的最大步数
+	JsCodeStepLimit = 100000
+)
+
 var (
 	typeMap = map[string]int32{
-		"Create": jsActionCreate,
-		"Call":   jsActionCall,
+		"Create":  jsActionCreate,
+		"Call":    jsActionCall,
+		"Upgrade": jsActionUpgrade,
 	}
 	logMap = map[int64]*types.LogInfo{
 		TyLogJs: {Ty: reflect.TypeOf(jsproto.JsLog{}), Name: "TyLogJs"},
@@ -52,6 +74,12 @@ var (
 	ErrDBType       = errors.New("chain33.js: ErrDBType")
 	// ErrJsCreator
 	ErrJsCreator = errors.New("ErrJsCreator")
+	//ErrNotContractOwner 只有合约创建者和 js-creator 管理员可以升级合约
+	ErrNotContractOwner = errors.New("chain33.js: ErrNotContractOwner")
+	//ErrCodeNotFound 合约或者指定版本的代码不存在
+	ErrCodeNotFound = errors.New("chain33.js: ErrCodeNotFound")
+	//ErrJsStepLimit 执行步数超过限制
+	ErrJsStepLimit = errors.New("chain33.js: ErrJsStepLimit")
 )
 
 func init() {
@@ -62,6 +90,10 @@ func init() {
 
 func InitFork(cfg *types.Chain33Config) {
 	cfg.RegisterDappFork(JsX, "Enable", 0)
+	//按照步数限制执行合约代码, 主网启用高度待定
+	cfg.RegisterDappFork(JsX, ForkJsStepLimit, types.MaxHeight)
+	//支持升级合约, 主网启用高度待定
+	cfg.RegisterDappFork(JsX, ForkJsUpgrade, types.MaxHeight)
 }
 
 func InitExecutor(cfg *types.Chain33Config) {
@@ -35,7 +35,7 @@ func (m *Create) Reset()         { *m = Create{} }
 func (m *Create) String() string { return proto.CompactTextString(m) }
 func (*Create) ProtoMessage()    {}
 func (*Create) Descriptor() ([]byte, []int) {
-	return fileDescriptor_js_afd63f08422ad153, []int{0}
+	return fileDescriptor_js_6f77c23df35040dd, []int{0}
 }
 func (m *Create) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Create.Unmarshal(m, b)
@@ -83,7 +83,7 @@ func (m *Call) Reset()         { *m = Call{} }
 func (m *Call) String() string { return proto.CompactTextString(m) }
 func (*Call) ProtoMessage()    {}
 func (*Call) Descriptor() ([]byte, []int) {
-	return fileDescriptor_js_afd63f08422ad153, []int{1}
+	return fileDescriptor_js_6f77c23df35040dd, []int{1}
 }
 func (m *Call) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Call.Unmarshal(m, b)
@@ -124,10 +124,66 @@ func (m *Call) GetArgs() string {
 	return ""
 }
 
+// upgrade action
+type Upgrade struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Code                 string   `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Args                 string   `protobuf:"bytes,3,opt,name=args,proto3" json:"args,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Upgrade) Reset()         { *m = Upgrade{} }
+func (m *Upgrade) String() string { return proto.CompactTextString(m) }
+func (*Upgrade) ProtoMessage()    {}
+func (*Upgrade) Descriptor() ([]byte, []int) {
+	return fileDescriptor_js_6f77c23df35040dd, []int{2}
+}
+func (m *Upgrade) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Upgrade.Unmarshal(m, b)
+}
+func (m *Upgrade) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Upgrade.Marshal(b, m, deterministic)
+}
+func (dst *Upgrade) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Upgrade.Merge(dst, src)
+}
+func (m *Upgrade) XXX_Size() int {
+	return xxx_messageInfo_Upgrade.Size(m)
+}
+func (m *Upgrade) XXX_DiscardUnknown() {
+	xxx_messageInfo_Upgrade.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Upgrade proto.InternalMessageInfo
+
+func (m *Upgrade) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Upgrade) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *Upgrade) GetArgs() string {
+	if m != nil {
+		return m.Args
+	}
+	return ""
+}
+
 type JsAction struct {
 	// Types that are valid to be assigned to Value:
 	//	*JsAction_Create
 	//	*JsAction_Call
+	//	*JsAction_Upgrade
 	Value                isJsAction_Value `protobuf_oneof:"value"`
 	Ty                   int32            `protobuf:"varint,3,opt,name=ty,proto3" json:"ty,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
@@ -139,7 +195,7 @@ func (m *JsAction) Reset()         { *m = JsAction{} }
 func (m *JsAction) String() string { return proto.CompactTextString(m) }
 func (*JsAction) ProtoMessage()    {}
 func (*JsAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_js_afd63f08422ad153, []int{2}
+	return fileDescriptor_js_6f77c23df35040dd, []int{3}
 }
 func (m *JsAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JsAction.Unmarshal(m, b)
@@ -171,10 +227,16 @@ type JsAction_Call struct {
 	Call *Call `protobuf:"bytes,2,opt,name=call,proto3,oneof"`
 }
 
+type JsAction_Upgrade struct {
+	Upgrade *Upgrade `protobuf:"bytes,4,opt,name=upgrade,proto3,oneof"`
+}
+
 func (*JsAction_Create) isJsAction_Value() {}
 
 func (*JsAction_Call) isJsAction_Value() {}
 
+func (*JsAction_Upgrade) isJsAction_Value() {}
+
 func (m *JsAction) GetValue() isJsAction_Value {
 	if m != nil {
 		return m.Value
@@ -196,6 +258,13 @@ func (m *JsAction) GetCall() *Call {
 	return nil
 }
 
+func (m *JsAction) GetUpgrade() *Upgrade {
+	if x, ok := m.GetValue().(*JsAction_Upgrade); ok {
+		return x.Upgrade
+	}
+	return nil
+}
+
 func (m *JsAction) GetTy() int32 {
 	if m != nil {
 		return m.Ty
@@ -208,6 +277,7 @@ func (*JsAction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) erro
 	return _JsAction_OneofMarshaler, _JsAction_OneofUnmarshaler, _JsAction_OneofSizer, []interface{}{
 		(*JsAction_Create)(nil),
 		(*JsAction_Call)(nil),
+		(*JsAction_Upgrade)(nil),
 	}
 }
 
@@ -225,6 +295,11 @@ func _JsAction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Call); err != nil {
 			return err
 		}
+	case *JsAction_Upgrade:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Upgrade); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("JsAction.Value has unexpected type %T", x)
@@ -251,6 +326,14 @@ func _JsAction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffe
 		err := b.DecodeMessage(msg)
 		m.Value = &JsAction_Call{msg}
 		return true, err
+	case 4: // value.upgrade
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Upgrade)
+		err := b.DecodeMessage(msg)
+		m.Value = &JsAction_Upgrade{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -270,6 +353,11 @@ func _JsAction_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *JsAction_Upgrade:
+		s := proto.Size(x.Upgrade)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -288,7 +376,7 @@ func (m *JsLog) Reset()         { *m = JsLog{} }
 func (m *JsLog) String() string { return proto.CompactTextString(m) }
 func (*JsLog) ProtoMessage()    {}
 func (*JsLog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_js_afd63f08422ad153, []int{3}
+	return fileDescriptor_js_6f77c23df35040dd, []int{4}
 }
 func (m *JsLog) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JsLog.Unmarshal(m, b)
@@ -326,7 +414,7 @@ func (m *QueryResult) Reset()         { *m = QueryResult{} }
 func (m *QueryResult) String() string { return proto.CompactTextString(m) }
 func (*QueryResult) ProtoMessage()    {}
 func (*QueryResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_js_afd63f08422ad153, []int{4}
+	return fileDescriptor_js_6f77c23df35040dd, []int{5}
 }
 func (m *QueryResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResult.Unmarshal(m, b)
@@ -353,31 +441,264 @@ func (m *QueryResult) GetData() string {
 	return ""
 }
 
+// 合约代码的一个版本
+type CodeVersion struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version              int32    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Height               int64    `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	TxHash               string   `protobuf:"bytes,4,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	From                 string   `protobuf:"bytes,5,opt,name=from,proto3" json:"from,omitempty"`
+	Creator              string   `protobuf:"bytes,6,opt,name=creator,proto3" json:"creator,omitempty"`
+	CodeHash             string   `protobuf:"bytes,7,opt,name=codeHash,proto3" json:"codeHash,omitempty"`
+	Code                 string   `protobuf:"bytes,8,opt,name=code,proto3" json:"code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CodeVersion) Reset()         { *m = CodeVersion{} }
+func (m *CodeVersion) String() string { return proto.CompactTextString(m) }
+func (*CodeVersion) ProtoMessage()    {}
+func (*CodeVersion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_js_6f77c23df35040dd, []int{6}
+}
+func (m *CodeVersion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CodeVersion.Unmarshal(m, b)
+}
+func (m *CodeVersion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CodeVersion.Marshal(b, m, deterministic)
+}
+func (dst *CodeVersion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CodeVersion.Merge(dst, src)
+}
+func (m *CodeVersion) XXX_Size() int {
+	return xxx_messageInfo_CodeVersion.Size(m)
+}
+func (m *CodeVersion) XXX_DiscardUnknown() {
+	xxx_messageInfo_CodeVersion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CodeVersion proto.InternalMessageInfo
+
+func (m *CodeVersion) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CodeVersion) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *CodeVersion) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *CodeVersion) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *CodeVersion) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *CodeVersion) GetCreator() string {
+	if m != nil {
+		return m.Creator
+	}
+	return ""
+}
+
+func (m *CodeVersion) GetCodeHash() string {
+	if m != nil {
+		return m.CodeHash
+	}
+	return ""
+}
+
+func (m *CodeVersion) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+type QueryVersionsReq struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryVersionsReq) Reset()         { *m = QueryVersionsReq{} }
+func (m *QueryVersionsReq) String() string { return proto.CompactTextString(m) }
+func (*QueryVersionsReq) ProtoMessage()    {}
+func (*QueryVersionsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_js_6f77c23df35040dd, []int{7}
+}
+func (m *QueryVersionsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryVersionsReq.Unmarshal(m, b)
+}
+func (m *QueryVersionsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryVersionsReq.Marshal(b, m, deterministic)
+}
+func (dst *QueryVersionsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryVersionsReq.Merge(dst, src)
+}
+func (m *QueryVersionsReq) XXX_Size() int {
+	return xxx_messageInfo_QueryVersionsReq.Size(m)
+}
+func (m *QueryVersionsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryVersionsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryVersionsReq proto.InternalMessageInfo
+
+func (m *QueryVersionsReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type CodeVersionList struct {
+	Versions             []*CodeVersion `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CodeVersionList) Reset()         { *m = CodeVersionList{} }
+func (m *CodeVersionList) String() string { return proto.CompactTextString(m) }
+func (*CodeVersionList) ProtoMessage()    {}
+func (*CodeVersionList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_js_6f77c23df35040dd, []int{8}
+}
+func (m *CodeVersionList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CodeVersionList.Unmarshal(m, b)
+}
+func (m *CodeVersionList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CodeVersionList.Marshal(b, m, deterministic)
+}
+func (dst *CodeVersionList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CodeVersionList.Merge(dst, src)
+}
+func (m *CodeVersionList) XXX_Size() int {
+	return xxx_messageInfo_CodeVersionList.Size(m)
+}
+func (m *CodeVersionList) XXX_DiscardUnknown() {
+	xxx_messageInfo_CodeVersionList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CodeVersionList proto.InternalMessageInfo
+
+func (m *CodeVersionList) GetVersions() []*CodeVersion {
+	if m != nil {
+		return m.Versions
+	}
+	return nil
+}
+
+type QueryCodeReq struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version              int32    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryCodeReq) Reset()         { *m = QueryCodeReq{} }
+func (m *QueryCodeReq) String() string { return proto.CompactTextString(m) }
+func (*QueryCodeReq) ProtoMessage()    {}
+func (*QueryCodeReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_js_6f77c23df35040dd, []int{9}
+}
+func (m *QueryCodeReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryCodeReq.Unmarshal(m, b)
+}
+func (m *QueryCodeReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryCodeReq.Marshal(b, m, deterministic)
+}
+func (dst *QueryCodeReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryCodeReq.Merge(dst, src)
+}
+func (m *QueryCodeReq) XXX_Size() int {
+	return xxx_messageInfo_QueryCodeReq.Size(m)
+}
+func (m *QueryCodeReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryCodeReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryCodeReq proto.InternalMessageInfo
+
+func (m *QueryCodeReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *QueryCodeReq) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Create)(nil), "jsproto.Create")
 	proto.RegisterType((*Call)(nil), "jsproto.Call")
+	proto.RegisterType((*Upgrade)(nil), "jsproto.Upgrade")
 	proto.RegisterType((*JsAction)(nil), "jsproto.JsAction")
 	proto.RegisterType((*JsLog)(nil), "jsproto.JsLog")
 	proto.RegisterType((*QueryResult)(nil), "jsproto.QueryResult")
-}
-
-func init() { proto.RegisterFile("js.proto", fileDescriptor_js_afd63f08422ad153) }
-
-var fileDescriptor_js_afd63f08422ad153 = []byte{
-	// 231 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x8f, 0xbf, 0x4e, 0xc3, 0x30,
-	0x10, 0xc6, 0x9b, 0x90, 0xa4, 0xe1, 0x22, 0x40, 0xf2, 0x14, 0xc1, 0x02, 0x66, 0x81, 0x25, 0x42,
-	0xe5, 0x09, 0xa0, 0x4b, 0x15, 0xb1, 0xe0, 0x37, 0x38, 0x5c, 0x53, 0x51, 0x1d, 0x31, 0xf2, 0x1f,
-	0xa4, 0xbc, 0x3d, 0xf2, 0xa5, 0x34, 0x0c, 0x6c, 0x3f, 0xdf, 0xfd, 0xec, 0xef, 0x33, 0xd4, 0x7b,
-	0xdf, 0x7d, 0x39, 0x1b, 0xac, 0x58, 0xee, 0x3d, 0x83, 0x7c, 0x80, 0x6a, 0xed, 0x0c, 0x06, 0x23,
-	0x04, 0x14, 0xda, 0x6e, 0x4d, 0x9b, 0x5d, 0x67, 0x77, 0xa7, 0x8a, 0x39, 0xcd, 0x06, 0xfc, 0x34,
-	0x6d, 0x3e, 0xcd, 0x12, 0xcb, 0x1e, 0x8a, 0x35, 0x12, 0x1d, 0x77, 0xd9, 0xbc, 0x13, 0x97, 0x50,
-	0xbf, 0xc7, 0x41, 0xff, 0xb9, 0x73, 0x3c, 0x27, 0x1f, 0xdd, 0xce, 0xb7, 0x27, 0x93, 0x9f, 0x58,
-	0x7a, 0xa8, 0x7b, 0xff, 0xa4, 0xc3, 0x87, 0x1d, 0xc4, 0x3d, 0x54, 0x9a, 0x9b, 0xf0, 0x8b, 0xcd,
-	0xea, 0xa2, 0x3b, 0x74, 0xec, 0xa6, 0x82, 0x9b, 0x85, 0x3a, 0x08, 0xe2, 0x16, 0x0a, 0x8d, 0x44,
-	0x1c, 0xd1, 0xac, 0xce, 0x66, 0x11, 0x89, 0x36, 0x0b, 0xc5, 0x4b, 0x71, 0x0e, 0x79, 0x18, 0x39,
-	0xad, 0x54, 0x79, 0x18, 0x9f, 0x97, 0x50, 0x7e, 0x23, 0x45, 0x23, 0xaf, 0xa0, 0xec, 0xfd, 0x8b,
-	0xdd, 0xa5, 0x46, 0x5b, 0x0c, 0xf8, 0xfb, 0x83, 0xc4, 0xf2, 0x06, 0x9a, 0xd7, 0x68, 0xdc, 0xa8,
-	0x8c, 0x8f, 0x14, 0xfe, 0x53, 0xde, 0x2a, 0x0e, 0x7b, 0xfc, 0x09, 0x00, 0x00, 0xff, 0xff, 0xdf,
-	0xa4, 0x90, 0x55, 0x4e, 0x01, 0x00, 0x00,
+	proto.RegisterType((*CodeVersion)(nil), "jsproto.CodeVersion")
+	proto.RegisterType((*QueryVersionsReq)(nil), "jsproto.QueryVersionsReq")
+	proto.RegisterType((*CodeVersionList)(nil), "jsproto.CodeVersionList")
+	proto.RegisterType((*QueryCodeReq)(nil), "jsproto.QueryCodeReq")
+}
+
+func init() { proto.RegisterFile("js.proto", fileDescriptor_js_6f77c23df35040dd) }
+
+var fileDescriptor_js_6f77c23df35040dd = []byte{
+	// 406 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0x5d, 0x8b, 0xd4, 0x30,
+	0x14, 0x86, 0xa7, 0x9d, 0x7e, 0xed, 0xa9, 0xba, 0x4b, 0x10, 0x09, 0x7a, 0xb3, 0x46, 0x90, 0x15,
+	0x64, 0x58, 0xc6, 0x5b, 0x6f, 0xb4, 0x08, 0x65, 0xd8, 0x1b, 0x03, 0x7a, 0x1f, 0xdb, 0x6c, 0x67,
+	0x96, 0xec, 0x64, 0x4d, 0xd2, 0xc5, 0xf9, 0x39, 0xfe, 0x1c, 0xff, 0x95, 0xe4, 0xf4, 0x6b, 0xc4,
+	0xce, 0xdd, 0xf9, 0x78, 0xfb, 0xf6, 0x3c, 0x79, 0x21, 0xbb, 0xb3, 0xab, 0x07, 0xa3, 0x9d, 0x26,
+	0xe9, 0x9d, 0xc5, 0x82, 0x5d, 0x43, 0x52, 0x18, 0x29, 0x9c, 0x24, 0x04, 0xa2, 0x4a, 0xd7, 0x92,
+	0x06, 0x97, 0xc1, 0xd5, 0x19, 0xc7, 0xda, 0xcf, 0xf6, 0xe2, 0x5e, 0xd2, 0xb0, 0x9b, 0xf9, 0x9a,
+	0x6d, 0x20, 0x2a, 0x84, 0x52, 0xe3, 0x2e, 0x98, 0x76, 0xe4, 0x25, 0x64, 0xb7, 0xed, 0xbe, 0x3a,
+	0xfa, 0x66, 0xec, 0xbd, 0x5e, 0x98, 0xc6, 0xd2, 0x65, 0xa7, 0xf7, 0x35, 0xfb, 0x02, 0xe9, 0xb7,
+	0x87, 0xc6, 0x88, 0x5a, 0xce, 0xda, 0x0d, 0x27, 0x85, 0xff, 0x9e, 0xf4, 0x9f, 0xcd, 0xef, 0x00,
+	0xb2, 0x8d, 0xfd, 0x54, 0xb9, 0x9d, 0xde, 0x93, 0x77, 0x90, 0x54, 0x48, 0x84, 0x56, 0xf9, 0xfa,
+	0x7c, 0xd5, 0xb3, 0xae, 0x3a, 0xd0, 0x72, 0xc1, 0x7b, 0x01, 0x79, 0x03, 0x51, 0x25, 0x94, 0x42,
+	0xff, 0x7c, 0xfd, 0x74, 0x12, 0x0a, 0xa5, 0xca, 0x05, 0xc7, 0x25, 0x79, 0x0f, 0x69, 0xdb, 0xdd,
+	0x48, 0x23, 0xd4, 0x5d, 0x8c, 0xba, 0xfe, 0xf6, 0x72, 0xc1, 0x07, 0x09, 0x79, 0x06, 0xa1, 0x3b,
+	0xe0, 0x71, 0x31, 0x0f, 0xdd, 0xe1, 0x73, 0x0a, 0xf1, 0xa3, 0x50, 0xad, 0x64, 0xaf, 0x20, 0xde,
+	0xd8, 0x1b, 0xdd, 0x78, 0x80, 0x5a, 0x38, 0x31, 0x80, 0xfa, 0x9a, 0xbd, 0x86, 0xfc, 0x6b, 0x2b,
+	0xcd, 0x81, 0x4b, 0xdb, 0x2a, 0x37, 0x2b, 0xf9, 0x13, 0x40, 0x5e, 0xe8, 0x5a, 0x7e, 0x97, 0xc6,
+	0x7a, 0xcc, 0xb9, 0xf7, 0xa2, 0x90, 0x3e, 0x76, 0x6b, 0x44, 0x8a, 0xf9, 0xd0, 0x92, 0x17, 0x90,
+	0x6c, 0xe5, 0xae, 0xd9, 0x3a, 0x3c, 0x6d, 0xc9, 0xfb, 0xce, 0xcf, 0xdd, 0xaf, 0x52, 0xd8, 0x2d,
+	0xb2, 0x9d, 0xf1, 0xbe, 0xf3, 0xee, 0xb7, 0x46, 0xdf, 0xd3, 0xb8, 0x73, 0xf7, 0xb5, 0x77, 0xc7,
+	0x77, 0xd3, 0x86, 0x26, 0x38, 0x1e, 0x5a, 0x1f, 0xbb, 0xcf, 0x06, 0x7d, 0xd2, 0x2e, 0xf6, 0xa1,
+	0x1f, 0x33, 0xcc, 0xa6, 0x0c, 0xd9, 0x5b, 0xb8, 0x40, 0xdc, 0x9e, 0xc5, 0x72, 0xf9, 0x73, 0x8e,
+	0x87, 0x15, 0x70, 0x7e, 0x84, 0x7c, 0xb3, 0xb3, 0x8e, 0x5c, 0x43, 0xd6, 0x33, 0x59, 0x1a, 0x5c,
+	0x2e, 0xaf, 0xf2, 0xf5, 0xf3, 0x29, 0xb6, 0x49, 0xcb, 0x47, 0x15, 0xfb, 0x08, 0x4f, 0xf0, 0x67,
+	0x7e, 0x7b, 0xe2, 0x47, 0xa7, 0x1f, 0xee, 0x47, 0x82, 0xd6, 0x1f, 0xfe, 0x0e, 0x00, 0x43, 0xea,
+	0x06, 0xb6, 0x3b, 0x03, 0x00, 0x00,
 }
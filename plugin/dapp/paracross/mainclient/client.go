@@ -0,0 +1,418 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package mainclient 平行链访问主链的grpc客户端，支持配置多个主链节点，
+// 定期检查各节点的健康状态，在健康的节点间负载均衡，节点出错或者高度停滞时切换到其他节点
+package mainclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/chain33/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultGrpcAddr            = "127.0.0.1:8802"
+	defaultGrpcPort            = "8802"
+	defaultHealthCheckInterval = 5  //second
+	defaultStallTimeout        = 60 //second
+	defaultMaxLagBlocks        = 10
+	grpcRecSize                = 100 * 1024 * 1024
+	callTimeout                = 5 * time.Second
+)
+
+var (
+	mlog = log.New("module", "para.mainclient")
+
+	mu            sync.Mutex
+	defaultClient *Client
+
+	// ErrNoHealthyEndpoint 没有可用的主链节点
+	ErrNoHealthyEndpoint = errors.New("ErrNoHealthyEndpoint")
+)
+
+//按高度和哈希查询的接口在各主链节点上结果一致，可以在健康的节点间负载均衡，
+//seq是各主链节点本地的编号，按seq查询和发送交易等接口只访问当前的主节点
+var balancedMethods = map[string]bool{
+	"/types.chain33/GetBlockHash":           true,
+	"/types.chain33/GetHeaders":             true,
+	"/types.chain33/GetBlocks":              true,
+	"/types.chain33/QueryTransaction":       true,
+	"/types.chain33/GetTransactionByHashes": true,
+	"/types.chain33/GetParaTxByHeight":      true,
+	"/types.chain33/LoadParaTxByTitle":      true,
+	"/types.chain33/GetProperFee":           true,
+	"/types.chain33/QueryChain":             true,
+}
+
+// Config 主链客户端配置
+type Config struct {
+	Addrs []string
+	//健康检查的间隔
+	HealthCheckInterval time.Duration
+	//落后最高节点超过该区块数的节点不可用
+	MaxLagBlocks int64
+	//其他节点高度增长时，高度停滞超过该时间的节点不可用
+	StallTimeout time.Duration
+}
+
+type endpoint struct {
+	index   int
+	addr    string
+	conn    *grpc.ClientConn
+	healthy bool
+	height  int64
+	hash    []byte
+	//最近一次高度变化的时间
+	lastChange time.Time
+}
+
+type endpointKey struct{}
+
+// Client 实现了types.Chain33Client，每次调用由拦截器选择主链节点
+type Client struct {
+	types.Chain33Client
+	cfg       Config
+	mtx       sync.Mutex
+	endpoints []*endpoint
+	primary   int
+	next      int
+	quit      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMainChainClient 根据consensus.sub.para的配置创建主链客户端，同一进程中的共识和mempool共用一个客户端
+func NewMainChainClient(cfg *types.Chain33Config) (*Client, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if defaultClient != nil {
+		return defaultClient, nil
+	}
+
+	conf := types.Conf(cfg, "config.consensus.sub.para")
+	client, err := New(&Config{
+		Addrs:               strings.Split(conf.GStr("ParaRemoteGrpcClient"), ","),
+		HealthCheckInterval: time.Duration(conf.GInt("mainHealthCheckInterval")) * time.Second,
+		MaxLagBlocks:        conf.GInt("mainMaxLagBlocks"),
+		StallTimeout:        time.Duration(conf.GInt("mainStallTimeout")) * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defaultClient = client
+	return client, nil
+}
+
+// New 创建主链客户端，建立连接不阻塞，节点不可用时在调用时切换
+func New(cfg *Config) (*Client, error) {
+	client := &Client{cfg: *cfg, quit: make(chan struct{})}
+	if client.cfg.HealthCheckInterval <= 0 {
+		client.cfg.HealthCheckInterval = defaultHealthCheckInterval * time.Second
+	}
+	if client.cfg.MaxLagBlocks <= 0 {
+		client.cfg.MaxLagBlocks = defaultMaxLagBlocks
+	}
+	if client.cfg.StallTimeout <= 0 {
+		client.cfg.StallTimeout = defaultStallTimeout * time.Second
+	}
+
+	var addrs []string
+	for _, addr := range cfg.Addrs {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			addr = net.JoinHostPort(addr, defaultGrpcPort)
+		}
+		addrs = append(addrs, addr)
+	}
+	if len(addrs) == 0 {
+		addrs = append(addrs, defaultGrpcAddr)
+	}
+
+	kp := keepalive.ClientParameters{
+		Time:                time.Second * 5,
+		Timeout:             time.Second * 20,
+		PermitWithoutStream: true,
+	}
+	for i, addr := range addrs {
+		opts := []grpc.DialOption{grpc.WithInsecure(),
+			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcRecSize)),
+			grpc.WithKeepaliveParams(kp)}
+		//第一个节点的连接同时作为对外的连接，所有调用经过拦截器分发到选中的节点
+		if i == 0 {
+			opts = append(opts, grpc.WithUnaryInterceptor(client.intercept))
+		}
+		conn, err := grpc.Dial(addr, opts...)
+		if err != nil {
+			client.closeConns()
+			return nil, err
+		}
+		client.endpoints = append(client.endpoints, &endpoint{index: i, addr: addr, conn: conn, healthy: true, lastChange: time.Now()})
+	}
+	client.Chain33Client = types.NewChain33Client(client.endpoints[0].conn)
+
+	go client.healthCheckLoop()
+	return client, nil
+}
+
+// Close 关闭所有连接
+func (c *Client) Close() {
+	c.closeOnce.Do(func() {
+		close(c.quit)
+		c.closeConns()
+	})
+}
+
+func (c *Client) closeConns() {
+	for _, ep := range c.endpoints {
+		ep.conn.Close()
+	}
+}
+
+// Primary 当前主节点的地址
+func (c *Client) Primary() string {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.endpoints[c.primary].addr
+}
+
+func (c *Client) intercept(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	//指定了节点的调用，如健康检查和区块哈希交叉校验
+	if ep, ok := ctx.Value(endpointKey{}).(*endpoint); ok {
+		return c.invokeOn(ctx, ep, method, req, reply, invoker, opts...)
+	}
+
+	var err error
+	tried := make(map[int]bool)
+	for {
+		ep := c.pick(balancedMethods[method], tried)
+		if ep == nil {
+			if err == nil {
+				err = ErrNoHealthyEndpoint
+			}
+			return err
+		}
+		tried[ep.index] = true
+		err = c.invokeOn(ctx, ep, method, req, reply, invoker, opts...)
+		if err == nil || !isTransportError(err) || ctx.Err() != nil {
+			return err
+		}
+		mlog.Error("main chain endpoint failed, try another", "addr", ep.addr, "method", method, "err", err)
+		c.setUnhealthy(ep)
+	}
+}
+
+func (c *Client) invokeOn(ctx context.Context, ep *endpoint, method string, req, reply interface{}, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if ep.index == 0 {
+		return invoker(ctx, method, req, reply, ep.conn, opts...)
+	}
+	return ep.conn.Invoke(ctx, method, req, reply, opts...)
+}
+
+//pick 负载均衡的接口在健康节点间轮询，其他接口访问主节点，已经失败过的节点不再选择
+func (c *Client) pick(balanced bool, tried map[int]bool) *endpoint {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if !balanced {
+		if !tried[c.primary] && c.endpoints[c.primary].healthy {
+			return c.endpoints[c.primary]
+		}
+		if ep := c.switchPrimary(tried); ep != nil {
+			return ep
+		}
+	} else {
+		for i := 0; i < len(c.endpoints); i++ {
+			ep := c.endpoints[(c.next+i)%len(c.endpoints)]
+			if ep.healthy && !tried[ep.index] {
+				c.next = (ep.index + 1) % len(c.endpoints)
+				return ep
+			}
+		}
+	}
+
+	//所有节点都不健康时，依次尝试未失败过的节点
+	for _, ep := range c.endpoints {
+		if !tried[ep.index] {
+			return ep
+		}
+	}
+	return nil
+}
+
+//switchPrimary 选择高度最高的健康节点作为主节点，调用时需持有锁
+func (c *Client) switchPrimary(exclude map[int]bool) *endpoint {
+	var best *endpoint
+	//高度相同时保持当前的主节点
+	if cur := c.endpoints[c.primary]; cur.healthy && !exclude[cur.index] {
+		best = cur
+	}
+	for _, ep := range c.endpoints {
+		if !ep.healthy || exclude[ep.index] {
+			continue
+		}
+		if best == nil || ep.height > best.height {
+			best = ep
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	if best.index != c.primary {
+		mlog.Info("switch main chain primary endpoint", "from", c.endpoints[c.primary].addr, "to", best.addr, "height", best.height)
+		c.primary = best.index
+	}
+	return best
+}
+
+func (c *Client) setUnhealthy(ep *endpoint) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	ep.healthy = false
+	if ep.index == c.primary {
+		c.switchPrimary(nil)
+	}
+}
+
+func isTransportError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+func (c *Client) healthCheckLoop() {
+	c.checkHealth()
+	ticker := time.NewTicker(c.cfg.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.quit:
+			return
+		case <-ticker.C:
+			c.checkHealth()
+		}
+	}
+}
+
+//checkHealth 查询各节点的最新高度，访问失败、落后过多或者高度停滞的节点标记为不可用
+func (c *Client) checkHealth() {
+	type result struct {
+		header *types.Header
+		err    error
+	}
+	results := make([]result, len(c.endpoints))
+	var wg sync.WaitGroup
+	for i, ep := range c.endpoints {
+		wg.Add(1)
+		go func(i int, ep *endpoint) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), endpointKey{}, ep), callTimeout)
+			defer cancel()
+			results[i].header, results[i].err = c.GetLastHeader(ctx, &types.ReqNil{})
+		}(i, ep)
+	}
+	wg.Wait()
+
+	now := time.Now()
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	maxHeight := int64(-1)
+	for i, ep := range c.endpoints {
+		if results[i].err != nil {
+			continue
+		}
+		if results[i].header.Height != ep.height {
+			ep.lastChange = now
+		}
+		ep.height = results[i].header.Height
+		ep.hash = results[i].header.Hash
+		if ep.height > maxHeight {
+			maxHeight = ep.height
+		}
+	}
+
+	for i, ep := range c.endpoints {
+		healthy := results[i].err == nil && maxHeight-ep.height <= c.cfg.MaxLagBlocks &&
+			(ep.height == maxHeight || now.Sub(ep.lastChange) <= c.cfg.StallTimeout)
+		if healthy != ep.healthy {
+			mlog.Info("main chain endpoint health changed", "addr", ep.addr, "healthy", healthy, "height", ep.height, "maxHeight", maxHeight, "err", results[i].err)
+		}
+		ep.healthy = healthy
+	}
+	if !c.endpoints[c.primary].healthy {
+		c.switchPrimary(nil)
+	}
+}
+
+// CheckBlockHash 向其他健康的主链节点查询该高度的区块哈希，与主节点返回的区块交叉校验，
+// 多数节点认可才接受该区块，多数节点不认可时切换主节点，持平时等待各节点一致
+func (c *Client) CheckBlockHash(height int64, hash []byte) error {
+	c.mtx.Lock()
+	primary := c.primary
+	var others []*endpoint
+	for _, ep := range c.endpoints {
+		if ep.index != primary && ep.healthy {
+			others = append(others, ep)
+		}
+	}
+	c.mtx.Unlock()
+	if len(others) == 0 {
+		return nil
+	}
+
+	hashes := make([][]byte, len(others))
+	var wg sync.WaitGroup
+	for i, ep := range others {
+		wg.Add(1)
+		go func(i int, ep *endpoint) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), endpointKey{}, ep), callTimeout)
+			defer cancel()
+			reply, err := c.GetBlockHash(ctx, &types.ReqInt{Height: height})
+			if err == nil {
+				hashes[i] = reply.Hash
+			}
+		}(i, ep)
+	}
+	wg.Wait()
+
+	agree, disagree := 1, 0
+	for i, h := range hashes {
+		//还没有同步到该高度的节点不参与校验
+		if len(h) == 0 {
+			continue
+		}
+		if bytes.Equal(h, hash) {
+			agree++
+		} else {
+			disagree++
+			mlog.Error("CheckBlockHash main chain block hash not match", "height", height, "addr", others[i].addr)
+		}
+	}
+	if agree > disagree {
+		return nil
+	}
+	if disagree > agree {
+		c.mtx.Lock()
+		if c.primary == primary {
+			c.switchPrimary(map[int]bool{primary: true})
+		}
+		c.mtx.Unlock()
+	}
+	return types.ErrBlockHashNoMatch
+}
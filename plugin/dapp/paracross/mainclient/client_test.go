@@ -0,0 +1,135 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mainclient
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type mockMainNode struct {
+	types.Chain33Server
+	height int64
+	hash   atomic.Value
+	seq    int64
+	calls  int32
+	addr   string
+	srv    *grpc.Server
+}
+
+func (m *mockMainNode) GetLastHeader(ctx context.Context, in *types.ReqNil) (*types.Header, error) {
+	return &types.Header{Height: atomic.LoadInt64(&m.height), Hash: m.hash.Load().([]byte)}, nil
+}
+
+func (m *mockMainNode) GetBlockHash(ctx context.Context, in *types.ReqInt) (*types.ReplyHash, error) {
+	atomic.AddInt32(&m.calls, 1)
+	if in.Height > atomic.LoadInt64(&m.height) {
+		return nil, types.ErrBlockNotFound
+	}
+	return &types.ReplyHash{Hash: m.hash.Load().([]byte)}, nil
+}
+
+func (m *mockMainNode) GetLastBlockSequence(ctx context.Context, in *types.ReqNil) (*types.Int64, error) {
+	return &types.Int64{Data: m.seq}, nil
+}
+
+func startMainNode(t *testing.T, height, seq int64, hash string) *mockMainNode {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	node := &mockMainNode{height: height, seq: seq, addr: lis.Addr().String(), srv: grpc.NewServer()}
+	node.hash.Store([]byte(hash))
+	types.RegisterChain33Server(node.srv, node)
+	go node.srv.Serve(lis)
+	return node
+}
+
+func newTestClient(t *testing.T, nodes ...*mockMainNode) *Client {
+	var addrs []string
+	for _, node := range nodes {
+		addrs = append(addrs, node.addr)
+	}
+	client, err := New(&Config{Addrs: addrs, HealthCheckInterval: time.Hour, MaxLagBlocks: 5, StallTimeout: time.Hour})
+	assert.Nil(t, err)
+	client.checkHealth()
+	return client
+}
+
+func TestFailover(t *testing.T) {
+	node0 := startMainNode(t, 100, 1000, "hash")
+	node1 := startMainNode(t, 100, 2000, "hash")
+	node2 := startMainNode(t, 100, 3000, "hash")
+	defer node1.srv.Stop()
+	defer node2.srv.Stop()
+	client := newTestClient(t, node0, node1, node2)
+	defer client.Close()
+
+	//按seq查询的接口只访问主节点
+	for i := 0; i < 3; i++ {
+		seq, err := client.GetLastBlockSequence(context.Background(), &types.ReqNil{})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1000), seq.Data)
+	}
+
+	//按高度查询的接口在节点间轮询
+	for i := 0; i < 6; i++ {
+		_, err := client.GetBlockHash(context.Background(), &types.ReqInt{Height: 10})
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&node0.calls))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&node1.calls))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&node2.calls))
+
+	//业务错误不切换节点
+	_, err := client.GetBlockHash(context.Background(), &types.ReqInt{Height: 200})
+	assert.NotNil(t, err)
+	assert.Equal(t, node0.addr, client.Primary())
+
+	//主节点宕机后切换到其他节点
+	node0.srv.Stop()
+	seq, err := client.GetLastBlockSequence(context.Background(), &types.ReqNil{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2000), seq.Data)
+	assert.Equal(t, node1.addr, client.Primary())
+
+	//落后过多的节点不可用
+	atomic.StoreInt64(&node2.height, 110)
+	client.checkHealth()
+	assert.Equal(t, node2.addr, client.Primary())
+	seq, err = client.GetLastBlockSequence(context.Background(), &types.ReqNil{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3000), seq.Data)
+}
+
+func TestCheckBlockHash(t *testing.T) {
+	node0 := startMainNode(t, 100, 1000, "hash")
+	node1 := startMainNode(t, 100, 2000, "hash")
+	node2 := startMainNode(t, 97, 3000, "fork")
+	defer node0.srv.Stop()
+	defer node1.srv.Stop()
+	defer node2.srv.Stop()
+	client := newTestClient(t, node0, node1, node2)
+	defer client.Close()
+
+	//多数节点认可即可接受
+	assert.Nil(t, client.CheckBlockHash(95, []byte("hash")))
+	//还没有同步到该高度的节点不参与校验
+	assert.Nil(t, client.CheckBlockHash(100, []byte("hash")))
+
+	//持平时不接受，也不切换主节点
+	node1.hash.Store([]byte("other"))
+	assert.Equal(t, types.ErrBlockHashNoMatch, client.CheckBlockHash(100, []byte("hash")))
+	assert.Equal(t, node0.addr, client.Primary())
+
+	//多数节点不认可时切换主节点
+	assert.Equal(t, types.ErrBlockHashNoMatch, client.CheckBlockHash(95, []byte("hash")))
+	assert.Equal(t, node1.addr, client.Primary())
+}
@@ -43,6 +43,9 @@ func TradeCmd() *cobra.Command {
 		ShowTokenBuyOrdersStatusCmd(),
 
 		ShowOnesOrdersStatusCmd(),
+
+		ShowKlineCmd(),
+		ShowTickerCmd(),
 	)
 
 	return cmd
@@ -706,3 +709,106 @@ func buyRevoke(cmd *cobra.Command, args []string) {
 	ctx := jsonrpc.NewRPCCtx(rpcLaddr, "trade.CreateRawTradeRevokeBuyTx", params, nil)
 	ctx.RunWithoutMarshal()
 }
+
+// ShowKlineCmd : show kline of a trading pair
+func ShowKlineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kline",
+		Short: "Show kline of a token trading pair",
+		Run:   showKline,
+	}
+	addShowKlineFlags(cmd)
+	return cmd
+}
+
+func addTradePairFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("token", "t", "", "token name")
+	cmd.MarkFlagRequired("token")
+	cmd.Flags().StringP("asset_exec", "", "token", "asset executor name")
+	cmd.Flags().StringP("price_exec", "", "coins", "price executor name")
+	cmd.Flags().StringP("price_symbol", "", "bty", "price symbol")
+}
+
+func addShowKlineFlags(cmd *cobra.Command) {
+	addTradePairFlags(cmd)
+	cmd.Flags().StringP("period", "p", pty.KlinePeriod1m, "kline period (1m, 5m, 1h or 1d)")
+	cmd.Flags().Int64P("start", "s", 0, "start time of the klines (not required)")
+	cmd.Flags().Int64P("end", "e", 0, "end time of the klines (not required)")
+	cmd.Flags().Int32P("count", "c", 10, "kline count")
+	cmd.Flags().Int32P("direction", "d", 0, "direction must be 0 (previous-page) or 1(next-page)")
+	cmd.Flags().StringP("from", "f", "", "start from kline key (not required)")
+}
+
+func showKline(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	token, _ := cmd.Flags().GetString("token")
+	assetExec, _ := cmd.Flags().GetString("asset_exec")
+	priceExec, _ := cmd.Flags().GetString("price_exec")
+	priceSymbol, _ := cmd.Flags().GetString("price_symbol")
+	period, _ := cmd.Flags().GetString("period")
+	start, _ := cmd.Flags().GetInt64("start")
+	end, _ := cmd.Flags().GetInt64("end")
+	count, _ := cmd.Flags().GetInt32("count")
+	dir, _ := cmd.Flags().GetInt32("direction")
+	from, _ := cmd.Flags().GetString("from")
+
+	req := pty.ReqTradeKline{
+		AssetExec:   assetExec,
+		TokenSymbol: token,
+		PriceExec:   priceExec,
+		PriceSymbol: priceSymbol,
+		Period:      period,
+		StartTime:   start,
+		EndTime:     end,
+		FromKey:     from,
+		Count:       count,
+		Direction:   dir,
+	}
+	var params rpctypes.Query4Jrpc
+	params.Execer = "trade"
+	params.FuncName = "GetKline"
+	params.Payload = types.MustPBToJSON(&req)
+	var res pty.ReplyTradeKlines
+	ctx := jsonrpc.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &res)
+	ctx.Run()
+}
+
+// ShowTickerCmd : show 24h ticker of a trading pair
+func ShowTickerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ticker",
+		Short: "Show 24h ticker of a token trading pair",
+		Run:   showTicker,
+	}
+	addShowTickerFlags(cmd)
+	return cmd
+}
+
+func addShowTickerFlags(cmd *cobra.Command) {
+	addTradePairFlags(cmd)
+	cmd.Flags().Int64P("time", "", 0, "end time of the ticker, default the last block time (not required)")
+}
+
+func showTicker(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	token, _ := cmd.Flags().GetString("token")
+	assetExec, _ := cmd.Flags().GetString("asset_exec")
+	priceExec, _ := cmd.Flags().GetString("price_exec")
+	priceSymbol, _ := cmd.Flags().GetString("price_symbol")
+	endTime, _ := cmd.Flags().GetInt64("time")
+
+	req := pty.ReqTradeTicker{
+		AssetExec:   assetExec,
+		TokenSymbol: token,
+		PriceExec:   priceExec,
+		PriceSymbol: priceSymbol,
+		Time:        endTime,
+	}
+	var params rpctypes.Query4Jrpc
+	params.Execer = "trade"
+	params.FuncName = "GetTicker"
+	params.Payload = types.MustPBToJSON(&req)
+	var res pty.ReplyTradeTicker
+	ctx := jsonrpc.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &res)
+	ctx.Run()
+}
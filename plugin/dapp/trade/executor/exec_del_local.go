@@ -97,6 +97,12 @@ func (t *trade) localDelLog(tx *types.Transaction, receipt *types.ReceiptData, i
 		return nil, err
 	}
 	set.KV = append(set.KV, newKvs...)
+	klineKvs, err := t.deleteKline(tx, receipt)
+	if err != nil {
+		tradelog.Error("trade deleteKline failed", "error", err)
+		return nil, err
+	}
+	set.KV = append(set.KV, klineKvs...)
 	for _, kv := range set.KV {
 		t.GetLocalDB().Set(kv.Key, kv.Value)
 	}
@@ -101,6 +101,12 @@ func (t *trade) localAddLog(tx *types.Transaction, receipt *types.ReceiptData, i
 	}
 
 	set.KV = append(set.KV, newKvs...)
+	klineKvs, err := t.saveKline(tx, receipt)
+	if err != nil {
+		tradelog.Error("trade saveKline failed", "error", err)
+		return nil, err
+	}
+	set.KV = append(set.KV, klineKvs...)
 	for _, kv := range set.KV {
 		t.GetLocalDB().Set(kv.Key, kv.Value)
 	}
@@ -0,0 +1,372 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/common/db/table"
+	"github.com/33cn/chain33/types"
+	pty "github.com/33cn/plugin/plugin/dapp/trade/types"
+)
+
+/*
+K线及24小时行情
+  1. 买单/卖单成交时（BuyMarket/SellMarket）, 按区块时间将成交累加到 1m, 5m, 1h, 1d 各周期的K线中
+  2. 成交价格为每 1e8 个资产单位对应的计价资产数量, 成交量以资产计, 成交额以计价资产计
+  3. K线是按时间累加的, 回滚时不能简单的减去成交, 所以在 ExecLocal 时记录修改前的K线, 由 ExecDelLocal 恢复
+  4. 24小时行情由 1m K线统计得到
+*/
+
+// klinePageCount : count of klines read from localdb at one time
+const klinePageCount = int32(100)
+
+var opt_kline_table = &table.Option{
+	Prefix:  "LODB-trade",
+	Name:    "kline",
+	Primary: "time",
+	Index:   nil,
+}
+
+// KlineRow kline row
+type KlineRow struct {
+	*pty.TradeKline
+}
+
+// NewKlineRow create row
+func NewKlineRow() *KlineRow {
+	return &KlineRow{TradeKline: nil}
+}
+
+// CreateRow create row
+func (r *KlineRow) CreateRow() *table.Row {
+	return &table.Row{Data: &pty.TradeKline{}}
+}
+
+// SetPayload set payload
+func (r *KlineRow) SetPayload(data types.Message) error {
+	if d, ok := data.(*pty.TradeKline); ok {
+		r.TradeKline = d
+		return nil
+	}
+	return types.ErrTypeAsset
+}
+
+// Get get index key
+func (r *KlineRow) Get(key string) ([]byte, error) {
+	switch key {
+	case "time":
+		return []byte(klinePrimaryKey(r.AssetExec, r.TokenSymbol, r.PriceExec, r.PriceSymbol, r.Period, r.StartTime)), nil
+	default:
+		return nil, types.ErrNotFound
+	}
+}
+
+// NewKlineTable create kline table
+func NewKlineTable(kvdb dbm.KV) *table.Table {
+	rowMeta := NewKlineRow()
+	rowMeta.SetPayload(&pty.TradeKline{})
+	t, err := table.NewTable(rowMeta, kvdb, opt_kline_table)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func klinePrefix(assetExec, assetSymbol, priceExec, priceSymbol, period string) string {
+	return fmt.Sprintf("%s.%s:%s.%s:%s:", assetExec, assetSymbol, priceExec, priceSymbol, period)
+}
+
+func klinePrimaryKey(assetExec, assetSymbol, priceExec, priceSymbol, period string, startTime int64) string {
+	return fmt.Sprintf("%s%016d", klinePrefix(assetExec, assetSymbol, priceExec, priceSymbol, period), startTime)
+}
+
+// 老的订单中资产和计价资产的执行器可以为空
+func (t *trade) fmtKlinePair(assetExec, priceExec, priceSymbol string) (string, string, string) {
+	if assetExec == "" {
+		assetExec = defaultAssetExec
+	}
+	if priceExec == "" {
+		priceExec = defaultPriceExec
+		priceSymbol = t.GetAPI().GetConfig().GetCoinSymbol()
+	}
+	return assetExec, priceExec, priceSymbol
+}
+
+// tradeFill : a fill of BuyMarket/SellMarket
+type tradeFill struct {
+	assetExec   string
+	assetSymbol string
+	priceExec   string
+	priceSymbol string
+	price       int64
+	volume      int64
+	turnover    int64
+}
+
+func (t *trade) newTradeFill(assetExec, assetSymbol, priceExec, priceSymbol, amountPerBoardlot, pricePerBoardlot string, boardlotCnt int64) *tradeFill {
+	amount := parseOrderAmountFloat(amountPerBoardlot)
+	price := parseOrderPriceFloat(pricePerBoardlot)
+	if amount <= 0 || boardlotCnt <= 0 {
+		return nil
+	}
+	fill := &tradeFill{
+		assetSymbol: assetSymbol,
+		volume:      amount * boardlotCnt,
+		turnover:    price * boardlotCnt,
+	}
+	fill.assetExec, fill.priceExec, fill.priceSymbol = t.fmtKlinePair(assetExec, priceExec, priceSymbol)
+	// 单价按 1e8 个资产单位计算, 防止溢出
+	fill.price = big.NewInt(0).Div(big.NewInt(0).Mul(big.NewInt(price), big.NewInt(types.TokenPrecision)), big.NewInt(amount)).Int64()
+	return fill
+}
+
+// klineFills get fills from receipt logs
+func (t *trade) klineFills(receipt *types.ReceiptData) []*tradeFill {
+	var fills []*tradeFill
+	for _, item := range receipt.Logs {
+		if item.Ty == pty.TyLogTradeBuyMarket {
+			var receipt pty.ReceiptTradeBuyMarket
+			err := types.Decode(item.Log, &receipt)
+			if err != nil {
+				panic(err) //数据错误了，已经被修改了
+			}
+			base := receipt.Base
+			fill := t.newTradeFill(base.AssetExec, base.TokenSymbol, base.PriceExec, base.PriceSymbol, base.AmountPerBoardlot, base.PricePerBoardlot, base.BoughtBoardlot)
+			if fill != nil {
+				fills = append(fills, fill)
+			}
+		} else if item.Ty == pty.TyLogTradeSellMarket {
+			var receipt pty.ReceiptSellMarket
+			err := types.Decode(item.Log, &receipt)
+			if err != nil {
+				panic(err) //数据错误了，已经被修改了
+			}
+			base := receipt.Base
+			fill := t.newTradeFill(base.AssetExec, base.TokenSymbol, base.PriceExec, base.PriceSymbol, base.AmountPerBoardlot, base.PricePerBoardlot, base.SoldBoardlot)
+			if fill != nil {
+				fills = append(fills, fill)
+			}
+		}
+	}
+	return fills
+}
+
+// saveKline add fills to klines, return kvs with rollback info
+func (t *trade) saveKline(tx *types.Transaction, receipt *types.ReceiptData) ([]*types.KeyValue, error) {
+	fills := t.klineFills(receipt)
+	if len(fills) == 0 {
+		return nil, nil
+	}
+	ldb := NewKlineTable(t.GetLocalDB())
+	blockTime := t.GetBlockTime()
+	// 同一根K线可能被多次修改, 先在内存中累加
+	cache := make(map[string]*pty.TradeKline)
+	for _, fill := range fills {
+		for _, period := range pty.KlinePeriods {
+			startTime := blockTime - blockTime%pty.KlinePeriodSeconds[period]
+			key := klinePrimaryKey(fill.assetExec, fill.assetSymbol, fill.priceExec, fill.priceSymbol, period, startTime)
+			kline, ok := cache[key]
+			if !ok {
+				kline = &pty.TradeKline{
+					AssetExec:   fill.assetExec,
+					TokenSymbol: fill.assetSymbol,
+					PriceExec:   fill.priceExec,
+					PriceSymbol: fill.priceSymbol,
+					Period:      period,
+					StartTime:   startTime,
+				}
+				row, err := ldb.GetData([]byte(key))
+				if err == nil {
+					kline = row.Data.(*pty.TradeKline)
+				} else if err != types.ErrNotFound {
+					return nil, err
+				}
+				cache[key] = kline
+			}
+			mergeKline(kline, fill)
+			err := ldb.Replace(kline)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	kvs, err := ldb.Save()
+	if err != nil {
+		return nil, err
+	}
+	return t.AddRollbackKV(tx, tx.Execer, kvs), nil
+}
+
+// deleteKline restore klines saved before this tx
+func (t *trade) deleteKline(tx *types.Transaction, receipt *types.ReceiptData) ([]*types.KeyValue, error) {
+	if len(t.klineFills(receipt)) == 0 {
+		return nil, nil
+	}
+	return t.DelRollbackKV(tx, tx.Execer)
+}
+
+func mergeKline(kline *pty.TradeKline, fill *tradeFill) {
+	if kline.Count == 0 {
+		kline.Open = fill.price
+		kline.High = fill.price
+		kline.Low = fill.price
+	}
+	if fill.price > kline.High {
+		kline.High = fill.price
+	}
+	if fill.price < kline.Low {
+		kline.Low = fill.price
+	}
+	kline.Close = fill.price
+	kline.Volume += fill.volume
+	kline.Turnover += fill.turnover
+	kline.Count++
+}
+
+// GetKline list klines of the pair in time range
+func (t *trade) GetKline(req *pty.ReqTradeKline) (types.Message, error) {
+	if _, ok := pty.KlinePeriodSeconds[req.Period]; !ok {
+		return nil, pty.ErrKlinePeriod
+	}
+	if req.TokenSymbol == "" || req.Count < 0 || req.Count > 20 || (req.Direction != 0 && req.Direction != 1) {
+		return nil, types.ErrInvalidParam
+	}
+	if req.EndTime != 0 && req.StartTime > req.EndTime {
+		return nil, pty.ErrTimeRange
+	}
+	assetExec, priceExec, priceSymbol := t.fmtKlinePair(req.AssetExec, req.PriceExec, req.PriceSymbol)
+	prefix := klinePrefix(assetExec, req.TokenSymbol, priceExec, priceSymbol, req.Period)
+	return listKline(t.GetLocalDB(), prefix, req.StartTime, req.EndTime, req.FromKey, req.Count, req.Direction)
+}
+
+// listKline 按前缀和时间区间列出K线, fromKey 为空时从最新(降序)或最早(升序)的K线开始
+func listKline(kvdb dbm.KV, prefix string, startTime, endTime int64, fromKey string, count, direction int32) (*pty.ReplyTradeKlines, error) {
+	if count == 0 {
+		count = 10
+	}
+	if endTime == 0 {
+		endTime = math.MaxInt64
+	}
+	if fromKey != "" && !strings.HasPrefix(fromKey, prefix) {
+		return nil, types.ErrInvalidParam
+	}
+	ldb := NewKlineTable(kvdb)
+	var reply pty.ReplyTradeKlines
+	for {
+		var rows []*table.Row
+		var err error
+		if fromKey == "" {
+			rows, err = ldb.ListIndex("time", []byte(prefix), nil, klinePageCount, direction)
+		} else {
+			// fromKey 一定是已经存在的K线, 不同交易对的K线在同一个表中, 需要按前缀过滤
+			rows, err = ldb.ListIndex("time", nil, []byte(fromKey), klinePageCount, direction)
+		}
+		if err == types.ErrNotFound {
+			return &reply, nil
+		}
+		if err != nil {
+			tradelog.Error("listKline", "prefix", prefix, "err", err)
+			return nil, err
+		}
+		for _, row := range rows {
+			kline := row.Data.(*pty.TradeKline)
+			if !strings.HasPrefix(string(row.Primary), prefix) {
+				return &reply, nil
+			}
+			// 还未进入时间区间
+			if (direction == 1 && kline.StartTime < startTime) || (direction == 0 && kline.StartTime > endTime) {
+				continue
+			}
+			// 已经超出时间区间
+			if kline.StartTime < startTime || kline.StartTime > endTime {
+				return &reply, nil
+			}
+			reply.Klines = append(reply.Klines, kline)
+			if len(reply.Klines) == int(count) {
+				reply.FromKey = string(row.Primary)
+				return &reply, nil
+			}
+		}
+		if len(rows) < int(klinePageCount) {
+			return &reply, nil
+		}
+		fromKey = string(rows[len(rows)-1].Primary)
+	}
+}
+
+// GetTicker 24h ticker of the pair, stat from 1m klines
+func (t *trade) GetTicker(req *pty.ReqTradeTicker) (types.Message, error) {
+	if req.TokenSymbol == "" {
+		return nil, types.ErrInvalidParam
+	}
+	endTime := req.Time
+	if endTime == 0 {
+		header, err := t.GetAPI().GetLastHeader()
+		if err != nil {
+			return nil, err
+		}
+		endTime = header.BlockTime
+	}
+	assetExec, priceExec, priceSymbol := t.fmtKlinePair(req.AssetExec, req.PriceExec, req.PriceSymbol)
+	prefix := klinePrefix(assetExec, req.TokenSymbol, priceExec, priceSymbol, pty.KlinePeriod1m)
+	ticker := &pty.ReplyTradeTicker{
+		AssetExec:   assetExec,
+		TokenSymbol: req.TokenSymbol,
+		PriceExec:   priceExec,
+		PriceSymbol: priceSymbol,
+		StartTime:   endTime - pty.TickerWindow,
+		EndTime:     endTime,
+	}
+	fromKey := ""
+	for {
+		// 从最新的K线往前统计
+		reply, err := listKline(t.GetLocalDB(), prefix, ticker.StartTime, endTime, fromKey, klinePageCount, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, kline := range reply.Klines {
+			if ticker.Count == 0 {
+				ticker.Close = kline.Close
+				ticker.High = kline.High
+				ticker.Low = kline.Low
+			}
+			if kline.High > ticker.High {
+				ticker.High = kline.High
+			}
+			if kline.Low < ticker.Low {
+				ticker.Low = kline.Low
+			}
+			ticker.Open = kline.Open
+			ticker.Volume += kline.Volume
+			ticker.Turnover += kline.Turnover
+			ticker.Count += kline.Count
+		}
+		if reply.FromKey == "" {
+			break
+		}
+		fromKey = reply.FromKey
+	}
+	if ticker.Count > 0 {
+		return ticker, nil
+	}
+	// 24小时内没有成交, 价格取最近一次成交价
+	reply, err := listKline(t.GetLocalDB(), prefix, 0, ticker.StartTime-1, "", 1, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(reply.Klines) > 0 {
+		price := reply.Klines[0].Close
+		ticker.Open = price
+		ticker.High = price
+		ticker.Low = price
+		ticker.Close = price
+	}
+	return ticker, nil
+}
@@ -0,0 +1,149 @@
+package executor
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/33cn/chain33/account"
+	apimock "github.com/33cn/chain33/client/mocks"
+	"github.com/33cn/chain33/common/address"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	pty "github.com/33cn/plugin/plugin/dapp/trade/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestKline(t *testing.T) {
+	total := 1000 * types.Coin
+	dir, ldb, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, ldb)
+	stateDB, _ := dbm.NewGoMemDB("1", "2", 100)
+
+	accA, _ := account.NewAccountDB(chain33TestCfg, AssetExecToken, Symbol, stateDB)
+	accA.SaveExecAccount(address.ExecAddress("trade"), &types.Account{Balance: total, Addr: string(Nodes[0])})
+	accB := account.NewCoinsAccount(chain33TestCfg)
+	accB.SetDB(stateDB)
+	accB.SaveExecAccount(address.ExecAddress("trade"), &types.Account{Balance: total, Addr: string(Nodes[1])})
+
+	api := new(apimock.QueueProtocolAPI)
+	api.On("GetConfig", mock.Anything).Return(chain33TestCfg, nil)
+	driver := newTrade()
+	driver.SetAPI(api)
+	driver.SetStateDB(stateDB)
+	driver.SetLocalDB(kvdb)
+	height := chain33TestCfg.GetDappFork("trade", pty.ForkTradePriceX)
+	blockTime := int64(1539918074)
+
+	exec := func(tx *types.Transaction, blockTime int64) (*types.Transaction, *types.ReceiptData) {
+		height++
+		driver.SetEnv(height, blockTime, 1)
+		receipt, err := driver.Exec(tx, 1)
+		assert.Nil(t, err)
+		for _, kv := range receipt.KV {
+			stateDB.Set(kv.Key, kv.Value)
+		}
+		receiptData := &types.ReceiptData{Ty: receipt.Ty, Logs: receipt.Logs}
+		_, err = driver.ExecLocal(tx, receiptData, 1)
+		assert.Nil(t, err)
+		return tx, receiptData
+	}
+	sellLimit := func(price int64) string {
+		sell := &pty.TradeSellTx{
+			TokenSymbol:       Symbol,
+			AmountPerBoardlot: types.Coin,
+			MinBoardlot:       1,
+			PricePerBoardlot:  price,
+			TotalBoardlot:     100,
+			AssetExec:         AssetExecToken,
+			PriceExec:         "coins",
+			PriceSymbol:       "bty",
+		}
+		tx, _ := pty.CreateRawTradeSellTx(chain33TestCfg, sell)
+		tx, _ = signTx(tx, PrivKeyA)
+		exec(tx, blockTime)
+		return hex.EncodeToString(tx.Hash())
+	}
+	buyMarket := func(sellID string, cnt int64, blockTime int64) (*types.Transaction, *types.ReceiptData) {
+		buy := &pty.TradeBuyTx{SellID: sellID, BoardlotCnt: cnt}
+		tx, _ := pty.CreateRawTradeBuyTx(chain33TestCfg, buy)
+		tx, _ = signTx(tx, PrivKeyB)
+		return exec(tx, blockTime)
+	}
+	query := func(req *pty.ReqTradeKline) *pty.ReplyTradeKlines {
+		resp, err := driver.Query("GetKline", types.Encode(req))
+		assert.Nil(t, err)
+		return resp.(*pty.ReplyTradeKlines)
+	}
+
+	// the first two buys fall into the same minute
+	sellID1 := sellLimit(2 * types.Coin)
+	sellID2 := sellLimit(3 * types.Coin)
+	buyMarket(sellID1, 5, blockTime)
+	buyMarket(sellID2, 2, blockTime+30)
+	req := &pty.ReqTradeKline{AssetExec: AssetExecToken, TokenSymbol: Symbol, PriceExec: "coins", PriceSymbol: "bty", Period: pty.KlinePeriod1m, Direction: 1}
+	before := query(req)
+	assert.Equal(t, 1, len(before.Klines))
+
+	// klines are restored after ExecDelLocal, the test db stores nil values instead of deleting them
+	tx, receipt := buyMarket(sellID1, 1, blockTime+60)
+	set, err := driver.ExecDelLocal(tx, receipt, 1)
+	assert.Nil(t, err)
+	for _, kv := range set.KV {
+		if kv.Value == nil {
+			ldb.Delete(kv.Key)
+		}
+	}
+	assert.Equal(t, before, query(req))
+	_, err = driver.ExecLocal(tx, receipt, 1)
+	assert.Nil(t, err)
+
+	klines := query(req).Klines
+	assert.Equal(t, 2, len(klines))
+	assert.Equal(t, int64(1539918060), klines[0].StartTime)
+	assert.Equal(t, 2*types.Coin, klines[0].Open)
+	assert.Equal(t, 3*types.Coin, klines[0].High)
+	assert.Equal(t, 2*types.Coin, klines[0].Low)
+	assert.Equal(t, 3*types.Coin, klines[0].Close)
+	assert.Equal(t, 7*types.Coin, klines[0].Volume)
+	assert.Equal(t, 16*types.Coin, klines[0].Turnover)
+	assert.Equal(t, int32(2), klines[0].Count)
+	assert.Equal(t, int64(1539918120), klines[1].StartTime)
+	assert.Equal(t, 2*types.Coin, klines[1].Close)
+	assert.Equal(t, int32(1), klines[1].Count)
+
+	// page by fromKey
+	req.Count = 1
+	req.Direction = 0
+	reply := query(req)
+	assert.Equal(t, int64(1539918120), reply.Klines[0].StartTime)
+	req.FromKey = reply.FromKey
+	reply = query(req)
+	assert.Equal(t, int64(1539918060), reply.Klines[0].StartTime)
+
+	req = &pty.ReqTradeKline{AssetExec: AssetExecToken, TokenSymbol: Symbol, PriceExec: "coins", PriceSymbol: "bty", Period: pty.KlinePeriod1d}
+	klines = query(req).Klines
+	assert.Equal(t, 1, len(klines))
+	assert.Equal(t, 8*types.Coin, klines[0].Volume)
+	assert.Equal(t, int32(3), klines[0].Count)
+	_, err = driver.Query("GetKline", types.Encode(&pty.ReqTradeKline{TokenSymbol: Symbol, Period: "3m"}))
+	assert.Equal(t, pty.ErrKlinePeriod, err)
+
+	resp, err := driver.Query("GetTicker", types.Encode(&pty.ReqTradeTicker{AssetExec: AssetExecToken, TokenSymbol: Symbol, PriceExec: "coins", PriceSymbol: "bty", Time: blockTime + 60}))
+	assert.Nil(t, err)
+	ticker := resp.(*pty.ReplyTradeTicker)
+	assert.Equal(t, 2*types.Coin, ticker.Open)
+	assert.Equal(t, 3*types.Coin, ticker.High)
+	assert.Equal(t, 2*types.Coin, ticker.Low)
+	assert.Equal(t, 2*types.Coin, ticker.Close)
+	assert.Equal(t, 8*types.Coin, ticker.Volume)
+	assert.Equal(t, int32(3), ticker.Count)
+
+	// no trade in the last 24 hours, prices fall back to the last close
+	resp, err = driver.Query("GetTicker", types.Encode(&pty.ReqTradeTicker{AssetExec: AssetExecToken, TokenSymbol: Symbol, PriceExec: "coins", PriceSymbol: "bty", Time: blockTime + pty.TickerWindow + 120}))
+	assert.Nil(t, err)
+	ticker = resp.(*pty.ReplyTradeTicker)
+	assert.Equal(t, 2*types.Coin, ticker.Open)
+	assert.Equal(t, int64(0), ticker.Volume)
+}
@@ -30,6 +30,16 @@ func (t *trade) Query_GetOneOrder(req *pty.ReqAddrAssets) (types.Message, error)
 	return t.GetOneOrder(req)
 }
 
+// kline of the pair
+func (t *trade) Query_GetKline(req *pty.ReqTradeKline) (types.Message, error) {
+	return t.GetKline(req)
+}
+
+// 24h ticker of the pair
+func (t *trade) Query_GetTicker(req *pty.ReqTradeTicker) (types.Message, error) {
+	return t.GetTicker(req)
+}
+
 // query reply utils
 
 const (
@@ -95,3 +95,28 @@ const (
 	// ForkTradePriceX all asset can be price
 	ForkTradePriceX = "ForkTradePrice"
 )
+
+// kline period
+const (
+	// KlinePeriod1m 1 minute kline
+	KlinePeriod1m = "1m"
+	// KlinePeriod5m 5 minutes kline
+	KlinePeriod5m = "5m"
+	// KlinePeriod1h 1 hour kline
+	KlinePeriod1h = "1h"
+	// KlinePeriod1d 1 day kline
+	KlinePeriod1d = "1d"
+	// TickerWindow 24h ticker window in seconds
+	TickerWindow = int64(24 * 3600)
+)
+
+// KlinePeriods : all kline periods, from short to long
+var KlinePeriods = []string{KlinePeriod1m, KlinePeriod5m, KlinePeriod1h, KlinePeriod1d}
+
+// KlinePeriodSeconds : kline period to seconds
+var KlinePeriodSeconds = map[string]int64{
+	KlinePeriod1m: 60,
+	KlinePeriod5m: 5 * 60,
+	KlinePeriod1h: 3600,
+	KlinePeriod1d: 24 * 3600,
+}
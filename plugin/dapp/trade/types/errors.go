@@ -39,4 +39,8 @@ var (
 	ErrTCntLessThanMinBoardlot = errors.New("ErrTradeCountLessThanMinBoardlot")
 	// ErrAssetAndPriceSame :
 	ErrAssetAndPriceSame = errors.New("ErrAssetAndPriceSame")
+	// ErrKlinePeriod :
+	ErrKlinePeriod = errors.New("ErrTradeKlinePeriod")
+	// ErrTimeRange :
+	ErrTimeRange = errors.New("ErrTradeTimeRange")
 )
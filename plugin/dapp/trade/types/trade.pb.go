@@ -49,7 +49,7 @@ func (m *Trade) Reset()         { *m = Trade{} }
 func (m *Trade) String() string { return proto.CompactTextString(m) }
 func (*Trade) ProtoMessage()    {}
 func (*Trade) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{0}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{0}
 }
 func (m *Trade) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Trade.Unmarshal(m, b)
@@ -343,7 +343,7 @@ func (m *TradeForSell) Reset()         { *m = TradeForSell{} }
 func (m *TradeForSell) String() string { return proto.CompactTextString(m) }
 func (*TradeForSell) ProtoMessage()    {}
 func (*TradeForSell) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{1}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{1}
 }
 func (m *TradeForSell) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TradeForSell.Unmarshal(m, b)
@@ -454,7 +454,7 @@ func (m *TradeForBuy) Reset()         { *m = TradeForBuy{} }
 func (m *TradeForBuy) String() string { return proto.CompactTextString(m) }
 func (*TradeForBuy) ProtoMessage()    {}
 func (*TradeForBuy) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{2}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{2}
 }
 func (m *TradeForBuy) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TradeForBuy.Unmarshal(m, b)
@@ -500,7 +500,7 @@ func (m *TradeForRevokeSell) Reset()         { *m = TradeForRevokeSell{} }
 func (m *TradeForRevokeSell) String() string { return proto.CompactTextString(m) }
 func (*TradeForRevokeSell) ProtoMessage()    {}
 func (*TradeForRevokeSell) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{3}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{3}
 }
 func (m *TradeForRevokeSell) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TradeForRevokeSell.Unmarshal(m, b)
@@ -547,7 +547,7 @@ func (m *TradeForBuyLimit) Reset()         { *m = TradeForBuyLimit{} }
 func (m *TradeForBuyLimit) String() string { return proto.CompactTextString(m) }
 func (*TradeForBuyLimit) ProtoMessage()    {}
 func (*TradeForBuyLimit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{4}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{4}
 }
 func (m *TradeForBuyLimit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TradeForBuyLimit.Unmarshal(m, b)
@@ -636,7 +636,7 @@ func (m *TradeForSellMarket) Reset()         { *m = TradeForSellMarket{} }
 func (m *TradeForSellMarket) String() string { return proto.CompactTextString(m) }
 func (*TradeForSellMarket) ProtoMessage()    {}
 func (*TradeForSellMarket) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{5}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{5}
 }
 func (m *TradeForSellMarket) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TradeForSellMarket.Unmarshal(m, b)
@@ -682,7 +682,7 @@ func (m *TradeForRevokeBuy) Reset()         { *m = TradeForRevokeBuy{} }
 func (m *TradeForRevokeBuy) String() string { return proto.CompactTextString(m) }
 func (*TradeForRevokeBuy) ProtoMessage()    {}
 func (*TradeForRevokeBuy) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{6}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{6}
 }
 func (m *TradeForRevokeBuy) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TradeForRevokeBuy.Unmarshal(m, b)
@@ -740,7 +740,7 @@ func (m *SellOrder) Reset()         { *m = SellOrder{} }
 func (m *SellOrder) String() string { return proto.CompactTextString(m) }
 func (*SellOrder) ProtoMessage()    {}
 func (*SellOrder) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{7}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{7}
 }
 func (m *SellOrder) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SellOrder.Unmarshal(m, b)
@@ -896,7 +896,7 @@ func (m *BuyLimitOrder) Reset()         { *m = BuyLimitOrder{} }
 func (m *BuyLimitOrder) String() string { return proto.CompactTextString(m) }
 func (*BuyLimitOrder) ProtoMessage()    {}
 func (*BuyLimitOrder) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{8}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{8}
 }
 func (m *BuyLimitOrder) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BuyLimitOrder.Unmarshal(m, b)
@@ -1033,7 +1033,7 @@ func (m *ReceiptBuyBase) Reset()         { *m = ReceiptBuyBase{} }
 func (m *ReceiptBuyBase) String() string { return proto.CompactTextString(m) }
 func (*ReceiptBuyBase) ProtoMessage()    {}
 func (*ReceiptBuyBase) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{9}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{9}
 }
 func (m *ReceiptBuyBase) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiptBuyBase.Unmarshal(m, b)
@@ -1191,7 +1191,7 @@ func (m *ReceiptSellBase) Reset()         { *m = ReceiptSellBase{} }
 func (m *ReceiptSellBase) String() string { return proto.CompactTextString(m) }
 func (*ReceiptSellBase) ProtoMessage()    {}
 func (*ReceiptSellBase) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{10}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{10}
 }
 func (m *ReceiptSellBase) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiptSellBase.Unmarshal(m, b)
@@ -1348,7 +1348,7 @@ func (m *ReceiptTradeBuyMarket) Reset()         { *m = ReceiptTradeBuyMarket{} }
 func (m *ReceiptTradeBuyMarket) String() string { return proto.CompactTextString(m) }
 func (*ReceiptTradeBuyMarket) ProtoMessage()    {}
 func (*ReceiptTradeBuyMarket) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{11}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{11}
 }
 func (m *ReceiptTradeBuyMarket) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiptTradeBuyMarket.Unmarshal(m, b)
@@ -1386,7 +1386,7 @@ func (m *ReceiptTradeBuyLimit) Reset()         { *m = ReceiptTradeBuyLimit{} }
 func (m *ReceiptTradeBuyLimit) String() string { return proto.CompactTextString(m) }
 func (*ReceiptTradeBuyLimit) ProtoMessage()    {}
 func (*ReceiptTradeBuyLimit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{12}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{12}
 }
 func (m *ReceiptTradeBuyLimit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiptTradeBuyLimit.Unmarshal(m, b)
@@ -1424,7 +1424,7 @@ func (m *ReceiptTradeBuyRevoke) Reset()         { *m = ReceiptTradeBuyRevoke{} }
 func (m *ReceiptTradeBuyRevoke) String() string { return proto.CompactTextString(m) }
 func (*ReceiptTradeBuyRevoke) ProtoMessage()    {}
 func (*ReceiptTradeBuyRevoke) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{13}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{13}
 }
 func (m *ReceiptTradeBuyRevoke) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiptTradeBuyRevoke.Unmarshal(m, b)
@@ -1462,7 +1462,7 @@ func (m *ReceiptTradeSellLimit) Reset()         { *m = ReceiptTradeSellLimit{} }
 func (m *ReceiptTradeSellLimit) String() string { return proto.CompactTextString(m) }
 func (*ReceiptTradeSellLimit) ProtoMessage()    {}
 func (*ReceiptTradeSellLimit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{14}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{14}
 }
 func (m *ReceiptTradeSellLimit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiptTradeSellLimit.Unmarshal(m, b)
@@ -1500,7 +1500,7 @@ func (m *ReceiptSellMarket) Reset()         { *m = ReceiptSellMarket{} }
 func (m *ReceiptSellMarket) String() string { return proto.CompactTextString(m) }
 func (*ReceiptSellMarket) ProtoMessage()    {}
 func (*ReceiptSellMarket) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{15}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{15}
 }
 func (m *ReceiptSellMarket) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiptSellMarket.Unmarshal(m, b)
@@ -1538,7 +1538,7 @@ func (m *ReceiptTradeSellRevoke) Reset()         { *m = ReceiptTradeSellRevoke{}
 func (m *ReceiptTradeSellRevoke) String() string { return proto.CompactTextString(m) }
 func (*ReceiptTradeSellRevoke) ProtoMessage()    {}
 func (*ReceiptTradeSellRevoke) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{16}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{16}
 }
 func (m *ReceiptTradeSellRevoke) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiptTradeSellRevoke.Unmarshal(m, b)
@@ -1582,7 +1582,7 @@ func (m *ReqAddrAssets) Reset()         { *m = ReqAddrAssets{} }
 func (m *ReqAddrAssets) String() string { return proto.CompactTextString(m) }
 func (*ReqAddrAssets) ProtoMessage()    {}
 func (*ReqAddrAssets) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{17}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{17}
 }
 func (m *ReqAddrAssets) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReqAddrAssets.Unmarshal(m, b)
@@ -1664,7 +1664,7 @@ func (m *ReqTokenSellOrder) Reset()         { *m = ReqTokenSellOrder{} }
 func (m *ReqTokenSellOrder) String() string { return proto.CompactTextString(m) }
 func (*ReqTokenSellOrder) ProtoMessage()    {}
 func (*ReqTokenSellOrder) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{18}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{18}
 }
 func (m *ReqTokenSellOrder) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReqTokenSellOrder.Unmarshal(m, b)
@@ -1734,7 +1734,7 @@ func (m *ReqTokenBuyOrder) Reset()         { *m = ReqTokenBuyOrder{} }
 func (m *ReqTokenBuyOrder) String() string { return proto.CompactTextString(m) }
 func (*ReqTokenBuyOrder) ProtoMessage()    {}
 func (*ReqTokenBuyOrder) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{19}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{19}
 }
 func (m *ReqTokenBuyOrder) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReqTokenBuyOrder.Unmarshal(m, b)
@@ -1813,7 +1813,7 @@ func (m *ReplyBuyOrder) Reset()         { *m = ReplyBuyOrder{} }
 func (m *ReplyBuyOrder) String() string { return proto.CompactTextString(m) }
 func (*ReplyBuyOrder) ProtoMessage()    {}
 func (*ReplyBuyOrder) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{20}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{20}
 }
 func (m *ReplyBuyOrder) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReplyBuyOrder.Unmarshal(m, b)
@@ -1955,7 +1955,7 @@ func (m *ReplySellOrder) Reset()         { *m = ReplySellOrder{} }
 func (m *ReplySellOrder) String() string { return proto.CompactTextString(m) }
 func (*ReplySellOrder) ProtoMessage()    {}
 func (*ReplySellOrder) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{21}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{21}
 }
 func (m *ReplySellOrder) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReplySellOrder.Unmarshal(m, b)
@@ -2084,7 +2084,7 @@ func (m *ReplySellOrders) Reset()         { *m = ReplySellOrders{} }
 func (m *ReplySellOrders) String() string { return proto.CompactTextString(m) }
 func (*ReplySellOrders) ProtoMessage()    {}
 func (*ReplySellOrders) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{22}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{22}
 }
 func (m *ReplySellOrders) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReplySellOrders.Unmarshal(m, b)
@@ -2122,7 +2122,7 @@ func (m *ReplyBuyOrders) Reset()         { *m = ReplyBuyOrders{} }
 func (m *ReplyBuyOrders) String() string { return proto.CompactTextString(m) }
 func (*ReplyBuyOrders) ProtoMessage()    {}
 func (*ReplyBuyOrders) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{23}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{23}
 }
 func (m *ReplyBuyOrders) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReplyBuyOrders.Unmarshal(m, b)
@@ -2177,7 +2177,7 @@ func (m *ReplyTradeOrder) Reset()         { *m = ReplyTradeOrder{} }
 func (m *ReplyTradeOrder) String() string { return proto.CompactTextString(m) }
 func (*ReplyTradeOrder) ProtoMessage()    {}
 func (*ReplyTradeOrder) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{24}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{24}
 }
 func (m *ReplyTradeOrder) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReplyTradeOrder.Unmarshal(m, b)
@@ -2334,7 +2334,7 @@ func (m *ReplyTradeOrders) Reset()         { *m = ReplyTradeOrders{} }
 func (m *ReplyTradeOrders) String() string { return proto.CompactTextString(m) }
 func (*ReplyTradeOrders) ProtoMessage()    {}
 func (*ReplyTradeOrders) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{25}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{25}
 }
 func (m *ReplyTradeOrders) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReplyTradeOrders.Unmarshal(m, b)
@@ -2373,7 +2373,7 @@ func (m *ReqSellToken) Reset()         { *m = ReqSellToken{} }
 func (m *ReqSellToken) String() string { return proto.CompactTextString(m) }
 func (*ReqSellToken) ProtoMessage()    {}
 func (*ReqSellToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{26}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{26}
 }
 func (m *ReqSellToken) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReqSellToken.Unmarshal(m, b)
@@ -2419,7 +2419,7 @@ func (m *ReqRevokeSell) Reset()         { *m = ReqRevokeSell{} }
 func (m *ReqRevokeSell) String() string { return proto.CompactTextString(m) }
 func (*ReqRevokeSell) ProtoMessage()    {}
 func (*ReqRevokeSell) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{27}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{27}
 }
 func (m *ReqRevokeSell) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReqRevokeSell.Unmarshal(m, b)
@@ -2465,7 +2465,7 @@ func (m *ReqBuyToken) Reset()         { *m = ReqBuyToken{} }
 func (m *ReqBuyToken) String() string { return proto.CompactTextString(m) }
 func (*ReqBuyToken) ProtoMessage()    {}
 func (*ReqBuyToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{28}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{28}
 }
 func (m *ReqBuyToken) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReqBuyToken.Unmarshal(m, b)
@@ -2529,7 +2529,7 @@ func (m *LocalOrder) Reset()         { *m = LocalOrder{} }
 func (m *LocalOrder) String() string { return proto.CompactTextString(m) }
 func (*LocalOrder) ProtoMessage()    {}
 func (*LocalOrder) Descriptor() ([]byte, []int) {
-	return fileDescriptor_trade_05e36a4efd974a28, []int{29}
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{29}
 }
 func (m *LocalOrder) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LocalOrder.Unmarshal(m, b)
@@ -2689,6 +2689,506 @@ func (m *LocalOrder) GetPriceSymbol() string {
 	return ""
 }
 
+// K线及24小时行情
+// 价格为每 1e8 个资产单位对应的计价资产数量, 成交量以资产计, 成交额以计价资产计
+// period : 1m, 5m, 1h, 1d
+// fromKey : 第一次传参为空， 翻页时传上一页返回的fromKey
+// direction : 0 按时间降序， 1 按时间升序
+type ReqTradeKline struct {
+	AssetExec            string   `protobuf:"bytes,1,opt,name=assetExec,proto3" json:"assetExec,omitempty"`
+	TokenSymbol          string   `protobuf:"bytes,2,opt,name=tokenSymbol,proto3" json:"tokenSymbol,omitempty"`
+	PriceExec            string   `protobuf:"bytes,3,opt,name=priceExec,proto3" json:"priceExec,omitempty"`
+	PriceSymbol          string   `protobuf:"bytes,4,opt,name=priceSymbol,proto3" json:"priceSymbol,omitempty"`
+	Period               string   `protobuf:"bytes,5,opt,name=period,proto3" json:"period,omitempty"`
+	StartTime            int64    `protobuf:"varint,6,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime              int64    `protobuf:"varint,7,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	FromKey              string   `protobuf:"bytes,8,opt,name=fromKey,proto3" json:"fromKey,omitempty"`
+	Count                int32    `protobuf:"varint,9,opt,name=count,proto3" json:"count,omitempty"`
+	Direction            int32    `protobuf:"varint,10,opt,name=direction,proto3" json:"direction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqTradeKline) Reset()         { *m = ReqTradeKline{} }
+func (m *ReqTradeKline) String() string { return proto.CompactTextString(m) }
+func (*ReqTradeKline) ProtoMessage()    {}
+func (*ReqTradeKline) Descriptor() ([]byte, []int) {
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{30}
+}
+func (m *ReqTradeKline) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqTradeKline.Unmarshal(m, b)
+}
+func (m *ReqTradeKline) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqTradeKline.Marshal(b, m, deterministic)
+}
+func (dst *ReqTradeKline) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqTradeKline.Merge(dst, src)
+}
+func (m *ReqTradeKline) XXX_Size() int {
+	return xxx_messageInfo_ReqTradeKline.Size(m)
+}
+func (m *ReqTradeKline) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqTradeKline.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqTradeKline proto.InternalMessageInfo
+
+func (m *ReqTradeKline) GetAssetExec() string {
+	if m != nil {
+		return m.AssetExec
+	}
+	return ""
+}
+
+func (m *ReqTradeKline) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+func (m *ReqTradeKline) GetPriceExec() string {
+	if m != nil {
+		return m.PriceExec
+	}
+	return ""
+}
+
+func (m *ReqTradeKline) GetPriceSymbol() string {
+	if m != nil {
+		return m.PriceSymbol
+	}
+	return ""
+}
+
+func (m *ReqTradeKline) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+func (m *ReqTradeKline) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *ReqTradeKline) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *ReqTradeKline) GetFromKey() string {
+	if m != nil {
+		return m.FromKey
+	}
+	return ""
+}
+
+func (m *ReqTradeKline) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ReqTradeKline) GetDirection() int32 {
+	if m != nil {
+		return m.Direction
+	}
+	return 0
+}
+
+type TradeKline struct {
+	AssetExec            string   `protobuf:"bytes,1,opt,name=assetExec,proto3" json:"assetExec,omitempty"`
+	TokenSymbol          string   `protobuf:"bytes,2,opt,name=tokenSymbol,proto3" json:"tokenSymbol,omitempty"`
+	PriceExec            string   `protobuf:"bytes,3,opt,name=priceExec,proto3" json:"priceExec,omitempty"`
+	PriceSymbol          string   `protobuf:"bytes,4,opt,name=priceSymbol,proto3" json:"priceSymbol,omitempty"`
+	Period               string   `protobuf:"bytes,5,opt,name=period,proto3" json:"period,omitempty"`
+	StartTime            int64    `protobuf:"varint,6,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	Open                 int64    `protobuf:"varint,7,opt,name=open,proto3" json:"open,omitempty"`
+	High                 int64    `protobuf:"varint,8,opt,name=high,proto3" json:"high,omitempty"`
+	Low                  int64    `protobuf:"varint,9,opt,name=low,proto3" json:"low,omitempty"`
+	Close                int64    `protobuf:"varint,10,opt,name=close,proto3" json:"close,omitempty"`
+	Volume               int64    `protobuf:"varint,11,opt,name=volume,proto3" json:"volume,omitempty"`
+	Turnover             int64    `protobuf:"varint,12,opt,name=turnover,proto3" json:"turnover,omitempty"`
+	Count                int32    `protobuf:"varint,13,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TradeKline) Reset()         { *m = TradeKline{} }
+func (m *TradeKline) String() string { return proto.CompactTextString(m) }
+func (*TradeKline) ProtoMessage()    {}
+func (*TradeKline) Descriptor() ([]byte, []int) {
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{31}
+}
+func (m *TradeKline) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TradeKline.Unmarshal(m, b)
+}
+func (m *TradeKline) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TradeKline.Marshal(b, m, deterministic)
+}
+func (dst *TradeKline) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TradeKline.Merge(dst, src)
+}
+func (m *TradeKline) XXX_Size() int {
+	return xxx_messageInfo_TradeKline.Size(m)
+}
+func (m *TradeKline) XXX_DiscardUnknown() {
+	xxx_messageInfo_TradeKline.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TradeKline proto.InternalMessageInfo
+
+func (m *TradeKline) GetAssetExec() string {
+	if m != nil {
+		return m.AssetExec
+	}
+	return ""
+}
+
+func (m *TradeKline) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+func (m *TradeKline) GetPriceExec() string {
+	if m != nil {
+		return m.PriceExec
+	}
+	return ""
+}
+
+func (m *TradeKline) GetPriceSymbol() string {
+	if m != nil {
+		return m.PriceSymbol
+	}
+	return ""
+}
+
+func (m *TradeKline) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+func (m *TradeKline) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *TradeKline) GetOpen() int64 {
+	if m != nil {
+		return m.Open
+	}
+	return 0
+}
+
+func (m *TradeKline) GetHigh() int64 {
+	if m != nil {
+		return m.High
+	}
+	return 0
+}
+
+func (m *TradeKline) GetLow() int64 {
+	if m != nil {
+		return m.Low
+	}
+	return 0
+}
+
+func (m *TradeKline) GetClose() int64 {
+	if m != nil {
+		return m.Close
+	}
+	return 0
+}
+
+func (m *TradeKline) GetVolume() int64 {
+	if m != nil {
+		return m.Volume
+	}
+	return 0
+}
+
+func (m *TradeKline) GetTurnover() int64 {
+	if m != nil {
+		return m.Turnover
+	}
+	return 0
+}
+
+func (m *TradeKline) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type ReplyTradeKlines struct {
+	Klines               []*TradeKline `protobuf:"bytes,1,rep,name=klines,proto3" json:"klines,omitempty"`
+	FromKey              string        `protobuf:"bytes,2,opt,name=fromKey,proto3" json:"fromKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ReplyTradeKlines) Reset()         { *m = ReplyTradeKlines{} }
+func (m *ReplyTradeKlines) String() string { return proto.CompactTextString(m) }
+func (*ReplyTradeKlines) ProtoMessage()    {}
+func (*ReplyTradeKlines) Descriptor() ([]byte, []int) {
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{32}
+}
+func (m *ReplyTradeKlines) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplyTradeKlines.Unmarshal(m, b)
+}
+func (m *ReplyTradeKlines) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplyTradeKlines.Marshal(b, m, deterministic)
+}
+func (dst *ReplyTradeKlines) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplyTradeKlines.Merge(dst, src)
+}
+func (m *ReplyTradeKlines) XXX_Size() int {
+	return xxx_messageInfo_ReplyTradeKlines.Size(m)
+}
+func (m *ReplyTradeKlines) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplyTradeKlines.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplyTradeKlines proto.InternalMessageInfo
+
+func (m *ReplyTradeKlines) GetKlines() []*TradeKline {
+	if m != nil {
+		return m.Klines
+	}
+	return nil
+}
+
+func (m *ReplyTradeKlines) GetFromKey() string {
+	if m != nil {
+		return m.FromKey
+	}
+	return ""
+}
+
+// time : 统计截止时间， 为0时取最新区块时间
+type ReqTradeTicker struct {
+	AssetExec            string   `protobuf:"bytes,1,opt,name=assetExec,proto3" json:"assetExec,omitempty"`
+	TokenSymbol          string   `protobuf:"bytes,2,opt,name=tokenSymbol,proto3" json:"tokenSymbol,omitempty"`
+	PriceExec            string   `protobuf:"bytes,3,opt,name=priceExec,proto3" json:"priceExec,omitempty"`
+	PriceSymbol          string   `protobuf:"bytes,4,opt,name=priceSymbol,proto3" json:"priceSymbol,omitempty"`
+	Time                 int64    `protobuf:"varint,5,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqTradeTicker) Reset()         { *m = ReqTradeTicker{} }
+func (m *ReqTradeTicker) String() string { return proto.CompactTextString(m) }
+func (*ReqTradeTicker) ProtoMessage()    {}
+func (*ReqTradeTicker) Descriptor() ([]byte, []int) {
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{33}
+}
+func (m *ReqTradeTicker) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqTradeTicker.Unmarshal(m, b)
+}
+func (m *ReqTradeTicker) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqTradeTicker.Marshal(b, m, deterministic)
+}
+func (dst *ReqTradeTicker) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqTradeTicker.Merge(dst, src)
+}
+func (m *ReqTradeTicker) XXX_Size() int {
+	return xxx_messageInfo_ReqTradeTicker.Size(m)
+}
+func (m *ReqTradeTicker) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqTradeTicker.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqTradeTicker proto.InternalMessageInfo
+
+func (m *ReqTradeTicker) GetAssetExec() string {
+	if m != nil {
+		return m.AssetExec
+	}
+	return ""
+}
+
+func (m *ReqTradeTicker) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+func (m *ReqTradeTicker) GetPriceExec() string {
+	if m != nil {
+		return m.PriceExec
+	}
+	return ""
+}
+
+func (m *ReqTradeTicker) GetPriceSymbol() string {
+	if m != nil {
+		return m.PriceSymbol
+	}
+	return ""
+}
+
+func (m *ReqTradeTicker) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+type ReplyTradeTicker struct {
+	AssetExec            string   `protobuf:"bytes,1,opt,name=assetExec,proto3" json:"assetExec,omitempty"`
+	TokenSymbol          string   `protobuf:"bytes,2,opt,name=tokenSymbol,proto3" json:"tokenSymbol,omitempty"`
+	PriceExec            string   `protobuf:"bytes,3,opt,name=priceExec,proto3" json:"priceExec,omitempty"`
+	PriceSymbol          string   `protobuf:"bytes,4,opt,name=priceSymbol,proto3" json:"priceSymbol,omitempty"`
+	StartTime            int64    `protobuf:"varint,5,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime              int64    `protobuf:"varint,6,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	Open                 int64    `protobuf:"varint,7,opt,name=open,proto3" json:"open,omitempty"`
+	High                 int64    `protobuf:"varint,8,opt,name=high,proto3" json:"high,omitempty"`
+	Low                  int64    `protobuf:"varint,9,opt,name=low,proto3" json:"low,omitempty"`
+	Close                int64    `protobuf:"varint,10,opt,name=close,proto3" json:"close,omitempty"`
+	Volume               int64    `protobuf:"varint,11,opt,name=volume,proto3" json:"volume,omitempty"`
+	Turnover             int64    `protobuf:"varint,12,opt,name=turnover,proto3" json:"turnover,omitempty"`
+	Count                int32    `protobuf:"varint,13,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplyTradeTicker) Reset()         { *m = ReplyTradeTicker{} }
+func (m *ReplyTradeTicker) String() string { return proto.CompactTextString(m) }
+func (*ReplyTradeTicker) ProtoMessage()    {}
+func (*ReplyTradeTicker) Descriptor() ([]byte, []int) {
+	return fileDescriptor_trade_26193d59cb66e2c3, []int{34}
+}
+func (m *ReplyTradeTicker) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplyTradeTicker.Unmarshal(m, b)
+}
+func (m *ReplyTradeTicker) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplyTradeTicker.Marshal(b, m, deterministic)
+}
+func (dst *ReplyTradeTicker) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplyTradeTicker.Merge(dst, src)
+}
+func (m *ReplyTradeTicker) XXX_Size() int {
+	return xxx_messageInfo_ReplyTradeTicker.Size(m)
+}
+func (m *ReplyTradeTicker) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplyTradeTicker.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplyTradeTicker proto.InternalMessageInfo
+
+func (m *ReplyTradeTicker) GetAssetExec() string {
+	if m != nil {
+		return m.AssetExec
+	}
+	return ""
+}
+
+func (m *ReplyTradeTicker) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+func (m *ReplyTradeTicker) GetPriceExec() string {
+	if m != nil {
+		return m.PriceExec
+	}
+	return ""
+}
+
+func (m *ReplyTradeTicker) GetPriceSymbol() string {
+	if m != nil {
+		return m.PriceSymbol
+	}
+	return ""
+}
+
+func (m *ReplyTradeTicker) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *ReplyTradeTicker) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *ReplyTradeTicker) GetOpen() int64 {
+	if m != nil {
+		return m.Open
+	}
+	return 0
+}
+
+func (m *ReplyTradeTicker) GetHigh() int64 {
+	if m != nil {
+		return m.High
+	}
+	return 0
+}
+
+func (m *ReplyTradeTicker) GetLow() int64 {
+	if m != nil {
+		return m.Low
+	}
+	return 0
+}
+
+func (m *ReplyTradeTicker) GetClose() int64 {
+	if m != nil {
+		return m.Close
+	}
+	return 0
+}
+
+func (m *ReplyTradeTicker) GetVolume() int64 {
+	if m != nil {
+		return m.Volume
+	}
+	return 0
+}
+
+func (m *ReplyTradeTicker) GetTurnover() int64 {
+	if m != nil {
+		return m.Turnover
+	}
+	return 0
+}
+
+func (m *ReplyTradeTicker) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Trade)(nil), "types.Trade")
 	proto.RegisterType((*TradeForSell)(nil), "types.TradeForSell")
@@ -2720,6 +3220,11 @@ func init() {
 	proto.RegisterType((*ReqRevokeSell)(nil), "types.ReqRevokeSell")
 	proto.RegisterType((*ReqBuyToken)(nil), "types.ReqBuyToken")
 	proto.RegisterType((*LocalOrder)(nil), "types.LocalOrder")
+	proto.RegisterType((*ReqTradeKline)(nil), "types.ReqTradeKline")
+	proto.RegisterType((*TradeKline)(nil), "types.TradeKline")
+	proto.RegisterType((*ReplyTradeKlines)(nil), "types.ReplyTradeKlines")
+	proto.RegisterType((*ReqTradeTicker)(nil), "types.ReqTradeTicker")
+	proto.RegisterType((*ReplyTradeTicker)(nil), "types.ReplyTradeTicker")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2959,94 +3464,108 @@ var _Trade_serviceDesc = grpc.ServiceDesc{
 	Metadata: "trade.proto",
 }
 
-func init() { proto.RegisterFile("trade.proto", fileDescriptor_trade_05e36a4efd974a28) }
-
-var fileDescriptor_trade_05e36a4efd974a28 = []byte{
-	// 1371 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0xdd, 0x8a, 0xdb, 0xc6,
-	0x17, 0x5f, 0x5b, 0x96, 0xd7, 0x3a, 0xfe, 0x58, 0xef, 0xc4, 0xd9, 0xbf, 0xb2, 0xfc, 0x29, 0x46,
-	0x84, 0x36, 0x09, 0x61, 0xa1, 0x09, 0x81, 0x42, 0x4b, 0x4b, 0x9c, 0x34, 0x75, 0xda, 0x84, 0x96,
-	0x59, 0x17, 0x7a, 0x2b, 0x5b, 0x93, 0xac, 0x58, 0x59, 0xf2, 0x4a, 0xa3, 0xc4, 0x7a, 0x83, 0x42,
-	0xdf, 0xa0, 0xbd, 0xe8, 0x03, 0xf4, 0x36, 0x50, 0x42, 0xdf, 0xa7, 0x97, 0xbd, 0xea, 0x03, 0x94,
-	0x19, 0x8d, 0xa5, 0xd1, 0x97, 0x3f, 0x20, 0x85, 0x4d, 0xd2, 0x3b, 0xcf, 0x99, 0x33, 0x67, 0x8e,
-	0xce, 0xef, 0xf7, 0x9b, 0x2f, 0x43, 0x9b, 0xfa, 0xa6, 0x45, 0x4e, 0x16, 0xbe, 0x47, 0x3d, 0xa4,
-	0xd2, 0x68, 0x41, 0x82, 0xe3, 0x43, 0xea, 0x9b, 0x6e, 0x60, 0xce, 0xa8, 0xed, 0xb9, 0x71, 0x8f,
-	0xf1, 0x77, 0x1d, 0xd4, 0x09, 0xf3, 0x44, 0x77, 0x41, 0x0b, 0x88, 0xe3, 0x3c, 0xb1, 0xe7, 0x36,
-	0xd5, 0x6b, 0xc3, 0xda, 0x8d, 0xf6, 0x9d, 0x2b, 0x27, 0x7c, 0xdc, 0x09, 0x77, 0x78, 0xe4, 0xf9,
-	0xa7, 0xc4, 0x71, 0xc6, 0x7b, 0x38, 0xf5, 0x43, 0x77, 0x40, 0x9b, 0x86, 0xd1, 0x53, 0xd3, 0x3f,
-	0x27, 0x54, 0xaf, 0xf3, 0x41, 0x28, 0x37, 0x68, 0x14, 0x46, 0x6c, 0x4c, 0xe2, 0x86, 0x3e, 0x05,
-	0xf0, 0xc9, 0x0b, 0xef, 0x9c, 0xb0, 0x70, 0xba, 0xc2, 0x07, 0x5d, 0xcb, 0x0d, 0xc2, 0x89, 0xc3,
-	0x78, 0x0f, 0x4b, 0xee, 0xe8, 0x1e, 0xb4, 0xa6, 0x61, 0x14, 0x27, 0xa9, 0xf2, 0xa1, 0xff, 0x2b,
-	0xce, 0xc7, 0xbb, 0xc7, 0x7b, 0x38, 0x71, 0x65, 0x73, 0xb2, 0xa4, 0x45, 0xa2, 0xcd, 0xd2, 0x39,
-	0x4f, 0x13, 0x07, 0x36, 0x67, 0xea, 0x8e, 0x3e, 0x01, 0x2d, 0xce, 0x60, 0x14, 0x46, 0xfa, 0x3e,
-	0x1f, 0xab, 0x97, 0xe6, 0x2b, 0x3e, 0x35, 0x71, 0x46, 0x3d, 0xa8, 0xd3, 0x48, 0x6f, 0x0c, 0x6b,
-	0x37, 0x54, 0x5c, 0xa7, 0xd1, 0x68, 0x1f, 0xd4, 0x17, 0xa6, 0x13, 0x12, 0xe3, 0x47, 0x05, 0x3a,
-	0xf2, 0xbc, 0x68, 0x08, 0x6d, 0xea, 0x9d, 0x13, 0xf7, 0x34, 0x9a, 0x4f, 0x3d, 0x87, 0xd7, 0x5f,
-	0xc3, 0xb2, 0x09, 0xdd, 0x86, 0x43, 0x73, 0xee, 0x85, 0x2e, 0xfd, 0x8e, 0xf8, 0x23, 0xcf, 0xf4,
-	0x2d, 0xc7, 0x8b, 0x4b, 0xae, 0xe0, 0x62, 0x07, 0x8b, 0x37, 0xb7, 0xdd, 0xc4, 0x4f, 0xe1, 0x7e,
-	0xb2, 0x09, 0xdd, 0x82, 0xfe, 0xc2, 0xb7, 0x67, 0x44, 0x0e, 0xd7, 0xe0, 0x6e, 0x05, 0x3b, 0xba,
-	0x0e, 0x5d, 0xea, 0x51, 0xd3, 0x49, 0x1c, 0x55, 0xee, 0x98, 0x35, 0xa2, 0xff, 0x83, 0x16, 0x50,
-	0xd3, 0xa7, 0xd4, 0x9e, 0x13, 0x5e, 0x63, 0x05, 0xa7, 0x06, 0x74, 0x0c, 0xad, 0x80, 0x7a, 0x0b,
-	0xde, 0xb9, 0xcf, 0x3b, 0x93, 0x36, 0x1b, 0x39, 0xf3, 0xbd, 0x97, 0xd6, 0xb3, 0xd0, 0xb5, 0xf4,
-	0xd6, 0xb0, 0x76, 0xa3, 0x85, 0x53, 0x03, 0xeb, 0x35, 0x83, 0x80, 0xd0, 0x2f, 0x97, 0x64, 0xa6,
-	0x6b, 0xbc, 0x32, 0xa9, 0x81, 0xf5, 0xf2, 0x7c, 0x79, 0x2f, 0xc4, 0xbd, 0x89, 0x81, 0xd5, 0x81,
-	0x37, 0x44, 0x5d, 0xdb, 0x71, 0x5d, 0x25, 0x93, 0xf1, 0x15, 0xb4, 0x25, 0xea, 0xa0, 0x23, 0x68,
-	0x32, 0xe8, 0x1f, 0x3f, 0x14, 0x18, 0x88, 0x16, 0x0b, 0x34, 0x15, 0x1f, 0xfa, 0xc0, 0x5d, 0x15,
-	0x5e, 0x36, 0x19, 0xb7, 0x01, 0x15, 0xe9, 0x5b, 0x15, 0xcf, 0x78, 0x55, 0x87, 0x7e, 0x9e, 0xb2,
-	0xef, 0x0a, 0x0b, 0x52, 0xb4, 0x9a, 0x6b, 0xd1, 0xda, 0xdf, 0x80, 0x56, 0xab, 0x88, 0xd6, 0x93,
-	0xb4, 0xc8, 0xa9, 0x5e, 0xd1, 0x00, 0xd4, 0x69, 0x18, 0x25, 0x35, 0x8e, 0x1b, 0x5b, 0x40, 0x76,
-	0x13, 0x0e, 0x0b, 0x0a, 0x2e, 0x0f, 0x66, 0xfc, 0xd4, 0x00, 0x8d, 0xcd, 0xf8, 0xad, 0x6f, 0x11,
-	0x7f, 0x0b, 0xa0, 0x74, 0xd8, 0x37, 0x2d, 0xcb, 0x27, 0x41, 0xc0, 0x27, 0xd6, 0xf0, 0xaa, 0x59,
-	0x0e, 0xa1, 0xb2, 0x25, 0x84, 0x8d, 0xed, 0x20, 0x54, 0xb7, 0x85, 0xb0, 0x59, 0x06, 0xa1, 0x01,
-	0x9d, 0xc0, 0x73, 0xac, 0xc4, 0x29, 0x96, 0x6b, 0xc6, 0x96, 0x15, 0x7b, 0x6b, 0x9d, 0xd8, 0xb5,
-	0x75, 0x62, 0x87, 0xbc, 0xd8, 0x53, 0xbd, 0xb4, 0x33, 0xfa, 0x63, 0x76, 0x6a, 0xd2, 0x30, 0xd0,
-	0x3b, 0x7c, 0x39, 0x15, 0x2d, 0x66, 0x3f, 0x23, 0xf6, 0xf3, 0x33, 0xaa, 0x77, 0xf9, 0x3c, 0xa2,
-	0x95, 0xa5, 0x61, 0x6f, 0x2d, 0x0d, 0x0f, 0x36, 0xd0, 0xb0, 0x5f, 0xa4, 0xe1, 0x6b, 0x05, 0xba,
-	0x2b, 0xd5, 0xbe, 0x0f, 0x8c, 0xf8, 0x10, 0x7a, 0x53, 0x2f, 0x7c, 0x7e, 0x46, 0x73, 0x9c, 0xc8,
-	0x59, 0x53, 0xed, 0xb4, 0x64, 0x21, 0xa6, 0xd8, 0x69, 0x15, 0xd8, 0x41, 0x35, 0x76, 0xed, 0xb5,
-	0xd8, 0x75, 0x36, 0x60, 0xd7, 0x2d, 0x62, 0xf7, 0xa7, 0x02, 0x3d, 0x4c, 0x66, 0xc4, 0x5e, 0xd0,
-	0x51, 0x18, 0x8d, 0xcc, 0x80, 0x6c, 0x01, 0xde, 0x00, 0x54, 0xef, 0xa5, 0x4b, 0x7c, 0x01, 0x5d,
-	0xdc, 0xa8, 0x06, 0x4e, 0x7b, 0xb3, 0xc0, 0x69, 0x97, 0x02, 0x38, 0x4d, 0x06, 0x4e, 0x88, 0x14,
-	0xf2, 0x22, 0xa5, 0xcb, 0xb1, 0x19, 0x9c, 0xad, 0xc4, 0x1b, 0xb7, 0x24, 0xa0, 0x3b, 0xd5, 0x40,
-	0x77, 0xd7, 0x02, 0xdd, 0xdb, 0x00, 0xf4, 0x41, 0x11, 0xe8, 0x3f, 0x1a, 0x70, 0x20, 0x80, 0x66,
-	0x2b, 0xf7, 0x3b, 0x8e, 0xf4, 0xe5, 0x5f, 0xb4, 0x53, 0xfe, 0x24, 0x6c, 0xeb, 0xe6, 0xd8, 0x26,
-	0xd8, 0xd3, 0xab, 0x60, 0xcf, 0x41, 0x35, 0x7b, 0xfa, 0x6b, 0xd9, 0x73, 0xb8, 0x81, 0x3d, 0xa8,
-	0xc8, 0x9e, 0x11, 0x5c, 0x15, 0xe4, 0xe1, 0x47, 0x84, 0x51, 0x72, 0x7f, 0xb9, 0x09, 0x8d, 0xa9,
-	0x19, 0x10, 0x71, 0x47, 0xba, 0x2a, 0x6e, 0x02, 0xd9, 0x15, 0x05, 0x73, 0x17, 0xe3, 0x3e, 0x0c,
-	0x72, 0x31, 0xe2, 0x73, 0xde, 0x0e, 0x21, 0x8a, 0x69, 0xc4, 0x27, 0x95, 0x5d, 0x62, 0x3c, 0xc8,
-	0xc6, 0x38, 0x4d, 0xae, 0x6f, 0xb7, 0x32, 0x31, 0x8e, 0xb2, 0x31, 0x56, 0x9a, 0x11, 0x41, 0xbe,
-	0x80, 0x43, 0xa9, 0x43, 0xd4, 0x62, 0x97, 0x00, 0x0f, 0xe1, 0x28, 0x9f, 0x85, 0xf8, 0x94, 0x5d,
-	0xa2, 0xfc, 0x5a, 0x83, 0x2e, 0x26, 0x17, 0xf7, 0x2d, 0xcb, 0xbf, 0xcf, 0xb0, 0x0e, 0x10, 0x82,
-	0x06, 0xdb, 0x48, 0x85, 0x96, 0xf9, 0x6f, 0x89, 0x78, 0xf5, 0xcc, 0x8e, 0x33, 0x00, 0x95, 0x6b,
-	0x5d, 0x57, 0x86, 0x0a, 0x23, 0x1e, 0x6f, 0x30, 0xaa, 0x58, 0xb6, 0x4f, 0xf8, 0xbd, 0x58, 0xdc,
-	0xd6, 0x52, 0x03, 0x1b, 0x33, 0x63, 0x02, 0xe7, 0xfa, 0x54, 0x71, 0xdc, 0x60, 0xbb, 0xf9, 0x33,
-	0xdf, 0x9b, 0x7f, 0x43, 0x22, 0x71, 0xc8, 0x5d, 0x35, 0x8d, 0x5f, 0x6a, 0xac, 0x52, 0x17, 0x13,
-	0xbe, 0xa6, 0xec, 0x76, 0x62, 0x5c, 0x45, 0xac, 0x67, 0x22, 0xa6, 0x19, 0x28, 0x72, 0x06, 0xeb,
-	0xb3, 0x4e, 0x2b, 0xa0, 0xca, 0x15, 0x30, 0x7e, 0xae, 0x41, 0x7f, 0x95, 0xdd, 0x28, 0x8c, 0x2e,
-	0x57, 0x72, 0xbf, 0x2b, 0x0c, 0xdc, 0x85, 0x13, 0xed, 0x90, 0xd9, 0x8e, 0xeb, 0xf5, 0xbb, 0x7f,
-	0xa4, 0x7a, 0x23, 0x3b, 0x73, 0x1f, 0x94, 0x73, 0x12, 0x89, 0xf5, 0x99, 0xfd, 0x5c, 0x7f, 0xa0,
-	0x36, 0x5e, 0xf1, 0x43, 0xd5, 0xc2, 0x89, 0x76, 0x61, 0xfc, 0xdb, 0x0a, 0xdd, 0x36, 0x5b, 0xed,
-	0xdb, 0x01, 0xdb, 0x98, 0x9d, 0x90, 0x64, 0xd4, 0x02, 0x74, 0x2f, 0x7e, 0x2a, 0x8b, 0x5b, 0x7a,
-	0x6d, 0xa8, 0x64, 0x76, 0x17, 0xd9, 0x17, 0x4b, 0x8e, 0xc6, 0x43, 0x81, 0xff, 0x4a, 0xb9, 0x81,
-	0x78, 0x1b, 0xcc, 0xc4, 0x19, 0xc8, 0x71, 0x56, 0x9e, 0x38, 0x75, 0x33, 0x5e, 0x37, 0x44, 0x42,
-	0x7c, 0x8b, 0x78, 0x0f, 0x96, 0x00, 0xfe, 0x4a, 0x9b, 0x67, 0x52, 0xce, 0x7a, 0x99, 0xb8, 0x34,
-	0x75, 0xbc, 0xd9, 0xf9, 0x84, 0x9d, 0x10, 0x7b, 0xf1, 0xf1, 0x31, 0x31, 0xb0, 0x0a, 0xda, 0x41,
-	0x42, 0x0e, 0x7e, 0x56, 0x6b, 0x61, 0xd9, 0xf4, 0xaf, 0x1f, 0xd8, 0xfa, 0x39, 0xea, 0x04, 0xe8,
-	0x04, 0x9a, 0x9e, 0x4c, 0xc0, 0x23, 0x99, 0x80, 0xa9, 0x23, 0x16, 0x5e, 0xc6, 0x53, 0xe8, 0x60,
-	0x72, 0xc1, 0x32, 0xe6, 0x1b, 0x24, 0xfa, 0x08, 0x1a, 0xac, 0x7a, 0x6b, 0xde, 0xc3, 0x31, 0x77,
-	0x28, 0xa7, 0xa0, 0xf1, 0x03, 0x3f, 0xab, 0x48, 0xaf, 0x81, 0x1f, 0x43, 0x33, 0x7e, 0x1d, 0x16,
-	0x11, 0xab, 0xdf, 0xbd, 0xb1, 0x70, 0xac, 0x88, 0xfc, 0x18, 0xda, 0x98, 0x5c, 0x8c, 0xc2, 0x28,
-	0xce, 0xf3, 0x3a, 0x28, 0xd3, 0x30, 0x12, 0x41, 0x4b, 0x5e, 0xe0, 0x31, 0xeb, 0x16, 0x3c, 0x4a,
-	0x43, 0xf1, 0x86, 0xf1, 0x57, 0x03, 0xe0, 0x89, 0x37, 0x33, 0xd3, 0x65, 0x9b, 0x63, 0x92, 0x95,
-	0x9b, 0x64, 0xfa, 0x4f, 0x6e, 0x3b, 0xcb, 0x4d, 0xb9, 0x84, 0x72, 0xd3, 0x61, 0x9f, 0x2e, 0x1f,
-	0xbb, 0x16, 0x59, 0x0a, 0xb1, 0xad, 0x9a, 0xe8, 0x03, 0x00, 0x3b, 0x78, 0x64, 0xbb, 0x76, 0x70,
-	0x46, 0x2c, 0xae, 0xb4, 0x16, 0x96, 0x2c, 0x59, 0xa1, 0x5e, 0xd9, 0x20, 0xd4, 0x41, 0x41, 0xa8,
-	0x77, 0x7e, 0x53, 0x40, 0xe5, 0x25, 0x47, 0x9f, 0xc3, 0xe0, 0x81, 0x4f, 0x4c, 0x4a, 0xb0, 0xf9,
-	0x32, 0xb9, 0x14, 0x4c, 0x96, 0xa8, 0x4c, 0x68, 0xc7, 0x07, 0xc2, 0xf8, 0xbd, 0x1b, 0xd8, 0xcf,
-	0xdd, 0xc9, 0xd2, 0xd8, 0x43, 0x9f, 0xc1, 0x95, 0xec, 0x78, 0x26, 0x88, 0x25, 0x2a, 0x11, 0x40,
-	0xd9, 0xe8, 0x47, 0x70, 0x94, 0x1d, 0x1d, 0xab, 0x6f, 0xb2, 0x44, 0xd5, 0xb2, 0x2c, 0x8f, 0xa3,
-	0x17, 0xb2, 0xe0, 0xf7, 0xab, 0xc9, 0x12, 0x55, 0xfd, 0x3b, 0x55, 0x16, 0xe7, 0x6b, 0x38, 0x2e,
-	0x56, 0x23, 0xbe, 0x68, 0x95, 0xe4, 0x94, 0x76, 0x96, 0xc5, 0x1a, 0xc3, 0xb5, 0xb2, 0x6f, 0x8b,
-	0xeb, 0x53, 0xf9, 0xef, 0x55, 0x49, 0xa4, 0x69, 0x93, 0xff, 0x51, 0x78, 0xf7, 0x9f, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0xe0, 0xb9, 0xad, 0xb0, 0x51, 0x1c, 0x00, 0x00,
+func init() { proto.RegisterFile("trade.proto", fileDescriptor_trade_26193d59cb66e2c3) }
+
+var fileDescriptor_trade_26193d59cb66e2c3 = []byte{
+	// 1589 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0x4d, 0x6f, 0xdb, 0x46,
+	0x13, 0xb6, 0x44, 0x51, 0x16, 0x47, 0x96, 0x6c, 0x6f, 0x1c, 0xbf, 0x8c, 0xf1, 0xe2, 0x85, 0x41,
+	0x04, 0x6f, 0x93, 0x20, 0x30, 0xd0, 0x04, 0x01, 0x0a, 0xb4, 0x68, 0x11, 0x25, 0x4d, 0x9d, 0x26,
+	0x41, 0x0b, 0x5a, 0x45, 0x7b, 0xa5, 0xc4, 0x8d, 0x4d, 0x88, 0x22, 0x65, 0x7e, 0xd8, 0xe2, 0x3f,
+	0x28, 0xd0, 0x7f, 0xd0, 0x1e, 0x7a, 0xe9, 0xad, 0xe8, 0x2d, 0x40, 0x11, 0xf4, 0xaf, 0xf4, 0x58,
+	0xf4, 0xd8, 0x53, 0x7f, 0x40, 0xb1, 0xc3, 0x15, 0xb9, 0xfc, 0xd2, 0x07, 0x90, 0xa2, 0x4a, 0xd2,
+	0x1b, 0x67, 0x76, 0x76, 0x38, 0x9a, 0xe7, 0x99, 0xdd, 0xd9, 0xa5, 0xa0, 0x1d, 0x78, 0x86, 0x49,
+	0x8f, 0x26, 0x9e, 0x1b, 0xb8, 0x44, 0x0e, 0xa2, 0x09, 0xf5, 0x0f, 0x76, 0x03, 0xcf, 0x70, 0x7c,
+	0x63, 0x18, 0x58, 0xae, 0x13, 0x8f, 0x68, 0x7f, 0xd6, 0x41, 0xee, 0x33, 0x4b, 0x72, 0x17, 0x14,
+	0x9f, 0xda, 0xf6, 0x53, 0x6b, 0x6c, 0x05, 0x6a, 0xed, 0xb0, 0x76, 0xa3, 0x7d, 0xe7, 0xca, 0x11,
+	0xce, 0x3b, 0x42, 0x83, 0x47, 0xae, 0x77, 0x42, 0x6d, 0xfb, 0x78, 0x43, 0x4f, 0xed, 0xc8, 0x1d,
+	0x50, 0x06, 0x61, 0xf4, 0xcc, 0xf0, 0x46, 0x34, 0x50, 0xeb, 0x38, 0x89, 0xe4, 0x26, 0xf5, 0xc2,
+	0x88, 0xcd, 0x49, 0xcc, 0xc8, 0xfb, 0x00, 0x1e, 0xbd, 0x70, 0x47, 0x94, 0xb9, 0x53, 0x25, 0x9c,
+	0x74, 0x2d, 0x37, 0x49, 0x4f, 0x0c, 0x8e, 0x37, 0x74, 0xc1, 0x9c, 0xdc, 0x83, 0xd6, 0x20, 0x8c,
+	0xe2, 0x20, 0x65, 0x9c, 0xfa, 0x9f, 0xe2, 0xfb, 0x70, 0xf8, 0x78, 0x43, 0x4f, 0x4c, 0xd9, 0x3b,
+	0x59, 0xd0, 0x3c, 0xd0, 0x66, 0xe9, 0x3b, 0x4f, 0x12, 0x03, 0xf6, 0xce, 0xd4, 0x9c, 0xbc, 0x07,
+	0x4a, 0x1c, 0x41, 0x2f, 0x8c, 0xd4, 0x4d, 0x9c, 0xab, 0x96, 0xc6, 0xcb, 0x7f, 0x6a, 0x62, 0x4c,
+	0xba, 0x50, 0x0f, 0x22, 0xb5, 0x71, 0x58, 0xbb, 0x21, 0xeb, 0xf5, 0x20, 0xea, 0x6d, 0x82, 0x7c,
+	0x61, 0xd8, 0x21, 0xd5, 0xbe, 0x96, 0x60, 0x4b, 0x7c, 0x2f, 0x39, 0x84, 0x76, 0xe0, 0x8e, 0xa8,
+	0x73, 0x12, 0x8d, 0x07, 0xae, 0x8d, 0xf9, 0x57, 0x74, 0x51, 0x45, 0x6e, 0xc3, 0xae, 0x31, 0x76,
+	0x43, 0x27, 0xf8, 0x9c, 0x7a, 0x3d, 0xd7, 0xf0, 0x4c, 0xdb, 0x8d, 0x53, 0x2e, 0xe9, 0xc5, 0x01,
+	0xe6, 0x6f, 0x6c, 0x39, 0x89, 0x9d, 0x84, 0x76, 0xa2, 0x8a, 0xdc, 0x82, 0x9d, 0x89, 0x67, 0x0d,
+	0xa9, 0xe8, 0xae, 0x81, 0x66, 0x05, 0x3d, 0xb9, 0x0e, 0x9d, 0xc0, 0x0d, 0x0c, 0x3b, 0x31, 0x94,
+	0xd1, 0x30, 0xab, 0x24, 0xff, 0x05, 0xc5, 0x0f, 0x0c, 0x2f, 0x08, 0xac, 0x31, 0xc5, 0x1c, 0x4b,
+	0x7a, 0xaa, 0x20, 0x07, 0xd0, 0xf2, 0x03, 0x77, 0x82, 0x83, 0x9b, 0x38, 0x98, 0xc8, 0x6c, 0xe6,
+	0xd0, 0x73, 0x2f, 0xcd, 0xe7, 0xa1, 0x63, 0xaa, 0xad, 0xc3, 0xda, 0x8d, 0x96, 0x9e, 0x2a, 0xd8,
+	0xa8, 0xe1, 0xfb, 0x34, 0xf8, 0x78, 0x4a, 0x87, 0xaa, 0x82, 0x99, 0x49, 0x15, 0x6c, 0x14, 0xe3,
+	0xc5, 0x51, 0x88, 0x47, 0x13, 0x05, 0xcb, 0x03, 0x0a, 0x3c, 0xaf, 0xed, 0x38, 0xaf, 0x82, 0x4a,
+	0xfb, 0x04, 0xda, 0x02, 0x75, 0xc8, 0x3e, 0x34, 0x19, 0xf4, 0x8f, 0x1f, 0x72, 0x0c, 0xb8, 0xc4,
+	0x1c, 0x0d, 0xf8, 0x0f, 0x7d, 0xe0, 0xcc, 0x12, 0x2f, 0xaa, 0xb4, 0xdb, 0x40, 0x8a, 0xf4, 0xad,
+	0xf2, 0xa7, 0xbd, 0xa8, 0xc3, 0x4e, 0x9e, 0xb2, 0x6f, 0x0a, 0x0b, 0x52, 0xb4, 0x9a, 0x73, 0xd1,
+	0xda, 0x5c, 0x80, 0x56, 0xab, 0x88, 0xd6, 0xd3, 0x34, 0xc9, 0x69, 0xbd, 0x92, 0x3d, 0x90, 0x07,
+	0x61, 0x94, 0xe4, 0x38, 0x16, 0x96, 0x80, 0xec, 0x26, 0xec, 0x16, 0x2a, 0xb8, 0xdc, 0x99, 0xf6,
+	0x4d, 0x03, 0x14, 0xf6, 0xc6, 0xcf, 0x3c, 0x93, 0x7a, 0x4b, 0x00, 0xa5, 0xc2, 0xa6, 0x61, 0x9a,
+	0x1e, 0xf5, 0x7d, 0x7c, 0xb1, 0xa2, 0xcf, 0xc4, 0x72, 0x08, 0xa5, 0x25, 0x21, 0x6c, 0x2c, 0x07,
+	0xa1, 0xbc, 0x2c, 0x84, 0xcd, 0x32, 0x08, 0x35, 0xd8, 0xf2, 0x5d, 0xdb, 0x4c, 0x8c, 0xe2, 0x72,
+	0xcd, 0xe8, 0xb2, 0xc5, 0xde, 0x9a, 0x57, 0xec, 0xca, 0xbc, 0x62, 0x87, 0x7c, 0xb1, 0xa7, 0xf5,
+	0xd2, 0xce, 0xd4, 0x1f, 0xd3, 0x07, 0x46, 0x10, 0xfa, 0xea, 0x16, 0x2e, 0xa7, 0x5c, 0x62, 0xfa,
+	0x33, 0x6a, 0x9d, 0x9e, 0x05, 0x6a, 0x07, 0xdf, 0xc3, 0xa5, 0x2c, 0x0d, 0xbb, 0x73, 0x69, 0xb8,
+	0xbd, 0x80, 0x86, 0x3b, 0x45, 0x1a, 0xbe, 0x94, 0xa0, 0x33, 0xab, 0xda, 0xb7, 0x81, 0x11, 0xff,
+	0x87, 0xee, 0xc0, 0x0d, 0x4f, 0xcf, 0x82, 0x1c, 0x27, 0x72, 0xda, 0xb4, 0x76, 0x5a, 0x62, 0x21,
+	0xa6, 0xd8, 0x29, 0x15, 0xd8, 0x41, 0x35, 0x76, 0xed, 0xb9, 0xd8, 0x6d, 0x2d, 0xc0, 0xae, 0x53,
+	0xc4, 0xee, 0x77, 0x09, 0xba, 0x3a, 0x1d, 0x52, 0x6b, 0x12, 0xf4, 0xc2, 0xa8, 0x67, 0xf8, 0x74,
+	0x09, 0xf0, 0xf6, 0x40, 0x76, 0x2f, 0x1d, 0xea, 0x71, 0xe8, 0x62, 0xa1, 0x1a, 0x38, 0xe5, 0xd5,
+	0x02, 0xa7, 0xac, 0x05, 0x70, 0x8a, 0x08, 0x1c, 0x2f, 0x52, 0xc8, 0x17, 0x69, 0x30, 0x3d, 0x36,
+	0xfc, 0xb3, 0x59, 0xf1, 0xc6, 0x92, 0x00, 0xf4, 0x56, 0x35, 0xd0, 0x9d, 0xb9, 0x40, 0x77, 0x17,
+	0x00, 0xbd, 0x5d, 0x04, 0xfa, 0x97, 0x06, 0x6c, 0x73, 0xa0, 0xd9, 0xca, 0xfd, 0x86, 0x23, 0xbd,
+	0xfe, 0x8b, 0x76, 0xca, 0x9f, 0x84, 0x6d, 0x9d, 0x1c, 0xdb, 0x38, 0x7b, 0xba, 0x15, 0xec, 0xd9,
+	0xae, 0x66, 0xcf, 0xce, 0x5c, 0xf6, 0xec, 0x2e, 0x60, 0x0f, 0x29, 0xb2, 0xa7, 0x07, 0x57, 0x39,
+	0x79, 0xb0, 0x45, 0xe8, 0x25, 0xe7, 0x97, 0x9b, 0xd0, 0x18, 0x18, 0x3e, 0xe5, 0x67, 0xa4, 0xab,
+	0xfc, 0x24, 0x90, 0x5d, 0x51, 0x74, 0x34, 0xd1, 0xee, 0xc3, 0x5e, 0xce, 0x47, 0xdc, 0xe7, 0xad,
+	0xe0, 0xa2, 0x18, 0x46, 0xdc, 0xa9, 0xac, 0xe2, 0xe3, 0x41, 0xd6, 0xc7, 0x49, 0x72, 0x7c, 0xbb,
+	0x95, 0xf1, 0xb1, 0x9f, 0xf5, 0x31, 0xab, 0x19, 0xee, 0xe4, 0x23, 0xd8, 0x15, 0x06, 0x78, 0x2e,
+	0x56, 0x71, 0xf0, 0x10, 0xf6, 0xf3, 0x51, 0xf0, 0x9f, 0xb2, 0x8a, 0x97, 0xef, 0x6b, 0xd0, 0xd1,
+	0xe9, 0xf9, 0x7d, 0xd3, 0xf4, 0xee, 0x33, 0xac, 0x7d, 0x42, 0xa0, 0xc1, 0x36, 0x52, 0x5e, 0xcb,
+	0xf8, 0x2c, 0x10, 0xaf, 0x9e, 0xd9, 0x71, 0xf6, 0x40, 0xc6, 0x5a, 0x57, 0xa5, 0x43, 0x89, 0x11,
+	0x0f, 0x05, 0x46, 0x15, 0xd3, 0xf2, 0x28, 0x9e, 0x8b, 0xf9, 0x69, 0x2d, 0x55, 0xb0, 0x39, 0x43,
+	0x56, 0xe0, 0x58, 0x9f, 0xb2, 0x1e, 0x0b, 0x6c, 0x37, 0x7f, 0xee, 0xb9, 0xe3, 0x27, 0x34, 0xe2,
+	0x4d, 0xee, 0x4c, 0xd4, 0xbe, 0xab, 0xb1, 0x4c, 0x9d, 0xf7, 0x71, 0x4d, 0x59, 0xad, 0x63, 0x9c,
+	0x79, 0xac, 0x67, 0x3c, 0xa6, 0x11, 0x48, 0x62, 0x04, 0xf3, 0xa3, 0x4e, 0x33, 0x20, 0x8b, 0x19,
+	0xd0, 0xbe, 0xad, 0xc1, 0xce, 0x2c, 0xba, 0x5e, 0x18, 0xad, 0x57, 0x70, 0x3f, 0x4b, 0x0c, 0xdc,
+	0x89, 0x1d, 0xad, 0x10, 0xd9, 0x8a, 0xeb, 0xf5, 0x9b, 0xdf, 0x52, 0xbd, 0x92, 0x9d, 0x79, 0x07,
+	0xa4, 0x11, 0x8d, 0xf8, 0xfa, 0xcc, 0x1e, 0xe7, 0x37, 0xd4, 0xda, 0x0b, 0x6c, 0xaa, 0x26, 0x76,
+	0xb4, 0x0a, 0xe3, 0x5f, 0x57, 0xe8, 0x96, 0xd9, 0x6a, 0x5f, 0x0f, 0xd8, 0x8e, 0x61, 0x3b, 0x8b,
+	0x9a, 0x4f, 0xee, 0xc5, 0x57, 0x65, 0xb1, 0xa4, 0xd6, 0x0e, 0xa5, 0xcc, 0xee, 0x22, 0xda, 0xea,
+	0x82, 0xa1, 0xf6, 0x10, 0xba, 0x99, 0xca, 0xf5, 0xf9, 0xdd, 0x60, 0xc6, 0xcf, 0x9e, 0xe8, 0x67,
+	0x66, 0xa9, 0xa7, 0x66, 0xda, 0xcb, 0x06, 0x0f, 0x08, 0xb7, 0x88, 0xb7, 0x60, 0x09, 0xc0, 0x5b,
+	0xda, 0x3c, 0x93, 0x72, 0xda, 0x75, 0xe2, 0xd2, 0xc0, 0x76, 0x87, 0xa3, 0x3e, 0xeb, 0x10, 0xbb,
+	0x68, 0x9c, 0x2a, 0x58, 0x06, 0x2d, 0x3f, 0x21, 0x07, 0xf6, 0x6a, 0x2d, 0x5d, 0x54, 0xfd, 0xed,
+	0x0d, 0xdb, 0x4e, 0x8e, 0x3a, 0x3e, 0x39, 0x82, 0xa6, 0x2b, 0x12, 0x70, 0x5f, 0x24, 0x60, 0x6a,
+	0xa8, 0x73, 0x2b, 0xed, 0x19, 0x6c, 0xe9, 0xf4, 0x9c, 0x45, 0x8c, 0x1b, 0x24, 0x79, 0x07, 0x1a,
+	0x2c, 0x7b, 0x73, 0xee, 0xc3, 0x75, 0x34, 0x28, 0xa7, 0xa0, 0xf6, 0x15, 0xf6, 0x2a, 0xc2, 0x6d,
+	0xe0, 0xbb, 0xd0, 0x8c, 0x6f, 0x87, 0xd5, 0x5a, 0xe9, 0x1d, 0x74, 0x6a, 0xaa, 0x73, 0xc3, 0x0a,
+	0xcf, 0x8f, 0xa1, 0xad, 0xd3, 0xf3, 0x5e, 0x18, 0xc5, 0x71, 0x5e, 0x07, 0x69, 0x10, 0x46, 0x6a,
+	0xad, 0xea, 0x06, 0x5e, 0x67, 0xc3, 0x9c, 0x47, 0xa9, 0x2b, 0x14, 0xb4, 0x3f, 0x1a, 0x00, 0x4f,
+	0xdd, 0xa1, 0x91, 0x2e, 0xdb, 0x88, 0x49, 0xb6, 0xdc, 0x04, 0xd5, 0xbf, 0xe5, 0xb6, 0x72, 0xb9,
+	0x49, 0x6b, 0x58, 0x6e, 0x2a, 0x6c, 0x06, 0xd3, 0xc7, 0x8e, 0x49, 0xa7, 0xbc, 0xd8, 0x66, 0x22,
+	0xf9, 0x1f, 0x80, 0xe5, 0x3f, 0xb2, 0x1c, 0xcb, 0x3f, 0xa3, 0x26, 0x56, 0x5a, 0x4b, 0x17, 0x34,
+	0xd9, 0x42, 0xbd, 0xb2, 0xa0, 0x50, 0xf7, 0x8a, 0x85, 0xfa, 0x53, 0x1d, 0xcb, 0x02, 0xe9, 0xf9,
+	0xc4, 0xb6, 0x1c, 0x9a, 0x8d, 0xb4, 0x96, 0x8f, 0x34, 0xb7, 0x01, 0xd4, 0x8b, 0x1b, 0x40, 0x26,
+	0x22, 0x69, 0x41, 0x44, 0x8d, 0x42, 0x44, 0x0c, 0x93, 0x09, 0xf5, 0x2c, 0xd7, 0xe4, 0x67, 0x70,
+	0x2e, 0x25, 0xe7, 0xe5, 0x7e, 0xfe, 0x8b, 0x06, 0x22, 0xa0, 0xc2, 0x26, 0x75, 0xcc, 0x7e, 0xfa,
+	0x41, 0x63, 0x26, 0x8a, 0xdd, 0x72, 0xab, 0xa2, 0x5b, 0x56, 0x2a, 0xbb, 0x65, 0xc8, 0x75, 0xcb,
+	0xda, 0xaf, 0x75, 0x80, 0xd7, 0x3e, 0x59, 0x04, 0x1a, 0xee, 0x84, 0x3a, 0x3c, 0x53, 0xf8, 0xcc,
+	0x74, 0x67, 0xd6, 0xe9, 0x19, 0xbf, 0x89, 0xc0, 0x67, 0x56, 0x06, 0xb6, 0x7b, 0xc9, 0xef, 0x1f,
+	0xd8, 0x23, 0xa6, 0xcc, 0x76, 0x7d, 0xca, 0x2f, 0x09, 0x63, 0x81, 0x45, 0x71, 0xe1, 0xda, 0xe1,
+	0x98, 0xe2, 0x6e, 0x26, 0xe9, 0x5c, 0x62, 0x97, 0x18, 0x41, 0xe8, 0x39, 0xee, 0x05, 0xf5, 0x78,
+	0x81, 0x25, 0x72, 0x9a, 0xfc, 0x8e, 0x90, 0x7c, 0xed, 0x4b, 0x71, 0xdf, 0xc0, 0x14, 0xfb, 0xe4,
+	0x26, 0x34, 0x47, 0xf8, 0xc4, 0xf7, 0x8d, 0x5d, 0x71, 0x49, 0x45, 0x1b, 0x9d, 0x1b, 0x54, 0x9f,
+	0x8c, 0xb4, 0x1f, 0x6a, 0xd0, 0x9d, 0xf1, 0xbc, 0x6f, 0x0d, 0x47, 0xd4, 0xfb, 0xc7, 0xb1, 0x23,
+	0xd0, 0xc0, 0xeb, 0x9d, 0x78, 0x21, 0xc5, 0x67, 0xed, 0xb7, 0xba, 0x98, 0x80, 0x35, 0x09, 0x34,
+	0x43, 0x26, 0x79, 0x4e, 0xe5, 0x35, 0xb3, 0x95, 0xb7, 0xb6, 0x34, 0xbb, 0xf3, 0xa3, 0x04, 0x32,
+	0x6e, 0x34, 0xe4, 0x43, 0xd8, 0x7b, 0xe0, 0x51, 0x23, 0xa0, 0xba, 0x71, 0x99, 0x5c, 0x85, 0xf4,
+	0xa7, 0xa4, 0xac, 0xbd, 0x38, 0xd8, 0xe6, 0xca, 0x2f, 0x1c, 0xdf, 0x3a, 0x75, 0xfa, 0x53, 0x6d,
+	0x83, 0x7c, 0x00, 0x57, 0xb2, 0xf3, 0x59, 0x1b, 0x30, 0x25, 0x25, 0xdb, 0x7e, 0xd9, 0xec, 0x47,
+	0xb0, 0x9f, 0x9d, 0x1d, 0xf7, 0x1c, 0xfd, 0x29, 0xa9, 0x6e, 0x46, 0xca, 0xfd, 0xa8, 0x85, 0x28,
+	0xf0, 0x56, 0xa9, 0x3f, 0x25, 0x55, 0xdf, 0xe4, 0xcb, 0xfc, 0x7c, 0x0a, 0x07, 0xc5, 0x6c, 0xc4,
+	0xd7, 0x4b, 0x25, 0x31, 0xa5, 0x83, 0x65, 0xbe, 0x8e, 0xe1, 0x5a, 0xd9, 0x6f, 0x8b, 0xf3, 0x53,
+	0xf9, 0xcd, 0xbe, 0xc4, 0xd3, 0xa0, 0x89, 0x7f, 0x8f, 0xb8, 0xfb, 0xd7, 0x00, 0xa0, 0x2b, 0x9e,
+	0x5c, 0x47, 0x21, 0x00, 0x00,
 }
@@ -10,6 +10,7 @@ import (
 	"math/rand"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/33cn/chain33/common"
@@ -41,6 +42,10 @@ func ValCmd() *cobra.Command {
 		GetBlockInfoCmd(),
 		GetNodeInfoCmd(),
 		AddNodeCmd(),
+		ProposeCmd(),
+		ApproveCmd(),
+		GetProposalCmd(),
+		ListProposalsCmd(),
 		CreateCmd(),
 	)
 	return cmd
@@ -186,6 +191,178 @@ func addNode(cmd *cobra.Command, args []string) {
 	ctx.RunWithoutMarshal()
 }
 
+// ProposeCmd propose validator changes
+func ProposeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "propose",
+		Short: "Propose tendermint validator changes, power 0 to remove validator",
+		Run:   propose,
+	}
+	addProposeFlags(cmd)
+	return cmd
+}
+
+func addProposeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("pubkeys", "p", "", "public keys, separated by ','")
+	cmd.MarkFlagRequired("pubkeys")
+	cmd.Flags().StringP("powers", "w", "", "voting powers, separated by ','")
+	cmd.MarkFlagRequired("powers")
+	cmd.Flags().Int64P("expire", "e", 0, "expire height of the proposal, default current height + 10000")
+	cmd.Flags().StringP("key", "k", "priv_validator.json", "priv validator file of the proposer")
+}
+
+func propose(cmd *cobra.Command, args []string) {
+	pubkeys, _ := cmd.Flags().GetString("pubkeys")
+	powers, _ := cmd.Flags().GetString("powers")
+	expire, _ := cmd.Flags().GetInt64("expire")
+
+	pubkeyArr := strings.Split(pubkeys, ",")
+	powerArr := strings.Split(powers, ",")
+	if len(pubkeyArr) != len(powerArr) {
+		fmt.Fprintln(os.Stderr, "the number of pubkeys and powers must be equal")
+		return
+	}
+	propose := &vt.ValNodePropose{ExpireHeight: expire}
+	for i, pubkey := range pubkeyArr {
+		pubkeybyte, err := hex.DecodeString(strings.TrimSpace(pubkey))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		power, err := strconv.ParseInt(strings.TrimSpace(powerArr[i]), 10, 64)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		propose.Nodes = append(propose.Nodes, &vt.ValNode{PubKey: pubkeybyte, Power: power})
+	}
+	value := &vt.ValNodeAction_Propose{Propose: propose}
+	action := &vt.ValNodeAction{Value: value, Ty: vt.ValNodeActionPropose}
+	sendValidatorTx(cmd, action)
+}
+
+// ApproveCmd approve validator proposal
+func ApproveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approve",
+		Short: "Approve tendermint validator proposal",
+		Run:   approve,
+	}
+	addApproveFlags(cmd)
+	return cmd
+}
+
+func addApproveFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("id", "i", "", "proposal id")
+	cmd.MarkFlagRequired("id")
+	cmd.Flags().StringP("key", "k", "priv_validator.json", "priv validator file of the approver")
+}
+
+func approve(cmd *cobra.Command, args []string) {
+	id, _ := cmd.Flags().GetString("id")
+	value := &vt.ValNodeAction_Approve{Approve: &vt.ValNodeApprove{ProposalID: id}}
+	action := &vt.ValNodeAction{Value: value, Ty: vt.ValNodeActionApprove}
+	sendValidatorTx(cmd, action)
+}
+
+// sendValidatorTx 提案和批准交易使用验证者的私钥签名
+func sendValidatorTx(cmd *cobra.Command, action *vt.ValNodeAction) {
+	title, _ := cmd.Flags().GetString("title")
+	cfg := types.GetCliSysParam(title)
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	key, _ := cmd.Flags().GetString("key")
+
+	if _, err := os.Stat(key); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	if err := initCryptoImpl(); err != nil {
+		return
+	}
+	privValidator := ttypes.LoadPrivValidatorFS(key)
+	tx := &types.Transaction{Execer: []byte(vt.ValNodeX), Payload: types.Encode(action), Fee: 0}
+	err := tx.SetRealFee(cfg.GInt("MinFee"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	tx.Nonce = random.Int63()
+	tx.To = address.ExecAddress(vt.ValNodeX)
+	tx.Sign(types.ED25519, privValidator.PrivKey)
+
+	txHex := types.Encode(tx)
+	data := hex.EncodeToString(txHex)
+	params := rpctypes.RawParm{
+		Data: data,
+	}
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.SendTransaction", params, nil)
+	ctx.RunWithoutMarshal()
+}
+
+// GetProposalCmd get validator proposal
+func GetProposalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proposal",
+		Short: "Get tendermint validator proposal",
+		Run:   getProposal,
+	}
+	cmd.Flags().StringP("id", "i", "", "proposal id")
+	cmd.MarkFlagRequired("id")
+	return cmd
+}
+
+func getProposal(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	id, _ := cmd.Flags().GetString("id")
+	req := &vt.ReqValNodeProposal{
+		ProposalID: id,
+	}
+	params := rpctypes.Query4Jrpc{
+		Execer:   vt.ValNodeX,
+		FuncName: "GetValNodeProposal",
+		Payload:  types.MustPBToJSON(req),
+	}
+
+	var res vt.ValNodeProposal
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &res)
+	ctx.Run()
+}
+
+// ListProposalsCmd list validator proposals
+func ListProposalsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proposals",
+		Short: "List tendermint validator proposals",
+		Run:   listProposals,
+	}
+	cmd.Flags().Int32P("status", "s", 0, "proposal status, 0:all 1:pending 2:approved 3:expired")
+	cmd.Flags().Int32P("count", "c", 20, "count of the proposals scanned in one page, max 100")
+	cmd.Flags().StringP("primary", "p", "", "primary key returned by the previous page")
+	return cmd
+}
+
+func listProposals(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	status, _ := cmd.Flags().GetInt32("status")
+	count, _ := cmd.Flags().GetInt32("count")
+	primary, _ := cmd.Flags().GetString("primary")
+	req := &vt.ReqValNodeProposals{
+		Status:     status,
+		Count:      count,
+		PrimaryKey: primary,
+	}
+	params := rpctypes.Query4Jrpc{
+		Execer:   vt.ValNodeX,
+		FuncName: "ListValNodeProposals",
+		Payload:  types.MustPBToJSON(req),
+	}
+
+	var res vt.ValNodeProposals
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &res)
+	ctx.Run()
+}
+
 func getprivkey() (crypto.PrivKey, error) {
 	key := "CC38546E9E659D15E6B4893F0AB32A06D103931A8230B0BDE71459D2B27D6944"
 	cr, err := crypto.New(types.GetSignName("", types.SECP256K1))
@@ -0,0 +1,90 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"testing"
+
+	apimock "github.com/33cn/chain33/client/mocks"
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	pty "github.com/33cn/plugin/plugin/dapp/valnode/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testChainID = "test-chain"
+
+func signVote(priv crypto.PrivKey, chainID string, index int32, height int64, blockHash []byte) *ttypes.Vote {
+	vote := &ttypes.Vote{Vote: &pty.Vote{
+		ValidatorAddress: ttypes.GenAddressByPubKey(priv.PubKey()),
+		ValidatorIndex:   index,
+		Height:           height,
+		Type:             uint32(ttypes.VoteTypePrevote),
+		BlockID:          &pty.BlockID{Hash: blockHash},
+	}}
+	vote.Signature = priv.Sign(ttypes.SignBytes(chainID, vote)).Bytes()
+	return vote
+}
+
+func evidenceAction(priv crypto.PrivKey, index int32, height int64, chainID string) *pty.ValNodeAction {
+	ev := ttypes.NewDuplicateVoteEvidence(priv.PubKey().Bytes(), chainID,
+		signVote(priv, chainID, index, height, []byte("block-a")), signVote(priv, chainID, index, height, []byte("block-b")))
+	return &pty.ValNodeAction{Value: &pty.ValNodeAction_Evidence{Evidence: ev.DuplicateVoteEvidence}, Ty: pty.ValNodeActionEvidence}
+}
+
+func TestValNodeEvidence(t *testing.T) {
+	env := newTestEnv(t)
+	keys := genKeys(t, 3)
+
+	// 区块5的基础交易中记录了当时的验证者集合，之后keys[1]被移除
+	valSet := &pty.ValidatorSet{}
+	for _, key := range keys[:2] {
+		valSet.Validators = append(valSet.Validators, &pty.Validator{
+			Address:     ttypes.GenAddressByPubKey(key.PubKey()),
+			PubKey:      key.PubKey().Bytes(),
+			VotingPower: 10,
+		})
+	}
+	state := &pty.State{
+		ChainID:         testChainID,
+		Validators:      valSet,
+		ConsensusParams: &pty.ConsensusParams{EvidenceParams: &pty.EvidenceParams{MaxAge: 5}},
+	}
+	blockInfo := &pty.TendermintBlockInfo{State: state}
+	action := &pty.ValNodeAction{Value: &pty.ValNodeAction_BlockInfo{BlockInfo: blockInfo}, Ty: pty.ValNodeActionBlockInfo}
+	block := &types.Block{Height: 5, Txs: []*types.Transaction{{Execer: []byte(pty.ValNodeX), Payload: types.Encode(action)}}}
+
+	api := new(apimock.QueueProtocolAPI)
+	api.On("GetConfig", mock.Anything).Return(env.exec.GetAPI().GetConfig(), nil)
+	api.On("GetBlocks", &types.ReqBlocks{Start: 5, End: 5}).Return(&types.BlockDetails{Items: []*types.BlockDetail{{Block: block}}}, nil)
+	api.On("GetBlocks", mock.Anything).Return(&types.BlockDetails{}, nil)
+	env.exec.SetAPI(api)
+	//在高度height执行证据交易
+	execEvidence := func(height int64, action *pty.ValNodeAction) error {
+		env.height = height - 1
+		return env.execTx(action, keys[2], types.SECP256K1)
+	}
+
+	assert.Equal(t, pty.ErrEvidenceHeight, execEvidence(6, evidenceAction(keys[0], 0, 6, testChainID)))
+	assert.Equal(t, types.ErrBlockNotFound, execEvidence(6, evidenceAction(keys[0], 0, 4, testChainID)))
+	assert.Equal(t, pty.ErrEvidenceChainID, execEvidence(6, evidenceAction(keys[0], 0, 5, "other-chain")))
+	// 双签者必须是当时的验证者，且序号一致
+	assert.Equal(t, pty.ErrEvidenceValidator, execEvidence(6, evidenceAction(keys[2], 0, 5, testChainID)))
+	assert.Equal(t, pty.ErrEvidenceValidator, execEvidence(6, evidenceAction(keys[1], 0, 5, testChainID)))
+	assert.Equal(t, pty.ErrEvidenceValidator, execEvidence(6, evidenceAction(keys[1], 2, 5, testChainID)))
+
+	assert.Nil(t, execEvidence(6, evidenceAction(keys[1], 1, 5, testChainID)))
+	assert.True(t, env.exec.isSlashed(keys[1].PubKey().Bytes()))
+	assert.Equal(t, pty.ErrEvidenceDuplicate, execEvidence(6, evidenceAction(keys[1], 1, 5, testChainID)))
+	ev := ttypes.NewDuplicateVoteEvidence(keys[1].PubKey().Bytes(), testChainID,
+		signVote(keys[1], testChainID, 1, 5, []byte("block-a")), signVote(keys[1], testChainID, 1, 5, []byte("block-c")))
+	action = &pty.ValNodeAction{Value: &pty.ValNodeAction_Evidence{Evidence: ev.DuplicateVoteEvidence}, Ty: pty.ValNodeActionEvidence}
+	assert.Equal(t, pty.ErrValNodeSlashed, execEvidence(6, action))
+
+	// 超过证据有效期
+	assert.Equal(t, pty.ErrEvidenceExpired, execEvidence(11, evidenceAction(keys[0], 0, 5, testChainID)))
+}
@@ -5,18 +5,187 @@
 package executor
 
 import (
+	"bytes"
+	"encoding/hex"
+
 	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
 	pty "github.com/33cn/plugin/plugin/dapp/valnode/types"
 )
 
 // Exec_Node method
 func (val *ValNode) Exec_Node(node *pty.ValNode, tx *types.Transaction, index int) (*types.Receipt, error) {
+	if val.governEnabled() {
+		return nil, pty.ErrValNodeNeedProposal
+	}
+	receipt := &types.Receipt{Ty: types.ExecOk, KV: nil, Logs: nil}
+	//重新加入验证者是恢复被罚没验证者的唯一方式
+	if node.GetPower() > 0 && val.isSlashed(node.GetPubKey()) {
+		receipt.KV = append(receipt.KV, &types.KeyValue{Key: CalcValNodeSlashKey(node.GetPubKey()), Value: nil})
+	}
+	return receipt, nil
+}
+
+// Exec_Evidence method
+func (val *ValNode) Exec_Evidence(ev *pty.DuplicateVoteEvidence, tx *types.Transaction, index int) (*types.Receipt, error) {
+	evidence := &ttypes.DuplicateVoteEvidence{DuplicateVoteEvidence: ev}
+	if err := evidence.Verify(); err != nil {
+		return nil, err
+	}
+	//双签高度的区块必须已经存在
+	if evidence.Height() >= val.GetHeight() {
+		return nil, pty.ErrEvidenceHeight
+	}
+	if err := val.checkEvidenceState(evidence); err != nil {
+		return nil, err
+	}
+	hash := evidence.Hash()
+	evidenceKey := CalcValNodeEvidenceKey(hash)
+	if value, err := val.GetStateDB().Get(evidenceKey); err == nil && len(value) > 0 {
+		return nil, pty.ErrEvidenceDuplicate
+	}
+	if val.isSlashed(ev.GetPubKey()) {
+		return nil, pty.ErrValNodeSlashed
+	}
+	clog.Info("slash validator", "evidence", evidence.String(), "height", val.GetHeight())
+
+	slash := &pty.ValNodeSlash{PubKey: ev.GetPubKey(), Height: val.GetHeight(), EvidenceHash: hash}
 	receipt := &types.Receipt{Ty: types.ExecOk, KV: nil, Logs: nil}
+	receipt.KV = append(receipt.KV, &types.KeyValue{Key: evidenceKey, Value: types.Encode(slash)})
+	receipt.KV = append(receipt.KV, &types.KeyValue{Key: CalcValNodeSlashKey(ev.GetPubKey()), Value: types.Encode(slash)})
 	return receipt, nil
 }
 
 // Exec_BlockInfo method
 func (val *ValNode) Exec_BlockInfo(blockInfo *pty.TendermintBlockInfo, tx *types.Transaction, index int) (*types.Receipt, error) {
 	receipt := &types.Receipt{Ty: types.ExecOk, KV: nil, Logs: nil}
+	if !val.governEnabled() || blockInfo.GetState().GetValidators() == nil {
+		return receipt, nil
+	}
+	//记录当前高度的验证者集合，用于验证提案和批准交易
+	valSet := &pty.ValNodes{}
+	for _, v := range blockInfo.GetState().GetValidators().GetValidators() {
+		valSet.Nodes = append(valSet.Nodes, &pty.ValNode{PubKey: v.GetPubKey(), Power: v.GetVotingPower()})
+	}
+	value := types.Encode(valSet)
+	if old, err := val.GetStateDB().Get(CalcValNodeSetKey()); err == nil && bytes.Equal(old, value) {
+		return receipt, nil
+	}
+	receipt.KV = append(receipt.KV, &types.KeyValue{Key: CalcValNodeSetKey(), Value: value})
 	return receipt, nil
 }
+
+// Exec_Propose method
+func (val *ValNode) Exec_Propose(propose *pty.ValNodePropose, tx *types.Transaction, index int) (*types.Receipt, error) {
+	if !val.governEnabled() {
+		return nil, types.ErrActionNotSupport
+	}
+	valSet, err := val.getValidatorSet()
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := getSigner(tx, valSet)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProposalNodes(propose.GetNodes()); err != nil {
+		return nil, err
+	}
+	expireHeight := propose.GetExpireHeight()
+	if expireHeight == 0 {
+		expireHeight = val.GetHeight() + pty.DefaultProposalPeriod
+	}
+	if expireHeight <= val.GetHeight() || expireHeight > val.GetHeight()+pty.MaxProposalPeriod {
+		return nil, pty.ErrProposalExpireHeight
+	}
+	if err := checkPowerChange(valSet, propose.GetNodes()); err != nil {
+		return nil, err
+	}
+	proposal := &pty.ValNodeProposal{
+		ProposalID:   hex.EncodeToString(tx.Hash()),
+		Nodes:        propose.GetNodes(),
+		Proposer:     pubKey,
+		Height:       val.GetHeight(),
+		ExpireHeight: expireHeight,
+	}
+	//提案者默认批准自己的提案
+	return val.approve(proposal, pubKey, valSet, pty.TyLogValNodePropose)
+}
+
+// Exec_Approve method
+func (val *ValNode) Exec_Approve(approve *pty.ValNodeApprove, tx *types.Transaction, index int) (*types.Receipt, error) {
+	if !val.governEnabled() {
+		return nil, types.ErrActionNotSupport
+	}
+	proposal, err := val.getProposal(approve.GetProposalID())
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Status != pty.ProposalStatusPending {
+		return nil, pty.ErrProposalStatus
+	}
+	if val.GetHeight() > proposal.ExpireHeight {
+		return nil, pty.ErrProposalExpired
+	}
+	valSet, err := val.getValidatorSet()
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := getSigner(tx, valSet)
+	if err != nil {
+		return nil, err
+	}
+	return val.approve(proposal, pubKey, valSet, pty.TyLogValNodeApprove)
+}
+
+//checkEvidenceState 使用双签高度区块中记录的共识状态检查链ID、证据有效期以及双签者是否为当时的验证者
+func (val *ValNode) checkEvidenceState(evidence *ttypes.DuplicateVoteEvidence) error {
+	state, err := val.getBlockState(evidence.Height())
+	if err != nil {
+		return err
+	}
+	if evidence.ChainID != state.GetChainID() {
+		return pty.ErrEvidenceChainID
+	}
+	if val.GetHeight()-evidence.Height() > state.GetConsensusParams().GetEvidenceParams().GetMaxAge() {
+		return pty.ErrEvidenceExpired
+	}
+	validators := state.GetValidators().GetValidators()
+	index := evidence.VoteA.GetValidatorIndex()
+	if index < 0 || int(index) >= len(validators) {
+		return pty.ErrEvidenceValidator
+	}
+	validator := validators[index]
+	if !bytes.Equal(validator.GetAddress(), evidence.Address()) || !bytes.Equal(validator.GetPubKey(), evidence.GetPubKey()) {
+		return pty.ErrEvidenceValidator
+	}
+	return nil
+}
+
+//getBlockState 获取指定高度区块基础交易中的共识状态，其中的验证者集合为该高度的验证者
+func (val *ValNode) getBlockState(height int64) (*pty.State, error) {
+	blocks, err := val.GetAPI().GetBlocks(&types.ReqBlocks{Start: height, End: height})
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks.GetItems()) != 1 || blocks.Items[0].GetBlock() == nil {
+		return nil, types.ErrBlockNotFound
+	}
+	txs := blocks.Items[0].Block.GetTxs()
+	if len(txs) == 0 {
+		return nil, types.ErrEmptyTx
+	}
+	var action pty.ValNodeAction
+	if err := types.Decode(txs[0].GetPayload(), &action); err != nil {
+		return nil, err
+	}
+	if action.GetTy() != pty.ValNodeActionBlockInfo || action.GetBlockInfo().GetState() == nil {
+		return nil, pty.ErrBlockInfoNotFound
+	}
+	return action.GetBlockInfo().GetState(), nil
+}
+
+func (val *ValNode) isSlashed(pubKey []byte) bool {
+	value, err := val.GetStateDB().Get(CalcValNodeSlashKey(pubKey))
+	return err == nil && len(value) > 0
+}
@@ -17,6 +17,14 @@ func (val *ValNode) ExecDelLocal_Node(node *pty.ValNode, tx *types.Transaction,
 	return set, nil
 }
 
+// ExecDelLocal_Evidence method
+func (val *ValNode) ExecDelLocal_Evidence(ev *pty.DuplicateVoteEvidence, tx *types.Transaction, receipt *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	set := &types.LocalDBSet{}
+	key := CalcValNodeUpdateHeightIndexKey(val.GetHeight(), index)
+	set.KV = append(set.KV, &types.KeyValue{Key: key, Value: nil})
+	return set, nil
+}
+
 // ExecDelLocal_BlockInfo method
 func (val *ValNode) ExecDelLocal_BlockInfo(blockInfo *pty.TendermintBlockInfo, tx *types.Transaction, receipt *types.ReceiptData, index int) (*types.LocalDBSet, error) {
 	set := &types.LocalDBSet{}
@@ -24,3 +32,21 @@ func (val *ValNode) ExecDelLocal_BlockInfo(blockInfo *pty.TendermintBlockInfo, t
 	set.KV = append(set.KV, &types.KeyValue{Key: key, Value: nil})
 	return set, nil
 }
+
+// ExecDelLocal_Propose method
+func (val *ValNode) ExecDelLocal_Propose(propose *pty.ValNodePropose, tx *types.Transaction, receipt *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	proposal, err := getProposalFromReceipt(receipt, pty.TyLogValNodePropose)
+	if err != nil {
+		return nil, err
+	}
+	return val.proposalLocalKV(proposal, index, true, true), nil
+}
+
+// ExecDelLocal_Approve method
+func (val *ValNode) ExecDelLocal_Approve(approve *pty.ValNodeApprove, tx *types.Transaction, receipt *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	proposal, err := getProposalFromReceipt(receipt, pty.TyLogValNodeApprove)
+	if err != nil {
+		return nil, err
+	}
+	return val.proposalLocalKV(proposal, index, false, true), nil
+}
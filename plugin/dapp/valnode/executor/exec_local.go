@@ -27,6 +27,17 @@ func (val *ValNode) ExecLocal_Node(node *pty.ValNode, tx *types.Transaction, rec
 	return set, nil
 }
 
+// ExecLocal_Evidence method
+func (val *ValNode) ExecLocal_Evidence(ev *pty.DuplicateVoteEvidence, tx *types.Transaction, receipt *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	set := &types.LocalDBSet{}
+	//双签验证者的权重降为0，共识模块据此将其移出验证者集合
+	node := &pty.ValNode{PubKey: ev.GetPubKey(), Power: 0}
+	clog.Info("slash validator", "pubkey", hex.EncodeToString(node.GetPubKey()))
+	key := CalcValNodeUpdateHeightIndexKey(val.GetHeight(), index)
+	set.KV = append(set.KV, &types.KeyValue{Key: key, Value: types.Encode(node)})
+	return set, nil
+}
+
 // ExecLocal_BlockInfo method
 func (val *ValNode) ExecLocal_BlockInfo(blockInfo *pty.TendermintBlockInfo, tx *types.Transaction, receipt *types.ReceiptData, index int) (*types.LocalDBSet, error) {
 	set := &types.LocalDBSet{}
@@ -34,3 +45,21 @@ func (val *ValNode) ExecLocal_BlockInfo(blockInfo *pty.TendermintBlockInfo, tx *
 	set.KV = append(set.KV, &types.KeyValue{Key: key, Value: types.Encode(blockInfo)})
 	return set, nil
 }
+
+// ExecLocal_Propose method
+func (val *ValNode) ExecLocal_Propose(propose *pty.ValNodePropose, tx *types.Transaction, receipt *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	proposal, err := getProposalFromReceipt(receipt, pty.TyLogValNodePropose)
+	if err != nil {
+		return nil, err
+	}
+	return val.proposalLocalKV(proposal, index, true, false), nil
+}
+
+// ExecLocal_Approve method
+func (val *ValNode) ExecLocal_Approve(approve *pty.ValNodeApprove, tx *types.Transaction, receipt *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	proposal, err := getProposalFromReceipt(receipt, pty.TyLogValNodeApprove)
+	if err != nil {
+		return nil, err
+	}
+	return val.proposalLocalKV(proposal, index, false, false), nil
+}
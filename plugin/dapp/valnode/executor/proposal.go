@@ -0,0 +1,194 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	pty "github.com/33cn/plugin/plugin/dapp/valnode/types"
+	"github.com/golang/protobuf/proto"
+)
+
+func (val *ValNode) governEnabled() bool {
+	cfg := val.GetAPI().GetConfig()
+	return cfg.IsDappFork(val.GetHeight(), pty.ValNodeX, pty.ForkValNodeGovern)
+}
+
+// getValidatorSet 返回基础交易中记录的当前高度的验证者集合
+func (val *ValNode) getValidatorSet() (*pty.ValNodes, error) {
+	value, err := val.GetStateDB().Get(CalcValNodeSetKey())
+	if err != nil || len(value) == 0 {
+		return nil, pty.ErrValidatorSetNotFound
+	}
+	valSet := &pty.ValNodes{}
+	if err := types.Decode(value, valSet); err != nil {
+		return nil, err
+	}
+	return valSet, nil
+}
+
+func (val *ValNode) getProposal(proposalID string) (*pty.ValNodeProposal, error) {
+	value, err := val.GetStateDB().Get(CalcValNodeProposalKey(proposalID))
+	if err != nil || len(value) == 0 {
+		return nil, pty.ErrProposalNotFound
+	}
+	proposal := &pty.ValNodeProposal{}
+	if err := types.Decode(value, proposal); err != nil {
+		return nil, err
+	}
+	return proposal, nil
+}
+
+func getPower(valSet *pty.ValNodes, pubKey []byte) int64 {
+	for _, node := range valSet.GetNodes() {
+		if bytes.Equal(node.GetPubKey(), pubKey) {
+			return node.GetPower()
+		}
+	}
+	return 0
+}
+
+func getTotalPower(valSet *pty.ValNodes) int64 {
+	total := int64(0)
+	for _, node := range valSet.GetNodes() {
+		total += node.GetPower()
+	}
+	return total
+}
+
+// getSigner 提案和批准交易必须由当前验证者的ed25519私钥签名
+func getSigner(tx *types.Transaction, valSet *pty.ValNodes) ([]byte, error) {
+	sig := tx.GetSignature()
+	if sig.GetTy() != types.ED25519 || getPower(valSet, sig.GetPubkey()) <= 0 {
+		return nil, pty.ErrNotValidator
+	}
+	return sig.GetPubkey(), nil
+}
+
+func checkProposalNodes(nodes []*pty.ValNode) error {
+	if len(nodes) == 0 {
+		return pty.ErrProposalNodes
+	}
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool)
+	for _, node := range nodes {
+		if node.GetPower() < 0 || seen[string(node.GetPubKey())] {
+			return pty.ErrProposalNodes
+		}
+		if _, err := cr.PubKeyFromBytes(node.GetPubKey()); err != nil {
+			return pty.ErrProposalNodes
+		}
+		seen[string(node.GetPubKey())] = true
+	}
+	return nil
+}
+
+// checkPowerChange 与共识模块一致，一次变更的权重必须小于总权重的1/3
+func checkPowerChange(valSet *pty.ValNodes, nodes []*pty.ValNode) error {
+	threshold := getTotalPower(valSet) / 3
+	acc := int64(0)
+	for _, node := range nodes {
+		change := node.GetPower() - getPower(valSet, node.GetPubKey())
+		if change < 0 {
+			change = -change
+		}
+		acc += change
+		if acc >= threshold {
+			return pty.ErrProposalPowerChange
+		}
+	}
+	return nil
+}
+
+// approve 记录验证者的批准，当前验证者中超过2/3权重批准后提案通过
+func (val *ValNode) approve(proposal *pty.ValNodeProposal, pubKey []byte, valSet *pty.ValNodes, logTy int32) (*types.Receipt, error) {
+	var prev *pty.ValNodeProposal
+	if proposal.Status != 0 {
+		prev = proto.Clone(proposal).(*pty.ValNodeProposal)
+	}
+	for _, approval := range proposal.Approvals {
+		if bytes.Equal(approval, pubKey) {
+			return nil, pty.ErrProposalDupApprove
+		}
+	}
+	proposal.Approvals = append(proposal.Approvals, pubKey)
+	proposal.Status = pty.ProposalStatusPending
+
+	approvedPower := int64(0)
+	for _, approval := range proposal.Approvals {
+		approvedPower += getPower(valSet, approval)
+	}
+	receipt := &types.Receipt{Ty: types.ExecOk, KV: nil, Logs: nil}
+	if approvedPower*3 > getTotalPower(valSet)*2 {
+		//批准时验证者集合可能已经变化，需要重新检查变更权重
+		if err := checkPowerChange(valSet, proposal.Nodes); err != nil {
+			return nil, err
+		}
+		proposal.Status = pty.ProposalStatusApproved
+		proposal.ApprovedHeight = val.GetHeight()
+		clog.Info("validator proposal approved", "proposalID", proposal.ProposalID, "height", val.GetHeight())
+		for _, node := range proposal.Nodes {
+			//重新加入验证者是恢复被罚没验证者的唯一方式
+			if node.GetPower() > 0 && val.isSlashed(node.GetPubKey()) {
+				receipt.KV = append(receipt.KV, &types.KeyValue{Key: CalcValNodeSlashKey(node.GetPubKey()), Value: nil})
+			}
+		}
+	}
+	receipt.KV = append(receipt.KV, &types.KeyValue{Key: CalcValNodeProposalKey(proposal.ProposalID), Value: types.Encode(proposal)})
+	log := &pty.ReceiptValNodeProposal{Prev: prev, Current: proposal}
+	receipt.Logs = append(receipt.Logs, &types.ReceiptLog{Ty: logTy, Log: types.Encode(log)})
+	return receipt, nil
+}
+
+// proposalStatus 待批准的提案超过过期高度后视为过期
+func proposalStatus(proposal *pty.ValNodeProposal, height int64) int32 {
+	if proposal.Status == pty.ProposalStatusPending && height > proposal.ExpireHeight {
+		return pty.ProposalStatusExpired
+	}
+	return proposal.Status
+}
+
+func getProposalFromReceipt(receipt *types.ReceiptData, logTy int32) (*pty.ValNodeProposal, error) {
+	for _, log := range receipt.GetLogs() {
+		if log.Ty != logTy {
+			continue
+		}
+		var rlog pty.ReceiptValNodeProposal
+		if err := types.Decode(log.Log, &rlog); err != nil {
+			return nil, err
+		}
+		return rlog.Current, nil
+	}
+	return nil, types.ErrLogType
+}
+
+// proposalLocalKV 提案的本地索引，通过的提案写入验证者更新供共识模块查询
+func (val *ValNode) proposalLocalKV(proposal *pty.ValNodeProposal, index int, isNew bool, del bool) *types.LocalDBSet {
+	set := &types.LocalDBSet{}
+	if isNew {
+		var value []byte
+		if !del {
+			value = []byte(proposal.ProposalID)
+		}
+		set.KV = append(set.KV, &types.KeyValue{Key: CalcValNodeProposalHeightIndexKey(val.GetHeight(), proposal.ProposalID), Value: value})
+	}
+	if proposal.Status == pty.ProposalStatusApproved {
+		for i, node := range proposal.Nodes {
+			var value []byte
+			if !del {
+				clog.Info("update validator", "pubkey", hex.EncodeToString(node.GetPubKey()), "power", node.GetPower())
+				value = types.Encode(node)
+			}
+			set.KV = append(set.KV, &types.KeyValue{Key: CalcValNodeProposalUpdateKey(val.GetHeight(), index, i), Value: value})
+		}
+	}
+	return set
+}
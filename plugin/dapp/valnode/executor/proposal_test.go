@@ -0,0 +1,175 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/33cn/chain33/client"
+	"github.com/33cn/chain33/common/crypto"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	pty "github.com/33cn/plugin/plugin/dapp/valnode/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var initOnce sync.Once
+
+type testEnv struct {
+	t       *testing.T
+	exec    *ValNode
+	stateDB dbm.DB
+	kvdb    dbm.KVDB
+	height  int64
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	initOnce.Do(func() { Init(pty.ValNodeX, cfg, nil) })
+	stateDB, _ := dbm.NewGoMemDB("1", "2", 1000)
+	_, _, kvdb := util.CreateTestDB()
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	api, _ := client.New(q.Client(), nil)
+	exec := newValNode().(*ValNode)
+	exec.SetAPI(api)
+	exec.SetStateDB(stateDB)
+	exec.SetLocalDB(kvdb)
+	return &testEnv{t: t, exec: exec, stateDB: stateDB, kvdb: kvdb}
+}
+
+func (env *testEnv) execTx(action *pty.ValNodeAction, priv crypto.PrivKey, signTy int) error {
+	env.height++
+	tx := &types.Transaction{Execer: []byte(pty.ValNodeX), Payload: types.Encode(action), Nonce: env.height}
+	tx.Sign(int32(signTy), priv)
+	env.exec.SetEnv(env.height, 1539918074, 0)
+	if err := env.exec.CheckTx(tx, 1); err != nil {
+		return err
+	}
+	receipt, err := env.exec.Exec(tx, 1)
+	if err != nil {
+		return err
+	}
+	for _, kv := range receipt.KV {
+		env.stateDB.Set(kv.Key, kv.Value)
+	}
+	set, err := env.exec.ExecLocal(tx, &types.ReceiptData{Ty: receipt.Ty, Logs: receipt.Logs}, 1)
+	assert.Nil(env.t, err)
+	for _, kv := range set.KV {
+		env.kvdb.Set(kv.Key, kv.Value)
+	}
+	return nil
+}
+
+func genKeys(t *testing.T, n int) []crypto.PrivKey {
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	assert.Nil(t, err)
+	keys := make([]crypto.PrivKey, n)
+	for i := range keys {
+		keys[i], err = cr.GenKey()
+		assert.Nil(t, err)
+	}
+	return keys
+}
+
+func proposeAction(pubKey []byte, power int64, expireHeight int64) *pty.ValNodeAction {
+	propose := &pty.ValNodePropose{Nodes: []*pty.ValNode{{PubKey: pubKey, Power: power}}, ExpireHeight: expireHeight}
+	return &pty.ValNodeAction{Value: &pty.ValNodeAction_Propose{Propose: propose}, Ty: pty.ValNodeActionPropose}
+}
+
+func approveAction(proposalID string) *pty.ValNodeAction {
+	approve := &pty.ValNodeApprove{ProposalID: proposalID}
+	return &pty.ValNodeAction{Value: &pty.ValNodeAction_Approve{Approve: approve}, Ty: pty.ValNodeActionApprove}
+}
+
+func TestValNodeProposal(t *testing.T) {
+	env := newTestEnv(t)
+	keys := genKeys(t, 6)
+	validators := keys[:4]
+
+	// 提案前需要基础交易记录验证者集合
+	err := env.execTx(proposeAction(keys[4].PubKey().Bytes(), 10, 0), validators[0], types.ED25519)
+	assert.Equal(t, pty.ErrValidatorSetNotFound, err)
+
+	valSet := &pty.ValidatorSet{}
+	for _, key := range validators {
+		valSet.Validators = append(valSet.Validators, &pty.Validator{PubKey: key.PubKey().Bytes(), VotingPower: 10})
+	}
+	blockInfo := &pty.TendermintBlockInfo{State: &pty.State{Validators: valSet}}
+	action := &pty.ValNodeAction{Value: &pty.ValNodeAction_BlockInfo{BlockInfo: blockInfo}, Ty: pty.ValNodeActionBlockInfo}
+	assert.Nil(t, env.execTx(action, keys[5], types.SECP256K1))
+	reply, err := env.exec.Query_GetValNodeSet(&types.ReqNil{})
+	assert.Nil(t, err)
+	assert.Len(t, reply.(*pty.ValNodes).Nodes, 4)
+
+	// 分叉之后不能直接变更验证者
+	node := &pty.ValNodeAction{Value: &pty.ValNodeAction_Node{Node: &pty.ValNode{PubKey: keys[4].PubKey().Bytes(), Power: 10}}, Ty: pty.ValNodeActionUpdate}
+	assert.Equal(t, pty.ErrValNodeNeedProposal, env.execTx(node, keys[5], types.ED25519))
+
+	// 非验证者不能提案，变更的权重必须小于总权重的1/3
+	assert.Equal(t, pty.ErrNotValidator, env.execTx(proposeAction(keys[4].PubKey().Bytes(), 10, 0), keys[5], types.ED25519))
+	assert.Equal(t, pty.ErrProposalPowerChange, env.execTx(proposeAction(keys[4].PubKey().Bytes(), 20, 0), validators[0], types.ED25519))
+	assert.Equal(t, pty.ErrProposalNodes, env.execTx(proposeAction([]byte("pubkey"), 10, 0), validators[0], types.ED25519))
+	assert.Equal(t, pty.ErrProposalExpireHeight, env.execTx(proposeAction(keys[4].PubKey().Bytes(), 10, 1), validators[0], types.ED25519))
+
+	assert.Nil(t, env.execTx(proposeAction(keys[4].PubKey().Bytes(), 10, 0), validators[0], types.ED25519))
+	list, err := env.exec.Query_ListValNodeProposals(&pty.ReqValNodeProposals{Status: pty.ProposalStatusPending})
+	assert.Nil(t, err)
+	assert.Len(t, list.(*pty.ValNodeProposals).Proposals, 1)
+	proposalID := list.(*pty.ValNodeProposals).Proposals[0].ProposalID
+
+	assert.Equal(t, pty.ErrProposalDupApprove, env.execTx(approveAction(proposalID), validators[0], types.ED25519))
+	assert.Equal(t, pty.ErrNotValidator, env.execTx(approveAction(proposalID), keys[5], types.ED25519))
+	assert.Nil(t, env.execTx(approveAction(proposalID), validators[1], types.ED25519))
+	proposal, err := env.exec.Query_GetValNodeProposal(&pty.ReqValNodeProposal{ProposalID: proposalID})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(pty.ProposalStatusPending), proposal.(*pty.ValNodeProposal).Status)
+
+	// 超过2/3权重批准后提案通过，共识模块可以查询到验证者更新
+	assert.Nil(t, env.execTx(approveAction(proposalID), validators[2], types.ED25519))
+	proposal, err = env.exec.Query_GetValNodeProposal(&pty.ReqValNodeProposal{ProposalID: proposalID})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(pty.ProposalStatusApproved), proposal.(*pty.ValNodeProposal).Status)
+	assert.Equal(t, env.height, proposal.(*pty.ValNodeProposal).ApprovedHeight)
+	nodes, err := env.exec.Query_GetValNodeByHeight(&pty.ReqNodeInfo{Height: env.height})
+	assert.Nil(t, err)
+	assert.Len(t, nodes.(*pty.ValNodes).Nodes, 1)
+	assert.Equal(t, keys[4].PubKey().Bytes(), nodes.(*pty.ValNodes).Nodes[0].PubKey)
+	assert.Equal(t, pty.ErrProposalStatus, env.execTx(approveAction(proposalID), validators[3], types.ED25519))
+
+	// 过期的提案不能再批准
+	assert.Nil(t, env.execTx(proposeAction(validators[3].PubKey().Bytes(), 0, env.height+2), validators[0], types.ED25519))
+	list, err = env.exec.Query_ListValNodeProposals(&pty.ReqValNodeProposals{})
+	assert.Nil(t, err)
+	assert.Len(t, list.(*pty.ValNodeProposals).Proposals, 2)
+	assert.Empty(t, list.(*pty.ValNodeProposals).PrimaryKey)
+	proposalID = list.(*pty.ValNodeProposals).Proposals[0].ProposalID
+
+	// 分页查询，按高度倒序每页遍历count个提案
+	list, err = env.exec.Query_ListValNodeProposals(&pty.ReqValNodeProposals{Count: 1})
+	assert.Nil(t, err)
+	page := list.(*pty.ValNodeProposals)
+	assert.Len(t, page.Proposals, 1)
+	assert.Equal(t, proposalID, page.Proposals[0].ProposalID)
+	assert.NotEmpty(t, page.PrimaryKey)
+	list, err = env.exec.Query_ListValNodeProposals(&pty.ReqValNodeProposals{Count: 1, PrimaryKey: page.PrimaryKey, Status: pty.ProposalStatusApproved})
+	assert.Nil(t, err)
+	page = list.(*pty.ValNodeProposals)
+	assert.Len(t, page.Proposals, 1)
+	assert.NotEqual(t, proposalID, page.Proposals[0].ProposalID)
+	list, err = env.exec.Query_ListValNodeProposals(&pty.ReqValNodeProposals{Count: 1, PrimaryKey: page.PrimaryKey})
+	assert.Nil(t, err)
+	assert.Empty(t, list.(*pty.ValNodeProposals).Proposals)
+	assert.Empty(t, list.(*pty.ValNodeProposals).PrimaryKey)
+	env.height += 2
+	assert.Equal(t, pty.ErrProposalExpired, env.execTx(approveAction(proposalID), validators[1], types.ED25519))
+	proposal, err = env.exec.Query_GetValNodeProposal(&pty.ReqValNodeProposal{ProposalID: proposalID})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(pty.ProposalStatusExpired), proposal.(*pty.ValNodeProposal).Status)
+}
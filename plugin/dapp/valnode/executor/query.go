@@ -60,3 +60,78 @@ func (val *ValNode) Query_GetBlockInfoByHeight(in *pty.ReqBlockInfo) (types.Mess
 	}
 	return reply, nil
 }
+
+// Query_GetValNodeSlash method
+func (val *ValNode) Query_GetValNodeSlash(in *pty.ReqValNodeSlash) (types.Message, error) {
+	if len(in.GetPubKey()) == 0 {
+		return nil, types.ErrInvalidParam
+	}
+	value, err := val.GetStateDB().Get(CalcValNodeSlashKey(in.GetPubKey()))
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, types.ErrNotFound
+	}
+
+	reply := &pty.ValNodeSlash{}
+	err = types.Decode(value, reply)
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// Query_GetValNodeProposal method
+func (val *ValNode) Query_GetValNodeProposal(in *pty.ReqValNodeProposal) (types.Message, error) {
+	if in.GetProposalID() == "" {
+		return nil, types.ErrInvalidParam
+	}
+	proposal, err := val.getProposal(in.GetProposalID())
+	if err != nil {
+		return nil, err
+	}
+	proposal.Status = proposalStatus(proposal, val.GetHeight())
+	return proposal, nil
+}
+
+// Query_ListValNodeProposals method
+func (val *ValNode) Query_ListValNodeProposals(in *pty.ReqValNodeProposals) (types.Message, error) {
+	count := in.GetCount()
+	if count <= 0 {
+		count = pty.DefaultProposalListCount
+	}
+	if count > pty.MaxProposalListCount {
+		count = pty.MaxProposalListCount
+	}
+	var primaryKey []byte
+	if in.GetPrimaryKey() != "" {
+		primaryKey = []byte(in.GetPrimaryKey())
+	}
+	//按提案高度倒序每次遍历count个提案，status为0时返回所有状态的提案，其他状态的提案被跳过
+	values, err := val.GetLocalDB().List(CalcValNodeProposalPrefix(), primaryKey, count, 0)
+	if err != nil && err != types.ErrNotFound {
+		return nil, err
+	}
+	reply := &pty.ValNodeProposals{}
+	for i, id := range values {
+		proposal, err := val.getProposal(string(id))
+		if err != nil {
+			return nil, err
+		}
+		if i == len(values)-1 && int32(len(values)) == count {
+			reply.PrimaryKey = string(CalcValNodeProposalHeightIndexKey(proposal.Height, proposal.ProposalID))
+		}
+		proposal.Status = proposalStatus(proposal, val.GetHeight())
+		if in.GetStatus() != 0 && in.GetStatus() != proposal.Status {
+			continue
+		}
+		reply.Proposals = append(reply.Proposals, proposal)
+	}
+	return reply, nil
+}
+
+// Query_GetValNodeSet method
+func (val *ValNode) Query_GetValNodeSet(in *types.ReqNil) (types.Message, error) {
+	return val.getValidatorSet()
+}
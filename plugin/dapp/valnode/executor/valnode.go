@@ -5,11 +5,14 @@
 package executor
 
 import (
+	"encoding/hex"
 	"fmt"
 
 	log "github.com/33cn/chain33/common/log/log15"
 	drivers "github.com/33cn/chain33/system/dapp"
 	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	pty "github.com/33cn/plugin/plugin/dapp/valnode/types"
 )
 
 var clog = log.New("module", "execs.valnode")
@@ -52,6 +55,20 @@ func (val *ValNode) GetDriverName() string {
 
 // CheckTx method
 func (val *ValNode) CheckTx(tx *types.Transaction, index int) error {
+	var action pty.ValNodeAction
+	err := types.Decode(tx.GetPayload(), &action)
+	if err != nil {
+		return err
+	}
+	//双签证据在进入mempool之前先验证签名
+	if action.GetTy() == pty.ValNodeActionEvidence {
+		evidence := &ttypes.DuplicateVoteEvidence{DuplicateVoteEvidence: action.GetEvidence()}
+		return evidence.Verify()
+	}
+	//分叉之后验证者变更必须通过提案
+	if action.GetTy() == pty.ValNodeActionUpdate && val.governEnabled() {
+		return pty.ErrValNodeNeedProposal
+	}
 	return nil
 }
 
@@ -70,6 +87,41 @@ func CalcValNodeBlockInfoHeightKey(height int64) []byte {
 	return []byte(fmt.Sprintf("LODB-valnode-BlockInfo:%18d:", height))
 }
 
+// CalcValNodeEvidenceKey method
+func CalcValNodeEvidenceKey(hash []byte) []byte {
+	return []byte(fmt.Sprintf("mavl-valnode-evidence-%s", hex.EncodeToString(hash)))
+}
+
+// CalcValNodeSlashKey method
+func CalcValNodeSlashKey(pubKey []byte) []byte {
+	return []byte(fmt.Sprintf("mavl-valnode-slash-%s", hex.EncodeToString(pubKey)))
+}
+
+// CalcValNodeSetKey method
+func CalcValNodeSetKey() []byte {
+	return []byte("mavl-valnode-validators")
+}
+
+// CalcValNodeProposalKey method
+func CalcValNodeProposalKey(proposalID string) []byte {
+	return []byte(fmt.Sprintf("mavl-valnode-proposal-%s", proposalID))
+}
+
+// CalcValNodeProposalHeightIndexKey 提案按高度的索引，同时作为分页查询的primaryKey
+func CalcValNodeProposalHeightIndexKey(height int64, proposalID string) []byte {
+	return []byte(fmt.Sprintf("LODB-valnode-Proposal:%18d:%s", height, proposalID))
+}
+
+// CalcValNodeProposalPrefix method
+func CalcValNodeProposalPrefix() []byte {
+	return []byte("LODB-valnode-Proposal:")
+}
+
+// CalcValNodeProposalUpdateKey 通过的提案中的每个节点在同一交易下按序号写入验证者更新
+func CalcValNodeProposalUpdateKey(height int64, index int, i int) []byte {
+	return []byte(fmt.Sprintf("LODB-valnode-Update:%18d:%18d:%6d", height, int64(index), int64(i)))
+}
+
 // CheckReceiptExecOk return true to check if receipt ty is ok
 func (val *ValNode) CheckReceiptExecOk() bool {
 	return true
@@ -8,4 +8,33 @@ package types
 const (
 	ValNodeActionUpdate    = 1
 	ValNodeActionBlockInfo = 2
+	ValNodeActionEvidence  = 3
+	ValNodeActionPropose   = 4
+	ValNodeActionApprove   = 5
+)
+
+//valnode log
+const (
+	TyLogValNodePropose = 1201
+	TyLogValNodeApprove = 1202
+)
+
+//proposal status
+const (
+	ProposalStatusPending  = 1
+	ProposalStatusApproved = 2
+	ProposalStatusExpired  = 3
+)
+
+const (
+	// ForkValNodeGovern 之后验证者变更只能通过提案执行
+	ForkValNodeGovern = "ForkValNodeGovern"
+	// MaxProposalPeriod 提案的最长有效区块数
+	MaxProposalPeriod = 100000
+	// DefaultProposalPeriod 未指定过期高度时提案的有效区块数
+	DefaultProposalPeriod = 10000
+	// DefaultProposalListCount 分页查询提案时每页默认的数目
+	DefaultProposalListCount = 20
+	// MaxProposalListCount 分页查询提案时每页最多的数目
+	MaxProposalListCount = 100
 )
@@ -0,0 +1,29 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import "errors"
+
+// valnode errors
+var (
+	ErrEvidenceDuplicate = errors.New("ErrEvidenceDuplicate")
+	ErrEvidenceHeight    = errors.New("ErrEvidenceHeight")
+	ErrValNodeSlashed    = errors.New("ErrValNodeSlashed")
+	ErrEvidenceChainID   = errors.New("ErrEvidenceChainID")
+	ErrEvidenceExpired   = errors.New("ErrEvidenceExpired")
+	ErrEvidenceValidator = errors.New("ErrEvidenceValidator")
+	ErrBlockInfoNotFound = errors.New("ErrBlockInfoNotFound")
+
+	ErrValNodeNeedProposal  = errors.New("ErrValNodeNeedProposal")
+	ErrNotValidator         = errors.New("ErrNotValidator")
+	ErrProposalNodes        = errors.New("ErrProposalNodes")
+	ErrProposalExpireHeight = errors.New("ErrProposalExpireHeight")
+	ErrProposalNotFound     = errors.New("ErrProposalNotFound")
+	ErrProposalStatus       = errors.New("ErrProposalStatus")
+	ErrProposalExpired      = errors.New("ErrProposalExpired")
+	ErrProposalDupApprove   = errors.New("ErrProposalDupApprove")
+	ErrProposalPowerChange  = errors.New("ErrProposalPowerChange")
+	ErrValidatorSetNotFound = errors.New("ErrValidatorSetNotFound")
+)
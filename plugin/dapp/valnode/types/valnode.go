@@ -5,6 +5,8 @@
 package types
 
 import (
+	"reflect"
+
 	"github.com/33cn/chain33/types"
 )
 
@@ -19,6 +21,8 @@ func init() {
 
 func InitFork(cfg *types.Chain33Config) {
 	cfg.RegisterDappFork(ValNodeX, "Enable", 0)
+	//验证者变更提案，主网启用高度待定
+	cfg.RegisterDappFork(ValNodeX, ForkValNodeGovern, types.MaxHeight)
 }
 
 func InitExecutor(cfg *types.Chain33Config) {
@@ -53,10 +57,16 @@ func (t *ValNodeType) GetTypeMap() map[string]int32 {
 	return map[string]int32{
 		"Node":      ValNodeActionUpdate,
 		"BlockInfo": ValNodeActionBlockInfo,
+		"Evidence":  ValNodeActionEvidence,
+		"Propose":   ValNodeActionPropose,
+		"Approve":   ValNodeActionApprove,
 	}
 }
 
 // GetLogMap method
 func (t *ValNodeType) GetLogMap() map[int64]*types.LogInfo {
-	return map[int64]*types.LogInfo{}
+	return map[int64]*types.LogInfo{
+		TyLogValNodePropose: {Ty: reflect.TypeOf(ReceiptValNodeProposal{}), Name: "LogValNodePropose"},
+		TyLogValNodeApprove: {Ty: reflect.TypeOf(ReceiptValNodeProposal{}), Name: "LogValNodeApprove"},
+	}
 }
@@ -40,7 +40,7 @@ func (m *ValNode) Reset()         { *m = ValNode{} }
 func (m *ValNode) String() string { return proto.CompactTextString(m) }
 func (*ValNode) ProtoMessage()    {}
 func (*ValNode) Descriptor() ([]byte, []int) {
-	return fileDescriptor_valnode_51979219d1bb0d85, []int{0}
+	return fileDescriptor_valnode_d2dca79ee00f4b2c, []int{0}
 }
 func (m *ValNode) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ValNode.Unmarshal(m, b)
@@ -85,7 +85,7 @@ func (m *ValNodes) Reset()         { *m = ValNodes{} }
 func (m *ValNodes) String() string { return proto.CompactTextString(m) }
 func (*ValNodes) ProtoMessage()    {}
 func (*ValNodes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_valnode_51979219d1bb0d85, []int{1}
+	return fileDescriptor_valnode_d2dca79ee00f4b2c, []int{1}
 }
 func (m *ValNodes) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ValNodes.Unmarshal(m, b)
@@ -116,6 +116,9 @@ type ValNodeAction struct {
 	// Types that are valid to be assigned to Value:
 	//	*ValNodeAction_Node
 	//	*ValNodeAction_BlockInfo
+	//	*ValNodeAction_Evidence
+	//	*ValNodeAction_Propose
+	//	*ValNodeAction_Approve
 	Value                isValNodeAction_Value `protobuf_oneof:"value"`
 	Ty                   int32                 `protobuf:"varint,3,opt,name=Ty,proto3" json:"Ty,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
@@ -127,7 +130,7 @@ func (m *ValNodeAction) Reset()         { *m = ValNodeAction{} }
 func (m *ValNodeAction) String() string { return proto.CompactTextString(m) }
 func (*ValNodeAction) ProtoMessage()    {}
 func (*ValNodeAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_valnode_51979219d1bb0d85, []int{2}
+	return fileDescriptor_valnode_d2dca79ee00f4b2c, []int{2}
 }
 func (m *ValNodeAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ValNodeAction.Unmarshal(m, b)
@@ -159,10 +162,28 @@ type ValNodeAction_BlockInfo struct {
 	BlockInfo *TendermintBlockInfo `protobuf:"bytes,2,opt,name=blockInfo,proto3,oneof"`
 }
 
+type ValNodeAction_Evidence struct {
+	Evidence *DuplicateVoteEvidence `protobuf:"bytes,4,opt,name=evidence,proto3,oneof"`
+}
+
+type ValNodeAction_Propose struct {
+	Propose *ValNodePropose `protobuf:"bytes,5,opt,name=propose,proto3,oneof"`
+}
+
+type ValNodeAction_Approve struct {
+	Approve *ValNodeApprove `protobuf:"bytes,6,opt,name=approve,proto3,oneof"`
+}
+
 func (*ValNodeAction_Node) isValNodeAction_Value() {}
 
 func (*ValNodeAction_BlockInfo) isValNodeAction_Value() {}
 
+func (*ValNodeAction_Evidence) isValNodeAction_Value() {}
+
+func (*ValNodeAction_Propose) isValNodeAction_Value() {}
+
+func (*ValNodeAction_Approve) isValNodeAction_Value() {}
+
 func (m *ValNodeAction) GetValue() isValNodeAction_Value {
 	if m != nil {
 		return m.Value
@@ -184,6 +205,27 @@ func (m *ValNodeAction) GetBlockInfo() *TendermintBlockInfo {
 	return nil
 }
 
+func (m *ValNodeAction) GetEvidence() *DuplicateVoteEvidence {
+	if x, ok := m.GetValue().(*ValNodeAction_Evidence); ok {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (m *ValNodeAction) GetPropose() *ValNodePropose {
+	if x, ok := m.GetValue().(*ValNodeAction_Propose); ok {
+		return x.Propose
+	}
+	return nil
+}
+
+func (m *ValNodeAction) GetApprove() *ValNodeApprove {
+	if x, ok := m.GetValue().(*ValNodeAction_Approve); ok {
+		return x.Approve
+	}
+	return nil
+}
+
 func (m *ValNodeAction) GetTy() int32 {
 	if m != nil {
 		return m.Ty
@@ -196,6 +238,9 @@ func (*ValNodeAction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer)
 	return _ValNodeAction_OneofMarshaler, _ValNodeAction_OneofUnmarshaler, _ValNodeAction_OneofSizer, []interface{}{
 		(*ValNodeAction_Node)(nil),
 		(*ValNodeAction_BlockInfo)(nil),
+		(*ValNodeAction_Evidence)(nil),
+		(*ValNodeAction_Propose)(nil),
+		(*ValNodeAction_Approve)(nil),
 	}
 }
 
@@ -213,6 +258,21 @@ func _ValNodeAction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.BlockInfo); err != nil {
 			return err
 		}
+	case *ValNodeAction_Evidence:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Evidence); err != nil {
+			return err
+		}
+	case *ValNodeAction_Propose:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Propose); err != nil {
+			return err
+		}
+	case *ValNodeAction_Approve:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Approve); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("ValNodeAction.Value has unexpected type %T", x)
@@ -239,6 +299,30 @@ func _ValNodeAction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.
 		err := b.DecodeMessage(msg)
 		m.Value = &ValNodeAction_BlockInfo{msg}
 		return true, err
+	case 4: // value.evidence
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(DuplicateVoteEvidence)
+		err := b.DecodeMessage(msg)
+		m.Value = &ValNodeAction_Evidence{msg}
+		return true, err
+	case 5: // value.propose
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ValNodePropose)
+		err := b.DecodeMessage(msg)
+		m.Value = &ValNodeAction_Propose{msg}
+		return true, err
+	case 6: // value.approve
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ValNodeApprove)
+		err := b.DecodeMessage(msg)
+		m.Value = &ValNodeAction_Approve{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -258,6 +342,21 @@ func _ValNodeAction_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *ValNodeAction_Evidence:
+		s := proto.Size(x.Evidence)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ValNodeAction_Propose:
+		s := proto.Size(x.Propose)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ValNodeAction_Approve:
+		s := proto.Size(x.Approve)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -265,6 +364,529 @@ func _ValNodeAction_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// DuplicateVoteEvidence 同一验证者在相同高度和轮次对不同区块的两个签名投票
+type DuplicateVoteEvidence struct {
+	PubKey               []byte   `protobuf:"bytes,1,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	VoteA                *Vote    `protobuf:"bytes,2,opt,name=voteA,proto3" json:"voteA,omitempty"`
+	VoteB                *Vote    `protobuf:"bytes,3,opt,name=voteB,proto3" json:"voteB,omitempty"`
+	ChainID              string   `protobuf:"bytes,4,opt,name=chainID,proto3" json:"chainID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DuplicateVoteEvidence) Reset()         { *m = DuplicateVoteEvidence{} }
+func (m *DuplicateVoteEvidence) String() string { return proto.CompactTextString(m) }
+func (*DuplicateVoteEvidence) ProtoMessage()    {}
+func (*DuplicateVoteEvidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_valnode_d2dca79ee00f4b2c, []int{3}
+}
+func (m *DuplicateVoteEvidence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DuplicateVoteEvidence.Unmarshal(m, b)
+}
+func (m *DuplicateVoteEvidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DuplicateVoteEvidence.Marshal(b, m, deterministic)
+}
+func (dst *DuplicateVoteEvidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DuplicateVoteEvidence.Merge(dst, src)
+}
+func (m *DuplicateVoteEvidence) XXX_Size() int {
+	return xxx_messageInfo_DuplicateVoteEvidence.Size(m)
+}
+func (m *DuplicateVoteEvidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_DuplicateVoteEvidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DuplicateVoteEvidence proto.InternalMessageInfo
+
+func (m *DuplicateVoteEvidence) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *DuplicateVoteEvidence) GetVoteA() *Vote {
+	if m != nil {
+		return m.VoteA
+	}
+	return nil
+}
+
+func (m *DuplicateVoteEvidence) GetVoteB() *Vote {
+	if m != nil {
+		return m.VoteB
+	}
+	return nil
+}
+
+func (m *DuplicateVoteEvidence) GetChainID() string {
+	if m != nil {
+		return m.ChainID
+	}
+	return ""
+}
+
+// ValNodeSlash 验证者因双签被罚没的记录
+type ValNodeSlash struct {
+	PubKey               []byte   `protobuf:"bytes,1,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	Height               int64    `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	EvidenceHash         []byte   `protobuf:"bytes,3,opt,name=evidenceHash,proto3" json:"evidenceHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValNodeSlash) Reset()         { *m = ValNodeSlash{} }
+func (m *ValNodeSlash) String() string { return proto.CompactTextString(m) }
+func (*ValNodeSlash) ProtoMessage()    {}
+func (*ValNodeSlash) Descriptor() ([]byte, []int) {
+	return fileDescriptor_valnode_d2dca79ee00f4b2c, []int{4}
+}
+func (m *ValNodeSlash) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValNodeSlash.Unmarshal(m, b)
+}
+func (m *ValNodeSlash) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValNodeSlash.Marshal(b, m, deterministic)
+}
+func (dst *ValNodeSlash) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValNodeSlash.Merge(dst, src)
+}
+func (m *ValNodeSlash) XXX_Size() int {
+	return xxx_messageInfo_ValNodeSlash.Size(m)
+}
+func (m *ValNodeSlash) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValNodeSlash.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValNodeSlash proto.InternalMessageInfo
+
+func (m *ValNodeSlash) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *ValNodeSlash) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ValNodeSlash) GetEvidenceHash() []byte {
+	if m != nil {
+		return m.EvidenceHash
+	}
+	return nil
+}
+
+type ReqValNodeSlash struct {
+	PubKey               []byte   `protobuf:"bytes,1,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqValNodeSlash) Reset()         { *m = ReqValNodeSlash{} }
+func (m *ReqValNodeSlash) String() string { return proto.CompactTextString(m) }
+func (*ReqValNodeSlash) ProtoMessage()    {}
+func (*ReqValNodeSlash) Descriptor() ([]byte, []int) {
+	return fileDescriptor_valnode_d2dca79ee00f4b2c, []int{5}
+}
+func (m *ReqValNodeSlash) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqValNodeSlash.Unmarshal(m, b)
+}
+func (m *ReqValNodeSlash) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqValNodeSlash.Marshal(b, m, deterministic)
+}
+func (dst *ReqValNodeSlash) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqValNodeSlash.Merge(dst, src)
+}
+func (m *ReqValNodeSlash) XXX_Size() int {
+	return xxx_messageInfo_ReqValNodeSlash.Size(m)
+}
+func (m *ReqValNodeSlash) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqValNodeSlash.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqValNodeSlash proto.InternalMessageInfo
+
+func (m *ReqValNodeSlash) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+// ValNodePropose 验证者增加、删除和权重变更的提案，需要超过2/3权重的当前验证者批准
+type ValNodePropose struct {
+	Nodes                []*ValNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	ExpireHeight         int64      `protobuf:"varint,2,opt,name=expireHeight,proto3" json:"expireHeight,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ValNodePropose) Reset()         { *m = ValNodePropose{} }
+func (m *ValNodePropose) String() string { return proto.CompactTextString(m) }
+func (*ValNodePropose) ProtoMessage()    {}
+func (*ValNodePropose) Descriptor() ([]byte, []int) {
+	return fileDescriptor_valnode_d2dca79ee00f4b2c, []int{6}
+}
+func (m *ValNodePropose) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValNodePropose.Unmarshal(m, b)
+}
+func (m *ValNodePropose) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValNodePropose.Marshal(b, m, deterministic)
+}
+func (dst *ValNodePropose) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValNodePropose.Merge(dst, src)
+}
+func (m *ValNodePropose) XXX_Size() int {
+	return xxx_messageInfo_ValNodePropose.Size(m)
+}
+func (m *ValNodePropose) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValNodePropose.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValNodePropose proto.InternalMessageInfo
+
+func (m *ValNodePropose) GetNodes() []*ValNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *ValNodePropose) GetExpireHeight() int64 {
+	if m != nil {
+		return m.ExpireHeight
+	}
+	return 0
+}
+
+// ValNodeApprove 当前验证者批准提案
+type ValNodeApprove struct {
+	ProposalID           string   `protobuf:"bytes,1,opt,name=proposalID,proto3" json:"proposalID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValNodeApprove) Reset()         { *m = ValNodeApprove{} }
+func (m *ValNodeApprove) String() string { return proto.CompactTextString(m) }
+func (*ValNodeApprove) ProtoMessage()    {}
+func (*ValNodeApprove) Descriptor() ([]byte, []int) {
+	return fileDescriptor_valnode_d2dca79ee00f4b2c, []int{7}
+}
+func (m *ValNodeApprove) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValNodeApprove.Unmarshal(m, b)
+}
+func (m *ValNodeApprove) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValNodeApprove.Marshal(b, m, deterministic)
+}
+func (dst *ValNodeApprove) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValNodeApprove.Merge(dst, src)
+}
+func (m *ValNodeApprove) XXX_Size() int {
+	return xxx_messageInfo_ValNodeApprove.Size(m)
+}
+func (m *ValNodeApprove) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValNodeApprove.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValNodeApprove proto.InternalMessageInfo
+
+func (m *ValNodeApprove) GetProposalID() string {
+	if m != nil {
+		return m.ProposalID
+	}
+	return ""
+}
+
+// ValNodeProposal 链上保存的验证者变更提案
+type ValNodeProposal struct {
+	ProposalID           string     `protobuf:"bytes,1,opt,name=proposalID,proto3" json:"proposalID,omitempty"`
+	Nodes                []*ValNode `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Proposer             []byte     `protobuf:"bytes,3,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	Height               int64      `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	ExpireHeight         int64      `protobuf:"varint,5,opt,name=expireHeight,proto3" json:"expireHeight,omitempty"`
+	Approvals            [][]byte   `protobuf:"bytes,6,rep,name=approvals,proto3" json:"approvals,omitempty"`
+	Status               int32      `protobuf:"varint,7,opt,name=status,proto3" json:"status,omitempty"`
+	ApprovedHeight       int64      `protobuf:"varint,8,opt,name=approvedHeight,proto3" json:"approvedHeight,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ValNodeProposal) Reset()         { *m = ValNodeProposal{} }
+func (m *ValNodeProposal) String() string { return proto.CompactTextString(m) }
+func (*ValNodeProposal) ProtoMessage()    {}
+func (*ValNodeProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_valnode_d2dca79ee00f4b2c, []int{8}
+}
+func (m *ValNodeProposal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValNodeProposal.Unmarshal(m, b)
+}
+func (m *ValNodeProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValNodeProposal.Marshal(b, m, deterministic)
+}
+func (dst *ValNodeProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValNodeProposal.Merge(dst, src)
+}
+func (m *ValNodeProposal) XXX_Size() int {
+	return xxx_messageInfo_ValNodeProposal.Size(m)
+}
+func (m *ValNodeProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValNodeProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValNodeProposal proto.InternalMessageInfo
+
+func (m *ValNodeProposal) GetProposalID() string {
+	if m != nil {
+		return m.ProposalID
+	}
+	return ""
+}
+
+func (m *ValNodeProposal) GetNodes() []*ValNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *ValNodeProposal) GetProposer() []byte {
+	if m != nil {
+		return m.Proposer
+	}
+	return nil
+}
+
+func (m *ValNodeProposal) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ValNodeProposal) GetExpireHeight() int64 {
+	if m != nil {
+		return m.ExpireHeight
+	}
+	return 0
+}
+
+func (m *ValNodeProposal) GetApprovals() [][]byte {
+	if m != nil {
+		return m.Approvals
+	}
+	return nil
+}
+
+func (m *ValNodeProposal) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *ValNodeProposal) GetApprovedHeight() int64 {
+	if m != nil {
+		return m.ApprovedHeight
+	}
+	return 0
+}
+
+type ReceiptValNodeProposal struct {
+	Prev                 *ValNodeProposal `protobuf:"bytes,1,opt,name=prev,proto3" json:"prev,omitempty"`
+	Current              *ValNodeProposal `protobuf:"bytes,2,opt,name=current,proto3" json:"current,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ReceiptValNodeProposal) Reset()         { *m = ReceiptValNodeProposal{} }
+func (m *ReceiptValNodeProposal) String() string { return proto.CompactTextString(m) }
+func (*ReceiptValNodeProposal) ProtoMessage()    {}
+func (*ReceiptValNodeProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_valnode_d2dca79ee00f4b2c, []int{9}
+}
+func (m *ReceiptValNodeProposal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiptValNodeProposal.Unmarshal(m, b)
+}
+func (m *ReceiptValNodeProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiptValNodeProposal.Marshal(b, m, deterministic)
+}
+func (dst *ReceiptValNodeProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiptValNodeProposal.Merge(dst, src)
+}
+func (m *ReceiptValNodeProposal) XXX_Size() int {
+	return xxx_messageInfo_ReceiptValNodeProposal.Size(m)
+}
+func (m *ReceiptValNodeProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiptValNodeProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiptValNodeProposal proto.InternalMessageInfo
+
+func (m *ReceiptValNodeProposal) GetPrev() *ValNodeProposal {
+	if m != nil {
+		return m.Prev
+	}
+	return nil
+}
+
+func (m *ReceiptValNodeProposal) GetCurrent() *ValNodeProposal {
+	if m != nil {
+		return m.Current
+	}
+	return nil
+}
+
+type ReqValNodeProposal struct {
+	ProposalID           string   `protobuf:"bytes,1,opt,name=proposalID,proto3" json:"proposalID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqValNodeProposal) Reset()         { *m = ReqValNodeProposal{} }
+func (m *ReqValNodeProposal) String() string { return proto.CompactTextString(m) }
+func (*ReqValNodeProposal) ProtoMessage()    {}
+func (*ReqValNodeProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_valnode_d2dca79ee00f4b2c, []int{10}
+}
+func (m *ReqValNodeProposal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqValNodeProposal.Unmarshal(m, b)
+}
+func (m *ReqValNodeProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqValNodeProposal.Marshal(b, m, deterministic)
+}
+func (dst *ReqValNodeProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqValNodeProposal.Merge(dst, src)
+}
+func (m *ReqValNodeProposal) XXX_Size() int {
+	return xxx_messageInfo_ReqValNodeProposal.Size(m)
+}
+func (m *ReqValNodeProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqValNodeProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqValNodeProposal proto.InternalMessageInfo
+
+func (m *ReqValNodeProposal) GetProposalID() string {
+	if m != nil {
+		return m.ProposalID
+	}
+	return ""
+}
+
+// ReqValNodeProposals 按提案高度倒序分页查询，primaryKey为上一页返回的位置
+type ReqValNodeProposals struct {
+	Status               int32    `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Count                int32    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	PrimaryKey           string   `protobuf:"bytes,3,opt,name=primaryKey,proto3" json:"primaryKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqValNodeProposals) Reset()         { *m = ReqValNodeProposals{} }
+func (m *ReqValNodeProposals) String() string { return proto.CompactTextString(m) }
+func (*ReqValNodeProposals) ProtoMessage()    {}
+func (*ReqValNodeProposals) Descriptor() ([]byte, []int) {
+	return fileDescriptor_valnode_d2dca79ee00f4b2c, []int{11}
+}
+func (m *ReqValNodeProposals) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqValNodeProposals.Unmarshal(m, b)
+}
+func (m *ReqValNodeProposals) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqValNodeProposals.Marshal(b, m, deterministic)
+}
+func (dst *ReqValNodeProposals) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqValNodeProposals.Merge(dst, src)
+}
+func (m *ReqValNodeProposals) XXX_Size() int {
+	return xxx_messageInfo_ReqValNodeProposals.Size(m)
+}
+func (m *ReqValNodeProposals) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqValNodeProposals.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqValNodeProposals proto.InternalMessageInfo
+
+func (m *ReqValNodeProposals) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *ReqValNodeProposals) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ReqValNodeProposals) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
+// ValNodeProposals primaryKey不为空时还有下一页
+type ValNodeProposals struct {
+	Proposals            []*ValNodeProposal `protobuf:"bytes,1,rep,name=proposals,proto3" json:"proposals,omitempty"`
+	PrimaryKey           string             `protobuf:"bytes,2,opt,name=primaryKey,proto3" json:"primaryKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ValNodeProposals) Reset()         { *m = ValNodeProposals{} }
+func (m *ValNodeProposals) String() string { return proto.CompactTextString(m) }
+func (*ValNodeProposals) ProtoMessage()    {}
+func (*ValNodeProposals) Descriptor() ([]byte, []int) {
+	return fileDescriptor_valnode_d2dca79ee00f4b2c, []int{12}
+}
+func (m *ValNodeProposals) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValNodeProposals.Unmarshal(m, b)
+}
+func (m *ValNodeProposals) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValNodeProposals.Marshal(b, m, deterministic)
+}
+func (dst *ValNodeProposals) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValNodeProposals.Merge(dst, src)
+}
+func (m *ValNodeProposals) XXX_Size() int {
+	return xxx_messageInfo_ValNodeProposals.Size(m)
+}
+func (m *ValNodeProposals) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValNodeProposals.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValNodeProposals proto.InternalMessageInfo
+
+func (m *ValNodeProposals) GetProposals() []*ValNodeProposal {
+	if m != nil {
+		return m.Proposals
+	}
+	return nil
+}
+
+func (m *ValNodeProposals) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
 type ReqNodeInfo struct {
 	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -276,7 +898,7 @@ func (m *ReqNodeInfo) Reset()         { *m = ReqNodeInfo{} }
 func (m *ReqNodeInfo) String() string { return proto.CompactTextString(m) }
 func (*ReqNodeInfo) ProtoMessage()    {}
 func (*ReqNodeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_valnode_51979219d1bb0d85, []int{3}
+	return fileDescriptor_valnode_d2dca79ee00f4b2c, []int{13}
 }
 func (m *ReqNodeInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReqNodeInfo.Unmarshal(m, b)
@@ -314,7 +936,7 @@ func (m *ReqBlockInfo) Reset()         { *m = ReqBlockInfo{} }
 func (m *ReqBlockInfo) String() string { return proto.CompactTextString(m) }
 func (*ReqBlockInfo) ProtoMessage()    {}
 func (*ReqBlockInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_valnode_51979219d1bb0d85, []int{4}
+	return fileDescriptor_valnode_d2dca79ee00f4b2c, []int{14}
 }
 func (m *ReqBlockInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReqBlockInfo.Unmarshal(m, b)
@@ -345,6 +967,16 @@ func init() {
 	proto.RegisterType((*ValNode)(nil), "types.ValNode")
 	proto.RegisterType((*ValNodes)(nil), "types.ValNodes")
 	proto.RegisterType((*ValNodeAction)(nil), "types.ValNodeAction")
+	proto.RegisterType((*DuplicateVoteEvidence)(nil), "types.DuplicateVoteEvidence")
+	proto.RegisterType((*ValNodeSlash)(nil), "types.ValNodeSlash")
+	proto.RegisterType((*ReqValNodeSlash)(nil), "types.ReqValNodeSlash")
+	proto.RegisterType((*ValNodePropose)(nil), "types.ValNodePropose")
+	proto.RegisterType((*ValNodeApprove)(nil), "types.ValNodeApprove")
+	proto.RegisterType((*ValNodeProposal)(nil), "types.ValNodeProposal")
+	proto.RegisterType((*ReceiptValNodeProposal)(nil), "types.ReceiptValNodeProposal")
+	proto.RegisterType((*ReqValNodeProposal)(nil), "types.ReqValNodeProposal")
+	proto.RegisterType((*ReqValNodeProposals)(nil), "types.ReqValNodeProposals")
+	proto.RegisterType((*ValNodeProposals)(nil), "types.ValNodeProposals")
 	proto.RegisterType((*ReqNodeInfo)(nil), "types.ReqNodeInfo")
 	proto.RegisterType((*ReqBlockInfo)(nil), "types.ReqBlockInfo")
 }
@@ -454,29 +1086,52 @@ var _Valnode_serviceDesc = grpc.ServiceDesc{
 	Metadata: "valnode.proto",
 }
 
-func init() { proto.RegisterFile("valnode.proto", fileDescriptor_valnode_51979219d1bb0d85) }
-
-var fileDescriptor_valnode_51979219d1bb0d85 = []byte{
-	// 322 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x51, 0xcd, 0x6a, 0xf2, 0x40,
-	0x14, 0x4d, 0xcc, 0x97, 0xf8, 0xf5, 0x46, 0x45, 0xa6, 0x45, 0x42, 0x56, 0x61, 0xb0, 0x25, 0x50,
-	0x90, 0xa2, 0x8b, 0x42, 0x77, 0x75, 0x53, 0xa5, 0xd0, 0xc5, 0x28, 0xdd, 0xc7, 0xe4, 0xb6, 0x06,
-	0xe3, 0x4c, 0x4c, 0x46, 0x4b, 0x5e, 0xa1, 0x4f, 0x5d, 0x32, 0x99, 0x46, 0x68, 0xe9, 0xf2, 0xfc,
-	0xe5, 0x9c, 0xdc, 0x81, 0xfe, 0x29, 0xca, 0xb8, 0x48, 0x70, 0x92, 0x17, 0x42, 0x0a, 0x62, 0xcb,
-	0x2a, 0xc7, 0xd2, 0xef, 0xc5, 0x62, 0xbf, 0x17, 0xbc, 0x21, 0xfd, 0xa1, 0x44, 0x9e, 0x60, 0xb1,
-	0x4f, 0xb9, 0x6c, 0x18, 0x7a, 0x0f, 0xdd, 0xd7, 0x28, 0x7b, 0x11, 0x09, 0x92, 0x11, 0x38, 0xf9,
-	0x71, 0xf3, 0x8c, 0x95, 0x67, 0x06, 0x66, 0xd8, 0x63, 0x1a, 0x91, 0x2b, 0xb0, 0x73, 0xf1, 0x81,
-	0x85, 0xd7, 0x09, 0xcc, 0xd0, 0x62, 0x0d, 0xa0, 0x77, 0xf0, 0x5f, 0x07, 0x4b, 0x32, 0x06, 0xbb,
-	0x6e, 0x2e, 0x3d, 0x33, 0xb0, 0x42, 0x77, 0x3a, 0x98, 0xa8, 0xee, 0x89, 0xd6, 0x59, 0x23, 0xd2,
-	0x4f, 0x13, 0xfa, 0x9a, 0x7a, 0x8c, 0x65, 0x2a, 0x38, 0x19, 0xc3, 0xbf, 0x5a, 0x52, 0x7d, 0xbf,
-	0x62, 0x0b, 0x83, 0x29, 0x95, 0x3c, 0xc0, 0xc5, 0x26, 0x13, 0xf1, 0x6e, 0xc9, 0xdf, 0x84, 0xda,
-	0xe0, 0x4e, 0x7d, 0x6d, 0x5d, 0xb7, 0xbf, 0x33, 0xff, 0x76, 0x2c, 0x0c, 0x76, 0xb6, 0x93, 0x01,
-	0x74, 0xd6, 0x95, 0x67, 0x05, 0x66, 0x68, 0xb3, 0xce, 0xba, 0x9a, 0x77, 0xc1, 0x3e, 0x45, 0xd9,
-	0x11, 0xe9, 0x35, 0xb8, 0x0c, 0x0f, 0x75, 0x8f, 0xf2, 0x8d, 0xc0, 0xd9, 0x62, 0xfa, 0xbe, 0x95,
-	0x6a, 0x8b, 0xc5, 0x34, 0xa2, 0x37, 0xd0, 0x63, 0x78, 0x68, 0x3f, 0xfe, 0x97, 0x6f, 0xba, 0x83,
-	0xae, 0x3e, 0x3f, 0xb9, 0x05, 0x67, 0x59, 0xae, 0x2a, 0x1e, 0x93, 0xbe, 0x5e, 0x59, 0x17, 0xa5,
-	0x99, 0x3f, 0xd4, 0x70, 0x59, 0x2e, 0x30, 0xca, 0xe4, 0xb6, 0xa2, 0x06, 0x99, 0x81, 0xfb, 0x84,
-	0xb2, 0x9d, 0xf1, 0x23, 0x71, 0x79, 0xbe, 0x48, 0x9a, 0x44, 0x52, 0x14, 0x2b, 0x94, 0xd4, 0xd8,
-	0x38, 0xea, 0xe9, 0x66, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xce, 0xc8, 0x21, 0x55, 0xf2, 0x01,
-	0x00, 0x00,
+func init() { proto.RegisterFile("valnode.proto", fileDescriptor_valnode_d2dca79ee00f4b2c) }
+
+var fileDescriptor_valnode_d2dca79ee00f4b2c = []byte{
+	// 696 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xdd, 0x6a, 0xdb, 0x4c,
+	0x10, 0xb5, 0x64, 0xcb, 0x3f, 0x63, 0xc7, 0x09, 0x9b, 0xc4, 0x08, 0x13, 0x3e, 0xfc, 0x2d, 0x69,
+	0x70, 0x5b, 0x08, 0x69, 0x12, 0x28, 0xe4, 0x2e, 0x26, 0xa5, 0x36, 0x85, 0x52, 0x36, 0x21, 0x17,
+	0xbd, 0x5b, 0xcb, 0xd3, 0x5a, 0x44, 0xd6, 0x2a, 0xd2, 0xda, 0xad, 0x1e, 0xa2, 0xf4, 0x45, 0xfa,
+	0x90, 0x45, 0xab, 0x95, 0x65, 0xd9, 0x71, 0x9a, 0xcb, 0x99, 0x39, 0x47, 0xe7, 0xcc, 0x8f, 0x24,
+	0xd8, 0x59, 0x70, 0xcf, 0x17, 0x13, 0x3c, 0x0d, 0x42, 0x21, 0x05, 0xb1, 0x64, 0x1c, 0x60, 0xd4,
+	0x6d, 0x39, 0x62, 0x36, 0x13, 0x7e, 0x9a, 0xec, 0xee, 0x49, 0xf4, 0x27, 0x18, 0xce, 0x5c, 0x5f,
+	0xa6, 0x19, 0xfa, 0x1e, 0x6a, 0xf7, 0xdc, 0xfb, 0x2c, 0x26, 0x48, 0x3a, 0x50, 0x0d, 0xe6, 0xe3,
+	0x4f, 0x18, 0xdb, 0x46, 0xcf, 0xe8, 0xb7, 0x98, 0x8e, 0xc8, 0x01, 0x58, 0x81, 0xf8, 0x81, 0xa1,
+	0x6d, 0xf6, 0x8c, 0x7e, 0x99, 0xa5, 0x01, 0x3d, 0x83, 0xba, 0x26, 0x46, 0xe4, 0x18, 0xac, 0x44,
+	0x39, 0xb2, 0x8d, 0x5e, 0xb9, 0xdf, 0x3c, 0x6f, 0x9f, 0x2a, 0xed, 0x53, 0x5d, 0x67, 0x69, 0x91,
+	0xfe, 0x31, 0x61, 0x47, 0xa7, 0xae, 0x1d, 0xe9, 0x0a, 0x9f, 0x1c, 0x43, 0x25, 0x29, 0x29, 0xbd,
+	0x0d, 0xda, 0xb0, 0xc4, 0x54, 0x95, 0x5c, 0x41, 0x63, 0xec, 0x09, 0xe7, 0x61, 0xe4, 0x7f, 0x13,
+	0xca, 0x43, 0xf3, 0xbc, 0xab, 0xa1, 0x77, 0xcb, 0x76, 0x06, 0x19, 0x62, 0x58, 0x62, 0x39, 0x9c,
+	0x5c, 0x41, 0x1d, 0x17, 0xee, 0x04, 0x7d, 0x07, 0xed, 0x8a, 0xa2, 0x1e, 0x69, 0xea, 0xcd, 0x3c,
+	0xf0, 0x5c, 0x87, 0x4b, 0xbc, 0x17, 0x12, 0x3f, 0x68, 0xcc, 0xb0, 0xc4, 0x96, 0x78, 0xf2, 0x0e,
+	0x6a, 0x41, 0x28, 0x02, 0x11, 0xa1, 0x6d, 0x29, 0xea, 0x61, 0xd1, 0xe0, 0x97, 0xb4, 0x38, 0x2c,
+	0xb1, 0x0c, 0x97, 0x50, 0x78, 0x10, 0x84, 0x62, 0x81, 0x76, 0xf5, 0x29, 0xca, 0x75, 0x5a, 0x4c,
+	0x28, 0x1a, 0x47, 0xda, 0x60, 0xde, 0xc5, 0x76, 0xb9, 0x67, 0xf4, 0x2d, 0x66, 0xde, 0xc5, 0x83,
+	0x1a, 0x58, 0x0b, 0xee, 0xcd, 0x91, 0xfe, 0x36, 0xe0, 0xf0, 0x49, 0x93, 0x5b, 0x17, 0xf5, 0x3f,
+	0x58, 0x0b, 0x21, 0xf1, 0x5a, 0x0f, 0xa9, 0x99, 0x69, 0x0b, 0x89, 0x2c, 0xad, 0x64, 0x90, 0x81,
+	0x5d, 0xde, 0x02, 0x19, 0x10, 0x1b, 0x6a, 0xce, 0x94, 0xbb, 0xfe, 0xe8, 0x46, 0x4d, 0xac, 0xc1,
+	0xb2, 0x90, 0x8e, 0xa1, 0xa5, 0xfb, 0xb8, 0xf5, 0x78, 0x34, 0xdd, 0xea, 0xa3, 0x03, 0xd5, 0x29,
+	0xba, 0xdf, 0xa7, 0x52, 0x5f, 0x8c, 0x8e, 0x08, 0x85, 0x56, 0x36, 0xdc, 0x21, 0x8f, 0xa6, 0xca,
+	0x43, 0x8b, 0x15, 0x72, 0xf4, 0x35, 0xec, 0x32, 0x7c, 0x7c, 0x89, 0x0c, 0xfd, 0x0a, 0xed, 0xe2,
+	0x26, 0x5e, 0x76, 0x87, 0xca, 0xc6, 0xcf, 0xc0, 0x0d, 0x71, 0xb8, 0x6a, 0xb2, 0x90, 0xa3, 0x67,
+	0xd0, 0x2e, 0xae, 0x8c, 0xfc, 0x07, 0x90, 0x6e, 0x99, 0x7b, 0xa3, 0x1b, 0xe5, 0xa4, 0xc1, 0x56,
+	0x32, 0xf4, 0x97, 0x09, 0xbb, 0x05, 0x3b, 0xdc, 0xfb, 0x17, 0x27, 0xf7, 0x6b, 0x3e, 0xe7, 0xb7,
+	0x0b, 0xf5, 0x94, 0x83, 0xa1, 0x1e, 0xd9, 0x32, 0x5e, 0x19, 0x75, 0x65, 0x63, 0xd4, 0xab, 0x3d,
+	0x5a, 0x9b, 0x3d, 0x92, 0x23, 0x68, 0xa4, 0x47, 0xc8, 0xbd, 0xc8, 0xae, 0xf6, 0xca, 0xfd, 0x16,
+	0xcb, 0x13, 0xc9, 0x93, 0x23, 0xc9, 0xe5, 0x3c, 0xb2, 0x6b, 0xea, 0x36, 0x75, 0x44, 0x4e, 0xa0,
+	0x9d, 0x82, 0x70, 0xa2, 0x9f, 0x5d, 0x57, 0xcf, 0x5e, 0xcb, 0xd2, 0x05, 0x74, 0x18, 0x3a, 0xe8,
+	0x06, 0x72, 0x7d, 0x2a, 0x6f, 0xa0, 0x12, 0x84, 0xb8, 0xd0, 0x6f, 0x7d, 0xe7, 0xa9, 0x97, 0x8a,
+	0x7b, 0x4c, 0x61, 0xc8, 0x19, 0xd4, 0x9c, 0x79, 0x18, 0xa2, 0x2f, 0x6d, 0xf3, 0x59, 0x78, 0x06,
+	0xa3, 0x97, 0x40, 0xf2, 0x03, 0x7a, 0xe9, 0x26, 0xa8, 0x03, 0xfb, 0x9b, 0xac, 0xd5, 0x21, 0x18,
+	0x85, 0x21, 0x1c, 0x80, 0xe5, 0x88, 0xb9, 0x36, 0x65, 0xb1, 0x34, 0x48, 0x45, 0xdc, 0x19, 0x0f,
+	0xe3, 0xe4, 0x58, 0xcb, 0x99, 0x48, 0x96, 0xa1, 0x53, 0xd8, 0xdb, 0x50, 0xb8, 0x84, 0x46, 0x66,
+	0x23, 0x3b, 0xdb, 0x6d, 0x2d, 0xe6, 0xc0, 0x35, 0x25, 0x73, 0x43, 0xe9, 0x15, 0x34, 0x19, 0x3e,
+	0x26, 0x6c, 0xf5, 0x15, 0xcc, 0xaf, 0xc4, 0x58, 0xbd, 0x12, 0x7a, 0x02, 0x2d, 0x86, 0x8f, 0xcb,
+	0x4f, 0xe7, 0x36, 0xdc, 0xf9, 0x03, 0xd4, 0xf4, 0xcf, 0x85, 0xbc, 0x85, 0xea, 0x28, 0xba, 0x8d,
+	0x7d, 0x87, 0xec, 0x68, 0x9b, 0x89, 0x90, 0xeb, 0x75, 0xf7, 0x74, 0x38, 0x8a, 0x86, 0xc8, 0x3d,
+	0x39, 0x8d, 0x69, 0x89, 0x5c, 0x40, 0xf3, 0x23, 0xca, 0xa5, 0x8d, 0x35, 0xc6, 0x7e, 0xde, 0xa7,
+	0x3b, 0xe1, 0x52, 0x84, 0xb7, 0x28, 0x69, 0x69, 0x5c, 0x55, 0x3f, 0xa6, 0x8b, 0xbf, 0x03, 0x00,
+	0xe9, 0xd2, 0x87, 0x3e, 0xd0, 0x06, 0x00, 0x00,
 }
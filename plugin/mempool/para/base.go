@@ -2,32 +2,55 @@ package para
 
 import (
 	"context"
+	"errors"
 	"sync/atomic"
+	"time"
 
 	"sync"
 
+	dbm "github.com/33cn/chain33/common/db"
 	log "github.com/33cn/chain33/common/log/log15"
 	"github.com/33cn/chain33/queue"
-	"github.com/33cn/chain33/rpc/grpcclient"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/dapp/paracross/mainclient"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var mlog = log.New("module", "mempool.para")
 var topic = "mempool"
 
+var (
+	sendTimeout   = 10 * time.Second
+	retryTick     = time.Second
+	lastTxCount   = 10
+	queueDBName   = "paramempool"
+	queueDBCache  = int32(16)
+	defaultDriver = "leveldb"
+)
+
 //Mempool mempool 基础类
 type Mempool struct {
 	key         string
 	wg          sync.WaitGroup
 	client      queue.Client
+	cliMu       sync.Mutex
 	mainGrpcCli types.Chain33Client
 	isclose     int32
+	cfg         *types.Mempool
+	subCfg      subConfig
+	db          dbm.DB
+	txs         *txQueue
+	done        chan struct{}
 }
 
 //NewMempool 新建mempool 实例
 func NewMempool(cfg *types.Mempool) *Mempool {
 	pool := &Mempool{}
 	pool.key = topic
+	pool.cfg = cfg
+	pool.done = make(chan struct{})
+	pool.subCfg.setDefault(cfg)
 	return pool
 }
 
@@ -36,39 +59,233 @@ func (mem *Mempool) SetQueueClient(client queue.Client) {
 	mem.client = client
 	mem.client.Sub(mem.key)
 	mem.setMainGrpcCli(client.GetConfig())
-	mem.wg.Add(1)
+	mem.db = openQueueDB(client.GetConfig())
+	mem.txs = newTxQueue(mem.db, &mem.subCfg)
+	mem.wg.Add(2)
+	go mem.retryLoop()
 	go func() {
 		defer mem.wg.Done()
 		for msg := range client.Recv() {
 			var err error
 			var reply interface{}
+			ty := int64(types.EventReply)
 			switch msg.Ty {
 			case types.EventTx:
 				mlog.Info("Receive msg from para mempool")
-				tx := msg.GetData().(*types.Transaction)
-				reply, err = mem.mainGrpcCli.SendTransaction(context.Background(), tx)
+				reply, err = mem.addTx(msg.GetData().(*types.Transaction))
 			case types.EventGetProperFee:
-				reply, err = mem.mainGrpcCli.GetProperFee(context.Background(), &types.ReqProperFee{})
+				var grpcCli types.Chain33Client
+				if grpcCli, err = mem.getMainGrpcCli(); err == nil {
+					reply, err = grpcCli.GetProperFee(context.Background(), &types.ReqProperFee{})
+				}
+			case types.EventGetMempool:
+				ty, reply = types.EventReplyTxList, &types.ReplyTxList{Txs: mem.txs.txs(0)}
+			case types.EventGetLastMempool:
+				ty, reply = types.EventReplyTxList, &types.ReplyTxList{Txs: mem.txs.lastTxs(lastTxCount)}
+			case types.EventGetMempoolSize:
+				ty, reply = types.EventMempoolSize, &types.MempoolSize{Size: mem.txs.size()}
+			case types.EventGetAddrTxs:
+				ty, reply = types.EventReplyAddrTxs, mem.txs.accTxs(msg.GetData().(*types.ReqAddrs))
+			case types.EventTxListByHash:
+				ty, reply = types.EventReplyTxList, mem.getTxListByHash(msg.GetData().(*types.ReqTxHashList))
+			case types.EventAddBlock:
+				mem.removeTxsOfBlock(msg.GetData().(*types.BlockDetail).Block)
+				continue
 			default:
-				msg.Reply(client.NewMessage(mem.key, types.EventReply, types.ErrActionNotSupport))
+				err = types.ErrActionNotSupport
 			}
 			if err != nil {
 				msg.Reply(client.NewMessage(mem.key, types.EventReply, err))
 			} else {
-				msg.Reply(client.NewMessage(mem.key, types.EventReply, reply))
+				msg.Reply(client.NewMessage(mem.key, ty, reply))
 			}
 		}
 	}()
 }
 
-func (mem *Mempool) setMainGrpcCli(cfg *types.Chain33Config) {
-	if cfg != nil && cfg.IsPara() {
-		grpcCli, err := grpcclient.NewMainChainClient(cfg, "")
-		if err != nil {
-			panic(err)
+//openQueueDB 本地交易队列和区块数据保存在同一个目录下
+func openQueueDB(cfg *types.Chain33Config) dbm.DB {
+	if cfg == nil {
+		return dbm.NewDB(queueDBName, "memdb", "", queueDBCache)
+	}
+	bcfg := cfg.GetModuleConfig().BlockChain
+	driver := bcfg.Driver
+	if driver == "" {
+		driver = defaultDriver
+	}
+	return dbm.NewDB(queueDBName, driver, bcfg.DbPath, queueDBCache)
+}
+
+//setMainGrpcCli 主链客户端创建失败时不退出，在下次发送交易时重试
+func (mem *Mempool) setMainGrpcCli(cfg *types.Chain33Config) error {
+	if mem.mainGrpcCli != nil || cfg == nil || !cfg.IsPara() {
+		return nil
+	}
+	grpcCli, err := mainclient.NewMainChainClient(cfg)
+	if err != nil {
+		mlog.Error("para mempool create main chain client failed", "err", err)
+		return err
+	}
+	mem.mainGrpcCli = grpcCli
+	return nil
+}
+
+//getMainGrpcCli 事件处理和重发交易的协程都会用到主链客户端
+func (mem *Mempool) getMainGrpcCli() (types.Chain33Client, error) {
+	mem.cliMu.Lock()
+	defer mem.cliMu.Unlock()
+	if mem.mainGrpcCli == nil {
+		if err := mem.setMainGrpcCli(mem.client.GetConfig()); err != nil {
+			return nil, err
+		}
+	}
+	if mem.mainGrpcCli == nil {
+		return nil, mainclient.ErrNoHealthyEndpoint
+	}
+	return mem.mainGrpcCli, nil
+}
+
+//sendTx 发送交易到主链，主链返回的错误信息转换为本地的错误
+func (mem *Mempool) sendTx(tx *types.Transaction) error {
+	grpcCli, err := mem.getMainGrpcCli()
+	if err != nil {
+		return mainclient.ErrNoHealthyEndpoint
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	reply, err := grpcCli.SendTransaction(ctx, tx)
+	if err != nil {
+		if isMainUnavailable(err) {
+			return err
+		}
+		return errors.New(status.Convert(err).Message())
+	}
+	if !reply.GetIsOk() {
+		return errors.New(string(reply.GetMsg()))
+	}
+	return nil
+}
+
+//isMainUnavailable 主链暂时不可用，交易留在本地队列中重发
+func isMainUnavailable(err error) bool {
+	if err == mainclient.ErrNoHealthyEndpoint {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+		return true
+	}
+	return false
+}
+
+//addTx 交易先发送到主链，主链暂时不可用时缓存在本地，主链拒绝的交易直接返回错误
+func (mem *Mempool) addTx(tx *types.Transaction) (*types.Reply, error) {
+	if !tx.CheckSign() {
+		return nil, types.ErrSign
+	}
+	if err := mem.txs.check(tx); err != nil {
+		return nil, err
+	}
+	sent := true
+	var lastErr string
+	err := mem.sendTx(tx)
+	switch {
+	case err == nil || err.Error() == types.ErrTxExist.Error():
+	case isMainUnavailable(err):
+		mlog.Error("para mempool send tx to main chain failed, wait to retry", "hash", types.CalcTxShortHash(tx.Hash()), "err", err)
+		sent, lastErr = false, err.Error()
+	default:
+		return nil, err
+	}
+	if err := mem.txs.push(tx, sent, lastErr, types.Now()); err != nil {
+		return nil, err
+	}
+	return &types.Reply{IsOk: true, Msg: tx.Hash()}, nil
+}
+
+func (mem *Mempool) retryLoop() {
+	defer mem.wg.Done()
+	ticker := time.NewTicker(retryTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-mem.done:
+			return
+		case <-ticker.C:
+			mem.resendTxs(types.Now())
+		}
+	}
+}
+
+//resendTxs 丢弃过期的交易，重发到期的交易：
+//还没有被主链接收的交易需要重新发送，已经被主链接收的交易也需要重发，防止主链mempool丢弃了交易，
+//主链返回ErrTxExist说明交易还在主链mempool中，返回ErrDupTx说明交易已经打包
+func (mem *Mempool) resendTxs(now time.Time) {
+	for _, item := range mem.txs.expired(now) {
+		if mem.txs.remove(item.hash) {
+			mlog.Error("para mempool drop expired tx", "hash", types.CalcTxShortHash([]byte(item.hash)), "retries", item.Retries, "lastErr", item.LastErr)
+		}
+	}
+	for _, item := range mem.txs.due(now) {
+		err := mem.sendTx(item.tx)
+		switch {
+		case err == nil || err.Error() == types.ErrTxExist.Error():
+			mem.txs.retried(item.hash, true, "", now)
+		case err.Error() == types.ErrDupTx.Error():
+			mem.txs.remove(item.hash)
+		case isMainUnavailable(err):
+			mem.txs.retried(item.hash, item.Sent, err.Error(), now)
+		default:
+			mlog.Error("para mempool drop tx rejected by main chain", "hash", types.CalcTxShortHash([]byte(item.hash)), "err", err)
+			mem.txs.remove(item.hash)
+		}
+	}
+}
+
+//removeTxsOfBlock 平行链区块中的交易已经确认，从本地队列删除
+func (mem *Mempool) removeTxsOfBlock(block *types.Block) {
+	for _, tx := range block.GetTxs() {
+		mem.txs.remove(string(tx.Hash()))
+	}
+}
+
+//TxStatus 本地队列中交易的发送状态，Sent表示主链已经接收，LastErr为最近一次发送失败的原因
+type TxStatus struct {
+	Tx      *types.Transaction
+	Sent    bool
+	Retries int64
+	LastErr string
+}
+
+//GetTxStatusByHash 按hash查询本地队列中交易的发送状态，不在队列中的交易对应nil
+func (mem *Mempool) GetTxStatusByHash(hashList *types.ReqTxHashList) []*TxStatus {
+	status := make([]*TxStatus, 0, len(hashList.GetHashes()))
+	for _, hash := range hashList.GetHashes() {
+		var item *txItem
+		if hashList.GetIsShortHash() {
+			item = mem.txs.itemByShortHash(hash)
+		} else {
+			item = mem.txs.item([]byte(hash))
 		}
-		mem.mainGrpcCli = grpcCli
+		if item == nil {
+			status = append(status, nil)
+			continue
+		}
+		status = append(status, &TxStatus{Tx: item.tx, Sent: item.Sent, Retries: item.Retries, LastErr: item.LastErr})
+	}
+	return status
+}
+
+func (mem *Mempool) getTxListByHash(hashList *types.ReqTxHashList) *types.ReplyTxList {
+	var reply types.ReplyTxList
+	for _, status := range mem.GetTxStatusByHash(hashList) {
+		if status == nil {
+			reply.Txs = append(reply.Txs, nil)
+			continue
+		}
+		reply.Txs = append(reply.Txs, status.Tx)
 	}
+	return &reply
 }
 
 // Wait for ready
@@ -79,11 +296,15 @@ func (mem *Mempool) Close() {
 	if !atomic.CompareAndSwapInt32(&mem.isclose, 0, 1) {
 		return
 	}
+	close(mem.done)
 	if mem.client != nil {
 		mem.client.Close()
 	}
 	// wait for cycle quit
 	mlog.Info("para mempool module closing")
 	mem.wg.Wait()
+	if mem.db != nil {
+		mem.db.Close()
+	}
 	mlog.Info("para mempool module closed")
 }
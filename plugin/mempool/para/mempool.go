@@ -13,7 +13,10 @@ func init() {
 	drivers.Reg("para", New)
 }
 
-//New 创建price cache 结构的 mempool
+//New 创建平行链 mempool，交易缓存在本地队列中转发到主链
 func New(cfg *types.Mempool, sub []byte) queue.Module {
-	return NewMempool(cfg)
+	pool := NewMempool(cfg)
+	types.MustDecode(sub, &pool.subCfg)
+	pool.subCfg.setDefault(cfg)
+	return pool
 }
@@ -44,7 +44,11 @@ func TestParaNodeMempool(t *testing.T) {
 	hash := mockpara.Para.SendTx(tx)
 	assert.Equal(t, tx.Hash(), hash)
 
-	_, err := mockpara.Para.GetAPI().GetMempool(&types.ReqGetMempool{})
-	assert.Equal(t, err, types.ErrActionNotSupport)
-	t.Log(err)
+	//交易在平行链区块确认之前保存在本地队列中
+	reply, err := mockpara.Para.GetAPI().GetMempool(&types.ReqGetMempool{})
+	assert.Nil(t, err)
+	assert.Len(t, reply.Txs, 1)
+	assert.Equal(t, tx.Hash(), reply.Txs[0].Hash())
+	_, err = mockpara.Para.GetAPI().GetLastMempool()
+	assert.Nil(t, err)
 }
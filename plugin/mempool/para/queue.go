@@ -0,0 +1,344 @@
+package para
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+)
+
+const (
+	defaultPoolCacheSize      = 10240
+	defaultMaxTxNumPerAccount = 100
+	defaultRetryInterval      = 2
+	defaultMaxRetryInterval   = 120
+	defaultTxExpireSeconds    = 3600
+)
+
+var txKeyPrefix = []byte("mempool-para-tx-")
+
+type subConfig struct {
+	//本地最多缓存的交易数
+	PoolCacheSize int64 `json:"poolCacheSize"`
+	//每个账户最多缓存的交易数
+	MaxTxNumPerAccount int64 `json:"maxTxNumPerAccount"`
+	//发送主链失败后的首次重试间隔，单位秒，之后每次重试间隔加倍
+	RetryInterval int64 `json:"retryInterval"`
+	//最大重试间隔，单位秒
+	MaxRetryInterval int64 `json:"maxRetryInterval"`
+	//交易在本地缓存的最长时间，单位秒，超时还没有被确认的交易会被丢弃
+	TxExpireSeconds int64 `json:"txExpireSeconds"`
+}
+
+func (c *subConfig) setDefault(cfg *types.Mempool) {
+	if c.PoolCacheSize <= 0 && cfg != nil {
+		c.PoolCacheSize = cfg.PoolCacheSize
+	}
+	if c.PoolCacheSize <= 0 {
+		c.PoolCacheSize = defaultPoolCacheSize
+	}
+	if c.MaxTxNumPerAccount <= 0 && cfg != nil {
+		c.MaxTxNumPerAccount = cfg.MaxTxNumPerAccount
+	}
+	if c.MaxTxNumPerAccount <= 0 {
+		c.MaxTxNumPerAccount = defaultMaxTxNumPerAccount
+	}
+	if c.RetryInterval <= 0 {
+		c.RetryInterval = defaultRetryInterval
+	}
+	if c.MaxRetryInterval < c.RetryInterval {
+		c.MaxRetryInterval = defaultMaxRetryInterval
+		if c.MaxRetryInterval < c.RetryInterval {
+			c.MaxRetryInterval = c.RetryInterval
+		}
+	}
+	if c.TxExpireSeconds <= 0 {
+		c.TxExpireSeconds = defaultTxExpireSeconds
+	}
+}
+
+//txItem 本地缓存的交易，Sent表示主链已经接收，等待打包确认
+type txItem struct {
+	Tx        []byte `json:"tx"`
+	From      string `json:"from"`
+	EnterTime int64  `json:"enterTime"`
+	Retries   int64  `json:"retries"`
+	NextRetry int64  `json:"nextRetry"`
+	Sent      bool   `json:"sent"`
+	LastErr   string `json:"lastErr,omitempty"`
+
+	tx   *types.Transaction
+	hash string
+}
+
+func (item *txItem) clone() *txItem {
+	cp := *item
+	return &cp
+}
+
+//txQueue 平行链交易的本地缓存，持久化到数据库，重启后继续发送
+type txQueue struct {
+	mu    sync.Mutex
+	db    dbm.DB
+	cfg   *subConfig
+	items map[string]*txItem
+	accs  map[string]int64
+}
+
+func newTxQueue(db dbm.DB, cfg *subConfig) *txQueue {
+	q := &txQueue{db: db, cfg: cfg, items: make(map[string]*txItem), accs: make(map[string]int64)}
+	for _, value := range dbm.NewListHelper(db).PrefixScan(txKeyPrefix) {
+		item := &txItem{}
+		if err := json.Unmarshal(value, item); err != nil {
+			mlog.Error("newTxQueue decode item", "err", err)
+			continue
+		}
+		tx := &types.Transaction{}
+		if err := types.Decode(item.Tx, tx); err != nil {
+			mlog.Error("newTxQueue decode tx", "err", err)
+			continue
+		}
+		item.tx = tx
+		item.hash = string(tx.Hash())
+		q.items[item.hash] = item
+		q.accs[item.From]++
+	}
+	mlog.Info("para mempool load txs", "count", len(q.items))
+	return q
+}
+
+func txKey(hash string) []byte {
+	return append(append([]byte{}, txKeyPrefix...), hash...)
+}
+
+func (q *txQueue) save(item *txItem) {
+	value, err := json.Marshal(item)
+	if err != nil {
+		mlog.Error("txQueue save", "err", err)
+		return
+	}
+	if err := q.db.Set(txKey(item.hash), value); err != nil {
+		mlog.Error("txQueue save", "err", err)
+	}
+}
+
+//check 交易进入队列前检查是否重复和队列容量
+func (q *txQueue) check(tx *types.Transaction) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.checkTx(tx)
+}
+
+func (q *txQueue) checkTx(tx *types.Transaction) error {
+	if _, ok := q.items[string(tx.Hash())]; ok {
+		return types.ErrTxExist
+	}
+	if int64(len(q.items)) >= q.cfg.PoolCacheSize {
+		return types.ErrMemFull
+	}
+	if q.accs[tx.From()] >= q.cfg.MaxTxNumPerAccount {
+		return types.ErrManyTx
+	}
+	return nil
+}
+
+//push 交易进入队列，sent为false的交易在下一个重试周期发送
+func (q *txQueue) push(tx *types.Transaction, sent bool, lastErr string, now time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.checkTx(tx); err != nil {
+		return err
+	}
+	item := &txItem{
+		Tx:        types.Encode(tx),
+		From:      tx.From(),
+		EnterTime: now.Unix(),
+		Sent:      sent,
+		LastErr:   lastErr,
+		tx:        tx,
+		hash:      string(tx.Hash()),
+	}
+	item.NextRetry = now.Add(q.backoff(0)).Unix()
+	q.items[item.hash] = item
+	q.accs[item.From]++
+	q.save(item)
+	return nil
+}
+
+func (q *txQueue) remove(hash string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	item, ok := q.items[hash]
+	if !ok {
+		return false
+	}
+	delete(q.items, hash)
+	q.accs[item.From]--
+	if q.accs[item.From] <= 0 {
+		delete(q.accs, item.From)
+	}
+	if err := q.db.Delete(txKey(hash)); err != nil {
+		mlog.Error("txQueue remove", "err", err)
+	}
+	return true
+}
+
+//backoff 第retries次重试前等待的时间
+func (q *txQueue) backoff(retries int64) time.Duration {
+	interval := q.cfg.RetryInterval
+	for i := int64(0); i < retries && interval < q.cfg.MaxRetryInterval; i++ {
+		interval *= 2
+	}
+	if interval > q.cfg.MaxRetryInterval {
+		interval = q.cfg.MaxRetryInterval
+	}
+	return time.Duration(interval) * time.Second
+}
+
+//retried 记录一次重试的结果
+func (q *txQueue) retried(hash string, sent bool, lastErr string, now time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	//重试期间可能已经被区块确认
+	item, ok := q.items[hash]
+	if !ok {
+		return
+	}
+	item.Retries++
+	item.Sent = sent
+	item.LastErr = lastErr
+	item.NextRetry = now.Add(q.backoff(item.Retries)).Unix()
+	q.save(item)
+}
+
+//expired 超过缓存时间的交易
+func (q *txQueue) expired(now time.Time) (items []*txItem) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, item := range q.items {
+		if now.Unix()-item.EnterTime > q.cfg.TxExpireSeconds {
+			items = append(items, item.clone())
+		}
+	}
+	return items
+}
+
+//due 到了重试时间的交易，按进入队列的顺序返回
+func (q *txQueue) due(now time.Time) (items []*txItem) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, item := range q.items {
+		if item.NextRetry <= now.Unix() {
+			items = append(items, item.clone())
+		}
+	}
+	sortItems(items)
+	return items
+}
+
+func sortItems(items []*txItem) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].EnterTime != items[j].EnterTime {
+			return items[i].EnterTime < items[j].EnterTime
+		}
+		return items[i].hash < items[j].hash
+	})
+}
+
+func (q *txQueue) sorted() []*txItem {
+	items := make([]*txItem, 0, len(q.items))
+	for _, item := range q.items {
+		items = append(items, item)
+	}
+	sortItems(items)
+	return items
+}
+
+func (q *txQueue) size() int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return int64(len(q.items))
+}
+
+//item 返回队列中交易的副本，不存在返回nil
+func (q *txQueue) item(hash []byte) *txItem {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if item, ok := q.items[string(hash)]; ok {
+		return item.clone()
+	}
+	return nil
+}
+
+func (q *txQueue) itemByShortHash(shash string) *txItem {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for hash, item := range q.items {
+		if types.CalcTxShortHash([]byte(hash)) == shash {
+			return item.clone()
+		}
+	}
+	return nil
+}
+
+func (q *txQueue) get(hash []byte) *types.Transaction {
+	if item := q.item(hash); item != nil {
+		return item.tx
+	}
+	return nil
+}
+
+//txs 按进入队列的顺序返回缓存的交易，count<=0返回全部
+func (q *txQueue) txs(count int) []*types.Transaction {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var txs []*types.Transaction
+	for _, item := range q.sorted() {
+		if count > 0 && len(txs) >= count {
+			break
+		}
+		txs = append(txs, item.tx)
+	}
+	return txs
+}
+
+//lastTxs 最新进入队列的count笔交易
+func (q *txQueue) lastTxs(count int) []*types.Transaction {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	items := q.sorted()
+	var txs []*types.Transaction
+	for i := len(items) - 1; i >= 0 && len(txs) < count; i-- {
+		txs = append(txs, items[i].tx)
+	}
+	return txs
+}
+
+func (q *txQueue) accTxs(addrs *types.ReqAddrs) *types.TransactionDetails {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	addrMap := make(map[string]bool)
+	for _, addr := range addrs.GetAddrs() {
+		addrMap[addr] = true
+	}
+	details := &types.TransactionDetails{}
+	for _, item := range q.sorted() {
+		if !addrMap[item.From] {
+			continue
+		}
+		amount, err := item.tx.Amount()
+		if err != nil {
+			amount = 0
+		}
+		details.Txs = append(details.Txs, &types.TransactionDetail{
+			Tx:         item.tx,
+			Amount:     amount,
+			Fromaddr:   item.From,
+			ActionName: item.tx.ActionName(),
+		})
+	}
+	return details
+}
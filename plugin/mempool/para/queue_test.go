@@ -0,0 +1,168 @@
+package para
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	typesmocks "github.com/33cn/chain33/types/mocks"
+	"github.com/33cn/chain33/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testCfg = types.NewChain33Config(types.GetDefaultCfgstring())
+
+func newTestMempool(cli types.Chain33Client, db dbm.DB) *Mempool {
+	mem := NewMempool(&types.Mempool{PoolCacheSize: 10, MaxTxNumPerAccount: 2})
+	mem.mainGrpcCli = cli
+	mem.db = db
+	mem.txs = newTxQueue(db, &mem.subCfg)
+	return mem
+}
+
+func TestAddTx(t *testing.T) {
+	cli := new(typesmocks.Chain33Client)
+	mem := newTestMempool(cli, dbm.NewDB("test", "memdb", "", 16))
+	tx1 := util.CreateNoneTx(testCfg, util.TestPrivkeyList[0])
+	tx2 := util.CreateNoneTx(testCfg, util.TestPrivkeyList[0])
+	tx3 := util.CreateNoneTx(testCfg, util.TestPrivkeyList[0])
+	tx4 := util.CreateNoneTx(testCfg, util.TestPrivkeyList[1])
+	cli.On("SendTransaction", mock.Anything, tx1).Return(nil, status.Error(codes.Unavailable, "connection refused"))
+	cli.On("SendTransaction", mock.Anything, tx2).Return(&types.Reply{IsOk: true, Msg: tx2.Hash()}, nil)
+	cli.On("SendTransaction", mock.Anything, tx4).Return(nil, status.Error(codes.Unknown, types.ErrTxFeeTooLow.Error()))
+
+	//主链不可用时交易缓存在本地
+	reply, err := mem.addTx(tx1)
+	assert.Nil(t, err)
+	assert.Equal(t, tx1.Hash(), reply.Msg)
+	reply, err = mem.addTx(tx2)
+	assert.Nil(t, err)
+	assert.Equal(t, tx2.Hash(), reply.Msg)
+	assert.Equal(t, int64(2), mem.txs.size())
+	assert.False(t, mem.txs.items[string(tx1.Hash())].Sent)
+	assert.True(t, mem.txs.items[string(tx2.Hash())].Sent)
+
+	//主链拒绝的交易不缓存
+	_, err = mem.addTx(tx4)
+	assert.Equal(t, types.ErrTxFeeTooLow.Error(), err.Error())
+	_, err = mem.addTx(tx1)
+	assert.Equal(t, types.ErrTxExist, err)
+	_, err = mem.addTx(tx3)
+	assert.Equal(t, types.ErrManyTx, err)
+	tx1.Signature.Signature[0]++
+	_, err = mem.addTx(tx1)
+	assert.Equal(t, types.ErrSign, err)
+	tx1.Signature.Signature[0]--
+
+	//查询本地缓存的交易
+	assert.Len(t, mem.txs.txs(0), 2)
+	assert.Len(t, mem.txs.lastTxs(1), 1)
+	details := mem.txs.accTxs(&types.ReqAddrs{Addrs: []string{tx1.From()}})
+	assert.Len(t, details.Txs, 2)
+	assert.Equal(t, tx1.From(), details.Txs[0].Fromaddr)
+	list := mem.getTxListByHash(&types.ReqTxHashList{Hashes: []string{string(tx1.Hash()), string(tx4.Hash())}})
+	assert.Equal(t, tx1.Hash(), list.Txs[0].Hash())
+	assert.Nil(t, list.Txs[1])
+	list = mem.getTxListByHash(&types.ReqTxHashList{Hashes: []string{types.CalcTxShortHash(tx2.Hash())}, IsShortHash: true})
+	assert.Equal(t, tx2.Hash(), list.Txs[0].Hash())
+	txStatus := mem.GetTxStatusByHash(&types.ReqTxHashList{Hashes: []string{string(tx1.Hash()), string(tx2.Hash()), string(tx4.Hash())}})
+	assert.Len(t, txStatus, 3)
+	assert.False(t, txStatus[0].Sent)
+	assert.Equal(t, "rpc error: code = Unavailable desc = connection refused", txStatus[0].LastErr)
+	assert.True(t, txStatus[1].Sent)
+	assert.Nil(t, txStatus[2])
+
+	//平行链区块确认后从本地删除
+	mem.removeTxsOfBlock(&types.Block{Txs: []*types.Transaction{tx1, tx4}})
+	assert.Equal(t, int64(1), mem.txs.size())
+	assert.Nil(t, mem.txs.get(tx1.Hash()))
+}
+
+func TestResendTxs(t *testing.T) {
+	cli := new(typesmocks.Chain33Client)
+	mem := newTestMempool(cli, dbm.NewDB("test", "memdb", "", 16))
+	mem.subCfg = subConfig{RetryInterval: 2, MaxRetryInterval: 5, TxExpireSeconds: 100}
+	mem.subCfg.setDefault(mem.cfg)
+	now := time.Unix(1000, 0)
+	tx1 := util.CreateNoneTx(testCfg, util.TestPrivkeyList[0])
+	tx2 := util.CreateNoneTx(testCfg, util.TestPrivkeyList[1])
+	tx3 := util.CreateNoneTx(testCfg, util.TestPrivkeyList[2])
+	assert.Nil(t, mem.txs.push(tx1, false, "", now))
+	assert.Nil(t, mem.txs.push(tx2, false, "", now))
+
+	//还没到重试时间
+	mem.resendTxs(now.Add(time.Second))
+	cli.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+
+	//主链仍然不可用，重试间隔加倍
+	cli.On("SendTransaction", mock.Anything, tx1).Return(nil, status.Error(codes.Unavailable, "connection refused")).Times(2)
+	cli.On("SendTransaction", mock.Anything, tx2).Return(nil, status.Error(codes.Unknown, types.ErrTxExpire.Error())).Once()
+	mem.resendTxs(now.Add(2 * time.Second))
+	item := mem.txs.items[string(tx1.Hash())]
+	assert.Equal(t, int64(1), item.Retries)
+	assert.Equal(t, now.Add(6*time.Second).Unix(), item.NextRetry)
+	//主链拒绝的交易被丢弃
+	assert.Nil(t, mem.txs.get(tx2.Hash()))
+
+	//重试间隔不超过最大值
+	mem.resendTxs(now.Add(6 * time.Second))
+	item = mem.txs.items[string(tx1.Hash())]
+	assert.Equal(t, int64(2), item.Retries)
+	assert.Equal(t, now.Add(11*time.Second).Unix(), item.NextRetry)
+	assert.Equal(t, "rpc error: code = Unavailable desc = connection refused", item.LastErr)
+	txStatus := mem.GetTxStatusByHash(&types.ReqTxHashList{Hashes: []string{types.CalcTxShortHash(tx1.Hash())}, IsShortHash: true})
+	assert.Equal(t, int64(2), txStatus[0].Retries)
+	assert.Equal(t, item.LastErr, txStatus[0].LastErr)
+
+	//主链接收后继续等待确认，主链打包后删除
+	cli.On("SendTransaction", mock.Anything, tx1).Return(&types.Reply{IsOk: true}, nil).Once()
+	mem.resendTxs(now.Add(11 * time.Second))
+	assert.True(t, mem.txs.items[string(tx1.Hash())].Sent)
+	cli.On("SendTransaction", mock.Anything, tx1).Return(nil, status.Error(codes.Unknown, types.ErrDupTx.Error())).Once()
+	mem.resendTxs(now.Add(20 * time.Second))
+	assert.Equal(t, int64(0), mem.txs.size())
+
+	//超过缓存时间的交易被丢弃
+	assert.Nil(t, mem.txs.push(tx3, false, "", now))
+	mem.resendTxs(now.Add(101 * time.Second))
+	assert.Equal(t, int64(0), mem.txs.size())
+	cli.AssertExpectations(t)
+}
+
+func TestQueueReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "paramempool")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := &subConfig{}
+	cfg.setDefault(nil)
+	db := dbm.NewDB("paramempool", "leveldb", dir, 16)
+	q := newTxQueue(db, cfg)
+	tx1 := util.CreateNoneTx(testCfg, util.TestPrivkeyList[0])
+	tx2 := util.CreateNoneTx(testCfg, util.TestPrivkeyList[1])
+	assert.Nil(t, q.push(tx1, true, "", time.Unix(1000, 0)))
+	assert.Nil(t, q.push(tx2, false, "ErrNoHealthyEndpoint", time.Unix(1001, 0)))
+	q.retried(string(tx2.Hash()), false, "ErrNoHealthyEndpoint", time.Unix(1003, 0))
+	db.Close()
+
+	//重启后恢复本地缓存的交易
+	db = dbm.NewDB("paramempool", "leveldb", dir, 16)
+	defer db.Close()
+	q = newTxQueue(db, cfg)
+	assert.Equal(t, int64(2), q.size())
+	txs := q.txs(0)
+	assert.Equal(t, tx1.Hash(), txs[0].Hash())
+	assert.Equal(t, tx2.Hash(), txs[1].Hash())
+	item := q.items[string(tx2.Hash())]
+	assert.False(t, item.Sent)
+	assert.Equal(t, int64(1), item.Retries)
+	assert.Equal(t, int64(1007), item.NextRetry)
+	assert.True(t, q.remove(string(tx1.Hash())))
+	assert.Equal(t, types.ErrTxExist, q.check(tx2))
+}
@@ -5,4 +5,5 @@ import (
 	_ "github.com/33cn/plugin/plugin/store/kvmvcc"     //auto gen
 	_ "github.com/33cn/plugin/plugin/store/kvmvccmavl" //auto gen
 	_ "github.com/33cn/plugin/plugin/store/mpt"        //auto gen
+	_ "github.com/33cn/plugin/plugin/store/snapshot"   //auto gen
 )
@@ -5,17 +5,25 @@
 package kvdb
 
 import (
+	"bytes"
+
 	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
 	clog "github.com/33cn/chain33/common/log"
 	log "github.com/33cn/chain33/common/log/log15"
 	"github.com/33cn/chain33/queue"
 	drivers "github.com/33cn/chain33/system/store"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot/core"
+	sty "github.com/33cn/plugin/plugin/store/snapshot/types"
 	"github.com/golang/protobuf/proto"
 )
 
 var klog = log.New("module", "kvdb")
 
+//kvdb只保存最新的状态，记录最新状态对应的statehash，遍历时只支持最新的状态
+var lastStateHashKey = []byte("kvdb-last-state-hash")
+
 // SetLogLevel set log level
 func SetLogLevel(level string) {
 	clog.SetLogLevel(level)
@@ -33,13 +41,15 @@ func init() {
 // KVStore implementation
 type KVStore struct {
 	*drivers.BaseStore
-	cache map[string]map[string]*types.KeyValue
+	cache    map[string]map[string]*types.KeyValue
+	snapshot *core.Server
 }
 
 // New KVStore module
 func New(cfg *types.Store, sub []byte, chain33cfg *types.Chain33Config) queue.Module {
 	bs := drivers.NewBaseStore(cfg)
-	kvs := &KVStore{bs, make(map[string]map[string]*types.KeyValue)}
+	kvs := &KVStore{BaseStore: bs, cache: make(map[string]map[string]*types.KeyValue)}
+	kvs.snapshot = core.NewServer("kvdb", kvs)
 	bs.SetChild(kvs)
 	return kvs
 }
@@ -57,7 +67,7 @@ func (kvs *KVStore) Set(datas *types.StoreSet, sync bool) ([]byte, error) {
 	for _, kv := range datas.KV {
 		kvmap[string(kv.Key)] = kv
 	}
-	kvs.save(kvmap)
+	kvs.save(hash, kvmap)
 	return hash, nil
 }
 
@@ -116,7 +126,7 @@ func (kvs *KVStore) Commit(req *types.ReqHash) ([]byte, error) {
 		delete(kvs.cache, string(req.Hash))
 		return req.Hash, nil
 	}
-	kvs.save(kvmap)
+	kvs.save(req.Hash, kvmap)
 	delete(kvs.cache, string(req.Hash))
 	return req.Hash, nil
 }
@@ -144,11 +154,92 @@ func (kvs *KVStore) Rollback(req *types.ReqHash) ([]byte, error) {
 	return req.Hash, nil
 }
 
-// IterateRangeByStateHash method
+// IterateRangeByStateHash 按照kv最新值进行遍历，要求statehash必须是最新提交的statehash，否则不支持该接口
 func (kvs *KVStore) IterateRangeByStateHash(statehash []byte, start []byte, end []byte, ascending bool, fn func(key, value []byte) bool) {
-	panic("empty")
-	//TODO:
-	//kvs.IterateRangeByStateHash(mavls.GetDB(), statehash, start, end, ascending, fn)
+	lastHash, err := kvs.lastStateHash()
+	if err != nil {
+		klog.Error("KVStore IterateRangeByStateHash can't get last state hash, ignore the call.", "err", err)
+		return
+	}
+	if !bytes.Equal(lastHash, statehash) {
+		klog.Error("KVStore IterateRangeByStateHash call failed for statehash is not the last one.", "lastStateHash", common.ToHex(lastHash), "stateHash", common.ToHex(statehash))
+		return
+	}
+	//区间为[start, end)，end为空时遍历start前缀的所有key
+	it := kvs.GetDB().Iterator(start, end, !ascending)
+	defer it.Close()
+	for it.Rewind(); it.Valid(); it.Next() {
+		if it.Error() != nil {
+			klog.Error("KVStore IterateRangeByStateHash", "err", it.Error())
+			return
+		}
+		if bytes.Equal(it.Key(), lastStateHashKey) {
+			continue
+		}
+		if fn(common.CopyBytes(it.Key()), it.ValueCopy()) {
+			return
+		}
+	}
+}
+
+func (kvs *KVStore) lastStateHash() ([]byte, error) {
+	lastHash, err := kvs.GetDB().Get(lastStateHashKey)
+	if err != nil && err != dbm.ErrNotFoundInDb {
+		return nil, err
+	}
+	if lastHash == nil {
+		//还没有提交过数据
+		lastHash = drivers.EmptyRoot[:]
+	}
+	return lastHash, nil
+}
+
+// IterateState 按key的顺序遍历最新的状态，用于导出状态快照，只支持最新提交的statehash
+func (kvs *KVStore) IterateState(stateHash, start []byte, fn func(key, value []byte) bool) error {
+	lastHash, err := kvs.lastStateHash()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(lastHash, stateHash) {
+		return sty.ErrSnapshotNotSupport
+	}
+	//end为EmptyValue时不限制遍历的上限
+	it := kvs.GetDB().Iterator(start, types.EmptyValue, false)
+	defer it.Close()
+	for it.Rewind(); it.Valid(); it.Next() {
+		if it.Error() != nil {
+			return it.Error()
+		}
+		if bytes.Equal(it.Key(), lastStateHashKey) {
+			continue
+		}
+		if fn(it.Key(), it.Value()) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// ImportState 把快照数据写入空的store，kvdb的statehash不依赖数据，直接记录为最新的statehash
+func (kvs *KVStore) ImportState(stateHash []byte, height int64, next func() ([]*types.KeyValue, error)) ([]byte, error) {
+	return core.ImportState(kvs, kvs.GetDB(), stateHash, height, next)
+}
+
+// WriteState 写入一批数据，kvdb没有状态根，返回root
+func (kvs *KVStore) WriteState(root []byte, height int64, kvset []*types.KeyValue) ([]byte, error) {
+	batch := kvs.GetDB().NewBatch(true)
+	for _, kv := range kvset {
+		batch.Set(kv.Key, kv.Value)
+	}
+	return root, batch.Write()
+}
+
+// FinishState 把stateHash记录为最新的statehash
+func (kvs *KVStore) FinishState(stateHash, root []byte, height int64) ([]byte, error) {
+	if err := kvs.GetDB().SetSync(lastStateHashKey, stateHash); err != nil {
+		return nil, err
+	}
+	return stateHash, nil
 }
 
 // ProcEvent handles supported events
@@ -156,6 +247,9 @@ func (kvs *KVStore) ProcEvent(msg *queue.Message) {
 	if msg == nil {
 		return
 	}
+	if kvs.snapshot.ProcEvent(kvs.GetQueueClient(), msg) {
+		return
+	}
 	msg.ReplyErr("KVStore", types.ErrActionNotSupport)
 }
 
@@ -165,8 +259,9 @@ func (kvs *KVStore) Del(req *types.StoreDel) ([]byte, error) {
 	return nil, nil
 }
 
-func (kvs *KVStore) save(kvmap map[string]*types.KeyValue) {
+func (kvs *KVStore) save(hash []byte, kvmap map[string]*types.KeyValue) {
 	storeBatch := kvs.GetDB().NewBatch(true)
+	storeBatch.Set(lastStateHashKey, hash)
 	for _, kv := range kvmap {
 		if kv.Value == nil {
 			storeBatch.Delete(kv.Key)
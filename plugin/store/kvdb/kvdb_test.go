@@ -5,13 +5,20 @@
 package kvdb
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/33cn/chain33/account"
+	"github.com/33cn/chain33/common"
 	drivers "github.com/33cn/chain33/system/store"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot/core"
+	sty "github.com/33cn/plugin/plugin/store/snapshot/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func newStoreCfg(dir string) *types.Store {
@@ -160,3 +167,156 @@ func TestKvdbRollback(t *testing.T) {
 	notExistHash, _ := store.Rollback(&types.ReqHash{Hash: drivers.EmptyRoot[:]})
 	assert.Nil(t, notExistHash)
 }
+
+func TestIterateRangeByStateHash(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	os.RemoveAll(dir)       //删除已存在目录
+	var storeCfg = newStoreCfg(dir)
+	store := New(storeCfg, nil, nil).(*KVStore)
+	assert.NotNil(t, store)
+
+	execaddr := "0111vcBNSEA7fZhAdLJphDwQRQJa111"
+	addr := "06htvcBNSEA7fZhAdLJphDwQRQJaHpy"
+	accCoin := account.NewCoinsAccount(types.NewChain33ConfigNoInit(types.GetDefaultCfgstring()))
+	var kv []*types.KeyValue
+	for i, balance := range []int64{700, 800, 1000, 900} {
+		acc := &types.Account{Balance: balance * 1e8, Addr: fmt.Sprintf("%d6htvcBNSEA7fZhAdLJphDwQRQJaHpyHTp", 4-i)}
+		set := accCoin.GetKVSet(acc)
+		kv = append(kv, &types.KeyValue{Key: set[0].GetKey(), Value: set[0].GetValue()})
+	}
+	set := accCoin.GetExecKVSet(execaddr, &types.Account{Balance: 700 * 1e8, Addr: addr})
+	kv = append(kv, &types.KeyValue{Key: set[0].GetKey(), Value: set[0].GetValue()})
+
+	//还没有提交数据时遍历空状态
+	resp := &types.ReplyGetTotalCoins{Count: 100000}
+	store.IterateRangeByStateHash(drivers.EmptyRoot[:], []byte("mavl-coins-bty-"), []byte("mavl-coins-bty-exec"), true, resp.IterateRangeByStateHash)
+	assert.Equal(t, int64(0), resp.Num)
+
+	datas := &types.StoreSet{StateHash: drivers.EmptyRoot[:], KV: kv, Height: 0}
+	hash, err := store.MemSet(datas, true)
+	assert.Nil(t, err)
+	_, err = store.Commit(&types.ReqHash{Hash: hash})
+	assert.Nil(t, err)
+
+	resp = &types.ReplyGetTotalCoins{Count: 100000}
+	store.IterateRangeByStateHash(hash, []byte("mavl-coins-bty-"), []byte("mavl-coins-bty-exec"), true, resp.IterateRangeByStateHash)
+	assert.Equal(t, int64(4), resp.Num)
+	assert.Equal(t, int64(340000000000), resp.Amount)
+
+	hash1 := hash
+	for i := 1; i <= 10; i++ {
+		acc := &types.Account{Balance: (1000 + int64(i)) * 1e8, Addr: addr + fmt.Sprintf("%03d", 11-i)}
+		set := accCoin.GetKVSet(acc)
+		datas1 := &types.StoreSet{StateHash: hash1, KV: set, Height: int64(i)}
+		hash1, err = store.MemSet(datas1, true)
+		assert.Nil(t, err)
+		_, err = store.Commit(&types.ReqHash{Hash: hash1})
+		assert.Nil(t, err)
+	}
+
+	resp = &types.ReplyGetTotalCoins{Count: 100000}
+	store.IterateRangeByStateHash(hash1, []byte("mavl-coins-bty-"), []byte("mavl-coins-bty-exec"), true, resp.IterateRangeByStateHash)
+	assert.Equal(t, int64(14), resp.Num)
+	assert.Equal(t, int64(1345500000000), resp.Amount)
+
+	resp = &types.ReplyGetTotalCoins{Count: 2}
+	store.IterateRangeByStateHash(hash1, []byte("mavl-coins-bty-06htvcBNSEA7fZhAdLJphDwQRQJaHpy003"), []byte("mavl-coins-bty-exec"), true, resp.IterateRangeByStateHash)
+	assert.Equal(t, int64(2), resp.Num)
+	assert.Equal(t, int64(201500000000), resp.Amount)
+
+	//降序遍历
+	var keys []string
+	store.IterateRangeByStateHash(hash1, []byte("mavl-coins-bty-"), []byte("mavl-coins-bty-exec"), false, func(key, value []byte) bool {
+		keys = append(keys, string(key))
+		return len(keys) == 3
+	})
+	assert.Equal(t, []string{"mavl-coins-bty-46htvcBNSEA7fZhAdLJphDwQRQJaHpyHTp", "mavl-coins-bty-36htvcBNSEA7fZhAdLJphDwQRQJaHpyHTp",
+		"mavl-coins-bty-26htvcBNSEA7fZhAdLJphDwQRQJaHpyHTp"}, keys)
+
+	//历史状态和没有提交的状态不支持遍历
+	resp = &types.ReplyGetTotalCoins{Count: 100000}
+	store.IterateRangeByStateHash(hash, []byte("mavl-coins-bty-"), []byte("mavl-coins-bty-exec"), true, resp.IterateRangeByStateHash)
+	assert.Equal(t, int64(0), resp.Num)
+	hash2, err := store.MemSet(&types.StoreSet{StateHash: hash1, KV: kv[:1], Height: 11}, true)
+	assert.Nil(t, err)
+	store.IterateRangeByStateHash(hash2, []byte("mavl-coins-bty-"), []byte("mavl-coins-bty-exec"), true, resp.IterateRangeByStateHash)
+	assert.Equal(t, int64(0), resp.Num)
+	store.Close()
+
+	//重启后仍然可以遍历最新的状态
+	store = New(storeCfg, nil, nil).(*KVStore)
+	defer store.Close()
+	resp = &types.ReplyGetTotalCoins{Count: 100000}
+	store.IterateRangeByStateHash(hash1, []byte("mavl-coins-bty-"), []byte("mavl-coins-bty-exec"), true, resp.IterateRangeByStateHash)
+	assert.Equal(t, int64(14), resp.Num)
+}
+
+func iterateAll(t *testing.T, exp core.Exporter, stateHash []byte) []*types.KeyValue {
+	var kvs []*types.KeyValue
+	err := exp.IterateState(stateHash, nil, func(key, value []byte) bool {
+		kvs = append(kvs, &types.KeyValue{Key: common.CopyBytes(key), Value: common.CopyBytes(value)})
+		return false
+	})
+	require.Nil(t, err)
+	return kvs
+}
+
+func TestSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	store := New(newStoreCfg(filepath.Join(dir, "src")), nil, nil).(*KVStore)
+	defer store.Close()
+
+	hash := drivers.EmptyRoot[:]
+	for i := 0; i < 3; i++ {
+		var kv []*types.KeyValue
+		for j := 0; j < 10; j++ {
+			kv = append(kv, &types.KeyValue{Key: []byte(fmt.Sprintf("key-%02d", i*5+j)), Value: []byte(fmt.Sprintf("value-%d-%d", i, j))})
+		}
+		if i == 2 {
+			kv[0].Value = nil
+		}
+		hash, err = store.MemSet(&types.StoreSet{StateHash: hash, KV: kv, Height: int64(i)}, true)
+		require.Nil(t, err)
+		_, err = store.Commit(&types.ReqHash{Hash: hash})
+		require.Nil(t, err)
+	}
+
+	manifest, err := core.BuildManifest(store, "kvdb", hash, 2, 4)
+	require.Nil(t, err)
+	assert.Equal(t, int64(19), manifest.Total)
+	snapDir := filepath.Join(dir, "snapshot")
+	require.Nil(t, core.SaveManifest(snapDir, manifest))
+	for i := range manifest.Chunks {
+		chunk, err := core.ReadChunk(store, manifest, int32(i))
+		require.Nil(t, err)
+		require.Nil(t, core.SaveChunk(snapDir, chunk))
+	}
+
+	store2 := New(newStoreCfg(filepath.Join(dir, "dst")), nil, nil).(*KVStore)
+	defer store2.Close()
+	//kvdb导入后不能校验状态哈希，需要可信的清单哈希
+	_, _, err = core.Import(store2, "kvdb", snapDir, hash, nil)
+	assert.Equal(t, sty.ErrManifestUntrusted, err)
+	_, _, err = core.Import(store2, "kvdb", snapDir, hash, core.ManifestHash(manifest))
+	require.Nil(t, err)
+	assert.Equal(t, iterateAll(t, store, hash), iterateAll(t, store2, hash))
+	keys := [][]byte{[]byte("key-10"), []byte("key-11"), []byte("key-19")}
+	assert.Equal(t, store.Get(&types.StoreGet{StateHash: hash, Keys: keys}), store2.Get(&types.StoreGet{StateHash: hash, Keys: keys}))
+
+	//导入后可以继续执行区块
+	hash2, err := store2.MemSet(&types.StoreSet{StateHash: hash, KV: []*types.KeyValue{{Key: []byte("key-99"), Value: []byte("v")}}, Height: 3}, true)
+	require.Nil(t, err)
+	_, err = store2.Commit(&types.ReqHash{Hash: hash2})
+	require.Nil(t, err)
+	assert.Equal(t, 20, len(iterateAll(t, store2, hash2)))
+
+	_, _, err = core.Import(store2, "kvdb", snapDir, hash, core.ManifestHash(manifest))
+	assert.Equal(t, sty.ErrStoreNotEmpty, err)
+	//kvdb只支持最新状态的快照
+	err = store2.IterateState(hash, nil, func(key, value []byte) bool { return false })
+	assert.Equal(t, sty.ErrSnapshotNotSupport, err)
+}
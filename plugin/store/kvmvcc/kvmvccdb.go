@@ -12,6 +12,7 @@ import (
 	"github.com/33cn/chain33/queue"
 	drivers "github.com/33cn/chain33/system/store"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot/core"
 	"github.com/golang/protobuf/proto"
 )
 
@@ -38,6 +39,7 @@ type KVMVCCStore struct {
 	kvsetmap       map[string][]*types.KeyValue
 	enableMVCCIter bool
 	sync           bool
+	snapshot       *core.Server
 }
 
 type subConfig struct {
@@ -55,10 +57,11 @@ func New(cfg *types.Store, sub []byte, chain33cfg *types.Chain33Config) queue.Mo
 		enable = subcfg.EnableMVCCIter
 	}
 	if enable {
-		kvs = &KVMVCCStore{bs, dbm.NewMVCCIter(bs.GetDB()), make(map[string][]*types.KeyValue), true, false}
+		kvs = &KVMVCCStore{bs, dbm.NewMVCCIter(bs.GetDB()), make(map[string][]*types.KeyValue), true, false, nil}
 	} else {
-		kvs = &KVMVCCStore{bs, dbm.NewMVCC(bs.GetDB()), make(map[string][]*types.KeyValue), false, false}
+		kvs = &KVMVCCStore{bs, dbm.NewMVCC(bs.GetDB()), make(map[string][]*types.KeyValue), false, false, nil}
 	}
+	kvs.snapshot = core.NewServer("kvmvcc", kvs)
 	bs.SetChild(kvs)
 	return kvs
 }
@@ -199,9 +202,35 @@ func (mvccs *KVMVCCStore) ProcEvent(msg *queue.Message) {
 	if msg == nil {
 		return
 	}
+	if mvccs.snapshot.ProcEvent(mvccs.GetQueueClient(), msg) {
+		return
+	}
 	msg.ReplyErr("KVStore", types.ErrActionNotSupport)
 }
 
+// IterateState 遍历statehash对应版本的状态，用于导出状态快照
+func (mvccs *KVMVCCStore) IterateState(stateHash, start []byte, fn func(key, value []byte) bool) error {
+	return core.IterateMVCC(mvccs.GetDB(), mvccs.mvcc, stateHash, start, fn)
+}
+
+// ImportState 把快照数据写入空的store，作为快照高度的版本
+func (mvccs *KVMVCCStore) ImportState(stateHash []byte, height int64, next func() ([]*types.KeyValue, error)) ([]byte, error) {
+	return core.ImportState(mvccs, mvccs.GetDB(), stateHash, height, next)
+}
+
+// WriteState 把一批数据写入height版本，mvcc没有状态根，返回root
+func (mvccs *KVMVCCStore) WriteState(root []byte, height int64, kvs []*types.KeyValue) ([]byte, error) {
+	return root, core.WriteMVCC(mvccs.GetDB(), mvccs.mvcc, mvccs.enableMVCCIter, height, kvs)
+}
+
+// FinishState 记录stateHash对应的版本，之前没有版本数据，不能回滚到height之前
+func (mvccs *KVMVCCStore) FinishState(stateHash, root []byte, height int64) ([]byte, error) {
+	if err := mvccs.mvcc.SetVersion(stateHash, height); err != nil {
+		return nil, err
+	}
+	return stateHash, nil
+}
+
 // Del set kvs to nil with StateHash
 func (mvccs *KVMVCCStore) Del(req *types.StoreDel) ([]byte, error) {
 	kvset, err := mvccs.mvcc.DelMVCC(req.StateHash, req.Height, true)
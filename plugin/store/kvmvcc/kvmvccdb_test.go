@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -17,7 +18,10 @@ import (
 	"github.com/33cn/chain33/common"
 	drivers "github.com/33cn/chain33/system/store"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot/core"
+	sty "github.com/33cn/plugin/plugin/store/snapshot/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const MaxKeylenth int = 64
@@ -1021,3 +1025,81 @@ func BenchmarkIterCommit(b *testing.B) {
 	fmt.Println("kvmvcc BenchmarkCommit cost time is", end.Sub(start), "num is", b.N)
 	b.StopTimer()
 }
+
+func iterateAll(t *testing.T, exp core.Exporter, stateHash []byte) []*types.KeyValue {
+	var kvs []*types.KeyValue
+	err := exp.IterateState(stateHash, nil, func(key, value []byte) bool {
+		kvs = append(kvs, &types.KeyValue{Key: common.CopyBytes(key), Value: common.CopyBytes(value)})
+		return false
+	})
+	require.Nil(t, err)
+	return kvs
+}
+
+func TestSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	storeCfg, sub := newStoreCfgIter(filepath.Join(dir, "src"))
+	store := New(storeCfg, sub, nil).(*KVMVCCStore)
+	defer store.Close()
+
+	var hashes [][]byte
+	hash := drivers.EmptyRoot[:]
+	for i := 0; i < 3; i++ {
+		var kv []*types.KeyValue
+		for j := 0; j < 10; j++ {
+			kv = append(kv, &types.KeyValue{Key: []byte(fmt.Sprintf("key-%02d", i*5+j)), Value: []byte(fmt.Sprintf("value-%d-%d", i, j))})
+		}
+		if i == 2 {
+			//kvmvcc中空值表示删除
+			kv[0].Value = []byte{}
+		}
+		hash, err = store.MemSet(&types.StoreSet{StateHash: hash, KV: kv, Height: int64(i)}, true)
+		require.Nil(t, err)
+		_, err = store.Commit(&types.ReqHash{Hash: hash})
+		require.Nil(t, err)
+		hashes = append(hashes, hash)
+	}
+	//历史状态也可以导出
+	assert.Equal(t, 15, len(iterateAll(t, store, hashes[1])))
+	assert.Equal(t, 19, len(iterateAll(t, store, hash)))
+
+	manifest, err := core.BuildManifest(store, "kvmvcc", hash, 2, 4)
+	require.Nil(t, err)
+	assert.Equal(t, int64(19), manifest.Total)
+	snapDir := filepath.Join(dir, "snapshot")
+	require.Nil(t, core.SaveManifest(snapDir, manifest))
+	for i := range manifest.Chunks {
+		chunk, err := core.ReadChunk(store, manifest, int32(i))
+		require.Nil(t, err)
+		require.Nil(t, core.SaveChunk(snapDir, chunk))
+	}
+
+	storeCfg2, sub2 := newStoreCfgIter(filepath.Join(dir, "dst"))
+	store2 := New(storeCfg2, sub2, nil).(*KVMVCCStore)
+	defer store2.Close()
+	_, _, err = core.Import(store2, "kvmvcc", snapDir, hash, core.ManifestHash(manifest))
+	require.Nil(t, err)
+	assert.Equal(t, iterateAll(t, store, hash), iterateAll(t, store2, hash))
+	keys := [][]byte{[]byte("key-00"), []byte("key-19")}
+	assert.Equal(t, store.Get(&types.StoreGet{StateHash: hash, Keys: keys}), store2.Get(&types.StoreGet{StateHash: hash, Keys: keys}))
+	//删除的key不导入
+	assert.Nil(t, store2.Get(&types.StoreGet{StateHash: hash, Keys: [][]byte{[]byte("key-10")}})[0])
+
+	//导入后可以按最新值遍历和继续执行区块
+	var count int
+	store2.IterateRangeByStateHash(hash, []byte("key-"), nil, true, func(key, value []byte) bool {
+		count++
+		return false
+	})
+	assert.Equal(t, 19, count)
+	hash2, err := store2.MemSet(&types.StoreSet{StateHash: hash, KV: []*types.KeyValue{{Key: []byte("key-99"), Value: []byte("v")}}, Height: 3}, true)
+	require.Nil(t, err)
+	_, err = store2.Commit(&types.ReqHash{Hash: hash2})
+	require.Nil(t, err)
+	assert.Equal(t, 20, len(iterateAll(t, store2, hash2)))
+
+	_, _, err = core.Import(store2, "kvmvcc", snapDir, hash, core.ManifestHash(manifest))
+	assert.Equal(t, sty.ErrStoreNotEmpty, err)
+}
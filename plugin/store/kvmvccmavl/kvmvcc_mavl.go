@@ -21,6 +21,8 @@ import (
 	drivers "github.com/33cn/chain33/system/store"
 	mavl "github.com/33cn/chain33/system/store/mavl/db"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot/core"
+	sty "github.com/33cn/plugin/plugin/store/snapshot/types"
 	lru "github.com/hashicorp/golang-lru"
 )
 
@@ -74,7 +76,8 @@ type KVmMavlStore struct {
 	*drivers.BaseStore
 	*KVMVCCStore
 	*MavlStore
-	cache *lru.Cache
+	cache    *lru.Cache
+	snapshot *core.Server
 }
 
 type subKVMVCCConfig struct {
@@ -144,7 +147,8 @@ func New(cfg *types.Store, sub []byte, chain33cfg *types.Chain33Config) queue.Mo
 	}
 
 	kvms = &KVmMavlStore{bs, NewKVMVCC(&subKVMVCCcfg, bs.GetDB()),
-		NewMavl(&subMavlcfg, bs.GetDB()), cache}
+		NewMavl(&subMavlcfg, bs.GetDB()), cache, nil}
+	kvms.snapshot = core.NewServer("kvmvccmavl", kvms)
 	// 查询是否已经删除mavl
 	_, err = bs.GetDB().Get(genDelMavlKey(mvccPrefix))
 	if err == nil {
@@ -361,9 +365,51 @@ func (kvmMavls *KVmMavlStore) ProcEvent(msg *queue.Message) {
 	if msg == nil {
 		return
 	}
+	if kvmMavls.snapshot.ProcEvent(kvmMavls.GetQueueClient(), msg) {
+		return
+	}
 	msg.ReplyErr("KVmMavlStore", types.ErrActionNotSupport)
 }
 
+// IterateState 遍历statehash对应版本的状态，用于导出状态快照，只支持kvmvccMavlFork之后的状态
+func (kvmMavls *KVmMavlStore) IterateState(stateHash, start []byte, fn func(key, value []byte) bool) error {
+	if value, ok := kvmMavls.cache.Get(string(stateHash)); ok && value.(int64) < kvmvccMavlFork {
+		return sty.ErrSnapshotNotSupport
+	}
+	hash := stateHash
+	if kvmMavls.kvmvccCfg.EnableEmptyBlockHandle {
+		mvccHash, err := kvmMavls.KVMVCCStore.GetFirstHashRdm(stateHash)
+		if err == nil {
+			hash = mvccHash
+		}
+	}
+	return core.IterateMVCC(kvmMavls.KVMVCCStore.db, kvmMavls.KVMVCCStore.mvcc, hash, start, fn)
+}
+
+// ImportState 把快照数据写入空的store，快照高度需要不低于kvmvccMavlFork，导入后只使用kvmvcc
+func (kvmMavls *KVmMavlStore) ImportState(stateHash []byte, height int64, next func() ([]*types.KeyValue, error)) ([]byte, error) {
+	return core.ImportState(kvmMavls, kvmMavls.GetDB(), stateHash, height, next)
+}
+
+// WriteState 把一批数据写入kvmvcc的height版本，height需要不低于kvmvccMavlFork
+func (kvmMavls *KVmMavlStore) WriteState(root []byte, height int64, kvs []*types.KeyValue) ([]byte, error) {
+	if height < kvmvccMavlFork {
+		return nil, sty.ErrSnapshotNotSupport
+	}
+	return root, core.WriteMVCC(kvmMavls.KVMVCCStore.db, kvmMavls.KVMVCCStore.mvcc, kvmMavls.kvmvccCfg.EnableMVCCIter, height, kvs)
+}
+
+// FinishState 记录stateHash对应的kvmvcc版本，之前没有版本数据，不能回滚到height之前
+func (kvmMavls *KVmMavlStore) FinishState(stateHash, root []byte, height int64) ([]byte, error) {
+	if height < kvmvccMavlFork {
+		return nil, sty.ErrSnapshotNotSupport
+	}
+	if err := kvmMavls.KVMVCCStore.mvcc.SetVersion(stateHash, height); err != nil {
+		return nil, err
+	}
+	return stateHash, nil
+}
+
 // MemSetUpgrade set kvs to the mem of KVmMavlStore module  not cache the tree and return the StateHash
 func (kvmMavls *KVmMavlStore) MemSetUpgrade(datas *types.StoreSet, sync bool) ([]byte, error) {
 	if datas.Height < kvmvccMavlFork {
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -21,6 +22,11 @@ import (
 	"github.com/33cn/chain33/queue"
 	drivers "github.com/33cn/chain33/system/store"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/mpt"
+	"github.com/33cn/plugin/plugin/store/mpt/proof"
+	mty "github.com/33cn/plugin/plugin/store/mpt/types"
+	"github.com/33cn/plugin/plugin/store/snapshot/core"
+	sty "github.com/33cn/plugin/plugin/store/snapshot/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -1798,3 +1804,213 @@ func benchmarkIterCommit(b *testing.B, isResetForkHeight bool) {
 	fmt.Println("kvmvcc BenchmarkCommit cost time is", end.Sub(start), "num is", b.N)
 	b.StopTimer()
 }
+
+func iterateAll(t *testing.T, exp core.Exporter, stateHash []byte) []*types.KeyValue {
+	var kvs []*types.KeyValue
+	err := exp.IterateState(stateHash, nil, func(key, value []byte) bool {
+		kvs = append(kvs, &types.KeyValue{Key: common.CopyBytes(key), Value: common.CopyBytes(value)})
+		return false
+	})
+	require.Nil(t, err)
+	return kvs
+}
+
+func TestSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	storeCfg, sub := newStoreCfgIter(filepath.Join(dir, "src"))
+	store := New(storeCfg, sub, nil).(*KVmMavlStore)
+	defer store.Close()
+
+	kvmvccMavlFork = 2
+	defer func() {
+		kvmvccMavlFork = 200 * 10000
+	}()
+	var hashes [][]byte
+	hash := drivers.EmptyRoot[:]
+	for i := 0; i < 4; i++ {
+		var kv []*types.KeyValue
+		for j := 0; j < 10; j++ {
+			kv = append(kv, &types.KeyValue{Key: []byte(fmt.Sprintf("key-%02d", i*5+j)), Value: []byte(fmt.Sprintf("value-%d-%d", i, j))})
+		}
+		hash, err = store.MemSet(&types.StoreSet{StateHash: hash, KV: kv, Height: int64(i)}, true)
+		require.Nil(t, err)
+		_, err = store.Commit(&types.ReqHash{Hash: hash})
+		require.Nil(t, err)
+		hashes = append(hashes, hash)
+	}
+	//分叉之前的mavl状态不支持快照
+	err = store.IterateState(hashes[1], nil, func(key, value []byte) bool { return false })
+	assert.Equal(t, sty.ErrSnapshotNotSupport, err)
+
+	manifest, err := core.BuildManifest(store, "kvmvccmavl", hash, 3, 4)
+	require.Nil(t, err)
+	assert.Equal(t, int64(25), manifest.Total)
+	snapDir := filepath.Join(dir, "snapshot")
+	require.Nil(t, core.SaveManifest(snapDir, manifest))
+	for i := range manifest.Chunks {
+		chunk, err := core.ReadChunk(store, manifest, int32(i))
+		require.Nil(t, err)
+		require.Nil(t, core.SaveChunk(snapDir, chunk))
+	}
+
+	storeCfg2, sub2 := newStoreCfgIter(filepath.Join(dir, "dst"))
+	store2 := New(storeCfg2, sub2, nil).(*KVmMavlStore)
+	defer store2.Close()
+	_, _, err = core.Import(store2, "kvmvccmavl", snapDir, hash, core.ManifestHash(manifest))
+	require.Nil(t, err)
+	assert.Equal(t, iterateAll(t, store, hash), iterateAll(t, store2, hash))
+	keys := [][]byte{[]byte("key-00"), []byte("key-12"), []byte("key-24")}
+	assert.Equal(t, store.Get(&types.StoreGet{StateHash: hash, Keys: keys}), store2.Get(&types.StoreGet{StateHash: hash, Keys: keys}))
+
+	//导入后可以继续执行区块
+	hash2, err := store2.MemSet(&types.StoreSet{StateHash: hash, KV: []*types.KeyValue{{Key: []byte("key-99"), Value: []byte("v")}}, Height: 4}, true)
+	require.Nil(t, err)
+	_, err = store2.Commit(&types.ReqHash{Hash: hash2})
+	require.Nil(t, err)
+	assert.Equal(t, 26, len(iterateAll(t, store2, hash2)))
+
+	_, _, err = core.Import(store2, "kvmvccmavl", snapDir, hash, core.ManifestHash(manifest))
+	assert.Equal(t, sty.ErrStoreNotEmpty, err)
+	//分叉之前的高度不能导入
+	manifest.Height = 1
+	require.Nil(t, core.SaveManifest(snapDir, manifest))
+	storeCfg3, sub3 := newStoreCfgIter(filepath.Join(dir, "mavl"))
+	store3 := New(storeCfg3, sub3, nil).(*KVmMavlStore)
+	defer store3.Close()
+	_, _, err = core.Import(store3, "kvmvccmavl", snapDir, hash, core.ManifestHash(manifest))
+	assert.Equal(t, sty.ErrSnapshotNotSupport, err)
+}
+
+//failTarget 写入limit批之后失败，模拟迁移中断
+type failTarget struct {
+	core.Target
+	limit int
+}
+
+func (f *failTarget) WriteState(root []byte, height int64, kvs []*types.KeyValue) ([]byte, error) {
+	if f.limit == 0 {
+		return nil, types.ErrNotSupport
+	}
+	f.limit--
+	return f.Target.WriteState(root, height, kvs)
+}
+
+//badTarget 读取的值被篡改
+type badTarget struct {
+	core.Target
+}
+
+func (b *badTarget) Get(datas *types.StoreGet) [][]byte {
+	values := b.Target.Get(datas)
+	values[0] = []byte("bad")
+	return values
+}
+
+func TestMigrate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	storeCfg, sub := newStoreCfgIter(filepath.Join(dir, "src"))
+	store := New(storeCfg, sub, nil).(*KVmMavlStore)
+	defer store.Close()
+
+	kvmvccMavlFork = 2
+	defer func() {
+		kvmvccMavlFork = 200 * 10000
+	}()
+	var keys [][]byte
+	hash := drivers.EmptyRoot[:]
+	for i := 0; i < 4; i++ {
+		var kv []*types.KeyValue
+		for j := 0; j < 10; j++ {
+			kv = append(kv, &types.KeyValue{Key: []byte(fmt.Sprintf("key-%02d", i*5+j)), Value: []byte(fmt.Sprintf("value-%d-%d", i, j))})
+		}
+		hash, err = store.MemSet(&types.StoreSet{StateHash: hash, KV: kv, Height: int64(i)}, true)
+		require.Nil(t, err)
+		_, err = store.Commit(&types.ReqHash{Hash: hash})
+		require.Nil(t, err)
+	}
+	for i := 0; i < 25; i++ {
+		keys = append(keys, []byte(fmt.Sprintf("key-%02d", i)))
+	}
+
+	//kvmvccmavl迁移到mpt，中断后继续
+	mptStore := mpt.New(&types.Store{Name: "mpt", Driver: "leveldb", DbPath: filepath.Join(dir, "mpt"), DbCache: 100}, nil, nil).(*mpt.Store)
+	defer mptStore.Close()
+	cfg := &core.MigrateConfig{From: "kvmvccmavl", To: "mpt", StateHash: hash, Height: 3, BatchSize: 4, SampleInterval: 3,
+		ProgressFile: filepath.Join(dir, "mpt.migrate.json")}
+	_, err = core.Migrate(store, &failTarget{Target: mptStore, limit: 2}, cfg)
+	assert.Equal(t, types.ErrNotSupport, err)
+	progress, err := core.LoadProgress(cfg.ProgressFile)
+	require.Nil(t, err)
+	assert.Equal(t, int64(8), progress.Count)
+	assert.False(t, progress.Done)
+
+	progress, err = core.Migrate(store, mptStore, cfg)
+	require.Nil(t, err)
+	assert.True(t, progress.Done)
+	assert.Equal(t, int64(25), progress.Count)
+	assert.Equal(t, 9, len(progress.Samples))
+	newRoot, err := common.FromHex(progress.NewRoot)
+	require.Nil(t, err)
+	assert.NotEqual(t, hash, newRoot)
+	assert.Equal(t, store.Get(&types.StoreGet{StateHash: hash, Keys: keys}), mptStore.Get(&types.StoreGet{StateHash: newRoot, Keys: keys}))
+
+	//迁移后按区块头中的状态哈希读取并继续执行区块
+	assert.Equal(t, newRoot, core.ResolveRoot(mptStore.GetDB(), hash))
+	assert.Equal(t, store.Get(&types.StoreGet{StateHash: hash, Keys: keys}), mptStore.Get(&types.StoreGet{StateHash: hash, Keys: keys}))
+	assert.Equal(t, 25, len(iterateAll(t, mptStore, hash)))
+	var count int
+	mptStore.IterateRangeByStateHash(hash, []byte("key-"), []byte("key-99"), true, func(key, value []byte) bool {
+		count++
+		return false
+	})
+	assert.Equal(t, 25, count)
+	//按区块头中的状态哈希生成证明，证明使用新状态根校验
+	proofs, err := mptStore.GetProof(&mty.ReqStateProof{StateHash: hash, Keys: keys[:3]})
+	require.Nil(t, err)
+	assert.Equal(t, newRoot, proofs.StateHash)
+	assert.Nil(t, proof.VerifyReply(newRoot, proofs))
+	assert.Equal(t, store.Get(&types.StoreGet{StateHash: hash, Keys: keys[:3]}), [][]byte{proofs.Proofs[0].Value, proofs.Proofs[1].Value, proofs.Proofs[2].Value})
+	hash3, err := mptStore.MemSet(&types.StoreSet{StateHash: hash, KV: []*types.KeyValue{{Key: []byte("key-98"), Value: []byte("v")}}, Height: 4}, true)
+	require.Nil(t, err)
+	_, err = mptStore.Commit(&types.ReqHash{Hash: hash3})
+	require.Nil(t, err)
+	assert.Equal(t, 26, len(iterateAll(t, mptStore, hash3)))
+
+	//已经完成的迁移直接返回，其他迁移的进度文件不能使用
+	_, err = core.Migrate(store, mptStore, cfg)
+	assert.Nil(t, err)
+	_, err = core.Migrate(store, mptStore, &core.MigrateConfig{From: "kvmvccmavl", To: "mpt", StateHash: hash, Height: 2, BatchSize: 4, SampleInterval: 3,
+		ProgressFile: cfg.ProgressFile})
+	assert.Equal(t, sty.ErrMigrateMismatch, err)
+	_, err = core.Migrate(store, mptStore, &core.MigrateConfig{From: "kvmvccmavl", To: "mpt", StateHash: hash, Height: 3, BatchSize: 4, SampleInterval: 3,
+		ProgressFile: filepath.Join(dir, "other.migrate.json")})
+	assert.Equal(t, sty.ErrStoreNotEmpty, err)
+
+	//mpt迁移回kvmvccmavl，新的store使用mpt的状态根
+	storeCfg2, sub2 := newStoreCfgIter(filepath.Join(dir, "dst"))
+	store2 := New(storeCfg2, sub2, nil).(*KVmMavlStore)
+	defer store2.Close()
+	cfg2 := &core.MigrateConfig{From: "mpt", To: "kvmvccmavl", StateHash: newRoot, Height: 3, BatchSize: 10, SampleInterval: 1,
+		ProgressFile: filepath.Join(dir, "dst.migrate.json")}
+	progress, err = core.Migrate(mptStore, store2, cfg2)
+	require.Nil(t, err)
+	assert.Equal(t, progress.StateHash, progress.NewRoot)
+	assert.Equal(t, store.Get(&types.StoreGet{StateHash: hash, Keys: keys}), store2.Get(&types.StoreGet{StateHash: newRoot, Keys: keys}))
+	hash2, err := store2.MemSet(&types.StoreSet{StateHash: newRoot, KV: []*types.KeyValue{{Key: []byte("key-99"), Value: []byte("v")}}, Height: 4}, true)
+	require.Nil(t, err)
+	_, err = store2.Commit(&types.ReqHash{Hash: hash2})
+	require.Nil(t, err)
+	assert.Equal(t, 26, len(iterateAll(t, store2, hash2)))
+
+	//抽样校验不一致
+	storeCfg3, sub3 := newStoreCfgIter(filepath.Join(dir, "bad"))
+	store3 := New(storeCfg3, sub3, nil).(*KVmMavlStore)
+	defer store3.Close()
+	cfg2.ProgressFile = filepath.Join(dir, "bad.migrate.json")
+	_, err = core.Migrate(mptStore, &badTarget{Target: store3}, cfg2)
+	assert.Equal(t, sty.ErrMigrateVerify, err)
+}
@@ -0,0 +1,143 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/rpc/jsonclient"
+	"github.com/33cn/plugin/plugin/store/mpt/proof"
+	mty "github.com/33cn/plugin/plugin/store/mpt/types"
+	"github.com/spf13/cobra"
+)
+
+//MptCmd mpt store cmd register
+func MptCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mpt",
+		Short: "MPT store state proof",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.AddCommand(
+		GetProofCmd(),
+		VerifyProofCmd(),
+	)
+	return cmd
+}
+
+//GetProofCmd get state proof of keys
+func GetProofCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proof",
+		Short: "Get the merkle proof of keys against a state hash",
+		Run:   getProof,
+	}
+	addProofFlags(cmd)
+	cmd.MarkFlagRequired("keys")
+	return cmd
+}
+
+func addProofFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("state_hash", "s", "", "state hash of the block header")
+	cmd.MarkFlagRequired("state_hash")
+	cmd.Flags().StringSliceP("keys", "k", nil, "state keys, hex key starts with 0x")
+}
+
+func getProof(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	stateHash, _ := cmd.Flags().GetString("state_hash")
+	keys, _ := cmd.Flags().GetStringSlice("keys")
+
+	params := &mty.ReqStateProofJSON{StateHash: stateHash, Keys: keys}
+	var res mty.ReplyStateProofJSON
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "mpt.GetStateProof", params, &res)
+	ctx.Run()
+}
+
+//VerifyProofCmd verify state proof locally
+func VerifyProofCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-proof",
+		Short: "Verify the merkle proof against a trusted state hash",
+		Long: "Verify the proof saved by 'mpt proof' with --file, or fetch the proof of --keys from the node and verify it locally. " +
+			"For the height a node migrated to mpt at, the proof is built on the newRoot recorded by the migration, verify it against that root",
+		Run:   verifyProof,
+	}
+	addProofFlags(cmd)
+	cmd.Flags().StringP("file", "f", "", "proof json file printed by 'mpt proof'")
+	return cmd
+}
+
+type verifyResult struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Exist bool   `json:"exist"`
+}
+
+func verifyProof(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	stateHash, _ := cmd.Flags().GetString("state_hash")
+	keys, _ := cmd.Flags().GetStringSlice("keys")
+	file, _ := cmd.Flags().GetString("file")
+
+	var res mty.ReplyStateProofJSON
+	if file != "" {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		if err := json.Unmarshal(data, &res); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+	} else {
+		if len(keys) == 0 {
+			fmt.Fprintln(os.Stderr, "keys or file is required")
+			return
+		}
+		params := &mty.ReqStateProofJSON{StateHash: stateHash, Keys: keys}
+		ctx := jsonclient.NewRPCCtx(rpcLaddr, "mpt.GetStateProof", params, &res)
+		if _, err := ctx.RunResult(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+	}
+	results, err := verifyReply(stateHash, &res)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	data, err := json.MarshalIndent(results, "", "    ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+//verifyReply 只信任命令行指定的状态哈希，不信任节点返回的状态哈希
+func verifyReply(stateHash string, res *mty.ReplyStateProofJSON) ([]*verifyResult, error) {
+	hash, err := common.FromHex(stateHash)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := res.ToPB()
+	if err != nil {
+		return nil, err
+	}
+	if err := proof.VerifyReply(hash, reply); err != nil {
+		return nil, err
+	}
+	var results []*verifyResult
+	for _, p := range reply.Proofs {
+		results = append(results, &verifyResult{Key: common.ToHex(p.Key), Value: common.ToHex(p.Value), Exist: len(p.Value) > 0})
+	}
+	return results, nil
+}
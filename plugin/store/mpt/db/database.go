@@ -455,6 +455,11 @@ func (db *Database) dereference(child common.Hash, parent common.Hash) {
 //
 // As a side effect, all pre-images accumulated up to this point are also written.
 func (db *Database) Commit(node common.Hash, report bool) error {
+	return db.CommitPrune(node, 0, nil, report)
+}
+
+// CommitPrune 与Commit相同，pruner不为空时在同一个batch中记录新写入节点的引用计数和height高度的状态根
+func (db *Database) CommitPrune(node common.Hash, height int64, pruner *Pruner, report bool) error {
 	// Create a database batch to flush persistent data out. It is important that
 	// outside code doesn't see an inconsistent state (referenced data removed from
 	// memory cache during commit but not yet in persistent storage). This is ensured
@@ -484,11 +489,22 @@ func (db *Database) Commit(node common.Hash, report bool) error {
 	}
 	// Move the trie itself into the batch, flushing if enough data is accumulated
 	nodes, storage := len(db.nodes), db.nodesSize
-	if err := db.commit(node, batch); err != nil {
+	var written []*writtenNode
+	if err := db.commit(node, batch, &written); err != nil {
 		mptlog.Error("Failed to commit trie from trie database", "err", err)
 		db.lock.RUnlock()
 		return err
 	}
+	if pruner != nil {
+		//引用计数的读取和写入需要与裁剪互斥，直到batch写入完成
+		pruner.mu.Lock()
+		defer pruner.mu.Unlock()
+		if err := pruner.track(batch, node, written, height); err != nil {
+			mptlog.Error("Failed to track trie references", "err", err)
+			db.lock.RUnlock()
+			return err
+		}
+	}
 	// Write batch ready, unlock for readers during persistence
 	if err := batch.Write(); err != nil {
 		mptlog.Error("Failed to write trie to disk", "err", err)
@@ -525,19 +541,21 @@ func (db *Database) Commit(node common.Hash, report bool) error {
 }
 
 // commit is the private locked version of Commit.
-func (db *Database) commit(hash common.Hash, batch dbm.Batch) error {
+func (db *Database) commit(hash common.Hash, batch dbm.Batch, written *[]*writtenNode) error {
 	// If the node does not exist, it's a previously committed node
 	node, ok := db.nodes[hash]
 	if !ok {
 		return nil
 	}
-	for _, child := range node.childs() {
-		if err := db.commit(child, batch); err != nil {
+	children := node.childs()
+	for _, child := range children {
+		if err := db.commit(child, batch, written); err != nil {
 			return err
 		}
 	}
 	//println(hex.EncodeToString(hash[:]), len(node.proto()))
 	batch.Set(hash[:], node.proto())
+	*written = append(*written, &writtenNode{hash: hash, children: children})
 	return nil
 }
 
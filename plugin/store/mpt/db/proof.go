@@ -153,3 +153,61 @@ func get(tn node, key []byte) ([]byte, node) {
 		}
 	}
 }
+
+// ProveKVPair 生成key在roothash对应状态下的证明，返回key对应的值和从根节点开始的路径节点，
+// key不存在时返回的值为空，证明节点可以证明key不存在
+func ProveKVPair(db dbm.DB, roothash []byte, key []byte) ([]byte, [][]byte, error) {
+	trie, err := NewEx(common.BytesToHash(roothash), NewDatabase(db))
+	if err != nil {
+		return nil, nil, err
+	}
+	if enableSecure {
+		key = common.Sha3(key)
+	}
+	proofDb, _ := dbm.NewGoMemDB("", "", 0)
+	if err := trie.Prove(key, 0, proofDb); err != nil {
+		return nil, nil, err
+	}
+	return collectProof(common.BytesToHash(roothash), key, proofDb)
+}
+
+// collectProof 按照验证的顺序从proofDb中取出证明节点
+func collectProof(rootHash common.Hash, key []byte, proofDb dbm.DB) (value []byte, proof [][]byte, err error) {
+	key = keybytesToHex(key)
+	wantHash := rootHash
+	for i := 0; ; i++ {
+		buf, _ := proofDb.Get(wantHash[:])
+		if buf == nil {
+			return nil, nil, fmt.Errorf("proof node %d (hash %064x) missing", i, wantHash)
+		}
+		proof = append(proof, buf)
+		n, err := decodeNode(wantHash[:], buf, 0)
+		if err != nil {
+			return nil, nil, fmt.Errorf("bad proof node %d: %v", i, err)
+		}
+		keyrest, cld := get(n, key)
+		switch cld := cld.(type) {
+		case nil:
+			return nil, proof, nil
+		case hashNode:
+			key = keyrest
+			copy(wantHash[:], cld.GetHash())
+		case valueNode:
+			return cld.GetValue(), proof, nil
+		}
+	}
+}
+
+// VerifyKVPairNodes 用证明节点验证key在roothash对应状态下的值，节点按照哈希索引，顺序不影响验证结果，
+// key不存在时返回的值为空
+func VerifyKVPairNodes(roothash []byte, key []byte, proof [][]byte) ([]byte, error) {
+	proofDb, _ := dbm.NewGoMemDB("", "", 0)
+	for _, node := range proof {
+		proofDb.Set(common.Sha3(node), node)
+	}
+	if enableSecure {
+		key = common.Sha3(key)
+	}
+	value, _, err := VerifyProof(common.BytesToHash(roothash), key, proofDb)
+	return value, err
+}
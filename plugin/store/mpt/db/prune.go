@@ -0,0 +1,275 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpt
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+)
+
+//mpt裁剪相关的数据：mpt-ref-{hash}记录节点被父节点和状态根引用的次数，
+//mpt-root-{height}-{hash}记录每个高度提交的状态根，回滚后同一个高度可能有多个状态根，
+//mpt-prune-height记录已经裁剪到的高度，与删除节点在同一个batch中写入
+var (
+	refKeyPrefix      = []byte("mpt-ref-")
+	rootKeyPrefix     = []byte("mpt-root-")
+	pruneHeightKey    = []byte("mpt-prune-height")
+	untrackedRefCount = int64(-1)
+)
+
+// writtenNode 提交到数据库的节点及其子节点
+type writtenNode struct {
+	hash     common.Hash
+	children []common.Hash
+}
+
+// Pruner 通过持久化的引用计数裁剪不再被保留的状态根引用的mpt节点，
+// 使能裁剪之前写入的节点没有引用计数，不会被删除
+type Pruner struct {
+	db      dbm.DB
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	running int32
+	closed  int32
+}
+
+// NewPruner 创建mpt裁剪
+func NewPruner(db dbm.DB) *Pruner {
+	return &Pruner{db: db}
+}
+
+func refKey(hash common.Hash) []byte {
+	return append(append([]byte{}, refKeyPrefix...), hash[:]...)
+}
+
+func rootHeightPrefix(height int64) []byte {
+	return []byte(fmt.Sprintf("%s%020d-", rootKeyPrefix, height))
+}
+
+func rootKey(height int64, hash common.Hash) []byte {
+	return append(rootHeightPrefix(height), hash[:]...)
+}
+
+// refCount 读取节点的引用计数，pending中是本batch中修改过还没有写入的计数，没有记录引用计数的节点返回-1
+func (p *Pruner) refCount(hash common.Hash, pending map[common.Hash]int64) (int64, error) {
+	if count, ok := pending[hash]; ok {
+		return count, nil
+	}
+	value, err := p.db.Get(refKey(hash))
+	if err == dbm.ErrNotFoundInDb || (err == nil && value == nil) {
+		return untrackedRefCount, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	var count types.Int64
+	if err := types.Decode(value, &count); err != nil {
+		return 0, err
+	}
+	return count.Data, nil
+}
+
+// track 记录一次提交新增的引用：新写入的节点引用它的子节点，height高度引用状态根。
+// 重复写入的已有节点不再增加子节点的引用，没有引用计数的旧节点不参与计数，需要持有mu
+func (p *Pruner) track(batch dbm.Batch, root common.Hash, written []*writtenNode, height int64) error {
+	pending := make(map[common.Hash]int64)
+	//先确定哪些节点是第一次写入，不受本次提交中计数变化的影响
+	tracked := make(map[common.Hash]bool)
+	fresh := make(map[common.Hash]bool)
+	for _, n := range written {
+		if tracked[n.hash] || fresh[n.hash] {
+			continue
+		}
+		count, err := p.refCount(n.hash, nil)
+		if err != nil {
+			return err
+		}
+		if count != untrackedRefCount {
+			tracked[n.hash] = true
+			continue
+		}
+		_, err = p.db.Get(n.hash[:])
+		if err == nil {
+			//使能裁剪之前写入的节点
+			continue
+		}
+		if err != dbm.ErrNotFoundInDb {
+			return err
+		}
+		fresh[n.hash] = true
+		pending[n.hash] = 0
+	}
+	incRef := func(hash common.Hash) (bool, error) {
+		count, err := p.refCount(hash, pending)
+		if err != nil || count == untrackedRefCount {
+			return false, err
+		}
+		pending[hash] = count + 1
+		return true, nil
+	}
+	counted := make(map[common.Hash]bool)
+	for _, n := range written {
+		if !fresh[n.hash] || counted[n.hash] {
+			continue
+		}
+		counted[n.hash] = true
+		for _, child := range n.children {
+			if _, err := incRef(child); err != nil {
+				return err
+			}
+		}
+	}
+	ok, err := incRef(root)
+	if err != nil {
+		return err
+	}
+	if ok {
+		batch.Set(rootKey(height, root), types.Encode(&types.BlockInfo{Height: height, Hash: root[:]}))
+	}
+	for hash, count := range pending {
+		batch.Set(refKey(hash), types.Encode(&types.Int64{Data: count}))
+	}
+	return nil
+}
+
+// dereference 减少节点的引用计数，计数为0时删除节点并继续减少其子节点的引用计数
+func (p *Pruner) dereference(batch dbm.Batch, hash common.Hash, pending map[common.Hash]int64) (int, error) {
+	count, err := p.refCount(hash, pending)
+	if err != nil || count == untrackedRefCount {
+		return 0, err
+	}
+	if count > 1 {
+		pending[hash] = count - 1
+		batch.Set(refKey(hash), types.Encode(&types.Int64{Data: count - 1}))
+		return 0, nil
+	}
+	pending[hash] = 0
+	batch.Delete(refKey(hash))
+	enc, err := p.db.Get(hash[:])
+	if err == dbm.ErrNotFoundInDb {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	n, err := decodeNode(hash[:], enc, 0)
+	if err != nil {
+		return 0, err
+	}
+	batch.Delete(hash[:])
+	var children []common.Hash
+	gatherChildren(n, &children)
+	deleted := 1
+	for _, child := range children {
+		num, err := p.dereference(batch, child, pending)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += num
+	}
+	return deleted, nil
+}
+
+// PrunedHeight 已经裁剪到的高度
+func (p *Pruner) PrunedHeight() int64 {
+	value, err := p.db.Get(pruneHeightKey)
+	if err != nil || value == nil {
+		return 0
+	}
+	var height types.Int64
+	if err := types.Decode(value, &height); err != nil {
+		return 0
+	}
+	return height.Data
+}
+
+// firstRoot 最低的还没有裁剪的状态根所在的高度
+func (p *Pruner) firstRoot() (int64, bool) {
+	values := dbm.NewListHelper(p.db).IteratorScanFromFirst(rootKeyPrefix, 1)
+	if len(values) == 0 {
+		return 0, false
+	}
+	var info types.BlockInfo
+	if err := types.Decode(values[0], &info); err != nil {
+		mptlog.Error("Pruner decode root", "err", err)
+		return 0, false
+	}
+	return info.Height, true
+}
+
+// pruneHeight 删除一个高度的所有状态根，每个高度在一个batch中写入，重启后从下一个高度继续
+func (p *Pruner) pruneHeight(height int64) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	batch := p.db.NewBatch(true)
+	pending := make(map[common.Hash]int64)
+	deleted := 0
+	values := dbm.NewListHelper(p.db).PrefixScan(rootHeightPrefix(height))
+	if len(values) == 0 {
+		//已经被其他的裁剪处理
+		return 0, nil
+	}
+	for _, value := range values {
+		var info types.BlockInfo
+		if err := types.Decode(value, &info); err != nil {
+			return 0, err
+		}
+		root := common.BytesToHash(info.Hash)
+		num, err := p.dereference(batch, root, pending)
+		if err != nil {
+			return 0, err
+		}
+		deleted += num
+		batch.Delete(rootKey(height, root))
+	}
+	batch.Set(pruneHeightKey, types.Encode(&types.Int64{Data: height}))
+	return deleted, batch.Write()
+}
+
+// Prune 裁剪高度不超过height的状态根，返回删除的节点数
+func (p *Pruner) Prune(height int64) (int, error) {
+	deleted := 0
+	start := time.Now()
+	for atomic.LoadInt32(&p.closed) == 0 {
+		first, ok := p.firstRoot()
+		if !ok || first > height {
+			break
+		}
+		num, err := p.pruneHeight(first)
+		if err != nil {
+			mptlog.Error("Pruner prune height", "height", first, "err", err)
+			return deleted, err
+		}
+		deleted += num
+	}
+	mptlog.Info("Pruner prune mpt", "height", height, "deleted", deleted, "cost", time.Since(start))
+	return deleted, nil
+}
+
+// PruneAsync 在后台裁剪高度不超过height的状态根，同一时间只有一个裁剪在运行
+func (p *Pruner) PruneAsync(height int64) bool {
+	if atomic.LoadInt32(&p.closed) != 0 || !atomic.CompareAndSwapInt32(&p.running, 0, 1) {
+		return false
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer atomic.StoreInt32(&p.running, 0)
+		p.Prune(height)
+	}()
+	return true
+}
+
+// Close 停止裁剪并等待正在写入的batch完成
+func (p *Pruner) Close() {
+	atomic.StoreInt32(&p.closed, 1)
+	p.wg.Wait()
+}
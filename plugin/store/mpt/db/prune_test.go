@@ -0,0 +1,186 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpt
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pruneKV 每个高度修改少量key，其余key的节点在各个状态根之间共享
+func pruneKV(height int64) map[string]string {
+	kvs := make(map[string]string)
+	for i := 0; i < 50; i++ {
+		kvs[fmt.Sprintf("shared-%02d", i)] = fmt.Sprintf("value-%02d", i)
+	}
+	kvs[fmt.Sprintf("shared-%02d", height%50)] = fmt.Sprintf("value-%02d-%d", height%50, height)
+	kvs[fmt.Sprintf("height-%d", height)] = fmt.Sprintf("value-%d", height)
+	return kvs
+}
+
+func commitHeight(t *testing.T, db dbm.DB, pruner *Pruner, parent common.Hash, height int64) common.Hash {
+	trie, err := NewEx(parent, NewDatabase(db))
+	require.Nil(t, err)
+	for k, v := range pruneKV(height) {
+		trie.Update([]byte(k), []byte(v))
+	}
+	if height > 1 {
+		//上一个高度修改的共享key恢复原值
+		prev := (height - 1) % 50
+		trie.Update([]byte(fmt.Sprintf("shared-%02d", prev)), []byte(fmt.Sprintf("value-%02d", prev)))
+	}
+	root, err := trie.Commit(nil)
+	require.Nil(t, err)
+	require.Nil(t, trie.Commit2DbPrune(root, height, pruner, false))
+	return root
+}
+
+func checkRoot(t *testing.T, db dbm.DB, root common.Hash, height int64) {
+	trie, err := NewEx(root, NewDatabase(db))
+	require.Nil(t, err)
+	for k, v := range pruneKV(height) {
+		value, err := trie.TryGet([]byte(k))
+		require.Nil(t, err)
+		assert.Equal(t, v, string(value))
+	}
+	for h := int64(1); h <= height; h++ {
+		value, err := trie.TryGet([]byte(fmt.Sprintf("height-%d", h)))
+		require.Nil(t, err)
+		assert.Equal(t, fmt.Sprintf("value-%d", h), string(value))
+	}
+}
+
+func rootNodes(t *testing.T, db dbm.DB, root common.Hash) map[common.Hash]bool {
+	trie, err := NewEx(root, NewDatabase(db))
+	require.Nil(t, err)
+	nodes := make(map[common.Hash]bool)
+	it := trie.NodeIterator(nil)
+	for it.Next(true) {
+		if it.Hash() != (common.Hash{}) {
+			nodes[it.Hash()] = true
+		}
+	}
+	require.Nil(t, it.Error())
+	return nodes
+}
+
+func TestPrune(t *testing.T) {
+	db, _ := dbm.NewGoMemDB("gomemdb", "", 128)
+	pruner := NewPruner(db)
+	roots := make([]common.Hash, 11)
+	for h := int64(1); h <= 10; h++ {
+		roots[h] = commitHeight(t, db, pruner, roots[h-1], h)
+	}
+	//空块的状态根与上一个高度相同
+	trie, err := NewEx(roots[10], NewDatabase(db))
+	require.Nil(t, err)
+	require.Nil(t, trie.Commit2DbPrune(roots[10], 11, pruner, false))
+
+	kept := make(map[common.Hash]bool)
+	for h := 8; h <= 10; h++ {
+		for hash := range rootNodes(t, db, roots[h]) {
+			kept[hash] = true
+		}
+	}
+	pruned := make(map[common.Hash]bool)
+	for h := 1; h <= 7; h++ {
+		for hash := range rootNodes(t, db, roots[h]) {
+			if !kept[hash] {
+				pruned[hash] = true
+			}
+		}
+	}
+	assert.NotEmpty(t, pruned)
+
+	deleted, err := pruner.Prune(7)
+	require.Nil(t, err)
+	assert.Equal(t, len(pruned), deleted)
+	assert.Equal(t, int64(7), pruner.PrunedHeight())
+	for h := 1; h <= 7; h++ {
+		_, err := NewEx(roots[h], NewDatabase(db))
+		assert.NotNil(t, err)
+	}
+	for hash := range pruned {
+		_, err := db.Get(hash[:])
+		assert.Equal(t, dbm.ErrNotFoundInDb, err)
+	}
+	//共享的节点仍然可以访问
+	for h := int64(8); h <= 10; h++ {
+		checkRoot(t, db, roots[h], h)
+	}
+	assert.Equal(t, int64(len(kept)), dbm.NewListHelper(db).PrefixCount(refKeyPrefix))
+
+	//同一个状态根被两个高度引用，裁剪较低的高度后仍然保留
+	_, err = pruner.Prune(10)
+	require.Nil(t, err)
+	checkRoot(t, db, roots[10], 10)
+	_, err = pruner.Prune(11)
+	require.Nil(t, err)
+	_, err = NewEx(roots[10], NewDatabase(db))
+	assert.NotNil(t, err)
+	assert.Equal(t, int64(0), dbm.NewListHelper(db).PrefixCount(refKeyPrefix))
+	assert.Equal(t, int64(0), dbm.NewListHelper(db).PrefixCount(rootKeyPrefix))
+}
+
+func TestPruneUntracked(t *testing.T) {
+	db, _ := dbm.NewGoMemDB("gomemdb", "", 128)
+	//使能裁剪之前写入的节点不会被删除
+	root0 := commitHeight(t, db, nil, common.Hash{}, 1)
+	pruner := NewPruner(db)
+	root1 := commitHeight(t, db, pruner, root0, 2)
+	root2 := commitHeight(t, db, pruner, root1, 3)
+	_, err := pruner.Prune(2)
+	require.Nil(t, err)
+	checkRoot(t, db, root0, 1)
+	checkRoot(t, db, root2, 3)
+	_, err = NewEx(root1, NewDatabase(db))
+	assert.NotNil(t, err)
+
+	_, err = pruner.Prune(3)
+	require.Nil(t, err)
+	checkRoot(t, db, root0, 1)
+}
+
+func TestPruneRestart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mptprune")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	db := dbm.NewDB("mpt", "leveldb", dir, 16)
+	pruner := NewPruner(db)
+	roots := make([]common.Hash, 11)
+	for h := int64(1); h <= 10; h++ {
+		roots[h] = commitHeight(t, db, pruner, roots[h-1], h)
+	}
+	_, err = pruner.Prune(3)
+	require.Nil(t, err)
+	pruner.Close()
+	db.Close()
+
+	//重启后从上次裁剪的高度继续
+	db = dbm.NewDB("mpt", "leveldb", dir, 16)
+	defer db.Close()
+	pruner = NewPruner(db)
+	assert.Equal(t, int64(3), pruner.PrunedHeight())
+	checkRoot(t, db, roots[4], 4)
+	assert.True(t, pruner.PruneAsync(8))
+	pruner.wg.Wait()
+	pruner.Close()
+	assert.False(t, pruner.PruneAsync(9))
+	assert.Equal(t, int64(8), pruner.PrunedHeight())
+	for h := 1; h <= 8; h++ {
+		_, err := NewEx(roots[h], NewDatabase(db))
+		assert.NotNil(t, err)
+	}
+	checkRoot(t, db, roots[9], 9)
+	checkRoot(t, db, roots[10], 10)
+}
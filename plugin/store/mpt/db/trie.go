@@ -552,8 +552,23 @@ func (t *TrieEx) Commit2Db(node common.Hash, report bool) error {
 	return t.Trie.Commit2Db(node, report)
 }
 
+// Commit2DbPrune writes all nodes to the trie's database and tracks their references in pruner
+func (t *TrieEx) Commit2DbPrune(node common.Hash, height int64, pruner *Pruner, report bool) error {
+	err := t.db.CommitPrune(node, height, pruner, report)
+	if nil != err {
+		mptlog.Error("Commit to db trie fail")
+		return err
+	}
+	return nil
+}
+
 // SetKVPair set key value 的对外接口
 func SetKVPair(db dbm.DB, storeSet *types.StoreSet, sync bool) ([]byte, error) {
+	return SetKVPairPrune(db, storeSet, sync, nil)
+}
+
+// SetKVPairPrune set key value 的对外接口，pruner不为空时记录节点的引用计数
+func SetKVPairPrune(db dbm.DB, storeSet *types.StoreSet, sync bool, pruner *Pruner) ([]byte, error) {
 	var err error
 	var trie *TrieEx
 	trie, err = NewEx(common.BytesToHash(storeSet.StateHash), NewDatabase(db))
@@ -569,7 +584,7 @@ func SetKVPair(db dbm.DB, storeSet *types.StoreSet, sync bool) ([]byte, error) {
 		mptlog.Error("SetKVPair Commit to memory trie fail")
 		return nil, err
 	}
-	err = trie.Commit2Db(root, true)
+	err = trie.Commit2DbPrune(root, storeSet.Height, pruner, true)
 	if err != nil {
 		mptlog.Error("SetKVPair save trie to db fail")
 		return nil, err
@@ -668,3 +683,43 @@ func IterateRangeByStateHash(db dbm.DB, statehash, start, end []byte, ascending
 		fn(it.Key, it.Value)
 	}
 }
+
+// IterateState 从start开始按key的顺序遍历状态，fn返回true时停止，用于导出状态快照
+func IterateState(db dbm.DB, statehash, start []byte, fn func([]byte, []byte) bool) error {
+	trie, err := NewEx(common.BytesToHash(statehash), NewDatabase(db))
+	if err != nil {
+		return err
+	}
+	it := NewIterator(trie.NodeIterator(start))
+	for it.Next() {
+		if fn(it.Key, it.Value) {
+			return nil
+		}
+	}
+	return it.Err
+}
+
+// WriteState 在root的基础上写入一批数据并提交，返回新的状态根，root为空时从空树开始，用于导入状态快照
+func WriteState(db dbm.DB, root []byte, height int64, kvs []*types.KeyValue, pruner *Pruner) ([]byte, error) {
+	hash := emptyRoot
+	if len(root) > 0 {
+		hash = common.BytesToHash(root)
+	}
+	trie, err := NewEx(hash, NewDatabase(db))
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range kvs {
+		if err := trie.TryUpdate(kv.Key, kv.Value); err != nil {
+			return nil, err
+		}
+	}
+	hash, err = trie.Commit(nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := trie.Commit2DbPrune(hash, height, pruner, false); err != nil {
+		return nil, err
+	}
+	return hash[:], nil
+}
@@ -5,6 +5,8 @@
 package mpt
 
 import (
+	"bytes"
+
 	"github.com/33cn/chain33/common"
 	clog "github.com/33cn/chain33/common/log"
 	log "github.com/33cn/chain33/common/log/log15"
@@ -12,6 +14,8 @@ import (
 	drivers "github.com/33cn/chain33/system/store"
 	"github.com/33cn/chain33/types"
 	mpt "github.com/33cn/plugin/plugin/store/mpt/db"
+	mty "github.com/33cn/plugin/plugin/store/mpt/types"
+	"github.com/33cn/plugin/plugin/store/snapshot/core"
 	lru "github.com/hashicorp/golang-lru"
 )
 
@@ -27,11 +31,25 @@ func DisableLog() {
 	mlog.SetHandler(log.DiscardHandler())
 }
 
+const defaultPruneHeight = 10000
+
 // Store mpt store struct
 type Store struct {
 	*drivers.BaseStore
 	trees map[string]*mpt.TrieEx
 	cache *lru.Cache
+	// 使能裁剪时记录内存树对应的高度，提交时作为状态根的高度
+	heights     map[string]int64
+	pruner      *mpt.Pruner
+	pruneHeight int64
+	snapshot    *core.Server
+}
+
+type subConfig struct {
+	// 是否使能mpt裁剪
+	EnableMptPrune bool `json:"enableMptPrune"`
+	// 至少保留最近pruneHeight个高度的状态，每隔pruneHeight个高度裁剪一次
+	PruneHeight int32 `json:"pruneHeight"`
 }
 
 func init() {
@@ -40,26 +58,53 @@ func init() {
 
 // New new mpt store module
 func New(cfg *types.Store, sub []byte, chain33cfg *types.Chain33Config) queue.Module {
+	var subcfg subConfig
+	if sub != nil {
+		types.MustDecode(sub, &subcfg)
+	}
 	bs := drivers.NewBaseStore(cfg)
-	mpts := &Store{bs, make(map[string]*mpt.TrieEx), nil}
+	mpts := &Store{BaseStore: bs, trees: make(map[string]*mpt.TrieEx), heights: make(map[string]int64)}
 	mpts.cache, _ = lru.New(10)
+	if subcfg.EnableMptPrune {
+		mpts.pruneHeight = int64(subcfg.PruneHeight)
+		if mpts.pruneHeight <= 0 {
+			mpts.pruneHeight = defaultPruneHeight
+		}
+		mpts.pruner = mpt.NewPruner(bs.GetDB())
+		mlog.Info("mpt prune enabled", "pruneHeight", mpts.pruneHeight, "prunedHeight", mpts.pruner.PrunedHeight())
+	}
+	mpts.snapshot = core.NewServer("mpt", mpts)
 	bs.SetChild(mpts)
 	return mpts
 }
 
 // Close close mpt store
 func (mpts *Store) Close() {
+	if mpts.pruner != nil {
+		mpts.pruner.Close()
+	}
 	mpts.BaseStore.Close()
 	mlog.Info("store mavl closed")
 }
 
+// root 从其他store迁移到mpt之后，区块头中迁移高度的状态哈希对应mpt中的新状态根
+func (mpts *Store) root(stateHash []byte) []byte {
+	return core.ResolveRoot(mpts.GetDB(), stateHash)
+}
+
 // Set set k v to mpt store db; sync is true represent write sync
 func (mpts *Store) Set(datas *types.StoreSet, sync bool) ([]byte, error) {
-	hash, err := mpt.SetKVPair(mpts.GetDB(), datas, sync)
+	if root := mpts.root(datas.StateHash); !bytes.Equal(root, datas.StateHash) {
+		set := *datas
+		set.StateHash = root
+		datas = &set
+	}
+	hash, err := mpt.SetKVPairPrune(mpts.GetDB(), datas, sync, mpts.pruner)
 	if err != nil {
 		mlog.Error("mpt store error", "err", err)
 		return nil, err
 	}
+	mpts.prune(datas.Height)
 	return hash, nil
 }
 
@@ -74,7 +119,7 @@ func (mpts *Store) Get(datas *types.StoreGet) [][]byte {
 	} else if data, ok := mpts.trees[search]; ok {
 		tree = data
 	} else {
-		tree, err = mpt.NewEx(common.BytesToHash(datas.StateHash), mpt.NewDatabase(mpts.GetDB()))
+		tree, err = mpt.NewEx(common.BytesToHash(mpts.root(datas.StateHash)), mpt.NewDatabase(mpts.GetDB()))
 		if nil != err {
 			mlog.Error("Store get can not find a trie")
 		}
@@ -98,7 +143,7 @@ func (mpts *Store) Get(datas *types.StoreGet) [][]byte {
 func (mpts *Store) MemSet(datas *types.StoreSet, sync bool) ([]byte, error) {
 	var err error
 	var tree *mpt.TrieEx
-	tree, err = mpt.NewEx(common.BytesToHash(datas.StateHash), mpt.NewDatabase(mpts.GetDB()))
+	tree, err = mpt.NewEx(common.BytesToHash(mpts.root(datas.StateHash)), mpt.NewDatabase(mpts.GetDB()))
 	if err != nil {
 		mlog.Info("MemSet create a new trie", "err", err)
 		return nil, err
@@ -113,6 +158,9 @@ func (mpts *Store) MemSet(datas *types.StoreSet, sync bool) ([]byte, error) {
 	}
 	hash := root[:]
 	mpts.trees[string(hash)] = tree
+	if mpts.pruner != nil {
+		mpts.heights[string(hash)] = datas.Height
+	}
 	if len(mpts.trees) > 1000 {
 		mlog.Error("too many trees in cache")
 	}
@@ -126,15 +174,26 @@ func (mpts *Store) Commit(req *types.ReqHash) ([]byte, error) {
 		mlog.Error("store mpt commit", "err", types.ErrHashNotFound)
 		return nil, types.ErrHashNotFound
 	}
-	err := tree.Commit2Db(common.BytesToHash(req.Hash), true)
+	height := mpts.heights[string(req.Hash)]
+	err := tree.Commit2DbPrune(common.BytesToHash(req.Hash), height, mpts.pruner, true)
 	if nil != err {
 		mlog.Error("store mpt commit", "err", types.ErrHashNotFound)
 		return nil, types.ErrDataBaseDamage
 	}
 	delete(mpts.trees, string(req.Hash))
+	delete(mpts.heights, string(req.Hash))
+	mpts.prune(height)
 	return req.Hash, nil
 }
 
+// prune 每隔pruneHeight个高度在后台裁剪一次，保留最近pruneHeight个高度的状态
+func (mpts *Store) prune(height int64) {
+	if mpts.pruner == nil || height%mpts.pruneHeight != 0 || height/mpts.pruneHeight <= 1 {
+		return
+	}
+	mpts.pruner.PruneAsync(height - mpts.pruneHeight)
+}
+
 // MemSetUpgrade set keys values to memcory mpt, return root hash and error
 func (mpts *Store) MemSetUpgrade(datas *types.StoreSet, sync bool) ([]byte, error) {
 	//not support
@@ -155,6 +214,7 @@ func (mpts *Store) Rollback(req *types.ReqHash) ([]byte, error) {
 		return nil, types.ErrHashNotFound
 	}
 	delete(mpts.trees, string(req.Hash))
+	delete(mpts.heights, string(req.Hash))
 	return req.Hash, nil
 }
 
@@ -166,13 +226,79 @@ func (mpts *Store) Del(req *types.StoreDel) ([]byte, error) {
 
 // IterateRangeByStateHash 迭代实现功能； statehash：当前状态hash, start：开始查找的key, end: 结束的key, ascending：升序，降序, fn 迭代回调函数
 func (mpts *Store) IterateRangeByStateHash(statehash []byte, start []byte, end []byte, ascending bool, fn func(key, value []byte) bool) {
-	mpt.IterateRangeByStateHash(mpts.GetDB(), statehash, start, end, ascending, fn)
+	mpt.IterateRangeByStateHash(mpts.GetDB(), mpts.root(statehash), start, end, ascending, fn)
 }
 
-// ProcEvent not support message
+// ProcEvent 处理mpt store扩展的事件
 func (mpts *Store) ProcEvent(msg *queue.Message) {
 	if msg == nil {
 		return
 	}
-	msg.ReplyErr("Store", types.ErrActionNotSupport)
+	switch msg.Ty {
+	case mty.EventStoreGetProof:
+		req, ok := msg.GetData().(*mty.ReqStateProof)
+		if !ok {
+			msg.ReplyErr("Store", types.ErrInvalidParam)
+			return
+		}
+		reply, err := mpts.GetProof(req)
+		if err != nil {
+			msg.ReplyErr("Store", err)
+			return
+		}
+		msg.Reply(mpts.GetQueueClient().NewMessage("", mty.EventStoreGetProofReply, reply))
+	default:
+		if mpts.snapshot.ProcEvent(mpts.GetQueueClient(), msg) {
+			return
+		}
+		msg.ReplyErr("Store", types.ErrActionNotSupport)
+	}
+}
+
+// IterateState 按key的顺序遍历状态，用于导出状态快照
+func (mpts *Store) IterateState(stateHash, start []byte, fn func(key, value []byte) bool) error {
+	return mpt.IterateState(mpts.GetDB(), mpts.root(stateHash), start, fn)
+}
+
+// ImportState 用快照数据重建状态树，使能裁剪时快照的状态根记录在快照高度
+func (mpts *Store) ImportState(stateHash []byte, height int64, next func() ([]*types.KeyValue, error)) ([]byte, error) {
+	return core.ImportState(mpts, mpts.GetDB(), stateHash, height, next)
+}
+
+// WriteState 在root的基础上写入一批数据，返回新的状态根
+func (mpts *Store) WriteState(root []byte, height int64, kvs []*types.KeyValue) ([]byte, error) {
+	return mpt.WriteState(mpts.GetDB(), root, height, kvs, mpts.pruner)
+}
+
+// FinishState 状态根由写入的数据决定，返回最后写入的状态根
+func (mpts *Store) FinishState(stateHash, root []byte, height int64) ([]byte, error) {
+	if len(root) == 0 {
+		return mpt.WriteState(mpts.GetDB(), nil, height, nil, mpts.pruner)
+	}
+	return root, nil
+}
+
+// VerifiesStateHash 状态根由导入的数据计算得到，导入后可以和区块头中的状态哈希比较
+func (mpts *Store) VerifiesStateHash() bool {
+	return true
+}
+
+// GetProof 生成一批key在指定状态下的证明，key不存在时返回不存在的证明，
+// 返回的StateHash为生成证明的状态根
+func (mpts *Store) GetProof(req *mty.ReqStateProof) (*mty.ReplyStateProof, error) {
+	if len(req.Keys) == 0 || len(req.Keys) > mty.MaxProofKeys {
+		return nil, mty.ErrProofKeyCount
+	}
+	//迁移高度的区块头状态哈希对应mpt中的新状态根，返回的证明只能用新状态根校验
+	root := mpts.root(req.StateHash)
+	reply := &mty.ReplyStateProof{StateHash: root}
+	for _, key := range req.Keys {
+		value, proof, err := mpt.ProveKVPair(mpts.GetDB(), root, key)
+		if err != nil {
+			mlog.Error("store mpt get proof", "stateHash", common.ToHex(req.StateHash), "err", err)
+			return nil, types.ErrHashNotFound
+		}
+		reply.Proofs = append(reply.Proofs, &mty.StateProof{Key: key, Value: value, Proof: proof})
+	}
+	return reply, nil
 }
@@ -7,15 +7,22 @@ package mpt
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"fmt"
 	"time"
 
 	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/queue"
 	drivers "github.com/33cn/chain33/system/store"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/mpt/proof"
+	mty "github.com/33cn/plugin/plugin/store/mpt/types"
+	"github.com/33cn/plugin/plugin/store/snapshot/core"
+	sty "github.com/33cn/plugin/plugin/store/snapshot/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const MaxKeylenth int = 64
@@ -168,6 +175,64 @@ func TestKvdbRollback(t *testing.T) {
 	assert.Nil(t, notExistHash)
 }
 
+func TestGetProof(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	var storeCfg = newStoreCfg(dir)
+	store := New(storeCfg, nil, nil).(*Store)
+	assert.NotNil(t, store)
+	q := queue.New("channel")
+	store.SetQueueClient(q.Client())
+	defer store.Close()
+
+	var kv []*types.KeyValue
+	for i := 0; i < 100; i++ {
+		kv = append(kv, &types.KeyValue{Key: []byte(fmt.Sprintf("mavl-coins-bty-%d", i)), Value: []byte(fmt.Sprintf("value-%d", i))})
+	}
+	hash, err := store.Set(&types.StoreSet{StateHash: drivers.EmptyRoot[:], KV: kv}, true)
+	assert.Nil(t, err)
+
+	//通过store事件获取证明
+	keys := [][]byte{[]byte("mavl-coins-bty-1"), []byte("mavl-coins-bty-99"), []byte("not-exist")}
+	client := q.Client()
+	msg := client.NewMessage("store", mty.EventStoreGetProof, &mty.ReqStateProof{StateHash: hash, Keys: keys})
+	assert.Nil(t, client.Send(msg, true))
+	resp, err := client.Wait(msg)
+	assert.Nil(t, err)
+	reply := resp.GetData().(*mty.ReplyStateProof)
+	assert.Len(t, reply.Proofs, 3)
+	assert.Equal(t, []byte("value-1"), reply.Proofs[0].Value)
+	assert.Equal(t, []byte("value-99"), reply.Proofs[1].Value)
+	assert.True(t, len(reply.Proofs[0].Proof) > 1)
+	//不存在的key也有证明
+	assert.Nil(t, reply.Proofs[2].Value)
+	assert.NotEmpty(t, reply.Proofs[2].Proof)
+	assert.Nil(t, proof.VerifyReply(hash, reply))
+
+	//篡改的值和节点不能通过校验
+	reply.Proofs[0].Value = []byte("value-2")
+	assert.Equal(t, mty.ErrProofValueMismatch, proof.Verify(hash, reply.Proofs[0]))
+	reply.Proofs[0].Value = []byte("value-1")
+	reply.Proofs[0].Proof[1] = reply.Proofs[0].Proof[1][1:]
+	assert.Equal(t, mty.ErrProofInvalid, proof.Verify(hash, reply.Proofs[0]))
+	reply.Proofs[2].Value = []byte("value")
+	assert.Equal(t, mty.ErrProofValueMismatch, proof.Verify(hash, reply.Proofs[2]))
+	assert.Equal(t, mty.ErrProofInvalid, proof.VerifyReply(drivers.EmptyRoot[:], reply))
+
+	//jrpc格式转换后仍然可以校验
+	p, err := store.GetProof(&mty.ReqStateProof{StateHash: hash, Keys: keys[:1]})
+	assert.Nil(t, err)
+	p, err = mty.ReplyToJSON(p).ToPB()
+	assert.Nil(t, err)
+	assert.Nil(t, proof.VerifyReply(hash, p))
+
+	_, err = store.GetProof(&mty.ReqStateProof{StateHash: hash})
+	assert.Equal(t, mty.ErrProofKeyCount, err)
+	_, err = store.GetProof(&mty.ReqStateProof{StateHash: common.Sha3([]byte("unknown")), Keys: keys})
+	assert.Equal(t, types.ErrHashNotFound, err)
+}
+
 func GetRandomString(length int) string {
 	return common.GetRandPrintString(20, length)
 }
@@ -319,3 +384,124 @@ func BenchmarkCommit(b *testing.B) {
 	fmt.Println("mpt BenchmarkCommit cost time is", end.Sub(start), "num is", b.N)
 	b.StopTimer()
 }
+
+func TestPrune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	var storeCfg = newStoreCfg(dir)
+	store := New(storeCfg, []byte(`{"enableMptPrune":true,"pruneHeight":2}`), nil).(*Store)
+	assert.NotNil(t, store.pruner)
+	assert.Equal(t, int64(2), store.pruneHeight)
+	defer store.Close()
+
+	hashes := [][]byte{drivers.EmptyRoot[:]}
+	for h := int64(1); h <= 6; h++ {
+		kv := []*types.KeyValue{
+			{Key: []byte("k1"), Value: []byte(fmt.Sprintf("v%d", h))},
+			{Key: []byte(fmt.Sprintf("height%d", h)), Value: []byte("v")},
+		}
+		hash, err := store.MemSet(&types.StoreSet{StateHash: hashes[h-1], KV: kv, Height: h}, true)
+		assert.Nil(t, err)
+		_, err = store.Commit(&types.ReqHash{Hash: hash})
+		assert.Nil(t, err)
+		hashes = append(hashes, hash)
+	}
+	assert.Len(t, store.heights, 0)
+
+	//保留最近的状态，更早的状态被裁剪
+	_, err = store.pruner.Prune(4)
+	assert.Nil(t, err)
+	for h := 1; h <= 4; h++ {
+		values := store.Get(&types.StoreGet{StateHash: hashes[h], Keys: [][]byte{[]byte("k1")}})
+		assert.Nil(t, values[0])
+	}
+	for h := 5; h <= 6; h++ {
+		values := store.Get(&types.StoreGet{StateHash: hashes[h], Keys: [][]byte{[]byte("k1"), []byte("height1")}})
+		assert.Equal(t, []byte(fmt.Sprintf("v%d", h)), values[0])
+		assert.Equal(t, []byte("v"), values[1])
+	}
+}
+
+func iterateAll(t *testing.T, exp core.Exporter, stateHash []byte) []*types.KeyValue {
+	var kvs []*types.KeyValue
+	err := exp.IterateState(stateHash, nil, func(key, value []byte) bool {
+		kvs = append(kvs, &types.KeyValue{Key: common.CopyBytes(key), Value: common.CopyBytes(value)})
+		return false
+	})
+	require.Nil(t, err)
+	return kvs
+}
+
+func TestSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	store := New(newStoreCfg(filepath.Join(dir, "src")), nil, nil).(*Store)
+	defer store.Close()
+
+	var hashes [][]byte
+	hash := drivers.EmptyRoot[:]
+	for i := 0; i < 3; i++ {
+		var kv []*types.KeyValue
+		for j := 0; j < 10; j++ {
+			kv = append(kv, &types.KeyValue{Key: []byte(fmt.Sprintf("key-%02d", i*5+j)), Value: []byte(fmt.Sprintf("value-%d-%d", i, j))})
+		}
+		if i == 2 {
+			kv[0].Value = nil
+		}
+		hash, err = store.MemSet(&types.StoreSet{StateHash: hash, KV: kv, Height: int64(i)}, true)
+		require.Nil(t, err)
+		_, err = store.Commit(&types.ReqHash{Hash: hash})
+		require.Nil(t, err)
+		hashes = append(hashes, hash)
+	}
+	assert.Equal(t, 15, len(iterateAll(t, store, hashes[1])))
+
+	manifest, err := core.BuildManifest(store, "mpt", hash, 2, 4)
+	require.Nil(t, err)
+	assert.Equal(t, int64(19), manifest.Total)
+	snapDir := filepath.Join(dir, "snapshot")
+	require.Nil(t, core.SaveManifest(snapDir, manifest))
+	for i := range manifest.Chunks {
+		chunk, err := core.ReadChunk(store, manifest, int32(i))
+		require.Nil(t, err)
+		require.Nil(t, core.SaveChunk(snapDir, chunk))
+	}
+
+	//导入后重建的状态根与快照一致
+	store2 := New(newStoreCfg(filepath.Join(dir, "dst")), []byte(`{"enableMptPrune":true,"pruneHeight":2}`), nil).(*Store)
+	defer store2.Close()
+	_, _, err = core.Import(store2, "mpt", snapDir, hash, nil)
+	require.Nil(t, err)
+	assert.Equal(t, iterateAll(t, store, hash), iterateAll(t, store2, hash))
+	keys := [][]byte{[]byte("key-00"), []byte("key-10"), []byte("key-19")}
+	assert.Equal(t, store.Get(&types.StoreGet{StateHash: hash, Keys: keys}), store2.Get(&types.StoreGet{StateHash: hash, Keys: keys}))
+
+	hash2, err := store2.MemSet(&types.StoreSet{StateHash: hash, KV: []*types.KeyValue{{Key: []byte("key-99"), Value: []byte("v")}}, Height: 3}, true)
+	require.Nil(t, err)
+	_, err = store2.Commit(&types.ReqHash{Hash: hash2})
+	require.Nil(t, err)
+	assert.Equal(t, 20, len(iterateAll(t, store2, hash2)))
+	//快照的状态根记录在快照高度，可以被裁剪
+	deleted, err := store2.pruner.Prune(2)
+	require.Nil(t, err)
+	assert.True(t, deleted > 0)
+	assert.Equal(t, 20, len(iterateAll(t, store2, hash2)))
+
+	_, _, err = core.Import(store2, "mpt", snapDir, hash, nil)
+	assert.Equal(t, sty.ErrStoreNotEmpty, err)
+
+	//篡改的数据重建的状态根不一致
+	store3 := New(newStoreCfg(filepath.Join(dir, "bad")), nil, nil).(*Store)
+	defer store3.Close()
+	kvs := iterateAll(t, store, hash)
+	kvs[0].Value = []byte("bad")
+	root, err := store3.ImportState(hash, 2, func() ([]*types.KeyValue, error) {
+		next := kvs
+		kvs = nil
+		return next, nil
+	})
+	require.Nil(t, err)
+	assert.NotEqual(t, hash, root)
+}
@@ -0,0 +1,26 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpt
+
+import (
+	"github.com/33cn/chain33/pluginmgr"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/mpt/commands"
+	"github.com/33cn/plugin/plugin/store/mpt/rpc"
+	mty "github.com/33cn/plugin/plugin/store/mpt/types"
+)
+
+func init() {
+	pluginmgr.Register(&pluginmgr.PluginBase{
+		Name:     mty.MptX,
+		ExecName: mty.MptX,
+		Exec:     initExec,
+		Cmd:      commands.MptCmd,
+		RPC:      rpc.Init,
+	})
+}
+
+// initExec mpt store 没有执行器，只提供状态证明的rpc和命令行
+func initExec(name string, cfg *types.Chain33Config, sub []byte) {}
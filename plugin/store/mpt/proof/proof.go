@@ -0,0 +1,48 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package proof 校验mpt store生成的状态证明，
+// 轻节点和跨链桥只需要可信的状态哈希(区块头中的StateHash)就可以校验状态数据，不需要信任提供证明的全节点
+package proof
+
+import (
+	"bytes"
+
+	mpt "github.com/33cn/plugin/plugin/store/mpt/db"
+	mty "github.com/33cn/plugin/plugin/store/mpt/types"
+)
+
+// Verify 校验单个key的证明，证明中的value为空表示key在该状态下不存在
+func Verify(stateHash []byte, p *mty.StateProof) (err error) {
+	//证明节点来自不可信的节点，构造的异常节点不能导致校验方崩溃
+	defer func() {
+		if r := recover(); r != nil {
+			err = mty.ErrProofInvalid
+		}
+	}()
+	if p == nil || len(p.Proof) == 0 {
+		return mty.ErrProofInvalid
+	}
+	value, err := mpt.VerifyKVPairNodes(stateHash, p.Key, p.Proof)
+	if err != nil {
+		return mty.ErrProofInvalid
+	}
+	if !bytes.Equal(value, p.Value) {
+		return mty.ErrProofValueMismatch
+	}
+	return nil
+}
+
+// VerifyReply 校验一批证明，证明的状态哈希必须是调用方信任的状态哈希
+func VerifyReply(stateHash []byte, reply *mty.ReplyStateProof) error {
+	if reply == nil || !bytes.Equal(stateHash, reply.StateHash) {
+		return mty.ErrProofInvalid
+	}
+	for _, p := range reply.Proofs {
+		if err := Verify(stateHash, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
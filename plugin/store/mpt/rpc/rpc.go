@@ -0,0 +1,79 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/33cn/chain33/queue"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+	mty "github.com/33cn/plugin/plugin/store/mpt/types"
+)
+
+// Jrpc mpt jrpc interface
+type Jrpc struct {
+	cli *channelClient
+}
+
+// Grpc mpt grpc interface
+type Grpc struct {
+	*channelClient
+}
+
+type channelClient struct {
+	rpctypes.ChannelClient
+	qclient queue.Client
+}
+
+// Init mpt rpc register
+func Init(name string, s rpctypes.RPCServer) {
+	cli := &channelClient{qclient: s.GetQueueClient()}
+	grpc := &Grpc{channelClient: cli}
+	cli.Init(name, s, &Jrpc{cli: cli}, grpc)
+
+	mty.RegisterMptServer(s.GRPC(), grpc)
+}
+
+// GetStateProof 从store模块获取状态证明，只有mpt store支持
+func (c *channelClient) GetStateProof(ctx context.Context, req *mty.ReqStateProof) (*mty.ReplyStateProof, error) {
+	if req == nil || len(req.StateHash) == 0 {
+		return nil, types.ErrInvalidParam
+	}
+	msg := c.qclient.NewMessage("store", mty.EventStoreGetProof, req)
+	if err := c.qclient.Send(msg, true); err != nil {
+		return nil, err
+	}
+	resp, err := c.qclient.Wait(msg)
+	if err != nil {
+		return nil, err
+	}
+	switch reply := resp.GetData().(type) {
+	case *mty.ReplyStateProof:
+		return reply, nil
+	case *types.Reply:
+		//store返回的错误，其他store不支持证明时返回ErrActionNotSupport
+		return nil, errors.New(string(reply.GetMsg()))
+	}
+	return nil, types.ErrTypeAsset
+}
+
+// GetStateProof 获取一批key在指定状态下的证明
+func (c *Jrpc) GetStateProof(in *mty.ReqStateProofJSON, result *interface{}) error {
+	if in == nil {
+		return types.ErrInvalidParam
+	}
+	req, err := in.ToPB()
+	if err != nil {
+		return types.ErrInvalidParam
+	}
+	reply, err := c.cli.GetStateProof(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	*result = mty.ReplyToJSON(reply)
+	return nil
+}
@@ -0,0 +1,282 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: mpt.proto
+
+package types
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ReqStateProof 获取指定状态下一批key的证明
+type ReqStateProof struct {
+	StateHash            []byte   `protobuf:"bytes,1,opt,name=stateHash,proto3" json:"stateHash,omitempty"`
+	Keys                 [][]byte `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqStateProof) Reset()         { *m = ReqStateProof{} }
+func (m *ReqStateProof) String() string { return proto.CompactTextString(m) }
+func (*ReqStateProof) ProtoMessage()    {}
+func (*ReqStateProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7b963b81dad3cc64, []int{0}
+}
+
+func (m *ReqStateProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqStateProof.Unmarshal(m, b)
+}
+func (m *ReqStateProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqStateProof.Marshal(b, m, deterministic)
+}
+func (m *ReqStateProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqStateProof.Merge(m, src)
+}
+func (m *ReqStateProof) XXX_Size() int {
+	return xxx_messageInfo_ReqStateProof.Size(m)
+}
+func (m *ReqStateProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqStateProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqStateProof proto.InternalMessageInfo
+
+func (m *ReqStateProof) GetStateHash() []byte {
+	if m != nil {
+		return m.StateHash
+	}
+	return nil
+}
+
+func (m *ReqStateProof) GetKeys() [][]byte {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+// StateProof 单个key的证明，proof为从根节点到key所在节点路径上的节点编码，
+// value为空时proof证明key不存在
+type StateProof struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Proof                [][]byte `protobuf:"bytes,3,rep,name=proof,proto3" json:"proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateProof) Reset()         { *m = StateProof{} }
+func (m *StateProof) String() string { return proto.CompactTextString(m) }
+func (*StateProof) ProtoMessage()    {}
+func (*StateProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7b963b81dad3cc64, []int{1}
+}
+
+func (m *StateProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateProof.Unmarshal(m, b)
+}
+func (m *StateProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateProof.Marshal(b, m, deterministic)
+}
+func (m *StateProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateProof.Merge(m, src)
+}
+func (m *StateProof) XXX_Size() int {
+	return xxx_messageInfo_StateProof.Size(m)
+}
+func (m *StateProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateProof proto.InternalMessageInfo
+
+func (m *StateProof) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *StateProof) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *StateProof) GetProof() [][]byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+type ReplyStateProof struct {
+	StateHash            []byte        `protobuf:"bytes,1,opt,name=stateHash,proto3" json:"stateHash,omitempty"`
+	Proofs               []*StateProof `protobuf:"bytes,2,rep,name=proofs,proto3" json:"proofs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ReplyStateProof) Reset()         { *m = ReplyStateProof{} }
+func (m *ReplyStateProof) String() string { return proto.CompactTextString(m) }
+func (*ReplyStateProof) ProtoMessage()    {}
+func (*ReplyStateProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7b963b81dad3cc64, []int{2}
+}
+
+func (m *ReplyStateProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplyStateProof.Unmarshal(m, b)
+}
+func (m *ReplyStateProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplyStateProof.Marshal(b, m, deterministic)
+}
+func (m *ReplyStateProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplyStateProof.Merge(m, src)
+}
+func (m *ReplyStateProof) XXX_Size() int {
+	return xxx_messageInfo_ReplyStateProof.Size(m)
+}
+func (m *ReplyStateProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplyStateProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplyStateProof proto.InternalMessageInfo
+
+func (m *ReplyStateProof) GetStateHash() []byte {
+	if m != nil {
+		return m.StateHash
+	}
+	return nil
+}
+
+func (m *ReplyStateProof) GetProofs() []*StateProof {
+	if m != nil {
+		return m.Proofs
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ReqStateProof)(nil), "types.ReqStateProof")
+	proto.RegisterType((*StateProof)(nil), "types.StateProof")
+	proto.RegisterType((*ReplyStateProof)(nil), "types.ReplyStateProof")
+}
+
+func init() { proto.RegisterFile("mpt.proto", fileDescriptor_7b963b81dad3cc64) }
+
+var fileDescriptor_7b963b81dad3cc64 = []byte{
+	// 200 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcc, 0x2d, 0x28, 0xd1,
+	0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2d, 0xa9, 0x2c, 0x48, 0x2d, 0x56, 0x72, 0xe4, 0xe2,
+	0x0d, 0x4a, 0x2d, 0x0c, 0x2e, 0x49, 0x2c, 0x49, 0x0d, 0x28, 0xca, 0xcf, 0x4f, 0x13, 0x92, 0xe1,
+	0xe2, 0x2c, 0x06, 0xf1, 0x3c, 0x12, 0x8b, 0x33, 0x24, 0x18, 0x15, 0x18, 0x35, 0x78, 0x82, 0x10,
+	0x02, 0x42, 0x42, 0x5c, 0x2c, 0xd9, 0xa9, 0x95, 0xc5, 0x12, 0x4c, 0x0a, 0xcc, 0x1a, 0x3c, 0x41,
+	0x60, 0xb6, 0x92, 0x17, 0x17, 0x17, 0x92, 0x7e, 0x01, 0x2e, 0xe6, 0xec, 0xd4, 0x4a, 0xa8, 0x4e,
+	0x10, 0x53, 0x48, 0x84, 0x8b, 0xb5, 0x2c, 0x31, 0xa7, 0x34, 0x55, 0x82, 0x09, 0x2c, 0x06, 0xe1,
+	0x80, 0x44, 0x0b, 0x40, 0x1a, 0x24, 0x98, 0xc1, 0x46, 0x41, 0x38, 0x4a, 0x51, 0x5c, 0xfc, 0x41,
+	0xa9, 0x05, 0x39, 0x95, 0x44, 0x3b, 0x48, 0x93, 0x8b, 0x0d, 0xac, 0x13, 0xe2, 0x24, 0x6e, 0x23,
+	0x41, 0x3d, 0xb0, 0xbf, 0xf4, 0x10, 0x06, 0x04, 0x41, 0x15, 0x18, 0xb9, 0x71, 0x31, 0xe7, 0x16,
+	0x94, 0x08, 0xd9, 0x73, 0xf1, 0xba, 0xa7, 0x96, 0x20, 0x59, 0x20, 0x02, 0xd5, 0x82, 0x12, 0x0e,
+	0x52, 0x62, 0x70, 0x51, 0x14, 0xe7, 0x28, 0x31, 0x24, 0xb1, 0x81, 0x03, 0xd0, 0x18, 0x30, 0x00,
+	0x9d, 0x8d, 0xc0, 0x07, 0x4d, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// MptClient is the client API for Mpt service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MptClient interface {
+	GetStateProof(ctx context.Context, in *ReqStateProof, opts ...grpc.CallOption) (*ReplyStateProof, error)
+}
+
+type mptClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMptClient(cc *grpc.ClientConn) MptClient {
+	return &mptClient{cc}
+}
+
+func (c *mptClient) GetStateProof(ctx context.Context, in *ReqStateProof, opts ...grpc.CallOption) (*ReplyStateProof, error) {
+	out := new(ReplyStateProof)
+	err := c.cc.Invoke(ctx, "/types.mpt/GetStateProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MptServer is the server API for Mpt service.
+type MptServer interface {
+	GetStateProof(context.Context, *ReqStateProof) (*ReplyStateProof, error)
+}
+
+// UnimplementedMptServer can be embedded to have forward compatible implementations.
+type UnimplementedMptServer struct {
+}
+
+func (*UnimplementedMptServer) GetStateProof(ctx context.Context, req *ReqStateProof) (*ReplyStateProof, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStateProof not implemented")
+}
+
+func RegisterMptServer(s *grpc.Server, srv MptServer) {
+	s.RegisterService(&_Mpt_serviceDesc, srv)
+}
+
+func _Mpt_GetStateProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqStateProof)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MptServer).GetStateProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.mpt/GetStateProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MptServer).GetStateProof(ctx, req.(*ReqStateProof))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Mpt_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.mpt",
+	HandlerType: (*MptServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStateProof",
+			Handler:    _Mpt_GetStateProof_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "mpt.proto",
+}
@@ -0,0 +1,119 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/33cn/chain33/common"
+)
+
+// MptX mpt store 对外提供rpc和命令行的名称
+const MptX = "mpt"
+
+// store 模块处理的mpt扩展事件
+const (
+	// EventStoreGetProof 获取状态证明
+	EventStoreGetProof = 1001
+	// EventStoreGetProofReply 状态证明的回复
+	EventStoreGetProofReply = 1002
+)
+
+// MaxProofKeys 单次请求最多获取证明的key数目
+const MaxProofKeys = 100
+
+var (
+	// ErrProofKeyCount key的数目为0或者超过MaxProofKeys
+	ErrProofKeyCount = errors.New("ErrProofKeyCount")
+	// ErrProofInvalid 证明与状态哈希不匹配
+	ErrProofInvalid = errors.New("ErrProofInvalid")
+	// ErrProofValueMismatch 证明得到的值与声明的值不一致
+	ErrProofValueMismatch = errors.New("ErrProofValueMismatch")
+)
+
+// ReqStateProofJSON jrpc请求，key以0x开头时按十六进制解析，否则按原始字符串处理
+type ReqStateProofJSON struct {
+	StateHash string   `json:"stateHash"`
+	Keys      []string `json:"keys"`
+}
+
+// StateProofJSON jrpc返回的单个key的证明，字段均为十六进制
+type StateProofJSON struct {
+	Key   string   `json:"key"`
+	Value string   `json:"value"`
+	Proof []string `json:"proof"`
+}
+
+// ReplyStateProofJSON jrpc返回的证明
+type ReplyStateProofJSON struct {
+	StateHash string            `json:"stateHash"`
+	Proofs    []*StateProofJSON `json:"proofs"`
+}
+
+// DecodeProofKey 解析jrpc和命令行中的key
+func DecodeProofKey(key string) ([]byte, error) {
+	if strings.HasPrefix(key, "0x") || strings.HasPrefix(key, "0X") {
+		return common.FromHex(key)
+	}
+	return []byte(key), nil
+}
+
+// ToPB 转换为grpc和store事件使用的请求
+func (req *ReqStateProofJSON) ToPB() (*ReqStateProof, error) {
+	stateHash, err := common.FromHex(req.StateHash)
+	if err != nil {
+		return nil, err
+	}
+	pb := &ReqStateProof{StateHash: stateHash}
+	for _, key := range req.Keys {
+		k, err := DecodeProofKey(key)
+		if err != nil {
+			return nil, err
+		}
+		pb.Keys = append(pb.Keys, k)
+	}
+	return pb, nil
+}
+
+// ReplyToJSON 转换为jrpc返回的证明
+func ReplyToJSON(reply *ReplyStateProof) *ReplyStateProofJSON {
+	res := &ReplyStateProofJSON{StateHash: common.ToHex(reply.StateHash)}
+	for _, p := range reply.Proofs {
+		item := &StateProofJSON{Key: common.ToHex(p.Key), Value: common.ToHex(p.Value)}
+		for _, node := range p.Proof {
+			item.Proof = append(item.Proof, common.ToHex(node))
+		}
+		res.Proofs = append(res.Proofs, item)
+	}
+	return res
+}
+
+// ToPB 转换回证明，用于本地校验jrpc返回的证明
+func (res *ReplyStateProofJSON) ToPB() (*ReplyStateProof, error) {
+	stateHash, err := common.FromHex(res.StateHash)
+	if err != nil {
+		return nil, err
+	}
+	reply := &ReplyStateProof{StateHash: stateHash}
+	for _, item := range res.Proofs {
+		p := &StateProof{}
+		if p.Key, err = common.FromHex(item.Key); err != nil {
+			return nil, err
+		}
+		if p.Value, err = common.FromHex(item.Value); err != nil {
+			return nil, err
+		}
+		for _, node := range item.Proof {
+			n, err := common.FromHex(node)
+			if err != nil {
+				return nil, err
+			}
+			p.Proof = append(p.Proof, n)
+		}
+		reply.Proofs = append(reply.Proofs, p)
+	}
+	return reply, nil
+}
@@ -0,0 +1,489 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/rpc/jsonclient"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	drivers "github.com/33cn/chain33/system/store"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot/core"
+	sty "github.com/33cn/plugin/plugin/store/snapshot/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/cobra"
+)
+
+const (
+	//等待节点生成清单的最长时间
+	manifestTimeout = 30 * time.Minute
+	//下载失败的分块重试的次数
+	chunkRetry = 3
+)
+
+//SnapshotCmd store state snapshot cmd register
+func SnapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Store state snapshot export, import and migration",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.AddCommand(
+		ManifestCmd(),
+		DownloadCmd(),
+		VerifyCmd(),
+		ImportCmd(),
+		MigrateCmd(),
+	)
+	return cmd
+}
+
+//ManifestCmd get the snapshot manifest from the node
+func ManifestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "manifest",
+		Short: "Get the snapshot manifest of a state hash, the node builds it in background on the first call",
+		Run:   manifest,
+	}
+	addManifestFlags(cmd)
+	return cmd
+}
+
+func addManifestFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("state_hash", "s", "", "state hash of the block header")
+	cmd.MarkFlagRequired("state_hash")
+	cmd.Flags().Int64P("height", "t", 0, "block height of the state hash")
+	cmd.MarkFlagRequired("height")
+	cmd.Flags().Int32P("chunk_size", "c", sty.DefaultChunkSize, "kv count of each chunk")
+}
+
+type manifestResult struct {
+	StateHash    string `json:"stateHash"`
+	Height       int64  `json:"height"`
+	Driver       string `json:"driver"`
+	ChunkSize    int32  `json:"chunkSize"`
+	Chunks       int    `json:"chunks"`
+	Total        int64  `json:"total"`
+	ManifestHash string `json:"manifestHash"`
+}
+
+func newManifestResult(manifest *sty.Manifest) *manifestResult {
+	return &manifestResult{
+		StateHash:    common.ToHex(manifest.StateHash),
+		Height:       manifest.Height,
+		Driver:       manifest.Driver,
+		ChunkSize:    manifest.ChunkSize,
+		Chunks:       len(manifest.Chunks),
+		Total:        manifest.Total,
+		ManifestHash: common.ToHex(core.ManifestHash(manifest)),
+	}
+}
+
+func printManifest(manifest *sty.Manifest) {
+	printJSON(newManifestResult(manifest))
+}
+
+func printJSON(result interface{}) {
+	data, err := json.MarshalIndent(result, "", "    ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+func getManifest(peer string, req *sty.ReqManifestJSON) (*sty.Manifest, error) {
+	var res sty.ReplySnapshotData
+	ctx := jsonclient.NewRPCCtx(peer, "snapshot.GetManifest", req, &res)
+	if _, err := ctx.RunResult(); err != nil {
+		return nil, err
+	}
+	manifest := &sty.Manifest{}
+	if err := res.DecodeJSON(manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func manifest(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	stateHash, _ := cmd.Flags().GetString("state_hash")
+	height, _ := cmd.Flags().GetInt64("height")
+	chunkSize, _ := cmd.Flags().GetInt32("chunk_size")
+
+	req := &sty.ReqManifestJSON{StateHash: stateHash, Height: height, ChunkSize: chunkSize}
+	manifest, err := getManifest(rpcLaddr, req)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	printManifest(manifest)
+}
+
+//DownloadCmd download the snapshot from a peer node and verify every chunk
+func DownloadCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "download",
+		Short: "Download the snapshot of a state hash from a peer node into a directory",
+		Long:  "Download the manifest and chunks, every chunk is verified against the manifest and the valid chunks already in the directory are skipped",
+		Run:   download,
+	}
+	addManifestFlags(cmd)
+	cmd.Flags().StringP("peer", "p", "", "rpc address of the peer node, default rpc_laddr")
+	cmd.Flags().StringP("dir", "d", "", "snapshot directory")
+	cmd.MarkFlagRequired("dir")
+	return cmd
+}
+
+func download(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	peer, _ := cmd.Flags().GetString("peer")
+	stateHash, _ := cmd.Flags().GetString("state_hash")
+	height, _ := cmd.Flags().GetInt64("height")
+	chunkSize, _ := cmd.Flags().GetInt32("chunk_size")
+	dir, _ := cmd.Flags().GetString("dir")
+	if peer == "" {
+		peer = rpcLaddr
+	}
+
+	manifest, err := downloadManifest(peer, &sty.ReqManifestJSON{StateHash: stateHash, Height: height, ChunkSize: chunkSize})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	if err := checkDirManifest(dir, manifest); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	if err := core.SaveManifest(dir, manifest); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	for i := range manifest.Chunks {
+		index := int32(i)
+		if _, err := core.LoadChunk(dir, manifest, index); err == nil {
+			continue
+		}
+		if err := downloadChunk(peer, dir, manifest, index); err != nil {
+			fmt.Fprintln(os.Stderr, "download chunk", index, err)
+			return
+		}
+		fmt.Printf("download chunk %d/%d\n", index+1, len(manifest.Chunks))
+	}
+	printManifest(manifest)
+}
+
+//downloadManifest 节点后台生成清单时等待重试，
+//清单来自不可信的节点时，导入前需要和可信节点的清单哈希比较
+func downloadManifest(peer string, req *sty.ReqManifestJSON) (*sty.Manifest, error) {
+	start := time.Now()
+	for {
+		manifest, err := getManifest(peer, req)
+		if err == nil {
+			if err := core.VerifyManifest(manifest); err != nil {
+				return nil, err
+			}
+			return manifest, nil
+		}
+		//节点正在生成这个或者其他状态的清单
+		building := err.Error() == sty.ErrManifestBuilding.Error() || err.Error() == sty.ErrManifestBusy.Error()
+		if !building || time.Since(start) > manifestTimeout {
+			return nil, err
+		}
+		time.Sleep(3 * time.Second)
+	}
+}
+
+//checkDirManifest 目录中已有其他快照时不能继续下载
+func checkDirManifest(dir string, manifest *sty.Manifest) error {
+	old, err := core.LoadManifest(dir)
+	if err != nil {
+		return nil
+	}
+	if !proto.Equal(old, manifest) {
+		return errors.New("dir already has another snapshot")
+	}
+	return nil
+}
+
+func downloadChunk(peer string, dir string, manifest *sty.Manifest, index int32) (err error) {
+	req := &sty.ReqChunkJSON{StateHash: common.ToHex(manifest.StateHash), Index: index, ChunkSize: manifest.ChunkSize}
+	for i := 0; i < chunkRetry; i++ {
+		var res sty.ReplySnapshotData
+		ctx := jsonclient.NewRPCCtx(peer, "snapshot.GetChunk", req, &res)
+		if _, err = ctx.RunResult(); err != nil {
+			continue
+		}
+		chunk := &sty.Chunk{}
+		if err = res.DecodeJSON(chunk); err != nil {
+			continue
+		}
+		if chunk.Index != index {
+			err = sty.ErrChunkIndex
+			continue
+		}
+		if err = core.VerifyChunk(manifest, chunk); err != nil {
+			continue
+		}
+		return core.SaveChunk(dir, chunk)
+	}
+	return err
+}
+
+//VerifyCmd verify the snapshot directory
+func VerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the manifest and all chunks in the snapshot directory",
+		Run:   verify,
+	}
+	cmd.Flags().StringP("dir", "d", "", "snapshot directory")
+	cmd.MarkFlagRequired("dir")
+	return cmd
+}
+
+func verify(cmd *cobra.Command, args []string) {
+	dir, _ := cmd.Flags().GetString("dir")
+	manifest, err := core.VerifyDir(dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	printManifest(manifest)
+}
+
+//ImportCmd import the snapshot into the store of a stopped node
+func ImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import the snapshot into the empty store of a stopped node",
+		Long: "The state hash of the snapshot must equal the state hash in the block header got from the trusted node rpc_laddr. " +
+			"Only the mpt store rebuilds the state hash from the imported data and checks it against the block header, " +
+			"other stores need manifest_hash, the manifest hash printed by the manifest command of a trusted node. " +
+			"The store is opened with the store config of the node, the relative dbPath is relative to the current directory",
+		Run: importSnapshot,
+	}
+	cmd.Flags().StringP("dir", "d", "", "snapshot directory")
+	cmd.MarkFlagRequired("dir")
+	cmd.Flags().StringP("conf", "f", "chain33.toml", "config file of the node")
+	cmd.Flags().StringP("manifest_hash", "m", "", "manifest hash got from a trusted node, required if the store can not verify the state hash")
+	return cmd
+}
+
+type importResult struct {
+	*manifestResult
+	//导入的数据和区块头中的状态哈希比较过
+	StateHashVerified bool `json:"stateHashVerified"`
+	//清单和可信节点的清单哈希比较过
+	ManifestHashVerified bool `json:"manifestHashVerified"`
+}
+
+func importSnapshot(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	dir, _ := cmd.Flags().GetString("dir")
+	conf, _ := cmd.Flags().GetString("conf")
+	manifestHashStr, _ := cmd.Flags().GetString("manifest_hash")
+
+	manifestHash, err := common.FromHex(manifestHashStr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	manifest, err := core.LoadManifest(dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	stateHash, err := getHeaderStateHash(rpcLaddr, manifest.Height)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	manifest, verified, err := importDir(conf, dir, stateHash, manifestHash)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	printJSON(&importResult{
+		manifestResult:       newManifestResult(manifest),
+		StateHashVerified:    verified,
+		ManifestHashVerified: len(manifestHash) > 0,
+	})
+}
+
+//getHeaderStateHash 从可信的节点获取区块头中的状态哈希
+func getHeaderStateHash(rpcLaddr string, height int64) ([]byte, error) {
+	params := &rpctypes.BlockParam{Start: height, End: height}
+	var res rpctypes.Headers
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.GetHeaders", params, &res)
+	if _, err := ctx.RunResult(); err != nil {
+		return nil, err
+	}
+	if len(res.Items) != 1 || res.Items[0].Height != height {
+		return nil, types.ErrBlockNotFound
+	}
+	return common.FromHex(res.Items[0].StateHash)
+}
+
+//openStore 按节点的配置打开store，dbPath不为空时替换配置中的路径
+func openStore(cfg *types.Chain33Config, name, dbPath string) (queue.Module, error) {
+	storeCfg := *cfg.GetModuleConfig().Store
+	storeCfg.Name = name
+	if dbPath != "" {
+		storeCfg.DbPath = dbPath
+	}
+	create, err := drivers.Load(name)
+	if err != nil {
+		return nil, err
+	}
+	return create(&storeCfg, cfg.GetSubConfig().Store[name], cfg), nil
+}
+
+//importDir 按节点的配置打开store并导入快照
+func importDir(conf string, dir string, stateHash, manifestHash []byte) (*sty.Manifest, bool, error) {
+	cfg := types.NewChain33Config(types.ReadFile(conf))
+	name := cfg.GetModuleConfig().Store.Name
+	store, err := openStore(cfg, name, "")
+	if err != nil {
+		return nil, false, err
+	}
+	defer store.Close()
+	imp, ok := store.(core.Importer)
+	if !ok {
+		return nil, false, sty.ErrSnapshotNotSupport
+	}
+	return core.Import(imp, name, dir, stateHash, manifestHash)
+}
+
+//MigrateCmd migrate the state of a stopped node to another store driver
+func MigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the state of a stopped node into an empty store of another driver",
+		Long: "The state at the height is read from the store in the node config and written into to_db with the to driver, " +
+			"the sub config of the to driver is read from the node config too. The progress is saved after every batch " +
+			"and the migration resumes from it after interruption. The sampled keys are verified after all data written, " +
+			"and the new state root of the height is recorded in the progress file and in the new store, " +
+			"which reads the state hash of the height from the block header as the new state root. " +
+			"The state hashes of the later blocks computed by the new driver differ from the old driver, " +
+			"so every node of the chain must switch to the new store at the same height",
+		Run: migrate,
+	}
+	cmd.Flags().StringP("conf", "f", "chain33.toml", "config file of the node")
+	cmd.Flags().StringP("to", "o", "", "store driver to migrate to, like mpt or kvmvccmavl")
+	cmd.MarkFlagRequired("to")
+	cmd.Flags().StringP("to_db", "d", "", "db path of the new store")
+	cmd.MarkFlagRequired("to_db")
+	cmd.Flags().Int64P("height", "t", 0, "block height of the state")
+	cmd.MarkFlagRequired("height")
+	cmd.Flags().StringP("state_hash", "s", "", "state hash of the height, default from the block header got from rpc_laddr")
+	cmd.Flags().StringP("progress", "p", "", "progress file, default to_db.migrate.json")
+	cmd.Flags().IntP("batch", "b", sty.DefaultChunkSize, "kv count of each batch")
+	cmd.Flags().Int64P("sample", "n", 1000, "verify one key of every sample keys")
+	return cmd
+}
+
+func migrate(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	conf, _ := cmd.Flags().GetString("conf")
+	to, _ := cmd.Flags().GetString("to")
+	toDB, _ := cmd.Flags().GetString("to_db")
+	height, _ := cmd.Flags().GetInt64("height")
+	stateHashStr, _ := cmd.Flags().GetString("state_hash")
+	progressFile, _ := cmd.Flags().GetString("progress")
+	batch, _ := cmd.Flags().GetInt("batch")
+	sample, _ := cmd.Flags().GetInt64("sample")
+	if progressFile == "" {
+		progressFile = filepath.Clean(toDB) + ".migrate.json"
+	}
+
+	var stateHash []byte
+	var err error
+	if stateHashStr != "" {
+		stateHash, err = common.FromHex(stateHashStr)
+	} else {
+		stateHash, err = getHeaderStateHash(rpcLaddr, height)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	progress, err := migrateStore(conf, to, toDB, &core.MigrateConfig{
+		To:             to,
+		StateHash:      stateHash,
+		Height:         height,
+		BatchSize:      batch,
+		SampleInterval: sample,
+		ProgressFile:   progressFile,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	data, err := json.MarshalIndent(&migrateResult{
+		From:      progress.From,
+		To:        progress.To,
+		Height:    progress.Height,
+		StateHash: progress.StateHash,
+		NewRoot:   progress.NewRoot,
+		Count:     progress.Count,
+		Samples:   len(progress.Samples),
+	}, "", "    ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+type migrateResult struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Height    int64  `json:"height"`
+	StateHash string `json:"stateHash"`
+	NewRoot   string `json:"newRoot"`
+	Count     int64  `json:"count"`
+	Samples   int    `json:"samples"`
+}
+
+//migrateStore 打开节点的store和新的store并迁移状态
+func migrateStore(conf, to, toDB string, migrateCfg *core.MigrateConfig) (*sty.MigrateProgress, error) {
+	cfg := types.NewChain33Config(types.ReadFile(conf))
+	storeCfg := cfg.GetModuleConfig().Store
+	if to == storeCfg.Name {
+		return nil, errors.New("the store is already " + to)
+	}
+	if filepath.Clean(toDB) == filepath.Clean(storeCfg.DbPath) {
+		return nil, errors.New("to_db is the db path of the node")
+	}
+	migrateCfg.From = storeCfg.Name
+	from, err := openStore(cfg, storeCfg.Name, "")
+	if err != nil {
+		return nil, err
+	}
+	defer from.Close()
+	src, ok := from.(core.Source)
+	if !ok {
+		return nil, sty.ErrSnapshotNotSupport
+	}
+	dst, err := openStore(cfg, to, toDB)
+	if err != nil {
+		return nil, err
+	}
+	defer dst.Close()
+	target, ok := dst.(core.Target)
+	if !ok {
+		return nil, sty.ErrSnapshotNotSupport
+	}
+	return core.Migrate(src, target, migrateCfg)
+}
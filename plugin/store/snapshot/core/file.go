@@ -0,0 +1,146 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+	sty "github.com/33cn/plugin/plugin/store/snapshot/types"
+)
+
+//快照目录中保存一个清单文件和每个分块一个文件
+const manifestFile = "manifest.dat"
+
+func chunkFile(index int32) string {
+	return fmt.Sprintf("chunk-%06d.dat", index)
+}
+
+//writeFile 先写临时文件再改名，下载中断后不会留下不完整的文件
+func writeFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func readFile(path string, msg types.Message) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return types.Decode(data, msg)
+}
+
+// SaveManifest 保存快照清单
+func SaveManifest(dir string, manifest *sty.Manifest) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(dir, manifestFile), types.Encode(manifest))
+}
+
+// LoadManifest 读取快照清单
+func LoadManifest(dir string) (*sty.Manifest, error) {
+	manifest := &sty.Manifest{}
+	if err := readFile(filepath.Join(dir, manifestFile), manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// SaveChunk 保存快照分块
+func SaveChunk(dir string, chunk *sty.Chunk) error {
+	return writeFile(filepath.Join(dir, chunkFile(chunk.Index)), types.Encode(chunk))
+}
+
+// LoadChunk 读取快照分块并按清单校验
+func LoadChunk(dir string, manifest *sty.Manifest, index int32) (*sty.Chunk, error) {
+	chunk := &sty.Chunk{}
+	if err := readFile(filepath.Join(dir, chunkFile(index)), chunk); err != nil {
+		return nil, err
+	}
+	if chunk.Index != index {
+		return nil, sty.ErrChunkIndex
+	}
+	if err := VerifyChunk(manifest, chunk); err != nil {
+		return nil, err
+	}
+	return chunk, nil
+}
+
+// VerifyDir 校验快照目录中的清单和全部分块
+func VerifyDir(dir string) (*sty.Manifest, error) {
+	manifest, err := LoadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyManifest(manifest); err != nil {
+		return nil, err
+	}
+	for i := range manifest.Chunks {
+		if _, err := LoadChunk(dir, manifest, int32(i)); err != nil {
+			slog.Error("VerifyDir", "chunk", i, "err", err)
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// Import 校验快照目录中的数据并导入到store，stateHash为可信的区块头中的状态哈希，
+// manifestHash为从可信节点得到的清单哈希，为空时只有导入后能用状态哈希校验数据的store驱动可以导入，
+// 返回的verified表示导入的数据是否和区块头中的状态哈希做了比较
+func Import(imp Importer, driver string, dir string, stateHash, manifestHash []byte) (manifest *sty.Manifest, verified bool, err error) {
+	manifest, err = LoadManifest(dir)
+	if err != nil {
+		return nil, false, err
+	}
+	verified = verifiesStateHash(imp)
+	if len(manifestHash) > 0 {
+		if !bytes.Equal(ManifestHash(manifest), manifestHash) {
+			return nil, false, sty.ErrManifestHashMismatch
+		}
+	} else if !verified {
+		//分块的哈希只能证明数据和清单一致，清单本身需要可信
+		return nil, false, sty.ErrManifestUntrusted
+	}
+	if err := VerifyManifest(manifest); err != nil {
+		return nil, false, err
+	}
+	if !bytes.Equal(manifest.StateHash, stateHash) {
+		return nil, false, sty.ErrStateHashMismatch
+	}
+	if manifest.Driver != driver {
+		return nil, false, sty.ErrDriverMismatch
+	}
+	var index int32
+	next := func() ([]*types.KeyValue, error) {
+		if int(index) >= len(manifest.Chunks) {
+			return nil, nil
+		}
+		chunk, err := LoadChunk(dir, manifest, index)
+		if err != nil {
+			return nil, err
+		}
+		index++
+		return chunk.Kvs, nil
+	}
+	hash, err := imp.ImportState(stateHash, manifest.Height, next)
+	if err != nil {
+		return nil, false, err
+	}
+	if verified && !bytes.Equal(hash, stateHash) {
+		slog.Error("Import state hash mismatch", "expect", common.ToHex(stateHash), "got", common.ToHex(hash))
+		return nil, false, sty.ErrStateHashMismatch
+	}
+	slog.Info("Import snapshot", "height", manifest.Height, "stateHash", common.ToHex(stateHash), "total", manifest.Total, "verified", verified)
+	return manifest, verified, nil
+}
@@ -0,0 +1,230 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	sty "github.com/33cn/plugin/plugin/store/snapshot/types"
+)
+
+//每次校验的key的数目
+const verifyBatch = 1000
+
+//迁移后区块头中的状态哈希到目标store中状态根的映射
+var rootMapPrefix = []byte("snapshot-migrate-root-")
+
+func rootMapKey(stateHash []byte) []byte {
+	return append(append([]byte{}, rootMapPrefix...), stateHash...)
+}
+
+// SaveRootMap 在目标store中记录stateHash迁移后对应的状态根
+func SaveRootMap(db dbm.DB, stateHash, root []byte) error {
+	return db.SetSync(rootMapKey(stateHash), root)
+}
+
+// ResolveRoot 返回stateHash在store中的状态根，迁移时状态根改变的store驱动在读取状态之前调用，
+// 没有迁移记录时返回stateHash
+func ResolveRoot(db dbm.DB, stateHash []byte) []byte {
+	root, err := db.Get(rootMapKey(stateHash))
+	if err != nil || len(root) == 0 {
+		return stateHash
+	}
+	return root
+}
+
+// Getter 按状态哈希读取key的值，用于迁移后抽样校验
+type Getter interface {
+	Get(datas *types.StoreGet) [][]byte
+}
+
+// Source 迁移的源store
+type Source interface {
+	Exporter
+	Getter
+}
+
+// Target 迁移的目标store
+type Target interface {
+	Writer
+	Getter
+	GetDB() dbm.DB
+}
+
+// MigrateConfig store迁移的参数
+type MigrateConfig struct {
+	From      string
+	To        string
+	StateHash []byte
+	Height    int64
+	// 每批写入的kv数目
+	BatchSize int
+	// 每隔SampleInterval个key抽样一个，迁移完成后校验
+	SampleInterval int64
+	// 保存迁移进度的文件
+	ProgressFile string
+}
+
+// LoadProgress 读取迁移进度，文件不存在时返回nil
+func LoadProgress(file string) (*sty.MigrateProgress, error) {
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	progress := &sty.MigrateProgress{}
+	if err := json.Unmarshal(data, progress); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+func saveProgress(file string, progress *sty.MigrateProgress) error {
+	data, err := json.MarshalIndent(progress, "", "    ")
+	if err != nil {
+		return err
+	}
+	return writeFile(file, data)
+}
+
+// Migrate 把源store中StateHash对应的状态分批写入空的目标store，每批写入后保存进度，
+// 中断后从保存的进度继续，全部写入后抽样校验，返回的进度中记录了Height对应的新状态根。
+// 新状态根和StateHash不同时映射关系保存在目标store中，读取StateHash时使用新状态根。
+// 之后的区块在目标store中计算的状态哈希和原来的store不同，全网的节点必须在同一个高度迁移
+func Migrate(src Source, dst Target, cfg *MigrateConfig) (*sty.MigrateProgress, error) {
+	if cfg.BatchSize <= 0 || cfg.SampleInterval <= 0 {
+		return nil, types.ErrInvalidParam
+	}
+	progress, err := LoadProgress(cfg.ProgressFile)
+	if err != nil {
+		return nil, err
+	}
+	stateHash := common.ToHex(cfg.StateHash)
+	if progress == nil {
+		if !IsEmptyDB(dst.GetDB()) {
+			return nil, sty.ErrStoreNotEmpty
+		}
+		progress = &sty.MigrateProgress{From: cfg.From, To: cfg.To, Height: cfg.Height, StateHash: stateHash}
+	} else if progress.From != cfg.From || progress.To != cfg.To || progress.Height != cfg.Height || progress.StateHash != stateHash {
+		return nil, sty.ErrMigrateMismatch
+	}
+	if progress.Done {
+		return progress, nil
+	}
+	slog.Info("Migrate start", "from", cfg.From, "to", cfg.To, "height", cfg.Height, "stateHash", stateHash, "count", progress.Count)
+	if err := migrate(src, dst, cfg, progress); err != nil {
+		return progress, err
+	}
+	slog.Info("Migrate done", "height", cfg.Height, "newRoot", progress.NewRoot, "count", progress.Count)
+	return progress, nil
+}
+
+func migrate(src Source, dst Target, cfg *MigrateConfig, progress *sty.MigrateProgress) error {
+	root, err := common.FromHex(progress.Root)
+	if err != nil {
+		return err
+	}
+	lastKey, err := common.FromHex(progress.LastKey)
+	if err != nil {
+		return err
+	}
+	var start []byte
+	if progress.Count > 0 {
+		start = lastKey
+	}
+	var batch []*types.KeyValue
+	flush := func() error {
+		root, err = dst.WriteState(root, cfg.Height, batch)
+		if err != nil {
+			return err
+		}
+		for i, kv := range batch {
+			if (progress.Count+int64(i))%cfg.SampleInterval == 0 {
+				progress.Samples = append(progress.Samples, common.ToHex(kv.Key))
+			}
+		}
+		progress.Count += int64(len(batch))
+		progress.LastKey = common.ToHex(batch[len(batch)-1].Key)
+		progress.Root = common.ToHex(root)
+		batch = nil
+		slog.Info("Migrate batch", "count", progress.Count, "lastKey", progress.LastKey)
+		return saveProgress(cfg.ProgressFile, progress)
+	}
+	var writeErr error
+	err = src.IterateState(cfg.StateHash, start, func(key, value []byte) bool {
+		//上次保存的最后一个key已经写入
+		if start != nil && bytes.Equal(key, start) {
+			return false
+		}
+		batch = append(batch, &types.KeyValue{Key: common.CopyBytes(key), Value: common.CopyBytes(value)})
+		if len(batch) == cfg.BatchSize {
+			writeErr = flush()
+			return writeErr != nil
+		}
+		return false
+	})
+	if err == nil {
+		err = writeErr
+	}
+	if err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+	newRoot, err := dst.FinishState(cfg.StateHash, root, cfg.Height)
+	if err != nil {
+		return err
+	}
+	progress.NewRoot = common.ToHex(newRoot)
+	if !bytes.Equal(newRoot, cfg.StateHash) {
+		if err := SaveRootMap(dst.GetDB(), cfg.StateHash, newRoot); err != nil {
+			return err
+		}
+	}
+	//目标store按区块头中的状态哈希读取
+	if err := verifySamples(src, dst, cfg.StateHash, progress.Samples); err != nil {
+		return err
+	}
+	progress.Done = true
+	return saveProgress(cfg.ProgressFile, progress)
+}
+
+//verifySamples 抽样的key在源store和目标store中的值需要一致
+func verifySamples(src, dst Getter, stateHash []byte, samples []string) error {
+	for i := 0; i < len(samples); i += verifyBatch {
+		end := i + verifyBatch
+		if end > len(samples) {
+			end = len(samples)
+		}
+		var keys [][]byte
+		for _, sample := range samples[i:end] {
+			key, err := common.FromHex(sample)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, key)
+		}
+		srcValues := src.Get(&types.StoreGet{StateHash: stateHash, Keys: keys})
+		dstValues := dst.Get(&types.StoreGet{StateHash: stateHash, Keys: keys})
+		for j := range keys {
+			if !bytes.Equal(srcValues[j], dstValues[j]) {
+				slog.Error("Migrate verify", "key", common.ToHex(keys[j]), "src", common.ToHex(srcValues[j]), "dst", common.ToHex(dstValues[j]))
+				return sty.ErrMigrateVerify
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package core
+
+import (
+	"bytes"
+	"strconv"
+
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+)
+
+var (
+	//同common/db中的mvcc相关的定义保持一致
+	mvccData = []byte(".-mvcc-.d.")
+	mvccLast = []byte(".-mvcc-.l.")
+	//mvccData前缀之后的第一个key，作为遍历的上限
+	mvccDataEnd = []byte(".-mvcc-.d/")
+)
+
+// splitMVCCKey 把mvcc的数据key拆分为原始的key和版本号
+func splitMVCCKey(key []byte) ([]byte, int64, bool) {
+	if !bytes.HasPrefix(key, mvccData) {
+		return nil, 0, false
+	}
+	i := bytes.LastIndexByte(key, '.')
+	if i < len(mvccData) {
+		return nil, 0, false
+	}
+	version, err := strconv.ParseInt(string(key[i+1:]), 10, 64)
+	if err != nil {
+		return nil, 0, false
+	}
+	return key[len(mvccData):i], version, true
+}
+
+// IterateMVCC 遍历mvcc存储中stateHash对应版本的全部数据，
+// 每个key取不超过该版本的最新值，按mvcc数据key的顺序遍历
+func IterateMVCC(db dbm.DB, mvcc dbm.MVCC, stateHash []byte, start []byte, fn func(key, value []byte) bool) error {
+	version, err := mvcc.GetVersion(stateHash)
+	if err != nil {
+		return err
+	}
+	seek := mvccData
+	if start != nil {
+		seek = dbm.GetKeyPerfix(start)
+	}
+	it := db.Iterator(seek, mvccDataEnd, false)
+	defer it.Close()
+	var curKey, curValue []byte
+	emit := func() bool {
+		if len(curValue) == 0 {
+			return false
+		}
+		return fn(curKey, curValue)
+	}
+	for it.Rewind(); it.Valid(); it.Next() {
+		if it.Error() != nil {
+			return it.Error()
+		}
+		key, ver, ok := splitMVCCKey(it.Key())
+		if !ok {
+			continue
+		}
+		if curKey == nil || !bytes.Equal(key, curKey) {
+			if curKey != nil && emit() {
+				return nil
+			}
+			curKey, curValue = append([]byte{}, key...), nil
+		}
+		if ver <= version {
+			curValue = it.ValueCopy()
+		}
+	}
+	if curKey != nil {
+		emit()
+	}
+	return nil
+}
+
+// WriteMVCC 把一批数据作为height版本写入mvcc存储，重复写入同样的数据结果不变
+func WriteMVCC(db dbm.DB, mvcc dbm.MVCC, enableIter bool, height int64, kvs []*types.KeyValue) error {
+	batch := db.NewBatch(true)
+	for _, kv := range kvs {
+		save, err := mvcc.GetSaveKV(kv.Key, kv.Value, height)
+		if err != nil {
+			return err
+		}
+		batch.Set(save.Key, save.Value)
+		if enableIter {
+			batch.Set(append(append([]byte{}, mvccLast...), kv.Key...), kv.Value)
+		}
+	}
+	return batch.Write()
+}
@@ -0,0 +1,187 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/client"
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	sty "github.com/33cn/plugin/plugin/store/snapshot/types"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	//同时缓存的快照清单数目
+	manifestCacheSize = 4
+	//只为最近的状态生成清单，更早的状态可能已经被裁剪
+	maxStateAge = 10000
+)
+
+type manifestEntry struct {
+	manifest *sty.Manifest
+	err      error
+	done     bool
+}
+
+// HeaderAPI 查询区块头，生成清单之前检查请求的状态哈希在主链上
+type HeaderAPI interface {
+	GetLastHeader() (*types.Header, error)
+	GetHeaders(param *types.ReqBlocks) (*types.Headers, error)
+}
+
+// Server 在store模块中为其他节点提供快照下载，清单在后台生成并缓存，同时只生成一个清单
+type Server struct {
+	driver   string
+	exp      Exporter
+	mu       sync.Mutex
+	cache    *lru.Cache
+	building bool
+}
+
+// NewServer 创建快照服务，driver为store驱动的名称
+func NewServer(driver string, exp Exporter) *Server {
+	cache, err := lru.New(manifestCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	return &Server{driver: driver, exp: exp, cache: cache}
+}
+
+//manifestKey 同一个状态按不同大小分块的清单分别缓存
+func manifestKey(stateHash []byte, chunkSize int32) string {
+	if chunkSize <= 0 {
+		chunkSize = sty.DefaultChunkSize
+	}
+	return fmt.Sprintf("%x-%d", stateHash, chunkSize)
+}
+
+//checkState 状态哈希必须与主链上该高度的区块头一致，并且不能落后最新高度太多
+func checkState(api HeaderAPI, req *sty.ReqManifest) error {
+	last, err := api.GetLastHeader()
+	if err != nil {
+		return err
+	}
+	if req.Height < 0 || req.Height > last.Height {
+		return sty.ErrStateHashUnknown
+	}
+	if last.Height-req.Height > maxStateAge {
+		return sty.ErrStateTooOld
+	}
+	headers, err := api.GetHeaders(&types.ReqBlocks{Start: req.Height, End: req.Height})
+	if err != nil {
+		return err
+	}
+	if len(headers.GetItems()) != 1 || !bytes.Equal(headers.Items[0].StateHash, req.StateHash) {
+		return sty.ErrStateHashUnknown
+	}
+	return nil
+}
+
+// Manifest 获取快照清单，第一次请求时在后台生成并返回ErrManifestBuilding，
+// 正在为其他请求生成清单时返回ErrManifestBusy
+func (s *Server) Manifest(api HeaderAPI, req *sty.ReqManifest) (*sty.Manifest, error) {
+	if len(req.StateHash) == 0 || req.ChunkSize < 0 || req.ChunkSize > sty.MaxChunkSize {
+		return nil, types.ErrInvalidParam
+	}
+	if err := checkState(api, req); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := manifestKey(req.StateHash, req.ChunkSize)
+	if value, ok := s.cache.Get(key); ok {
+		entry := value.(*manifestEntry)
+		if !entry.done {
+			return nil, sty.ErrManifestBuilding
+		}
+		if entry.err != nil {
+			//失败的清单不缓存，下次请求重新生成
+			s.cache.Remove(key)
+			return nil, entry.err
+		}
+		return entry.manifest, nil
+	}
+	if s.building {
+		return nil, sty.ErrManifestBusy
+	}
+	entry := &manifestEntry{}
+	s.cache.Add(key, entry)
+	s.building = true
+	go func() {
+		start := time.Now()
+		manifest, err := BuildManifest(s.exp, s.driver, req.StateHash, req.Height, req.ChunkSize)
+		slog.Info("Server build manifest", "stateHash", common.ToHex(req.StateHash), "total", manifest.GetTotal(), "cost", time.Since(start), "err", err)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		entry.manifest, entry.err, entry.done = manifest, err, true
+		s.building = false
+	}()
+	return nil, sty.ErrManifestBuilding
+}
+
+// Chunk 按已经生成的清单读取分块
+func (s *Server) Chunk(req *sty.ReqChunk) (*sty.Chunk, error) {
+	s.mu.Lock()
+	value, ok := s.cache.Get(manifestKey(req.StateHash, req.ChunkSize))
+	var entry manifestEntry
+	if ok {
+		entry = *value.(*manifestEntry)
+	}
+	s.mu.Unlock()
+	if !ok {
+		return nil, sty.ErrManifestNotFound
+	}
+	if !entry.done {
+		return nil, sty.ErrManifestBuilding
+	}
+	if entry.err != nil {
+		return nil, entry.err
+	}
+	return ReadChunk(s.exp, entry.manifest, req.Index)
+}
+
+// ProcEvent 处理快照相关的store事件，不是快照事件时返回false
+func (s *Server) ProcEvent(qclient queue.Client, msg *queue.Message) bool {
+	switch msg.Ty {
+	case sty.EventStoreGetManifest:
+		req, ok := msg.GetData().(*sty.ReqManifest)
+		if !ok {
+			msg.ReplyErr("Store", types.ErrInvalidParam)
+			return true
+		}
+		api, err := client.New(qclient, nil)
+		if err != nil {
+			msg.ReplyErr("Store", err)
+			return true
+		}
+		manifest, err := s.Manifest(api, req)
+		if err != nil {
+			msg.ReplyErr("Store", err)
+			return true
+		}
+		msg.Reply(qclient.NewMessage("", sty.EventStoreGetManifestReply, manifest))
+	case sty.EventStoreGetChunk:
+		req, ok := msg.GetData().(*sty.ReqChunk)
+		if !ok {
+			msg.ReplyErr("Store", types.ErrInvalidParam)
+			return true
+		}
+		chunk, err := s.Chunk(req)
+		if err != nil {
+			msg.ReplyErr("Store", err)
+			return true
+		}
+		msg.Reply(qclient.NewMessage("", sty.EventStoreGetChunkReply, chunk))
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,182 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package core 实现store状态快照的导出、校验和导入以及store之间的离线迁移，各个store驱动实现Exporter、Importer和Writer
+package core
+
+import (
+	"bytes"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	log "github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/chain33/types"
+	sty "github.com/33cn/plugin/plugin/store/snapshot/types"
+)
+
+var slog = log.New("module", "store.snapshot")
+
+// Exporter store驱动按照确定的顺序遍历一个状态的全部数据
+type Exporter interface {
+	// IterateState 从start开始(包含start)遍历stateHash对应的状态，start为空时从头开始，fn返回true时停止
+	IterateState(stateHash []byte, start []byte, fn func(key, value []byte) bool) error
+}
+
+// Importer store驱动用快照数据重建状态
+type Importer interface {
+	// ImportState 依次写入next返回的数据直到返回空，返回重建后的状态哈希
+	ImportState(stateHash []byte, height int64, next func() ([]*types.KeyValue, error)) ([]byte, error)
+}
+
+// StateVerifier 状态哈希由状态数据计算得到的store驱动，导入后返回的状态哈希可以和区块头比较，
+// 其他驱动的FinishState直接返回传入的stateHash，不能校验导入的数据
+type StateVerifier interface {
+	// VerifiesStateHash 导入后返回的状态哈希由导入的数据计算得到
+	VerifiesStateHash() bool
+}
+
+// Writer store驱动分批写入状态数据，用于导入快照和迁移store，
+// 中断后可以从上一批返回的root继续写入
+type Writer interface {
+	// WriteState 在root的基础上写入一批数据，返回新的root，root为空时从空状态开始
+	WriteState(root []byte, height int64, kvs []*types.KeyValue) ([]byte, error)
+	// FinishState 全部数据写入后记录stateHash对应的状态，返回store中该状态的哈希
+	FinishState(stateHash, root []byte, height int64) ([]byte, error)
+}
+
+// IsEmptyDB 检查数据库中没有任何数据
+func IsEmptyDB(db dbm.DB) bool {
+	it := db.Iterator(nil, types.EmptyValue, false)
+	defer it.Close()
+	it.Rewind()
+	return !it.Valid()
+}
+
+// ImportState 用Writer把next返回的数据写入空的store，store驱动用来实现Importer
+func ImportState(w Writer, db dbm.DB, stateHash []byte, height int64, next func() ([]*types.KeyValue, error)) ([]byte, error) {
+	if !IsEmptyDB(db) {
+		return nil, sty.ErrStoreNotEmpty
+	}
+	var root []byte
+	for {
+		kvs, err := next()
+		if err != nil {
+			return nil, err
+		}
+		if len(kvs) == 0 {
+			break
+		}
+		root, err = w.WriteState(root, height, kvs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	//最后记录状态，中断后需要清空store重新导入
+	return w.FinishState(stateHash, root, height)
+}
+
+// ChunkHash 分块的哈希
+func ChunkHash(chunk *sty.Chunk) []byte {
+	return common.Sha256(types.Encode(chunk))
+}
+
+// ManifestHash 清单编码的哈希，从可信节点得到后用于校验其他节点下载的快照
+func ManifestHash(manifest *sty.Manifest) []byte {
+	return common.Sha256(types.Encode(manifest))
+}
+
+// verifiesStateHash 检查store驱动导入后能否用状态哈希校验数据
+func verifiesStateHash(imp Importer) bool {
+	v, ok := imp.(StateVerifier)
+	return ok && v.VerifiesStateHash()
+}
+
+// BuildManifest 遍历一次状态数据，按chunkSize分块并计算每个分块的哈希
+func BuildManifest(exp Exporter, driver string, stateHash []byte, height int64, chunkSize int32) (*sty.Manifest, error) {
+	if chunkSize <= 0 {
+		chunkSize = sty.DefaultChunkSize
+	}
+	if chunkSize > sty.MaxChunkSize {
+		return nil, sty.ErrChunkSize
+	}
+	manifest := &sty.Manifest{StateHash: stateHash, Height: height, Driver: driver, ChunkSize: chunkSize}
+	chunk := &sty.Chunk{}
+	flush := func() {
+		manifest.Chunks = append(manifest.Chunks, &sty.ChunkInfo{
+			Index:    chunk.Index,
+			StartKey: chunk.Kvs[0].Key,
+			Count:    int32(len(chunk.Kvs)),
+			Hash:     ChunkHash(chunk),
+		})
+		manifest.Total += int64(len(chunk.Kvs))
+		chunk = &sty.Chunk{Index: chunk.Index + 1}
+	}
+	err := exp.IterateState(stateHash, nil, func(key, value []byte) bool {
+		chunk.Kvs = append(chunk.Kvs, &types.KeyValue{Key: common.CopyBytes(key), Value: common.CopyBytes(value)})
+		if int32(len(chunk.Kvs)) == chunkSize {
+			flush()
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(chunk.Kvs) > 0 {
+		flush()
+	}
+	return manifest, nil
+}
+
+// ReadChunk 按清单读取一个分块，状态已经改变时返回ErrChunkHash
+func ReadChunk(exp Exporter, manifest *sty.Manifest, index int32) (*sty.Chunk, error) {
+	if index < 0 || int(index) >= len(manifest.Chunks) {
+		return nil, sty.ErrChunkIndex
+	}
+	info := manifest.Chunks[index]
+	chunk := &sty.Chunk{Index: index}
+	err := exp.IterateState(manifest.StateHash, info.StartKey, func(key, value []byte) bool {
+		chunk.Kvs = append(chunk.Kvs, &types.KeyValue{Key: common.CopyBytes(key), Value: common.CopyBytes(value)})
+		return int32(len(chunk.Kvs)) == info.Count
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyChunk(manifest, chunk); err != nil {
+		return nil, err
+	}
+	return chunk, nil
+}
+
+// VerifyManifest 检查清单中分块的序号、数目和大小
+func VerifyManifest(manifest *sty.Manifest) error {
+	if manifest.ChunkSize <= 0 || manifest.ChunkSize > sty.MaxChunkSize {
+		return sty.ErrChunkSize
+	}
+	var total int64
+	for i, info := range manifest.Chunks {
+		if info.Index != int32(i) || info.Count <= 0 || info.Count > manifest.ChunkSize || len(info.Hash) != 32 {
+			return sty.ErrManifestInvalid
+		}
+		total += int64(info.Count)
+	}
+	if total != manifest.Total {
+		return sty.ErrManifestInvalid
+	}
+	return nil
+}
+
+// VerifyChunk 检查分块的数据与清单一致
+func VerifyChunk(manifest *sty.Manifest, chunk *sty.Chunk) error {
+	if chunk.Index < 0 || int(chunk.Index) >= len(manifest.Chunks) {
+		return sty.ErrChunkIndex
+	}
+	info := manifest.Chunks[chunk.Index]
+	if len(chunk.Kvs) == 0 || int32(len(chunk.Kvs)) != info.Count || !bytes.Equal(ChunkHash(chunk), info.Hash) {
+		return sty.ErrChunkHash
+	}
+	if !bytes.Equal(chunk.Kvs[0].Key, info.StartKey) {
+		return sty.ErrChunkHash
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+	sty "github.com/33cn/plugin/plugin/store/snapshot/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//mapStore 只有一个状态的测试store，状态哈希为全部数据的哈希，noVerify时模拟导入后不能校验状态哈希的store
+type mapStore struct {
+	kvs      map[string][]byte
+	noVerify bool
+}
+
+func newMapStore(count int) *mapStore {
+	s := &mapStore{kvs: make(map[string][]byte)}
+	for i := 0; i < count; i++ {
+		s.kvs[fmt.Sprintf("key-%04d", i)] = []byte(fmt.Sprintf("value-%d", i))
+	}
+	return s
+}
+
+func (s *mapStore) sortedKeys() []string {
+	var keys []string
+	for k := range s.kvs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *mapStore) hash() []byte {
+	var kvs []*types.KeyValue
+	for _, k := range s.sortedKeys() {
+		kvs = append(kvs, &types.KeyValue{Key: []byte(k), Value: s.kvs[k]})
+	}
+	return common.Sha256(types.Encode(&types.StoreSet{KV: kvs}))
+}
+
+func (s *mapStore) IterateState(stateHash, start []byte, fn func(key, value []byte) bool) error {
+	if !bytes.Equal(stateHash, s.hash()) {
+		return types.ErrHashNotFound
+	}
+	for _, k := range s.sortedKeys() {
+		if k < string(start) {
+			continue
+		}
+		if fn([]byte(k), s.kvs[k]) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *mapStore) ImportState(stateHash []byte, height int64, next func() ([]*types.KeyValue, error)) ([]byte, error) {
+	for {
+		kvs, err := next()
+		if err != nil {
+			return nil, err
+		}
+		if len(kvs) == 0 {
+			break
+		}
+		for _, kv := range kvs {
+			s.kvs[string(kv.Key)] = kv.Value
+		}
+	}
+	return s.hash(), nil
+}
+
+func (s *mapStore) VerifiesStateHash() bool {
+	return !s.noVerify
+}
+
+//headerAPI 测试用的区块头，heights中为每个高度的状态哈希
+type headerAPI struct {
+	last    int64
+	heights map[int64][]byte
+}
+
+func (api *headerAPI) GetLastHeader() (*types.Header, error) {
+	return &types.Header{Height: api.last}, nil
+}
+
+func (api *headerAPI) GetHeaders(param *types.ReqBlocks) (*types.Headers, error) {
+	stateHash, ok := api.heights[param.Start]
+	if !ok {
+		return nil, types.ErrBlockNotFound
+	}
+	return &types.Headers{Items: []*types.Header{{Height: param.Start, StateHash: stateHash}}}, nil
+}
+
+func TestBuildManifest(t *testing.T) {
+	store := newMapStore(25)
+	stateHash := store.hash()
+	manifest, err := BuildManifest(store, "map", stateHash, 10, 10)
+	require.Nil(t, err)
+	assert.Equal(t, "map", manifest.Driver)
+	assert.Equal(t, int64(25), manifest.Total)
+	assert.Equal(t, 3, len(manifest.Chunks))
+	assert.Equal(t, []byte("key-0010"), manifest.Chunks[1].StartKey)
+	assert.Equal(t, int32(5), manifest.Chunks[2].Count)
+	assert.Nil(t, VerifyManifest(manifest))
+
+	for i := range manifest.Chunks {
+		chunk, err := ReadChunk(store, manifest, int32(i))
+		require.Nil(t, err)
+		assert.Equal(t, int32(i), chunk.Index)
+	}
+	_, err = ReadChunk(store, manifest, 3)
+	assert.Equal(t, sty.ErrChunkIndex, err)
+
+	//篡改的分块不能通过校验
+	chunk, err := ReadChunk(store, manifest, 1)
+	require.Nil(t, err)
+	chunk.Kvs[0].Value = []byte("bad")
+	assert.Equal(t, sty.ErrChunkHash, VerifyChunk(manifest, chunk))
+
+	//清单的总数不一致
+	manifest.Total++
+	assert.Equal(t, sty.ErrManifestInvalid, VerifyManifest(manifest))
+	manifest.Total--
+
+	//状态改变后不能再读取
+	store.kvs["key-0011"] = []byte("changed")
+	_, err = ReadChunk(store, manifest, 1)
+	assert.NotNil(t, err)
+
+	_, err = BuildManifest(store, "map", stateHash, 10, sty.MaxChunkSize+1)
+	assert.Equal(t, sty.ErrChunkSize, err)
+}
+
+func TestFileImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := newMapStore(25)
+	stateHash := store.hash()
+	manifest, err := BuildManifest(store, "map", stateHash, 10, 10)
+	require.Nil(t, err)
+	require.Nil(t, SaveManifest(dir, manifest))
+	for i := range manifest.Chunks {
+		chunk, err := ReadChunk(store, manifest, int32(i))
+		require.Nil(t, err)
+		require.Nil(t, SaveChunk(dir, chunk))
+	}
+	loaded, err := VerifyDir(dir)
+	require.Nil(t, err)
+	assert.Equal(t, manifest.Total, loaded.Total)
+
+	_, _, err = Import(newMapStore(0), "map", dir, []byte("other"), nil)
+	assert.Equal(t, sty.ErrStateHashMismatch, err)
+	_, _, err = Import(newMapStore(0), "other", dir, stateHash, nil)
+	assert.Equal(t, sty.ErrDriverMismatch, err)
+
+	//导入后的状态哈希与原状态一致
+	imported := newMapStore(0)
+	_, verified, err := Import(imported, "map", dir, stateHash, nil)
+	require.Nil(t, err)
+	assert.True(t, verified)
+	assert.Equal(t, store.kvs, imported.kvs)
+
+	//导入的store中有多余的数据时状态哈希不一致
+	dirty := newMapStore(0)
+	dirty.kvs["extra"] = []byte("extra")
+	_, _, err = Import(dirty, "map", dir, stateHash, nil)
+	assert.Equal(t, sty.ErrStateHashMismatch, err)
+
+	//不能校验状态哈希的store需要可信的清单哈希
+	untrusted := &mapStore{kvs: make(map[string][]byte), noVerify: true}
+	_, _, err = Import(untrusted, "map", dir, stateHash, nil)
+	assert.Equal(t, sty.ErrManifestUntrusted, err)
+	_, _, err = Import(untrusted, "map", dir, stateHash, []byte("other"))
+	assert.Equal(t, sty.ErrManifestHashMismatch, err)
+	_, verified, err = Import(untrusted, "map", dir, stateHash, ManifestHash(manifest))
+	require.Nil(t, err)
+	assert.False(t, verified)
+	assert.Equal(t, store.kvs, untrusted.kvs)
+
+	//损坏的分块文件
+	other, err := ReadChunk(store, manifest, 0)
+	require.Nil(t, err)
+	other.Index = 1
+	require.Nil(t, SaveChunk(dir, other))
+	_, err = VerifyDir(dir)
+	assert.Equal(t, sty.ErrChunkHash, err)
+	_, _, err = Import(newMapStore(0), "map", dir, stateHash, nil)
+	assert.Equal(t, sty.ErrChunkHash, err)
+
+	require.Nil(t, os.Remove(filepath.Join(dir, chunkFile(1))))
+	_, err = VerifyDir(dir)
+	assert.NotNil(t, err)
+}
+
+func waitManifest(t *testing.T, server *Server, api HeaderAPI, req *sty.ReqManifest) (*sty.Manifest, error) {
+	for i := 0; i < 100; i++ {
+		manifest, err := server.Manifest(api, req)
+		if err != sty.ErrManifestBuilding {
+			return manifest, err
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("wait manifest timeout")
+	return nil, nil
+}
+
+func TestServer(t *testing.T) {
+	store := newMapStore(25)
+	stateHash := store.hash()
+	server := NewServer("map", store)
+	api := &headerAPI{last: 20, heights: map[int64][]byte{10: stateHash, 11: []byte("pruned")}}
+
+	_, err := server.Chunk(&sty.ReqChunk{StateHash: stateHash, Index: 0, ChunkSize: 10})
+	assert.Equal(t, sty.ErrManifestNotFound, err)
+
+	//不在主链上或者太旧的状态不生成清单
+	_, err = server.Manifest(api, &sty.ReqManifest{StateHash: []byte("unknown"), Height: 10})
+	assert.Equal(t, sty.ErrStateHashUnknown, err)
+	_, err = server.Manifest(api, &sty.ReqManifest{StateHash: stateHash, Height: 21})
+	assert.Equal(t, sty.ErrStateHashUnknown, err)
+	old := &headerAPI{last: 10 + maxStateAge + 1, heights: api.heights}
+	_, err = server.Manifest(old, &sty.ReqManifest{StateHash: stateHash, Height: 10})
+	assert.Equal(t, sty.ErrStateTooOld, err)
+
+	//同时只生成一个清单
+	_, err = server.Manifest(api, &sty.ReqManifest{StateHash: stateHash, Height: 10, ChunkSize: 10})
+	assert.Equal(t, sty.ErrManifestBuilding, err)
+	_, err = server.Manifest(api, &sty.ReqManifest{StateHash: stateHash, Height: 10, ChunkSize: 20})
+	if err != sty.ErrManifestBusy {
+		//第一个清单已经生成完成
+		assert.Equal(t, sty.ErrManifestBuilding, err)
+	}
+	manifest, err := waitManifest(t, server, api, &sty.ReqManifest{StateHash: stateHash, Height: 10, ChunkSize: 10})
+	require.Nil(t, err)
+	assert.Equal(t, 3, len(manifest.Chunks))
+	chunk, err := server.Chunk(&sty.ReqChunk{StateHash: stateHash, Index: 2, ChunkSize: 10})
+	require.Nil(t, err)
+	assert.Nil(t, VerifyChunk(manifest, chunk))
+
+	//同一个状态按不同大小分块的清单分别缓存
+	other, err := waitManifest(t, server, api, &sty.ReqManifest{StateHash: stateHash, Height: 10, ChunkSize: 20})
+	require.Nil(t, err)
+	assert.Equal(t, 2, len(other.Chunks))
+	chunk, err = server.Chunk(&sty.ReqChunk{StateHash: stateHash, Index: 1, ChunkSize: 20})
+	require.Nil(t, err)
+	assert.Nil(t, VerifyChunk(other, chunk))
+	_, err = server.Chunk(&sty.ReqChunk{StateHash: stateHash, Index: 0, ChunkSize: 5})
+	assert.Equal(t, sty.ErrManifestNotFound, err)
+
+	//生成失败的清单在下次请求时重新生成
+	req := &sty.ReqManifest{StateHash: []byte("pruned"), Height: 11}
+	_, err = waitManifest(t, server, api, req)
+	assert.Equal(t, types.ErrHashNotFound, err)
+	_, err = server.Manifest(api, req)
+	assert.Equal(t, sty.ErrManifestBuilding, err)
+	_, err = waitManifest(t, server, api, req)
+	assert.Equal(t, types.ErrHashNotFound, err)
+}
@@ -0,0 +1,26 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"github.com/33cn/chain33/pluginmgr"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot/commands"
+	"github.com/33cn/plugin/plugin/store/snapshot/rpc"
+	sty "github.com/33cn/plugin/plugin/store/snapshot/types"
+)
+
+func init() {
+	pluginmgr.Register(&pluginmgr.PluginBase{
+		Name:     sty.SnapshotX,
+		ExecName: sty.SnapshotX,
+		Exec:     initExec,
+		Cmd:      commands.SnapshotCmd,
+		RPC:      rpc.Init,
+	})
+}
+
+// initExec 状态快照没有执行器，只提供快照下载的rpc和快照、迁移的命令行，快照由各个store驱动导出和导入
+func initExec(name string, cfg *types.Chain33Config, sub []byte) {}
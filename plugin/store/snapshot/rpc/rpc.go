@@ -0,0 +1,119 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/33cn/chain33/queue"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+	sty "github.com/33cn/plugin/plugin/store/snapshot/types"
+)
+
+// Jrpc snapshot jrpc interface
+type Jrpc struct {
+	cli *channelClient
+}
+
+// Grpc snapshot grpc interface
+type Grpc struct {
+	*channelClient
+}
+
+type channelClient struct {
+	rpctypes.ChannelClient
+	qclient queue.Client
+}
+
+// Init snapshot rpc register
+func Init(name string, s rpctypes.RPCServer) {
+	cli := &channelClient{qclient: s.GetQueueClient()}
+	grpc := &Grpc{channelClient: cli}
+	cli.Init(name, s, &Jrpc{cli: cli}, grpc)
+
+	sty.RegisterSnapshotServer(s.GRPC(), grpc)
+}
+
+func (c *channelClient) sendStore(ty int64, req types.Message) (interface{}, error) {
+	msg := c.qclient.NewMessage("store", ty, req)
+	if err := c.qclient.Send(msg, true); err != nil {
+		return nil, err
+	}
+	resp, err := c.qclient.Wait(msg)
+	if err != nil {
+		return nil, err
+	}
+	if reply, ok := resp.GetData().(*types.Reply); ok {
+		//store返回的错误，清单正在生成时返回ErrManifestBuilding
+		return nil, errors.New(string(reply.GetMsg()))
+	}
+	return resp.GetData(), nil
+}
+
+// GetManifest 从store模块获取快照清单，第一次请求时后台生成
+func (c *channelClient) GetManifest(ctx context.Context, req *sty.ReqManifest) (*sty.Manifest, error) {
+	if req == nil || len(req.StateHash) == 0 {
+		return nil, types.ErrInvalidParam
+	}
+	resp, err := c.sendStore(sty.EventStoreGetManifest, req)
+	if err != nil {
+		return nil, err
+	}
+	if reply, ok := resp.(*sty.Manifest); ok {
+		return reply, nil
+	}
+	return nil, types.ErrTypeAsset
+}
+
+// GetChunk 从store模块获取快照分块
+func (c *channelClient) GetChunk(ctx context.Context, req *sty.ReqChunk) (*sty.Chunk, error) {
+	if req == nil || len(req.StateHash) == 0 {
+		return nil, types.ErrInvalidParam
+	}
+	resp, err := c.sendStore(sty.EventStoreGetChunk, req)
+	if err != nil {
+		return nil, err
+	}
+	if reply, ok := resp.(*sty.Chunk); ok {
+		return reply, nil
+	}
+	return nil, types.ErrTypeAsset
+}
+
+// GetManifest 获取快照清单，返回protobuf编码的数据
+func (c *Jrpc) GetManifest(in *sty.ReqManifestJSON, result *interface{}) error {
+	if in == nil {
+		return types.ErrInvalidParam
+	}
+	req, err := in.ToPB()
+	if err != nil {
+		return types.ErrInvalidParam
+	}
+	reply, err := c.cli.GetManifest(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	*result = sty.EncodeJSON(reply)
+	return nil
+}
+
+// GetChunk 获取快照分块，返回protobuf编码的数据
+func (c *Jrpc) GetChunk(in *sty.ReqChunkJSON, result *interface{}) error {
+	if in == nil {
+		return types.ErrInvalidParam
+	}
+	req, err := in.ToPB()
+	if err != nil {
+		return types.ErrInvalidParam
+	}
+	reply, err := c.cli.GetChunk(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	*result = sty.EncodeJSON(reply)
+	return nil
+}
@@ -0,0 +1,481 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: snapshot.proto
+
+package types
+
+import (
+	context "context"
+	fmt "fmt"
+	types "github.com/33cn/chain33/types"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ChunkInfo 快照中一个分块的信息，startKey为分块的第一个key，hash为分块编码的sha256
+type ChunkInfo struct {
+	Index                int32    `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	StartKey             []byte   `protobuf:"bytes,2,opt,name=startKey,proto3" json:"startKey,omitempty"`
+	Count                int32    `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Hash                 []byte   `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChunkInfo) Reset()         { *m = ChunkInfo{} }
+func (m *ChunkInfo) String() string { return proto.CompactTextString(m) }
+func (*ChunkInfo) ProtoMessage()    {}
+func (*ChunkInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c8aab8e59648e0b, []int{0}
+}
+
+func (m *ChunkInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChunkInfo.Unmarshal(m, b)
+}
+func (m *ChunkInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChunkInfo.Marshal(b, m, deterministic)
+}
+func (m *ChunkInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChunkInfo.Merge(m, src)
+}
+func (m *ChunkInfo) XXX_Size() int {
+	return xxx_messageInfo_ChunkInfo.Size(m)
+}
+func (m *ChunkInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChunkInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChunkInfo proto.InternalMessageInfo
+
+func (m *ChunkInfo) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ChunkInfo) GetStartKey() []byte {
+	if m != nil {
+		return m.StartKey
+	}
+	return nil
+}
+
+func (m *ChunkInfo) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ChunkInfo) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+// Manifest 快照清单，stateHash为区块头中的状态哈希，driver为导出快照的store驱动
+type Manifest struct {
+	StateHash            []byte       `protobuf:"bytes,1,opt,name=stateHash,proto3" json:"stateHash,omitempty"`
+	Height               int64        `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Driver               string       `protobuf:"bytes,3,opt,name=driver,proto3" json:"driver,omitempty"`
+	ChunkSize            int32        `protobuf:"varint,4,opt,name=chunkSize,proto3" json:"chunkSize,omitempty"`
+	Total                int64        `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	Chunks               []*ChunkInfo `protobuf:"bytes,6,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *Manifest) Reset()         { *m = Manifest{} }
+func (m *Manifest) String() string { return proto.CompactTextString(m) }
+func (*Manifest) ProtoMessage()    {}
+func (*Manifest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c8aab8e59648e0b, []int{1}
+}
+
+func (m *Manifest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Manifest.Unmarshal(m, b)
+}
+func (m *Manifest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Manifest.Marshal(b, m, deterministic)
+}
+func (m *Manifest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Manifest.Merge(m, src)
+}
+func (m *Manifest) XXX_Size() int {
+	return xxx_messageInfo_Manifest.Size(m)
+}
+func (m *Manifest) XXX_DiscardUnknown() {
+	xxx_messageInfo_Manifest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Manifest proto.InternalMessageInfo
+
+func (m *Manifest) GetStateHash() []byte {
+	if m != nil {
+		return m.StateHash
+	}
+	return nil
+}
+
+func (m *Manifest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *Manifest) GetDriver() string {
+	if m != nil {
+		return m.Driver
+	}
+	return ""
+}
+
+func (m *Manifest) GetChunkSize() int32 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+func (m *Manifest) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *Manifest) GetChunks() []*ChunkInfo {
+	if m != nil {
+		return m.Chunks
+	}
+	return nil
+}
+
+// Chunk 按key的顺序排列的一批状态数据
+type Chunk struct {
+	Index                int32             `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Kvs                  []*types.KeyValue `protobuf:"bytes,2,rep,name=kvs,proto3" json:"kvs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Chunk) Reset()         { *m = Chunk{} }
+func (m *Chunk) String() string { return proto.CompactTextString(m) }
+func (*Chunk) ProtoMessage()    {}
+func (*Chunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c8aab8e59648e0b, []int{2}
+}
+
+func (m *Chunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Chunk.Unmarshal(m, b)
+}
+func (m *Chunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Chunk.Marshal(b, m, deterministic)
+}
+func (m *Chunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Chunk.Merge(m, src)
+}
+func (m *Chunk) XXX_Size() int {
+	return xxx_messageInfo_Chunk.Size(m)
+}
+func (m *Chunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_Chunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Chunk proto.InternalMessageInfo
+
+func (m *Chunk) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *Chunk) GetKvs() []*types.KeyValue {
+	if m != nil {
+		return m.Kvs
+	}
+	return nil
+}
+
+type ReqManifest struct {
+	StateHash            []byte   `protobuf:"bytes,1,opt,name=stateHash,proto3" json:"stateHash,omitempty"`
+	Height               int64    `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	ChunkSize            int32    `protobuf:"varint,3,opt,name=chunkSize,proto3" json:"chunkSize,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqManifest) Reset()         { *m = ReqManifest{} }
+func (m *ReqManifest) String() string { return proto.CompactTextString(m) }
+func (*ReqManifest) ProtoMessage()    {}
+func (*ReqManifest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c8aab8e59648e0b, []int{3}
+}
+
+func (m *ReqManifest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqManifest.Unmarshal(m, b)
+}
+func (m *ReqManifest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqManifest.Marshal(b, m, deterministic)
+}
+func (m *ReqManifest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqManifest.Merge(m, src)
+}
+func (m *ReqManifest) XXX_Size() int {
+	return xxx_messageInfo_ReqManifest.Size(m)
+}
+func (m *ReqManifest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqManifest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqManifest proto.InternalMessageInfo
+
+func (m *ReqManifest) GetStateHash() []byte {
+	if m != nil {
+		return m.StateHash
+	}
+	return nil
+}
+
+func (m *ReqManifest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ReqManifest) GetChunkSize() int32 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+// ReqChunk chunkSize与请求清单时一致，用于区分同一状态按不同大小分块的清单
+type ReqChunk struct {
+	StateHash            []byte   `protobuf:"bytes,1,opt,name=stateHash,proto3" json:"stateHash,omitempty"`
+	Index                int32    `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	ChunkSize            int32    `protobuf:"varint,3,opt,name=chunkSize,proto3" json:"chunkSize,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqChunk) Reset()         { *m = ReqChunk{} }
+func (m *ReqChunk) String() string { return proto.CompactTextString(m) }
+func (*ReqChunk) ProtoMessage()    {}
+func (*ReqChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c8aab8e59648e0b, []int{4}
+}
+
+func (m *ReqChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqChunk.Unmarshal(m, b)
+}
+func (m *ReqChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqChunk.Marshal(b, m, deterministic)
+}
+func (m *ReqChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqChunk.Merge(m, src)
+}
+func (m *ReqChunk) XXX_Size() int {
+	return xxx_messageInfo_ReqChunk.Size(m)
+}
+func (m *ReqChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqChunk proto.InternalMessageInfo
+
+func (m *ReqChunk) GetStateHash() []byte {
+	if m != nil {
+		return m.StateHash
+	}
+	return nil
+}
+
+func (m *ReqChunk) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ReqChunk) GetChunkSize() int32 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ChunkInfo)(nil), "types.ChunkInfo")
+	proto.RegisterType((*Manifest)(nil), "types.Manifest")
+	proto.RegisterType((*Chunk)(nil), "types.Chunk")
+	proto.RegisterType((*ReqManifest)(nil), "types.ReqManifest")
+	proto.RegisterType((*ReqChunk)(nil), "types.ReqChunk")
+}
+
+func init() { proto.RegisterFile("snapshot.proto", fileDescriptor_0c8aab8e59648e0b) }
+
+var fileDescriptor_0c8aab8e59648e0b = []byte{
+	// 347 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x52, 0x41, 0x6b, 0xf2, 0x40,
+	0x10, 0x35, 0xc6, 0x84, 0x38, 0xca, 0xe7, 0xc7, 0xf2, 0xf1, 0x11, 0x42, 0x0f, 0x36, 0xa7, 0x40,
+	0xc1, 0x83, 0xed, 0x0f, 0x28, 0xf4, 0x60, 0x8b, 0xf4, 0xb2, 0x85, 0x9e, 0x7a, 0xd9, 0xea, 0x68,
+	0x82, 0x9a, 0xd5, 0xec, 0x28, 0xb5, 0x3f, 0xac, 0xbf, 0xaf, 0xec, 0x24, 0x46, 0x5b, 0xa8, 0x97,
+	0xde, 0xf6, 0xbd, 0x9d, 0x79, 0x33, 0xef, 0x31, 0xf0, 0xc7, 0xe4, 0x6a, 0x6d, 0x52, 0x4d, 0x83,
+	0x75, 0xa1, 0x49, 0x0b, 0x8f, 0xf6, 0x6b, 0x34, 0x51, 0x77, 0xa2, 0x57, 0x2b, 0x9d, 0x97, 0x64,
+	0x3c, 0x87, 0xf6, 0x5d, 0xba, 0xcd, 0x17, 0x0f, 0xf9, 0x4c, 0x8b, 0x7f, 0xe0, 0x65, 0xf9, 0x14,
+	0xdf, 0x42, 0xa7, 0xef, 0x24, 0x9e, 0x2c, 0x81, 0x88, 0x20, 0x30, 0xa4, 0x0a, 0x1a, 0xe3, 0x3e,
+	0x6c, 0xf6, 0x9d, 0xa4, 0x2b, 0x6b, 0x6c, 0x3b, 0x26, 0x7a, 0x9b, 0x53, 0xe8, 0x96, 0x1d, 0x0c,
+	0x84, 0x80, 0x56, 0xaa, 0x4c, 0x1a, 0xb6, 0xb8, 0x9a, 0xdf, 0xf1, 0x87, 0x03, 0xc1, 0xa3, 0xca,
+	0xb3, 0x19, 0x1a, 0x12, 0x17, 0xd0, 0x36, 0xa4, 0x08, 0xef, 0x6d, 0x95, 0xc3, 0x55, 0x47, 0x42,
+	0xfc, 0x07, 0x3f, 0xc5, 0x6c, 0x9e, 0x12, 0x8f, 0x73, 0x65, 0x85, 0x2c, 0x3f, 0x2d, 0xb2, 0x1d,
+	0x16, 0x3c, 0xad, 0x2d, 0x2b, 0x64, 0xd5, 0x26, 0xd6, 0xc3, 0x53, 0xf6, 0x8e, 0x3c, 0xd3, 0x93,
+	0x47, 0xc2, 0xae, 0x48, 0x9a, 0xd4, 0x32, 0xf4, 0x58, 0xac, 0x04, 0x22, 0x01, 0x9f, 0x4b, 0x4c,
+	0xe8, 0xf7, 0xdd, 0xa4, 0x33, 0xfc, 0x3b, 0xe0, 0x74, 0x06, 0x75, 0x18, 0xb2, 0xfa, 0x8f, 0x6f,
+	0xc1, 0x63, 0xf2, 0x87, 0x74, 0x2e, 0xc1, 0x5d, 0xec, 0x4c, 0xd8, 0x64, 0x95, 0x5e, 0xa5, 0x32,
+	0xc6, 0xfd, 0xb3, 0x5a, 0x6e, 0x51, 0xda, 0xbf, 0x58, 0x41, 0x47, 0xe2, 0xe6, 0x97, 0xe6, 0xbf,
+	0x98, 0x74, 0xbf, 0x99, 0x8c, 0x5f, 0x20, 0x90, 0xb8, 0x29, 0xf7, 0x3c, 0xaf, 0x5f, 0xbb, 0x68,
+	0x9e, 0xba, 0x38, 0xab, 0x3e, 0x5c, 0x41, 0x70, 0xb8, 0x25, 0x71, 0x03, 0x9d, 0x11, 0x52, 0x6d,
+	0x46, 0x54, 0x8e, 0x4f, 0x0c, 0x46, 0x87, 0x14, 0x0e, 0x44, 0xdc, 0x10, 0x57, 0x10, 0x8c, 0x90,
+	0xca, 0xfd, 0x7a, 0xc7, 0x16, 0x26, 0xa2, 0xee, 0x69, 0xf6, 0x71, 0xe3, 0xd5, 0xe7, 0xd3, 0xbc,
+	0xfe, 0x1c, 0x00, 0xf2, 0x39, 0x8f, 0x6c, 0xc1, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SnapshotClient is the client API for Snapshot service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SnapshotClient interface {
+	GetManifest(ctx context.Context, in *ReqManifest, opts ...grpc.CallOption) (*Manifest, error)
+	GetChunk(ctx context.Context, in *ReqChunk, opts ...grpc.CallOption) (*Chunk, error)
+}
+
+type snapshotClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSnapshotClient(cc *grpc.ClientConn) SnapshotClient {
+	return &snapshotClient{cc}
+}
+
+func (c *snapshotClient) GetManifest(ctx context.Context, in *ReqManifest, opts ...grpc.CallOption) (*Manifest, error) {
+	out := new(Manifest)
+	err := c.cc.Invoke(ctx, "/types.snapshot/GetManifest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snapshotClient) GetChunk(ctx context.Context, in *ReqChunk, opts ...grpc.CallOption) (*Chunk, error) {
+	out := new(Chunk)
+	err := c.cc.Invoke(ctx, "/types.snapshot/GetChunk", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SnapshotServer is the server API for Snapshot service.
+type SnapshotServer interface {
+	GetManifest(context.Context, *ReqManifest) (*Manifest, error)
+	GetChunk(context.Context, *ReqChunk) (*Chunk, error)
+}
+
+// UnimplementedSnapshotServer can be embedded to have forward compatible implementations.
+type UnimplementedSnapshotServer struct {
+}
+
+func (*UnimplementedSnapshotServer) GetManifest(ctx context.Context, req *ReqManifest) (*Manifest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetManifest not implemented")
+}
+func (*UnimplementedSnapshotServer) GetChunk(ctx context.Context, req *ReqChunk) (*Chunk, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChunk not implemented")
+}
+
+func RegisterSnapshotServer(s *grpc.Server, srv SnapshotServer) {
+	s.RegisterService(&_Snapshot_serviceDesc, srv)
+}
+
+func _Snapshot_GetManifest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqManifest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapshotServer).GetManifest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.snapshot/GetManifest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapshotServer).GetManifest(ctx, req.(*ReqManifest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Snapshot_GetChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqChunk)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapshotServer).GetChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.snapshot/GetChunk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapshotServer).GetChunk(ctx, req.(*ReqChunk))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Snapshot_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.snapshot",
+	HandlerType: (*SnapshotServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetManifest",
+			Handler:    _Snapshot_GetManifest_Handler,
+		},
+		{
+			MethodName: "GetChunk",
+			Handler:    _Snapshot_GetChunk_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "snapshot.proto",
+}
@@ -0,0 +1,137 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"errors"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+)
+
+// SnapshotX 状态快照对外提供rpc和命令行的名称
+const SnapshotX = "snapshot"
+
+// store 模块处理的快照事件，与其他store扩展事件的编号不重复
+const (
+	// EventStoreGetManifest 获取快照清单
+	EventStoreGetManifest = 1101
+	// EventStoreGetManifestReply 快照清单的回复
+	EventStoreGetManifestReply = 1102
+	// EventStoreGetChunk 获取快照分块
+	EventStoreGetChunk = 1103
+	// EventStoreGetChunkReply 快照分块的回复
+	EventStoreGetChunkReply = 1104
+)
+
+const (
+	// DefaultChunkSize 每个分块默认包含的kv数目
+	DefaultChunkSize = 10000
+	// MaxChunkSize 每个分块最多包含的kv数目
+	MaxChunkSize = 100000
+)
+
+var (
+	// ErrManifestBuilding 快照清单正在后台生成，稍后重试
+	ErrManifestBuilding = errors.New("ErrManifestBuilding")
+	// ErrManifestBusy 正在为其他状态生成快照清单，同时只生成一个清单
+	ErrManifestBusy = errors.New("ErrManifestBusy")
+	// ErrManifestNotFound 还没有请求过该状态的快照清单
+	ErrManifestNotFound = errors.New("ErrManifestNotFound")
+	// ErrManifestInvalid 快照清单的分块信息不完整
+	ErrManifestInvalid = errors.New("ErrManifestInvalid")
+	// ErrChunkIndex 分块序号超出范围
+	ErrChunkIndex = errors.New("ErrChunkIndex")
+	// ErrChunkHash 分块的哈希与清单不一致，导出时说明状态已经改变
+	ErrChunkHash = errors.New("ErrChunkHash")
+	// ErrChunkSize 分块大小超出范围
+	ErrChunkSize = errors.New("ErrChunkSize")
+	// ErrStateHashMismatch 快照的状态哈希与区块头不一致
+	ErrStateHashMismatch = errors.New("ErrStateHashMismatch")
+	// ErrStateHashUnknown 请求的状态哈希与主链上该高度的区块头不一致
+	ErrStateHashUnknown = errors.New("ErrStateHashUnknown")
+	// ErrStateTooOld 请求的状态落后最新高度太多，不再生成快照
+	ErrStateTooOld = errors.New("ErrStateTooOld")
+	// ErrManifestHashMismatch 快照清单的哈希与可信的清单哈希不一致
+	ErrManifestHashMismatch = errors.New("ErrManifestHashMismatch")
+	// ErrManifestUntrusted store驱动导入后不能用区块头的状态哈希校验数据，需要提供可信的清单哈希
+	ErrManifestUntrusted = errors.New("ErrManifestUntrusted")
+	// ErrDriverMismatch 快照由其他store驱动导出
+	ErrDriverMismatch = errors.New("ErrDriverMismatch")
+	// ErrStoreNotEmpty 导入快照的store已经有数据
+	ErrStoreNotEmpty = errors.New("ErrStoreNotEmpty")
+	// ErrSnapshotNotSupport store驱动不支持该状态的快照
+	ErrSnapshotNotSupport = errors.New("ErrSnapshotNotSupport")
+	// ErrMigrateMismatch 进度文件属于其他的迁移
+	ErrMigrateMismatch = errors.New("ErrMigrateMismatch")
+	// ErrMigrateVerify 迁移后抽样的key在两个store中的值不一致
+	ErrMigrateVerify = errors.New("ErrMigrateVerify")
+)
+
+// ReqManifestJSON jrpc请求快照清单
+type ReqManifestJSON struct {
+	StateHash string `json:"stateHash"`
+	Height    int64  `json:"height"`
+	ChunkSize int32  `json:"chunkSize"`
+}
+
+// ReqChunkJSON jrpc请求快照分块
+type ReqChunkJSON struct {
+	StateHash string `json:"stateHash"`
+	Index     int32  `json:"index"`
+	ChunkSize int32  `json:"chunkSize"`
+}
+
+// ReplySnapshotData jrpc返回的快照清单和分块，data为protobuf编码的十六进制
+type ReplySnapshotData struct {
+	Data string `json:"data"`
+}
+
+// MigrateProgress store迁移的进度，每批写入后保存，中断后从LastKey之后继续，
+// 完成后NewRoot为目标store中Height对应的状态根，和StateHash不同时映射关系也保存在目标store中
+type MigrateProgress struct {
+	From      string   `json:"from"`
+	To        string   `json:"to"`
+	Height    int64    `json:"height"`
+	StateHash string   `json:"stateHash"`
+	Root      string   `json:"root"`
+	LastKey   string   `json:"lastKey"`
+	Count     int64    `json:"count"`
+	Samples   []string `json:"samples"`
+	NewRoot   string   `json:"newRoot"`
+	Done      bool     `json:"done"`
+}
+
+// ToPB 转换为grpc和store事件使用的请求
+func (req *ReqManifestJSON) ToPB() (*ReqManifest, error) {
+	stateHash, err := common.FromHex(req.StateHash)
+	if err != nil {
+		return nil, err
+	}
+	return &ReqManifest{StateHash: stateHash, Height: req.Height, ChunkSize: req.ChunkSize}, nil
+}
+
+// ToPB 转换为grpc和store事件使用的请求
+func (req *ReqChunkJSON) ToPB() (*ReqChunk, error) {
+	stateHash, err := common.FromHex(req.StateHash)
+	if err != nil {
+		return nil, err
+	}
+	return &ReqChunk{StateHash: stateHash, Index: req.Index, ChunkSize: req.ChunkSize}, nil
+}
+
+// EncodeJSON 编码为jrpc返回的数据
+func EncodeJSON(msg types.Message) *ReplySnapshotData {
+	return &ReplySnapshotData{Data: common.ToHex(types.Encode(msg))}
+}
+
+// DecodeJSON 解码jrpc返回的数据
+func (reply *ReplySnapshotData) DecodeJSON(msg types.Message) error {
+	data, err := common.FromHex(reply.Data)
+	if err != nil {
+		return err
+	}
+	return types.Decode(data, msg)
+}